@@ -88,16 +88,18 @@ func (c *Client) RestoreReader(r io.ReadSeeker, meta *params.BackupsMetadataResu
 		logger.Errorf("could not clean up after failed backup upload: %v", finishErr)
 		return errors.Annotatef(err, "cannot upload backup file")
 	}
-	return c.restore(backupId, newClient)
+	return c.restore(params.RestoreArgs{BackupId: backupId}, newClient)
 }
 
 // Restore performs restore using a backup id corresponding to a backup stored in the server.
-func (c *Client) Restore(backupId string, newClient ClientConnection) error {
+// If the backup is encrypted, the args must also hold the passphrase or private key with
+// which to decrypt it.
+func (c *Client) Restore(args params.RestoreArgs, newClient ClientConnection) error {
 	if err := prepareRestore(newClient); err != nil {
 		return errors.Trace(err)
 	}
 	logger.Debugf("Server in 'about to restore' mode")
-	return c.restore(backupId, newClient)
+	return c.restore(args, newClient)
 }
 
 func restoreAttempt(client *Client, restoreArgs params.RestoreArgs) (error, error) {
@@ -110,20 +112,15 @@ func restoreAttempt(client *Client, restoreArgs params.RestoreArgs) (error, erro
 // restore is responsible for triggering the whole restore process in a remote
 // machine. The backup information for the process should already be in the
 // server and loaded in the backup storage under the backupId id.
-// It takes backupId as the identifier for the remote backup file and a
+// It takes restoreArgs identifying the remote backup file and a
 // client connection factory newClient (newClient should no longer be
 // necessary when lp:1399722 is sorted out).
-func (c *Client) restore(backupId string, newClient ClientConnection) error {
+func (c *Client) restore(restoreArgs params.RestoreArgs, newClient ClientConnection) error {
 	var err, remoteError error
 
-	// Restore
-	restoreArgs := params.RestoreArgs{
-		BackupId: backupId,
-	}
-
 	cleanExit := false
 	for a := restoreStrategy.Start(); a.Next(); {
-		logger.Debugf("Attempting Restore of %q", backupId)
+		logger.Debugf("Attempting Restore of %q", restoreArgs.BackupId)
 		var restoreClient *Client
 		restoreClient, err = newClient()
 		if err != nil {
@@ -0,0 +1,25 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// RestoreModel re-creates the model captured by the stored model
+// backup in the controller, and returns the tag of the restored model.
+func (c *Client) RestoreModel(args params.RestoreArgs) (names.ModelTag, error) {
+	var result params.RestoreModelResult
+	if err := c.facade.FacadeCall("RestoreModel", args, &result); err != nil {
+		return names.ModelTag{}, errors.Trace(err)
+	}
+	tag, err := names.ParseModelTag(result.ModelTag)
+	if err != nil {
+		return names.ModelTag{}, errors.Trace(err)
+	}
+	return tag, nil
+}
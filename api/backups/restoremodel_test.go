@@ -0,0 +1,44 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/backups"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/testing"
+)
+
+type restoreModelSuite struct {
+	baseSuite
+}
+
+var _ = gc.Suite(&restoreModelSuite{})
+
+func (s *restoreModelSuite) TestRestoreModel(c *gc.C) {
+	cleanup := backups.PatchClientFacadeCall(s.client,
+		func(req string, paramsIn interface{}, resp interface{}) error {
+			c.Check(req, gc.Equals, "RestoreModel")
+
+			c.Assert(paramsIn, gc.FitsTypeOf, params.RestoreArgs{})
+			p := paramsIn.(params.RestoreArgs)
+			c.Check(p.BackupId, gc.Equals, "spam")
+			c.Check(p.Passphrase, gc.Equals, "secret")
+
+			if result, ok := resp.(*params.RestoreModelResult); ok {
+				result.ModelTag = testing.ModelTag.String()
+			} else {
+				c.Fatalf("wrong output structure")
+			}
+			return nil
+		},
+	)
+	defer cleanup()
+
+	tag, err := s.client.RestoreModel(params.RestoreArgs{BackupId: "spam", Passphrase: "secret"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(tag, gc.Equals, testing.ModelTag)
+}
@@ -0,0 +1,19 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// ScheduleStatus implements the API method.
+func (c *Client) ScheduleStatus() (*params.BackupsScheduleStatusResult, error) {
+	var result params.BackupsScheduleStatusResult
+	if err := c.facade.FacadeCall("ScheduleStatus", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &result, nil
+}
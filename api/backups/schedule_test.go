@@ -0,0 +1,47 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/backups"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type scheduleSuite struct {
+	baseSuite
+}
+
+var _ = gc.Suite(&scheduleSuite{})
+
+func (s *scheduleSuite) TestScheduleStatus(c *gc.C) {
+	expected := params.BackupsScheduleStatusResult{
+		Schedule:     "@daily",
+		LastRun:      time.Date(2016, 6, 1, 0, 0, 0, 0, time.UTC),
+		LastBackupID: "20160601-000000.spam",
+		NextRun:      time.Date(2016, 6, 2, 0, 0, 0, 0, time.UTC),
+	}
+	cleanup := backups.PatchClientFacadeCall(s.client,
+		func(req string, paramsIn interface{}, resp interface{}) error {
+			c.Check(req, gc.Equals, "ScheduleStatus")
+			c.Check(paramsIn, gc.IsNil)
+
+			if result, ok := resp.(*params.BackupsScheduleStatusResult); ok {
+				*result = expected
+			} else {
+				c.Fatalf("wrong output structure")
+			}
+			return nil
+		},
+	)
+	defer cleanup()
+
+	result, err := s.client.ScheduleStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(*result, jc.DeepEquals, expected)
+}
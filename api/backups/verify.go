@@ -0,0 +1,20 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Verify checks that the stored backup is usable, and describes its
+// content.
+func (c *Client) Verify(args params.BackupsVerifyArgs) (*params.BackupsVerifyResult, error) {
+	var result params.BackupsVerifyResult
+	if err := c.facade.FacadeCall("Verify", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &result, nil
+}
@@ -0,0 +1,50 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/backups"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type verifySuite struct {
+	baseSuite
+}
+
+var _ = gc.Suite(&verifySuite{})
+
+func (s *verifySuite) TestVerify(c *gc.C) {
+	expected := params.BackupsVerifyResult{
+		Version:     version.MustParse("2.0.0"),
+		ModelCount:  2,
+		Collections: 40,
+		Documents:   1000,
+	}
+	cleanup := backups.PatchClientFacadeCall(s.client,
+		func(req string, paramsIn interface{}, resp interface{}) error {
+			c.Check(req, gc.Equals, "Verify")
+
+			c.Assert(paramsIn, gc.FitsTypeOf, params.BackupsVerifyArgs{})
+			p := paramsIn.(params.BackupsVerifyArgs)
+			c.Check(p.ID, gc.Equals, "spam")
+			c.Check(p.Passphrase, gc.Equals, "secret")
+
+			if result, ok := resp.(*params.BackupsVerifyResult); ok {
+				*result = expected
+			} else {
+				c.Fatalf("wrong output structure")
+			}
+			return nil
+		},
+	)
+	defer cleanup()
+
+	result, err := s.client.Verify(params.BackupsVerifyArgs{ID: "spam", Passphrase: "secret"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(*result, jc.DeepEquals, expected)
+}
@@ -11,6 +11,7 @@ import (
 	"net/url"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -22,6 +23,7 @@ import (
 	"gopkg.in/macaroon.v1"
 
 	"github.com/juju/juju/api/base"
+	apiwatcher "github.com/juju/juju/api/watcher"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/downloader"
@@ -29,6 +31,7 @@ import (
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/status"
 	"github.com/juju/juju/tools"
+	"github.com/juju/juju/watcher"
 )
 
 // Client represents the client-accessible part of the state.
@@ -228,6 +231,19 @@ func (c *Client) WatchAll() (*AllWatcher, error) {
 	return NewAllWatcher(c.st, &info.AllWatcherId), nil
 }
 
+// WatchMigrationStatus returns a watcher which reports the status of
+// the latest migration of the model.
+func (c *Client) WatchMigrationStatus() (watcher.MigrationStatusWatcher, error) {
+	var result params.NotifyWatchResult
+	if err := c.facade.FacadeCall("WatchMigrationStatus", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return apiwatcher.NewMigrationStatusWatcher(c.facade.RawAPICaller(), result.NotifyWatcherId), nil
+}
+
 // Close closes the Client's underlying State connection
 // Client is unique among the api.State facades in closing its own State
 // connection, but it is conventional to use a Client object without any access
@@ -508,6 +524,75 @@ func (c *Client) UploadTools(r io.ReadSeeker, vers version.Binary, additionalSer
 	return resp.ToolsList, nil
 }
 
+// UploadModelTools sends tools to the controller for a model that is
+// being migrated to it. The tools are stored for that model rather
+// than the one the client is connected to.
+func (c *Client) UploadModelTools(modelUUID string, vers version.Binary, content io.ReadSeeker) error {
+	query := url.Values{
+		"model":         {modelUUID},
+		"binaryVersion": {vers.String()},
+	}
+	endpoint := "/migrate/tools?" + query.Encode()
+	contentType := "application/x-tar-gz"
+	var resp params.ToolsResult
+	if err := c.httpPost(content, endpoint, contentType, &resp); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// UploadModelCharm sends a charm archive to the controller for a model
+// that is being migrated to it. The charm is added to that model with
+// the URL it had in the exported model.
+func (c *Client) UploadModelCharm(modelUUID string, curl *charm.URL, content io.ReadSeeker) error {
+	query := url.Values{
+		"model": {modelUUID},
+		"url":   {curl.String()},
+	}
+	endpoint := "/migrate/charms?" + query.Encode()
+	contentType := "application/zip"
+	var resp params.CharmsResponse
+	if err := c.httpPost(content, endpoint, contentType, &resp); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// UploadResource sends the content of a resource to the controller
+// for a model that is being migrated to it. The resource metadata
+// must already have been imported with the model.
+func (c *Client) UploadResource(modelUUID, application, name string, content io.ReadSeeker) error {
+	query := url.Values{
+		"model":       {modelUUID},
+		"application": {application},
+		"name":        {name},
+	}
+	endpoint := "/migrate/resources?" + query.Encode()
+	contentType := "application/octet-stream"
+	var resp params.ResourceUploadResult
+	if err := c.httpPost(content, endpoint, contentType, &resp); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// ExportModel streams out an archive of the client's model from the
+// controller. The archive holds the serialized model and the binaries
+// it uses, and can be imported into another controller. The caller is
+// responsible for closing the returned reader.
+func (c *Client) ExportModel() (io.ReadCloser, error) {
+	// The returned httpClient sets the base url to /model/<uuid> if it can.
+	httpClient, err := c.st.HTTPClient()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	archive, err := openBlob(httpClient, "/export", nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return archive, nil
+}
+
 func (c *Client) httpPost(content io.ReadSeeker, endpoint, contentType string, response interface{}) error {
 	req, err := http.NewRequest("POST", endpoint, nil)
 	if err != nil {
@@ -604,6 +689,18 @@ type DebugLogParams struct {
 	// NoTail tells the server to only return the logs it has now, and not
 	// to wait for new logs to arrive.
 	NoTail bool
+	// MessagePattern is a regular expression which, if set, the message
+	// of each log line must match.
+	MessagePattern string
+	// Format specifies the format of the log lines sent back: "text", the
+	// default, or "json", in which case each line is a JSON-encoded
+	// params.DebugLogRecord.
+	Format string
+	// StartTime, if set, is the time of the earliest log lines to return.
+	StartTime time.Time
+	// EndTime, if set, is the time of the latest log lines to return.
+	// Once it is reached, the socket is closed.
+	EndTime time.Time
 }
 
 func (args DebugLogParams) URLQuery() url.Values {
@@ -628,6 +725,18 @@ func (args DebugLogParams) URLQuery() url.Values {
 	if args.Level != loggo.UNSPECIFIED {
 		attrs.Set("level", fmt.Sprint(args.Level))
 	}
+	if args.MessagePattern != "" {
+		attrs.Set("messagePattern", args.MessagePattern)
+	}
+	if args.Format != "" {
+		attrs.Set("format", args.Format)
+	}
+	if !args.StartTime.IsZero() {
+		attrs.Set("startTime", args.StartTime.UTC().Format(time.RFC3339Nano))
+	}
+	if !args.EndTime.IsZero() {
+		attrs.Set("endTime", args.EndTime.UTC().Format(time.RFC3339Nano))
+	}
 	return attrs
 }
 
@@ -14,6 +14,7 @@ import (
 	"net/url"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/httprequest"
@@ -89,6 +90,82 @@ func (s *clientSuite) TestUploadToolsOtherEnvironment(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *clientSuite) TestUploadResource(c *gc.C) {
+	client := s.APIState.Client()
+	var called bool
+
+	// UploadResource does not use the facades, so instead of patching
+	// the facade call, we set up a fake endpoint to test.
+	defer fakeAPIEndpoint(c, client, envEndpoint(c, s.APIState, "migrate/resources"), "POST",
+		func(w http.ResponseWriter, r *http.Request) {
+			called = true
+
+			c.Assert(r.URL.Query(), gc.DeepEquals, url.Values{
+				"model":       []string{"some-uuid"},
+				"application": []string{"app"},
+				"name":        []string{"spam"},
+			})
+			defer r.Body.Close()
+			content, err := ioutil.ReadAll(r.Body)
+			c.Assert(err, jc.ErrorIsNil)
+			c.Assert(string(content), gc.Equals, "spamspamspam")
+		},
+	).Close()
+
+	// We don't test the error as we only wish to assert that the API
+	// client POSTs the resource to the correct endpoint.
+	client.UploadResource("some-uuid", "app", "spam", strings.NewReader("spamspamspam"))
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *clientSuite) TestUploadModelTools(c *gc.C) {
+	client := s.APIState.Client()
+	var called bool
+
+	defer fakeAPIEndpoint(c, client, envEndpoint(c, s.APIState, "migrate/tools"), "POST",
+		func(w http.ResponseWriter, r *http.Request) {
+			called = true
+
+			c.Assert(r.URL.Query(), gc.DeepEquals, url.Values{
+				"model":         []string{"some-uuid"},
+				"binaryVersion": []string{"2.0.1-trusty-amd64"},
+			})
+			c.Assert(r.Header.Get("Content-Type"), gc.Equals, "application/x-tar-gz")
+			defer r.Body.Close()
+			content, err := ioutil.ReadAll(r.Body)
+			c.Assert(err, jc.ErrorIsNil)
+			c.Assert(string(content), gc.Equals, "fake tools")
+		},
+	).Close()
+
+	client.UploadModelTools("some-uuid", version.MustParseBinary("2.0.1-trusty-amd64"), strings.NewReader("fake tools"))
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *clientSuite) TestUploadModelCharm(c *gc.C) {
+	client := s.APIState.Client()
+	var called bool
+
+	defer fakeAPIEndpoint(c, client, envEndpoint(c, s.APIState, "migrate/charms"), "POST",
+		func(w http.ResponseWriter, r *http.Request) {
+			called = true
+
+			c.Assert(r.URL.Query(), gc.DeepEquals, url.Values{
+				"model": []string{"some-uuid"},
+				"url":   []string{"cs:trusty/magic-2"},
+			})
+			c.Assert(r.Header.Get("Content-Type"), gc.Equals, "application/zip")
+			defer r.Body.Close()
+			content, err := ioutil.ReadAll(r.Body)
+			c.Assert(err, jc.ErrorIsNil)
+			c.Assert(string(content), gc.Equals, "fake charm")
+		},
+	).Close()
+
+	client.UploadModelCharm("some-uuid", charm.MustParseURL("cs:trusty/magic-2"), strings.NewReader("fake charm"))
+	c.Assert(called, jc.IsTrue)
+}
+
 func (s *clientSuite) TestAddLocalCharm(c *gc.C) {
 	charmArchive := testcharms.Repo.CharmArchive(c.MkDir(), "dummy")
 	curl := charm.MustParseURL(
@@ -234,6 +311,26 @@ func testMinVer(client *api.Client, t minverTest, c *gc.C) {
 	}
 }
 
+func (s *clientSuite) TestExportModel(c *gc.C) {
+	client := s.APIState.Client()
+
+	// ExportModel does not use the facades, so instead of patching
+	// the facade call, we set up a fake endpoint to test.
+	defer fakeAPIEndpoint(c, client, envEndpoint(c, s.APIState, "export"), "GET",
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/x-tar-gz")
+			w.Write([]byte("model archive"))
+		},
+	).Close()
+
+	archive, err := client.ExportModel()
+	c.Assert(err, jc.ErrorIsNil)
+	defer archive.Close()
+	content, err := ioutil.ReadAll(archive)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(content), gc.Equals, "model archive")
+}
+
 func (s *clientSuite) TestOpenCharmFound(c *gc.C) {
 	client := s.APIState.Client()
 	curl, ch := addLocalCharm(c, client, "dummy")
@@ -388,15 +485,19 @@ func (s *clientSuite) TestWatchDebugLogParamsEncoded(c *gc.C) {
 	s.PatchValue(api.WebsocketDialConfig, echoURL(c))
 
 	params := api.DebugLogParams{
-		IncludeEntity: []string{"a", "b"},
-		IncludeModule: []string{"c", "d"},
-		ExcludeEntity: []string{"e", "f"},
-		ExcludeModule: []string{"g", "h"},
-		Limit:         100,
-		Backlog:       200,
-		Level:         loggo.ERROR,
-		Replay:        true,
-		NoTail:        true,
+		IncludeEntity:  []string{"a", "b"},
+		IncludeModule:  []string{"c", "d"},
+		ExcludeEntity:  []string{"e", "f"},
+		ExcludeModule:  []string{"g", "h"},
+		Limit:          100,
+		Backlog:        200,
+		Level:          loggo.ERROR,
+		Replay:         true,
+		NoTail:         true,
+		MessagePattern: "^hook",
+		Format:         "json",
+		StartTime:      time.Date(2016, 10, 12, 22, 0, 0, 0, time.UTC),
+		EndTime:        time.Date(2016, 10, 13, 0, 0, 0, 500, time.UTC),
 	}
 
 	client := s.APIState.Client()
@@ -406,15 +507,19 @@ func (s *clientSuite) TestWatchDebugLogParamsEncoded(c *gc.C) {
 	connectURL := connectURLFromReader(c, reader)
 	values := connectURL.Query()
 	c.Assert(values, jc.DeepEquals, url.Values{
-		"includeEntity": params.IncludeEntity,
-		"includeModule": params.IncludeModule,
-		"excludeEntity": params.ExcludeEntity,
-		"excludeModule": params.ExcludeModule,
-		"maxLines":      {"100"},
-		"backlog":       {"200"},
-		"level":         {"ERROR"},
-		"replay":        {"true"},
-		"noTail":        {"true"},
+		"includeEntity":  params.IncludeEntity,
+		"includeModule":  params.IncludeModule,
+		"excludeEntity":  params.ExcludeEntity,
+		"excludeModule":  params.ExcludeModule,
+		"maxLines":       {"100"},
+		"backlog":        {"200"},
+		"level":          {"ERROR"},
+		"replay":         {"true"},
+		"noTail":         {"true"},
+		"messagePattern": {"^hook"},
+		"format":         {"json"},
+		"startTime":      {"2016-10-12T22:00:00Z"},
+		"endTime":        {"2016-10-13T00:00:00.0000005Z"},
 	})
 }
 
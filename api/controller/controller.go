@@ -4,6 +4,8 @@
 package controller
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 
@@ -11,6 +13,7 @@ import (
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/api/common"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/migration"
 )
 
 // Client provides methods that the Juju client command uses to interact
@@ -149,6 +152,11 @@ type ModelMigrationSpec struct {
 	TargetCACert         string
 	TargetUser           string
 	TargetPassword       string
+
+	// Offline is true when the model has already been imported into
+	// the target controller from a model archive, so only the
+	// model's agents need to be migrated.
+	Offline bool
 }
 
 // Validate performs sanity checks on the migration configuration it
@@ -185,18 +193,7 @@ func (c *Client) InitiateModelMigration(spec ModelMigrationSpec) (string, error)
 	if err := spec.Validate(); err != nil {
 		return "", errors.Trace(err)
 	}
-	args := params.InitiateModelMigrationArgs{
-		Specs: []params.ModelMigrationSpec{{
-			ModelTag: names.NewModelTag(spec.ModelUUID).String(),
-			TargetInfo: params.ModelMigrationTargetInfo{
-				ControllerTag: names.NewModelTag(spec.TargetControllerUUID).String(),
-				Addrs:         spec.TargetAddrs,
-				CACert:        spec.TargetCACert,
-				AuthTag:       names.NewUserTag(spec.TargetUser).String(),
-				Password:      spec.TargetPassword,
-			},
-		}},
-	}
+	args := migrationArgs(spec)
 	response := params.InitiateModelMigrationResults{}
 	if err := c.facade.FacadeCall("InitiateModelMigration", args, &response); err != nil {
 		return "", errors.Trace(err)
@@ -210,3 +207,208 @@ func (c *Client) InitiateModelMigration(spec ModelMigrationSpec) (string, error)
 	}
 	return result.Id, nil
 }
+
+// MigrationDryRun checks whether the specified model could be
+// migrated to the target controller, without changing either
+// controller. It returns the reasons, if any, that the migration
+// would fail.
+func (c *Client) MigrationDryRun(spec ModelMigrationSpec) ([]string, error) {
+	if c.BestAPIVersion() < 4 {
+		return nil, errors.NotImplementedf("MigrationDryRun() (need V4+)")
+	}
+	if err := spec.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	args := migrationArgs(spec)
+	response := params.MigrationDryRunResults{}
+	if err := c.facade.FacadeCall("MigrationDryRun", args, &response); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(response.Results) != 1 {
+		return nil, errors.New("unexpected number of results returned")
+	}
+	result := response.Results[0]
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	return result.Blockers, nil
+}
+
+// MigrationProgress describes the progress of a model migration.
+type MigrationProgress struct {
+	MigrationId      string
+	Phase            migration.Phase
+	StatusMessage    string
+	AbortReason      string
+	StartTime        time.Time
+	PhaseChangedTime time.Time
+	// EndTime is zero if the migration hasn't finished.
+	EndTime    time.Time
+	PhaseTimes []MigrationPhaseTime
+
+	// MinionsSucceeded and MinionsFailed count the agents which
+	// have reported on the current phase.
+	MinionsSucceeded int
+	MinionsFailed    int
+	FailedMinions    []names.Tag
+
+	CharmBytesUploaded int64
+	ToolsBytesUploaded int64
+}
+
+// MigrationPhaseTime records when a migration entered a phase.
+type MigrationPhaseTime struct {
+	Phase   migration.Phase
+	Entered time.Time
+}
+
+// MigrationProgress returns the progress of the latest migration
+// attempt for the specified model.
+func (c *Client) MigrationProgress(modelUUID string) (MigrationProgress, error) {
+	var empty MigrationProgress
+	if c.BestAPIVersion() < 4 {
+		return empty, errors.NotImplementedf("MigrationProgress() (need V4+)")
+	}
+	if !names.IsValidModel(modelUUID) {
+		return empty, errors.NotValidf("model UUID")
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewModelTag(modelUUID).String()}},
+	}
+	response := params.MigrationProgressResults{}
+	if err := c.facade.FacadeCall("MigrationProgress", args, &response); err != nil {
+		return empty, errors.Trace(err)
+	}
+	if len(response.Results) != 1 {
+		return empty, errors.New("unexpected number of results returned")
+	}
+	result := response.Results[0]
+	if result.Error != nil {
+		return empty, errors.Trace(result.Error)
+	}
+	if result.Progress == nil {
+		return empty, errors.New("missing migration progress")
+	}
+	return convertMigrationProgress(*result.Progress)
+}
+
+func convertMigrationProgress(in params.MigrationProgress) (MigrationProgress, error) {
+	var empty MigrationProgress
+	phase, ok := migration.ParsePhase(in.Phase)
+	if !ok {
+		return empty, errors.Errorf("invalid phase %q", in.Phase)
+	}
+	out := MigrationProgress{
+		MigrationId:        in.MigrationId,
+		Phase:              phase,
+		StatusMessage:      in.StatusMessage,
+		AbortReason:        in.AbortReason,
+		StartTime:          in.StartTime,
+		PhaseChangedTime:   in.PhaseChangedTime,
+		MinionsSucceeded:   in.MinionsSucceeded,
+		MinionsFailed:      in.MinionsFailed,
+		CharmBytesUploaded: in.CharmBytesUploaded,
+		ToolsBytesUploaded: in.ToolsBytesUploaded,
+	}
+	if in.EndTime != nil {
+		out.EndTime = *in.EndTime
+	}
+	for _, p := range in.Phases {
+		phase, ok := migration.ParsePhase(p.Phase)
+		if !ok {
+			return empty, errors.Errorf("invalid phase %q", p.Phase)
+		}
+		out.PhaseTimes = append(out.PhaseTimes, MigrationPhaseTime{
+			Phase:   phase,
+			Entered: p.Entered,
+		})
+	}
+	for _, tagString := range in.FailedMinions {
+		tag, err := names.ParseTag(tagString)
+		if err != nil {
+			return empty, errors.Trace(err)
+		}
+		out.FailedMinions = append(out.FailedMinions, tag)
+	}
+	return out, nil
+}
+
+// ResumeModelMigration resumes a migration which failed to remove
+// its model from the controller. Agents which haven't moved to the
+// target controller are told to move again, and removing the model is
+// reattempted.
+func (c *Client) ResumeModelMigration(modelUUID string) error {
+	return c.reapFailedMigrationCall("ResumeModelMigration", modelUUID)
+}
+
+// CleanupModelMigration completes a migration which failed to remove
+// its model from the controller, by removing the model directly.
+func (c *Client) CleanupModelMigration(modelUUID string) error {
+	return c.reapFailedMigrationCall("CleanupModelMigration", modelUUID)
+}
+
+func (c *Client) reapFailedMigrationCall(request, modelUUID string) error {
+	if c.BestAPIVersion() < 4 {
+		return errors.NotImplementedf("%s() (need V4+)", request)
+	}
+	if !names.IsValidModel(modelUUID) {
+		return errors.NotValidf("model UUID")
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewModelTag(modelUUID).String()}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall(request, args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(results.OneError())
+}
+
+// CloneModel creates a copy of a model in the controller under the
+// given name. The UUID of the new model is returned.
+func (c *Client) CloneModel(modelUUID, name string) (string, error) {
+	if c.BestAPIVersion() < 4 {
+		return "", errors.NotImplementedf("CloneModel() (need V4+)")
+	}
+	if !names.IsValidModel(modelUUID) {
+		return "", errors.NotValidf("model UUID")
+	}
+	args := params.CloneModelArgs{
+		Specs: []params.CloneModelSpec{{
+			ModelTag: names.NewModelTag(modelUUID).String(),
+			Name:     name,
+		}},
+	}
+	response := params.CloneModelResults{}
+	if err := c.facade.FacadeCall("CloneModel", args, &response); err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(response.Results) != 1 {
+		return "", errors.New("unexpected number of results returned")
+	}
+	result := response.Results[0]
+	if result.Error != nil {
+		return "", errors.Trace(result.Error)
+	}
+	cloneTag, err := names.ParseModelTag(result.CloneTag)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return cloneTag.Id(), nil
+}
+
+func migrationArgs(spec ModelMigrationSpec) params.InitiateModelMigrationArgs {
+	return params.InitiateModelMigrationArgs{
+		Specs: []params.ModelMigrationSpec{{
+			ModelTag: names.NewModelTag(spec.ModelUUID).String(),
+			TargetInfo: params.ModelMigrationTargetInfo{
+				ControllerTag: names.NewModelTag(spec.TargetControllerUUID).String(),
+				Addrs:         spec.TargetAddrs,
+				CACert:        spec.TargetCACert,
+				AuthTag:       names.NewUserTag(spec.TargetUser).String(),
+				Password:      spec.TargetPassword,
+			},
+			Offline: spec.Offline,
+		}},
+	}
+}
@@ -14,9 +14,11 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/base"
+	apitesting "github.com/juju/juju/api/base/testing"
 	"github.com/juju/juju/api/controller"
 	commontesting "github.com/juju/juju/apiserver/common/testing"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/migration"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/multiwatcher"
@@ -202,6 +204,30 @@ func (s *controllerSuite) TestInitiateModelMigration(c *gc.C) {
 	mig, err := st.GetModelMigration()
 	c.Assert(err, jc.ErrorIsNil)
 	c.Check(mig.Id(), gc.Equals, expectedId)
+	c.Check(mig.Offline(), jc.IsFalse)
+}
+
+func (s *controllerSuite) TestInitiateModelMigrationOffline(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	spec := controller.ModelMigrationSpec{
+		ModelUUID:            st.ModelUUID(),
+		TargetControllerUUID: randomUUID(),
+		TargetAddrs:          []string{"1.2.3.4:5"},
+		TargetCACert:         "cert",
+		TargetUser:           "someone",
+		TargetPassword:       "secret",
+		Offline:              true,
+	}
+
+	controller := s.OpenAPI(c)
+	_, err := controller.InitiateModelMigration(spec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	mig, err := st.GetModelMigration()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mig.Offline(), jc.IsTrue)
 }
 
 func (s *controllerSuite) TestInitiateModelMigrationError(c *gc.C) {
@@ -220,6 +246,208 @@ func (s *controllerSuite) TestInitiateModelMigrationError(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, "unable to read model: .+")
 }
 
+func (s *controllerSuite) TestMigrationDryRun(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	// The controller under test stands in for the target controller.
+	apiInfo := s.APIInfo(c)
+	spec := controller.ModelMigrationSpec{
+		ModelUUID:            st.ModelUUID(),
+		TargetControllerUUID: randomUUID(),
+		TargetAddrs:          apiInfo.Addrs,
+		TargetCACert:         apiInfo.CACert,
+		TargetUser:           apiInfo.Tag.Id(),
+		TargetPassword:       apiInfo.Password,
+	}
+
+	controller := s.OpenAPI(c)
+	blockers, err := controller.MigrationDryRun(spec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(blockers, jc.DeepEquals, []string{
+		fmt.Sprintf("model %s already exists on target controller", st.ModelUUID()),
+	})
+
+	// No migration was started.
+	_, err = st.GetModelMigration()
+	c.Assert(errors.IsNotFound(err), jc.IsTrue)
+}
+
+func (s *controllerSuite) TestMigrationDryRunError(c *gc.C) {
+	spec := controller.ModelMigrationSpec{
+		ModelUUID:            randomUUID(), // Model doesn't exist.
+		TargetControllerUUID: randomUUID(),
+		TargetAddrs:          []string{"1.2.3.4:5"},
+		TargetCACert:         "cert",
+		TargetUser:           "someone",
+		TargetPassword:       "secret",
+	}
+
+	controller := s.OpenAPI(c)
+	blockers, err := controller.MigrationDryRun(spec)
+	c.Check(blockers, gc.IsNil)
+	c.Check(err, gc.ErrorMatches, "unable to read model: .+")
+}
+
+func (s *controllerSuite) TestMigrationProgress(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	spec := controller.ModelMigrationSpec{
+		ModelUUID:            st.ModelUUID(),
+		TargetControllerUUID: randomUUID(),
+		TargetAddrs:          []string{"1.2.3.4:5"},
+		TargetCACert:         "cert",
+		TargetUser:           "someone",
+		TargetPassword:       "secret",
+	}
+	controller := s.OpenAPI(c)
+	_, err := controller.InitiateModelMigration(spec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	mig, err := st.GetModelMigration()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mig.SetPhase(migration.READONLY), jc.ErrorIsNil)
+	c.Assert(mig.SubmitMinionReport(names.NewMachineTag("0"), migration.READONLY, true), jc.ErrorIsNil)
+	c.Assert(mig.SubmitMinionReport(names.NewMachineTag("1"), migration.READONLY, false), jc.ErrorIsNil)
+	c.Assert(mig.SetUploadProgress(100, 200), jc.ErrorIsNil)
+
+	progress, err := controller.MigrationProgress(st.ModelUUID())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(progress.MigrationId, gc.Equals, mig.Id())
+	c.Check(progress.Phase, gc.Equals, migration.READONLY)
+	c.Check(progress.StartTime.Equal(mig.StartTime()), jc.IsTrue)
+	c.Check(progress.EndTime.IsZero(), jc.IsTrue)
+	c.Assert(progress.PhaseTimes, gc.HasLen, 2)
+	c.Check(progress.PhaseTimes[0].Phase, gc.Equals, migration.QUIESCE)
+	c.Check(progress.PhaseTimes[1].Phase, gc.Equals, migration.READONLY)
+	c.Check(progress.PhaseTimes[1].Entered.Equal(mig.PhaseChangedTime()), jc.IsTrue)
+	c.Check(progress.MinionsSucceeded, gc.Equals, 1)
+	c.Check(progress.MinionsFailed, gc.Equals, 1)
+	c.Check(progress.FailedMinions, jc.DeepEquals, []names.Tag{names.NewMachineTag("1")})
+	c.Check(progress.CharmBytesUploaded, gc.Equals, int64(100))
+	c.Check(progress.ToolsBytesUploaded, gc.Equals, int64(200))
+}
+
+func (s *controllerSuite) TestMigrationProgressNoMigration(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	controller := s.OpenAPI(c)
+	_, err := controller.MigrationProgress(st.ModelUUID())
+	c.Check(err, gc.ErrorMatches, "migration not found")
+	c.Check(err, jc.Satisfies, params.IsCodeNotFound)
+}
+
+func (s *controllerSuite) makeReapFailedMigration(c *gc.C, st *state.State) state.ModelMigration {
+	mig, err := st.CreateModelMigration(state.ModelMigrationSpec{
+		InitiatedBy: names.NewUserTag("admin"),
+		TargetInfo: migration.TargetInfo{
+			ControllerTag: names.NewModelTag(randomUUID()),
+			Addrs:         []string{"1.2.3.4:5"},
+			CACert:        "cert",
+			AuthTag:       names.NewUserTag("someone"),
+			Password:      "secret",
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	for phase := migration.READONLY; phase <= migration.REAPFAILED; phase++ {
+		c.Assert(mig.SetPhase(phase), jc.ErrorIsNil)
+	}
+	return mig
+}
+
+func (s *controllerSuite) TestResumeModelMigration(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	mig := s.makeReapFailedMigration(c, st)
+
+	controller := s.OpenAPI(c)
+	err := controller.ResumeModelMigration(st.ModelUUID())
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(mig.Refresh(), jc.ErrorIsNil)
+	phase, err := mig.Phase()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(phase, gc.Equals, migration.SUCCESS)
+}
+
+func (s *controllerSuite) TestResumeModelMigrationError(c *gc.C) {
+	controller := s.OpenAPI(c)
+	err := controller.ResumeModelMigration(randomUUID()) // Model doesn't exist.
+	c.Check(err, gc.ErrorMatches, "unable to read model: .+")
+}
+
+func (s *controllerSuite) TestCleanupModelMigration(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	mig := s.makeReapFailedMigration(c, st)
+
+	controller := s.OpenAPI(c)
+	err := controller.CleanupModelMigration(st.ModelUUID())
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.GetModel(st.ModelTag())
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(mig.Refresh(), jc.ErrorIsNil)
+	phase, err := mig.Phase()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(phase, gc.Equals, migration.DONE)
+}
+
+func (s *controllerSuite) TestCleanupModelMigrationError(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	controller := s.OpenAPI(c)
+	err := controller.CleanupModelMigration(st.ModelUUID()) // No migration.
+	c.Check(err, gc.ErrorMatches, "migration not found")
+	c.Check(err, jc.Satisfies, params.IsCodeNotFound)
+}
+
+func (s *controllerSuite) TestCloneModel(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	controller := s.OpenAPI(c)
+	cloneUUID, err := controller.CloneModel(st.ModelUUID(), "staging")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cloneUUID, gc.Not(gc.Equals), st.ModelUUID())
+
+	clone, err := s.State.GetModel(names.NewModelTag(cloneUUID))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(clone.Name(), gc.Equals, "staging")
+}
+
+func (s *controllerSuite) TestCloneModelError(c *gc.C) {
+	controller := s.OpenAPI(c)
+	cloneUUID, err := controller.CloneModel(randomUUID(), "staging") // Model doesn't exist.
+	c.Check(cloneUUID, gc.Equals, "")
+	c.Check(err, gc.ErrorMatches, "unable to read model: .+")
+}
+
+func (s *controllerSuite) TestNewMethodsNeedV4(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(
+		func(string, int, string, string, interface{}, interface{}) error {
+			c.Fatalf("facade called")
+			return nil
+		},
+	)
+	client := controller.NewClient(apiCaller)
+	modelUUID := randomUUID()
+
+	_, err := client.MigrationDryRun(controller.ModelMigrationSpec{})
+	c.Check(err, gc.ErrorMatches, `MigrationDryRun\(\) \(need V4\+\) not implemented`)
+	_, err = client.MigrationProgress(modelUUID)
+	c.Check(err, gc.ErrorMatches, `MigrationProgress\(\) \(need V4\+\) not implemented`)
+	err = client.ResumeModelMigration(modelUUID)
+	c.Check(err, gc.ErrorMatches, `ResumeModelMigration\(\) \(need V4\+\) not implemented`)
+	err = client.CleanupModelMigration(modelUUID)
+	c.Check(err, gc.ErrorMatches, `CleanupModelMigration\(\) \(need V4\+\) not implemented`)
+	_, err = client.CloneModel(modelUUID, "staging")
+	c.Check(err, gc.ErrorMatches, `CloneModel\(\) \(need V4\+\) not implemented`)
+}
+
 func randomUUID() string {
 	return utils.MustNewUUID().String()
 }
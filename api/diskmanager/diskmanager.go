@@ -4,6 +4,7 @@
 package diskmanager
 
 import (
+	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/base"
@@ -43,3 +44,52 @@ func (st *State) SetMachineBlockDevices(devices []storage.BlockDevice) error {
 	}
 	return results.OneError()
 }
+
+// FilesystemMountPoints returns the mount points of the Juju-managed
+// filesystems attached to the machine identified by the authenticated
+// machine tag.
+func (st *State) FilesystemMountPoints() ([]string, error) {
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: st.tag.String()}},
+	}
+	var results params.StringsResults
+	err := st.facade.FacadeCall("FilesystemMountPoints", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return result.Result, nil
+}
+
+// SetFilesystemUsage records the capacity usage of filesystems mounted
+// on the machine identified by the authenticated machine tag.
+func (st *State) SetFilesystemUsage(usage []storage.FilesystemUsage) error {
+	paramsUsage := make([]params.FilesystemUsage, len(usage))
+	for i, u := range usage {
+		paramsUsage[i] = params.FilesystemUsage{
+			MountPoint: u.Path,
+			UsedBytes:  u.UsedBytes,
+			FreeBytes:  u.FreeBytes,
+			UsedInodes: u.UsedInodes,
+			FreeInodes: u.FreeInodes,
+		}
+	}
+	args := params.SetMachineFilesystemUsage{
+		MachineFilesystemUsage: []params.MachineFilesystemUsage{{
+			Machine: st.tag.String(),
+			Usage:   paramsUsage,
+		}},
+	}
+	var results params.ErrorResults
+	err := st.facade.FacadeCall("SetMachineFilesystemUsage", args, &results)
+	if err != nil {
+		return err
+	}
+	return results.OneError()
+}
@@ -124,3 +124,79 @@ func (s *DiskManagerSuite) TestSetMachineBlockDevicesResultCountInvalid(c *gc.C)
 		c.Check(err, gc.ErrorMatches, fmt.Sprintf("expected 1 result, got %d", n))
 	}
 }
+
+func (s *DiskManagerSuite) TestFilesystemMountPoints(c *gc.C) {
+	var callCount int
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "DiskManager")
+		c.Check(request, gc.Equals, "FilesystemMountPoints")
+		c.Check(arg, gc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "machine-123"}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.StringsResults{})
+		*(result.(*params.StringsResults)) = params.StringsResults{
+			Results: []params.StringsResult{{
+				Result: []string{"/srv/a", "/srv/b"},
+			}},
+		}
+		callCount++
+		return nil
+	})
+
+	st := diskmanager.NewState(apiCaller, names.NewMachineTag("123"))
+	mountPoints, err := st.FilesystemMountPoints()
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(mountPoints, jc.DeepEquals, []string{"/srv/a", "/srv/b"})
+	c.Check(callCount, gc.Equals, 1)
+}
+
+func (s *DiskManagerSuite) TestFilesystemMountPointsResultError(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.StringsResults)) = params.StringsResults{
+			Results: []params.StringsResult{{
+				Error: &params.Error{Message: "permission denied"},
+			}},
+		}
+		return nil
+	})
+	st := diskmanager.NewState(apiCaller, names.NewMachineTag("123"))
+	_, err := st.FilesystemMountPoints()
+	c.Check(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *DiskManagerSuite) TestSetFilesystemUsage(c *gc.C) {
+	var callCount int
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "DiskManager")
+		c.Check(request, gc.Equals, "SetMachineFilesystemUsage")
+		c.Check(arg, gc.DeepEquals, params.SetMachineFilesystemUsage{
+			MachineFilesystemUsage: []params.MachineFilesystemUsage{{
+				Machine: "machine-123",
+				Usage: []params.FilesystemUsage{{
+					MountPoint: "/srv",
+					UsedBytes:  1,
+					FreeBytes:  2,
+					UsedInodes: 3,
+					FreeInodes: 4,
+				}},
+			}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{}},
+		}
+		callCount++
+		return nil
+	})
+
+	st := diskmanager.NewState(apiCaller, names.NewMachineTag("123"))
+	err := st.SetFilesystemUsage([]storage.FilesystemUsage{{
+		Path:       "/srv",
+		UsedBytes:  1,
+		FreeBytes:  2,
+		UsedInodes: 3,
+		FreeInodes: 4,
+	}})
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(callCount, gc.Equals, 1)
+}
@@ -19,17 +19,17 @@ var facadeVersions = map[string]int{
 	"Annotations":                  2,
 	"Application":                  1,
 	"ApplicationScaler":            1,
-	"Backups":                      1,
+	"Backups":                      2,
 	"Block":                        2,
 	"CharmRevisionUpdater":         2,
 	"Charms":                       2,
 	"Cleaner":                      2,
 	"Client":                       1,
 	"Cloud":                        1,
-	"Controller":                   3,
+	"Controller":                   4,
 	"Deployer":                     1,
 	"DiscoverSpaces":               2,
-	"DiskManager":                  2,
+	"DiskManager":                  3,
 	"EntityWatcher":                2,
 	"FilesystemAttachmentsWatcher": 2,
 	"Firewaller":                   3,
@@ -42,7 +42,7 @@ var facadeVersions = map[string]int{
 	"KeyUpdater":                   1,
 	"LeadershipService":            2,
 	"LifeFlag":                     1,
-	"LogForwarding":                1,
+	"LogForwarding":                2,
 	"Logger":                       1,
 	"MachineActions":               1,
 	"MachineManager":               2,
@@ -52,10 +52,10 @@ var facadeVersions = map[string]int{
 	"MetricsDebug":                 2,
 	"MetricsManager":               1,
 	"MigrationFlag":                1,
-	"MigrationMaster":              1,
+	"MigrationMaster":              2,
 	"MigrationMinion":              1,
 	"MigrationStatusWatcher":       1,
-	"MigrationTarget":              1,
+	"MigrationTarget":              2,
 	"ModelManager":                 2,
 	"NotifyWatcher":                1,
 	"Payloads":                     1,
@@ -73,7 +73,7 @@ var facadeVersions = map[string]int{
 	"Spaces":                       2,
 	"SSHClient":                    1,
 	"StatusHistory":                2,
-	"Storage":                      2,
+	"Storage":                      3,
 	"StorageProvisioner":           2,
 	"StringsWatcher":               1,
 	"Subnets":                      2,
@@ -18,6 +18,8 @@ import (
 // identified "sink" (for a given model).
 type LastSentID struct {
 	// ModelTag identifies the model associated with the log record.
+	// If it is the zero value then the ID covers the log records of
+	// all models.
 	Model names.ModelTag
 
 	// Sink is the name of the log forwarding target to which a log
@@ -69,7 +71,7 @@ func (c LastSentClient) GetList(ids []LastSentID) ([]LastSentResult, error) {
 	args.IDs = make([]params.LogForwardingID, len(ids))
 	for i, id := range ids {
 		args.IDs[i] = params.LogForwardingID{
-			ModelTag: id.Model.String(),
+			ModelTag: modelTagString(id.Model),
 			Sink:     id.Sink,
 		}
 	}
@@ -101,7 +103,7 @@ func (c LastSentClient) SetList(reqs []LastSentInfo) ([]LastSentResult, error) {
 	for i, req := range reqs {
 		args.Params[i] = params.LogForwardingSetLastSentParam{
 			LogForwardingID: params.LogForwardingID{
-				ModelTag: req.Model.String(),
+				ModelTag: modelTagString(req.Model),
 				Sink:     req.Sink,
 			},
 			RecordID: req.RecordID,
@@ -123,3 +125,12 @@ func (c LastSentClient) SetList(reqs []LastSentInfo) ([]LastSentResult, error) {
 	}
 	return results, nil
 }
+
+// modelTagString returns the string form of the tag, which is empty
+// for the zero tag (all models).
+func modelTagString(tag names.ModelTag) string {
+	if tag.Id() == "" {
+		return ""
+	}
+	return tag.String()
+}
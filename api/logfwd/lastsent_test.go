@@ -183,11 +183,49 @@ func (s *LastSentSuite) TestSetLastSent(c *gc.C) {
 	})
 }
 
+func (s *LastSentSuite) TestSetLastSentAllModels(c *gc.C) {
+	stub := &testing.Stub{}
+	caller := &stubFacadeCaller{stub: stub}
+	caller.ReturnFacadeCallSet = params.ErrorResults{
+		Results: []params.ErrorResult{{
+			Error: nil,
+		}},
+	}
+	client := logfwd.NewLastSentClient(caller.newFacadeCaller)
+
+	results, err := client.SetList([]logfwd.LastSentInfo{{
+		LastSentID: logfwd.LastSentID{
+			Sink: "spam",
+		},
+		RecordID: 10,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(results, jc.DeepEquals, []logfwd.LastSentResult{{
+		LastSentInfo: logfwd.LastSentInfo{
+			LastSentID: logfwd.LastSentID{
+				Sink: "spam",
+			},
+			RecordID: 10,
+		},
+	}})
+	stub.CheckCall(c, 1, "FacadeCall", "SetLastSent", params.LogForwardingSetLastSentParams{
+		Params: []params.LogForwardingSetLastSentParam{{
+			LogForwardingID: params.LogForwardingID{
+				ModelTag: "",
+				Sink:     "spam",
+			},
+			RecordID: 10,
+		}},
+	})
+}
+
 type stubFacadeCaller struct {
 	stub *testing.Stub
 
-	ReturnFacadeCallGet params.LogForwardingGetLastSentResults
-	ReturnFacadeCallSet params.ErrorResults
+	ReturnFacadeCallGet          params.LogForwardingGetLastSentResults
+	ReturnFacadeCallSet          params.ErrorResults
+	ReturnFacadeCallModelConfigs params.LogForwardingModelConfigResults
 }
 
 func (s *stubFacadeCaller) newFacadeCaller(facade string) logfwd.FacadeCaller {
@@ -210,6 +248,9 @@ func (s *stubFacadeCaller) FacadeCall(request string, args, response interface{}
 	case "SetLastSent":
 		actual := response.(*params.ErrorResults)
 		*actual = s.ReturnFacadeCallSet
+	case "ModelConfigs":
+		actual := response.(*params.LogForwardingModelConfigResults)
+		*actual = s.ReturnFacadeCallModelConfigs
 	}
 	return nil
 }
@@ -0,0 +1,81 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logfwd
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/logfwd"
+)
+
+// ModelConfig holds the log forwarding config of a hosted model.
+type ModelConfig struct {
+	// Model identifies the hosted model.
+	Model names.ModelTag
+
+	// Config is the model's log forwarding config.
+	Config logfwd.SinkConfig
+}
+
+// ModelConfigClient exposes the per-model config methods of the
+// LogForwarding API facade.
+type ModelConfigClient struct {
+	caller FacadeCaller
+}
+
+// NewModelConfigClient creates a new API client for the facade.
+func NewModelConfigClient(newFacadeCaller func(string) FacadeCaller) *ModelConfigClient {
+	return &ModelConfigClient{
+		caller: newFacadeCaller("LogForwarding"),
+	}
+}
+
+// ModelConfigs makes a "ModelConfigs" call on the facade and returns
+// the log forwarding config of each hosted model that has one.
+func (c ModelConfigClient) ModelConfigs() ([]ModelConfig, error) {
+	var apiResults params.LogForwardingModelConfigResults
+	err := c.caller.FacadeCall("ModelConfigs", nil, &apiResults)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	results := make([]ModelConfig, len(apiResults.Results))
+	for i, apiRes := range apiResults.Results {
+		tag, err := names.ParseModelTag(apiRes.ModelTag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		filter := logfwd.Filter{
+			IncludeEntity: apiRes.IncludeEntity,
+			ExcludeEntity: apiRes.ExcludeEntity,
+			IncludeModule: apiRes.IncludeModule,
+			ExcludeModule: apiRes.ExcludeModule,
+		}
+		if apiRes.MinLevel != "" {
+			level, ok := loggo.ParseLevel(apiRes.MinLevel)
+			if !ok {
+				return nil, errors.NotValidf("min level %q", apiRes.MinLevel)
+			}
+			filter.MinLevel = level
+		}
+		results[i] = ModelConfig{
+			Model: tag,
+			Config: logfwd.SinkConfig{
+				Enabled:       apiRes.Enabled,
+				Type:          logfwd.SinkType(apiRes.Type),
+				Endpoint:      apiRes.Endpoint,
+				CACert:        apiRes.CACert,
+				ClientCert:    apiRes.ClientCert,
+				ClientKey:     apiRes.ClientKey,
+				BatchSize:     apiRes.BatchSize,
+				FlushInterval: apiRes.FlushInterval,
+				Filter:        filter,
+			},
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,79 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logfwd_test
+
+import (
+	"time"
+
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/logfwd"
+	"github.com/juju/juju/apiserver/params"
+	corelogfwd "github.com/juju/juju/logfwd"
+)
+
+type ModelConfigSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ModelConfigSuite{})
+
+func (s *ModelConfigSuite) TestModelConfigs(c *gc.C) {
+	stub := &testing.Stub{}
+	caller := &stubFacadeCaller{stub: stub}
+	modelTag := names.NewModelTag("deadbeef-2f18-4fd2-967d-db9663db7bea")
+	caller.ReturnFacadeCallModelConfigs = params.LogForwardingModelConfigResults{
+		Results: []params.LogForwardingModelConfig{{
+			ModelTag:      modelTag.String(),
+			Enabled:       true,
+			Type:          "loki",
+			Endpoint:      "https://loki.example.com/loki/api/v1/push",
+			BatchSize:     10,
+			FlushInterval: time.Second,
+			MinLevel:      "WARNING",
+			ExcludeEntity: []string{"machine-*"},
+		}},
+	}
+	client := logfwd.NewModelConfigClient(caller.newFacadeCaller)
+
+	results, err := client.ModelConfigs()
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(results, jc.DeepEquals, []logfwd.ModelConfig{{
+		Model: modelTag,
+		Config: corelogfwd.SinkConfig{
+			Enabled:       true,
+			Type:          corelogfwd.SinkLoki,
+			Endpoint:      "https://loki.example.com/loki/api/v1/push",
+			BatchSize:     10,
+			FlushInterval: time.Second,
+			Filter: corelogfwd.Filter{
+				MinLevel:      loggo.WARNING,
+				ExcludeEntity: []string{"machine-*"},
+			},
+		},
+	}})
+	stub.CheckCallNames(c, "newFacadeCaller", "FacadeCall")
+	stub.CheckCall(c, 1, "FacadeCall", "ModelConfigs", nil)
+}
+
+func (s *ModelConfigSuite) TestModelConfigsBadLevel(c *gc.C) {
+	stub := &testing.Stub{}
+	caller := &stubFacadeCaller{stub: stub}
+	caller.ReturnFacadeCallModelConfigs = params.LogForwardingModelConfigResults{
+		Results: []params.LogForwardingModelConfig{{
+			ModelTag: names.NewModelTag("deadbeef-2f18-4fd2-967d-db9663db7bea").String(),
+			MinLevel: "LOUD",
+		}},
+	}
+	client := logfwd.NewModelConfigClient(caller.newFacadeCaller)
+
+	_, err := client.ModelConfigs()
+
+	c.Check(err, gc.ErrorMatches, `min level "LOUD" not valid`)
+}
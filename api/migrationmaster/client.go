@@ -29,9 +29,26 @@ type Client interface {
 	// migration.
 	SetPhase(migration.Phase) error
 
+	// SetStatusMessage sets a human readable message about the
+	// progress of the currently active model migration.
+	SetStatusMessage(string) error
+
 	// Export returns a serialized representation of the model
 	// associated with the API connection.
 	Export() ([]byte, error)
+
+	// UploadBinaries sends the tools, charms and resources used by
+	// the model associated with the API connection to the target
+	// controller of its active migration.
+	UploadBinaries() error
+
+	// NeedsCleanup reports whether the model associated with the
+	// API connection has cleanups pending.
+	NeedsCleanup() (bool, error)
+
+	// Reap removes the model associated with the API connection
+	// from the controller once it has been migrated.
+	Reap() error
 }
 
 // MigrationStatus returns the details for a migration as needed by
@@ -41,6 +58,10 @@ type MigrationStatus struct {
 	Attempt    int
 	Phase      migration.Phase
 	TargetInfo migration.TargetInfo
+
+	// Offline is true when the model has already been imported into
+	// the target controller from a model archive.
+	Offline bool
 }
 
 // NewClient returns a new Client based on an existing API connection.
@@ -108,6 +129,7 @@ func (c *client) GetMigrationStatus() (MigrationStatus, error) {
 			AuthTag:       authTag,
 			Password:      target.Password,
 		},
+		Offline: status.Spec.Offline,
 	}, nil
 }
 
@@ -119,6 +141,14 @@ func (c *client) SetPhase(phase migration.Phase) error {
 	return c.caller.FacadeCall("SetPhase", args, nil)
 }
 
+// SetStatusMessage implements Client.
+func (c *client) SetStatusMessage(message string) error {
+	args := params.SetMigrationStatusMessageArgs{
+		Message: message,
+	}
+	return c.caller.FacadeCall("SetStatusMessage", args, nil)
+}
+
 // Export implements Client.
 func (c *client) Export() ([]byte, error) {
 	var serialized params.SerializedModel
@@ -128,3 +158,26 @@ func (c *client) Export() ([]byte, error) {
 	}
 	return serialized.Bytes, nil
 }
+
+// UploadBinaries implements Client.
+func (c *client) UploadBinaries() error {
+	return c.caller.FacadeCall("UploadBinaries", nil, nil)
+}
+
+// NeedsCleanup implements Client.
+func (c *client) NeedsCleanup() (bool, error) {
+	var result params.BoolResult
+	err := c.caller.FacadeCall("NeedsCleanup", nil, &result)
+	if err != nil {
+		return false, err
+	}
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.Result, nil
+}
+
+// Reap implements Client.
+func (c *client) Reap() error {
+	return c.caller.FacadeCall("Reap", nil, nil)
+}
@@ -4,10 +4,12 @@
 package migrationtarget
 
 import (
+	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/storage"
 )
 
 // Client describes the client side API for the MigrationTarget
@@ -18,6 +20,22 @@ type Client interface {
 	// controller.
 	Import([]byte) error
 
+	// CheckImport returns the reasons, if any, that a serialized
+	// model can't be imported into the target controller. Nothing is
+	// imported.
+	CheckImport([]byte) ([]string, error)
+
+	// CheckImported returns the reasons, if any, that a model
+	// imported into the target controller from a model archive can't
+	// take over from the source model in an offline migration: it
+	// must be active, and hold all the machines and units given.
+	CheckImported(modelUUID string, machineIds, unitNames []string) ([]string, error)
+
+	// MissingVolumes returns the provider volume IDs, of those
+	// given, that can't be seen by the target controller's cloud
+	// using the storage pool.
+	MissingVolumes(pool *storage.Config, volumeIDs []string) ([]string, error)
+
 	// Abort removes all data relating to a previously imported
 	// model.
 	Abort(string) error
@@ -42,6 +60,61 @@ func (c *client) Import(bytes []byte) error {
 	return c.caller.FacadeCall("Import", serialized, nil)
 }
 
+// CheckImport implements Client.
+func (c *client) CheckImport(bytes []byte) ([]string, error) {
+	serialized := params.SerializedModel{Bytes: bytes}
+	var result params.MigrationImportCheckResult
+	if err := c.caller.FacadeCall("CheckImport", serialized, &result); err != nil {
+		return nil, err
+	}
+	return result.Blockers, nil
+}
+
+// CheckImported implements Client.
+func (c *client) CheckImported(modelUUID string, machineIds, unitNames []string) ([]string, error) {
+	args := params.MigrationImportedCheck{
+		ModelTag: names.NewModelTag(modelUUID).String(),
+		Machines: make([]string, len(machineIds)),
+		Units:    make([]string, len(unitNames)),
+	}
+	for i, id := range machineIds {
+		args.Machines[i] = names.NewMachineTag(id).String()
+	}
+	for i, name := range unitNames {
+		args.Units[i] = names.NewUnitTag(name).String()
+	}
+	var result params.MigrationImportCheckResult
+	if err := c.caller.FacadeCall("CheckImported", args, &result); err != nil {
+		return nil, err
+	}
+	return result.Blockers, nil
+}
+
+// MissingVolumes implements Client.
+func (c *client) MissingVolumes(pool *storage.Config, volumeIDs []string) ([]string, error) {
+	args := params.MigrationVolumeChecks{
+		Checks: []params.MigrationVolumeCheck{{
+			Pool: params.StoragePool{
+				Name:     pool.Name(),
+				Provider: string(pool.Provider()),
+				Attrs:    pool.Attrs(),
+			},
+			VolumeIds: volumeIDs,
+		}},
+	}
+	var results params.StringsResults
+	if err := c.caller.FacadeCall("MissingVolumes", args, &results); err != nil {
+		return nil, err
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, err
+	}
+	return results.Results[0].Result, nil
+}
+
 // Abort implements Client.
 func (c *client) Abort(modelUUID string) error {
 	args := params.ModelArgs{ModelTag: names.NewModelTag(modelUUID).String()}
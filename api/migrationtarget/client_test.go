@@ -6,12 +6,14 @@ package migrationtarget_test
 import (
 	"github.com/juju/errors"
 	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
 
 	apitesting "github.com/juju/juju/api/base/testing"
 	"github.com/juju/juju/api/migrationtarget"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/storage"
 )
 
 type ClientSuite struct {
@@ -42,6 +44,108 @@ func (s *ClientSuite) TestImport(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "boom")
 }
 
+func (s *ClientSuite) TestCheckImport(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		stub.AddCall(objType+"."+request, id, arg)
+		*(result.(*params.MigrationImportCheckResult)) = params.MigrationImportCheckResult{
+			Blockers: []string{"bad"},
+		}
+		return nil
+	})
+	client := migrationtarget.NewClient(apiCaller)
+
+	blockers, err := client.CheckImport([]byte("foo"))
+	c.Assert(err, gc.IsNil)
+	c.Check(blockers, gc.DeepEquals, []string{"bad"})
+	expectedArg := params.SerializedModel{Bytes: []byte("foo")}
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationTarget.CheckImport", []interface{}{"", expectedArg}},
+	})
+}
+
+func (s *ClientSuite) TestCheckImportError(c *gc.C) {
+	client, _ := s.getClientAndStub(c)
+	_, err := client.CheckImport([]byte("foo"))
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *ClientSuite) TestCheckImported(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		stub.AddCall(objType+"."+request, id, arg)
+		*(result.(*params.MigrationImportCheckResult)) = params.MigrationImportCheckResult{
+			Blockers: []string{"bad"},
+		}
+		return nil
+	})
+	client := migrationtarget.NewClient(apiCaller)
+
+	blockers, err := client.CheckImported("fake", []string{"0", "0/lxd/1"}, []string{"mysql/0"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(blockers, jc.DeepEquals, []string{"bad"})
+	expectedArg := params.MigrationImportedCheck{
+		ModelTag: names.NewModelTag("fake").String(),
+		Machines: []string{"machine-0", "machine-0-lxd-1"},
+		Units:    []string{"unit-mysql-0"},
+	}
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationTarget.CheckImported", []interface{}{"", expectedArg}},
+	})
+}
+
+func (s *ClientSuite) TestCheckImportedError(c *gc.C) {
+	client, _ := s.getClientAndStub(c)
+	_, err := client.CheckImported("fake", nil, nil)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *ClientSuite) TestMissingVolumes(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		stub.AddCall(objType+"."+request, id, arg)
+		*(result.(*params.StringsResults)) = params.StringsResults{
+			Results: []params.StringsResult{{Result: []string{"vol-1"}}},
+		}
+		return nil
+	})
+	client := migrationtarget.NewClient(apiCaller)
+	pool, err := storage.NewConfig("fast", "ebs", map[string]interface{}{"volume-type": "ssd"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	missing, err := client.MissingVolumes(pool, []string{"vol-0", "vol-1"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(missing, jc.DeepEquals, []string{"vol-1"})
+	expectedArg := params.MigrationVolumeChecks{
+		Checks: []params.MigrationVolumeCheck{{
+			Pool: params.StoragePool{
+				Name:     "fast",
+				Provider: "ebs",
+				Attrs:    map[string]interface{}{"volume-type": "ssd"},
+			},
+			VolumeIds: []string{"vol-0", "vol-1"},
+		}},
+	}
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationTarget.MissingVolumes", []interface{}{"", expectedArg}},
+	})
+}
+
+func (s *ClientSuite) TestMissingVolumesResultError(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.StringsResults)) = params.StringsResults{
+			Results: []params.StringsResult{{Error: &params.Error{Message: "boom"}}},
+		}
+		return nil
+	})
+	client := migrationtarget.NewClient(apiCaller)
+	pool, err := storage.NewConfig("loop", "loop", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = client.MissingVolumes(pool, []string{"vol-0"})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
 func (s *ClientSuite) TestAbort(c *gc.C) {
 	client, stub := s.getClientAndStub(c)
 
@@ -8,7 +8,9 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/base"
+	apiwatcher "github.com/juju/juju/api/watcher"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/watcher"
 )
 
 // Client allows access to the storage API end point.
@@ -89,6 +91,50 @@ func (c *Client) CreatePool(pname, provider string, attrs map[string]interface{}
 	return c.facade.FacadeCall("CreatePool", args, nil)
 }
 
+// UpdatePool replaces the attributes of the named pool.
+func (c *Client) UpdatePool(pname string, attrs map[string]interface{}) error {
+	args := params.StoragePoolArgs{
+		Pools: []params.StoragePool{{
+			Name:  pname,
+			Attrs: attrs,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("UpdatePool", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// RemovePool removes the named pool. If force is true, the pool
+// is removed even if storage in the model still refers to it.
+func (c *Client) RemovePool(pname string, force bool) error {
+	args := params.StoragePoolDeleteArgs{
+		Pools: []params.StoragePoolDeleteArg{{
+			Name:  pname,
+			Force: force,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("RemovePool", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// WatchPools returns a StringsWatcher that notifies of the names
+// of storage pools as they are created, updated or removed.
+func (c *Client) WatchPools() (watcher.StringsWatcher, error) {
+	var result params.StringsWatchResult
+	if err := c.facade.FacadeCall("WatchPools", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	return apiwatcher.NewStringsWatcher(c.facade.RawAPICaller(), result), nil
+}
+
 // ListVolumes lists volumes for desired machines.
 // If no machines provided, a list of all volumes is returned.
 func (c *Client) ListVolumes(machines []string) ([]params.VolumeDetailsListResult, error) {
@@ -288,6 +288,69 @@ func (s *storageMockSuite) TestCreatePoolFacadeCallError(c *gc.C) {
 	c.Assert(errors.Cause(err), gc.ErrorMatches, msg)
 }
 
+func (s *storageMockSuite) TestUpdatePool(c *gc.C) {
+	var called bool
+	poolConfig := map[string]interface{}{"test": "two"}
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			called = true
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "UpdatePool")
+			c.Assert(a, jc.DeepEquals, params.StoragePoolArgs{
+				Pools: []params.StoragePool{{
+					Name:  "poolName",
+					Attrs: poolConfig,
+				}},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{}},
+			}
+			return nil
+		})
+	storageClient := storage.NewClient(apiCaller)
+	err := storageClient.UpdatePool("poolName", poolConfig)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *storageMockSuite) TestRemovePool(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			called = true
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "RemovePool")
+			c.Assert(a, jc.DeepEquals, params.StoragePoolDeleteArgs{
+				Pools: []params.StoragePoolDeleteArg{{
+					Name:  "poolName",
+					Force: true,
+				}},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{
+					Error: &params.Error{Message: "in use"},
+				}},
+			}
+			return nil
+		})
+	storageClient := storage.NewClient(apiCaller)
+	err := storageClient.RemovePool("poolName", true)
+	c.Assert(err, gc.ErrorMatches, "in use")
+	c.Assert(called, jc.IsTrue)
+}
+
 func (s *storageMockSuite) TestListVolumes(c *gc.C) {
 	var called bool
 	machines := []string{"0", "1"}
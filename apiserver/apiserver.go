@@ -366,6 +366,26 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 			ctxt: strictCtxt,
 		},
 	)
+	add("/model/:modeluuid/migrate/charms",
+		&charmsMigrationUploadHandler{
+			ctxt: strictCtxt,
+		},
+	)
+	add("/model/:modeluuid/migrate/tools",
+		&toolsMigrationUploadHandler{
+			ctxt: strictCtxt,
+		},
+	)
+	add("/model/:modeluuid/migrate/resources",
+		&resourcesMigrationUploadHandler{
+			ctxt: strictCtxt,
+		},
+	)
+	add("/model/:modeluuid/export",
+		&modelExportHandler{
+			ctxt: strictCtxt,
+		},
+	)
 	add("/model/:modeluuid/api", mainAPIHandler)
 
 	endpoints = append(endpoints, guiEndpoints("/gui/:modeluuid/", srv.dataDir, httpCtxt)...)
@@ -38,8 +38,8 @@ func (s *backupsCommonSuite) SetUpTest(c *gc.C) {
 
 	s.fake = &backupstesting.FakeBackups{}
 	s.PatchValue(apiserver.NewBackups,
-		func(st *state.State) (backups.Backups, io.Closer) {
-			return s.fake, ioutil.NopCloser(nil)
+		func(st *state.State) (backups.Backups, io.Closer, error) {
+			return s.fake, ioutil.NopCloser(nil), nil
 		},
 	)
 }
@@ -14,6 +14,7 @@ import (
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/description"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/mongo"
 	"github.com/juju/juju/state"
@@ -33,7 +34,10 @@ type Backend interface {
 	ModelConfig() (*config.Config, error)
 	ControllerConfig() (controller.Config, error)
 	StateServingInfo() (state.StateServingInfo, error)
+	BackupStorageS3SecretKey() (string, error)
 	RestoreInfo() *state.RestoreInfo
+	ForModel(tag names.ModelTag) (*state.State, error)
+	Import(model description.Model) (*state.Model, *state.State, error)
 }
 
 // API serves backup-specific API methods.
@@ -96,9 +100,12 @@ func extractResourceValue(resources *common.Resources, key string) (string, erro
 	return strRes.String(), nil
 }
 
-var newBackups = func(backend Backend) (backups.Backups, io.Closer) {
-	stor := backups.NewStorage(backend)
-	return backups.NewBackups(stor), stor
+var newBackups = func(backend Backend) (backups.Backups, io.Closer, error) {
+	stor, err := backups.NewBackupStorage(backend)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return backups.NewBackups(stor), stor, nil
 }
 
 // ResultFromMetadata updates the result with the information in the
@@ -120,6 +127,9 @@ func ResultFromMetadata(meta *backups.Metadata) params.BackupsMetadataResult {
 		result.Finished = *meta.Finished
 	}
 	result.Notes = meta.Notes
+	result.Schedule = meta.Schedule
+	result.Encryption = meta.Encryption
+	result.ModelUUID = meta.ModelUUID
 
 	result.Model = meta.Origin.Model
 	result.Machine = meta.Origin.Machine
@@ -154,6 +164,9 @@ func MetadataFromResult(result params.BackupsMetadataResult) *backups.Metadata {
 	meta.Origin.Version = result.Version
 	meta.Origin.Series = result.Series
 	meta.Notes = result.Notes
+	meta.Schedule = result.Schedule
+	meta.Encryption = result.Encryption
+	meta.ModelUUID = result.ModelUUID
 	meta.SetFileInfo(result.Size, result.Checksum, result.ChecksumFormat)
 	return meta
 }
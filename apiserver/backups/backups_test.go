@@ -54,15 +54,15 @@ func (s *backupsSuite) setBackups(c *gc.C, meta *backups.Metadata, err string) *
 		fake.Error = errors.Errorf(err)
 	}
 	s.PatchValue(backupsAPI.NewBackups,
-		func(backupsAPI.Backend) (backups.Backups, io.Closer) {
-			return &fake, ioutil.NopCloser(nil)
+		func(backupsAPI.Backend) (backups.Backups, io.Closer, error) {
+			return &fake, ioutil.NopCloser(nil), nil
 		},
 	)
 	return &fake
 }
 
 func (s *backupsSuite) TestRegistered(c *gc.C) {
-	_, err := common.Facades.GetType("Backups", 1)
+	_, err := common.Facades.GetType("Backups", 2)
 	c.Check(err, jc.ErrorIsNil)
 }
 
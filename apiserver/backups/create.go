@@ -6,6 +6,7 @@ package backups
 import (
 	"github.com/juju/errors"
 	"github.com/juju/replicaset"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/state/backups"
@@ -16,9 +17,35 @@ var waitUntilReady = replicaset.WaitUntilReady
 // Create is the API method that requests juju to create a new backup
 // of its state.  It returns the metadata for that backup.
 func (a *API) Create(args params.BackupsCreateArgs) (p params.BackupsMetadataResult, err error) {
-	backupsMethods, closer := newBackups(a.backend)
+	backupsMethods, closer, err := newBackups(a.backend)
+	if err != nil {
+		return p, errors.Trace(err)
+	}
 	defer closer.Close()
 
+	mSeries, err := a.backend.MachineSeries(a.machineID)
+	if err != nil {
+		return p, errors.Trace(err)
+	}
+
+	meta, err := backups.NewMetadataState(a.backend, a.machineID, mSeries)
+	if err != nil {
+		return p, errors.Trace(err)
+	}
+	meta.Notes = args.Notes
+
+	encryption := backups.EncryptArgs{
+		Passphrase: args.Passphrase,
+		PublicKey:  args.PublicKey,
+	}
+	if args.ModelUUID != "" {
+		err = a.createModel(backupsMethods, meta, args.ModelUUID, encryption)
+		if err != nil {
+			return p, errors.Trace(err)
+		}
+		return ResultFromMetadata(meta), nil
+	}
+
 	session := a.backend.MongoSession().Copy()
 	defer session.Close()
 
@@ -33,21 +60,24 @@ func (a *API) Create(args params.BackupsCreateArgs) (p params.BackupsMetadataRes
 	if err != nil {
 		return p, errors.Trace(err)
 	}
-	mSeries, err := a.backend.MachineSeries(a.machineID)
-	if err != nil {
-		return p, errors.Trace(err)
-	}
 
-	meta, err := backups.NewMetadataState(a.backend, a.machineID, mSeries)
+	err = backupsMethods.Create(meta, a.paths, dbInfo, encryption)
 	if err != nil {
 		return p, errors.Trace(err)
 	}
-	meta.Notes = args.Notes
 
-	err = backupsMethods.Create(meta, a.paths, dbInfo)
+	return ResultFromMetadata(meta), nil
+}
+
+// createModel creates a backup of the single model with the given UUID.
+func (a *API) createModel(backupsMethods backups.Backups, meta *backups.Metadata, modelUUID string, encryption backups.EncryptArgs) error {
+	if !names.IsValidModel(modelUUID) {
+		return errors.NotValidf("model UUID %q", modelUUID)
+	}
+	st, err := a.backend.ForModel(names.NewModelTag(modelUUID))
 	if err != nil {
-		return p, errors.Trace(err)
+		return errors.Annotatef(err, "cannot open model %q", modelUUID)
 	}
-
-	return ResultFromMetadata(meta), nil
+	defer st.Close()
+	return errors.Trace(backupsMethods.CreateModel(meta, st, encryption))
 }
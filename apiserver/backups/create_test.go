@@ -10,6 +10,7 @@ import (
 
 	"github.com/juju/juju/apiserver/backups"
 	"github.com/juju/juju/apiserver/params"
+	statebackups "github.com/juju/juju/state/backups"
 )
 
 func (s *backupsSuite) TestCreateOkay(c *gc.C) {
@@ -42,6 +43,48 @@ func (s *backupsSuite) TestCreateNotes(c *gc.C) {
 	c.Check(result, gc.DeepEquals, expected)
 }
 
+func (s *backupsSuite) TestCreateEncrypted(c *gc.C) {
+	s.PatchValue(backups.WaitUntilReady,
+		func(*mgo.Session, int) error { return nil },
+	)
+	s.meta.Encryption = statebackups.PassphraseEncryption
+	fake := s.setBackups(c, s.meta, "")
+	args := params.BackupsCreateArgs{
+		Passphrase: "secret",
+	}
+	result, err := s.api.Create(args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(fake.EncryptionArg, gc.Equals, statebackups.EncryptArgs{Passphrase: "secret"})
+	c.Check(result.Encryption, gc.Equals, statebackups.PassphraseEncryption)
+}
+
+func (s *backupsSuite) TestCreateModel(c *gc.C) {
+	hosted := s.Factory.MakeModel(c, nil)
+	defer hosted.Close()
+	s.meta.ModelUUID = hosted.ModelUUID()
+	fake := s.setBackups(c, s.meta, "")
+	args := params.BackupsCreateArgs{
+		ModelUUID: hosted.ModelUUID(),
+	}
+	result, err := s.api.Create(args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(fake.Calls, jc.DeepEquals, []string{"CreateModel"})
+	c.Assert(fake.ModelBackendArg, gc.NotNil)
+	c.Check(fake.ModelBackendArg.ModelUUID(), gc.Equals, hosted.ModelUUID())
+	c.Check(result.ModelUUID, gc.Equals, hosted.ModelUUID())
+}
+
+func (s *backupsSuite) TestCreateModelInvalidUUID(c *gc.C) {
+	s.setBackups(c, s.meta, "")
+	args := params.BackupsCreateArgs{
+		ModelUUID: "not-a-uuid",
+	}
+	_, err := s.api.Create(args)
+	c.Check(err, gc.ErrorMatches, `model UUID "not-a-uuid" not valid`)
+}
+
 func (s *backupsSuite) TestCreateError(c *gc.C) {
 	s.setBackups(c, nil, "failed!")
 	s.PatchValue(backups.WaitUntilReady,
@@ -10,9 +10,12 @@ import (
 )
 
 func (a *API) Remove(args params.BackupsRemoveArgs) error {
-	backups, closer := newBackups(a.backend)
+	backups, closer, err := newBackups(a.backend)
+	if err != nil {
+		return errors.Trace(err)
+	}
 	defer closer.Close()
 
-	err := backups.Remove(args.ID)
+	err = backups.Remove(args.ID)
 	return errors.Trace(err)
 }
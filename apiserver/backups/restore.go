@@ -24,7 +24,10 @@ func (a *API) Restore(p params.RestoreArgs) error {
 	logger.Infof("Starting server side restore")
 
 	// Get hold of a backup file Reader
-	backup, closer := newBackups(a.backend)
+	backup, closer, err := newBackups(a.backend)
+	if err != nil {
+		return errors.Trace(err)
+	}
 	defer closer.Close()
 
 	// Obtain the address of current machine, where we will be performing restore.
@@ -68,6 +71,10 @@ func (a *API) Restore(p params.RestoreArgs) error {
 		NewInstId:      instanceId,
 		NewInstTag:     machine.Tag(),
 		NewInstSeries:  machine.Series(),
+		Decryption: backups.DecryptArgs{
+			Passphrase: p.Passphrase,
+			PrivateKey: p.PrivateKey,
+		},
 	}
 
 	session := a.backend.MongoSession().Copy()
@@ -152,3 +159,27 @@ func (a *API) FinishRestore() error {
 	logger.Infof("Succesfully restored")
 	return info.SetStatus(state.RestoreChecked)
 }
+
+// RestoreModel implements the server side of Backups.RestoreModel. It
+// re-creates the model captured by a model backup in this controller,
+// which otherwise carries on running undisturbed.
+func (a *API) RestoreModel(p params.RestoreArgs) (params.RestoreModelResult, error) {
+	var result params.RestoreModelResult
+	backup, closer, err := newBackups(a.backend)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	defer closer.Close()
+
+	logger.Infof("restoring model from backup %q", p.BackupId)
+	decryption := backups.DecryptArgs{
+		Passphrase: p.Passphrase,
+		PrivateKey: p.PrivateKey,
+	}
+	tag, err := backup.RestoreModel(p.BackupId, a.backend, decryption)
+	if err != nil {
+		return result, errors.Annotate(err, "restore failed")
+	}
+	result.ModelTag = tag.String()
+	return result, nil
+}
@@ -0,0 +1,36 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	statebackups "github.com/juju/juju/state/backups"
+	"github.com/juju/juju/testing"
+)
+
+func (s *backupsSuite) TestRestoreModel(c *gc.C) {
+	fake := s.setBackups(c, s.meta, "")
+	fake.ModelTag = testing.ModelTag
+	args := params.RestoreArgs{
+		BackupId:   "some-id",
+		Passphrase: "secret",
+	}
+	result, err := s.api.RestoreModel(args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(result.ModelTag, gc.Equals, testing.ModelTag.String())
+	c.Check(fake.Calls, jc.DeepEquals, []string{"RestoreModel"})
+	c.Check(fake.IDArg, gc.Equals, "some-id")
+	c.Check(fake.DecryptionArg, gc.Equals, statebackups.DecryptArgs{Passphrase: "secret"})
+	c.Check(fake.ModelImporterArg, gc.NotNil)
+}
+
+func (s *backupsSuite) TestRestoreModelError(c *gc.C) {
+	s.setBackups(c, nil, "failed!")
+	_, err := s.api.RestoreModel(params.RestoreArgs{BackupId: "some-id"})
+	c.Check(err, gc.ErrorMatches, "restore failed: failed!")
+}
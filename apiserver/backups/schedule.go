@@ -0,0 +1,37 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state/backups"
+)
+
+// ScheduleStatus provides the implementation of the API method.
+func (a *API) ScheduleStatus() (params.BackupsScheduleStatusResult, error) {
+	var result params.BackupsScheduleStatusResult
+	controllerConfig, err := a.backend.ControllerConfig()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Schedule = controllerConfig.BackupSchedule()
+	if result.Schedule == "" {
+		return result, nil
+	}
+
+	status, err := backups.GetScheduleStatus(a.backend)
+	if errors.IsNotFound(err) {
+		// No scheduled backup has been run yet.
+		return result, nil
+	} else if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.LastRun = status.LastRun
+	result.LastBackupID = status.LastBackupID
+	result.LastError = status.LastError
+	result.NextRun = status.NextRun
+	return result, nil
+}
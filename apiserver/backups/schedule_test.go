@@ -0,0 +1,63 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state/backups"
+)
+
+func (s *backupsSuite) TestScheduleStatusNotScheduled(c *gc.C) {
+	result, err := s.api.ScheduleStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.BackupsScheduleStatusResult{})
+}
+
+type scheduleSuite struct {
+	backupsSuite
+}
+
+var _ = gc.Suite(&scheduleSuite{})
+
+func (s *scheduleSuite) SetUpTest(c *gc.C) {
+	s.ControllerConfigAttrs = map[string]interface{}{
+		controller.BackupSchedule: "@daily",
+	}
+	s.backupsSuite.SetUpTest(c)
+}
+
+func (s *scheduleSuite) TestScheduleStatusNotRun(c *gc.C) {
+	result, err := s.api.ScheduleStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.BackupsScheduleStatusResult{
+		Schedule: "@daily",
+	})
+}
+
+func (s *scheduleSuite) TestScheduleStatus(c *gc.C) {
+	lastRun := time.Date(2016, 6, 1, 0, 0, 0, 0, time.UTC)
+	nextRun := lastRun.Add(24 * time.Hour)
+	err := backups.SetScheduleStatus(s.State, backups.ScheduleStatus{
+		Schedule:  "@daily",
+		LastRun:   lastRun,
+		LastError: "boom",
+		NextRun:   nextRun,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.api.ScheduleStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.BackupsScheduleStatusResult{
+		Schedule:  "@daily",
+		LastRun:   lastRun,
+		LastError: "boom",
+		NextRun:   nextRun,
+	})
+}
@@ -15,7 +15,7 @@ import (
 // *trivially* correct, you would be Doing It Wrong.
 
 func init() {
-	common.RegisterStandardFacade("Backups", 1, newAPI)
+	common.RegisterStandardFacade("Backups", 2, newAPI)
 }
 
 type stateShim struct {
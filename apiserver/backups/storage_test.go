@@ -0,0 +1,70 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/filestorage"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state/backups"
+	backupstesting "github.com/juju/juju/state/backups/testing"
+)
+
+type directoryStorageSuite struct {
+	backupsSuite
+	dir string
+}
+
+var _ = gc.Suite(&directoryStorageSuite{})
+
+func (s *directoryStorageSuite) SetUpTest(c *gc.C) {
+	s.dir = c.MkDir()
+	s.ControllerConfigAttrs = map[string]interface{}{
+		controller.BackupStorage:          controller.DirectoryBackupStorage,
+		controller.BackupStorageDirectory: s.dir,
+	}
+	s.backupsSuite.SetUpTest(c)
+}
+
+func (s *directoryStorageSuite) addBackup(c *gc.C, stor filestorage.FileStorage, started time.Time) string {
+	defer stor.Close()
+	meta := backupstesting.NewMetadataStarted()
+	meta.Started = started
+	backupstesting.FinishMetadata(meta)
+	id, err := stor.Add(meta, bytes.NewBufferString("0123456789"))
+	c.Assert(err, jc.ErrorIsNil)
+	return id
+}
+
+func (s *directoryStorageSuite) TestListAcrossStorage(c *gc.C) {
+	controllerID := s.addBackup(c, backups.NewStorage(s.State), time.Date(2016, 6, 1, 0, 0, 0, 0, time.UTC))
+	directoryID := s.addBackup(c, backups.NewDirectoryStorage(s.dir), time.Date(2016, 6, 2, 0, 0, 0, 0, time.UTC))
+
+	result, err := s.api.List(params.BackupsListArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+	var ids []string
+	for _, item := range result.List {
+		ids = append(ids, item.ID)
+	}
+	c.Check(ids, jc.SameContents, []string{controllerID, directoryID})
+}
+
+func (s *directoryStorageSuite) TestRemoveFromDirectory(c *gc.C) {
+	id := s.addBackup(c, backups.NewDirectoryStorage(s.dir), time.Date(2016, 6, 2, 0, 0, 0, 0, time.UTC))
+
+	err := s.api.Remove(params.BackupsRemoveArgs{ID: id})
+	c.Assert(err, jc.ErrorIsNil)
+
+	stor := backups.NewDirectoryStorage(s.dir)
+	defer stor.Close()
+	_, err = stor.Metadata(id)
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
@@ -0,0 +1,41 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state/backups"
+)
+
+// Verify provides the implementation of the API method. It checks that
+// the stored backup is usable, without restoring it.
+func (a *API) Verify(args params.BackupsVerifyArgs) (params.BackupsVerifyResult, error) {
+	backupsMethods, closer, err := newBackups(a.backend)
+	if err != nil {
+		return params.BackupsVerifyResult{}, errors.Trace(err)
+	}
+	defer closer.Close()
+
+	decryption := backups.DecryptArgs{
+		Passphrase: args.Passphrase,
+		PrivateKey: args.PrivateKey,
+	}
+	result, err := backupsMethods.Verify(args.ID, decryption)
+	if err != nil {
+		return params.BackupsVerifyResult{}, errors.Trace(err)
+	}
+	return VerifyResult(result), nil
+}
+
+// VerifyResult returns the API result describing a verified backup.
+func VerifyResult(result *backups.Verification) params.BackupsVerifyResult {
+	return params.BackupsVerifyResult{
+		Version:     result.Version,
+		ModelCount:  result.ModelCount,
+		Collections: result.Collections,
+		Documents:   result.Documents,
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	statebackups "github.com/juju/juju/state/backups"
+)
+
+func (s *backupsSuite) TestVerify(c *gc.C) {
+	fake := s.setBackups(c, s.meta, "")
+	fake.Verification = &statebackups.Verification{
+		Version:     version.MustParse("2.0.0"),
+		ModelCount:  2,
+		Collections: 40,
+		Documents:   1000,
+	}
+	args := params.BackupsVerifyArgs{
+		ID:         "some-id",
+		Passphrase: "secret",
+	}
+	result, err := s.api.Verify(args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(result, jc.DeepEquals, params.BackupsVerifyResult{
+		Version:     version.MustParse("2.0.0"),
+		ModelCount:  2,
+		Collections: 40,
+		Documents:   1000,
+	})
+	c.Check(fake.Calls, jc.DeepEquals, []string{"Verify"})
+	c.Check(fake.IDArg, gc.Equals, "some-id")
+	c.Check(fake.DecryptionArg, gc.Equals, statebackups.DecryptArgs{Passphrase: "secret"})
+}
+
+func (s *backupsSuite) TestVerifyError(c *gc.C) {
+	s.setBackups(c, nil, "failed!")
+	_, err := s.api.Verify(params.BackupsVerifyArgs{ID: "some-id"})
+	c.Check(err, gc.ErrorMatches, "failed!")
+}
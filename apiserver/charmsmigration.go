@@ -0,0 +1,105 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/storage"
+)
+
+// charmsMigrationUploadHandler handles the upload of charm archives
+// for a model that is being migrated to this controller.
+type charmsMigrationUploadHandler struct {
+	ctxt httpContext
+}
+
+func (h *charmsMigrationUploadHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case "POST":
+		curl, err := h.processPost(r)
+		if err != nil {
+			sendError(w, err)
+			return
+		}
+		sendStatusAndJSON(w, http.StatusOK, &params.CharmsResponse{
+			CharmURL: curl.String(),
+		})
+	default:
+		sendError(w, errors.MethodNotAllowedf("unsupported method: %q", r.Method))
+	}
+}
+
+// processPost stores the uploaded charm archive in the importing
+// model under the charm URL it had in the exported model.
+func (h *charmsMigrationUploadHandler) processPost(r *http.Request) (*charm.URL, error) {
+	st, err := stateForMigrationUpload(h.ctxt, r)
+	if err != nil {
+		return nil, err
+	}
+	defer st.Close()
+
+	curl, err := charm.ParseURL(r.URL.Query().Get("url"))
+	if err != nil {
+		return nil, errors.NewBadRequest(err, "invalid charm URL")
+	}
+	contentType := r.Header.Get("Content-Type")
+	if contentType != "application/zip" {
+		return nil, errors.BadRequestf("expected Content-Type: application/zip, got: %v", contentType)
+	}
+
+	tempFile, err := ioutil.TempFile("", "charm")
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot create temp file")
+	}
+	defer tempFile.Close()
+	defer os.Remove(tempFile.Name())
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(tempFile, hash), r.Body)
+	if err != nil {
+		return nil, errors.Annotate(err, "error processing file upload")
+	}
+	archive, err := charm.ReadCharmArchive(tempFile.Name())
+	if err != nil {
+		return nil, errors.NewBadRequest(err, "invalid charm archive")
+	}
+	if _, err := tempFile.Seek(0, os.SEEK_SET); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	uuid, err := utils.NewUUID()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	storagePath := fmt.Sprintf("charms/%s-%s", curl.String(), uuid)
+	charmStorage := storage.NewStorage(st.ModelUUID(), st.MongoSession())
+	if err := charmStorage.Put(storagePath, tempFile, size); err != nil {
+		return nil, errors.Annotate(err, "cannot add charm to storage")
+	}
+	_, err = st.AddCharm(state.CharmInfo{
+		Charm:       archive,
+		ID:          curl,
+		StoragePath: storagePath,
+		SHA256:      hex.EncodeToString(hash.Sum(nil)),
+	})
+	if err != nil {
+		if err := charmStorage.Remove(storagePath); err != nil {
+			logger.Errorf("cannot remove unrecorded charm archive from storage: %v", err)
+		}
+		return nil, errors.Trace(err)
+	}
+	return curl, nil
+}
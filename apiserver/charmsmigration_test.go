@@ -0,0 +1,121 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testcharms"
+	"github.com/juju/juju/testing/factory"
+)
+
+type charmsMigrationSuite struct {
+	authHttpSuite
+	importing *state.State
+}
+
+var _ = gc.Suite(&charmsMigrationSuite{})
+
+func (s *charmsMigrationSuite) SetUpTest(c *gc.C) {
+	s.authHttpSuite.SetUpTest(c)
+	s.userTag = s.AdminUserTag(c)
+	s.password = "dummy-secret"
+
+	s.importing = s.Factory.MakeModel(c, nil)
+	s.AddCleanup(func(*gc.C) { s.importing.Close() })
+	model, err := s.importing.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	err = model.SetMigrationMode(state.MigrationModeImporting)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *charmsMigrationSuite) charmsURI(c *gc.C, model, curl string) string {
+	uri := s.baseURL(c)
+	uri.Path = fmt.Sprintf("/model/%s/migrate/charms", s.State.ModelUUID())
+	uri.RawQuery = url.Values{"model": {model}, "url": {curl}}.Encode()
+	return uri.String()
+}
+
+func (s *charmsMigrationSuite) assertErrorResponse(c *gc.C, resp *http.Response, expCode int, expError string) {
+	body := assertResponse(c, resp, expCode, params.ContentTypeJSON)
+	var result params.ErrorResult
+	err := json.Unmarshal(body, &result)
+	c.Assert(err, jc.ErrorIsNil, gc.Commentf("body: %s", body))
+	c.Assert(result.Error, gc.NotNil)
+	c.Assert(result.Error.Message, gc.Matches, expError)
+}
+
+func (s *charmsMigrationSuite) TestRequiresAuth(c *gc.C) {
+	resp := s.sendRequest(c, httpRequestParams{method: "POST", url: s.charmsURI(c, "", "")})
+	s.assertErrorResponse(c, resp, http.StatusUnauthorized, "no credentials provided")
+}
+
+func (s *charmsMigrationSuite) TestRequiresControllerAdmin(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{Password: "hunter2"})
+	resp := s.sendRequest(c, httpRequestParams{
+		tag:      user.Tag().String(),
+		password: "hunter2",
+		method:   "POST",
+		url:      s.charmsURI(c, s.importing.ModelUUID(), "cs:quantal/dummy-1"),
+	})
+	s.assertErrorResponse(c, resp, http.StatusUnauthorized, "permission denied")
+}
+
+func (s *charmsMigrationSuite) TestRequiresPOST(c *gc.C) {
+	resp := s.authRequest(c, httpRequestParams{method: "PUT", url: s.charmsURI(c, "", "")})
+	s.assertErrorResponse(c, resp, http.StatusMethodNotAllowed, `unsupported method: "PUT"`)
+}
+
+func (s *charmsMigrationSuite) TestRequiresImportingModel(c *gc.C) {
+	resp := s.authRequest(c, httpRequestParams{
+		method: "POST",
+		url:    s.charmsURI(c, s.State.ModelUUID(), "cs:quantal/dummy-1"),
+	})
+	s.assertErrorResponse(c, resp, http.StatusBadRequest, `model ".*" is not being imported`)
+}
+
+func (s *charmsMigrationSuite) TestRequiresZip(c *gc.C) {
+	ch := testcharms.Repo.CharmArchive(c.MkDir(), "dummy")
+	resp := s.uploadRequest(c, s.charmsURI(c, s.importing.ModelUUID(), "cs:quantal/dummy-1"), "application/octet-stream", ch.Path)
+	s.assertErrorResponse(c, resp, http.StatusBadRequest, "expected Content-Type: application/zip, got: application/octet-stream")
+}
+
+func (s *charmsMigrationSuite) TestUpload(c *gc.C) {
+	ch := testcharms.Repo.CharmArchive(c.MkDir(), "dummy")
+	f, err := os.Open(ch.Path)
+	c.Assert(err, jc.ErrorIsNil)
+	defer f.Close()
+	resp := s.authRequest(c, httpRequestParams{
+		method:      "POST",
+		url:         s.charmsURI(c, s.importing.ModelUUID(), "cs:quantal/dummy-1"),
+		contentType: "application/zip",
+		body:        f,
+	})
+	body := assertResponse(c, resp, http.StatusOK, params.ContentTypeJSON)
+	var result params.CharmsResponse
+	err = json.Unmarshal(body, &result)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.CharmURL, gc.Equals, "cs:quantal/dummy-1")
+
+	// The charm is added to the importing model, keeping the URL it
+	// had in the exported model, and not to the controller model.
+	curl := charm.MustParseURL("cs:quantal/dummy-1")
+	sch, err := s.importing.Charm(curl)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(sch.IsUploaded(), jc.IsTrue)
+	c.Check(sch.BundleSha256(), gc.Not(gc.Equals), "")
+	_, err = s.State.Charm(curl)
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
@@ -82,6 +82,20 @@ func (c *Client) WatchAll() (params.AllWatcherId, error) {
 	}, nil
 }
 
+// WatchMigrationStatus starts watching the status of the latest
+// migration of the model. An initial event is fired if there has ever
+// been a migration attempt for the model. The MigrationStatusWatcher
+// facade must be used to receive events from the watcher.
+func (c *Client) WatchMigrationStatus() (params.NotifyWatchResult, error) {
+	w, err := c.api.stateAccessor.WatchMigrationStatus()
+	if err != nil {
+		return params.NotifyWatchResult{}, errors.Trace(err)
+	}
+	return params.NotifyWatchResult{
+		NotifyWatcherId: c.api.resources.Register(w),
+	}, nil
+}
+
 // Resolved implements the server side of Client.Resolved.
 func (c *Client) Resolved(p params.Resolved) error {
 	if err := c.check.ChangeAllowed(); err != nil {
@@ -25,6 +25,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/core/migration"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/manual"
@@ -582,6 +583,20 @@ func (s *clientSuite) TestClientWatchAll(c *gc.C) {
 	}
 }
 
+func (s *clientSuite) TestClientWatchMigrationStatus(c *gc.C) {
+	w, err := s.APIState.Client().WatchMigrationStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	defer worker.Stop(w)
+
+	select {
+	case migStatus, ok := <-w.Changes():
+		c.Assert(ok, jc.IsTrue)
+		c.Check(migStatus.Phase, gc.Equals, migration.NONE)
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for migration status")
+	}
+}
+
 func (s *clientSuite) TestClientSetModelConstraints(c *gc.C) {
 	// Set constraints for the model.
 	cons, err := constraints.Parse("mem=4096", "cpu-cores=2")
@@ -61,6 +61,7 @@ type stateInterface interface {
 	AddModelUser(state.ModelUserSpec) (*state.ModelUser, error)
 	RemoveModelUser(names.UserTag) error
 	Watch() *state.Multiwatcher
+	WatchMigrationStatus() (state.NotifyWatcher, error)
 	AbortCurrentUpgrade() error
 	APIHostPorts() ([][]network.HostPort, error)
 }
@@ -42,6 +42,7 @@ type ModelManagerBackend interface {
 	RemoveModelUser(names.UserTag) error
 	ModelUser(names.UserTag) (*state.ModelUser, error)
 	ModelTag() names.ModelTag
+	LastLogPrune() (*state.LogPrune, error)
 	Close() error
 }
 
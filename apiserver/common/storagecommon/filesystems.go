@@ -88,9 +88,10 @@ func FilesystemToState(v params.Filesystem) (names.FilesystemTag, state.Filesyst
 		return names.FilesystemTag{}, state.FilesystemInfo{}, errors.Trace(err)
 	}
 	return filesystemTag, state.FilesystemInfo{
-		v.Info.Size,
-		"", // pool is set by state
-		v.Info.FilesystemId,
+		Size:         v.Info.Size,
+		Pool:         "", // pool is set by state
+		FilesystemId: v.Info.FilesystemId,
+		Encrypted:    v.Info.Encrypted,
 	}, nil
 }
 
@@ -117,8 +118,9 @@ func FilesystemFromState(f state.Filesystem) (params.Filesystem, error) {
 // FilesystemInfoFromState converts a state.FilesystemInfo to params.FilesystemInfo.
 func FilesystemInfoFromState(info state.FilesystemInfo) params.FilesystemInfo {
 	return params.FilesystemInfo{
-		info.FilesystemId,
-		info.Size,
+		FilesystemId: info.FilesystemId,
+		Size:         info.Size,
+		Encrypted:    info.Encrypted,
 	}
 }
 
@@ -157,8 +159,19 @@ func FilesystemAttachmentFromState(v state.FilesystemAttachment) (params.Filesys
 // to params.FilesystemAttachmentInfo.
 func FilesystemAttachmentInfoFromState(info state.FilesystemAttachmentInfo) params.FilesystemAttachmentInfo {
 	return params.FilesystemAttachmentInfo{
-		info.MountPoint,
-		info.ReadOnly,
+		MountPoint: info.MountPoint,
+		ReadOnly:   info.ReadOnly,
+	}
+}
+
+// FilesystemUsageFromState converts a state.FilesystemUsage to
+// params.FilesystemUsage.
+func FilesystemUsageFromState(usage state.FilesystemUsage) params.FilesystemUsage {
+	return params.FilesystemUsage{
+		UsedBytes:  usage.UsedBytes,
+		FreeBytes:  usage.FreeBytes,
+		UsedInodes: usage.UsedInodes,
+		FreeInodes: usage.FreeInodes,
 	}
 }
 
@@ -114,11 +114,12 @@ func VolumeToState(v params.Volume) (names.VolumeTag, state.VolumeInfo, error) {
 		return names.VolumeTag{}, state.VolumeInfo{}, errors.Trace(err)
 	}
 	return volumeTag, state.VolumeInfo{
-		v.Info.HardwareId,
-		v.Info.Size,
-		"", // pool is set by state
-		v.Info.VolumeId,
-		v.Info.Persistent,
+		HardwareId: v.Info.HardwareId,
+		Size:       v.Info.Size,
+		Pool:       "", // pool is set by state
+		VolumeId:   v.Info.VolumeId,
+		Persistent: v.Info.Persistent,
+		Encrypted:  v.Info.Encrypted,
 	}, nil
 }
 
@@ -137,10 +138,11 @@ func VolumeFromState(v state.Volume) (params.Volume, error) {
 // VolumeInfoFromState converts a state.VolumeInfo to params.VolumeInfo.
 func VolumeInfoFromState(info state.VolumeInfo) params.VolumeInfo {
 	return params.VolumeInfo{
-		info.VolumeId,
-		info.HardwareId,
-		info.Size,
-		info.Persistent,
+		VolumeId:   info.VolumeId,
+		HardwareId: info.HardwareId,
+		Size:       info.Size,
+		Persistent: info.Persistent,
+		Encrypted:  info.Encrypted,
 	}
 }
 
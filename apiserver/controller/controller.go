@@ -6,23 +6,43 @@
 package controller
 
 import (
+	"fmt"
 	"sort"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"github.com/juju/utils"
 	"github.com/juju/utils/set"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/migrationtarget"
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
-	"github.com/juju/juju/core/migration"
+	"github.com/juju/juju/core/description"
+	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/migration"
 	"github.com/juju/juju/state"
 )
 
 var logger = loggo.GetLogger("juju.apiserver.controller")
 
+// openTargetAPI opens a connection to the controller that a model is
+// being migrated to. It is a variable so it can be replaced in tests.
+var openTargetAPI = func(targetInfo coremigration.TargetInfo) (api.Connection, error) {
+	apiInfo := &api.Info{
+		Addrs:    targetInfo.Addrs,
+		CACert:   targetInfo.CACert,
+		Tag:      targetInfo.AuthTag,
+		Password: targetInfo.Password,
+	}
+	// Use zero DialOpts (no retries) so that an unreachable target
+	// doesn't hold up the API request.
+	return api.Open(apiInfo, api.DialOpts{})
+}
+
 func init() {
-	common.RegisterStandardFacade("Controller", 3, NewControllerAPI)
+	common.RegisterStandardFacade("Controller", 4, NewControllerAPI)
 }
 
 // Controller defines the methods on the controller API end point.
@@ -36,6 +56,11 @@ type Controller interface {
 	WatchAllModels() (params.AllWatcherId, error)
 	ModelStatus(req params.Entities) (params.ModelStatusResults, error)
 	InitiateModelMigration(params.InitiateModelMigrationArgs) (params.InitiateModelMigrationResults, error)
+	MigrationDryRun(params.InitiateModelMigrationArgs) (params.MigrationDryRunResults, error)
+	MigrationProgress(params.Entities) (params.MigrationProgressResults, error)
+	ResumeModelMigration(params.Entities) (params.ErrorResults, error)
+	CleanupModelMigration(params.Entities) (params.ErrorResults, error)
+	CloneModel(params.CloneModelArgs) (params.CloneModelResults, error)
 }
 
 // ControllerAPI implements the environment manager interface and is
@@ -307,26 +332,14 @@ func (c *ControllerAPI) initiateOneModelMigration(spec params.ModelMigrationSpec
 	defer hostedState.Close()
 
 	// Start the migration.
-	targetInfo := spec.TargetInfo
-
-	controllerTag, err := names.ParseModelTag(targetInfo.ControllerTag)
+	targetInfo, err := targetInfoFromParams(spec.TargetInfo)
 	if err != nil {
-		return "", errors.Annotate(err, "controller tag")
-	}
-	authTag, err := names.ParseUserTag(targetInfo.AuthTag)
-	if err != nil {
-		return "", errors.Annotate(err, "auth tag")
+		return "", errors.Trace(err)
 	}
-
 	args := state.ModelMigrationSpec{
 		InitiatedBy: c.apiUser,
-		TargetInfo: migration.TargetInfo{
-			ControllerTag: controllerTag,
-			Addrs:         targetInfo.Addrs,
-			CACert:        targetInfo.CACert,
-			AuthTag:       authTag,
-			Password:      targetInfo.Password,
-		},
+		TargetInfo:  targetInfo,
+		Offline:     spec.Offline,
 	}
 	mig, err := hostedState.CreateModelMigration(args)
 	if err != nil {
@@ -335,6 +348,309 @@ func (c *ControllerAPI) initiateOneModelMigration(spec params.ModelMigrationSpec
 	return mig.Id(), nil
 }
 
+// MigrationDryRun runs the checks of a model migration for one or more
+// models without starting a migration, reporting anything that would
+// block each migration. Neither this controller nor the target
+// controller is changed.
+func (c *ControllerAPI) MigrationDryRun(reqArgs params.InitiateModelMigrationArgs) (
+	params.MigrationDryRunResults, error,
+) {
+	out := params.MigrationDryRunResults{
+		Results: make([]params.MigrationDryRunResult, len(reqArgs.Specs)),
+	}
+	for i, spec := range reqArgs.Specs {
+		result := &out.Results[i]
+		result.ModelTag = spec.ModelTag
+		blockers, err := c.dryRunOneModelMigration(spec)
+		if err != nil {
+			result.Error = common.ServerError(err)
+		} else {
+			result.Blockers = blockers
+		}
+	}
+	return out, nil
+}
+
+func (c *ControllerAPI) dryRunOneModelMigration(spec params.ModelMigrationSpec) ([]string, error) {
+	modelTag, err := names.ParseModelTag(spec.ModelTag)
+	if err != nil {
+		return nil, errors.Annotate(err, "model tag")
+	}
+	if _, err := c.state.GetModel(modelTag); err != nil {
+		return nil, errors.Annotate(err, "unable to read model")
+	}
+	hostedState, err := c.state.ForModel(modelTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer hostedState.Close()
+
+	targetInfo, err := targetInfoFromParams(spec.TargetInfo)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := targetInfo.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var blockers []string
+	if hostedState.IsController() {
+		blockers = append(blockers, "controllers can't be migrated")
+	}
+	if active, err := hostedState.IsModelMigrationActive(); err != nil {
+		return nil, errors.Trace(err)
+	} else if active {
+		blockers = append(blockers, "migration already in progress")
+	}
+
+	conn, err := openTargetAPI(targetInfo)
+	if err != nil {
+		// An unreachable target controller blocks the migration
+		// just as surely as anything it would report.
+		return append(blockers, fmt.Sprintf("cannot connect to target controller: %v", err)), nil
+	}
+	defer conn.Close()
+
+	// The volumes are checked by the target controller, which can
+	// see the cloud that the model is moving to.
+	targetClient := migrationtarget.NewClient(conn)
+	dryRunBlockers, err := migration.DryRun(hostedState, targetClient, targetClient)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return append(blockers, dryRunBlockers...), nil
+}
+
+// MigrationProgress reports the progress of the latest migration
+// attempt for one or more models.
+func (c *ControllerAPI) MigrationProgress(args params.Entities) (params.MigrationProgressResults, error) {
+	out := params.MigrationProgressResults{
+		Results: make([]params.MigrationProgressResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		result := &out.Results[i]
+		result.ModelTag = entity.Tag
+		progress, err := c.oneMigrationProgress(entity.Tag)
+		if err != nil {
+			result.Error = common.ServerError(err)
+		} else {
+			result.Progress = progress
+		}
+	}
+	return out, nil
+}
+
+func (c *ControllerAPI) oneMigrationProgress(tag string) (*params.MigrationProgress, error) {
+	modelTag, err := names.ParseModelTag(tag)
+	if err != nil {
+		return nil, errors.Annotate(err, "model tag")
+	}
+	if _, err := c.state.GetModel(modelTag); err != nil {
+		return nil, errors.Annotate(err, "unable to read model")
+	}
+	hostedState, err := c.state.ForModel(modelTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer hostedState.Close()
+
+	mig, err := hostedState.GetModelMigration()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	phase, err := mig.Phase()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	reports, err := mig.MinionReports()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	progress := &params.MigrationProgress{
+		MigrationId:        mig.Id(),
+		Phase:              phase.String(),
+		StatusMessage:      mig.StatusMessage(),
+		AbortReason:        mig.AbortReason(),
+		StartTime:          mig.StartTime(),
+		PhaseChangedTime:   mig.PhaseChangedTime(),
+		MinionsSucceeded:   len(reports.Succeeded),
+		MinionsFailed:      len(reports.Failed),
+		CharmBytesUploaded: mig.CharmBytesUploaded(),
+		ToolsBytesUploaded: mig.ToolsBytesUploaded(),
+	}
+	if endTime := mig.EndTime(); !endTime.IsZero() {
+		progress.EndTime = &endTime
+	}
+	for _, tag := range reports.Failed {
+		progress.FailedMinions = append(progress.FailedMinions, tag.String())
+	}
+	// A resumed migration enters some phases again, so the phases
+	// are listed in the order they were last entered. Phases entered
+	// at the same time stay in declaration order.
+	phaseTimes := mig.PhaseTimes()
+	for p := coremigration.QUIESCE; p <= coremigration.ABORTDONE; p++ {
+		if entered, ok := phaseTimes[p]; ok {
+			progress.Phases = append(progress.Phases, params.MigrationPhaseTime{
+				Phase:   p.String(),
+				Entered: entered,
+			})
+		}
+	}
+	sort.Stable(orderedPhaseTimes(progress.Phases))
+	return progress, nil
+}
+
+// ResumeModelMigration resumes migrations which failed to remove
+// their model from this controller. Agents which haven't moved to the
+// target controller are told to move again, and removing the model
+// is reattempted.
+func (c *ControllerAPI) ResumeModelMigration(args params.Entities) (params.ErrorResults, error) {
+	return c.forReapFailedMigrations(args, c.resumeOneModelMigration)
+}
+
+func (c *ControllerAPI) resumeOneModelMigration(st *state.State, mig state.ModelMigration) error {
+	return errors.Trace(mig.SetPhase(coremigration.SUCCESS))
+}
+
+// CleanupModelMigration completes migrations which failed to remove
+// their model from this controller, by removing the model here
+// directly.
+func (c *ControllerAPI) CleanupModelMigration(args params.Entities) (params.ErrorResults, error) {
+	return c.forReapFailedMigrations(args, c.cleanupOneModelMigration)
+}
+
+func (c *ControllerAPI) cleanupOneModelMigration(st *state.State, mig state.ModelMigration) error {
+	model, err := st.Model()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// The model may still be marked as active if its migration
+	// reached SUCCESS before models were marked as exporting.
+	if err := model.SetMigrationMode(state.MigrationModeExporting); err != nil {
+		return errors.Trace(err)
+	}
+	if err := st.RemoveExportingModelDocs(); err != nil {
+		return errors.Annotate(err, "failed to remove model")
+	}
+	return errors.Trace(mig.SetPhase(coremigration.DONE))
+}
+
+// forReapFailedMigrations calls fn with the state and migration of
+// each model in args, returning an error for models whose latest
+// migration isn't waiting in REAPFAILED.
+func (c *ControllerAPI) forReapFailedMigrations(
+	args params.Entities,
+	fn func(*state.State, state.ModelMigration) error,
+) (params.ErrorResults, error) {
+	out := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		if err := c.forReapFailedMigration(entity.Tag, fn); err != nil {
+			out.Results[i].Error = common.ServerError(err)
+		}
+	}
+	return out, nil
+}
+
+func (c *ControllerAPI) forReapFailedMigration(tag string, fn func(*state.State, state.ModelMigration) error) error {
+	modelTag, err := names.ParseModelTag(tag)
+	if err != nil {
+		return errors.Annotate(err, "model tag")
+	}
+	if _, err := c.state.GetModel(modelTag); err != nil {
+		return errors.Annotate(err, "unable to read model")
+	}
+	hostedState, err := c.state.ForModel(modelTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer hostedState.Close()
+
+	mig, err := hostedState.GetModelMigration()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	phase, err := mig.Phase()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if phase != coremigration.REAPFAILED {
+		return errors.Errorf("migration is in the %s phase, not %s", phase, coremigration.REAPFAILED)
+	}
+	return errors.Trace(fn(hostedState, mig))
+}
+
+// CloneModel creates a copy of one or more models in this controller.
+// Each copy has the topology and configuration of its original but
+// provisions machines and storage of its own.
+func (c *ControllerAPI) CloneModel(args params.CloneModelArgs) (params.CloneModelResults, error) {
+	out := params.CloneModelResults{
+		Results: make([]params.CloneModelResult, len(args.Specs)),
+	}
+	for i, spec := range args.Specs {
+		result := &out.Results[i]
+		result.ModelTag = spec.ModelTag
+		cloneTag, err := c.cloneOneModel(spec)
+		if err != nil {
+			result.Error = common.ServerError(err)
+		} else {
+			result.CloneTag = cloneTag.String()
+		}
+	}
+	return out, nil
+}
+
+func (c *ControllerAPI) cloneOneModel(spec params.CloneModelSpec) (names.ModelTag, error) {
+	modelTag, err := names.ParseModelTag(spec.ModelTag)
+	if err != nil {
+		return names.ModelTag{}, errors.Annotate(err, "model tag")
+	}
+	if _, err := c.state.GetModel(modelTag); err != nil {
+		return names.ModelTag{}, errors.Annotate(err, "unable to read model")
+	}
+	hostedState, err := c.state.ForModel(modelTag)
+	if err != nil {
+		return names.ModelTag{}, errors.Trace(err)
+	}
+	defer hostedState.Close()
+	if hostedState.IsController() {
+		return names.ModelTag{}, errors.New("controller models can't be cloned")
+	}
+
+	uuid, err := utils.NewUUID()
+	if err != nil {
+		return names.ModelTag{}, errors.Trace(err)
+	}
+	model, st, err := migration.CloneModel(hostedState, description.CloneArgs{
+		UUID: uuid.String(),
+		Name: spec.Name,
+	})
+	if err != nil {
+		return names.ModelTag{}, errors.Trace(err)
+	}
+	defer st.Close()
+	return model.ModelTag(), nil
+}
+
+func targetInfoFromParams(info params.ModelMigrationTargetInfo) (coremigration.TargetInfo, error) {
+	controllerTag, err := names.ParseModelTag(info.ControllerTag)
+	if err != nil {
+		return coremigration.TargetInfo{}, errors.Annotate(err, "controller tag")
+	}
+	authTag, err := names.ParseUserTag(info.AuthTag)
+	if err != nil {
+		return coremigration.TargetInfo{}, errors.Annotate(err, "auth tag")
+	}
+	return coremigration.TargetInfo{
+		ControllerTag: controllerTag,
+		Addrs:         info.Addrs,
+		CACert:        info.CACert,
+		AuthTag:       authTag,
+		Password:      info.Password,
+	}, nil
+}
+
 func (c *ControllerAPI) environStatus(tag string) (params.ModelStatus, error) {
 	var status params.ModelStatus
 	modelTag, err := names.ParseModelTag(tag)
@@ -385,6 +701,20 @@ func (o orderedBlockInfo) Swap(i, j int) {
 	o[i], o[j] = o[j], o[i]
 }
 
+type orderedPhaseTimes []params.MigrationPhaseTime
+
+func (o orderedPhaseTimes) Len() int {
+	return len(o)
+}
+
+func (o orderedPhaseTimes) Less(i, j int) bool {
+	return o[i].Entered.Before(o[j].Entered)
+}
+
+func (o orderedPhaseTimes) Swap(i, j int) {
+	o[i], o[j] = o[j], o[i]
+}
+
 type orderedUserModels []params.UserModel
 
 func (o orderedUserModels) Len() int {
@@ -4,19 +4,23 @@
 package controller_test
 
 import (
+	"fmt"
 	"time"
 
+	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/api"
 	"github.com/juju/juju/apiserver"
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/controller"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	coremigration "github.com/juju/juju/core/migration"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/multiwatcher"
@@ -324,6 +328,7 @@ func (s *controllerSuite) TestInitiateModelMigration(c *gc.C) {
 					AuthTag:       names.NewUserTag("admin2").String(),
 					Password:      "secret2",
 				},
+				Offline: true,
 			},
 		},
 	}
@@ -347,6 +352,7 @@ func (s *controllerSuite) TestInitiateModelMigration(c *gc.C) {
 		c.Check(mig.Id(), gc.Equals, expectedId)
 		c.Check(mig.ModelUUID(), gc.Equals, st.ModelUUID())
 		c.Check(mig.InitiatedBy(), gc.Equals, s.AdminUserTag(c).Id())
+		c.Check(mig.Offline(), gc.Equals, spec.Offline)
 		targetInfo, err := mig.TargetInfo()
 		c.Assert(err, jc.ErrorIsNil)
 		c.Check(targetInfo.ControllerTag.String(), gc.Equals, spec.TargetInfo.ControllerTag)
@@ -409,7 +415,389 @@ func (s *controllerSuite) TestInitiateModelMigrationPartialFailure(c *gc.C) {
 	c.Check(out.Results[1].Error, gc.ErrorMatches, "unable to read model: .+")
 }
 
+func (s *controllerSuite) dryRunTargetInfo(c *gc.C) params.ModelMigrationTargetInfo {
+	// The controller under test stands in for the target controller.
+	apiInfo := s.APIInfo(c)
+	return params.ModelMigrationTargetInfo{
+		ControllerTag: randomModelTag(),
+		Addrs:         apiInfo.Addrs,
+		CACert:        apiInfo.CACert,
+		AuthTag:       apiInfo.Tag.String(),
+		Password:      apiInfo.Password,
+	}
+}
+
+func (s *controllerSuite) TestMigrationDryRun(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	args := params.InitiateModelMigrationArgs{
+		Specs: []params.ModelMigrationSpec{{
+			ModelTag:   st.ModelTag().String(),
+			TargetInfo: s.dryRunTargetInfo(c),
+		}},
+	}
+	out, err := s.controller.MigrationDryRun(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 1)
+	result := out.Results[0]
+	c.Check(result.ModelTag, gc.Equals, st.ModelTag().String())
+	c.Check(result.Error, gc.IsNil)
+	// As the target is the same controller, the model already exists.
+	c.Check(result.Blockers, jc.DeepEquals, []string{
+		fmt.Sprintf("model %s already exists on target controller", st.ModelUUID()),
+	})
+
+	// No migration was started.
+	_, err = st.GetModelMigration()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *controllerSuite) TestMigrationDryRunControllerModel(c *gc.C) {
+	args := params.InitiateModelMigrationArgs{
+		Specs: []params.ModelMigrationSpec{{
+			ModelTag:   s.State.ModelTag().String(),
+			TargetInfo: s.dryRunTargetInfo(c),
+		}},
+	}
+	out, err := s.controller.MigrationDryRun(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 1)
+	c.Check(out.Results[0].Error, gc.IsNil)
+	c.Assert(len(out.Results[0].Blockers) > 0, jc.IsTrue)
+	c.Check(out.Results[0].Blockers[0], gc.Equals, "controllers can't be migrated")
+}
+
+func (s *controllerSuite) TestMigrationDryRunTargetUnreachable(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	s.PatchValue(controller.OpenTargetAPI, func(coremigration.TargetInfo) (api.Connection, error) {
+		return nil, errors.New("boom")
+	})
+
+	args := params.InitiateModelMigrationArgs{
+		Specs: []params.ModelMigrationSpec{{
+			ModelTag:   st.ModelTag().String(),
+			TargetInfo: s.dryRunTargetInfo(c),
+		}},
+	}
+	out, err := s.controller.MigrationDryRun(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 1)
+	c.Check(out.Results[0].Error, gc.IsNil)
+	c.Check(out.Results[0].Blockers, jc.DeepEquals, []string{"cannot connect to target controller: boom"})
+}
+
+func (s *controllerSuite) TestMigrationDryRunValidationError(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	args := params.InitiateModelMigrationArgs{
+		Specs: []params.ModelMigrationSpec{{
+			ModelTag: st.ModelTag().String(),
+			// TargetInfo missing
+		}},
+	}
+	out, err := s.controller.MigrationDryRun(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 1)
+	c.Check(out.Results[0].Blockers, gc.HasLen, 0)
+	c.Check(out.Results[0].Error, gc.ErrorMatches, "controller tag: .+ is not a valid tag")
+}
+
+func (s *controllerSuite) TestMigrationProgress(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	mig, err := st.CreateModelMigration(state.ModelMigrationSpec{
+		InitiatedBy: s.AdminUserTag(c),
+		TargetInfo: coremigration.TargetInfo{
+			ControllerTag: names.NewModelTag(utils.MustNewUUID().String()),
+			Addrs:         []string{"1.2.3.4:5"},
+			CACert:        "cert",
+			AuthTag:       names.NewUserTag("admin"),
+			Password:      "secret",
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mig.SetPhase(coremigration.READONLY), jc.ErrorIsNil)
+	c.Assert(mig.SetStatusMessage("checking things"), jc.ErrorIsNil)
+	c.Assert(mig.SetUploadProgress(100, 200), jc.ErrorIsNil)
+	c.Assert(mig.SubmitMinionReport(names.NewMachineTag("0"), coremigration.READONLY, true), jc.ErrorIsNil)
+	c.Assert(mig.SubmitMinionReport(names.NewMachineTag("1"), coremigration.READONLY, true), jc.ErrorIsNil)
+	c.Assert(mig.SubmitMinionReport(names.NewUnitTag("foo/0"), coremigration.READONLY, false), jc.ErrorIsNil)
+
+	out, err := s.controller.MigrationProgress(params.Entities{
+		Entities: []params.Entity{{Tag: st.ModelTag().String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 1)
+	result := out.Results[0]
+	c.Check(result.ModelTag, gc.Equals, st.ModelTag().String())
+	c.Assert(result.Error, gc.IsNil)
+
+	phaseTimes := mig.PhaseTimes()
+	c.Check(result.Progress, jc.DeepEquals, &params.MigrationProgress{
+		MigrationId:      mig.Id(),
+		Phase:            "READONLY",
+		StatusMessage:    "checking things",
+		StartTime:        mig.StartTime(),
+		PhaseChangedTime: mig.PhaseChangedTime(),
+		Phases: []params.MigrationPhaseTime{
+			{Phase: "QUIESCE", Entered: phaseTimes[coremigration.QUIESCE]},
+			{Phase: "READONLY", Entered: phaseTimes[coremigration.READONLY]},
+		},
+		MinionsSucceeded:   2,
+		MinionsFailed:      1,
+		FailedMinions:      []string{"unit-foo-0"},
+		CharmBytesUploaded: 100,
+		ToolsBytesUploaded: 200,
+	})
+}
+
+func (s *controllerSuite) TestMigrationProgressAborted(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	mig, err := st.CreateModelMigration(state.ModelMigrationSpec{
+		InitiatedBy: s.AdminUserTag(c),
+		TargetInfo: coremigration.TargetInfo{
+			ControllerTag: names.NewModelTag(utils.MustNewUUID().String()),
+			Addrs:         []string{"1.2.3.4:5"},
+			CACert:        "cert",
+			AuthTag:       names.NewUserTag("admin"),
+			Password:      "secret",
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mig.SetStatusMessage("import failed"), jc.ErrorIsNil)
+	c.Assert(mig.SetPhase(coremigration.ABORT), jc.ErrorIsNil)
+	c.Assert(mig.SetPhase(coremigration.ABORTDONE), jc.ErrorIsNil)
+
+	out, err := s.controller.MigrationProgress(params.Entities{
+		Entities: []params.Entity{{Tag: st.ModelTag().String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 1)
+	progress := out.Results[0].Progress
+	c.Assert(progress, gc.NotNil)
+	c.Check(progress.Phase, gc.Equals, "ABORTDONE")
+	c.Check(progress.AbortReason, gc.Equals, "import failed")
+	c.Assert(progress.EndTime, gc.NotNil)
+	c.Check(*progress.EndTime, gc.Equals, mig.EndTime())
+	c.Assert(progress.Phases, gc.HasLen, 3)
+	c.Check(progress.Phases[2].Phase, gc.Equals, "ABORTDONE")
+}
+
+func (s *controllerSuite) TestMigrationProgressErrors(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	out, err := s.controller.MigrationProgress(params.Entities{
+		Entities: []params.Entity{
+			{Tag: st.ModelTag().String()}, // No migration.
+			{Tag: randomModelTag()},       // No model.
+			{Tag: "machine-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 3)
+	c.Check(out.Results[0].Progress, gc.IsNil)
+	c.Check(out.Results[0].Error, jc.Satisfies, params.IsCodeNotFound)
+	c.Check(out.Results[1].Error, gc.ErrorMatches, "unable to read model: .+")
+	c.Check(out.Results[2].Error, gc.ErrorMatches, `model tag: "machine-0" is not a valid model tag`)
+}
+
+func (s *controllerSuite) TestMigrationProgressResumed(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	mig := s.makeReapFailedMigration(c, st)
+	c.Assert(mig.SetPhase(coremigration.SUCCESS), jc.ErrorIsNil)
+
+	out, err := s.controller.MigrationProgress(params.Entities{
+		Entities: []params.Entity{{Tag: st.ModelTag().String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 1)
+	progress := out.Results[0].Progress
+	c.Assert(progress, gc.NotNil)
+	c.Check(progress.Phase, gc.Equals, "SUCCESS")
+
+	// SUCCESS was entered again after REAPFAILED.
+	var phases []string
+	for _, phase := range progress.Phases {
+		phases = append(phases, phase.Phase)
+	}
+	c.Check(phases, jc.DeepEquals, []string{
+		"QUIESCE", "READONLY", "PRECHECK", "IMPORT", "VALIDATION",
+		"LOGTRANSFER", "REAP", "REAPFAILED", "SUCCESS",
+	})
+}
+
+func (s *controllerSuite) makeReapFailedMigration(c *gc.C, st *state.State) state.ModelMigration {
+	mig, err := st.CreateModelMigration(state.ModelMigrationSpec{
+		InitiatedBy: s.AdminUserTag(c),
+		TargetInfo: coremigration.TargetInfo{
+			ControllerTag: names.NewModelTag(utils.MustNewUUID().String()),
+			Addrs:         []string{"1.2.3.4:5"},
+			CACert:        "cert",
+			AuthTag:       names.NewUserTag("admin"),
+			Password:      "secret",
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	for _, phase := range []coremigration.Phase{
+		coremigration.READONLY,
+		coremigration.PRECHECK,
+		coremigration.IMPORT,
+		coremigration.VALIDATION,
+		coremigration.SUCCESS,
+		coremigration.LOGTRANSFER,
+		coremigration.REAP,
+		coremigration.REAPFAILED,
+	} {
+		c.Assert(mig.SetPhase(phase), jc.ErrorIsNil)
+	}
+	return mig
+}
+
+func (s *controllerSuite) TestResumeModelMigration(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	mig := s.makeReapFailedMigration(c, st)
+
+	out, err := s.controller.ResumeModelMigration(params.Entities{
+		Entities: []params.Entity{{Tag: st.ModelTag().String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 1)
+	c.Assert(out.Results[0].Error, gc.IsNil)
+
+	c.Assert(mig.Refresh(), jc.ErrorIsNil)
+	phase, err := mig.Phase()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(phase, gc.Equals, coremigration.SUCCESS)
+}
+
+func (s *controllerSuite) TestCleanupModelMigration(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	mig := s.makeReapFailedMigration(c, st)
+	// Reconcile a model which wasn't marked as exporting.
+	model, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.SetMigrationMode(state.MigrationModeActive), jc.ErrorIsNil)
+
+	out, err := s.controller.CleanupModelMigration(params.Entities{
+		Entities: []params.Entity{{Tag: st.ModelTag().String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 1)
+	c.Assert(out.Results[0].Error, gc.IsNil)
+
+	_, err = s.State.GetModel(st.ModelTag())
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(mig.Refresh(), jc.ErrorIsNil)
+	phase, err := mig.Phase()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(phase, gc.Equals, coremigration.DONE)
+}
+
+func (s *controllerSuite) TestResumeModelMigrationErrors(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	_, err := st.CreateModelMigration(state.ModelMigrationSpec{
+		InitiatedBy: s.AdminUserTag(c),
+		TargetInfo: coremigration.TargetInfo{
+			ControllerTag: names.NewModelTag(utils.MustNewUUID().String()),
+			Addrs:         []string{"1.2.3.4:5"},
+			CACert:        "cert",
+			AuthTag:       names.NewUserTag("admin"),
+			Password:      "secret",
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	other := s.Factory.MakeModel(c, nil)
+	defer other.Close()
+
+	args := params.Entities{
+		Entities: []params.Entity{
+			{Tag: st.ModelTag().String()},    // Not REAPFAILED.
+			{Tag: other.ModelTag().String()}, // No migration.
+			{Tag: randomModelTag()},          // No model.
+			{Tag: "machine-0"},
+		},
+	}
+	for _, call := range []func(params.Entities) (params.ErrorResults, error){
+		s.controller.ResumeModelMigration,
+		s.controller.CleanupModelMigration,
+	} {
+		out, err := call(args)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(out.Results, gc.HasLen, 4)
+		c.Check(out.Results[0].Error, gc.ErrorMatches, "migration is in the QUIESCE phase, not REAPFAILED")
+		c.Check(out.Results[1].Error, jc.Satisfies, params.IsCodeNotFound)
+		c.Check(out.Results[2].Error, gc.ErrorMatches, "unable to read model: .+")
+		c.Check(out.Results[3].Error, gc.ErrorMatches, `model tag: "machine-0" is not a valid model tag`)
+	}
+
+	// The model is left alone.
+	_, err = s.State.GetModel(st.ModelTag())
+	c.Check(err, jc.ErrorIsNil)
+}
+
 func randomModelTag() string {
 	uuid := utils.MustNewUUID().String()
 	return names.NewModelTag(uuid).String()
 }
+
+func (s *controllerSuite) TestCloneModel(c *gc.C) {
+	st := s.Factory.MakeModel(c, &factory.ModelParams{Name: "production"})
+	defer st.Close()
+	original, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+
+	out, err := s.controller.CloneModel(params.CloneModelArgs{
+		Specs: []params.CloneModelSpec{{
+			ModelTag: st.ModelTag().String(),
+			Name:     "staging",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 1)
+	result := out.Results[0]
+	c.Assert(result.Error, gc.IsNil)
+	c.Check(result.ModelTag, gc.Equals, st.ModelTag().String())
+
+	cloneTag, err := names.ParseModelTag(result.CloneTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cloneTag, gc.Not(gc.Equals), st.ModelTag())
+	clone, err := s.State.GetModel(cloneTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(clone.Name(), gc.Equals, "staging")
+	c.Check(clone.Owner(), gc.Equals, original.Owner())
+	c.Check(clone.MigrationMode(), gc.Equals, state.MigrationModeActive)
+}
+
+func (s *controllerSuite) TestCloneModelErrors(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	out, err := s.controller.CloneModel(params.CloneModelArgs{
+		Specs: []params.CloneModelSpec{
+			{ModelTag: s.State.ModelTag().String(), Name: "staging"},
+			{ModelTag: st.ModelTag().String()},
+			{ModelTag: randomModelTag(), Name: "staging"},
+			{ModelTag: "machine-0", Name: "staging"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 4)
+	c.Check(out.Results[0].Error, gc.ErrorMatches, "controller models can't be cloned")
+	c.Check(out.Results[1].Error, gc.ErrorMatches, "empty model name not valid")
+	c.Check(out.Results[2].Error, gc.ErrorMatches, "unable to read model: .+")
+	c.Check(out.Results[3].Error, gc.ErrorMatches, `model tag: "machine-0" is not a valid model tag`)
+	for _, result := range out.Results {
+		c.Check(result.CloneTag, gc.Equals, "")
+	}
+}
@@ -8,8 +8,10 @@ import (
 	"net"
 	"net/http"
 	"net/url"
+	"regexp"
 	"strconv"
 	"syscall"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -67,6 +69,12 @@ func newDebugLogHandler(
 //   replay -> string - one of [true, false], if true, start the file from the start
 //   noTail -> string - one of [true, false], if true, existing logs are sent back,
 //      - but the command does not wait for new ones.
+//   messagePattern -> string - a regular expression the log messages must match
+//   format -> string - one of [text, json], the format of the log lines sent
+//      - json lines each hold a params.DebugLogRecord
+//   startTime -> string - an RFC3339 time, only lines logged at or after it are sent
+//   endTime -> string - an RFC3339 time, only lines logged at or before it are sent
+//      - tailing stops when the end time is reached
 func (h *debugLogHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	server := websocket.Server{
 		Handler: func(conn *websocket.Conn) {
@@ -141,17 +149,28 @@ func (s *debugLogSocketImpl) sendError(err error) {
 
 // debugLogParams contains the parsed debuglog API request parameters.
 type debugLogParams struct {
-	maxLines      uint
-	fromTheStart  bool
-	noTail        bool
-	backlog       uint
-	filterLevel   loggo.Level
-	includeEntity []string
-	excludeEntity []string
-	includeModule []string
-	excludeModule []string
+	maxLines       uint
+	fromTheStart   bool
+	noTail         bool
+	backlog        uint
+	filterLevel    loggo.Level
+	includeEntity  []string
+	excludeEntity  []string
+	includeModule  []string
+	excludeModule  []string
+	messagePattern string
+	format         string
+	startTime      time.Time
+	endTime        time.Time
 }
 
+const (
+	// debugLogFormatText and debugLogFormatJSON are the formats in
+	// which the debug log may be sent.
+	debugLogFormatText = "text"
+	debugLogFormatJSON = "json"
+)
+
 func readDebugLogParams(queryMap url.Values) (*debugLogParams, error) {
 	params := new(debugLogParams)
 
@@ -197,6 +216,38 @@ func readDebugLogParams(queryMap url.Values) (*debugLogParams, error) {
 		params.filterLevel = level
 	}
 
+	if value := queryMap.Get("messagePattern"); value != "" {
+		if _, err := regexp.Compile(value); err != nil {
+			return nil, errors.Errorf("messagePattern value %q is not a valid regular expression", value)
+		}
+		params.messagePattern = value
+	}
+
+	params.format = debugLogFormatText
+	if value := queryMap.Get("format"); value != "" {
+		if value != debugLogFormatText && value != debugLogFormatJSON {
+			return nil, errors.Errorf("format value %q is not one of %q, %q",
+				value, debugLogFormatText, debugLogFormatJSON)
+		}
+		params.format = value
+	}
+
+	if value := queryMap.Get("startTime"); value != "" {
+		startTime, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return nil, errors.Errorf("startTime value %q is not a valid RFC3339 time", value)
+		}
+		params.startTime = startTime
+	}
+
+	if value := queryMap.Get("endTime"); value != "" {
+		endTime, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return nil, errors.Errorf("endTime value %q is not a valid RFC3339 time", value)
+		}
+		params.endTime = endTime
+	}
+
 	params.includeEntity = queryMap["includeEntity"]
 	params.excludeEntity = queryMap["excludeEntity"]
 	params.includeModule = queryMap["includeModule"]
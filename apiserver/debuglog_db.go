@@ -4,12 +4,14 @@
 package apiserver
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"time"
 
 	"github.com/juju/errors"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/state"
 )
 
@@ -43,8 +45,11 @@ func handleDebugLogDBRequest(
 				return errors.Annotate(tailer.Err(), "tailer stopped")
 			}
 
-			line := formatLogRecord(rec)
-			_, err := socket.Write([]byte(line))
+			line, err := formatLogLine(rec, reqParams.format)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			_, err = socket.Write(line)
 			if err != nil {
 				return errors.Annotate(err, "sending failed")
 			}
@@ -59,13 +64,16 @@ func handleDebugLogDBRequest(
 
 func makeLogTailerParams(reqParams *debugLogParams) *state.LogTailerParams {
 	params := &state.LogTailerParams{
-		MinLevel:      reqParams.filterLevel,
-		NoTail:        reqParams.noTail,
-		InitialLines:  int(reqParams.backlog),
-		IncludeEntity: reqParams.includeEntity,
-		ExcludeEntity: reqParams.excludeEntity,
-		IncludeModule: reqParams.includeModule,
-		ExcludeModule: reqParams.excludeModule,
+		StartTime:      reqParams.startTime,
+		EndTime:        reqParams.endTime,
+		MinLevel:       reqParams.filterLevel,
+		NoTail:         reqParams.noTail,
+		InitialLines:   int(reqParams.backlog),
+		IncludeEntity:  reqParams.includeEntity,
+		ExcludeEntity:  reqParams.excludeEntity,
+		IncludeModule:  reqParams.includeModule,
+		ExcludeModule:  reqParams.excludeModule,
+		MessagePattern: reqParams.messagePattern,
 	}
 	if reqParams.fromTheStart {
 		params.InitialLines = 0
@@ -73,6 +81,14 @@ func makeLogTailerParams(reqParams *debugLogParams) *state.LogTailerParams {
 	return params
 }
 
+// formatLogLine returns the log record as a line in the requested format.
+func formatLogLine(r *state.LogRecord, format string) ([]byte, error) {
+	if format == debugLogFormatJSON {
+		return formatLogRecordJSON(r)
+	}
+	return []byte(formatLogRecord(r)), nil
+}
+
 func formatLogRecord(r *state.LogRecord) string {
 	return fmt.Sprintf("%s: %s %s %s %s %s\n",
 		r.Entity,
@@ -84,6 +100,23 @@ func formatLogRecord(r *state.LogRecord) string {
 	)
 }
 
+// formatLogRecordJSON returns the log record as a line of JSON.
+func formatLogRecordJSON(r *state.LogRecord) ([]byte, error) {
+	line, err := json.Marshal(params.DebugLogRecord{
+		ModelUUID: r.ModelUUID,
+		Entity:    r.Entity.String(),
+		Timestamp: r.Time.In(time.UTC),
+		Level:     r.Level.String(),
+		Module:    r.Module,
+		Location:  r.Location,
+		Message:   r.Message,
+	})
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot marshal log record")
+	}
+	return append(line, '\n'), nil
+}
+
 func formatTime(t time.Time) string {
 	return t.In(time.UTC).Format("2006-01-02 15:04:05")
 }
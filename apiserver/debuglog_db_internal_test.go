@@ -30,23 +30,25 @@ func (s *debugLogDBIntSuite) SetUpTest(c *gc.C) {
 
 func (s *debugLogDBIntSuite) TestParamConversion(c *gc.C) {
 	reqParams := &debugLogParams{
-		fromTheStart:  false,
-		noTail:        true,
-		backlog:       11,
-		filterLevel:   loggo.INFO,
-		includeEntity: []string{"foo"},
-		includeModule: []string{"bar"},
-		excludeEntity: []string{"baz"},
-		excludeModule: []string{"qux"},
+		fromTheStart:   false,
+		noTail:         true,
+		backlog:        11,
+		filterLevel:    loggo.INFO,
+		includeEntity:  []string{"foo"},
+		includeModule:  []string{"bar"},
+		excludeEntity:  []string{"baz"},
+		excludeModule:  []string{"qux"},
+		messagePattern: "^hook",
+		startTime:      time.Date(2016, 10, 12, 22, 0, 0, 0, time.UTC),
+		endTime:        time.Date(2016, 10, 13, 0, 0, 0, 0, time.UTC),
 	}
 
 	called := false
 	s.PatchValue(&newLogTailer, func(_ state.LogTailerState, params *state.LogTailerParams) (state.LogTailer, error) {
 		called = true
 
-		// Start time will be used once the client is extended to send
-		// time range arguments.
-		c.Assert(params.StartTime.IsZero(), jc.IsTrue)
+		c.Assert(params.StartTime, gc.Equals, time.Date(2016, 10, 12, 22, 0, 0, 0, time.UTC))
+		c.Assert(params.EndTime, gc.Equals, time.Date(2016, 10, 13, 0, 0, 0, 0, time.UTC))
 		c.Assert(params.NoTail, jc.IsTrue)
 		c.Assert(params.MinLevel, gc.Equals, loggo.INFO)
 		c.Assert(params.InitialLines, gc.Equals, 11)
@@ -54,6 +56,7 @@ func (s *debugLogDBIntSuite) TestParamConversion(c *gc.C) {
 		c.Assert(params.IncludeModule, jc.DeepEquals, []string{"bar"})
 		c.Assert(params.ExcludeEntity, jc.DeepEquals, []string{"baz"})
 		c.Assert(params.ExcludeModule, jc.DeepEquals, []string{"qux"})
+		c.Assert(params.MessagePattern, gc.Equals, "^hook")
 
 		return newFakeLogTailer(), nil
 	})
@@ -125,6 +128,35 @@ func (s *debugLogDBIntSuite) TestFullRequest(c *gc.C) {
 	s.assertStops(c, done, tailer)
 }
 
+func (s *debugLogDBIntSuite) TestFullRequestJSON(c *gc.C) {
+	tailer := newFakeLogTailer()
+	tailer.logsCh <- &state.LogRecord{
+		Time:      time.Date(2015, 6, 19, 15, 34, 37, 0, time.UTC),
+		ModelUUID: "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		Entity:    names.NewMachineTag("99"),
+		Module:    "some.where",
+		Location:  "code.go:42",
+		Level:     loggo.INFO,
+		Message:   "stuff \"happened\"",
+	}
+	s.PatchValue(&newLogTailer, func(_ state.LogTailerState, params *state.LogTailerParams) (state.LogTailer, error) {
+		return tailer, nil
+	})
+
+	stop := make(chan struct{})
+	done := s.runRequest(&debugLogParams{format: debugLogFormatJSON}, stop)
+
+	s.assertOutput(c, []string{
+		"ok",
+		`{"model-uuid":"deadbeef-0bad-400d-8000-4b1d0d06f00d","entity":"machine-99",` +
+			`"timestamp":"2015-06-19T15:34:37Z","level":"INFO","module":"some.where",` +
+			`"location":"code.go:42","message":"stuff \"happened\""}` + "\n",
+	})
+
+	close(stop)
+	s.assertStops(c, done, tailer)
+}
+
 func (s *debugLogDBIntSuite) TestRequestStopsWhenTailerStops(c *gc.C) {
 	tailer := newFakeLogTailer()
 	s.PatchValue(&newLogTailer, func(_ state.LogTailerState, params *state.LogTailerParams) (state.LogTailer, error) {
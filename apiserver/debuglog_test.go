@@ -28,6 +28,24 @@ func (s *debugLogBaseSuite) TestBadParams(c *gc.C) {
 	s.assertWebsocketClosed(c, reader)
 }
 
+func (s *debugLogBaseSuite) TestBadFormat(c *gc.C) {
+	reader := s.openWebsocket(c, url.Values{"format": {"yaml"}})
+	assertJSONError(c, reader, `format value "yaml" is not one of "text", "json"`)
+	s.assertWebsocketClosed(c, reader)
+}
+
+func (s *debugLogBaseSuite) TestBadMessagePattern(c *gc.C) {
+	reader := s.openWebsocket(c, url.Values{"messagePattern": {"(foo"}})
+	assertJSONError(c, reader, `messagePattern value "\(foo" is not a valid regular expression`)
+	s.assertWebsocketClosed(c, reader)
+}
+
+func (s *debugLogBaseSuite) TestBadStartTime(c *gc.C) {
+	reader := s.openWebsocket(c, url.Values{"startTime": {"yesterday"}})
+	assertJSONError(c, reader, `startTime value "yesterday" is not a valid RFC3339 time`)
+	s.assertWebsocketClosed(c, reader)
+}
+
 func (s *debugLogBaseSuite) TestWithHTTP(c *gc.C) {
 	uri := s.logURL(c, "http", nil).String()
 	s.sendRequest(c, httpRequestParams{
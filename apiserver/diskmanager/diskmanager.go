@@ -4,6 +4,10 @@
 package diskmanager
 
 import (
+	"sort"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
@@ -12,8 +16,10 @@ import (
 	"github.com/juju/juju/storage"
 )
 
+var logger = loggo.GetLogger("juju.apiserver.diskmanager")
+
 func init() {
-	common.RegisterStandardFacade("DiskManager", 2, NewDiskManagerAPI)
+	common.RegisterStandardFacade("DiskManager", 3, NewDiskManagerAPI)
 }
 
 // DiskManagerAPI provides access to the DiskManager API facade.
@@ -89,6 +95,114 @@ func (d *DiskManagerAPI) SetMachineBlockDevices(args params.SetMachineBlockDevic
 	return result, nil
 }
 
+// FilesystemMountPoints returns the mount points of the provisioned
+// filesystems attached to each of the specified machines.
+func (d *DiskManagerAPI) FilesystemMountPoints(args params.Entities) (params.StringsResults, error) {
+	result := params.StringsResults{
+		Results: make([]params.StringsResult, len(args.Entities)),
+	}
+	canAccess, err := d.getAuthFunc()
+	if err != nil {
+		return result, err
+	}
+	for i, arg := range args.Entities {
+		tag, err := names.ParseMachineTag(arg.Tag)
+		if err != nil || !canAccess(tag) {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		mountPoints, err := d.machineFilesystemMountPoints(tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result.Results[i].Result = mountPoints
+	}
+	return result, nil
+}
+
+func (d *DiskManagerAPI) machineFilesystemMountPoints(tag names.MachineTag) ([]string, error) {
+	mountPoints, err := d.machineFilesystemsByMountPoint(tag)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]string, 0, len(mountPoints))
+	for mountPoint := range mountPoints {
+		result = append(result, mountPoint)
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+// machineFilesystemsByMountPoint returns the tags of the provisioned
+// filesystems attached to the machine, keyed by mount point.
+func (d *DiskManagerAPI) machineFilesystemsByMountPoint(tag names.MachineTag) (map[string]names.FilesystemTag, error) {
+	attachments, err := d.st.MachineFilesystemAttachments(tag)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]names.FilesystemTag)
+	for _, attachment := range attachments {
+		info, err := attachment.Info()
+		if errors.IsNotProvisioned(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		if info.MountPoint == "" {
+			continue
+		}
+		result[info.MountPoint] = attachment.Filesystem()
+	}
+	return result, nil
+}
+
+// SetMachineFilesystemUsage records the capacity usage of the filesystems
+// mounted on each of the specified machines. Usage reported for mount
+// points that do not correspond to a Juju-managed filesystem is ignored.
+func (d *DiskManagerAPI) SetMachineFilesystemUsage(args params.SetMachineFilesystemUsage) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.MachineFilesystemUsage)),
+	}
+	canAccess, err := d.getAuthFunc()
+	if err != nil {
+		return result, err
+	}
+	for i, arg := range args.MachineFilesystemUsage {
+		tag, err := names.ParseMachineTag(arg.Machine)
+		if err != nil || !canAccess(tag) {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		err = d.setMachineFilesystemUsage(tag, arg.Usage)
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (d *DiskManagerAPI) setMachineFilesystemUsage(tag names.MachineTag, usage []params.FilesystemUsage) error {
+	filesystems, err := d.machineFilesystemsByMountPoint(tag)
+	if err != nil {
+		return err
+	}
+	for _, u := range usage {
+		filesystemTag, ok := filesystems[u.MountPoint]
+		if !ok {
+			logger.Debugf("ignoring usage for unknown mount point %q", u.MountPoint)
+			continue
+		}
+		if err := d.st.SetFilesystemAttachmentUsage(tag, filesystemTag, state.FilesystemUsage{
+			UsedBytes:  u.UsedBytes,
+			FreeBytes:  u.FreeBytes,
+			UsedInodes: u.UsedInodes,
+			FreeInodes: u.FreeInodes,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func stateBlockDeviceInfo(devices []storage.BlockDevice) []state.BlockDeviceInfo {
 	result := make([]state.BlockDeviceInfo, len(devices))
 	for i, dev := range devices {
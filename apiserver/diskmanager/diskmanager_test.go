@@ -6,6 +6,7 @@ package diskmanager_test
 import (
 	"errors"
 
+	jujuerrors "github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
@@ -107,10 +108,96 @@ func (s *DiskManagerSuite) TestSetMachineBlockDevicesStateError(c *gc.C) {
 	})
 }
 
+func (s *DiskManagerSuite) TestFilesystemMountPoints(c *gc.C) {
+	s.st.attachments = []state.FilesystemAttachment{
+		&mockFilesystemAttachment{
+			filesystem: names.NewFilesystemTag("1"),
+			info:       &state.FilesystemAttachmentInfo{MountPoint: "/srv/b"},
+		},
+		&mockFilesystemAttachment{
+			filesystem: names.NewFilesystemTag("2"),
+		},
+		&mockFilesystemAttachment{
+			filesystem: names.NewFilesystemTag("3"),
+			info:       &state.FilesystemAttachmentInfo{MountPoint: "/srv/a"},
+		},
+	}
+	results, err := s.api.FilesystemMountPoints(params.Entities{
+		Entities: []params.Entity{{Tag: "machine-0"}, {Tag: "machine-1"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.StringsResults{
+		Results: []params.StringsResult{{
+			Result: []string{"/srv/a", "/srv/b"},
+		}, {
+			Error: &params.Error{Message: "permission denied", Code: "unauthorized access"},
+		}},
+	})
+}
+
+func (s *DiskManagerSuite) TestSetMachineFilesystemUsage(c *gc.C) {
+	s.st.attachments = []state.FilesystemAttachment{
+		&mockFilesystemAttachment{
+			filesystem: names.NewFilesystemTag("1"),
+			info:       &state.FilesystemAttachmentInfo{MountPoint: "/srv"},
+		},
+	}
+	results, err := s.api.SetMachineFilesystemUsage(params.SetMachineFilesystemUsage{
+		MachineFilesystemUsage: []params.MachineFilesystemUsage{{
+			Machine: "machine-0",
+			Usage: []params.FilesystemUsage{{
+				MountPoint: "/srv",
+				UsedBytes:  1024,
+				FreeBytes:  2048,
+				UsedInodes: 1,
+				FreeInodes: 2,
+			}, {
+				MountPoint: "/",
+				UsedBytes:  4096,
+			}},
+		}, {
+			Machine: "machine-1",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{{
+			Error: nil,
+		}, {
+			Error: &params.Error{Message: "permission denied", Code: "unauthorized access"},
+		}},
+	})
+	c.Assert(s.st.usage, jc.DeepEquals, map[names.FilesystemTag]state.FilesystemUsage{
+		names.NewFilesystemTag("1"): {
+			UsedBytes:  1024,
+			FreeBytes:  2048,
+			UsedInodes: 1,
+			FreeInodes: 2,
+		},
+	})
+}
+
+func (s *DiskManagerSuite) TestSetMachineFilesystemUsageStateError(c *gc.C) {
+	s.st.err = errors.New("boom")
+	results, err := s.api.SetMachineFilesystemUsage(params.SetMachineFilesystemUsage{
+		MachineFilesystemUsage: []params.MachineFilesystemUsage{{
+			Machine: "machine-0",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{{
+			Error: &params.Error{Message: "boom", Code: ""},
+		}},
+	})
+}
+
 type mockState struct {
-	calls   int
-	devices map[string][]state.BlockDeviceInfo
-	err     error
+	calls       int
+	devices     map[string][]state.BlockDeviceInfo
+	attachments []state.FilesystemAttachment
+	usage       map[names.FilesystemTag]state.FilesystemUsage
+	err         error
 }
 
 func (st *mockState) SetMachineBlockDevices(machineId string, devices []state.BlockDeviceInfo) error {
@@ -121,3 +208,37 @@ func (st *mockState) SetMachineBlockDevices(machineId string, devices []state.Bl
 	st.devices[machineId] = devices
 	return st.err
 }
+
+func (st *mockState) MachineFilesystemAttachments(machine names.MachineTag) ([]state.FilesystemAttachment, error) {
+	if st.err != nil {
+		return nil, st.err
+	}
+	return st.attachments, nil
+}
+
+func (st *mockState) SetFilesystemAttachmentUsage(
+	machine names.MachineTag, filesystem names.FilesystemTag, usage state.FilesystemUsage,
+) error {
+	if st.usage == nil {
+		st.usage = make(map[names.FilesystemTag]state.FilesystemUsage)
+	}
+	st.usage[filesystem] = usage
+	return st.err
+}
+
+type mockFilesystemAttachment struct {
+	state.FilesystemAttachment
+	filesystem names.FilesystemTag
+	info       *state.FilesystemAttachmentInfo
+}
+
+func (a *mockFilesystemAttachment) Filesystem() names.FilesystemTag {
+	return a.filesystem
+}
+
+func (a *mockFilesystemAttachment) Info() (state.FilesystemAttachmentInfo, error) {
+	if a.info == nil {
+		return state.FilesystemAttachmentInfo{}, jujuerrors.NotProvisionedf("filesystem attachment")
+	}
+	return *a.info, nil
+}
@@ -15,7 +15,7 @@ import (
 )
 
 func init() {
-	common.RegisterStandardFacade("LogForwarding", 1, func(st *state.State, _ *common.Resources, auth common.Authorizer) (*LogForwardingAPI, error) {
+	common.RegisterStandardFacade("LogForwarding", 2, func(st *state.State, _ *common.Resources, auth common.Authorizer) (*LogForwardingAPI, error) {
 		return NewLogForwardingAPI(&stateAdapter{st}, auth)
 	})
 }
@@ -37,6 +37,14 @@ type LogForwardingState interface {
 	// NewLastSentTracker creates a new tracker for the given model
 	// and log sink.
 	NewLastSentTracker(tag names.ModelTag, sink string) (LastSentTracker, error)
+
+	// NewAllLastSentTracker creates a new tracker for the given log
+	// sink that covers the records of all models.
+	NewAllLastSentTracker(sink string) (LastSentTracker, error)
+
+	// ModelLogForwardConfigs returns the log forwarding config of each
+	// hosted model that has one.
+	ModelLogForwardConfigs() ([]ModelLogForwardConfig, error)
 }
 
 // LogForwardingAPI is the concrete implementation of the api end point.
@@ -112,6 +120,9 @@ func (api *LogForwardingAPI) set(arg params.LogForwardingSetLastSentParam) *para
 }
 
 func (api *LogForwardingAPI) newLastSentTracker(id params.LogForwardingID) (LastSentTracker, error) {
+	if id.ModelTag == "" {
+		return api.state.NewAllLastSentTracker(id.Sink)
+	}
 	tag, err := names.ParseModelTag(id.ModelTag)
 	if err != nil {
 		return nil, err
@@ -140,6 +151,21 @@ func (st stateAdapter) NewLastSentTracker(tag names.ModelTag, sink string) (Last
 	return &lastSentCloser{lastSent, loggingState}, nil
 }
 
+// NewAllLastSentTracker implements LogForwardingState.
+func (st stateAdapter) NewAllLastSentTracker(sink string) (LastSentTracker, error) {
+	lastSent, err := state.NewAllLastSentLogTracker(st, sink)
+	if err != nil {
+		return nil, err
+	}
+	return &lastSentCloser{lastSent, nopCloser{}}, nil
+}
+
+type nopCloser struct{}
+
+func (nopCloser) Close() error {
+	return nil
+}
+
 type lastSentCloser struct {
 	*state.LastSentLogTracker
 	io.Closer
@@ -202,10 +202,58 @@ func (s *LastSentSuite) TestSetLastSentBulk(c *gc.C) {
 	s.stub.CheckCall(c, 7, "Set", int64(15))
 }
 
+func (s *LastSentSuite) TestGetLastSentAllModels(c *gc.C) {
+	tracker := s.state.addTracker()
+	tracker.ReturnGet = 10
+	api, err := logfwd.NewLogForwardingAPI(s.state, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	res := api.GetLastSent(params.LogForwardingGetLastSentParams{
+		IDs: []params.LogForwardingID{{
+			ModelTag: "",
+			Sink:     "spam",
+		}},
+	})
+
+	c.Check(res, jc.DeepEquals, params.LogForwardingGetLastSentResults{
+		Results: []params.LogForwardingGetLastSentResult{{
+			RecordID: 10,
+		}},
+	})
+	s.stub.CheckCallNames(c, "NewAllLastSentTracker", "Get", "Close")
+	s.stub.CheckCall(c, 0, "NewAllLastSentTracker", "spam")
+}
+
+func (s *LastSentSuite) TestSetLastSentAllModels(c *gc.C) {
+	s.state.addTracker()
+	api, err := logfwd.NewLogForwardingAPI(s.state, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	res := api.SetLastSent(params.LogForwardingSetLastSentParams{
+		Params: []params.LogForwardingSetLastSentParam{{
+			LogForwardingID: params.LogForwardingID{
+				ModelTag: "",
+				Sink:     "spam",
+			},
+			RecordID: 10,
+		}},
+	})
+
+	c.Check(res, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{{
+			Error: nil,
+		}},
+	})
+	s.stub.CheckCallNames(c, "NewAllLastSentTracker", "Set", "Close")
+	s.stub.CheckCall(c, 0, "NewAllLastSentTracker", "spam")
+	s.stub.CheckCall(c, 1, "Set", int64(10))
+}
+
 type stubState struct {
 	stub *testing.Stub
 
-	ReturnNewLastSentTracker []logfwd.LastSentTracker
+	ReturnNewLastSentTracker     []logfwd.LastSentTracker
+	ReturnModelLogForwardConfigs []logfwd.ModelLogForwardConfig
 }
 
 func (s *stubState) addTracker() *stubTracker {
@@ -220,12 +268,34 @@ func (s *stubState) NewLastSentTracker(tag names.ModelTag, sink string) (logfwd.
 		return nil, err
 	}
 
+	return s.nextTracker(), nil
+}
+
+func (s *stubState) NewAllLastSentTracker(sink string) (logfwd.LastSentTracker, error) {
+	s.stub.AddCall("NewAllLastSentTracker", sink)
+	if err := s.stub.NextErr(); err != nil {
+		return nil, err
+	}
+
+	return s.nextTracker(), nil
+}
+
+func (s *stubState) ModelLogForwardConfigs() ([]logfwd.ModelLogForwardConfig, error) {
+	s.stub.AddCall("ModelLogForwardConfigs")
+	if err := s.stub.NextErr(); err != nil {
+		return nil, err
+	}
+
+	return s.ReturnModelLogForwardConfigs, nil
+}
+
+func (s *stubState) nextTracker() logfwd.LastSentTracker {
 	if len(s.ReturnNewLastSentTracker) == 0 {
 		panic("ran out of trackers")
 	}
 	tracker := s.ReturnNewLastSentTracker[0]
 	s.ReturnNewLastSentTracker = s.ReturnNewLastSentTracker[1:]
-	return tracker, nil
+	return tracker
 }
 
 type stubTracker struct {
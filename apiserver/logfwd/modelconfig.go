@@ -0,0 +1,85 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logfwd
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/state"
+)
+
+// ModelLogForwardConfig is the log forwarding config of a hosted model.
+type ModelLogForwardConfig struct {
+	// Model identifies the hosted model.
+	Model names.ModelTag
+
+	// Config is the model's log forwarding config.
+	Config logfwd.SinkConfig
+}
+
+// ModelConfigs returns the log forwarding config of each of the
+// controller's hosted models that has one. Log forwarding for the
+// controller model itself covers all models, and is not included.
+func (api *LogForwardingAPI) ModelConfigs() (params.LogForwardingModelConfigResults, error) {
+	configs, err := api.state.ModelLogForwardConfigs()
+	if err != nil {
+		return params.LogForwardingModelConfigResults{}, errors.Trace(err)
+	}
+	results := make([]params.LogForwardingModelConfig, len(configs))
+	for i, modelCfg := range configs {
+		cfg := modelCfg.Config
+		results[i] = params.LogForwardingModelConfig{
+			ModelTag:      modelCfg.Model.String(),
+			Enabled:       cfg.Enabled,
+			Type:          string(cfg.Type),
+			Endpoint:      cfg.Endpoint,
+			CACert:        cfg.CACert,
+			ClientCert:    cfg.ClientCert,
+			ClientKey:     cfg.ClientKey,
+			BatchSize:     cfg.BatchSize,
+			FlushInterval: cfg.FlushInterval,
+			IncludeEntity: cfg.Filter.IncludeEntity,
+			ExcludeEntity: cfg.Filter.ExcludeEntity,
+			IncludeModule: cfg.Filter.IncludeModule,
+			ExcludeModule: cfg.Filter.ExcludeModule,
+		}
+		if cfg.Filter.MinLevel > loggo.UNSPECIFIED {
+			results[i].MinLevel = cfg.Filter.MinLevel.String()
+		}
+	}
+	return params.LogForwardingModelConfigResults{
+		Results: results,
+	}, nil
+}
+
+// ModelLogForwardConfigs implements LogForwardingState.
+func (st stateAdapter) ModelLogForwardConfigs() ([]ModelLogForwardConfig, error) {
+	models, err := st.AllModels()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var configs []ModelLogForwardConfig
+	for _, model := range models {
+		if model.UUID() == st.ControllerUUID() || model.Life() != state.Alive {
+			continue
+		}
+		modelCfg, err := model.Config()
+		if err != nil {
+			return nil, errors.Annotatef(err, "getting config for model %q", model.UUID())
+		}
+		cfg, ok := modelCfg.LogForwardSink()
+		if !ok {
+			continue
+		}
+		configs = append(configs, ModelLogForwardConfig{
+			Model:  model.ModelTag(),
+			Config: *cfg,
+		})
+	}
+	return configs, nil
+}
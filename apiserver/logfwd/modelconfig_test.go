@@ -0,0 +1,90 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logfwd_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/logfwd"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	corelogfwd "github.com/juju/juju/logfwd"
+)
+
+type ModelConfigSuite struct {
+	testing.IsolationSuite
+
+	stub       *testing.Stub
+	state      *stubState
+	authorizer apiservertesting.FakeAuthorizer
+}
+
+var _ = gc.Suite(&ModelConfigSuite{})
+
+func (s *ModelConfigSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+
+	s.stub = &testing.Stub{}
+	s.state = &stubState{stub: s.stub}
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewMachineTag("99"),
+	}
+}
+
+func (s *ModelConfigSuite) TestModelConfigs(c *gc.C) {
+	modelTag := names.NewModelTag("deadbeef-2f18-4fd2-967d-db9663db7bea")
+	s.state.ReturnModelLogForwardConfigs = []logfwd.ModelLogForwardConfig{{
+		Model: modelTag,
+		Config: corelogfwd.SinkConfig{
+			Enabled:       true,
+			Type:          corelogfwd.SinkHTTPJSON,
+			Endpoint:      "https://logs.example.com/juju",
+			BatchSize:     10,
+			FlushInterval: time.Second,
+			Filter: corelogfwd.Filter{
+				MinLevel:      loggo.WARNING,
+				IncludeEntity: []string{"unit-mysql-*"},
+				ExcludeModule: []string{"juju.worker.uniter"},
+			},
+		},
+	}}
+	api, err := logfwd.NewLogForwardingAPI(s.state, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	res, err := api.ModelConfigs()
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(res, jc.DeepEquals, params.LogForwardingModelConfigResults{
+		Results: []params.LogForwardingModelConfig{{
+			ModelTag:      modelTag.String(),
+			Enabled:       true,
+			Type:          "http-json",
+			Endpoint:      "https://logs.example.com/juju",
+			BatchSize:     10,
+			FlushInterval: time.Second,
+			MinLevel:      "WARNING",
+			IncludeEntity: []string{"unit-mysql-*"},
+			ExcludeModule: []string{"juju.worker.uniter"},
+		}},
+	})
+	s.stub.CheckCallNames(c, "ModelLogForwardConfigs")
+}
+
+func (s *ModelConfigSuite) TestModelConfigsError(c *gc.C) {
+	failure := errors.New("<failure>")
+	s.stub.SetErrors(failure)
+	api, err := logfwd.NewLogForwardingAPI(s.state, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = api.ModelConfigs()
+
+	c.Check(errors.Cause(err), gc.Equals, failure)
+}
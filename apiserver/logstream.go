@@ -9,6 +9,7 @@ import (
 	"github.com/gorilla/schema"
 	"github.com/juju/errors"
 	"golang.org/x/net/websocket"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
@@ -18,6 +19,7 @@ import (
 type logStreamSource interface {
 	getStart(sink string, allModels bool) (int64, error)
 	newTailer(*state.LogTailerParams) (state.LogTailer, error)
+	forModel(modelUUID string) (logStreamSource, error)
 }
 
 // logStreamEndpointHandler takes requests to stream logs from the DB.
@@ -45,6 +47,7 @@ func newLogStreamEndpointHandler(ctxt httpContext) *logStreamEndpointHandler {
 // Args for the HTTP request are as follows:
 //   all -> string - one of [true, false], if true, include records from all models
 //   sink -> string - the name of the the log forwarding target
+//   model -> string - the UUID of the model whose records to include (controller only)
 func (eph *logStreamEndpointHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	logger.Infof("log stream request handler starting")
 	server := websocket.Server{
@@ -84,6 +87,16 @@ func (eph *logStreamEndpointHandler) newLogStreamRequestHandler(req *http.Reques
 		return nil, errors.Annotate(err, "decoding schema")
 	}
 
+	if cfg.Model != "" {
+		if cfg.AllModels {
+			return nil, errors.NotValidf("model with all models")
+		}
+		source, err = source.forModel(cfg.Model)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+
 	tailer, err := eph.newTailer(source, cfg)
 	if err != nil {
 		return nil, errors.Annotate(err, "creating new tailer")
@@ -92,7 +105,7 @@ func (eph *logStreamEndpointHandler) newLogStreamRequestHandler(req *http.Reques
 	reqHandler := &logStreamRequestHandler{
 		req:           req,
 		tailer:        tailer,
-		sendModelUUID: cfg.AllModels,
+		sendModelUUID: cfg.AllModels || cfg.Model != "",
 	}
 	return reqHandler, nil
 }
@@ -155,6 +168,33 @@ func (st logStreamState) newTailer(args *state.LogTailerParams) (state.LogTailer
 	return tailer, nil
 }
 
+func (st logStreamState) forModel(modelUUID string) (logStreamSource, error) {
+	if !st.IsController() {
+		return nil, errors.New("only the controller model can stream the logs of another model")
+	}
+	if !names.IsValidModel(modelUUID) {
+		return nil, errors.NotValidf("model UUID %q", modelUUID)
+	}
+	return logStreamState{hostedModelState{st.LogTailerState, modelUUID}}, nil
+}
+
+// hostedModelState presents the controller's state as that of one of
+// its hosted models, for the purpose of tailing and tracking its logs.
+type hostedModelState struct {
+	state.LogTailerState
+	modelUUID string
+}
+
+// ModelUUID implements state.ModelSessioner.
+func (st hostedModelState) ModelUUID() string {
+	return st.modelUUID
+}
+
+// IsController implements state.LogTailerState.
+func (st hostedModelState) IsController() bool {
+	return false
+}
+
 type logStreamRequestHandler struct {
 	req           *http.Request
 	tailer        state.LogTailer
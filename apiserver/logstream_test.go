@@ -58,6 +58,54 @@ func (s *LogStreamIntSuite) TestParamConversion(c *gc.C) {
 	})
 }
 
+func (s *LogStreamIntSuite) TestParamConversionModel(c *gc.C) {
+	cfg := params.LogStreamConfig{
+		Sink:  "spam",
+		Model: "deadbeef-2f18-4fd2-967d-db9663db7bea",
+	}
+	req := s.newReq(c, cfg)
+
+	stub := &testing.Stub{}
+	source := &stubSource{stub: stub}
+	source.ReturnGetStart = 10
+	handler := logStreamEndpointHandler{
+		stopCh:    nil,
+		newSource: source.newSource,
+	}
+
+	reqHandler, err := handler.newLogStreamRequestHandler(req)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(reqHandler.sendModelUUID, jc.IsTrue)
+	stub.CheckCallNames(c, "newSource", "forModel", "getStart", "newTailer")
+	stub.CheckCall(c, 1, "forModel", "deadbeef-2f18-4fd2-967d-db9663db7bea")
+	stub.CheckCall(c, 2, "getStart", "spam", false)
+	stub.CheckCall(c, 3, "newTailer", &state.LogTailerParams{
+		StartID: 10,
+	})
+}
+
+func (s *LogStreamIntSuite) TestModelWithAllModels(c *gc.C) {
+	cfg := params.LogStreamConfig{
+		AllModels: true,
+		Sink:      "spam",
+		Model:     "deadbeef-2f18-4fd2-967d-db9663db7bea",
+	}
+	req := s.newReq(c, cfg)
+
+	stub := &testing.Stub{}
+	source := &stubSource{stub: stub}
+	handler := logStreamEndpointHandler{
+		stopCh:    nil,
+		newSource: source.newSource,
+	}
+
+	_, err := handler.newLogStreamRequestHandler(req)
+
+	c.Check(err, gc.ErrorMatches, `model with all models not valid`)
+	stub.CheckCallNames(c, "newSource")
+}
+
 func (s *LogStreamIntSuite) TestFullRequest(c *gc.C) {
 
 	// Create test data: i.e. log records for tailing...
@@ -234,6 +282,15 @@ func (s *stubSource) getStart(sink string, allModels bool) (int64, error) {
 	return s.ReturnGetStart, nil
 }
 
+func (s *stubSource) forModel(modelUUID string) (logStreamSource, error) {
+	s.stub.AddCall("forModel", modelUUID)
+	if err := s.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return s, nil
+}
+
 func (s *stubSource) newTailer(args *state.LogTailerParams) (state.LogTailer, error) {
 	s.stub.AddCall("newTailer", args)
 	if err := s.stub.NextErr(); err != nil {
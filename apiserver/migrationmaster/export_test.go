@@ -4,6 +4,8 @@
 package migrationmaster
 
 import (
+	"github.com/juju/juju/api"
+	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/migration"
 	"github.com/juju/juju/state"
 )
@@ -18,6 +20,14 @@ func PatchExportModel(p Patcher, f func(migration.StateExporter) ([]byte, error)
 	p.PatchValue(&exportModel, f)
 }
 
+func PatchUploadBinaries(p Patcher, f func(migration.UploadBinariesConfig) error) {
+	p.PatchValue(&uploadBinaries, f)
+}
+
+func PatchOpenTargetAPI(p Patcher, f func(coremigration.TargetInfo) (api.Connection, error)) {
+	p.PatchValue(&openTargetAPI, f)
+}
+
 type Patcher interface {
 	PatchValue(ptr, value interface{})
 }
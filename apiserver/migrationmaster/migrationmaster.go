@@ -7,6 +7,7 @@ import (
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/api"
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
 	coremigration "github.com/juju/juju/core/migration"
@@ -16,7 +17,7 @@ import (
 )
 
 func init() {
-	common.RegisterStandardFacade("MigrationMaster", 1, NewAPI)
+	common.RegisterStandardFacade("MigrationMaster", 2, NewAPI)
 }
 
 // API implements the API required for the model migration
@@ -94,6 +95,7 @@ func (api *API) GetMigrationStatus() (params.FullMigrationStatus, error) {
 				AuthTag:       target.AuthTag.String(),
 				Password:      target.Password,
 			},
+			Offline: mig.Offline(),
 		},
 		Attempt: attempt,
 		Phase:   phase.String(),
@@ -118,7 +120,37 @@ func (api *API) SetPhase(args params.SetMigrationPhaseArgs) error {
 	return errors.Annotate(err, "failed to set phase")
 }
 
-var exportModel = migration.ExportModel
+// SetStatusMessage sets a human readable status message containing
+// information about the migration's progress. When the migration is
+// aborted, the current message is kept as the reason for the abort.
+func (api *API) SetStatusMessage(args params.SetMigrationStatusMessageArgs) error {
+	mig, err := api.backend.GetModelMigration()
+	if err != nil {
+		return errors.Annotate(err, "could not get migration")
+	}
+	err = mig.SetStatusMessage(args.Message)
+	return errors.Annotate(err, "failed to set status message")
+}
+
+var (
+	exportModel    = migration.ExportModel
+	uploadBinaries = migration.UploadBinaries
+)
+
+// openTargetAPI opens a connection to the controller that the model
+// is being migrated to. It is a variable so it can be replaced in
+// tests.
+var openTargetAPI = func(targetInfo coremigration.TargetInfo) (api.Connection, error) {
+	apiInfo := &api.Info{
+		Addrs:    targetInfo.Addrs,
+		CACert:   targetInfo.CACert,
+		Tag:      targetInfo.AuthTag,
+		Password: targetInfo.Password,
+	}
+	// Use zero DialOpts (no retries) so that an unreachable target
+	// doesn't hold up the API request.
+	return api.Open(apiInfo, api.DialOpts{})
+}
 
 // Export serializes the model associated with the API connection.
 func (api *API) Export() (params.SerializedModel, error) {
@@ -132,3 +164,53 @@ func (api *API) Export() (params.SerializedModel, error) {
 	serialized.Bytes = bytes
 	return serialized, nil
 }
+
+// UploadBinaries sends the tools, charms and resources used by the
+// model associated with the API connection to the target controller
+// of its active migration, recording the number of bytes of charms
+// and tools sent as the migration's upload progress. The model must
+// already have been imported there, so that the binaries have
+// documents to be attached to.
+func (api *API) UploadBinaries() error {
+	mig, err := api.backend.GetModelMigration()
+	if err != nil {
+		return errors.Annotate(err, "could not get migration")
+	}
+	target, err := mig.TargetInfo()
+	if err != nil {
+		return errors.Annotate(err, "retrieving target info")
+	}
+	model, err := api.backend.Export()
+	if err != nil {
+		return errors.Annotate(err, "exporting model")
+	}
+	conn, err := openTargetAPI(*target)
+	if err != nil {
+		return errors.Annotate(err, "connecting to target controller")
+	}
+	defer conn.Close()
+
+	config := migration.NewUploadBinariesConfig(api.backend, model, conn)
+	config.SetProgress = mig.SetUploadProgress
+	err = uploadBinaries(config)
+	return errors.Annotate(err, "failed to upload binaries")
+}
+
+// NeedsCleanup reports whether the model associated with the API
+// connection has cleanups pending, which would prevent it from being
+// migrated.
+func (api *API) NeedsCleanup() (params.BoolResult, error) {
+	needsCleanup, err := api.backend.NeedsCleanup()
+	if err != nil {
+		return params.BoolResult{}, errors.Trace(err)
+	}
+	return params.BoolResult{Result: needsCleanup}, nil
+}
+
+// Reap removes the documents of the model associated with the API
+// connection once it has been migrated to another controller. The
+// model must have reached the SUCCESS phase of its migration.
+func (api *API) Reap() error {
+	err := api.backend.RemoveExportingModelDocs()
+	return errors.Annotate(err, "failed to remove model")
+}
@@ -12,10 +12,12 @@ import (
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/api"
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/migrationmaster"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/description"
 	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/migration"
 	"github.com/juju/juju/state"
@@ -97,6 +99,15 @@ func (s *Suite) TestGetMigrationStatus(c *gc.C) {
 	})
 }
 
+func (s *Suite) TestGetMigrationStatusOffline(c *gc.C) {
+	s.backend.migration.offline = true
+	api := s.mustMakeAPI(c)
+
+	status, err := api.GetMigrationStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(status.Spec.Offline, jc.IsTrue)
+}
+
 func (s *Suite) TestSetPhase(c *gc.C) {
 	api := s.mustMakeAPI(c)
 
@@ -129,6 +140,31 @@ func (s *Suite) TestSetPhaseError(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "failed to set phase: blam")
 }
 
+func (s *Suite) TestSetStatusMessage(c *gc.C) {
+	api := s.mustMakeAPI(c)
+
+	err := api.SetStatusMessage(params.SetMigrationStatusMessageArgs{Message: "foo"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.backend.migration.messageSet, gc.Equals, "foo")
+}
+
+func (s *Suite) TestSetStatusMessageNoMigration(c *gc.C) {
+	s.backend.getErr = errors.New("boom")
+	api := s.mustMakeAPI(c)
+
+	err := api.SetStatusMessage(params.SetMigrationStatusMessageArgs{Message: "foo"})
+	c.Check(err, gc.ErrorMatches, "could not get migration: boom")
+}
+
+func (s *Suite) TestSetStatusMessageError(c *gc.C) {
+	s.backend.migration.setMessageErr = errors.New("blam")
+	api := s.mustMakeAPI(c)
+
+	err := api.SetStatusMessage(params.SetMigrationStatusMessageArgs{Message: "foo"})
+	c.Assert(err, gc.ErrorMatches, "failed to set status message: blam")
+}
+
 func (s *Suite) TestExport(c *gc.C) {
 	exportModel := func(migration.StateExporter) ([]byte, error) {
 		return []byte("foo"), nil
@@ -144,6 +180,99 @@ func (s *Suite) TestExport(c *gc.C) {
 	})
 }
 
+func (s *Suite) TestUploadBinaries(c *gc.C) {
+	model := description.NewModel(description.ModelArgs{
+		Owner:  names.NewUserTag("admin"),
+		Config: map[string]interface{}{"uuid": modelUUID},
+	})
+	s.backend.model = model
+	conn := &stubConnection{}
+	var targetInfo coremigration.TargetInfo
+	migrationmaster.PatchOpenTargetAPI(s, func(info coremigration.TargetInfo) (api.Connection, error) {
+		targetInfo = info
+		return conn, nil
+	})
+	var config migration.UploadBinariesConfig
+	migrationmaster.PatchUploadBinaries(s, func(c migration.UploadBinariesConfig) error {
+		config = c
+		return nil
+	})
+	api := s.mustMakeAPI(c)
+
+	err := api.UploadBinaries()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(targetInfo.Addrs, jc.DeepEquals, []string{"1.1.1.1:1", "2.2.2.2:2"})
+	c.Check(targetInfo.Password, gc.Equals, "secret")
+	c.Check(config.State, gc.Equals, s.backend)
+	c.Check(config.Model, gc.Equals, model)
+	c.Check(config.Target, gc.Equals, conn)
+	c.Check(conn.closed, jc.IsTrue)
+
+	// Upload progress is recorded against the migration.
+	err = config.SetProgress(100, 200)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.backend.migration.charmBytes, gc.Equals, int64(100))
+	c.Check(s.backend.migration.toolsBytes, gc.Equals, int64(200))
+}
+
+func (s *Suite) TestUploadBinariesConnectError(c *gc.C) {
+	migrationmaster.PatchOpenTargetAPI(s, func(coremigration.TargetInfo) (api.Connection, error) {
+		return nil, errors.New("boom")
+	})
+	api := s.mustMakeAPI(c)
+
+	err := api.UploadBinaries()
+	c.Assert(err, gc.ErrorMatches, "connecting to target controller: boom")
+}
+
+func (s *Suite) TestUploadBinariesError(c *gc.C) {
+	conn := &stubConnection{}
+	migrationmaster.PatchOpenTargetAPI(s, func(coremigration.TargetInfo) (api.Connection, error) {
+		return conn, nil
+	})
+	migrationmaster.PatchUploadBinaries(s, func(migration.UploadBinariesConfig) error {
+		return errors.New("boom")
+	})
+	api := s.mustMakeAPI(c)
+
+	err := api.UploadBinaries()
+	c.Assert(err, gc.ErrorMatches, "failed to upload binaries: boom")
+	c.Check(conn.closed, jc.IsTrue)
+}
+
+func (s *Suite) TestNeedsCleanup(c *gc.C) {
+	s.backend.needsCleanup = true
+	api := s.mustMakeAPI(c)
+
+	result, err := api.NeedsCleanup()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, gc.Equals, params.BoolResult{Result: true})
+}
+
+func (s *Suite) TestNeedsCleanupError(c *gc.C) {
+	s.backend.needsCleanupErr = errors.New("boom")
+	api := s.mustMakeAPI(c)
+
+	_, err := api.NeedsCleanup()
+	c.Check(err, gc.ErrorMatches, "boom")
+}
+
+func (s *Suite) TestReap(c *gc.C) {
+	api := s.mustMakeAPI(c)
+
+	err := api.Reap()
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(s.backend.reaped, jc.IsTrue)
+}
+
+func (s *Suite) TestReapError(c *gc.C) {
+	s.backend.reapErr = errors.New("boom")
+	api := s.mustMakeAPI(c)
+
+	err := api.Reap()
+	c.Check(err, gc.ErrorMatches, "failed to remove model: boom")
+}
+
 func (s *Suite) makeAPI() (*migrationmaster.API, error) {
 	return migrationmaster.NewAPI(nil, s.resources, s.authorizer)
 }
@@ -157,8 +286,13 @@ func (s *Suite) mustMakeAPI(c *gc.C) *migrationmaster.API {
 type stubBackend struct {
 	migrationmaster.Backend
 
-	getErr    error
-	migration *stubMigration
+	model           description.Model
+	getErr          error
+	migration       *stubMigration
+	needsCleanup    bool
+	needsCleanupErr error
+	reapErr         error
+	reaped          bool
 }
 
 func (b *stubBackend) WatchForModelMigration() state.NotifyWatcher {
@@ -172,10 +306,31 @@ func (b *stubBackend) GetModelMigration() (state.ModelMigration, error) {
 	return b.migration, nil
 }
 
+func (b *stubBackend) Export() (description.Model, error) {
+	return b.model, nil
+}
+
+func (b *stubBackend) NeedsCleanup() (bool, error) {
+	return b.needsCleanup, b.needsCleanupErr
+}
+
+func (b *stubBackend) RemoveExportingModelDocs() error {
+	if b.reapErr != nil {
+		return b.reapErr
+	}
+	b.reaped = true
+	return nil
+}
+
 type stubMigration struct {
 	state.ModelMigration
-	setPhaseErr error
-	phaseSet    coremigration.Phase
+	offline       bool
+	setPhaseErr   error
+	phaseSet      coremigration.Phase
+	setMessageErr error
+	messageSet    string
+	charmBytes    int64
+	toolsBytes    int64
 }
 
 func (m *stubMigration) Phase() (coremigration.Phase, error) {
@@ -200,6 +355,10 @@ func (m *stubMigration) TargetInfo() (*coremigration.TargetInfo, error) {
 	}, nil
 }
 
+func (m *stubMigration) Offline() bool {
+	return m.offline
+}
+
 func (m *stubMigration) SetPhase(phase coremigration.Phase) error {
 	if m.setPhaseErr != nil {
 		return m.setPhaseErr
@@ -208,6 +367,30 @@ func (m *stubMigration) SetPhase(phase coremigration.Phase) error {
 	return nil
 }
 
+func (m *stubMigration) SetStatusMessage(message string) error {
+	if m.setMessageErr != nil {
+		return m.setMessageErr
+	}
+	m.messageSet = message
+	return nil
+}
+
+func (m *stubMigration) SetUploadProgress(charmBytes, toolsBytes int64) error {
+	m.charmBytes = charmBytes
+	m.toolsBytes = toolsBytes
+	return nil
+}
+
+type stubConnection struct {
+	api.Connection
+	closed bool
+}
+
+func (c *stubConnection) Close() error {
+	c.closed = true
+	return nil
+}
+
 var modelUUID string
 var controllerUUID string
 
@@ -12,9 +12,12 @@ import (
 // migrationmaster facade.
 type Backend interface {
 	migration.StateExporter
+	migration.UploadBackend
 
 	WatchForModelMigration() state.NotifyWatcher
 	GetModelMigration() (state.ModelMigration, error)
+	NeedsCleanup() (bool, error)
+	RemoveExportingModelDocs() error
 }
 
 var getBackend = func(st *state.State) Backend {
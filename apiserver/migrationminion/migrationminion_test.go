@@ -11,7 +11,9 @@ import (
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/migrationminion"
+	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/testing"
 )
@@ -32,7 +34,9 @@ var _ = gc.Suite(&Suite{})
 func (s *Suite) SetUpTest(c *gc.C) {
 	s.BaseSuite.SetUpTest(c)
 
-	s.backend = &stubBackend{}
+	s.backend = &stubBackend{
+		migration: &stubMigration{id: "id"},
+	}
 	migrationminion.PatchState(s, s.backend)
 
 	s.resources = common.NewResources()
@@ -74,6 +78,62 @@ func (s *Suite) TestWatch(c *gc.C) {
 	c.Assert(s.resources.Get(result.NotifyWatcherId), gc.NotNil)
 }
 
+func (s *Suite) TestReport(c *gc.C) {
+	api := s.mustMakeAPI(c)
+	err := api.Report(params.MinionReport{
+		MigrationId: "id",
+		Phase:       "READONLY",
+		Success:     true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.backend.migration.reports, jc.DeepEquals, []stubReport{{
+		tag:     names.NewMachineTag("99"),
+		phase:   coremigration.READONLY,
+		success: true,
+	}})
+}
+
+func (s *Suite) TestReportInvalidPhase(c *gc.C) {
+	api := s.mustMakeAPI(c)
+	err := api.Report(params.MinionReport{
+		MigrationId: "id",
+		Phase:       "WTF",
+		Success:     true,
+	})
+	c.Assert(err, gc.ErrorMatches, "unable to parse phase")
+	c.Check(s.backend.migration.reports, gc.HasLen, 0)
+}
+
+func (s *Suite) TestReportNoMigration(c *gc.C) {
+	s.backend.getErr = errors.New("boom")
+	api := s.mustMakeAPI(c)
+	err := api.Report(params.MinionReport{
+		MigrationId: "id",
+		Phase:       "READONLY",
+	})
+	c.Assert(err, gc.ErrorMatches, "unable to load migration: boom")
+}
+
+func (s *Suite) TestReportWrongMigration(c *gc.C) {
+	api := s.mustMakeAPI(c)
+	err := api.Report(params.MinionReport{
+		MigrationId: "other",
+		Phase:       "READONLY",
+	})
+	c.Assert(err, gc.ErrorMatches, `migration id "other" not valid`)
+	c.Check(s.backend.migration.reports, gc.HasLen, 0)
+}
+
+func (s *Suite) TestReportError(c *gc.C) {
+	s.backend.migration.reportErr = errors.New("blam")
+	api := s.mustMakeAPI(c)
+	err := api.Report(params.MinionReport{
+		MigrationId: "id",
+		Phase:       "READONLY",
+	})
+	c.Assert(err, gc.ErrorMatches, "blam")
+}
+
 func (s *Suite) makeAPI() (*migrationminion.API, error) {
 	return migrationminion.NewAPI(nil, s.resources, s.authorizer)
 }
@@ -87,6 +147,8 @@ func (s *Suite) mustMakeAPI(c *gc.C) *migrationminion.API {
 type stubBackend struct {
 	migrationminion.Backend
 	watchError error
+	getErr     error
+	migration  *stubMigration
 }
 
 func (b *stubBackend) WatchMigrationStatus() (state.NotifyWatcher, error) {
@@ -95,3 +157,35 @@ func (b *stubBackend) WatchMigrationStatus() (state.NotifyWatcher, error) {
 	}
 	return apiservertesting.NewFakeNotifyWatcher(), nil
 }
+
+func (b *stubBackend) GetModelMigration() (state.ModelMigration, error) {
+	if b.getErr != nil {
+		return nil, b.getErr
+	}
+	return b.migration, nil
+}
+
+type stubMigration struct {
+	state.ModelMigration
+	id        string
+	reportErr error
+	reports   []stubReport
+}
+
+type stubReport struct {
+	tag     names.Tag
+	phase   coremigration.Phase
+	success bool
+}
+
+func (m *stubMigration) Id() string {
+	return m.id
+}
+
+func (m *stubMigration) SubmitMinionReport(tag names.Tag, phase coremigration.Phase, success bool) error {
+	if m.reportErr != nil {
+		return m.reportErr
+	}
+	m.reports = append(m.reports, stubReport{tag, phase, success})
+	return nil
+}
@@ -4,6 +4,8 @@
 package migrationtarget
 
 import (
+	"fmt"
+
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 
@@ -11,10 +13,12 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/migration"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/storage"
+	jujuversion "github.com/juju/juju/version"
 )
 
 func init() {
-	common.RegisterStandardFacade("MigrationTarget", 1, NewAPI)
+	common.RegisterStandardFacade("MigrationTarget", 2, NewAPI)
 }
 
 // API implements the API required for the model migration
@@ -69,6 +73,106 @@ func (api *API) Import(serialized params.SerializedModel) error {
 	return err
 }
 
+// CheckImport reports the reasons, if any, that a serialized Juju
+// model can't be imported into the receiving controller. Nothing is
+// imported.
+func (api *API) CheckImport(serialized params.SerializedModel) (params.MigrationImportCheckResult, error) {
+	blockers, err := migration.CheckImport(api.state, serialized.Bytes, jujuversion.Current)
+	if err != nil {
+		return params.MigrationImportCheckResult{}, errors.Trace(err)
+	}
+	return params.MigrationImportCheckResult{Blockers: blockers}, nil
+}
+
+// CheckImported reports the reasons, if any, that a model imported
+// into the receiving controller from a model archive can't take over
+// from the source model in an offline migration. The model must be
+// active, and must hold every machine and unit given.
+func (api *API) CheckImported(args params.MigrationImportedCheck) (params.MigrationImportCheckResult, error) {
+	var result params.MigrationImportCheckResult
+	tag, err := names.ParseModelTag(args.ModelTag)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	model, err := api.state.GetModel(tag)
+	if errors.IsNotFound(err) {
+		result.Blockers = []string{fmt.Sprintf("model %s not found on target controller", tag.Id())}
+		return result, nil
+	} else if err != nil {
+		return result, errors.Trace(err)
+	}
+	if mode := model.MigrationMode(); mode != state.MigrationModeActive {
+		result.Blockers = []string{fmt.Sprintf("model %s not active on target controller (migration mode %q)", tag.Id(), mode)}
+		return result, nil
+	}
+
+	st, err := api.state.ForModel(tag)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	defer st.Close()
+
+	for _, machine := range args.Machines {
+		machineTag, err := names.ParseMachineTag(machine)
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		if _, err := st.Machine(machineTag.Id()); errors.IsNotFound(err) {
+			result.Blockers = append(result.Blockers, fmt.Sprintf("machine %s not found on target controller", machineTag.Id()))
+		} else if err != nil {
+			return result, errors.Trace(err)
+		}
+	}
+	for _, unit := range args.Units {
+		unitTag, err := names.ParseUnitTag(unit)
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		if _, err := st.Unit(unitTag.Id()); errors.IsNotFound(err) {
+			result.Blockers = append(result.Blockers, fmt.Sprintf("unit %s not found on target controller", unitTag.Id()))
+		} else if err != nil {
+			return result, errors.Trace(err)
+		}
+	}
+	return result, nil
+}
+
+// MissingVolumes reports, for each storage pool given, the provider
+// volume IDs that can't be seen by the cloud of the receiving
+// controller. Volumes of providers not managed by the cloud are never
+// reported.
+func (api *API) MissingVolumes(args params.MigrationVolumeChecks) (params.StringsResults, error) {
+	results := params.StringsResults{
+		Results: make([]params.StringsResult, len(args.Checks)),
+	}
+	cfg, err := api.state.ModelConfig()
+	if err != nil {
+		return results, errors.Trace(err)
+	}
+	target := migration.NewPrecheckTarget(cfg)
+	for i, check := range args.Checks {
+		missing, err := missingVolumes(target, check)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = missing
+	}
+	return results, nil
+}
+
+func missingVolumes(target migration.PrecheckTarget, check params.MigrationVolumeCheck) ([]string, error) {
+	pool, err := storage.NewConfig(
+		check.Pool.Name,
+		storage.ProviderType(check.Pool.Provider),
+		check.Pool.Attrs,
+	)
+	if err != nil {
+		return nil, errors.Annotatef(err, "pool %q", check.Pool.Name)
+	}
+	return target.MissingVolumes(pool, check.VolumeIds)
+}
+
 func (api *API) getModel(args params.ModelArgs) (*state.Model, error) {
 	tag, err := names.ParseModelTag(args.ModelTag)
 	if err != nil {
@@ -101,13 +205,24 @@ func (api *API) Abort(args params.ModelArgs) error {
 	return st.RemoveImportingModelDocs()
 }
 
-// Activate sets the migration mode of the model to "active". It is an error to
-// attempt to Abort a model that has a migration mode other than importing.
+// Activate checks that the imported model is consistent with the
+// receiving controller and sets its migration mode to "active". It is
+// an error to attempt to Activate a model that has a migration mode
+// other than importing.
 func (api *API) Activate(args params.ModelArgs) error {
 	model, err := api.getModel(args)
 	if err != nil {
 		return errors.Trace(err)
 	}
 
+	st, err := api.state.ForModel(model.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer st.Close()
+
+	if err := migration.ValidateEndpointBindings(st); err != nil {
+		return errors.Trace(err)
+	}
 	return model.SetMigrationMode(state.MigrationModeActive)
 }
@@ -4,11 +4,14 @@
 package migrationtarget_test
 
 import (
+	"fmt"
+
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
+	"gopkg.in/yaml.v2"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/migrationtarget"
@@ -62,7 +65,7 @@ func (s *Suite) SetUpTest(c *gc.C) {
 }
 
 func (s *Suite) TestFacadeRegistered(c *gc.C) {
-	factory, err := common.Facades.GetFactory("MigrationTarget", 1)
+	factory, err := common.Facades.GetFactory("MigrationTarget", 2)
 	c.Assert(err, jc.ErrorIsNil)
 
 	api, err := factory(s.State, s.resources, s.authorizer, "")
@@ -99,6 +102,107 @@ func (s *Suite) TestImport(c *gc.C) {
 	c.Assert(model.MigrationMode(), gc.Equals, state.MigrationModeImporting)
 }
 
+func (s *Suite) TestCheckImport(c *gc.C) {
+	api := s.mustNewAPI(c)
+	uuid, bytes := s.makeExportedModel(c)
+
+	result, err := api.CheckImport(params.SerializedModel{Bytes: bytes})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Blockers, gc.HasLen, 0)
+
+	// Nothing was imported.
+	_, err = s.State.GetModel(names.NewModelTag(uuid))
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *Suite) TestCheckImportExistingModel(c *gc.C) {
+	api := s.mustNewAPI(c)
+	tag := s.importModel(c, api)
+	model, err := s.State.GetModel(tag)
+	c.Assert(err, jc.ErrorIsNil)
+	bytes, err := description.Serialize(s.exportModel(c, tag.Id(), model.Name()))
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := api.CheckImport(params.SerializedModel{Bytes: bytes})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Blockers, jc.DeepEquals, []string{
+		fmt.Sprintf("model %s already exists on target controller", tag.Id()),
+	})
+}
+
+func (s *Suite) TestCheckImportBadBytes(c *gc.C) {
+	api := s.mustNewAPI(c)
+	result, err := api.CheckImport(params.SerializedModel{Bytes: []byte("foo")})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Blockers, gc.HasLen, 1)
+	c.Check(result.Blockers[0], gc.Matches, "model description not supported: .*")
+}
+
+func (s *Suite) TestCheckImported(c *gc.C) {
+	unit := s.Factory.MakeUnit(c, nil)
+	machineId, err := unit.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	api := s.mustNewAPI(c)
+	tag := s.importModel(c, api)
+	err = api.Activate(params.ModelArgs{ModelTag: tag.String()})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := api.CheckImported(params.MigrationImportedCheck{
+		ModelTag: tag.String(),
+		Machines: []string{names.NewMachineTag(machineId).String(), "machine-42"},
+		Units:    []string{unit.Tag().String(), "unit-mysql-42"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Blockers, jc.DeepEquals, []string{
+		"machine 42 not found on target controller",
+		"unit mysql/42 not found on target controller",
+	})
+}
+
+func (s *Suite) TestCheckImportedMissingModel(c *gc.C) {
+	api := s.mustNewAPI(c)
+	uuid := utils.MustNewUUID().String()
+	result, err := api.CheckImported(params.MigrationImportedCheck{
+		ModelTag: names.NewModelTag(uuid).String(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Blockers, jc.DeepEquals, []string{
+		fmt.Sprintf("model %s not found on target controller", uuid),
+	})
+}
+
+func (s *Suite) TestCheckImportedNotActive(c *gc.C) {
+	api := s.mustNewAPI(c)
+	tag := s.importModel(c, api)
+	result, err := api.CheckImported(params.MigrationImportedCheck{
+		ModelTag: tag.String(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Blockers, jc.DeepEquals, []string{
+		fmt.Sprintf(`model %s not active on target controller (migration mode "importing")`, tag.Id()),
+	})
+}
+
+func (s *Suite) TestMissingVolumes(c *gc.C) {
+	api := s.mustNewAPI(c)
+	results, err := api.MissingVolumes(params.MigrationVolumeChecks{
+		Checks: []params.MigrationVolumeCheck{{
+			Pool:      params.StoragePool{Name: "loop", Provider: "loop"},
+			VolumeIds: []string{"vol-0"},
+		}, {
+			Pool:      params.StoragePool{Name: "bad", Provider: "bad"},
+			VolumeIds: []string{"vol-1"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+
+	// Loop volumes aren't managed by the cloud, so are never missing.
+	c.Check(results.Results[0].Error, gc.IsNil)
+	c.Check(results.Results[0].Result, gc.HasLen, 0)
+	c.Check(results.Results[1].Error, gc.ErrorMatches, `storage provider "bad" not found`)
+}
+
 func (s *Suite) TestAbort(c *gc.C) {
 	api := s.mustNewAPI(c)
 	tag := s.importModel(c, api)
@@ -148,6 +252,42 @@ func (s *Suite) TestActivate(c *gc.C) {
 	c.Assert(model.MigrationMode(), gc.Equals, state.MigrationModeActive)
 }
 
+func (s *Suite) TestActivateUnknownSpace(c *gc.C) {
+	_, err := s.State.AddSpace("db", "", nil, false)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddApplication(state.AddApplicationArgs{
+		Name:             "wordpress",
+		Charm:            s.Factory.MakeCharm(c, nil),
+		EndpointBindings: map[string]string{"db": "db"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	uuid, bytes := s.makeExportedModel(c)
+
+	// Drop the spaces from the serialized model so that the binding
+	// no longer resolves in the imported model.
+	var data map[string]interface{}
+	err = yaml.Unmarshal(bytes, &data)
+	c.Assert(err, jc.ErrorIsNil)
+	data["spaces"] = map[string]interface{}{
+		"version": 1,
+		"spaces":  []interface{}{},
+	}
+	bytes, err = yaml.Marshal(data)
+	c.Assert(err, jc.ErrorIsNil)
+
+	api := s.mustNewAPI(c)
+	err = api.Import(params.SerializedModel{Bytes: bytes})
+	c.Assert(err, jc.ErrorIsNil)
+	tag := names.NewModelTag(uuid)
+
+	err = api.Activate(params.ModelArgs{ModelTag: tag.String()})
+	c.Assert(err, gc.ErrorMatches, `application "wordpress" endpoint "db" bound to unknown space "db"`)
+
+	model, err := s.State.GetModel(tag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.MigrationMode(), gc.Equals, state.MigrationModeImporting)
+}
+
 func (s *Suite) TestActivateNotATag(c *gc.C) {
 	api := s.mustNewAPI(c)
 	err := api.Activate(params.ModelArgs{ModelTag: "not-a-tag"})
@@ -183,16 +323,19 @@ func (s *Suite) mustNewAPI(c *gc.C) *migrationtarget.API {
 }
 
 func (s *Suite) makeExportedModel(c *gc.C) (string, []byte) {
-	model, err := s.State.Export()
-	c.Assert(err, jc.ErrorIsNil)
-
 	newUUID := utils.MustNewUUID().String()
-	model.UpdateConfig(map[string]interface{}{
-		"name": "some-model",
-		"uuid": newUUID,
-	})
-
+	model := s.exportModel(c, newUUID, "some-model")
 	bytes, err := description.Serialize(model)
 	c.Assert(err, jc.ErrorIsNil)
 	return newUUID, bytes
 }
+
+func (s *Suite) exportModel(c *gc.C, uuid, name string) description.Model {
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+	model.UpdateConfig(map[string]interface{}{
+		"name": name,
+		"uuid": uuid,
+	})
+	return model
+}
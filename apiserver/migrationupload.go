@@ -0,0 +1,54 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"net/http"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/state"
+)
+
+// stateForMigrationUpload authenticates a request to upload binaries
+// for a model that is being migrated to this controller, and returns
+// the state of that model. The binaries are uploaded over the same
+// controller connection that the model was imported with, so the
+// model is named in the "model" query parameter. The caller is
+// responsible for closing the returned state.
+func stateForMigrationUpload(ctxt httpContext, r *http.Request) (*state.State, error) {
+	st, entity, err := ctxt.stateForRequestAuthenticatedUser(r)
+	if err != nil {
+		return nil, err
+	}
+	// Type assertion is fine because the entity is known to be a user.
+	isAdmin, err := st.IsControllerAdministrator(entity.Tag().(names.UserTag))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !isAdmin {
+		return nil, common.ErrPerm
+	}
+
+	modelUUID := r.URL.Query().Get("model")
+	if !names.IsValidModel(modelUUID) {
+		return nil, errors.BadRequestf("invalid model %q", modelUUID)
+	}
+	modelSt, err := st.ForModel(names.NewModelTag(modelUUID))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	model, err := modelSt.Model()
+	if err != nil {
+		modelSt.Close()
+		return nil, errors.Trace(err)
+	}
+	if model.MigrationMode() != state.MigrationModeImporting {
+		modelSt.Close()
+		return nil, errors.BadRequestf("model %q is not being imported", modelUUID)
+	}
+	return modelSt, nil
+}
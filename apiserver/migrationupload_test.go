@@ -0,0 +1,91 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/migrationtarget"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/migration"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testcharms"
+	"github.com/juju/juju/testing/factory"
+)
+
+// importArchiveSuite imports a model archive, as the import-model
+// command does, against a real controller.
+type importArchiveSuite struct {
+	jujutesting.JujuConnSuite
+}
+
+var _ = gc.Suite(&importArchiveSuite{})
+
+func (s *importArchiveSuite) TestImportArchive(c *gc.C) {
+	// Export a hosted model that uses a stored charm, and then remove
+	// it from the controller so that the archive can be imported.
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	curl := charm.MustParseURL("cs:quantal/dummy-1")
+	ch, err := jujutesting.AddCharm(st, curl, testcharms.Repo.CharmArchive(c.MkDir(), "dummy"))
+	c.Assert(err, jc.ErrorIsNil)
+	factory.NewFactory(st).MakeApplication(c, &factory.ApplicationParams{Name: "dummy", Charm: ch})
+
+	var buf bytes.Buffer
+	err = migration.ExportArchive(st, &buf)
+	c.Assert(err, jc.ErrorIsNil)
+	model, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	err = model.SetMigrationMode(state.MigrationModeImporting)
+	c.Assert(err, jc.ErrorIsNil)
+	err = st.RemoveImportingModelDocs()
+	c.Assert(err, jc.ErrorIsNil)
+
+	target := &importArchiveTarget{
+		Client: migrationtarget.NewClient(s.APIState),
+		client: s.APIState.Client(),
+	}
+	modelTag, err := migration.ImportArchive(&buf, target)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(modelTag, gc.Equals, st.ModelTag())
+
+	// The charm belongs to the imported model, not to the controller
+	// model that the archive was imported over.
+	imported, err := s.State.ForModel(modelTag)
+	c.Assert(err, jc.ErrorIsNil)
+	defer imported.Close()
+	sch, err := imported.Charm(curl)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(sch.IsUploaded(), jc.IsTrue)
+	c.Check(sch.BundleSha256(), gc.Equals, ch.BundleSha256())
+	_, err = s.State.Charm(curl)
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+// importArchiveTarget implements migration.ArchiveTarget in the same
+// way as the import-model command.
+type importArchiveTarget struct {
+	migrationtarget.Client
+	client *api.Client
+}
+
+func (t *importArchiveTarget) UploadModelTools(modelUUID string, vers version.Binary, content io.ReadSeeker) error {
+	return t.client.UploadModelTools(modelUUID, vers, content)
+}
+
+func (t *importArchiveTarget) UploadModelCharm(modelUUID string, curl *charm.URL, content io.ReadSeeker) error {
+	return t.client.UploadModelCharm(modelUUID, curl, content)
+}
+
+func (t *importArchiveTarget) UploadResource(modelUUID, application, name string, content io.ReadSeeker) error {
+	return t.client.UploadResource(modelUUID, application, name, content)
+}
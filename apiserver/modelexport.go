@@ -0,0 +1,91 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/migration"
+	"github.com/juju/juju/state"
+)
+
+// modelExportHandler handles requests for an archive of a model, which
+// can be imported into a controller that can't reach this one.
+type modelExportHandler struct {
+	ctxt httpContext
+}
+
+func (h *modelExportHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	st, entity, err := h.ctxt.stateForRequestAuthenticatedUser(r)
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+	// The archive holds everything needed to recreate the model
+	// elsewhere, so only controller administrators may export it.
+	// Type assertion is fine because the entity is known to be a user.
+	isAdmin, err := st.IsControllerAdministrator(entity.Tag().(names.UserTag))
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+	if !isAdmin {
+		sendError(w, common.ErrPerm)
+		return
+	}
+
+	switch r.Method {
+	case "GET":
+		logger.Infof("handling model export request for %s", st.ModelUUID())
+		if err := h.processGet(w, st); err != nil {
+			sendError(w, err)
+			return
+		}
+	default:
+		sendError(w, errors.MethodNotAllowedf("unsupported method: %q", r.Method))
+	}
+}
+
+// processGet writes the model archive to a temporary file before
+// sending it, so that export failures can be reported as errors rather
+// than as a truncated archive.
+func (h *modelExportHandler) processGet(w http.ResponseWriter, st *state.State) error {
+	archive, err := ioutil.TempFile("", "juju-model-export")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() {
+		archive.Close()
+		os.Remove(archive.Name())
+	}()
+
+	if err := migration.ExportArchive(st, archive); err != nil {
+		return errors.Annotate(err, "exporting model")
+	}
+	size, err := archive.Seek(0, os.SEEK_CUR)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := archive.Seek(0, os.SEEK_SET); err != nil {
+		return errors.Trace(err)
+	}
+
+	w.Header().Set("Content-Type", "application/x-tar-gz")
+	w.Header().Set("Content-Length", fmt.Sprint(size))
+	w.WriteHeader(http.StatusOK)
+	if _, err := io.Copy(w, archive); err != nil {
+		// The status has already been sent, so the error can only
+		// be logged.
+		logger.Errorf("failed to send model archive: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/description"
+	"github.com/juju/juju/testing/factory"
+)
+
+type modelExportSuite struct {
+	authHttpSuite
+}
+
+var _ = gc.Suite(&modelExportSuite{})
+
+func (s *modelExportSuite) SetUpTest(c *gc.C) {
+	s.authHttpSuite.SetUpTest(c)
+	s.userTag = s.AdminUserTag(c)
+	s.password = "dummy-secret"
+}
+
+func (s *modelExportSuite) exportURI(c *gc.C) string {
+	uri := s.baseURL(c)
+	uri.Path = fmt.Sprintf("/model/%s/export", s.State.ModelUUID())
+	return uri.String()
+}
+
+func (s *modelExportSuite) assertErrorResponse(c *gc.C, resp *http.Response, expCode int, expError string) {
+	body := assertResponse(c, resp, expCode, params.ContentTypeJSON)
+	var result params.ErrorResult
+	err := json.Unmarshal(body, &result)
+	c.Assert(err, jc.ErrorIsNil, gc.Commentf("body: %s", body))
+	c.Assert(result.Error, gc.NotNil)
+	c.Assert(result.Error.Message, gc.Matches, expError)
+}
+
+func (s *modelExportSuite) TestRequiresAuth(c *gc.C) {
+	resp := s.sendRequest(c, httpRequestParams{method: "GET", url: s.exportURI(c)})
+	s.assertErrorResponse(c, resp, http.StatusUnauthorized, "no credentials provided")
+}
+
+func (s *modelExportSuite) TestRequiresControllerAdmin(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{Password: "hunter2"})
+	resp := s.sendRequest(c, httpRequestParams{
+		tag:      user.Tag().String(),
+		password: "hunter2",
+		method:   "GET",
+		url:      s.exportURI(c),
+	})
+	s.assertErrorResponse(c, resp, http.StatusUnauthorized, "permission denied")
+}
+
+func (s *modelExportSuite) TestRequiresGET(c *gc.C) {
+	resp := s.authRequest(c, httpRequestParams{method: "POST", url: s.exportURI(c)})
+	s.assertErrorResponse(c, resp, http.StatusMethodNotAllowed, `unsupported method: "POST"`)
+}
+
+func (s *modelExportSuite) TestExport(c *gc.C) {
+	resp := s.authRequest(c, httpRequestParams{method: "GET", url: s.exportURI(c)})
+	body := assertResponse(c, resp, http.StatusOK, "application/x-tar-gz")
+
+	// The serialized model comes first in the archive.
+	gzipReader, err := gzip.NewReader(bytes.NewReader(body))
+	c.Assert(err, jc.ErrorIsNil)
+	tarReader := tar.NewReader(gzipReader)
+	header, err := tarReader.Next()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(header.Name, gc.Equals, "model.yaml")
+	modelBytes, err := ioutil.ReadAll(tarReader)
+	c.Assert(err, jc.ErrorIsNil)
+	model, err := description.Deserialize(modelBytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(model.Tag(), gc.Equals, s.State.ModelTag())
+}
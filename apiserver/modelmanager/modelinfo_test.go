@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/juju/errors"
+	"github.com/juju/loggo"
 	gitjujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils/series"
@@ -124,6 +125,7 @@ func (s *modelInfoSuite) TestModelInfo(c *gc.C) {
 		{"ForModel", []interface{}{names.NewModelTag(s.st.model.cfg.UUID())}},
 		{"Model", nil},
 		{"ControllerConfig", nil},
+		{"LastLogPrune", nil},
 		{"Close", nil},
 	})
 	s.st.model.CheckCalls(c, []gitjujutesting.StubCall{
@@ -151,6 +153,26 @@ func (s *modelInfoSuite) TestModelInfoNonOwner(c *gc.C) {
 	c.Assert(info.Users[0].UserName, gc.Equals, "charlotte@local")
 }
 
+func (s *modelInfoSuite) TestModelInfoLastLogPrune(c *gc.C) {
+	pruned := time.Date(2016, 8, 1, 12, 0, 0, 0, time.UTC)
+	s.st.lastLogPrune = &state.LogPrune{
+		Time:     pruned,
+		Expired:  map[loggo.Level]int{loggo.DEBUG: 20, loggo.INFO: 3},
+		Oversize: 5,
+	}
+	info := s.getModelInfo(c)
+	c.Assert(info.LastLogPrune, jc.DeepEquals, &params.ModelLogPruneInfo{
+		Time:     pruned,
+		Expired:  map[string]int{"DEBUG": 20, "INFO": 3},
+		Oversize: 5,
+	})
+}
+
+func (s *modelInfoSuite) TestModelInfoErrorLastLogPrune(c *gc.C) {
+	s.st.SetErrors(nil, nil, nil, errors.New("no pruning for you"))
+	s.testModelInfoError(c, coretesting.ModelTag.String(), `no pruning for you`)
+}
+
 func (s *modelInfoSuite) getModelInfo(c *gc.C) params.ModelInfo {
 	results, err := s.modelmanager.ModelInfo(params.Entities{
 		Entities: []params.Entity{{
@@ -218,6 +240,7 @@ type mockState struct {
 	controllerModel *mockModel
 	users           []*state.ModelUser
 	creds           map[string]cloud.Credential
+	lastLogPrune    *state.LogPrune
 }
 
 func (st *mockState) ModelUUID() string {
@@ -279,6 +302,17 @@ func (st *mockState) Model() (common.Model, error) {
 	return st.model, st.NextErr()
 }
 
+func (st *mockState) LastLogPrune() (*state.LogPrune, error) {
+	st.MethodCall(st, "LastLogPrune")
+	if err := st.NextErr(); err != nil {
+		return nil, err
+	}
+	if st.lastLogPrune == nil {
+		return nil, errors.NotFoundf("log pruning")
+	}
+	return st.lastLogPrune, nil
+}
+
 func (st *mockState) ModelTag() names.ModelTag {
 	st.MethodCall(st, "ModelTag")
 	return st.model.ModelTag()
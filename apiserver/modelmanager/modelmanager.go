@@ -412,6 +412,20 @@ func (m *ModelManagerAPI) getModelInfo(tag names.ModelTag) (params.ModelInfo, er
 		return params.ModelInfo{}, common.ErrPerm
 	}
 
+	prune, err := st.LastLogPrune()
+	if err == nil {
+		info.LastLogPrune = &params.ModelLogPruneInfo{
+			Time:     prune.Time,
+			Expired:  make(map[string]int),
+			Oversize: prune.Oversize,
+		}
+		for level, count := range prune.Expired {
+			info.LastLogPrune.Expired[level.String()] = count
+		}
+	} else if !errors.IsNotFound(err) {
+		return params.ModelInfo{}, errors.Trace(err)
+	}
+
 	return info, nil
 }
 
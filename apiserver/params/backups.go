@@ -12,6 +12,18 @@ import (
 // BackupsCreateArgs holds the args for the API Create method.
 type BackupsCreateArgs struct {
 	Notes string `json:"notes"`
+
+	// Passphrase, if set, is the passphrase with which the backup
+	// archive is encrypted.
+	Passphrase string `json:"passphrase,omitempty"`
+
+	// PublicKey, if set, is the ASCII-armored OpenPGP public key to
+	// which the backup archive is encrypted.
+	PublicKey string `json:"public-key,omitempty"`
+
+	// ModelUUID, if set, is the UUID of the single model to back up,
+	// rather than the whole controller.
+	ModelUUID string `json:"model-uuid,omitempty"`
 }
 
 // BackupsInfoArgs holds the args for the API Info method.
@@ -39,6 +51,24 @@ type BackupsRemoveArgs struct {
 	ID string `json:"id"`
 }
 
+// BackupsVerifyArgs holds the args for the API Verify method.
+type BackupsVerifyArgs struct {
+	ID string `json:"id"`
+
+	// Passphrase and PrivateKey decrypt an encrypted backup archive,
+	// as for RestoreArgs.
+	Passphrase string `json:"passphrase,omitempty"`
+	PrivateKey string `json:"private-key,omitempty"`
+}
+
+// BackupsVerifyResult describes the content of a verified backup.
+type BackupsVerifyResult struct {
+	Version     version.Number `json:"version"`
+	ModelCount  int            `json:"model-count"`
+	Collections int            `json:"collections"`
+	Documents   int            `json:"documents"`
+}
+
 // BackupsListResult holds the list of all stored backups.
 type BackupsListResult struct {
 	List []BackupsMetadataResult `json:"list"`
@@ -59,21 +89,51 @@ type BackupsMetadataResult struct {
 	Size           int64     `json:"size"`
 	Stored         time.Time `json:"stored"` // May be zero...
 
-	Started  time.Time      `json:"started"`
-	Finished time.Time      `json:"finished"` // May be zero...
-	Notes    string         `json:"notes"`
-	Model    string         `json:"model"`
-	Machine  string         `json:"machine"`
-	Hostname string         `json:"hostname"`
-	Version  version.Number `json:"version"`
-	Series   string         `json:"series"`
+	Started    time.Time      `json:"started"`
+	Finished   time.Time      `json:"finished"` // May be zero...
+	Notes      string         `json:"notes"`
+	Schedule   string         `json:"schedule,omitempty"`
+	Encryption string         `json:"encryption,omitempty"`
+	ModelUUID  string         `json:"model-uuid,omitempty"`
+	Model      string         `json:"model"`
+	Machine    string         `json:"machine"`
+	Hostname   string         `json:"hostname"`
+	Version    version.Number `json:"version"`
+	Series     string         `json:"series"`
 
 	CACert       string `json:"ca-cert"`
 	CAPrivateKey string `json:"ca-private-key"`
 }
 
+// BackupsScheduleStatusResult holds the status of scheduled backups,
+// as returned by the API ScheduleStatus method.
+type BackupsScheduleStatusResult struct {
+	// Schedule is the configured backup schedule, or "" if
+	// backups are only created on demand.
+	Schedule string `json:"schedule"`
+
+	LastRun      time.Time `json:"last-run"` // May be zero...
+	LastBackupID string    `json:"last-backup-id,omitempty"`
+	LastError    string    `json:"last-error,omitempty"`
+	NextRun      time.Time `json:"next-run"` // May be zero...
+}
+
 // RestoreArgs Holds the backup file or id
 type RestoreArgs struct {
 	// BackupId holds the id of the backup in server if any
 	BackupId string `json:"backup-id"`
+
+	// Passphrase is the passphrase with which an encrypted backup
+	// archive is decrypted, or with which PrivateKey is unlocked.
+	Passphrase string `json:"passphrase,omitempty"`
+
+	// PrivateKey is the ASCII-armored OpenPGP private key with which
+	// a backup archive encrypted to a public key is decrypted.
+	PrivateKey string `json:"private-key,omitempty"`
+}
+
+// RestoreModelResult holds the result of restoring a model backup.
+type RestoreModelResult struct {
+	// ModelTag is the tag of the restored model.
+	ModelTag string `json:"model-tag"`
 }
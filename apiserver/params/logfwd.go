@@ -3,6 +3,10 @@
 
 package params
 
+import (
+	"time"
+)
+
 // LogForwardingID is the API data that identifies a log forwarding
 // "last sent" value. The controller has a mapping from a set of IDs
 // to a timestamp (for each ID). The timestamp corresponds to the last
@@ -10,6 +14,7 @@ package params
 // identified "sink" (for a given model).
 type LogForwardingID struct {
 	// ModelTag identifies the model associated with the log record.
+	// If it is empty then the ID covers the log records of all models.
 	ModelTag string `json:"model"`
 
 	// Sink is the name of the log forwarding target to which a log
@@ -62,3 +67,30 @@ type LogForwardingSetLastSentParam struct {
 	// RecordID identifies the record ID to set for the given ID.
 	RecordID int64 `json:"record-id"`
 }
+
+// LogForwardingModelConfigResults holds the results of a call to the
+// ModelConfigs method of the LogForwarding facade.
+type LogForwardingModelConfigResults struct {
+	// Results holds the log forwarding config of each hosted model
+	// that has one.
+	Results []LogForwardingModelConfig `json:"results"`
+}
+
+// LogForwardingModelConfig holds the log forwarding config of a
+// single hosted model.
+type LogForwardingModelConfig struct {
+	ModelTag      string        `json:"model"`
+	Enabled       bool          `json:"enabled"`
+	Type          string        `json:"type"`
+	Endpoint      string        `json:"endpoint"`
+	CACert        string        `json:"ca-cert,omitempty"`
+	ClientCert    string        `json:"client-cert,omitempty"`
+	ClientKey     string        `json:"client-key,omitempty"`
+	BatchSize     int           `json:"batch-size,omitempty"`
+	FlushInterval time.Duration `json:"flush-interval,omitempty"`
+	MinLevel      string        `json:"min-level,omitempty"`
+	IncludeEntity []string      `json:"include-entity,omitempty"`
+	ExcludeEntity []string      `json:"exclude-entity,omitempty"`
+	IncludeModule []string      `json:"include-module,omitempty"`
+	ExcludeModule []string      `json:"exclude-module,omitempty"`
+}
@@ -39,4 +39,9 @@ type LogStreamConfig struct {
 	// This is used as a bookmark for where to start the next time logs
 	// are streamed for the same sink.
 	Sink string `schema:"sink" url:"sink,omitempty"`
+
+	// Model, if set, is the UUID of the model whose logs should be
+	// included, rather than the connection's model. Only a controller
+	// agent connected to the controller model may set it.
+	Model string `schema:"model" url:"model,omitempty"`
 }
@@ -3,6 +3,8 @@
 
 package params
 
+import "time"
+
 // InitiateModelMigrationArgs holds the details required to start one
 // or more model migrations.
 type InitiateModelMigrationArgs struct {
@@ -14,6 +16,12 @@ type InitiateModelMigrationArgs struct {
 type ModelMigrationSpec struct {
 	ModelTag   string                   `json:"model-tag"`
 	TargetInfo ModelMigrationTargetInfo `json:"target-info"`
+
+	// Offline is true when the model has already been imported into
+	// the target controller from a model archive, so only the
+	// model's agents need to be migrated. The target controller is
+	// still checked before the source model is removed.
+	Offline bool `json:"offline,omitempty"`
 }
 
 // ModelMigrationTargetInfo holds the details required to connect to
@@ -40,17 +48,136 @@ type InitiateModelMigrationResult struct {
 	Id       string `json:"id"` // the ID for the migration attempt
 }
 
+// MigrationDryRunResults is used to return the result of one or more
+// model migration dry runs.
+type MigrationDryRunResults struct {
+	Results []MigrationDryRunResult `json:"results"`
+}
+
+// MigrationDryRunResult is used to return the result of one model
+// migration dry run. Blockers holds everything that would stop the
+// migration from succeeding.
+type MigrationDryRunResult struct {
+	ModelTag string   `json:"model-tag"`
+	Error    *Error   `json:"error,omitempty"`
+	Blockers []string `json:"blockers,omitempty"`
+}
+
+// MigrationProgressResults is used to return the progress of the
+// latest migration of one or more models.
+type MigrationProgressResults struct {
+	Results []MigrationProgressResult `json:"results"`
+}
+
+// MigrationProgressResult is used to return the progress of the
+// latest migration of one model.
+type MigrationProgressResult struct {
+	ModelTag string             `json:"model-tag"`
+	Error    *Error             `json:"error,omitempty"`
+	Progress *MigrationProgress `json:"progress,omitempty"`
+}
+
+// MigrationProgress reports the progress of a model migration.
+type MigrationProgress struct {
+	MigrationId      string               `json:"migration-id"`
+	Phase            string               `json:"phase"`
+	StatusMessage    string               `json:"status-message,omitempty"`
+	AbortReason      string               `json:"abort-reason,omitempty"`
+	StartTime        time.Time            `json:"start-time"`
+	PhaseChangedTime time.Time            `json:"phase-changed-time"`
+	EndTime          *time.Time           `json:"end-time,omitempty"`
+	Phases           []MigrationPhaseTime `json:"phases"`
+
+	// MinionsSucceeded and MinionsFailed count the agents which
+	// have reported on the current phase. FailedMinions holds the
+	// tags of the agents which failed.
+	MinionsSucceeded int      `json:"minions-succeeded"`
+	MinionsFailed    int      `json:"minions-failed"`
+	FailedMinions    []string `json:"failed-minions,omitempty"`
+
+	CharmBytesUploaded int64 `json:"charm-bytes-uploaded"`
+	ToolsBytesUploaded int64 `json:"tools-bytes-uploaded"`
+}
+
+// MigrationPhaseTime records when a model migration entered a phase.
+type MigrationPhaseTime struct {
+	Phase   string    `json:"phase"`
+	Entered time.Time `json:"entered"`
+}
+
+// CloneModelArgs holds the details required to clone one or more
+// models.
+type CloneModelArgs struct {
+	Specs []CloneModelSpec `json:"specs"`
+}
+
+// CloneModelSpec holds the details required to clone a single model.
+type CloneModelSpec struct {
+	ModelTag string `json:"model-tag"`
+	Name     string `json:"name"`
+}
+
+// CloneModelResults is used to return the result of one or more
+// attempts to clone models.
+type CloneModelResults struct {
+	Results []CloneModelResult `json:"results"`
+}
+
+// CloneModelResult is used to return the result of one attempt to
+// clone a model. ModelTag is the tag of the model that was cloned and
+// CloneTag the tag of the new model.
+type CloneModelResult struct {
+	ModelTag string `json:"model-tag"`
+	Error    *Error `json:"error,omitempty"`
+	CloneTag string `json:"clone-tag,omitempty"`
+}
+
 // SetMigrationPhaseArgs provides a migration phase to the
 // migrationmaster.SetPhase API method.
 type SetMigrationPhaseArgs struct {
 	Phase string `json:"phase"`
 }
 
+// SetMigrationStatusMessageArgs provides a human readable message
+// about the progress of a model migration.
+type SetMigrationStatusMessageArgs struct {
+	Message string `json:"message"`
+}
+
 // SerializedModel wraps a buffer contain a serialised Juju model.
 type SerializedModel struct {
 	Bytes []byte `json:"bytes"`
 }
 
+// MigrationImportCheckResult holds the reasons, if any, that a
+// serialized model can't be imported into a controller.
+type MigrationImportCheckResult struct {
+	Blockers []string `json:"blockers,omitempty"`
+}
+
+// MigrationVolumeChecks holds the provider volumes of a model being
+// migrated, grouped by the storage pool they were created with.
+type MigrationVolumeChecks struct {
+	Checks []MigrationVolumeCheck `json:"checks"`
+}
+
+// MigrationVolumeCheck holds the provider IDs of volumes created with
+// a storage pool, so the target controller can check that its cloud
+// can see them.
+type MigrationVolumeCheck struct {
+	Pool      StoragePool `json:"pool"`
+	VolumeIds []string    `json:"volume-ids"`
+}
+
+// MigrationImportedCheck holds the machines and units of a model
+// being migrated offline, so the target controller can check that the
+// model it imported from a model archive holds all of them.
+type MigrationImportedCheck struct {
+	ModelTag string   `json:"model-tag"`
+	Machines []string `json:"machines"`
+	Units    []string `json:"units"`
+}
+
 // ModelArgs wraps a simple model tag.
 type ModelArgs struct {
 	ModelTag string `json:"model-tag"`
@@ -58,8 +185,9 @@ type ModelArgs struct {
 
 // MigrationStatus reports the current status of a model migration.
 type MigrationStatus struct {
-	Attempt int    `json:"attempt"`
-	Phase   string `json:"phase"`
+	MigrationId string `json:"migration-id"`
+	Attempt     int    `json:"attempt"`
+	Phase       string `json:"phase"`
 
 	// TODO(mjs): I'm not convinced these Source fields will get used.
 	SourceAPIAddrs []string `json:"source-api-addrs"`
@@ -86,3 +214,25 @@ type PhaseResult struct {
 type PhaseResults struct {
 	Results []PhaseResult `json:"results"`
 }
+
+// MinionReport holds the details of whether a migration minion
+// succeeded or failed for a specific migration phase.
+type MinionReport struct {
+	// MigrationId holds the id of the migration the agent is
+	// reporting about.
+	MigrationId string `json:"migration-id"`
+
+	// Phase holds the phase of the migration the agent is
+	// reporting about.
+	Phase string `json:"phase"`
+
+	// Success is true if the agent successfully completed its
+	// actions for the migration phase, false otherwise.
+	Success bool `json:"success"`
+}
+
+// ResourceUploadResult is returned when a resource blob is uploaded
+// for a model that is being imported.
+type ResourceUploadResult struct {
+	ID string `json:"id"`
+}
@@ -67,6 +67,26 @@ type ModelInfo struct {
 	// to the model. Owners and administrators can see all users
 	// that have access; other users can only see their own details.
 	Users []ModelUserInfo `json:"users"`
+
+	// LastLogPrune describes the last pruning of the model's logs
+	// that removed any records, if there has been one.
+	LastLogPrune *ModelLogPruneInfo `json:"last-log-prune,omitempty"`
+}
+
+// ModelLogPruneInfo describes a pruning of a model's logs.
+type ModelLogPruneInfo struct {
+	// Time is when the logs were pruned.
+	Time time.Time `json:"time"`
+
+	// Expired holds the number of records, by level name, that were
+	// removed for being older than the model's retention allows.
+	// Records removed under the model-wide retention, rather than the
+	// retention of their level, are counted under "UNSPECIFIED".
+	Expired map[string]int `json:"expired,omitempty"`
+
+	// Oversize holds the number of records that were removed to
+	// bring the logs collection within its maximum size.
+	Oversize int `json:"oversize,omitempty"`
 }
 
 // ModelInfoResult holds the result of a ModelInfo call.
@@ -782,6 +782,19 @@ type LogRecord struct {
 	Message  string    `json:"x"`
 }
 
+// DebugLogRecord is a log message sent by the debug-log API endpoint
+// when JSON output is requested. Unlike LogRecord, it is meant to be
+// read by people and their tools, so the field names are descriptive.
+type DebugLogRecord struct {
+	ModelUUID string    `json:"model-uuid"`
+	Entity    string    `json:"entity"`
+	Timestamp time.Time `json:"timestamp"`
+	Level     string    `json:"level"`
+	Module    string    `json:"module"`
+	Location  string    `json:"location"`
+	Message   string    `json:"message"`
+}
+
 // GetBundleChangesParams holds parameters for making GetBundleChanges calls.
 type GetBundleChangesParams struct {
 	// BundleDataYAML is the YAML-encoded charm bundle data
@@ -18,6 +18,30 @@ type SetMachineBlockDevices struct {
 	MachineBlockDevices []MachineBlockDevices `json:"machine-block-devices"`
 }
 
+// FilesystemUsage describes the capacity usage of a mounted filesystem.
+type FilesystemUsage struct {
+	// MountPoint is the path at which the filesystem is mounted.
+	MountPoint string `json:"mount-point,omitempty"`
+
+	UsedBytes  uint64 `json:"used-bytes"`
+	FreeBytes  uint64 `json:"free-bytes"`
+	UsedInodes uint64 `json:"used-inodes"`
+	FreeInodes uint64 `json:"free-inodes"`
+}
+
+// MachineFilesystemUsage holds a machine tag and the capacity usage
+// of the Juju-managed filesystems mounted on that machine.
+type MachineFilesystemUsage struct {
+	Machine string            `json:"machine"`
+	Usage   []FilesystemUsage `json:"usage,omitempty"`
+}
+
+// SetMachineFilesystemUsage holds the arguments for recording the
+// capacity usage of filesystems mounted on a set of machines.
+type SetMachineFilesystemUsage struct {
+	MachineFilesystemUsage []MachineFilesystemUsage `json:"machine-filesystem-usage"`
+}
+
 // BlockDeviceResult holds the result of an API call to retrieve details
 // of a block device.
 type BlockDeviceResult struct {
@@ -175,6 +199,7 @@ type VolumeInfo struct {
 	// Size is the size of the volume in MiB.
 	Size       uint64 `json:"size"`
 	Persistent bool   `json:"persistent"`
+	Encrypted  bool   `json:"encrypted,omitempty"`
 }
 
 // Volumes describes a set of storage volumes in the model.
@@ -294,7 +319,8 @@ type Filesystem struct {
 type FilesystemInfo struct {
 	FilesystemId string `json:"filesystem-id"`
 	// Size is the size of the filesystem in MiB.
-	Size uint64 `json:"size"`
+	Size      uint64 `json:"size"`
+	Encrypted bool   `json:"encrypted,omitempty"`
 }
 
 // Filesystems describes a set of storage filesystems in the model.
@@ -313,6 +339,10 @@ type FilesystemAttachment struct {
 type FilesystemAttachmentInfo struct {
 	MountPoint string `json:"mount-point,omitempty"`
 	ReadOnly   bool   `json:"read-only,omitempty"`
+
+	// Usage is the capacity usage of the attached filesystem, as last
+	// reported by the machine agent, if any.
+	Usage *FilesystemUsage `json:"usage,omitempty"`
 }
 
 // FilesystemAttachments describes a set of storage filesystem attachments.
@@ -409,6 +439,10 @@ type StorageDetails struct {
 	// the machine that it is attached to.
 	Persistent bool `json:"persistent"`
 
+	// Encrypted reports whether or not the underlying volume or
+	// filesystem is encrypted at rest.
+	Encrypted bool `json:"encrypted,omitempty"`
+
 	// Attachments contains a mapping from unit tag to
 	// storage attachment details.
 	Attachments map[string]StorageAttachmentDetails `json:"attachments,omitempty"`
@@ -462,6 +496,10 @@ type StorageAttachmentDetails struct {
 	// Location holds location (mount point/device path) of
 	// the attached storage.
 	Location string `json:"location,omitempty"`
+
+	// Usage is the capacity usage of the attached filesystem, if
+	// the storage is a filesystem and usage has been reported.
+	Usage *FilesystemUsage `json:"usage,omitempty"`
 }
 
 // StoragePool holds data for a pool instance.
@@ -476,6 +514,26 @@ type StoragePool struct {
 	Attrs map[string]interface{} `json:"attrs"`
 }
 
+// StoragePoolArgs holds a collection of storage pools.
+type StoragePoolArgs struct {
+	Pools []StoragePool `json:"pools"`
+}
+
+// StoragePoolDeleteArg holds the name of a storage pool to remove.
+type StoragePoolDeleteArg struct {
+	// Name is the name of the pool to remove.
+	Name string `json:"name"`
+
+	// Force, if true, removes the pool even if it is still
+	// referenced by storage in the model.
+	Force bool `json:"force,omitempty"`
+}
+
+// StoragePoolDeleteArgs holds a collection of storage pools to remove.
+type StoragePoolDeleteArgs struct {
+	Pools []StoragePoolDeleteArg `json:"pools"`
+}
+
 // StoragePoolFilter holds a filter for matching storage pools.
 type StoragePoolFilter struct {
 	// Names are pool's names to filter on.
@@ -0,0 +1,71 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"net/http"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// resourcesMigrationUploadHandler handles the upload of resource blobs
+// for a model that is being migrated to this controller.
+type resourcesMigrationUploadHandler struct {
+	ctxt httpContext
+}
+
+func (h *resourcesMigrationUploadHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case "POST":
+		res, err := h.processPost(r)
+		if err != nil {
+			sendError(w, err)
+			return
+		}
+		sendStatusAndJSON(w, http.StatusOK, &params.ResourceUploadResult{
+			ID: res,
+		})
+	default:
+		sendError(w, errors.MethodNotAllowedf("unsupported method: %q", r.Method))
+	}
+}
+
+// processPost stores the uploaded resource blob against the resource
+// metadata imported with the model, and returns the resource ID.
+func (h *resourcesMigrationUploadHandler) processPost(r *http.Request) (string, error) {
+	st, err := stateForMigrationUpload(h.ctxt, r)
+	if err != nil {
+		return "", err
+	}
+	defer st.Close()
+
+	query := r.URL.Query()
+	application := query.Get("application")
+	if !names.IsValidApplication(application) {
+		return "", errors.BadRequestf("invalid application %q", application)
+	}
+	name := query.Get("name")
+	if name == "" {
+		return "", errors.BadRequestf("missing resource name")
+	}
+
+	resources, err := st.Resources()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	res, err := resources.GetResource(application, name)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	// The imported metadata is kept, apart from the timestamp which
+	// SetResource always sets to when the blob is stored.
+	stored, err := resources.SetResource(application, res.Username, res.Resource, r.Body)
+	if err != nil {
+		return "", errors.Annotatef(err, "storing resource %q", name)
+	}
+	return stored.ID, nil
+}
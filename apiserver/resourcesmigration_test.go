@@ -0,0 +1,137 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/component/all"
+	"github.com/juju/juju/resource/resourcetesting"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+func init() {
+	if err := all.RegisterForServer(); err != nil {
+		panic(err)
+	}
+}
+
+type resourcesMigrationSuite struct {
+	authHttpSuite
+	importing *state.State
+}
+
+var _ = gc.Suite(&resourcesMigrationSuite{})
+
+func (s *resourcesMigrationSuite) SetUpTest(c *gc.C) {
+	s.authHttpSuite.SetUpTest(c)
+	s.userTag = s.AdminUserTag(c)
+	s.password = "dummy-secret"
+
+	s.importing = s.Factory.MakeModel(c, nil)
+	s.AddCleanup(func(*gc.C) { s.importing.Close() })
+	f := factory.NewFactory(s.importing)
+	application := f.MakeApplication(c, &factory.ApplicationParams{Name: "app"})
+
+	// The resource metadata arrives with the imported model, which
+	// is simulated here by setting a resource before the model is
+	// put into importing mode.
+	resources, err := s.importing.Resources()
+	c.Assert(err, jc.ErrorIsNil)
+	res := resourcetesting.NewCharmResource(c, "spam", "spamspamspam")
+	_, err = resources.SetResource(application.Name(), "bob", res, strings.NewReader("spamspamspam"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := s.importing.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	err = model.SetMigrationMode(state.MigrationModeImporting)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *resourcesMigrationSuite) resourcesURI(c *gc.C, query url.Values) string {
+	uri := s.baseURL(c)
+	uri.Path = fmt.Sprintf("/model/%s/migrate/resources", s.State.ModelUUID())
+	uri.RawQuery = query.Encode()
+	return uri.String()
+}
+
+func (s *resourcesMigrationSuite) query(model, application, name string) url.Values {
+	return url.Values{
+		"model":       {model},
+		"application": {application},
+		"name":        {name},
+	}
+}
+
+func (s *resourcesMigrationSuite) assertErrorResponse(c *gc.C, resp *http.Response, expCode int, expError string) {
+	body := assertResponse(c, resp, expCode, params.ContentTypeJSON)
+	var result params.ErrorResult
+	err := json.Unmarshal(body, &result)
+	c.Assert(err, jc.ErrorIsNil, gc.Commentf("body: %s", body))
+	c.Assert(result.Error, gc.NotNil)
+	c.Assert(result.Error.Message, gc.Matches, expError)
+}
+
+func (s *resourcesMigrationSuite) TestRequiresAuth(c *gc.C) {
+	resp := s.sendRequest(c, httpRequestParams{method: "POST", url: s.resourcesURI(c, nil)})
+	s.assertErrorResponse(c, resp, http.StatusUnauthorized, "no credentials provided")
+}
+
+func (s *resourcesMigrationSuite) TestRequiresControllerAdmin(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{Password: "hunter2"})
+	resp := s.sendRequest(c, httpRequestParams{
+		tag:      user.Tag().String(),
+		password: "hunter2",
+		method:   "POST",
+		url:      s.resourcesURI(c, s.query(s.importing.ModelUUID(), "app", "spam")),
+	})
+	s.assertErrorResponse(c, resp, http.StatusUnauthorized, "permission denied")
+}
+
+func (s *resourcesMigrationSuite) TestRequiresPOST(c *gc.C) {
+	resp := s.authRequest(c, httpRequestParams{method: "PUT", url: s.resourcesURI(c, nil)})
+	s.assertErrorResponse(c, resp, http.StatusMethodNotAllowed, `unsupported method: "PUT"`)
+}
+
+func (s *resourcesMigrationSuite) TestRequiresImportingModel(c *gc.C) {
+	resp := s.authRequest(c, httpRequestParams{
+		method: "POST",
+		url:    s.resourcesURI(c, s.query(s.State.ModelUUID(), "app", "spam")),
+	})
+	s.assertErrorResponse(c, resp, http.StatusBadRequest, `model ".*" is not being imported`)
+}
+
+func (s *resourcesMigrationSuite) TestUpload(c *gc.C) {
+	resp := s.authRequest(c, httpRequestParams{
+		method:      "POST",
+		url:         s.resourcesURI(c, s.query(s.importing.ModelUUID(), "app", "spam")),
+		contentType: "application/octet-stream",
+		body:        strings.NewReader("spamspamspam"),
+	})
+	body := assertResponse(c, resp, http.StatusOK, params.ContentTypeJSON)
+	var result params.ResourceUploadResult
+	err := json.Unmarshal(body, &result)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.ID, gc.Equals, "app/spam")
+
+	resources, err := s.importing.Resources()
+	c.Assert(err, jc.ErrorIsNil)
+	res, reader, err := resources.OpenResource("app", "spam")
+	c.Assert(err, jc.ErrorIsNil)
+	defer reader.Close()
+	c.Check(res.Username, gc.Equals, "bob")
+	data, err := ioutil.ReadAll(reader)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, "spamspamspam")
+}
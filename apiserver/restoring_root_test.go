@@ -21,7 +21,7 @@ var _ = gc.Suite(&restoreRootSuite{})
 func (r *restoreRootSuite) TestFindAllowedMethodWhenPreparing(c *gc.C) {
 	root := apiserver.TestingAboutToRestoreRoot(nil)
 
-	caller, err := root.FindMethod("Backups", 1, "Restore")
+	caller, err := root.FindMethod("Backups", 2, "Restore")
 
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(caller, gc.NotNil)
@@ -6,6 +6,7 @@ package storage_test
 import (
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/set"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
 
@@ -42,6 +43,7 @@ type baseStorageSuite struct {
 
 	poolManager *mockPoolManager
 	pools       map[string]*jujustorage.Config
+	poolsInUse  set.Strings
 
 	blocks map[state.BlockType]state.Block
 }
@@ -54,6 +56,7 @@ func (s *baseStorageSuite) SetUpTest(c *gc.C) {
 	s.state = s.constructState()
 
 	s.pools = make(map[string]*jujustorage.Config)
+	s.poolsInUse = make(set.Strings)
 	s.poolManager = s.constructPoolManager()
 
 	var err error
@@ -84,6 +87,7 @@ const (
 	addStorageForUnitCall                   = "addStorageForUnit"
 	getBlockForTypeCall                     = "getBlockForType"
 	volumeAttachmentCall                    = "volumeAttachment"
+	storagePoolInUseCall                    = "storagePoolInUse"
 )
 
 func (s *baseStorageSuite) constructState() *mockState {
@@ -227,6 +231,10 @@ func (s *baseStorageSuite) constructState() *mockState {
 			val, found := s.blocks[t]
 			return val, found, nil
 		},
+		storagePoolInUse: func(name string) (bool, error) {
+			s.calls = append(s.calls, storagePoolInUseCall)
+			return s.poolsInUse.Contains(name), nil
+		},
 	}
 }
 
@@ -267,6 +275,15 @@ func (s *baseStorageSuite) constructPoolManager() *mockPoolManager {
 			s.pools[name] = pool
 			return pool, err
 		},
+		replacePool: func(name string, attrs map[string]interface{}) (*jujustorage.Config, error) {
+			existing, ok := s.pools[name]
+			if !ok {
+				return nil, errors.NotFoundf("mock pool manager: replace pool %v", name)
+			}
+			pool, err := jujustorage.NewConfig(name, existing.Provider(), attrs)
+			s.pools[name] = pool
+			return pool, err
+		},
 		deletePool: func(name string) error {
 			delete(s.pools, name)
 			return nil
@@ -148,3 +148,37 @@ func (s *filesystemSuite) TestListFilesystemsVolumeBacked(c *gc.C) {
 	c.Assert(found.Results[0].Result, gc.HasLen, 1)
 	c.Assert(found.Results[0].Result[0], jc.DeepEquals, expected)
 }
+
+func (s *filesystemSuite) TestListFilesystemsAttachmentUsage(c *gc.C) {
+	s.filesystemAttachment.info = &state.FilesystemAttachmentInfo{
+		MountPoint: "/srv",
+	}
+	s.filesystemAttachment.usage = &state.FilesystemUsage{
+		UsedBytes:  1024,
+		FreeBytes:  3072,
+		UsedInodes: 10,
+		FreeInodes: 90,
+	}
+	expectedUsage := &params.FilesystemUsage{
+		UsedBytes:  1024,
+		FreeBytes:  3072,
+		UsedInodes: 10,
+		FreeInodes: 90,
+	}
+	expected := s.expectedFilesystemDetails()
+	expected.MachineAttachments[s.machineTag.String()] = params.FilesystemAttachmentInfo{
+		MountPoint: "/srv",
+		Usage:      expectedUsage,
+	}
+	expectedStorageAttachmentDetails := expected.Storage.Attachments["unit-mysql-0"]
+	expectedStorageAttachmentDetails.Location = "/srv"
+	expectedStorageAttachmentDetails.Usage = expectedUsage
+	expected.Storage.Attachments["unit-mysql-0"] = expectedStorageAttachmentDetails
+	found, err := s.api.ListFilesystems(params.FilesystemFilters{
+		[]params.FilesystemFilter{{}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found.Results, gc.HasLen, 1)
+	c.Assert(found.Results[0].Result, gc.HasLen, 1)
+	c.Assert(found.Results[0].Result[0], jc.DeepEquals, expected)
+}
@@ -14,10 +14,11 @@ import (
 )
 
 type mockPoolManager struct {
-	getPool    func(name string) (*jujustorage.Config, error)
-	createPool func(name string, providerType jujustorage.ProviderType, attrs map[string]interface{}) (*jujustorage.Config, error)
-	deletePool func(name string) error
-	listPools  func() ([]*jujustorage.Config, error)
+	getPool     func(name string) (*jujustorage.Config, error)
+	createPool  func(name string, providerType jujustorage.ProviderType, attrs map[string]interface{}) (*jujustorage.Config, error)
+	deletePool  func(name string) error
+	listPools   func() ([]*jujustorage.Config, error)
+	replacePool func(name string, attrs map[string]interface{}) (*jujustorage.Config, error)
 }
 
 func (m *mockPoolManager) Get(name string) (*jujustorage.Config, error) {
@@ -28,6 +29,10 @@ func (m *mockPoolManager) Create(name string, providerType jujustorage.ProviderT
 	return m.createPool(name, providerType, attrs)
 }
 
+func (m *mockPoolManager) Replace(name string, attrs map[string]interface{}) (*jujustorage.Config, error) {
+	return m.replacePool(name, attrs)
+}
+
 func (m *mockPoolManager) Delete(name string) error {
 	return m.deletePool(name)
 }
@@ -61,6 +66,8 @@ type mockState struct {
 	addStorageForUnit                   func(u names.UnitTag, name string, cons state.StorageConstraints) error
 	getBlockForType                     func(t state.BlockType) (state.Block, bool, error)
 	blockDevices                        func(names.MachineTag) ([]state.BlockDeviceInfo, error)
+	storagePoolInUse                    func(name string) (bool, error)
+	watchStoragePools                   func() state.StringsWatcher
 }
 
 func (st *mockState) StorageInstance(s names.StorageTag) (state.StorageInstance, error) {
@@ -162,6 +169,14 @@ func (st *mockState) BlockDevices(m names.MachineTag) ([]state.BlockDeviceInfo,
 	return []state.BlockDeviceInfo{}, nil
 }
 
+func (st *mockState) StoragePoolInUse(name string) (bool, error) {
+	return st.storagePoolInUse(name)
+}
+
+func (st *mockState) WatchStoragePools() state.StringsWatcher {
+	return st.watchStoragePools()
+}
+
 type mockNotifyWatcher struct {
 	state.NotifyWatcher
 	changes chan struct{}
@@ -171,6 +186,15 @@ func (m *mockNotifyWatcher) Changes() <-chan struct{} {
 	return m.changes
 }
 
+type mockStringsWatcher struct {
+	state.StringsWatcher
+	changes chan []string
+}
+
+func (m *mockStringsWatcher) Changes() <-chan []string {
+	return m.changes
+}
+
 type mockVolume struct {
 	state.Volume
 	tag     names.VolumeTag
@@ -249,6 +273,7 @@ type mockFilesystemAttachment struct {
 	filesystem names.FilesystemTag
 	machine    names.MachineTag
 	info       *state.FilesystemAttachmentInfo
+	usage      *state.FilesystemUsage
 }
 
 func (m *mockFilesystemAttachment) Filesystem() names.FilesystemTag {
@@ -266,6 +291,13 @@ func (m *mockFilesystemAttachment) Info() (state.FilesystemAttachmentInfo, error
 	return state.FilesystemAttachmentInfo{}, errors.NotProvisionedf("filesystem attachment")
 }
 
+func (m *mockFilesystemAttachment) Usage() (state.FilesystemUsage, bool) {
+	if m.usage != nil {
+		return *m.usage, true
+	}
+	return state.FilesystemUsage{}, false
+}
+
 type mockStorageInstance struct {
 	state.StorageInstance
 	kind       state.StorageKind
@@ -0,0 +1,93 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/storage/provider"
+)
+
+type poolRemoveSuite struct {
+	baseStorageSuite
+}
+
+var _ = gc.Suite(&poolRemoveSuite{})
+
+func (s *poolRemoveSuite) createPool(c *gc.C, name string) {
+	_, err := s.poolManager.Create(name, provider.LoopProviderType, nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *poolRemoveSuite) removePool(c *gc.C, name string, force bool) *params.Error {
+	results, err := s.api.RemovePool(params.StoragePoolDeleteArgs{
+		Pools: []params.StoragePoolDeleteArg{{Name: name, Force: force}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	return results.Results[0].Error
+}
+
+func (s *poolRemoveSuite) TestRemovePool(c *gc.C) {
+	s.createPool(c, "pname")
+	err := s.removePool(c, "pname", false)
+	c.Assert(err, gc.IsNil)
+
+	_, getErr := s.poolManager.Get("pname")
+	c.Assert(getErr, jc.Satisfies, errors.IsNotFound)
+	s.assertCalls(c, []string{getBlockForTypeCall, getBlockForTypeCall, storagePoolInUseCall})
+}
+
+func (s *poolRemoveSuite) TestRemovePoolNotFound(c *gc.C) {
+	err := s.removePool(c, "missing", false)
+	c.Assert(err, jc.Satisfies, params.IsCodeNotFound)
+}
+
+func (s *poolRemoveSuite) TestRemovePoolInUse(c *gc.C) {
+	s.createPool(c, "pname")
+	s.poolsInUse.Add("pname")
+	err := s.removePool(c, "pname", false)
+	c.Assert(err, gc.ErrorMatches, `storage pool "pname" in use`)
+
+	_, getErr := s.poolManager.Get("pname")
+	c.Assert(getErr, jc.ErrorIsNil)
+}
+
+func (s *poolRemoveSuite) TestRemovePoolInUseForced(c *gc.C) {
+	s.createPool(c, "pname")
+	s.poolsInUse.Add("pname")
+	err := s.removePool(c, "pname", true)
+	c.Assert(err, gc.IsNil)
+
+	_, getErr := s.poolManager.Get("pname")
+	c.Assert(getErr, jc.Satisfies, errors.IsNotFound)
+	s.assertCalls(c, []string{getBlockForTypeCall, getBlockForTypeCall})
+}
+
+func (s *poolRemoveSuite) TestRemovePoolBlocked(c *gc.C) {
+	s.blockRemoveObject(c, "TestRemovePoolBlocked")
+	_, err := s.api.RemovePool(params.StoragePoolDeleteArgs{
+		Pools: []params.StoragePoolDeleteArg{{Name: "pname"}},
+	})
+	s.assertBlocked(c, err, "TestRemovePoolBlocked")
+}
+
+func (s *poolRemoveSuite) TestWatchPools(c *gc.C) {
+	w := &mockStringsWatcher{changes: make(chan []string, 1)}
+	w.changes <- []string{"pname"}
+	s.state.watchStoragePools = func() state.StringsWatcher {
+		return w
+	}
+	result, err := s.api.WatchPools()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.StringsWatchResult{
+		StringsWatcherId: "1",
+		Changes:          []string{"pname"},
+	})
+	c.Assert(s.resources.Get("1"), gc.Equals, w)
+}
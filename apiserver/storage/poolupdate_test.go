@@ -0,0 +1,74 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	jujustorage "github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/provider"
+)
+
+type poolUpdateSuite struct {
+	baseStorageSuite
+}
+
+var _ = gc.Suite(&poolUpdateSuite{})
+
+func (s *poolUpdateSuite) TestUpdatePool(c *gc.C) {
+	s.createPool(c, "pname")
+	expected, _ := jujustorage.NewConfig("pname", provider.LoopProviderType, map[string]interface{}{"foo": "bar"})
+
+	results, err := s.api.UpdatePool(params.StoragePoolArgs{
+		Pools: []params.StoragePool{{
+			Name:  "pname",
+			Attrs: map[string]interface{}{"foo": "bar"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+
+	pool, err := s.poolManager.Get("pname")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pool, jc.DeepEquals, expected)
+}
+
+func (s *poolUpdateSuite) TestUpdatePoolNotFound(c *gc.C) {
+	results, err := s.api.UpdatePool(params.StoragePoolArgs{
+		Pools: []params.StoragePool{{Name: "missing"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, jc.Satisfies, params.IsCodeNotFound)
+}
+
+func (s *poolUpdateSuite) TestUpdatePoolError(c *gc.C) {
+	msg := "as expected"
+	s.baseStorageSuite.poolManager.replacePool = func(name string, attrs map[string]interface{}) (*jujustorage.Config, error) {
+		return nil, errors.New(msg)
+	}
+	results, err := s.api.UpdatePool(params.StoragePoolArgs{
+		Pools: []params.StoragePool{{Name: "pname"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, msg)
+}
+
+func (s *poolUpdateSuite) TestUpdatePoolBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestUpdatePoolBlocked")
+	_, err := s.api.UpdatePool(params.StoragePoolArgs{
+		Pools: []params.StoragePool{{Name: "pname"}},
+	})
+	s.assertBlocked(c, err, "TestUpdatePoolBlocked")
+}
+
+func (s *poolUpdateSuite) createPool(c *gc.C, name string) {
+	_, err := s.poolManager.Create(name, provider.LoopProviderType, nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
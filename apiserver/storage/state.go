@@ -80,6 +80,12 @@ type storageAccess interface {
 	// AddStorageForUnit is required for storage add functionality.
 	AddStorageForUnit(tag names.UnitTag, name string, cons state.StorageConstraints) error
 
+	// StoragePoolInUse is required for pool removal functionality.
+	StoragePoolInUse(name string) (bool, error)
+
+	// WatchStoragePools is required for pool functionality.
+	WatchStoragePools() state.StringsWatcher
+
 	// GetBlockForType is required to block operations.
 	GetBlockForType(t state.BlockType) (state.Block, bool, error)
 }
@@ -14,6 +14,7 @@ import (
 	"github.com/juju/juju/apiserver/common/storagecommon"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
 	"github.com/juju/juju/status"
 	"github.com/juju/juju/storage"
 	"github.com/juju/juju/storage/poolmanager"
@@ -21,7 +22,7 @@ import (
 )
 
 func init() {
-	common.RegisterStandardFacade("Storage", 2, NewAPI)
+	common.RegisterStandardFacade("Storage", 3, NewAPI)
 }
 
 // API implements the storage interface and is the concrete
@@ -29,6 +30,7 @@ func init() {
 type API struct {
 	storage     storageAccess
 	poolManager poolmanager.PoolManager
+	resources   *common.Resources
 	authorizer  common.Authorizer
 }
 
@@ -45,6 +47,7 @@ func createAPI(
 	return &API{
 		storage:     st,
 		poolManager: pm,
+		resources:   resources,
 		authorizer:  authorizer,
 	}, nil
 }
@@ -131,13 +134,19 @@ func (api *API) listStorageDetails(filter params.StorageFilter) ([]params.Storag
 
 func createStorageDetails(st storageAccess, si state.StorageInstance) (*params.StorageDetails, error) {
 	// Get information from underlying volume or filesystem.
-	var persistent bool
+	var persistent, encrypted bool
 	var statusEntity status.StatusGetter
+	var filesystem state.Filesystem
 	if si.Kind() != state.StorageKindBlock {
 		// TODO(axw) when we support persistent filesystems,
 		// e.g. CephFS, we'll need to do set "persistent"
 		// here too.
-		filesystem, err := st.StorageInstanceFilesystem(si.StorageTag())
+		var err error
+		filesystem, err = st.StorageInstanceFilesystem(si.StorageTag())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		encrypted, err = filesystemEncrypted(st, filesystem)
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
@@ -149,6 +158,7 @@ func createStorageDetails(st storageAccess, si state.StorageInstance) (*params.S
 		}
 		if info, err := volume.Info(); err == nil {
 			persistent = info.Persistent
+			encrypted = info.Encrypted
 		}
 		statusEntity = volume
 	}
@@ -171,10 +181,17 @@ func createStorageDetails(st storageAccess, si state.StorageInstance) (*params.S
 				return nil, errors.Trace(err)
 			}
 			details := params.StorageAttachmentDetails{
-				a.StorageInstance().String(),
-				a.Unit().String(),
-				machineTag.String(),
-				location,
+				StorageTag: a.StorageInstance().String(),
+				UnitTag:    a.Unit().String(),
+				MachineTag: machineTag.String(),
+				Location:   location,
+			}
+			if filesystem != nil && location != "" {
+				usage, err := filesystemAttachmentUsage(st, machineTag, filesystem.FilesystemTag())
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+				details.Usage = usage
 			}
 			storageAttachmentDetails[a.Unit().String()] = details
 		}
@@ -186,10 +203,55 @@ func createStorageDetails(st storageAccess, si state.StorageInstance) (*params.S
 		Kind:        params.StorageKind(si.Kind()),
 		Status:      common.EntityStatusFromState(status),
 		Persistent:  persistent,
+		Encrypted:   encrypted,
 		Attachments: storageAttachmentDetails,
 	}, nil
 }
 
+// filesystemEncrypted reports whether the filesystem, or the volume
+// backing it, is encrypted at rest.
+func filesystemEncrypted(st storageAccess, f state.Filesystem) (bool, error) {
+	if info, err := f.Info(); err == nil && info.Encrypted {
+		return true, nil
+	}
+	volumeTag, err := f.Volume()
+	if err == state.ErrNoBackingVolume {
+		return false, nil
+	} else if err != nil {
+		return false, errors.Trace(err)
+	}
+	volume, err := st.Volume(volumeTag)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	info, err := volume.Info()
+	if errors.IsNotProvisioned(err) {
+		return false, nil
+	} else if err != nil {
+		return false, errors.Trace(err)
+	}
+	return info.Encrypted, nil
+}
+
+// filesystemAttachmentUsage returns the capacity usage last reported for
+// the filesystem attached to the machine, or nil if none has been reported.
+func filesystemAttachmentUsage(
+	st storageAccess, machineTag names.MachineTag, filesystemTag names.FilesystemTag,
+) (*params.FilesystemUsage, error) {
+	attachment, err := st.FilesystemAttachment(machineTag, filesystemTag)
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	usage, ok := attachment.Usage()
+	if !ok {
+		return nil, nil
+	}
+	result := storagecommon.FilesystemUsageFromState(usage)
+	return &result, nil
+}
+
 func storageAttachmentInfo(st storageAccess, a state.StorageAttachment) (_ names.MachineTag, location string, _ error) {
 	machineTag, err := st.UnitAssignedMachine(a.Unit())
 	if errors.IsNotAssigned(err) {
@@ -359,6 +421,70 @@ func (a *API) CreatePool(p params.StoragePool) error {
 	return err
 }
 
+// UpdatePool replaces the attributes of existing pools with those
+// specified. The new attributes are validated by the pools' storage
+// providers before being saved.
+// A "CHANGE" block can block this operation.
+func (a *API) UpdatePool(args params.StoragePoolArgs) (params.ErrorResults, error) {
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := make([]params.ErrorResult, len(args.Pools))
+	for i, p := range args.Pools {
+		if _, err := a.poolManager.Replace(p.Name, p.Attrs); err != nil {
+			results[i].Error = common.ServerError(err)
+		}
+	}
+	return params.ErrorResults{Results: results}, nil
+}
+
+// RemovePool removes the named pools. A pool that is still referenced
+// by storage in the model will not be removed unless forced.
+// A "REMOVE" block can block this operation.
+func (a *API) RemovePool(args params.StoragePoolDeleteArgs) (params.ErrorResults, error) {
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.RemoveAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := make([]params.ErrorResult, len(args.Pools))
+	for i, p := range args.Pools {
+		if err := a.removePool(p.Name, p.Force); err != nil {
+			results[i].Error = common.ServerError(err)
+		}
+	}
+	return params.ErrorResults{Results: results}, nil
+}
+
+func (a *API) removePool(name string, force bool) error {
+	if _, err := a.poolManager.Get(name); err != nil {
+		return errors.Trace(err)
+	}
+	if !force {
+		inUse, err := a.storage.StoragePoolInUse(name)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if inUse {
+			return errors.Errorf("storage pool %q in use", name)
+		}
+	}
+	return a.poolManager.Delete(name)
+}
+
+// WatchPools returns a strings watcher that notifies of the names of
+// storage pools as they are created, updated or removed.
+func (a *API) WatchPools() (params.StringsWatchResult, error) {
+	w := a.storage.WatchStoragePools()
+	if changes, ok := <-w.Changes(); ok {
+		return params.StringsWatchResult{
+			StringsWatcherId: a.resources.Register(w),
+			Changes:          changes,
+		}, nil
+	}
+	return params.StringsWatchResult{}, watcher.EnsureErr(w)
+}
+
 // ListVolumes lists volumes with the given filters. Each filter produces
 // an independent list of volumes, or an error if the filter is invalid
 // or the volumes could not be listed.
@@ -626,6 +752,10 @@ func createFilesystemDetails(
 			if err == nil {
 				info = storagecommon.FilesystemAttachmentInfoFromState(stateInfo)
 			}
+			if usage, ok := attachment.Usage(); ok {
+				paramsUsage := storagecommon.FilesystemUsageFromState(usage)
+				info.Usage = &paramsUsage
+			}
 			details.MachineAttachments[attachment.Machine().String()] = info
 		}
 	}
@@ -242,10 +242,9 @@ func (s *storageSuite) createTestStorageDetails() params.StorageDetails {
 		},
 		Attachments: map[string]params.StorageAttachmentDetails{
 			s.unitTag.String(): params.StorageAttachmentDetails{
-				s.storageTag.String(),
-				s.unitTag.String(),
-				s.machineTag.String(),
-				"", // location
+				StorageTag: s.storageTag.String(),
+				UnitTag:    s.unitTag.String(),
+				MachineTag: s.machineTag.String(),
 			},
 		},
 	}
@@ -298,16 +297,43 @@ func (s *storageSuite) TestShowStorage(c *gc.C) {
 		},
 		Attachments: map[string]params.StorageAttachmentDetails{
 			s.unitTag.String(): params.StorageAttachmentDetails{
-				s.storageTag.String(),
-				s.unitTag.String(),
-				s.machineTag.String(),
-				"",
+				StorageTag: s.storageTag.String(),
+				UnitTag:    s.unitTag.String(),
+				MachineTag: s.machineTag.String(),
 			},
 		},
 	}
 	c.Assert(one.Result, jc.DeepEquals, &expected)
 }
 
+func (s *storageSuite) TestShowStorageEncryptedBackingVolume(c *gc.C) {
+	s.filesystem.volume = &s.volumeTag
+	s.volume.info = &state.VolumeInfo{VolumeId: "vol-0", Encrypted: true}
+	entity := params.Entity{Tag: s.storageTag.String()}
+
+	found, err := s.api.StorageDetails(
+		params.Entities{Entities: []params.Entity{entity}},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found.Results, gc.HasLen, 1)
+	c.Assert(found.Results[0].Error, gc.IsNil)
+	c.Assert(found.Results[0].Result.Encrypted, jc.IsTrue)
+}
+
+func (s *storageSuite) TestShowStorageEncryptedVolume(c *gc.C) {
+	s.storageInstance.kind = state.StorageKindBlock
+	s.volume.info = &state.VolumeInfo{VolumeId: "vol-0", Encrypted: true}
+	entity := params.Entity{Tag: s.storageTag.String()}
+
+	found, err := s.api.StorageDetails(
+		params.Entities{Entities: []params.Entity{entity}},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found.Results, gc.HasLen, 1)
+	c.Assert(found.Results[0].Error, gc.IsNil)
+	c.Assert(found.Results[0].Result.Encrypted, jc.IsTrue)
+}
+
 func (s *storageSuite) TestShowStorageInvalidId(c *gc.C) {
 	storageTag := "foo"
 	entity := params.Entity{Tag: storageTag}
@@ -851,6 +851,12 @@ func (s *provisionerSuite) TestWatchFilesystemAttachments(c *gc.C) {
 				Changes: []params.MachineStorageId{{
 					MachineTag:    "machine-0",
 					AttachmentTag: "filesystem-0-0",
+				}, {
+					MachineTag:    "machine-0",
+					AttachmentTag: "filesystem-1",
+				}, {
+					MachineTag:    "machine-0",
+					AttachmentTag: "filesystem-2",
 				}},
 			},
 			{
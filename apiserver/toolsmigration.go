@@ -0,0 +1,98 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+
+	"github.com/juju/errors"
+	"github.com/juju/version"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state/binarystorage"
+	"github.com/juju/juju/tools"
+)
+
+// toolsMigrationUploadHandler handles the upload of tools for a model
+// that is being migrated to this controller.
+type toolsMigrationUploadHandler struct {
+	ctxt httpContext
+}
+
+func (h *toolsMigrationUploadHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case "POST":
+		agentTools, err := h.processPost(r)
+		if err != nil {
+			sendError(w, err)
+			return
+		}
+		sendStatusAndJSON(w, http.StatusOK, &params.ToolsResult{
+			ToolsList: tools.List{agentTools},
+		})
+	default:
+		sendError(w, errors.MethodNotAllowedf("unsupported method: %q", r.Method))
+	}
+}
+
+// processPost stores the uploaded tools in the importing model's
+// tools storage. Tools already available to the model, such as those
+// in the controller's catalogue, are left alone.
+func (h *toolsMigrationUploadHandler) processPost(r *http.Request) (*tools.Tools, error) {
+	st, err := stateForMigrationUpload(h.ctxt, r)
+	if err != nil {
+		return nil, err
+	}
+	defer st.Close()
+
+	binaryVersionParam := r.URL.Query().Get("binaryVersion")
+	if binaryVersionParam == "" {
+		return nil, errors.BadRequestf("expected binaryVersion argument")
+	}
+	toolsVersion, err := version.ParseBinary(binaryVersionParam)
+	if err != nil {
+		return nil, errors.NewBadRequest(err, fmt.Sprintf("invalid tools version %q", binaryVersionParam))
+	}
+	contentType := r.Header.Get("Content-Type")
+	if contentType != "application/x-tar-gz" {
+		return nil, errors.BadRequestf("expected Content-Type: application/x-tar-gz, got: %v", contentType)
+	}
+
+	toolsStorage, err := st.ToolsStorage()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer toolsStorage.Close()
+
+	data, sha256, err := readAndHash(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, errors.BadRequestf("no tools uploaded")
+	}
+	result := &tools.Tools{
+		Version: toolsVersion,
+		Size:    int64(len(data)),
+		SHA256:  sha256,
+		URL:     common.ToolsURL(fmt.Sprintf("https://%s/model/%s", r.Host, st.ModelUUID()), toolsVersion),
+	}
+	if _, err := toolsStorage.Metadata(toolsVersion.String()); err == nil {
+		return result, nil
+	} else if !errors.IsNotFound(err) {
+		return nil, errors.Trace(err)
+	}
+	metadata := binarystorage.Metadata{
+		Version: toolsVersion.String(),
+		Size:    result.Size,
+		SHA256:  sha256,
+	}
+	if err := toolsStorage.Add(bytes.NewReader(data), metadata); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result, nil
+}
@@ -0,0 +1,124 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type toolsMigrationSuite struct {
+	authHttpSuite
+	importing *state.State
+}
+
+var _ = gc.Suite(&toolsMigrationSuite{})
+
+func (s *toolsMigrationSuite) SetUpTest(c *gc.C) {
+	s.authHttpSuite.SetUpTest(c)
+	s.userTag = s.AdminUserTag(c)
+	s.password = "dummy-secret"
+
+	s.importing = s.Factory.MakeModel(c, nil)
+	s.AddCleanup(func(*gc.C) { s.importing.Close() })
+	model, err := s.importing.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	err = model.SetMigrationMode(state.MigrationModeImporting)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *toolsMigrationSuite) toolsURI(c *gc.C, model, vers string) string {
+	uri := s.baseURL(c)
+	uri.Path = fmt.Sprintf("/model/%s/migrate/tools", s.State.ModelUUID())
+	uri.RawQuery = url.Values{"model": {model}, "binaryVersion": {vers}}.Encode()
+	return uri.String()
+}
+
+func (s *toolsMigrationSuite) assertErrorResponse(c *gc.C, resp *http.Response, expCode int, expError string) {
+	body := assertResponse(c, resp, expCode, params.ContentTypeJSON)
+	var result params.ErrorResult
+	err := json.Unmarshal(body, &result)
+	c.Assert(err, jc.ErrorIsNil, gc.Commentf("body: %s", body))
+	c.Assert(result.Error, gc.NotNil)
+	c.Assert(result.Error.Message, gc.Matches, expError)
+}
+
+func (s *toolsMigrationSuite) TestRequiresAuth(c *gc.C) {
+	resp := s.sendRequest(c, httpRequestParams{method: "POST", url: s.toolsURI(c, "", "")})
+	s.assertErrorResponse(c, resp, http.StatusUnauthorized, "no credentials provided")
+}
+
+func (s *toolsMigrationSuite) TestRequiresControllerAdmin(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{Password: "hunter2"})
+	resp := s.sendRequest(c, httpRequestParams{
+		tag:      user.Tag().String(),
+		password: "hunter2",
+		method:   "POST",
+		url:      s.toolsURI(c, s.importing.ModelUUID(), "1.9.0-quantal-amd64"),
+	})
+	s.assertErrorResponse(c, resp, http.StatusUnauthorized, "permission denied")
+}
+
+func (s *toolsMigrationSuite) TestRequiresPOST(c *gc.C) {
+	resp := s.authRequest(c, httpRequestParams{method: "PUT", url: s.toolsURI(c, "", "")})
+	s.assertErrorResponse(c, resp, http.StatusMethodNotAllowed, `unsupported method: "PUT"`)
+}
+
+func (s *toolsMigrationSuite) TestRequiresImportingModel(c *gc.C) {
+	resp := s.authRequest(c, httpRequestParams{
+		method: "POST",
+		url:    s.toolsURI(c, s.State.ModelUUID(), "1.9.0-quantal-amd64"),
+	})
+	s.assertErrorResponse(c, resp, http.StatusBadRequest, `model ".*" is not being imported`)
+}
+
+func (s *toolsMigrationSuite) TestRequiresTarGz(c *gc.C) {
+	resp := s.authRequest(c, httpRequestParams{
+		method:      "POST",
+		url:         s.toolsURI(c, s.importing.ModelUUID(), "1.9.0-quantal-amd64"),
+		contentType: "application/octet-stream",
+		body:        strings.NewReader("tools"),
+	})
+	s.assertErrorResponse(c, resp, http.StatusBadRequest, "expected Content-Type: application/x-tar-gz, got: application/octet-stream")
+}
+
+func (s *toolsMigrationSuite) TestUpload(c *gc.C) {
+	resp := s.authRequest(c, httpRequestParams{
+		method:      "POST",
+		url:         s.toolsURI(c, s.importing.ModelUUID(), "1.9.0-quantal-amd64"),
+		contentType: "application/x-tar-gz",
+		body:        strings.NewReader("tools"),
+	})
+	body := assertResponse(c, resp, http.StatusOK, params.ContentTypeJSON)
+	var result params.ToolsResult
+	err := json.Unmarshal(body, &result)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.ToolsList, gc.HasLen, 1)
+	c.Check(result.ToolsList[0].Version.String(), gc.Equals, "1.9.0-quantal-amd64")
+	c.Check(result.ToolsList[0].Size, gc.Equals, int64(len("tools")))
+
+	// The tools are stored for the importing model only.
+	storage, err := s.importing.ToolsStorage()
+	c.Assert(err, jc.ErrorIsNil)
+	defer storage.Close()
+	_, err = storage.Metadata("1.9.0-quantal-amd64")
+	c.Check(err, jc.ErrorIsNil)
+
+	controllerStorage, err := s.State.ToolsStorage()
+	c.Assert(err, jc.ErrorIsNil)
+	defer controllerStorage.Close()
+	_, err = controllerStorage.Metadata("1.9.0-quantal-amd64")
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
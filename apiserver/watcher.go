@@ -397,7 +397,8 @@ func newMigrationStatusWatcher(
 	auth common.Authorizer,
 	id string,
 ) (interface{}, error) {
-	if !(auth.AuthMachineAgent() || auth.AuthUnitAgent()) {
+	// Clients watch migrations to report their progress.
+	if !(auth.AuthMachineAgent() || auth.AuthUnitAgent() || auth.AuthClient()) {
 		return nil, common.ErrPerm
 	}
 	w, ok := resources.Get(id).(state.NotifyWatcher)
@@ -468,6 +469,7 @@ func (w *srvMigrationStatusWatcher) Next() (params.MigrationStatus, error) {
 	}
 
 	return params.MigrationStatus{
+		MigrationId:    mig.Id(),
 		Attempt:        attempt,
 		Phase:          phase.String(),
 		SourceAPIAddrs: sourceAddrs,
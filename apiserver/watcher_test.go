@@ -93,6 +93,7 @@ func (s *watcherSuite) TestMigrationStatusWatcher(c *gc.C) {
 	result, err := facade.Next()
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(result, jc.DeepEquals, params.MigrationStatus{
+		MigrationId:    "id",
 		Attempt:        2,
 		Phase:          "READONLY",
 		SourceAPIAddrs: []string{"1.2.3.4:5", "2.3.4.5:6", "3.4.5.6:7"},
@@ -117,9 +118,22 @@ func (s *watcherSuite) TestMigrationStatusWatcherNoMigration(c *gc.C) {
 	})
 }
 
-func (s *watcherSuite) TestMigrationStatusWatcherNotAgent(c *gc.C) {
-	id := s.resources.Register(apiservertesting.NewFakeNotifyWatcher())
+func (s *watcherSuite) TestMigrationStatusWatcherClient(c *gc.C) {
+	w := apiservertesting.NewFakeNotifyWatcher()
+	id := s.resources.Register(w)
 	s.authorizer.Tag = names.NewUserTag("frogdog")
+	apiserver.PatchGetMigrationBackend(s, &fakeMigrationBackend{noMigration: true})
+
+	facade := s.getFacade(c, "MigrationStatusWatcher", 1, id).(migrationStatusWatcher)
+	defer c.Check(facade.Stop(), jc.ErrorIsNil)
+	result, err := facade.Next()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Phase, gc.Equals, "NONE")
+}
+
+func (s *watcherSuite) TestMigrationStatusWatcherNotAgentOrClient(c *gc.C) {
+	id := s.resources.Register(apiservertesting.NewFakeNotifyWatcher())
+	s.authorizer.Tag = names.NewApplicationTag("frogdog")
 
 	factory, err := common.Facades.GetFactory("MigrationStatusWatcher", 1)
 	c.Assert(err, jc.ErrorIsNil)
@@ -182,6 +196,10 @@ type fakeModelMigration struct {
 	state.ModelMigration
 }
 
+func (m *fakeModelMigration) Id() string {
+	return "id"
+}
+
 func (m *fakeModelMigration) Attempt() (int, error) {
 	return 2, nil
 }
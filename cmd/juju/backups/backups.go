@@ -6,16 +6,21 @@ package backups
 import (
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
+	"strings"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
 	"launchpad.net/gnuflag"
 
+	"github.com/juju/juju/api"
 	"github.com/juju/juju/api/backups"
 	apiserverbackups "github.com/juju/juju/apiserver/backups"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/environs/bootstrap"
 	statebackups "github.com/juju/juju/state/backups"
 )
 
@@ -24,7 +29,7 @@ import (
 type APIClient interface {
 	io.Closer
 	// Create sends an RPC request to create a new backup.
-	Create(notes string) (*params.BackupsMetadataResult, error)
+	Create(args params.BackupsCreateArgs) (*params.BackupsMetadataResult, error)
 	// Info gets the backup's metadata.
 	Info(id string) (*params.BackupsMetadataResult, error)
 	// List gets all stored metadata.
@@ -36,9 +41,14 @@ type APIClient interface {
 	// Remove removes the stored backup.
 	Remove(id string) error
 	// Restore will restore a backup with the given id into the controller.
-	Restore(string, backups.ClientConnection) error
+	Restore(params.RestoreArgs, backups.ClientConnection) error
 	// RestoreReader will restore a backup file into the controller.
 	RestoreReader(io.ReadSeeker, *params.BackupsMetadataResult, backups.ClientConnection) error
+	// RestoreModel will re-create the model in a model backup with the
+	// given id in the controller.
+	RestoreModel(params.RestoreArgs) (names.ModelTag, error)
+	// Verify checks that the stored backup is usable.
+	Verify(params.BackupsVerifyArgs) (*params.BackupsVerifyResult, error)
 }
 
 // CommandBase is the base type for backups sub-commands.
@@ -46,6 +56,11 @@ type CommandBase struct {
 	// TODO(wallyworld) - remove Log when backup command is flattened.
 	Log *cmd.Log
 	modelcmd.ModelCommandBase
+
+	// controllerModel is set when the command must connect to the
+	// controller model, rather than the selected model, because it
+	// operates on a hosted model on its behalf.
+	controllerModel bool
 }
 
 // NewAPIClient returns a client for the backups api endpoint.
@@ -61,13 +76,51 @@ func (c *CommandBase) SetFlags(f *gnuflag.FlagSet) {
 }
 
 var newAPIClient = func(c *CommandBase) (APIClient, error) {
-	root, err := c.NewAPIRoot()
+	root, err := c.newAPIRoot()
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	return backups.NewClient(root)
 }
 
+// newAPIRoot returns a connection to the selected model, or to the
+// controller model if controllerModel is set.
+func (c *CommandBase) newAPIRoot() (api.Connection, error) {
+	if !c.controllerModel {
+		return c.NewAPIRoot()
+	}
+	// Make sure that the controller model is known locally.
+	if _, err := c.modelUUID(bootstrap.ControllerModelName); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return c.JujuCommandBase.NewAPIRoot(
+		c.ClientStore(), c.ControllerName(), c.AccountName(), bootstrap.ControllerModelName,
+	)
+}
+
+// isHostedModel returns whether the selected model is a hosted model,
+// rather than the controller model.
+func (c *CommandBase) isHostedModel() bool {
+	return c.ModelName() != "" && c.ModelName() != bootstrap.ControllerModelName
+}
+
+// modelUUID returns the UUID of the named model in the selected
+// controller, refreshing the locally cached models if it is unknown.
+func (c *CommandBase) modelUUID(modelName string) (string, error) {
+	store := c.ClientStore()
+	details, err := store.ModelByName(c.ControllerName(), c.AccountName(), modelName)
+	if errors.IsNotFound(err) {
+		if err := c.RefreshModels(store, c.ControllerName(), c.AccountName()); err != nil {
+			return "", errors.Annotate(err, "refreshing models")
+		}
+		details, err = store.ModelByName(c.ControllerName(), c.AccountName(), modelName)
+	}
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return details.ModelUUID, nil
+}
+
 // dumpMetadata writes the formatted backup metadata to stdout.
 func (c *CommandBase) dumpMetadata(ctx *cmd.Context, result *params.BackupsMetadataResult) {
 	fmt.Fprintf(ctx.Stdout, "backup ID:       %q\n", result.ID)
@@ -79,6 +132,15 @@ func (c *CommandBase) dumpMetadata(ctx *cmd.Context, result *params.BackupsMetad
 	fmt.Fprintf(ctx.Stdout, "started:         %v\n", result.Started)
 	fmt.Fprintf(ctx.Stdout, "finished:        %v\n", result.Finished)
 	fmt.Fprintf(ctx.Stdout, "notes:           %q\n", result.Notes)
+	if result.Schedule != "" {
+		fmt.Fprintf(ctx.Stdout, "schedule:        %q\n", result.Schedule)
+	}
+	if result.Encryption != "" {
+		fmt.Fprintf(ctx.Stdout, "encryption:      %q\n", result.Encryption)
+	}
+	if result.ModelUUID != "" {
+		fmt.Fprintf(ctx.Stdout, "backed up model: %q\n", result.ModelUUID)
+	}
 
 	fmt.Fprintf(ctx.Stdout, "model ID:        %q\n", result.Model)
 	fmt.Fprintf(ctx.Stdout, "machine ID:      %q\n", result.Machine)
@@ -86,6 +148,76 @@ func (c *CommandBase) dumpMetadata(ctx *cmd.Context, result *params.BackupsMetad
 	fmt.Fprintf(ctx.Stdout, "juju version:    %v\n", result.Version)
 }
 
+// decryptionFlags holds the flags of commands that decrypt encrypted
+// backup archives.
+type decryptionFlags struct {
+	// PassphraseFile is the file holding the passphrase with which the
+	// archive (or private key) is decrypted.
+	PassphraseFile string
+	// PrivateKeyFile is the file holding the ASCII-armored OpenPGP
+	// private key with which the archive is decrypted.
+	PrivateKeyFile string
+}
+
+func (f *decryptionFlags) SetFlags(fs *gnuflag.FlagSet) {
+	fs.StringVar(&f.PassphraseFile, "passphrase-file", "", "Decrypt the archive with the passphrase in this file")
+	fs.StringVar(&f.PrivateKeyFile, "private-key-file", "", "Decrypt the archive with the OpenPGP private key in this file")
+}
+
+// isSet returns whether any decryption flag was specified.
+func (f *decryptionFlags) isSet() bool {
+	return f.PassphraseFile != "" || f.PrivateKeyFile != ""
+}
+
+// scheme returns the encryption scheme implied by the flags, for
+// archives whose metadata cannot be read until they are decrypted.
+func (f *decryptionFlags) scheme() string {
+	if f.PrivateKeyFile != "" {
+		return statebackups.PublicKeyEncryption
+	}
+	return statebackups.PassphraseEncryption
+}
+
+// args reads the files named by the flags.
+func (f *decryptionFlags) args(ctx *cmd.Context) (statebackups.DecryptArgs, error) {
+	var args statebackups.DecryptArgs
+	var err error
+	if args.Passphrase, err = readPassphraseFile(ctx, f.PassphraseFile); err != nil {
+		return args, errors.Trace(err)
+	}
+	if args.PrivateKey, err = readKeyFile(ctx, f.PrivateKeyFile); err != nil {
+		return args, errors.Trace(err)
+	}
+	return args, nil
+}
+
+// readKeyFile returns the content of the named file, or "" if no file
+// is named.
+func readKeyFile(ctx *cmd.Context, filename string) (string, error) {
+	if filename == "" {
+		return "", nil
+	}
+	data, err := ioutil.ReadFile(ctx.AbsPath(filename))
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return string(data), nil
+}
+
+// readPassphraseFile returns the passphrase in the named file, or "" if
+// no file is named. Trailing newlines are not part of the passphrase.
+func readPassphraseFile(ctx *cmd.Context, filename string) (string, error) {
+	passphrase, err := readKeyFile(ctx, filename)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	passphrase = strings.TrimRight(passphrase, "\r\n")
+	if filename != "" && passphrase == "" {
+		return "", errors.Errorf("passphrase file %q is empty", filename)
+	}
+	return passphrase, nil
+}
+
 // ArchiveReader can read a backup archive.
 type ArchiveReader interface {
 	io.ReadSeeker
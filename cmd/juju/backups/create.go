@@ -13,6 +13,7 @@ import (
 	"github.com/juju/errors"
 	"launchpad.net/gnuflag"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/state/backups"
 )
@@ -27,14 +28,32 @@ const createDoc = `
 create-backup requests that juju create a backup of its state and print the
 backup's unique ID.  You may provide a note to associate with the backup.
 
+When the selected model is a hosted model, rather than the controller
+model, only that model is backed up: its configuration, machines,
+applications and the archives of their charms.  Such a model backup
+holds none of the controller's secrets, and may be restored into a
+running controller with restore-backup once the model has been
+destroyed.
+
 The backup archive and associated metadata are stored remotely by juju.
+By default they are stored in the controller's database; the controller's
+backup-storage config may instead specify a directory or an S3-compatible
+object store, which outlives the controller.
+
+The archive may be encrypted before it is stored, with either the
+passphrase in the file given by --passphrase-file, or the ASCII-armored
+OpenPGP public key in the file given by --public-key-file.  Encrypted
+archives are OpenPGP messages, which may also be decrypted with gpg.
+The passphrase or the matching private key must then be given to
+download-backup and restore-backup, and will not be recoverable from
+juju if it is lost.
 
 The --download option may be used without the --filename option.  In
 that case, the backup archive will be stored in the current working
 directory with a name matching juju-backup-<date>-<time>.tar.gz.
 
-WARNING: Remotely stored backups will be lost when the model is
-destroyed.  Furthermore, the remotely backup is not guaranteed to be
+WARNING: Backups stored in the controller's database will be lost when
+the model is destroyed.  Furthermore, the remotely backup is not guaranteed to be
 available.
 
 Therefore, you should use the --download or --filename options, or use:
@@ -60,6 +79,12 @@ type createCommand struct {
 	Filename string
 	// Notes is the custom message to associated with the new backup.
 	Notes string
+	// PassphraseFile is the file holding the passphrase with which
+	// the backup archive is encrypted.
+	PassphraseFile string
+	// PublicKeyFile is the file holding the OpenPGP public key to
+	// which the backup archive is encrypted.
+	PublicKeyFile string
 }
 
 // Info implements Command.Info.
@@ -77,6 +102,8 @@ func (c *createCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.CommandBase.SetFlags(f)
 	f.BoolVar(&c.NoDownload, "no-download", false, "Do not download the archive")
 	f.StringVar(&c.Filename, "filename", notset, "Download to this file")
+	f.StringVar(&c.PassphraseFile, "passphrase-file", "", "Encrypt the archive with the passphrase in this file")
+	f.StringVar(&c.PublicKeyFile, "public-key-file", "", "Encrypt the archive to the OpenPGP public key in this file")
 }
 
 // Init implements Command.Init.
@@ -93,6 +120,9 @@ func (c *createCommand) Init(args []string) error {
 	if c.Filename == "" {
 		return errors.Errorf("missing filename")
 	}
+	if c.PassphraseFile != "" && c.PublicKeyFile != "" {
+		return errors.Errorf("cannot mix --passphrase-file and --public-key-file")
+	}
 
 	return nil
 }
@@ -104,13 +134,31 @@ func (c *createCommand) Run(ctx *cmd.Context) error {
 			return err
 		}
 	}
+	args := params.BackupsCreateArgs{Notes: c.Notes}
+	var err error
+	if args.Passphrase, err = readPassphraseFile(ctx, c.PassphraseFile); err != nil {
+		return errors.Trace(err)
+	}
+	if args.PublicKey, err = readKeyFile(ctx, c.PublicKeyFile); err != nil {
+		return errors.Trace(err)
+	}
+
+	if c.isHostedModel() {
+		// Backups are served by the controller model, which backs up
+		// the hosted model on our behalf.
+		if args.ModelUUID, err = c.modelUUID(c.ModelName()); err != nil {
+			return errors.Trace(err)
+		}
+		c.controllerModel = true
+	}
+
 	client, err := c.NewAPIClient()
 	if err != nil {
 		return errors.Trace(err)
 	}
 	defer client.Close()
 
-	result, err := client.Create(c.Notes)
+	result, err := client.Create(args)
 	if err != nil {
 		return errors.Trace(err)
 	}
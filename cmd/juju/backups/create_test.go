@@ -5,6 +5,8 @@ package backups_test
 
 import (
 	"bytes"
+	"io/ioutil"
+	"path/filepath"
 	"strings"
 
 	"github.com/juju/cmd"
@@ -13,6 +15,8 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/cmd/juju/backups"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
 	"github.com/juju/juju/testing"
 )
 
@@ -133,6 +137,111 @@ func (s *createSuite) TestFilenameAndNoDownload(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, "cannot mix --no-download and --filename")
 }
 
+func (s *createSuite) TestPassphraseFile(c *gc.C) {
+	client := s.setSuccess()
+	passphraseFile := filepath.Join(c.MkDir(), "passphrase")
+	err := ioutil.WriteFile(passphraseFile, []byte("secret\n"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = testing.RunCommand(c, s.wrappedCommand, "--no-download", "--passphrase-file", passphraseFile)
+	c.Assert(err, jc.ErrorIsNil)
+
+	client.Check(c, "", "", "Create")
+	c.Check(client.createArgs.Passphrase, gc.Equals, "secret")
+	c.Check(client.createArgs.PublicKey, gc.Equals, "")
+}
+
+func (s *createSuite) TestPublicKeyFile(c *gc.C) {
+	client := s.setSuccess()
+	keyFile := filepath.Join(c.MkDir(), "key.asc")
+	err := ioutil.WriteFile(keyFile, []byte("<public key>\n"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = testing.RunCommand(c, s.wrappedCommand, "--no-download", "--public-key-file", keyFile)
+	c.Assert(err, jc.ErrorIsNil)
+
+	client.Check(c, "", "", "Create")
+	c.Check(client.createArgs.Passphrase, gc.Equals, "")
+	c.Check(client.createArgs.PublicKey, gc.Equals, "<public key>\n")
+}
+
+func (s *createSuite) TestEmptyPassphraseFile(c *gc.C) {
+	s.setSuccess()
+	passphraseFile := filepath.Join(c.MkDir(), "passphrase")
+	err := ioutil.WriteFile(passphraseFile, []byte("\n"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = testing.RunCommand(c, s.wrappedCommand, "--no-download", "--passphrase-file", passphraseFile)
+	c.Check(err, gc.ErrorMatches, `passphrase file ".*" is empty`)
+}
+
+func (s *createSuite) TestPassphraseAndPublicKeyFiles(c *gc.C) {
+	s.setSuccess()
+	_, err := testing.RunCommand(c, s.wrappedCommand, "--passphrase-file", "passphrase", "--public-key-file", "key.asc")
+
+	c.Check(err, gc.ErrorMatches, "cannot mix --passphrase-file and --public-key-file")
+}
+
+func (s *createSuite) setModels(c *gc.C) {
+	store := jujuclienttesting.NewMemStore()
+	store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	store.CurrentControllerName = "testing"
+	store.Accounts["testing"] = &jujuclient.ControllerAccounts{
+		Accounts: map[string]jujuclient.AccountDetails{
+			"admin@local": {User: "admin@local"},
+		},
+		CurrentAccount: "admin@local",
+	}
+	store.Models["testing"] = jujuclient.ControllerAccountModels{
+		AccountModels: map[string]*jujuclient.AccountModels{
+			"admin@local": {
+				Models: map[string]jujuclient.ModelDetails{
+					"controller": {"controller-uuid"},
+					"mymodel":    {"mymodel-uuid"},
+				},
+				CurrentModel: "controller",
+			},
+		},
+	}
+	s.command.SetClientStore(store)
+}
+
+func (s *createSuite) TestModelBackup(c *gc.C) {
+	s.setModels(c)
+	client := s.setSuccess()
+	var controllerModel bool
+	s.PatchValue(backups.NewAPIClient,
+		func(c *backups.CommandBase) (backups.APIClient, error) {
+			controllerModel = backups.ControllerModel(c)
+			return client, nil
+		},
+	)
+	_, err := testing.RunCommand(c, s.wrappedCommand, "--quiet", "--no-download", "-m", "mymodel")
+	c.Assert(err, jc.ErrorIsNil)
+
+	client.Check(c, "", "", "Create")
+	c.Check(client.createArgs.ModelUUID, gc.Equals, "mymodel-uuid")
+	c.Check(controllerModel, jc.IsTrue)
+}
+
+func (s *createSuite) TestControllerBackup(c *gc.C) {
+	s.setModels(c)
+	client := s.setSuccess()
+	var controllerModel bool
+	s.PatchValue(backups.NewAPIClient,
+		func(c *backups.CommandBase) (backups.APIClient, error) {
+			controllerModel = backups.ControllerModel(c)
+			return client, nil
+		},
+	)
+	_, err := testing.RunCommand(c, s.wrappedCommand, "--quiet", "--no-download")
+	c.Assert(err, jc.ErrorIsNil)
+
+	client.Check(c, "", "", "Create")
+	c.Check(client.createArgs.ModelUUID, gc.Equals, "")
+	c.Check(controllerModel, jc.IsFalse)
+}
+
 func (s *createSuite) TestError(c *gc.C) {
 	s.setFailure("failed!")
 	_, err := testing.RunCommand(c, s.wrappedCommand)
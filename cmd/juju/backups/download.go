@@ -21,6 +21,9 @@ download-backup retrieves a backup archive file.
 
 If --filename is not used, the archive is downloaded to a temporary
 location and the filename is printed to stdout.
+
+An encrypted archive is downloaded as it is stored, unless the
+--passphrase-file or --private-key-file option is used to decrypt it.
 `
 
 // NewDownloadCommand returns a commant used to download backups.
@@ -31,6 +34,7 @@ func NewDownloadCommand() cmd.Command {
 // downloadCommand is the sub-command for downloading a backup archive.
 type downloadCommand struct {
 	CommandBase
+	decryptionFlags
 	// Filename is where to save the downloaded archive.
 	Filename string
 	// ID is the backup ID to download.
@@ -51,6 +55,7 @@ func (c *downloadCommand) Info() *cmd.Info {
 func (c *downloadCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.CommandBase.SetFlags(f)
 	f.StringVar(&c.Filename, "filename", "", "Download target")
+	c.decryptionFlags.SetFlags(f)
 }
 
 // Init implements Command.Init.
@@ -79,6 +84,23 @@ func (c *downloadCommand) Run(ctx *cmd.Context) error {
 	}
 	defer client.Close()
 
+	// Find out how to decrypt the archive, if requested.
+	var scheme string
+	var decryption backups.DecryptArgs
+	if c.decryptionFlags.isSet() {
+		if decryption, err = c.decryptionFlags.args(ctx); err != nil {
+			return errors.Trace(err)
+		}
+		meta, err := client.Info(c.ID)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if meta.Encryption == "" {
+			return errors.Errorf("backup %q is not encrypted", c.ID)
+		}
+		scheme = meta.Encryption
+	}
+
 	// Download the archive.
 	resultArchive, err := client.Download(c.ID)
 	if err != nil {
@@ -86,6 +108,13 @@ func (c *downloadCommand) Run(ctx *cmd.Context) error {
 	}
 	defer resultArchive.Close()
 
+	var source io.Reader = resultArchive
+	if scheme != "" {
+		if source, err = backups.DecryptArchive(resultArchive, scheme, decryption); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
 	// Prepare the local archive.
 	filename := c.ResolveFilename()
 	archive, err := os.Create(filename)
@@ -94,9 +123,13 @@ func (c *downloadCommand) Run(ctx *cmd.Context) error {
 	}
 	defer archive.Close()
 
-	// Write out the archive.
-	_, err = io.Copy(archive, resultArchive)
+	// Write out the archive. The integrity of a decrypted archive is
+	// only known once it has been read in full, so a partial archive
+	// must not be left behind.
+	_, err = io.Copy(archive, source)
 	if err != nil {
+		archive.Close()
+		os.Remove(filename)
 		return errors.Annotate(err, "while creating local archive file")
 	}
 
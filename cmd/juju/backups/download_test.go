@@ -4,12 +4,17 @@
 package backups_test
 
 import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/cmd/juju/backups"
+	statebackups "github.com/juju/juju/state/backups"
 	"github.com/juju/juju/testing"
 )
 
@@ -66,6 +71,40 @@ func (s *downloadSuite) TestFilename(c *gc.C) {
 	s.checkArchive(c)
 }
 
+func (s *downloadSuite) writePassphraseFile(c *gc.C) string {
+	passphraseFile := filepath.Join(c.MkDir(), "passphrase")
+	err := ioutil.WriteFile(passphraseFile, []byte("secret\n"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	return passphraseFile
+}
+
+func (s *downloadSuite) TestDecrypt(c *gc.C) {
+	var encrypted bytes.Buffer
+	err := statebackups.EncryptArchive(&encrypted, bytes.NewBufferString(s.data), statebackups.EncryptArgs{
+		Passphrase: "secret",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.metaresult.Encryption = statebackups.PassphraseEncryption
+	client := s.setSuccess()
+	client.archive = ioutil.NopCloser(&encrypted)
+
+	ctx, err := testing.RunCommand(c, s.wrappedCommand, s.metaresult.ID, "--passphrase-file", s.writePassphraseFile(c))
+	c.Assert(err, jc.ErrorIsNil)
+
+	client.Check(c, s.metaresult.ID, "", "Info", "Download")
+	s.filename = "juju-backup-" + s.metaresult.ID + ".tar.gz"
+	s.checkStd(c, ctx, s.filename+"\n", "")
+	s.checkArchive(c)
+}
+
+func (s *downloadSuite) TestDecryptNotEncrypted(c *gc.C) {
+	client := s.setSuccess()
+	_, err := testing.RunCommand(c, s.wrappedCommand, s.metaresult.ID, "--passphrase-file", s.writePassphraseFile(c))
+	c.Check(err, gc.ErrorMatches, `backup "spam" is not encrypted`)
+
+	client.Check(c, s.metaresult.ID, "", "Info")
+}
+
 func (s *downloadSuite) TestError(c *gc.C) {
 	s.setFailure("failed!")
 	_, err := testing.RunCommand(c, s.wrappedCommand, s.metaresult.ID)
@@ -22,6 +22,12 @@ var (
 	NewAPIClient = &newAPIClient
 )
 
+// ControllerModel returns whether the command connects to the
+// controller model rather than the selected model.
+func ControllerModel(c *CommandBase) bool {
+	return c.controllerModel
+}
+
 type CreateCommand struct {
 	*createCommand
 }
@@ -60,6 +66,12 @@ func NewUploadCommandForTest() cmd.Command {
 	return modelcmd.Wrap(c)
 }
 
+func NewVerifyCommandForTest() cmd.Command {
+	c := &verifyCommand{}
+	c.Log = &cmd.Log{}
+	return modelcmd.Wrap(c)
+}
+
 func NewRemoveCommandForTest() cmd.Command {
 	c := &removeCommand{}
 	c.Log = &cmd.Log{}
@@ -14,6 +14,7 @@ import (
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
 
 	apibackups "github.com/juju/juju/api/backups"
 	"github.com/juju/juju/apiserver/params"
@@ -119,10 +120,14 @@ type fakeAPIClient struct {
 	archive    io.ReadCloser
 	err        error
 
-	calls []string
-	args  []string
-	idArg string
-	notes string
+	calls        []string
+	args         []string
+	idArg        string
+	notes        string
+	createArgs   params.BackupsCreateArgs
+	restoreArgs  params.RestoreArgs
+	verifyArgs   params.BackupsVerifyArgs
+	verifyResult *params.BackupsVerifyResult
 }
 
 func (f *fakeAPIClient) Check(c *gc.C, id, notes string, calls ...string) {
@@ -131,10 +136,11 @@ func (f *fakeAPIClient) Check(c *gc.C, id, notes string, calls ...string) {
 	c.Check(f.notes, gc.Equals, notes)
 }
 
-func (c *fakeAPIClient) Create(notes string) (*params.BackupsMetadataResult, error) {
+func (c *fakeAPIClient) Create(args params.BackupsCreateArgs) (*params.BackupsMetadataResult, error) {
 	c.calls = append(c.calls, "Create")
 	c.args = append(c.args, "notes")
-	c.notes = notes
+	c.notes = args.Notes
+	c.createArgs = args
 	if c.err != nil {
 		return nil, c.err
 	}
@@ -197,6 +203,28 @@ func (c *fakeAPIClient) RestoreReader(io.ReadSeeker, *params.BackupsMetadataResu
 	return nil
 }
 
-func (c *fakeAPIClient) Restore(string, apibackups.ClientConnection) error {
+func (c *fakeAPIClient) Restore(args params.RestoreArgs, newClient apibackups.ClientConnection) error {
+	c.calls = append(c.calls, "Restore")
+	c.restoreArgs = args
 	return nil
 }
+
+func (c *fakeAPIClient) Verify(args params.BackupsVerifyArgs) (*params.BackupsVerifyResult, error) {
+	c.calls = append(c.calls, "Verify")
+	c.args = append(c.args, "id")
+	c.idArg = args.ID
+	c.verifyArgs = args
+	if c.err != nil {
+		return nil, c.err
+	}
+	return c.verifyResult, nil
+}
+
+func (c *fakeAPIClient) RestoreModel(args params.RestoreArgs) (names.ModelTag, error) {
+	c.calls = append(c.calls, "RestoreModel")
+	c.restoreArgs = args
+	if c.err != nil {
+		return names.ModelTag{}, c.err
+	}
+	return jujutesting.ModelTag, nil
+}
@@ -7,6 +7,8 @@ import (
 	"crypto/rand"
 	"fmt"
 	"io"
+	"io/ioutil"
+	"os"
 	"path/filepath"
 	"strings"
 	"time"
@@ -14,6 +16,7 @@ import (
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/utils"
+	"gopkg.in/juju/names.v2"
 	"launchpad.net/gnuflag"
 
 	"github.com/juju/juju/api/backups"
@@ -28,6 +31,7 @@ import (
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/sync"
 	"github.com/juju/juju/jujuclient"
+	statebackups "github.com/juju/juju/state/backups"
 	"github.com/juju/juju/version"
 )
 
@@ -47,6 +51,7 @@ func NewRestoreCommand() cmd.Command {
 // it is invoked with "juju restore-backup".
 type restoreCommand struct {
 	CommandBase
+	decryptionFlags
 	constraints constraints.Value
 	filename    string
 	backupId    string
@@ -65,10 +70,19 @@ type RestoreAPI interface {
 	Close() error
 
 	// Restore is taken from backups.Client.
-	Restore(backupId string, newClient backups.ClientConnection) error
+	Restore(args params.RestoreArgs, newClient backups.ClientConnection) error
 
 	// RestoreReader is taken from backups.Client.
 	RestoreReader(r io.ReadSeeker, meta *params.BackupsMetadataResult, newClient backups.ClientConnection) error
+
+	// Info is taken from backups.Client.
+	Info(id string) (*params.BackupsMetadataResult, error)
+
+	// Upload is taken from backups.Client.
+	Upload(r io.ReadSeeker, meta params.BackupsMetadataResult) (string, error)
+
+	// RestoreModel is taken from backups.Client.
+	RestoreModel(args params.RestoreArgs) (names.ModelTag, error)
 }
 
 var restoreDoc = `
@@ -82,6 +96,19 @@ configured to treat the new controller as their master.
 
 The given constraints will be used to choose the new instance.
 
+An encrypted backup is decrypted with the passphrase in the file given
+by --passphrase-file, or the ASCII-armored OpenPGP private key in the
+file given by --private-key-file (and --passphrase-file, if the key is
+itself protected by a passphrase).  A backup restored with --id is
+decrypted by the controller; a backup restored with --file is decrypted
+locally before it is uploaded.
+
+A model backup, created with "juju create-backup" on a hosted model,
+is instead restored into the running controller, which must no longer
+hold that model; the -b option may not be used with it.  The model is
+re-created with the UUID it was backed up with, though its machines
+must then be provisioned afresh.
+
 If the provided state cannot be restored, this command will fail with
 an appropriate message.  For instance, if the existing bootstrap
 instance is already running then the command will fail with a message
@@ -110,6 +137,7 @@ func (c *restoreCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.StringVar(&c.filename, "file", "", "Provide a file to be used as the backup.")
 	f.StringVar(&c.backupId, "id", "", "Provide the name of the backup to be restored")
 	f.BoolVar(&c.uploadTools, "upload-tools", false, "Upload tools if bootstraping a new machine")
+	c.decryptionFlags.SetFlags(f)
 }
 
 // Init is where the preconditions for this commands can be checked.
@@ -130,6 +158,9 @@ func (c *restoreCommand) Init(args []string) error {
 			return errors.Trace(err)
 		}
 	}
+	// Backups are only served by the controller model, so restore
+	// through it unless a new controller is bootstrapped.
+	c.controllerModel = !c.bootstrap
 	return nil
 }
 
@@ -326,6 +357,11 @@ func (c *restoreCommand) Run(ctx *cmd.Context) error {
 		}
 	}
 
+	decryption, err := c.decryptionFlags.args(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
 	var archive ArchiveReader
 	var meta *params.BackupsMetadataResult
 	target := c.backupId
@@ -334,13 +370,24 @@ func (c *restoreCommand) Run(ctx *cmd.Context) error {
 		// we'll need the info later regardless if
 		// we need it now to rebootstrap.
 		target = c.filename
-		var err error
-		archive, meta, err = c.getArchiveFunc(c.filename)
+		filename := c.filename
+		if c.decryptionFlags.isSet() {
+			decrypted, err := decryptArchiveFile(c.filename, c.decryptionFlags.scheme(), decryption)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			defer os.Remove(decrypted)
+			filename = decrypted
+		}
+		archive, meta, err = c.getArchiveFunc(filename)
 		if err != nil {
 			return errors.Trace(err)
 		}
 		defer archive.Close()
 
+		if c.bootstrap && meta.ModelUUID != "" {
+			return errors.Errorf("cannot bootstrap a new controller from model backup %q", target)
+		}
 		if c.bootstrap {
 			if err := c.rebootstrap(ctx, meta); err != nil {
 				return errors.Trace(err)
@@ -356,10 +403,24 @@ func (c *restoreCommand) Run(ctx *cmd.Context) error {
 
 	// We have a backup client, now use the relevant method
 	// to restore the backup.
-	if c.filename != "" {
+	restoreArgs := params.RestoreArgs{
+		BackupId:   c.backupId,
+		Passphrase: decryption.Passphrase,
+		PrivateKey: decryption.PrivateKey,
+	}
+	if c.filename == "" {
+		meta, err = client.Info(c.backupId)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	switch {
+	case meta.ModelUUID != "":
+		err = c.restoreModel(ctx, client, archive, meta, restoreArgs)
+	case c.filename != "":
 		err = client.RestoreReader(archive, meta, c.newClient)
-	} else {
-		err = client.Restore(c.backupId, c.newClient)
+	default:
+		err = client.Restore(restoreArgs, c.newClient)
 	}
 	if err != nil {
 		return errors.Trace(err)
@@ -367,3 +428,55 @@ func (c *restoreCommand) Run(ctx *cmd.Context) error {
 	fmt.Fprintf(ctx.Stdout, "restore from %q completed\n", target)
 	return nil
 }
+
+// restoreModel re-creates the model in a model backup in the running
+// controller. An archive file is uploaded to the controller first; it
+// has already been decrypted, if need be.
+func (c *restoreCommand) restoreModel(
+	ctx *cmd.Context, client RestoreAPI, archive ArchiveReader,
+	meta *params.BackupsMetadataResult, args params.RestoreArgs,
+) error {
+	if archive != nil {
+		id, err := client.Upload(archive, *meta)
+		if err != nil {
+			return errors.Annotate(err, "cannot upload backup archive")
+		}
+		args = params.RestoreArgs{BackupId: id}
+	}
+	modelTag, err := client.RestoreModel(args)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	fmt.Fprintf(ctx.Stdout, "restored model %q\n", modelTag.Id())
+	return nil
+}
+
+// decryptArchiveFile decrypts the named archive file to a new temporary
+// file, and returns the name of that file.
+func decryptArchiveFile(filename, scheme string, args statebackups.DecryptArgs) (_ string, err error) {
+	encrypted, err := os.Open(filename)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	defer encrypted.Close()
+
+	decrypted, err := ioutil.TempFile("", "juju-restore-")
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	defer decrypted.Close()
+	defer func() {
+		if err != nil {
+			os.Remove(decrypted.Name())
+		}
+	}()
+
+	r, err := statebackups.DecryptArchive(encrypted, scheme, args)
+	if err != nil {
+		return "", errors.Annotatef(err, "cannot decrypt %q", filename)
+	}
+	if _, err := io.Copy(decrypted, r); err != nil {
+		return "", errors.Annotatef(err, "cannot decrypt %q", filename)
+	}
+	return decrypted.Name(), nil
+}
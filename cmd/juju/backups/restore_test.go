@@ -4,11 +4,16 @@
 package backups_test
 
 import (
+	"bytes"
+	"fmt"
 	"io"
+	"io/ioutil"
+	"path/filepath"
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
 
 	apibackups "github.com/juju/juju/api/backups"
 	"github.com/juju/juju/apiserver/params"
@@ -21,6 +26,7 @@ import (
 	"github.com/juju/juju/jujuclient/jujuclienttesting"
 	"github.com/juju/juju/network"
 	_ "github.com/juju/juju/provider/dummy"
+	statebackups "github.com/juju/juju/state/backups"
 	"github.com/juju/juju/testing"
 )
 
@@ -106,6 +112,9 @@ func (s *restoreSuite) TestRestoreArgs(c *gc.C) {
 // TODO(wallyworld) - add more api related unit tests
 type mockRestoreAPI struct {
 	backups.RestoreAPI
+	calls       []string
+	meta        params.BackupsMetadataResult
+	restoreArgs params.RestoreArgs
 }
 
 func (*mockRestoreAPI) Close() error {
@@ -116,6 +125,30 @@ func (*mockRestoreAPI) RestoreReader(io.ReadSeeker, *params.BackupsMetadataResul
 	return nil
 }
 
+func (m *mockRestoreAPI) Restore(args params.RestoreArgs, newClient apibackups.ClientConnection) error {
+	m.calls = append(m.calls, "Restore")
+	m.restoreArgs = args
+	return nil
+}
+
+func (m *mockRestoreAPI) Info(id string) (*params.BackupsMetadataResult, error) {
+	m.calls = append(m.calls, "Info")
+	meta := m.meta
+	meta.ID = id
+	return &meta, nil
+}
+
+func (m *mockRestoreAPI) Upload(r io.ReadSeeker, meta params.BackupsMetadataResult) (string, error) {
+	m.calls = append(m.calls, "Upload")
+	return "uploaded-id", nil
+}
+
+func (m *mockRestoreAPI) RestoreModel(args params.RestoreArgs) (names.ModelTag, error) {
+	m.calls = append(m.calls, "RestoreModel")
+	m.restoreArgs = args
+	return testing.ModelTag, nil
+}
+
 type mockArchiveReader struct {
 	backups.ArchiveReader
 }
@@ -124,6 +157,92 @@ func (*mockArchiveReader) Close() error {
 	return nil
 }
 
+func (s *restoreSuite) TestRestoreIDDecryption(c *gc.C) {
+	api := &mockRestoreAPI{}
+	s.command = backups.NewRestoreCommandForTest(s.store, api, nil, nil)
+	passphraseFile := filepath.Join(c.MkDir(), "passphrase")
+	err := ioutil.WriteFile(passphraseFile, []byte("secret\n"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = testing.RunCommand(c, s.command, "restore", "--id", "anid", "--passphrase-file", passphraseFile)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(api.restoreArgs, jc.DeepEquals, params.RestoreArgs{
+		BackupId:   "anid",
+		Passphrase: "secret",
+	})
+}
+
+func (s *restoreSuite) TestRestoreFileDecryption(c *gc.C) {
+	dir := c.MkDir()
+	passphraseFile := filepath.Join(dir, "passphrase")
+	err := ioutil.WriteFile(passphraseFile, []byte("secret\n"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	var encrypted bytes.Buffer
+	err = statebackups.EncryptArchive(&encrypted, bytes.NewBufferString(s.data), statebackups.EncryptArgs{
+		Passphrase: "secret",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	archiveFile := filepath.Join(dir, "backup.tar.gz.gpg")
+	err = ioutil.WriteFile(archiveFile, encrypted.Bytes(), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var data []byte
+	s.command = backups.NewRestoreCommandForTest(
+		s.store, &mockRestoreAPI{},
+		func(filename string) (backups.ArchiveReader, *params.BackupsMetadataResult, error) {
+			var err error
+			data, err = ioutil.ReadFile(filename)
+			c.Assert(err, jc.ErrorIsNil)
+			return &mockArchiveReader{}, &params.BackupsMetadataResult{}, nil
+		},
+		nil,
+	)
+	_, err = testing.RunCommand(c, s.command, "restore", "--file", archiveFile, "--passphrase-file", passphraseFile)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, s.data)
+}
+
+func (s *restoreSuite) TestRestoreModelID(c *gc.C) {
+	api := &mockRestoreAPI{
+		meta: params.BackupsMetadataResult{ModelUUID: testing.ModelTag.Id()},
+	}
+	s.command = backups.NewRestoreCommandForTest(s.store, api, nil, nil)
+	ctx, err := testing.RunCommand(c, s.command, "restore", "--id", "anid")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(api.calls, jc.DeepEquals, []string{"Info", "RestoreModel"})
+	c.Check(api.restoreArgs, jc.DeepEquals, params.RestoreArgs{BackupId: "anid"})
+	c.Check(testing.Stdout(ctx), jc.Contains, fmt.Sprintf("restored model %q\n", testing.ModelTag.Id()))
+}
+
+func (s *restoreSuite) TestRestoreModelFile(c *gc.C) {
+	api := &mockRestoreAPI{}
+	s.command = backups.NewRestoreCommandForTest(
+		s.store, api,
+		func(string) (backups.ArchiveReader, *params.BackupsMetadataResult, error) {
+			return &mockArchiveReader{}, &params.BackupsMetadataResult{ModelUUID: testing.ModelTag.Id()}, nil
+		},
+		nil,
+	)
+	_, err := testing.RunCommand(c, s.command, "restore", "--file", "afile")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(api.calls, jc.DeepEquals, []string{"Upload", "RestoreModel"})
+	c.Check(api.restoreArgs, jc.DeepEquals, params.RestoreArgs{BackupId: "uploaded-id"})
+}
+
+func (s *restoreSuite) TestRestoreModelRebootstrap(c *gc.C) {
+	s.command = backups.NewRestoreCommandForTest(
+		s.store, &mockRestoreAPI{},
+		func(string) (backups.ArchiveReader, *params.BackupsMetadataResult, error) {
+			return &mockArchiveReader{}, &params.BackupsMetadataResult{ModelUUID: testing.ModelTag.Id()}, nil
+		},
+		nil,
+	)
+	_, err := testing.RunCommand(c, s.command, "restore", "--file", "afile", "-b")
+	c.Assert(err, gc.ErrorMatches, `cannot bootstrap a new controller from model backup ".*afile"`)
+}
+
 func (s *restoreSuite) TestRestoreReboostrapControllerExists(c *gc.C) {
 	fakeEnv := fakeEnviron{controllerInstances: []instance.Id{"1"}}
 	s.command = backups.NewRestoreCommandForTest(
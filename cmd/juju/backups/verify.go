@@ -0,0 +1,152 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/state/backups"
+)
+
+const verifyDoc = `
+verify-backup checks that a backup is usable, without restoring it.
+
+The backup with the given ID is verified by the controller; a local
+archive file given with --file is verified on this machine instead.
+The archive's size and checksum are checked against its metadata, and
+its contents are unpacked: the database dump of a controller backup
+must be readable as mongorestore would read it, and a model backup
+must hold the model and its charms.  The version of Juju that created
+the backup, and the number of models in it, are then printed.
+
+An encrypted backup is decrypted with the passphrase in the file given
+by --passphrase-file, or the ASCII-armored OpenPGP private key in the
+file given by --private-key-file.
+`
+
+// NewVerifyCommand returns a command used to verify backups.
+func NewVerifyCommand() cmd.Command {
+	return modelcmd.Wrap(&verifyCommand{})
+}
+
+// verifyCommand is the sub-command for verifying a backup.
+type verifyCommand struct {
+	CommandBase
+	decryptionFlags
+	// ID is the ID of the stored backup to verify.
+	ID string
+	// Filename is the local archive file to verify.
+	Filename string
+}
+
+// Info implements Command.Info.
+func (c *verifyCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "verify-backup",
+		Args:    "<ID> | --file <filename>",
+		Purpose: "Check that a backup is usable.",
+		Doc:     verifyDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *verifyCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	f.StringVar(&c.Filename, "file", "", "Verify this local archive file")
+	c.decryptionFlags.SetFlags(f)
+}
+
+// Init implements Command.Init.
+func (c *verifyCommand) Init(args []string) error {
+	id, err := cmd.ZeroOrOneArgs(args)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	c.ID = id
+	if c.ID == "" && c.Filename == "" {
+		return errors.New("missing ID or --file")
+	}
+	if c.ID != "" && c.Filename != "" {
+		return errors.New("cannot specify both an ID and --file")
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *verifyCommand) Run(ctx *cmd.Context) error {
+	if c.Log != nil {
+		if err := c.Log.Start(ctx); err != nil {
+			return err
+		}
+	}
+	decryption, err := c.decryptionFlags.args(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	var result *params.BackupsVerifyResult
+	if c.Filename != "" {
+		result, err = c.verifyFile(ctx, decryption)
+	} else {
+		result, err = c.verifyStored(decryption)
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	fmt.Fprintf(ctx.Stdout, "juju version:    %v\n", result.Version)
+	fmt.Fprintf(ctx.Stdout, "models:          %d\n", result.ModelCount)
+	if result.Collections > 0 {
+		fmt.Fprintf(ctx.Stdout, "collections:     %d\n", result.Collections)
+		fmt.Fprintf(ctx.Stdout, "documents:       %d\n", result.Documents)
+	}
+	return nil
+}
+
+func (c *verifyCommand) verifyStored(decryption backups.DecryptArgs) (*params.BackupsVerifyResult, error) {
+	client, err := c.NewAPIClient()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer client.Close()
+
+	return client.Verify(params.BackupsVerifyArgs{
+		ID:         c.ID,
+		Passphrase: decryption.Passphrase,
+		PrivateKey: decryption.PrivateKey,
+	})
+}
+
+func (c *verifyCommand) verifyFile(ctx *cmd.Context, decryption backups.DecryptArgs) (*params.BackupsVerifyResult, error) {
+	archive, err := os.Open(ctx.AbsPath(c.Filename))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer archive.Close()
+
+	// The metadata of a local archive is inside it, so only the
+	// encryption scheme implied by the flags is known.
+	var meta *backups.Metadata
+	if c.decryptionFlags.isSet() {
+		meta = backups.NewMetadata()
+		meta.Encryption = c.decryptionFlags.scheme()
+	}
+	verification, err := backups.VerifyArchive(archive, meta, decryption)
+	if err != nil {
+		return nil, errors.Annotatef(err, "archive %q failed verification", c.Filename)
+	}
+	return &params.BackupsVerifyResult{
+		Version:     verification.Version,
+		ModelCount:  verification.ModelCount,
+		Collections: verification.Collections,
+		Documents:   verification.Documents,
+	}, nil
+}
@@ -0,0 +1,118 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/backups"
+	bt "github.com/juju/juju/state/backups/testing"
+	"github.com/juju/juju/testing"
+)
+
+type verifySuite struct {
+	BaseBackupsSuite
+	subcommand cmd.Command
+}
+
+var _ = gc.Suite(&verifySuite{})
+
+func (s *verifySuite) SetUpTest(c *gc.C) {
+	s.BaseBackupsSuite.SetUpTest(c)
+	s.subcommand = backups.NewVerifyCommandForTest()
+}
+
+func (s *verifySuite) TestArgs(c *gc.C) {
+	_, err := testing.RunCommand(c, s.subcommand)
+	c.Check(err, gc.ErrorMatches, "missing ID or --file")
+
+	_, err = testing.RunCommand(c, s.subcommand, "spam", "--file", "backup.tar.gz")
+	c.Check(err, gc.ErrorMatches, "cannot specify both an ID and --file")
+}
+
+func (s *verifySuite) TestVerifyID(c *gc.C) {
+	client := s.setSuccess()
+	client.verifyResult = &params.BackupsVerifyResult{
+		Version:     version.MustParse("2.0.0"),
+		ModelCount:  2,
+		Collections: 40,
+		Documents:   1000,
+	}
+	ctx, err := testing.RunCommand(c, s.subcommand, s.metaresult.ID)
+	c.Assert(err, jc.ErrorIsNil)
+
+	client.Check(c, s.metaresult.ID, "", "Verify")
+	s.checkStd(c, ctx, `
+juju version:    2.0.0
+models:          2
+collections:     40
+documents:       1000
+`[1:], "")
+}
+
+func (s *verifySuite) TestVerifyIDDecryption(c *gc.C) {
+	client := s.setSuccess()
+	client.verifyResult = &params.BackupsVerifyResult{ModelCount: 1}
+	passphraseFile := filepath.Join(c.MkDir(), "passphrase")
+	err := ioutil.WriteFile(passphraseFile, []byte("secret\n"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = testing.RunCommand(c, s.subcommand, s.metaresult.ID, "--passphrase-file", passphraseFile)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(client.verifyArgs, jc.DeepEquals, params.BackupsVerifyArgs{
+		ID:         s.metaresult.ID,
+		Passphrase: "secret",
+	})
+}
+
+func (s *verifySuite) TestVerifyFile(c *gc.C) {
+	meta := bt.NewMetadataStarted()
+	meta.Origin.Version = version.MustParse("2.0.0")
+	models, err := bson.Marshal(bson.M{"_id": "model-1"})
+	c.Assert(err, jc.ErrorIsNil)
+	archive, err := bt.NewArchive(meta, nil, []bt.File{
+		{Name: "juju", IsDir: true},
+		{Name: "juju/models.bson", Content: string(models)},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	filename := filepath.Join(c.MkDir(), "backup.tar.gz")
+	err = ioutil.WriteFile(filename, archive.Bytes(), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	client := s.setSuccess()
+	ctx, err := testing.RunCommand(c, s.subcommand, "--file", filename)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(client.calls, gc.HasLen, 0)
+	s.checkStd(c, ctx, `
+juju version:    2.0.0
+models:          1
+collections:     1
+documents:       1
+`[1:], "")
+}
+
+func (s *verifySuite) TestVerifyFileInvalid(c *gc.C) {
+	filename := filepath.Join(c.MkDir(), "backup.tar.gz")
+	err := ioutil.WriteFile(filename, []byte("<compressed archive data>"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = testing.RunCommand(c, s.subcommand, "--file", filename)
+	c.Check(err, gc.ErrorMatches, `archive ".*backup.tar.gz" failed verification: cannot unpack archive: .*`)
+}
+
+func (s *verifySuite) TestError(c *gc.C) {
+	s.setFailure("failed!")
+	_, err := testing.RunCommand(c, s.subcommand, s.metaresult.ID)
+	c.Check(errors.Cause(err), gc.ErrorMatches, "failed!")
+}
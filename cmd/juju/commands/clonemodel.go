@@ -0,0 +1,117 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+)
+
+func newCloneModelCommand() cmd.Command {
+	return modelcmd.WrapController(&cloneModelCommand{})
+}
+
+// cloneModelCommand creates a copy of a model in the same controller.
+type cloneModelCommand struct {
+	modelcmd.ControllerCommandBase
+	api cloneModelAPI
+
+	model    string
+	newModel string
+}
+
+type cloneModelAPI interface {
+	CloneModel(modelUUID, name string) (string, error)
+	Close() error
+}
+
+const cloneModelDoc = `
+clone-model creates a new model in the controller that is a copy of an
+existing one. The new model has the same machines, applications,
+relations, storage and configuration as the original, so it can be
+used as a staging copy of a production model.
+
+None of the cloud resources of the original model are shared with the
+copy. The new model provisions its own machines and storage, and its
+machine and unit agents get credentials of their own. Payloads,
+actions and other records of the running original model aren't
+copied.
+
+The original model is not changed. The new model isn't made the
+current model.
+
+Examples:
+    juju clone-model production staging
+
+See Also:
+   juju help add-model
+   juju help export-model
+`
+
+// Info implements cmd.Command.
+func (c *cloneModelCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "clone-model",
+		Args:    "<model-name> <new-model-name>",
+		Purpose: "create a copy of a model in the same controller",
+		Doc:     cloneModelDoc,
+	}
+}
+
+// Init implements cmd.Command.
+func (c *cloneModelCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("model not specified")
+	}
+	if len(args) < 2 {
+		return errors.New("new model name not specified")
+	}
+	if len(args) > 2 {
+		return errors.New("too many arguments specified")
+	}
+	c.model = args[0]
+	c.newModel = args[1]
+	return nil
+}
+
+// Run implements cmd.Command.
+func (c *cloneModelCommand) Run(ctx *cmd.Context) error {
+	store := c.ClientStore()
+	controllerName := c.ControllerName()
+	accountName := c.AccountName()
+	modelInfo, err := store.ModelByName(controllerName, accountName, c.model)
+	if err != nil {
+		return err
+	}
+
+	api, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	cloneUUID, err := api.CloneModel(modelInfo.ModelUUID, c.newModel)
+	if err != nil {
+		return errors.Annotatef(err, "cloning model %q", c.model)
+	}
+	// The clone has the users of the original model, so it can be
+	// used with the same account.
+	if err := store.UpdateModel(controllerName, accountName, c.newModel, jujuclient.ModelDetails{
+		ModelUUID: cloneUUID,
+	}); err != nil {
+		return errors.Trace(err)
+	}
+	ctx.Infof("Model %q cloned from %q", c.newModel, c.model)
+	return nil
+}
+
+func (c *cloneModelCommand) getAPI() (cloneModelAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return c.NewControllerAPIClient()
+}
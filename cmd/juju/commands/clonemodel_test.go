@@ -0,0 +1,131 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/feature"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/testing"
+)
+
+type CloneModelSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api   *fakeCloneModelAPI
+	store *jujuclienttesting.MemStore
+}
+
+var _ = gc.Suite(&CloneModelSuite{})
+
+const cloneUUID = "cafef00d-0bad-400d-8000-4b1d0d06f00d"
+
+func (s *CloneModelSuite) SetUpTest(c *gc.C) {
+	s.SetInitialFeatureFlags(feature.Migration)
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+
+	s.store = jujuclienttesting.NewMemStore()
+	err := s.store.UpdateController("ctrl", jujuclient.ControllerDetails{
+		ControllerUUID: targetControllerUUID,
+		CACert:         "cert",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.store.SetCurrentController("ctrl")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.store.UpdateAccount("ctrl", "admin@local", jujuclient.AccountDetails{
+		User: "admin@local",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.store.SetCurrentAccount("ctrl", "admin@local")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.store.UpdateModel("ctrl", "admin@local", "production", jujuclient.ModelDetails{
+		ModelUUID: modelUUID,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.store.SetCurrentModel("ctrl", "admin@local", "production")
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.api = &fakeCloneModelAPI{}
+}
+
+func (s *CloneModelSuite) TestMissingModel(c *gc.C) {
+	_, err := s.runCommand(c)
+	c.Assert(err, gc.ErrorMatches, "model not specified")
+}
+
+func (s *CloneModelSuite) TestMissingNewName(c *gc.C) {
+	_, err := s.runCommand(c, "production")
+	c.Assert(err, gc.ErrorMatches, "new model name not specified")
+}
+
+func (s *CloneModelSuite) TestTooManyArgs(c *gc.C) {
+	_, err := s.runCommand(c, "one", "too", "many")
+	c.Assert(err, gc.ErrorMatches, "too many arguments specified")
+}
+
+func (s *CloneModelSuite) TestClone(c *gc.C) {
+	ctx, err := s.runCommand(c, "production", "staging")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(testing.Stderr(ctx), gc.Equals, "Model \"staging\" cloned from \"production\"\n")
+	c.Check(s.api.modelUUID, gc.Equals, modelUUID)
+	c.Check(s.api.name, gc.Equals, "staging")
+	c.Check(s.api.closed, jc.IsTrue)
+
+	details, err := s.store.ModelByName("ctrl", "admin@local", "staging")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(details.ModelUUID, gc.Equals, cloneUUID)
+	current, err := s.store.CurrentModel("ctrl", "admin@local")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(current, gc.Equals, "production")
+}
+
+func (s *CloneModelSuite) TestCloneError(c *gc.C) {
+	s.api.err = errors.New("boom")
+	_, err := s.runCommand(c, "production", "staging")
+	c.Assert(err, gc.ErrorMatches, `cloning model "production": boom`)
+
+	_, err = s.store.ModelByName("ctrl", "admin@local", "staging")
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *CloneModelSuite) TestModelDoesntExist(c *gc.C) {
+	_, err := s.runCommand(c, "wat", "staging")
+	c.Check(err, gc.ErrorMatches, "model .+ not found")
+	c.Check(s.api.modelUUID, gc.Equals, "") // API shouldn't have been called
+}
+
+func (s *CloneModelSuite) runCommand(c *gc.C, args ...string) (*cmd.Context, error) {
+	cmd := &cloneModelCommand{
+		api: s.api,
+	}
+	cmd.SetClientStore(s.store)
+	return testing.RunCommand(c, modelcmd.WrapController(cmd), args...)
+}
+
+type fakeCloneModelAPI struct {
+	modelUUID string
+	name      string
+	err       error
+	closed    bool
+}
+
+func (a *fakeCloneModelAPI) CloneModel(modelUUID, name string) (string, error) {
+	if a.err != nil {
+		return "", a.err
+	}
+	a.modelUUID = modelUUID
+	a.name = name
+	return cloneUUID, nil
+}
+
+func (a *fakeCloneModelAPI) Close() error {
+	a.closed = true
+	return nil
+}
@@ -6,9 +6,12 @@ package commands
 import (
 	"fmt"
 	"io"
+	"regexp"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
 	"launchpad.net/gnuflag"
 
 	"github.com/juju/juju/api"
@@ -19,6 +22,17 @@ import (
 // display, from the end of the consolidated log.
 const defaultLineCount = 10
 
+// formatText and formatJSON are the formats in which the log
+// may be displayed.
+const (
+	formatText = "text"
+	formatJSON = "json"
+)
+
+// timeLayout is the layout of the timestamps shown in the log, which
+// are in UTC.
+const timeLayout = "2006-01-02 15:04:05"
+
 var usageDebugLogSummary = `
 Displays log messages for a model.`[1:]
 
@@ -43,13 +57,29 @@ The '--include-module' and '--exclude-module' options filter by (dotted)
 logging module name. The module name can be truncated such that all loggers
 with the prefix will match.
 
+The '--include-message' option filters by message text: only messages
+matching the given regular expression are shown. The filtering is done by
+the controller.
+
 The filtering options combine as follows:
 * All --include options are logically ORed together.
 * All --exclude options are logically ORed together.
 * All --include-module options are logically ORed together.
 * All --exclude-module options are logically ORed together.
-* The combined --include, --exclude, --include-module and --exclude-module
-  selections are logically ANDed to form the complete filter.
+* The combined --include, --exclude, --include-module, --exclude-module
+  and --include-message selections are logically ANDed to form the
+  complete filter.
+
+The '--since' and '--until' options bound the time of the messages shown.
+Each takes either a duration before now, such as "90m" or "2h30m", or a
+time in the format of the log, "YYYY-MM-DD hh:mm:ss" in UTC, or in RFC3339
+format. With '--since', all messages since then are shown, as with
+'--replay'; with '--until', the log is no longer appended to once that time
+is reached.
+
+With '--format json', each log message is emitted as a JSON object on a
+line of its own, with the fields "model-uuid", "entity", "timestamp",
+"level", "module", "location" and "message".
 
 Examples:
 
@@ -88,6 +118,19 @@ new WARNING and ERROR messages as they are logged:
 
     juju debug-log --replay --level WARNING
 
+Show all messages logged during a two hour window, and then exit:
+
+    juju debug-log --since "2016-10-12 22:00:00" --until "2016-10-13 00:00:00"
+
+Show the messages of the last half hour, and continue to append:
+
+    juju debug-log --since 30m
+
+Show the entities and messages of all failed hooks as JSON, and then exit:
+
+    juju debug-log --replay --no-tail --format json \
+        --include-message '^hook ".*" failed' | jq '{entity, message}'
+
 See also: 
     status
     ssh`
@@ -101,13 +144,16 @@ func (c *debugLogCommand) Info() *cmd.Info {
 }
 
 func newDebugLogCommand() cmd.Command {
-	return modelcmd.Wrap(&debugLogCommand{})
+	return modelcmd.Wrap(&debugLogCommand{clock: clock.WallClock})
 }
 
 type debugLogCommand struct {
 	modelcmd.ModelCommandBase
 
+	clock  clock.Clock
 	level  string
+	since  string
+	until  string
 	params api.DebugLogParams
 }
 
@@ -128,6 +174,10 @@ func (c *debugLogCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.params.Replay, "replay", false, "Show the entire (possibly filtered) log and continue to append")
 	f.BoolVar(&c.params.NoTail, "T", false, "Stop after returning existing log messages")
 	f.BoolVar(&c.params.NoTail, "no-tail", false, "")
+	f.StringVar(&c.params.MessagePattern, "include-message", "", "Only show log messages matching this regular expression")
+	f.StringVar(&c.params.Format, "format", formatText, "Output format, one of [text, json]")
+	f.StringVar(&c.since, "since", "", "Only show log messages logged since this time or duration ago")
+	f.StringVar(&c.until, "until", "", "Only show log messages logged until this time or duration ago")
 }
 
 func (c *debugLogCommand) Init(args []string) error {
@@ -139,9 +189,53 @@ func (c *debugLogCommand) Init(args []string) error {
 		}
 		c.params.Level = level
 	}
+	if c.params.Format != formatText && c.params.Format != formatJSON {
+		return fmt.Errorf("format value %q is not one of %q, %q", c.params.Format, formatText, formatJSON)
+	}
+	if c.params.MessagePattern != "" {
+		if _, err := regexp.Compile(c.params.MessagePattern); err != nil {
+			return fmt.Errorf("invalid message pattern %q: %v", c.params.MessagePattern, err)
+		}
+	}
+	if c.since != "" {
+		since, err := c.parseTime(c.since)
+		if err != nil {
+			return fmt.Errorf("invalid --since value: %v", err)
+		}
+		c.params.StartTime = since
+		c.params.Replay = true
+	}
+	if c.until != "" {
+		until, err := c.parseTime(c.until)
+		if err != nil {
+			return fmt.Errorf("invalid --until value: %v", err)
+		}
+		if !c.params.StartTime.IsZero() && !until.After(c.params.StartTime) {
+			return fmt.Errorf("--until %q is not after --since %q", c.until, c.since)
+		}
+		c.params.EndTime = until
+	}
 	return cmd.CheckEmpty(args)
 }
 
+// parseTime returns the time given either as a duration before now, or
+// as a time in the format of the log or in RFC3339 format.
+func (c *debugLogCommand) parseTime(value string) (time.Time, error) {
+	if d, err := time.ParseDuration(value); err == nil {
+		if d < 0 {
+			return time.Time{}, fmt.Errorf("negative duration %q", value)
+		}
+		return c.clock.Now().Add(-d).UTC(), nil
+	}
+	if t, err := time.Parse(timeLayout, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.UTC(), nil
+	}
+	return time.Time{}, fmt.Errorf("%q is neither a duration nor a time", value)
+}
+
 type DebugLogAPI interface {
 	WatchDebugLog(params api.DebugLogParams) (io.ReadCloser, error)
 	Close() error
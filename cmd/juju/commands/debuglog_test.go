@@ -7,6 +7,7 @@ import (
 	"io"
 	"io/ioutil"
 	"strings"
+	"time"
 
 	"github.com/juju/loggo"
 	jc "github.com/juju/testing/checkers"
@@ -32,13 +33,18 @@ func (s *DebugLogSuite) TestArgParsing(c *gc.C) {
 		{
 			expected: api.DebugLogParams{
 				Backlog: 10,
+				Format:  "text",
 			},
 		}, {
 			args: []string{"-n0"},
+			expected: api.DebugLogParams{
+				Format: "text",
+			},
 		}, {
 			args: []string{"--lines=50"},
 			expected: api.DebugLogParams{
 				Backlog: 50,
+				Format:  "text",
 			},
 		}, {
 			args:     []string{"-l", "foo"},
@@ -48,48 +54,93 @@ func (s *DebugLogSuite) TestArgParsing(c *gc.C) {
 			expected: api.DebugLogParams{
 				Backlog: 10,
 				Level:   loggo.INFO,
+				Format:  "text",
 			},
 		}, {
 			args: []string{"--include", "machine-1", "-i", "machine-2"},
 			expected: api.DebugLogParams{
 				IncludeEntity: []string{"machine-1", "machine-2"},
 				Backlog:       10,
+				Format:        "text",
 			},
 		}, {
 			args: []string{"--exclude", "machine-1", "-x", "machine-2"},
 			expected: api.DebugLogParams{
 				ExcludeEntity: []string{"machine-1", "machine-2"},
 				Backlog:       10,
+				Format:        "text",
 			},
 		}, {
 			args: []string{"--include-module", "juju.foo", "--include-module", "unit"},
 			expected: api.DebugLogParams{
 				IncludeModule: []string{"juju.foo", "unit"},
 				Backlog:       10,
+				Format:        "text",
 			},
 		}, {
 			args: []string{"--exclude-module", "juju.foo", "--exclude-module", "unit"},
 			expected: api.DebugLogParams{
 				ExcludeModule: []string{"juju.foo", "unit"},
 				Backlog:       10,
+				Format:        "text",
 			},
 		}, {
 			args: []string{"--replay"},
 			expected: api.DebugLogParams{
 				Backlog: 10,
 				Replay:  true,
+				Format:  "text",
 			},
 		}, {
 			args: []string{"--no-tail"},
 			expected: api.DebugLogParams{
 				Backlog: 10,
 				NoTail:  true,
+				Format:  "text",
+			},
+		}, {
+			args: []string{"--format", "json"},
+			expected: api.DebugLogParams{
+				Backlog: 10,
+				Format:  "json",
+			},
+		}, {
+			args:     []string{"--format", "yaml"},
+			errMatch: `format value "yaml" is not one of "text", "json"`,
+		}, {
+			args: []string{"--include-message", `^hook ".*" failed`},
+			expected: api.DebugLogParams{
+				Backlog:        10,
+				MessagePattern: `^hook ".*" failed`,
+				Format:         "text",
 			},
+		}, {
+			args:     []string{"--include-message", "(foo"},
+			errMatch: `invalid message pattern "\(foo": .*`,
+		}, {
+			args: []string{"--since", "2016-10-12 22:00:00", "--until", "2016-10-13T02:00:00+02:00"},
+			expected: api.DebugLogParams{
+				Backlog:   10,
+				Replay:    true,
+				Format:    "text",
+				StartTime: time.Date(2016, 10, 12, 22, 0, 0, 0, time.UTC),
+				EndTime:   time.Date(2016, 10, 13, 0, 0, 0, 0, time.UTC),
+			},
+		}, {
+			args:     []string{"--since", "yesterday"},
+			errMatch: `invalid --since value: "yesterday" is neither a duration nor a time`,
+		}, {
+			args:     []string{"--until=-1h"},
+			errMatch: `invalid --until value: negative duration "-1h"`,
+		}, {
+			args:     []string{"--since", "2016-10-13 00:00:00", "--until", "2016-10-12 22:00:00"},
+			errMatch: `--until "2016-10-12 22:00:00" is not after --since "2016-10-13 00:00:00"`,
 		}, {
 			args: []string{"--limit", "100"},
 			expected: api.DebugLogParams{
 				Backlog: 10,
 				Limit:   100,
+				Format:  "text",
 			},
 		},
 	} {
@@ -105,6 +156,16 @@ func (s *DebugLogSuite) TestArgParsing(c *gc.C) {
 	}
 }
 
+func (s *DebugLogSuite) TestRelativeTimes(c *gc.C) {
+	now := time.Date(2016, 10, 13, 0, 0, 0, 0, time.UTC)
+	command := &debugLogCommand{clock: testing.NewClock(now)}
+	err := testing.InitCommand(modelcmd.Wrap(command), []string{"--since", "2h", "--until", "30m"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(command.params.StartTime, gc.Equals, now.Add(-2*time.Hour))
+	c.Check(command.params.EndTime, gc.Equals, now.Add(-30*time.Minute))
+	c.Check(command.params.Replay, jc.IsTrue)
+}
+
 func (s *DebugLogSuite) TestParamsPassed(c *gc.C) {
 	fake := &fakeDebugLogAPI{}
 	s.PatchValue(&getDebugLogAPI, func(_ *debugLogCommand) (DebugLogAPI, error) {
@@ -116,15 +177,19 @@ func (s *DebugLogSuite) TestParamsPassed(c *gc.C) {
 		"--lines=500",
 		"--level=WARNING",
 		"--no-tail",
+		"--include-message=failed",
+		"--format=json",
 	)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(fake.params, gc.DeepEquals, api.DebugLogParams{
-		IncludeEntity: []string{"machine-1*"},
-		IncludeModule: []string{"juju.provisioner"},
-		ExcludeEntity: []string{"machine-1-lxd-1"},
-		Backlog:       500,
-		Level:         loggo.WARNING,
-		NoTail:        true,
+		IncludeEntity:  []string{"machine-1*"},
+		IncludeModule:  []string{"juju.provisioner"},
+		ExcludeEntity:  []string{"machine-1-lxd-1"},
+		Backlog:        500,
+		Level:          loggo.WARNING,
+		NoTail:         true,
+		MessagePattern: "failed",
+		Format:         "json",
 	})
 }
 
@@ -0,0 +1,121 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"io"
+	"os"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+func newExportModelCommand() cmd.Command {
+	return modelcmd.Wrap(&exportModelCommand{})
+}
+
+// exportModelCommand writes an archive of a model to a file.
+type exportModelCommand struct {
+	modelcmd.ModelCommandBase
+	api exportModelAPI
+
+	filename string
+}
+
+type exportModelAPI interface {
+	ExportModel() (io.ReadCloser, error)
+	Close() error
+}
+
+const exportModelDoc = `
+export-model writes an archive of a model to a file. The archive holds
+the model's description along with the charms, tools and resources
+that the model uses, so that the model can be imported into a
+controller that can't reach the model's current controller. See the
+"import-model" command for details of how to do this.
+
+The model keeps running on its current controller after it has been
+exported. Changes made to the model after the export aren't included
+in the archive.
+
+Examples:
+    juju export-model mymodel.tar.gz
+    juju export-model -m othermodel othermodel.tar.gz
+
+See Also:
+   juju help import-model
+   juju help migrate
+`
+
+// Info implements cmd.Command.
+func (c *exportModelCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "export-model",
+		Args:    "<file>",
+		Purpose: "write an archive of a model to a file",
+		Doc:     exportModelDoc,
+	}
+}
+
+// Init implements cmd.Command.
+func (c *exportModelCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("file not specified")
+	}
+	if len(args) > 1 {
+		return errors.New("too many arguments specified")
+	}
+	c.filename = args[0]
+	return nil
+}
+
+// Run implements cmd.Command.
+func (c *exportModelCommand) Run(ctx *cmd.Context) error {
+	api, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	archive, err := api.ExportModel()
+	if err != nil {
+		return err
+	}
+	defer archive.Close()
+
+	filename := ctx.AbsPath(c.filename)
+	if err := writeModelArchive(filename, archive); err != nil {
+		return errors.Annotatef(err, "writing %s", c.filename)
+	}
+	ctx.Infof("Model %q exported to %s", c.ModelName(), c.filename)
+	return nil
+}
+
+// writeModelArchive copies the archive to the named file, removing the
+// file if the archive can't be written in full.
+func writeModelArchive(filename string, archive io.Reader) (err error) {
+	f, err := os.Create(filename)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() {
+		if closeErr := f.Close(); err == nil {
+			err = errors.Trace(closeErr)
+		}
+		if err != nil {
+			os.Remove(filename)
+		}
+	}()
+	_, err = io.Copy(f, archive)
+	return errors.Trace(err)
+}
+
+func (c *exportModelCommand) getAPI() (exportModelAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return c.NewAPIClient()
+}
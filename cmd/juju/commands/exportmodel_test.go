@@ -0,0 +1,155 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/feature"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/testing"
+)
+
+type ExportModelSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api   *fakeExportModelAPI
+	store *jujuclienttesting.MemStore
+	dir   string
+}
+
+var _ = gc.Suite(&ExportModelSuite{})
+
+func (s *ExportModelSuite) SetUpTest(c *gc.C) {
+	s.SetInitialFeatureFlags(feature.Migration)
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+
+	s.store = jujuclienttesting.NewMemStore()
+	err := s.store.UpdateController("ctrl", jujuclient.ControllerDetails{
+		ControllerUUID: "eeeeeeee-0bad-400d-8000-4b1d0d06f00d",
+		CACert:         "somecert",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.store.SetCurrentController("ctrl")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.store.UpdateAccount("ctrl", "admin@local", jujuclient.AccountDetails{
+		User: "admin@local",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.store.SetCurrentAccount("ctrl", "admin@local")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.store.UpdateModel("ctrl", "admin@local", "model", jujuclient.ModelDetails{
+		ModelUUID: modelUUID,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.store.SetCurrentModel("ctrl", "admin@local", "model")
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.api = &fakeExportModelAPI{archive: []byte("archive")}
+	s.dir = c.MkDir()
+}
+
+func (s *ExportModelSuite) TestMissingFile(c *gc.C) {
+	_, err := s.runCommand(c)
+	c.Assert(err, gc.ErrorMatches, "file not specified")
+}
+
+func (s *ExportModelSuite) TestTooManyArgs(c *gc.C) {
+	_, err := s.runCommand(c, "one", "two")
+	c.Assert(err, gc.ErrorMatches, "too many arguments specified")
+}
+
+func (s *ExportModelSuite) TestExport(c *gc.C) {
+	filename := filepath.Join(s.dir, "model.tar.gz")
+	ctx, err := s.runCommand(c, filename)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(testing.Stderr(ctx), gc.Equals, `Model "model" exported to `+filename+"\n")
+	content, err := ioutil.ReadFile(filename)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(content), gc.Equals, "archive")
+	c.Check(s.api.archiveClosed, jc.IsTrue)
+	c.Check(s.api.closed, jc.IsTrue)
+}
+
+func (s *ExportModelSuite) TestExportError(c *gc.C) {
+	s.api.err = errors.New("boom")
+	filename := filepath.Join(s.dir, "model.tar.gz")
+	_, err := s.runCommand(c, filename)
+	c.Assert(err, gc.ErrorMatches, "boom")
+
+	_, err = os.Stat(filename)
+	c.Check(os.IsNotExist(err), jc.IsTrue)
+}
+
+func (s *ExportModelSuite) TestExportReadError(c *gc.C) {
+	s.api.readErr = errors.New("connection reset")
+	filename := filepath.Join(s.dir, "model.tar.gz")
+	_, err := s.runCommand(c, filename)
+	c.Assert(err, gc.ErrorMatches, "writing .*model.tar.gz: connection reset")
+
+	// The partially written archive is removed.
+	_, err = os.Stat(filename)
+	c.Check(os.IsNotExist(err), jc.IsTrue)
+}
+
+func (s *ExportModelSuite) runCommand(c *gc.C, args ...string) (*cmd.Context, error) {
+	cmd := &exportModelCommand{
+		api: s.api,
+	}
+	cmd.SetClientStore(s.store)
+	return testing.RunCommand(c, modelcmd.Wrap(cmd), args...)
+}
+
+type fakeExportModelAPI struct {
+	archive       []byte
+	err           error
+	readErr       error
+	archiveClosed bool
+	closed        bool
+}
+
+func (a *fakeExportModelAPI) ExportModel() (io.ReadCloser, error) {
+	if a.err != nil {
+		return nil, a.err
+	}
+	var r io.Reader = bytes.NewReader(a.archive)
+	if a.readErr != nil {
+		r = io.MultiReader(r, &errorReader{a.readErr})
+	}
+	return &fakeArchiveReader{Reader: r, closed: &a.archiveClosed}, nil
+}
+
+func (a *fakeExportModelAPI) Close() error {
+	a.closed = true
+	return nil
+}
+
+type fakeArchiveReader struct {
+	io.Reader
+	closed *bool
+}
+
+func (r *fakeArchiveReader) Close() error {
+	*r.closed = true
+	return nil
+}
+
+type errorReader struct {
+	err error
+}
+
+func (r *errorReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
@@ -0,0 +1,259 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"io"
+	"os"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/version"
+	"gopkg.in/juju/charm.v6-unstable"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/controller"
+	"github.com/juju/juju/api/migrationtarget"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/migration"
+)
+
+func newImportModelCommand() cmd.Command {
+	return modelcmd.WrapController(&importModelCommand{})
+}
+
+// importModelCommand imports a model archive, as written by the
+// export-model command, into a controller.
+type importModelCommand struct {
+	modelcmd.ControllerCommandBase
+	api       importModelAPI
+	sourceAPI importModelSourceAPI
+
+	filename         string
+	sourceController string
+}
+
+type importModelAPI interface {
+	migration.ArchiveTarget
+	Activate(modelUUID string) error
+	Close() error
+}
+
+type importModelSourceAPI interface {
+	InitiateModelMigration(spec controller.ModelMigrationSpec) (string, error)
+	Close() error
+}
+
+const importModelDoc = `
+import-model creates a model in the controller from an archive written
+by the "export-model" command, and uploads the charms, tools and
+resources held in the archive. The imported model has the same UUID as
+the exported one.
+
+The machines of the exported model keep running against their original
+controller. If that controller can be reached by the juju client, pass
+its name with --source to move the machine and unit agents over to the
+imported model. An agents-only migration is started on the source
+controller; its progress can be tracked with the "show-migration"
+command. Once it has completed, the model is no longer available at
+the source controller.
+
+The source controller must be in the juju client's local configuration
+cache. See the juju "login" command for details of how to do this.
+
+Examples:
+    juju import-model mymodel.tar.gz
+    juju import-model mymodel.tar.gz --source oldcontroller
+
+See Also:
+   juju help export-model
+   juju help migrate
+   juju help show-migration
+`
+
+// Info implements cmd.Command.
+func (c *importModelCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "import-model",
+		Args:    "<file>",
+		Purpose: "import a model archive into a controller",
+		Doc:     importModelDoc,
+	}
+}
+
+// SetFlags implements cmd.Command.
+func (c *importModelCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.StringVar(&c.sourceController, "source", "", "Move the model's agents from this controller once imported")
+}
+
+// Init implements cmd.Command.
+func (c *importModelCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("file not specified")
+	}
+	if len(args) > 1 {
+		return errors.New("too many arguments specified")
+	}
+	c.filename = args[0]
+	return nil
+}
+
+// Run implements cmd.Command.
+func (c *importModelCommand) Run(ctx *cmd.Context) error {
+	// Check that the agents could be moved before changing anything.
+	var spec *controller.ModelMigrationSpec
+	if c.sourceController != "" {
+		var err error
+		spec, err = c.getAgentMigrationSpec()
+		if err != nil {
+			return err
+		}
+	}
+
+	archive, err := os.Open(ctx.AbsPath(c.filename))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer archive.Close()
+
+	targetAPI, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer targetAPI.Close()
+
+	modelTag, err := migration.ImportArchive(archive, targetAPI)
+	if err != nil {
+		return err
+	}
+	if err := targetAPI.Activate(modelTag.Id()); err != nil {
+		return errors.Annotate(err, "activating model")
+	}
+	ctx.Infof("Model %s imported", modelTag.Id())
+
+	if spec == nil {
+		return nil
+	}
+	spec.ModelUUID = modelTag.Id()
+	return c.moveAgents(ctx, *spec)
+}
+
+// getAgentMigrationSpec returns the spec of a migration, on the source
+// controller, that moves the agents of the imported model over to
+// this controller. The model UUID is filled in once the model has been
+// imported.
+func (c *importModelCommand) getAgentMigrationSpec() (*controller.ModelMigrationSpec, error) {
+	store := c.ClientStore()
+
+	if _, err := store.ControllerByName(c.sourceController); err != nil {
+		return nil, err
+	}
+
+	controllerInfo, err := store.ControllerByName(c.ControllerName())
+	if err != nil {
+		return nil, err
+	}
+
+	accountInfo, err := store.AccountByName(c.ControllerName(), c.AccountName())
+	if err != nil {
+		return nil, err
+	}
+
+	return &controller.ModelMigrationSpec{
+		TargetControllerUUID: controllerInfo.ControllerUUID,
+		TargetAddrs:          controllerInfo.APIEndpoints,
+		TargetCACert:         controllerInfo.CACert,
+		TargetUser:           accountInfo.User,
+		TargetPassword:       accountInfo.Password,
+		Offline:              true,
+	}, nil
+}
+
+func (c *importModelCommand) moveAgents(ctx *cmd.Context, spec controller.ModelMigrationSpec) error {
+	sourceAPI, err := c.getSourceAPI()
+	if err != nil {
+		return err
+	}
+	defer sourceAPI.Close()
+
+	id, err := sourceAPI.InitiateModelMigration(spec)
+	if err != nil {
+		return errors.Annotatef(err, "moving agents from %q", c.sourceController)
+	}
+	ctx.Infof("Migration of agents from %q started with ID %q", c.sourceController, id)
+	return nil
+}
+
+func (c *importModelCommand) getAPI() (importModelAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &importModelClient{
+		target: migrationtarget.NewClient(root),
+		client: root.Client(),
+	}, nil
+}
+
+func (c *importModelCommand) getSourceAPI() (importModelSourceAPI, error) {
+	if c.sourceAPI != nil {
+		return c.sourceAPI, nil
+	}
+	store := c.ClientStore()
+	accountName, err := store.CurrentAccount(c.sourceController)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	root, err := c.JujuCommandBase.NewAPIRoot(store, c.sourceController, accountName, "")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return controller.NewClient(root), nil
+}
+
+// importModelClient implements importModelAPI using the migration
+// target facade for the import and the API client for the uploads.
+type importModelClient struct {
+	target migrationtarget.Client
+	client *api.Client
+}
+
+// Import implements migration.ArchiveTarget.
+func (c *importModelClient) Import(bytes []byte) error {
+	return c.target.Import(bytes)
+}
+
+// Abort implements migration.ArchiveTarget.
+func (c *importModelClient) Abort(modelUUID string) error {
+	return c.target.Abort(modelUUID)
+}
+
+// UploadModelTools implements migration.ArchiveTarget.
+func (c *importModelClient) UploadModelTools(modelUUID string, vers version.Binary, content io.ReadSeeker) error {
+	return c.client.UploadModelTools(modelUUID, vers, content)
+}
+
+// UploadModelCharm implements migration.ArchiveTarget.
+func (c *importModelClient) UploadModelCharm(modelUUID string, curl *charm.URL, content io.ReadSeeker) error {
+	return c.client.UploadModelCharm(modelUUID, curl, content)
+}
+
+// UploadResource implements migration.ArchiveTarget.
+func (c *importModelClient) UploadResource(modelUUID, application, name string, content io.ReadSeeker) error {
+	return c.client.UploadResource(modelUUID, application, name, content)
+}
+
+// Activate implements importModelAPI.
+func (c *importModelClient) Activate(modelUUID string) error {
+	return c.target.Activate(modelUUID)
+}
+
+// Close implements importModelAPI.
+func (c *importModelClient) Close() error {
+	return c.client.Close()
+}
@@ -0,0 +1,224 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/controller"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/description"
+	"github.com/juju/juju/feature"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/testing"
+)
+
+type ImportModelSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api       *fakeImportModelAPI
+	sourceAPI *fakeMigrateAPI
+	store     *jujuclienttesting.MemStore
+	archive   string
+}
+
+var _ = gc.Suite(&ImportModelSuite{})
+
+func (s *ImportModelSuite) SetUpTest(c *gc.C) {
+	s.SetInitialFeatureFlags(feature.Migration)
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+
+	s.store = jujuclienttesting.NewMemStore()
+
+	// Define the controller to import into and set it as the default.
+	err := s.store.UpdateController("target", jujuclient.ControllerDetails{
+		ControllerUUID: targetControllerUUID,
+		APIEndpoints:   []string{"1.2.3.4:5"},
+		CACert:         "cert",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.store.SetCurrentController("target")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.store.UpdateAccount("target", "target@local", jujuclient.AccountDetails{
+		User:     "admin@local",
+		Password: "secret",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.store.SetCurrentAccount("target", "target@local")
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Define the controller that the model was exported from.
+	err = s.store.UpdateController("source", jujuclient.ControllerDetails{
+		ControllerUUID: "eeeeeeee-0bad-400d-8000-4b1d0d06f00d",
+		CACert:         "somecert",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.api = &fakeImportModelAPI{}
+	s.sourceAPI = &fakeMigrateAPI{}
+	s.archive = s.writeArchive(c)
+}
+
+// writeArchive writes a model archive holding just the model.
+func (s *ImportModelSuite) writeArchive(c *gc.C) string {
+	model := description.NewModel(description.ModelArgs{
+		Owner:  names.NewUserTag("me"),
+		Config: map[string]interface{}{"uuid": modelUUID},
+	})
+	modelBytes, err := description.Serialize(model)
+	c.Assert(err, jc.ErrorIsNil)
+
+	filename := filepath.Join(c.MkDir(), "model.tar.gz")
+	f, err := os.Create(filename)
+	c.Assert(err, jc.ErrorIsNil)
+	defer f.Close()
+	gzipWriter := gzip.NewWriter(f)
+	tarWriter := tar.NewWriter(gzipWriter)
+	err = tarWriter.WriteHeader(&tar.Header{
+		Name: "model.yaml",
+		Mode: 0644,
+		Size: int64(len(modelBytes)),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = tarWriter.Write(modelBytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tarWriter.Close(), jc.ErrorIsNil)
+	c.Assert(gzipWriter.Close(), jc.ErrorIsNil)
+	return filename
+}
+
+func (s *ImportModelSuite) TestMissingFile(c *gc.C) {
+	_, err := s.runCommand(c)
+	c.Assert(err, gc.ErrorMatches, "file not specified")
+}
+
+func (s *ImportModelSuite) TestTooManyArgs(c *gc.C) {
+	_, err := s.runCommand(c, "one", "two")
+	c.Assert(err, gc.ErrorMatches, "too many arguments specified")
+}
+
+func (s *ImportModelSuite) TestImport(c *gc.C) {
+	ctx, err := s.runCommand(c, s.archive)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(testing.Stderr(ctx), gc.Equals, "Model "+modelUUID+" imported\n")
+	c.Check(s.api.imported, jc.IsTrue)
+	c.Check(s.api.activated, gc.Equals, modelUUID)
+	c.Check(s.api.closed, jc.IsTrue)
+	c.Check(s.sourceAPI.specSeen, gc.IsNil) // Agents shouldn't have been moved
+}
+
+func (s *ImportModelSuite) TestImportError(c *gc.C) {
+	s.api.importErr = errors.New("boom")
+	_, err := s.runCommand(c, s.archive)
+	c.Assert(err, gc.ErrorMatches, "importing model: boom")
+	c.Check(s.api.activated, gc.Equals, "")
+}
+
+func (s *ImportModelSuite) TestActivateError(c *gc.C) {
+	s.api.activateErr = errors.New("boom")
+	_, err := s.runCommand(c, s.archive, "--source", "source")
+	c.Assert(err, gc.ErrorMatches, "activating model: boom")
+	c.Check(s.sourceAPI.specSeen, gc.IsNil) // Agents shouldn't have been moved
+}
+
+func (s *ImportModelSuite) TestImportFileDoesntExist(c *gc.C) {
+	_, err := s.runCommand(c, filepath.Join(c.MkDir(), "wat.tar.gz"))
+	c.Assert(err, gc.ErrorMatches, "open .*wat.tar.gz: no such file or directory")
+	c.Check(s.api.imported, jc.IsFalse)
+}
+
+func (s *ImportModelSuite) TestImportMovesAgents(c *gc.C) {
+	ctx, err := s.runCommand(c, s.archive, "--source", "source")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(testing.Stderr(ctx), gc.Equals, `
+Model `[1:]+modelUUID+` imported
+Migration of agents from "source" started with ID "uuid:0"
+`)
+	c.Check(s.api.activated, gc.Equals, modelUUID)
+	c.Check(s.sourceAPI.specSeen, jc.DeepEquals, &controller.ModelMigrationSpec{
+		ModelUUID:            modelUUID,
+		TargetControllerUUID: targetControllerUUID,
+		TargetAddrs:          []string{"1.2.3.4:5"},
+		TargetCACert:         "cert",
+		TargetUser:           "admin@local",
+		TargetPassword:       "secret",
+		Offline:              true,
+	})
+}
+
+func (s *ImportModelSuite) TestSourceControllerDoesntExist(c *gc.C) {
+	_, err := s.runCommand(c, s.archive, "--source", "wat")
+	c.Check(err, gc.ErrorMatches, "controller wat not found")
+	c.Check(s.api.imported, jc.IsFalse) // Nothing should have been imported
+}
+
+func (s *ImportModelSuite) runCommand(c *gc.C, args ...string) (*cmd.Context, error) {
+	cmd := &importModelCommand{
+		api:       s.api,
+		sourceAPI: s.sourceAPI,
+	}
+	cmd.SetClientStore(s.store)
+	return testing.RunCommand(c, modelcmd.WrapController(cmd), args...)
+}
+
+type fakeImportModelAPI struct {
+	imported    bool
+	importErr   error
+	activated   string
+	activateErr error
+	aborted     string
+	closed      bool
+}
+
+func (a *fakeImportModelAPI) Import(bytes []byte) error {
+	if a.importErr != nil {
+		return a.importErr
+	}
+	a.imported = true
+	return nil
+}
+
+func (a *fakeImportModelAPI) Abort(modelUUID string) error {
+	a.aborted = modelUUID
+	return nil
+}
+
+func (a *fakeImportModelAPI) UploadModelTools(string, version.Binary, io.ReadSeeker) error {
+	return errors.New("unexpected tools upload")
+}
+
+func (a *fakeImportModelAPI) UploadModelCharm(string, *charm.URL, io.ReadSeeker) error {
+	return errors.New("unexpected charm upload")
+}
+
+func (a *fakeImportModelAPI) UploadResource(string, string, string, io.ReadSeeker) error {
+	return errors.New("unexpected resource upload")
+}
+
+func (a *fakeImportModelAPI) Activate(modelUUID string) error {
+	if a.activateErr != nil {
+		return a.activateErr
+	}
+	a.activated = modelUUID
+	return nil
+}
+
+func (a *fakeImportModelAPI) Close() error {
+	a.closed = true
+	return nil
+}
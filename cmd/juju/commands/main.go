@@ -263,6 +263,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(backups.NewRemoveCommand())
 	r.Register(backups.NewRestoreCommand())
 	r.Register(backups.NewUploadCommand())
+	r.Register(backups.NewVerifyCommand())
 
 	// Manage authorized ssh keys.
 	r.Register(NewAddKeysCommand())
@@ -303,6 +304,10 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 
 	if featureflag.Enabled(feature.Migration) {
 		r.Register(newMigrateCommand())
+		r.Register(newShowMigrationCommand())
+		r.Register(newExportModelCommand())
+		r.Register(newImportModelCommand())
+		r.Register(newCloneModelCommand())
 	}
 
 	// Manage and control actions
@@ -333,6 +338,8 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(storage.NewListCommand())
 	r.Register(storage.NewPoolCreateCommand())
 	r.Register(storage.NewPoolListCommand())
+	r.Register(storage.NewPoolUpdateCommand())
+	r.Register(storage.NewPoolRemoveCommand())
 	r.Register(storage.NewShowCommand())
 
 	// Manage spaces
@@ -455,6 +455,7 @@ var commandNames = []string{
 	"remove-relation", // alias for destroy-relation
 	"remove-ssh-key",
 	"remove-ssh-keys",
+	"remove-storage-pool",
 	"remove-unit", // alias for destroy-unit
 	"resolved",
 	"restore-backup",
@@ -505,10 +506,12 @@ var commandNames = []string{
 	"unregister",
 	"unset-model-config",
 	"update-clouds",
+	"update-storage-pool",
 	"upgrade-charm",
 	"upgrade-gui",
 	"upgrade-juju",
 	"users",
+	"verify-backup",
 	"version",
 }
 
@@ -517,7 +520,11 @@ var devFeatures = []string{feature.Migration}
 
 // These are the commands that are behind the `devFeatures`.
 var commandNamesBehindFlags = set.NewStrings(
+	"clone-model",
+	"export-model",
+	"import-model",
 	"migrate",
+	"show-migration",
 )
 
 func (s *MainSuite) TestHelpCommands(c *gc.C) {
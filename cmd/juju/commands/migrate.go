@@ -4,8 +4,11 @@
 package commands
 
 import (
+	"fmt"
+
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"launchpad.net/gnuflag"
 
 	"github.com/juju/juju/api/controller"
 	"github.com/juju/juju/cmd/modelcmd"
@@ -22,10 +25,16 @@ type migrateCommand struct {
 
 	model            string
 	targetController string
+	dryRun           bool
+	resume           bool
+	cleanup          bool
 }
 
 type migrateAPI interface {
 	InitiateModelMigration(spec controller.ModelMigrationSpec) (string, error)
+	MigrationDryRun(spec controller.ModelMigrationSpec) ([]string, error)
+	ResumeModelMigration(modelUUID string) error
+	CleanupModelMigration(modelUUID string) error
 }
 
 const migrateDoc = `
@@ -51,6 +60,26 @@ This command only starts a model migration - it does not wait for its
 completion. The progress of a migration can be tracked using the
 "status" command and by consulting the logs.
 
+With --dry-run, the migration is checked but not started. The model
+is exported and validated against the target controller, and any
+problems that would cause the migration to fail (version skew, missing
+charms, unknown users, etc) are reported. Neither controller is
+changed.
+
+Once a model has moved to the target controller, the migration can't
+be aborted. If the model then can't be removed from the source
+controller, the migration stops in the REAPFAILED phase and waits for
+one of:
+
+    juju migrate --resume <model-name>
+    juju migrate --cleanup <model-name>
+
+--resume tells any agents still connected to the source controller to
+move to the target controller again, and then reattempts removing the
+model. --cleanup removes the model from the source controller directly
+and completes the migration, leaving any agents which haven't moved
+behind.
+
 See Also:
    juju help login
    juju help controllers
@@ -61,17 +90,38 @@ See Also:
 func (c *migrateCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "migrate",
-		Args:    "<model-name> <target-controller-name>",
+		Args:    "<model-name> [<target-controller-name>]",
 		Purpose: "migrate a hosted model to another controller",
 		Doc:     migrateDoc,
 	}
 }
 
+// SetFlags implements cmd.Command.
+func (c *migrateCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.BoolVar(&c.dryRun, "dry-run", false, "Check the migration for problems without starting it")
+	f.BoolVar(&c.resume, "resume", false, "Resume a migration which failed to remove the model from the source controller")
+	f.BoolVar(&c.cleanup, "cleanup", false, "Complete a migration which failed to remove the model from the source controller")
+}
+
 // Init implements cmd.Command.
 func (c *migrateCommand) Init(args []string) error {
+	if c.resume && c.cleanup {
+		return errors.New("--resume and --cleanup can't be used together")
+	}
+	if c.dryRun && (c.resume || c.cleanup) {
+		return errors.New("--dry-run can't be used with --resume or --cleanup")
+	}
 	if len(args) < 1 {
 		return errors.New("model not specified")
 	}
+	if c.resume || c.cleanup {
+		// The migration already knows its target controller.
+		if len(args) > 1 {
+			return errors.New("too many arguments specified")
+		}
+		c.model = args[0]
+		return nil
+	}
 	if len(args) < 2 {
 		return errors.New("target controller not specified")
 	}
@@ -119,6 +169,9 @@ func (c *migrateCommand) getMigrationSpec() (*controller.ModelMigrationSpec, err
 
 // Run implements cmd.Command.
 func (c *migrateCommand) Run(ctx *cmd.Context) error {
+	if c.resume || c.cleanup {
+		return c.runReapFailed(ctx)
+	}
 	spec, err := c.getMigrationSpec()
 	if err != nil {
 		return err
@@ -127,6 +180,9 @@ func (c *migrateCommand) Run(ctx *cmd.Context) error {
 	if err != nil {
 		return err
 	}
+	if c.dryRun {
+		return c.runDryRun(ctx, api, spec)
+	}
 	id, err := api.InitiateModelMigration(*spec)
 	if err != nil {
 		return err
@@ -135,6 +191,44 @@ func (c *migrateCommand) Run(ctx *cmd.Context) error {
 	return nil
 }
 
+func (c *migrateCommand) runDryRun(ctx *cmd.Context, api migrateAPI, spec *controller.ModelMigrationSpec) error {
+	blockers, err := api.MigrationDryRun(*spec)
+	if err != nil {
+		return err
+	}
+	if len(blockers) == 0 {
+		ctx.Infof("No problems found migrating %q to %q", c.model, c.targetController)
+		return nil
+	}
+	for _, blocker := range blockers {
+		fmt.Fprintln(ctx.Stdout, blocker)
+	}
+	return errors.Errorf("migration of %q to %q would fail: %d problem(s) found", c.model, c.targetController, len(blockers))
+}
+
+func (c *migrateCommand) runReapFailed(ctx *cmd.Context) error {
+	modelInfo, err := c.ClientStore().ModelByName(c.ControllerName(), c.AccountName(), c.model)
+	if err != nil {
+		return err
+	}
+	api, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	if c.resume {
+		if err := api.ResumeModelMigration(modelInfo.ModelUUID); err != nil {
+			return err
+		}
+		ctx.Infof("Migration of %q resumed", c.model)
+		return nil
+	}
+	if err := api.CleanupModelMigration(modelInfo.ModelUUID); err != nil {
+		return err
+	}
+	ctx.Infof("Migration of %q cleaned up", c.model)
+	return nil
+}
+
 func (c *migrateCommand) getAPI() (migrateAPI, error) {
 	if c.api != nil {
 		return c.api, nil
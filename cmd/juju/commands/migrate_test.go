@@ -5,6 +5,7 @@ package commands
 
 import (
 	"github.com/juju/cmd"
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
@@ -106,6 +107,96 @@ func (s *MigrateSuite) TestSuccess(c *gc.C) {
 	})
 }
 
+func (s *MigrateSuite) TestDryRunNoBlockers(c *gc.C) {
+	ctx, err := s.runCommand(c, "model", "target", "--dry-run")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(testing.Stderr(ctx), gc.Equals, "No problems found migrating \"model\" to \"target\"\n")
+	c.Check(testing.Stdout(ctx), gc.Equals, "")
+	c.Check(s.api.dryRunSpecSeen, jc.DeepEquals, &controller.ModelMigrationSpec{
+		ModelUUID:            modelUUID,
+		TargetControllerUUID: targetControllerUUID,
+		TargetAddrs:          []string{"1.2.3.4:5"},
+		TargetCACert:         "cert",
+		TargetUser:           "admin@local",
+		TargetPassword:       "secret",
+	})
+	c.Check(s.api.specSeen, gc.IsNil) // Migration shouldn't have been started
+}
+
+func (s *MigrateSuite) TestDryRunBlockers(c *gc.C) {
+	s.api.blockers = []string{"charm \"cs:foo-1\" not found", "user \"bob\" not found on target controller"}
+
+	ctx, err := s.runCommand(c, "model", "target", "--dry-run")
+	c.Check(err, gc.ErrorMatches, `migration of "model" to "target" would fail: 2 problem\(s\) found`)
+	c.Check(testing.Stdout(ctx), gc.Equals, `charm "cs:foo-1" not found
+user "bob" not found on target controller
+`)
+	c.Check(s.api.specSeen, gc.IsNil) // Migration shouldn't have been started
+}
+
+func (s *MigrateSuite) TestDryRunError(c *gc.C) {
+	s.api.dryRunErr = errors.New("boom")
+
+	_, err := s.runCommand(c, "model", "target", "--dry-run")
+	c.Check(err, gc.ErrorMatches, "boom")
+	c.Check(s.api.specSeen, gc.IsNil) // Migration shouldn't have been started
+}
+
+func (s *MigrateSuite) TestResume(c *gc.C) {
+	ctx, err := s.runCommand(c, "model", "--resume")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(testing.Stderr(ctx), gc.Equals, "Migration of \"model\" resumed\n")
+	c.Check(s.api.resumed, gc.Equals, modelUUID)
+	c.Check(s.api.cleanedUp, gc.Equals, "")
+	c.Check(s.api.specSeen, gc.IsNil) // Migration shouldn't have been started
+}
+
+func (s *MigrateSuite) TestResumeError(c *gc.C) {
+	s.api.reapFailedErr = errors.New("migration is in the QUIESCE phase, not REAPFAILED")
+
+	_, err := s.runCommand(c, "model", "--resume")
+	c.Check(err, gc.ErrorMatches, "migration is in the QUIESCE phase, not REAPFAILED")
+}
+
+func (s *MigrateSuite) TestCleanup(c *gc.C) {
+	ctx, err := s.runCommand(c, "model", "--cleanup")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(testing.Stderr(ctx), gc.Equals, "Migration of \"model\" cleaned up\n")
+	c.Check(s.api.cleanedUp, gc.Equals, modelUUID)
+	c.Check(s.api.resumed, gc.Equals, "")
+}
+
+func (s *MigrateSuite) TestCleanupError(c *gc.C) {
+	s.api.reapFailedErr = errors.New("boom")
+
+	_, err := s.runCommand(c, "model", "--cleanup")
+	c.Check(err, gc.ErrorMatches, "boom")
+}
+
+func (s *MigrateSuite) TestResumeModelDoesntExist(c *gc.C) {
+	_, err := s.runCommand(c, "wat", "--resume")
+	c.Check(err, gc.ErrorMatches, "model .+ not found")
+	c.Check(s.api.resumed, gc.Equals, "") // API shouldn't have been called
+}
+
+func (s *MigrateSuite) TestResumeTooManyArgs(c *gc.C) {
+	_, err := s.runCommand(c, "model", "target", "--resume")
+	c.Assert(err, gc.ErrorMatches, "too many arguments specified")
+}
+
+func (s *MigrateSuite) TestResumeAndCleanup(c *gc.C) {
+	_, err := s.runCommand(c, "model", "--resume", "--cleanup")
+	c.Assert(err, gc.ErrorMatches, "--resume and --cleanup can't be used together")
+}
+
+func (s *MigrateSuite) TestDryRunAndCleanup(c *gc.C) {
+	_, err := s.runCommand(c, "model", "--dry-run", "--cleanup")
+	c.Assert(err, gc.ErrorMatches, "--dry-run can't be used with --resume or --cleanup")
+}
+
 func (s *MigrateSuite) TestModelDoesntExist(c *gc.C) {
 	_, err := s.runCommand(c, "wat", "target")
 	c.Check(err, gc.ErrorMatches, "model .+ not found")
@@ -127,10 +218,41 @@ func (s *MigrateSuite) runCommand(c *gc.C, args ...string) (*cmd.Context, error)
 }
 
 type fakeMigrateAPI struct {
-	specSeen *controller.ModelMigrationSpec
+	specSeen       *controller.ModelMigrationSpec
+	dryRunSpecSeen *controller.ModelMigrationSpec
+	blockers       []string
+	dryRunErr      error
+	resumed        string
+	cleanedUp      string
+	reapFailedErr  error
 }
 
 func (a *fakeMigrateAPI) InitiateModelMigration(spec controller.ModelMigrationSpec) (string, error) {
 	a.specSeen = &spec
 	return "uuid:0", nil
 }
+
+func (a *fakeMigrateAPI) MigrationDryRun(spec controller.ModelMigrationSpec) ([]string, error) {
+	a.dryRunSpecSeen = &spec
+	return a.blockers, a.dryRunErr
+}
+
+func (a *fakeMigrateAPI) ResumeModelMigration(modelUUID string) error {
+	if a.reapFailedErr != nil {
+		return a.reapFailedErr
+	}
+	a.resumed = modelUUID
+	return nil
+}
+
+func (a *fakeMigrateAPI) CleanupModelMigration(modelUUID string) error {
+	if a.reapFailedErr != nil {
+		return a.reapFailedErr
+	}
+	a.cleanedUp = modelUUID
+	return nil
+}
+
+func (a *fakeMigrateAPI) Close() error {
+	return nil
+}
@@ -0,0 +1,279 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/api/controller"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/migration"
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/worker"
+)
+
+func newShowMigrationCommand() cmd.Command {
+	return modelcmd.WrapController(&showMigrationCommand{
+		clock: clock.WallClock,
+	})
+}
+
+// showMigrationCommand reports the progress of a model migration.
+type showMigrationCommand struct {
+	modelcmd.ControllerCommandBase
+	api      showMigrationAPI
+	watchAPI showMigrationWatchAPI
+	clock    clock.Clock
+
+	out     cmd.Output
+	model   string
+	watch   bool
+	isoTime bool
+}
+
+type showMigrationAPI interface {
+	MigrationProgress(modelUUID string) (controller.MigrationProgress, error)
+	Close() error
+}
+
+type showMigrationWatchAPI interface {
+	WatchMigrationStatus() (watcher.MigrationStatusWatcher, error)
+	Close() error
+}
+
+const showMigrationDoc = `
+show-migration reports the progress of the latest migration of a
+model: the current phase, when each phase was entered and how long it
+took, how many of the model's agents have reported back for the
+current phase (and which of them failed), how many bytes of charms and
+tools have been uploaded to the target controller, and why the
+migration was aborted if it was.
+
+With --watch, phase transitions are printed as they happen until the
+migration finishes, or until it stops because the model couldn't be
+removed from the source controller. See "juju help migrate" for how to
+resume or clean up such a migration.
+
+Examples:
+    juju show-migration mymodel
+    juju show-migration mymodel --watch
+
+See Also:
+   juju help migrate
+`
+
+// Info implements cmd.Command.
+func (c *showMigrationCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "show-migration",
+		Args:    "<model-name>",
+		Purpose: "show the progress of a model migration",
+		Doc:     showMigrationDoc,
+	}
+}
+
+// SetFlags implements cmd.Command.
+func (c *showMigrationCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.BoolVar(&c.watch, "watch", false, "Print phase transitions until the migration finishes")
+	f.BoolVar(&c.isoTime, "utc", false, "Display time as UTC in RFC3339 format")
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+}
+
+// Init implements cmd.Command.
+func (c *showMigrationCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("model not specified")
+	}
+	if len(args) > 1 {
+		return errors.New("too many arguments specified")
+	}
+	c.model = args[0]
+	return nil
+}
+
+// Run implements cmd.Command.
+func (c *showMigrationCommand) Run(ctx *cmd.Context) error {
+	modelInfo, err := c.ClientStore().ModelByName(c.ControllerName(), c.AccountName(), c.model)
+	if err != nil {
+		return err
+	}
+	api, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	if c.watch {
+		return c.watchProgress(ctx, api, modelInfo.ModelUUID)
+	}
+	progress, err := api.MigrationProgress(modelInfo.ModelUUID)
+	if err != nil {
+		return err
+	}
+	return c.out.Write(ctx, c.formatProgress(progress))
+}
+
+func (c *showMigrationCommand) watchProgress(ctx *cmd.Context, api showMigrationAPI, modelUUID string) error {
+	watchAPI, err := c.getWatchAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer watchAPI.Close()
+	w, err := watchAPI.WatchMigrationStatus()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer worker.Stop(w)
+	watchDone := make(chan error, 1)
+	go func() {
+		watchDone <- w.Wait()
+	}()
+
+	// A resumed migration enters some phases again, so a phase is
+	// printed each time its entry time changes.
+	seen := make(map[migration.Phase]time.Time)
+	var lastMessage string
+	for {
+		select {
+		case <-w.Changes():
+		case err := <-watchDone:
+			return errors.Annotate(err, "watching migration")
+		}
+		progress, err := api.MigrationProgress(modelUUID)
+		if err != nil {
+			return err
+		}
+		for _, phaseTime := range progress.PhaseTimes {
+			if seen[phaseTime.Phase].Equal(phaseTime.Entered) {
+				continue
+			}
+			seen[phaseTime.Phase] = phaseTime.Entered
+			fmt.Fprintf(ctx.Stdout, "%s  %s\n", c.formatTime(phaseTime.Entered), phaseTime.Phase)
+		}
+		if progress.StatusMessage != "" && progress.StatusMessage != lastMessage {
+			fmt.Fprintf(ctx.Stdout, "    %s\n", progress.StatusMessage)
+		}
+		lastMessage = progress.StatusMessage
+		if progress.Phase.IsTerminal() {
+			if progress.AbortReason != "" {
+				fmt.Fprintf(ctx.Stdout, "migration aborted: %s\n", progress.AbortReason)
+			}
+			return nil
+		}
+		if progress.Phase == migration.REAPFAILED {
+			fmt.Fprintf(ctx.Stdout, "migration waiting: run \"juju migrate --resume %s\" or \"juju migrate --cleanup %s\"\n", c.model, c.model)
+			return nil
+		}
+	}
+}
+
+type migrationProgressOutput struct {
+	Id           string                  `yaml:"id" json:"id"`
+	Phase        string                  `yaml:"phase" json:"phase"`
+	Message      string                  `yaml:"message,omitempty" json:"message,omitempty"`
+	AbortReason  string                  `yaml:"abort-reason,omitempty" json:"abort-reason,omitempty"`
+	Started      string                  `yaml:"started" json:"started"`
+	Ended        string                  `yaml:"ended,omitempty" json:"ended,omitempty"`
+	Phases       []migrationPhaseOutput  `yaml:"phases" json:"phases"`
+	AgentReports migrationMinionsOutput  `yaml:"agent-reports" json:"agent-reports"`
+	Uploaded     migrationUploadedOutput `yaml:"uploaded" json:"uploaded"`
+}
+
+type migrationPhaseOutput struct {
+	Phase    string `yaml:"phase" json:"phase"`
+	Entered  string `yaml:"entered" json:"entered"`
+	Duration string `yaml:"duration,omitempty" json:"duration,omitempty"`
+}
+
+type migrationMinionsOutput struct {
+	Succeeded    int      `yaml:"succeeded" json:"succeeded"`
+	Failed       int      `yaml:"failed" json:"failed"`
+	FailedAgents []string `yaml:"failed-agents,omitempty" json:"failed-agents,omitempty"`
+}
+
+type migrationUploadedOutput struct {
+	CharmBytes int64 `yaml:"charm-bytes" json:"charm-bytes"`
+	ToolsBytes int64 `yaml:"tools-bytes" json:"tools-bytes"`
+}
+
+func (c *showMigrationCommand) formatProgress(progress controller.MigrationProgress) migrationProgressOutput {
+	out := migrationProgressOutput{
+		Id:          progress.MigrationId,
+		Phase:       progress.Phase.String(),
+		Message:     progress.StatusMessage,
+		AbortReason: progress.AbortReason,
+		Started:     c.formatTime(progress.StartTime),
+		AgentReports: migrationMinionsOutput{
+			Succeeded: progress.MinionsSucceeded,
+			Failed:    progress.MinionsFailed,
+		},
+		Uploaded: migrationUploadedOutput{
+			CharmBytes: progress.CharmBytesUploaded,
+			ToolsBytes: progress.ToolsBytesUploaded,
+		},
+	}
+	if !progress.EndTime.IsZero() {
+		out.Ended = c.formatTime(progress.EndTime)
+	}
+	for _, tag := range progress.FailedMinions {
+		out.AgentReports.FailedAgents = append(out.AgentReports.FailedAgents, tag.String())
+	}
+	for i, phaseTime := range progress.PhaseTimes {
+		// A phase lasts until the next one is entered. The
+		// current phase lasts until now, unless the migration
+		// has finished.
+		var end time.Time
+		switch {
+		case i+1 < len(progress.PhaseTimes):
+			end = progress.PhaseTimes[i+1].Entered
+		case !progress.Phase.IsTerminal():
+			end = c.clock.Now()
+		}
+		var duration string
+		if !end.IsZero() {
+			d := end.Sub(phaseTime.Entered)
+			duration = (d - d%time.Second).String()
+		}
+		out.Phases = append(out.Phases, migrationPhaseOutput{
+			Phase:    phaseTime.Phase.String(),
+			Entered:  c.formatTime(phaseTime.Entered),
+			Duration: duration,
+		})
+	}
+	return out
+}
+
+func (c *showMigrationCommand) formatTime(t time.Time) string {
+	return common.FormatTime(&t, c.isoTime)
+}
+
+func (c *showMigrationCommand) getAPI() (showMigrationAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return c.NewControllerAPIClient()
+}
+
+func (c *showMigrationCommand) getWatchAPI() (showMigrationWatchAPI, error) {
+	if c.watchAPI != nil {
+		return c.watchAPI, nil
+	}
+	// The migration status watcher reports on the model of the
+	// connection it is made over.
+	root, err := c.JujuCommandBase.NewAPIRoot(c.ClientStore(), c.ControllerName(), c.AccountName(), c.model)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return root.Client(), nil
+}
@@ -0,0 +1,360 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/clock"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/controller"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/migration"
+	"github.com/juju/juju/feature"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/watcher"
+)
+
+type ShowMigrationSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api      *fakeShowMigrationAPI
+	watchAPI *fakeShowMigrationWatchAPI
+	store    *jujuclienttesting.MemStore
+	clock    *fakeShowClock
+}
+
+var _ = gc.Suite(&ShowMigrationSuite{})
+
+var migrationStart = time.Date(2016, 7, 1, 10, 0, 0, 0, time.UTC)
+
+func (s *ShowMigrationSuite) SetUpTest(c *gc.C) {
+	s.SetInitialFeatureFlags(feature.Migration)
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+
+	s.store = jujuclienttesting.NewMemStore()
+	err := s.store.UpdateController("source", jujuclient.ControllerDetails{
+		ControllerUUID: "eeeeeeee-0bad-400d-8000-4b1d0d06f00d",
+		CACert:         "somecert",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.store.SetCurrentController("source")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.store.UpdateAccount("source", "source@local", jujuclient.AccountDetails{
+		User: "whatever@local",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.store.SetCurrentAccount("source", "source@local")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.store.UpdateModel("source", "source@local", "model", jujuclient.ModelDetails{
+		ModelUUID: modelUUID,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.api = &fakeShowMigrationAPI{}
+	s.watchAPI = &fakeShowMigrationWatchAPI{}
+	s.clock = &fakeShowClock{now: migrationStart.Add(90 * time.Second)}
+}
+
+func (s *ShowMigrationSuite) TestMissingModel(c *gc.C) {
+	_, err := s.runCommand(c)
+	c.Assert(err, gc.ErrorMatches, "model not specified")
+}
+
+func (s *ShowMigrationSuite) TestTooManyArgs(c *gc.C) {
+	_, err := s.runCommand(c, "one", "two")
+	c.Assert(err, gc.ErrorMatches, "too many arguments specified")
+}
+
+func (s *ShowMigrationSuite) TestModelDoesntExist(c *gc.C) {
+	_, err := s.runCommand(c, "wat")
+	c.Check(err, gc.ErrorMatches, "model .+ not found")
+	c.Check(s.api.calls, gc.Equals, 0)
+}
+
+func (s *ShowMigrationSuite) TestShow(c *gc.C) {
+	s.api.progress = []controller.MigrationProgress{{
+		MigrationId:      modelUUID + ":0",
+		Phase:            migration.READONLY,
+		StatusMessage:    "waiting for agents",
+		StartTime:        migrationStart,
+		PhaseChangedTime: migrationStart.Add(time.Minute),
+		PhaseTimes: []controller.MigrationPhaseTime{
+			{Phase: migration.QUIESCE, Entered: migrationStart},
+			{Phase: migration.READONLY, Entered: migrationStart.Add(time.Minute)},
+		},
+		MinionsSucceeded:   3,
+		MinionsFailed:      1,
+		FailedMinions:      []names.Tag{names.NewUnitTag("foo/0")},
+		CharmBytesUploaded: 1024,
+		ToolsBytesUploaded: 2048,
+	}}
+
+	ctx, err := s.runCommand(c, "model", "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.api.modelUUID, gc.Equals, modelUUID)
+	c.Check(s.api.closed, jc.IsTrue)
+	c.Check(testing.Stdout(ctx), gc.Equals, `
+id: deadbeef-0bad-400d-8000-4b1d0d06f00d:0
+phase: READONLY
+message: waiting for agents
+started: 2016-07-01 10:00:00Z
+phases:
+- phase: QUIESCE
+  entered: 2016-07-01 10:00:00Z
+  duration: 1m0s
+- phase: READONLY
+  entered: 2016-07-01 10:01:00Z
+  duration: 30s
+agent-reports:
+  succeeded: 3
+  failed: 1
+  failed-agents:
+  - unit-foo-0
+uploaded:
+  charm-bytes: 1024
+  tools-bytes: 2048
+`[1:])
+}
+
+func (s *ShowMigrationSuite) TestShowAborted(c *gc.C) {
+	s.api.progress = []controller.MigrationProgress{{
+		MigrationId: modelUUID + ":1",
+		Phase:       migration.ABORTDONE,
+		AbortReason: "import failed",
+		StartTime:   migrationStart,
+		EndTime:     migrationStart.Add(2 * time.Minute),
+		PhaseTimes: []controller.MigrationPhaseTime{
+			{Phase: migration.QUIESCE, Entered: migrationStart},
+			{Phase: migration.ABORT, Entered: migrationStart.Add(time.Minute)},
+			{Phase: migration.ABORTDONE, Entered: migrationStart.Add(2 * time.Minute)},
+		},
+	}}
+
+	ctx, err := s.runCommand(c, "model", "--utc", "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(testing.Stdout(ctx), gc.Equals, `{"id":"deadbeef-0bad-400d-8000-4b1d0d06f00d:1","phase":"ABORTDONE","abort-reason":"import failed",`+
+		`"started":"2016-07-01 10:00:00Z","ended":"2016-07-01 10:02:00Z","phases":[`+
+		`{"phase":"QUIESCE","entered":"2016-07-01 10:00:00Z","duration":"1m0s"},`+
+		`{"phase":"ABORT","entered":"2016-07-01 10:01:00Z","duration":"1m0s"},`+
+		`{"phase":"ABORTDONE","entered":"2016-07-01 10:02:00Z"}],`+
+		`"agent-reports":{"succeeded":0,"failed":0},"uploaded":{"charm-bytes":0,"tools-bytes":0}}`+"\n")
+}
+
+func (s *ShowMigrationSuite) TestShowError(c *gc.C) {
+	s.api.err = errors.New("boom")
+	_, err := s.runCommand(c, "model")
+	c.Check(err, gc.ErrorMatches, "boom")
+}
+
+func (s *ShowMigrationSuite) TestWatch(c *gc.C) {
+	quiesce := controller.MigrationPhaseTime{Phase: migration.QUIESCE, Entered: migrationStart}
+	readOnly := controller.MigrationPhaseTime{Phase: migration.READONLY, Entered: migrationStart.Add(time.Minute)}
+	abort := controller.MigrationPhaseTime{Phase: migration.ABORT, Entered: migrationStart.Add(2 * time.Minute)}
+	abortDone := controller.MigrationPhaseTime{Phase: migration.ABORTDONE, Entered: migrationStart.Add(3 * time.Minute)}
+	s.api.progress = []controller.MigrationProgress{{
+		Phase:      migration.QUIESCE,
+		PhaseTimes: []controller.MigrationPhaseTime{quiesce},
+	}, {
+		Phase:      migration.QUIESCE,
+		PhaseTimes: []controller.MigrationPhaseTime{quiesce},
+	}, {
+		Phase:      migration.READONLY,
+		PhaseTimes: []controller.MigrationPhaseTime{quiesce, readOnly},
+	}, {
+		Phase:       migration.ABORTDONE,
+		AbortReason: "import failed",
+		PhaseTimes:  []controller.MigrationPhaseTime{quiesce, readOnly, abort, abortDone},
+	}}
+
+	s.watchAPI.changes = 4
+
+	ctx, err := s.runCommand(c, "model", "--watch", "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.api.calls, gc.Equals, 4)
+	c.Check(s.watchAPI.closed, jc.IsTrue)
+	c.Check(s.watchAPI.watcher.stopped, jc.IsTrue)
+	c.Check(testing.Stdout(ctx), gc.Equals, `
+2016-07-01 10:00:00Z  QUIESCE
+2016-07-01 10:01:00Z  READONLY
+2016-07-01 10:02:00Z  ABORT
+2016-07-01 10:03:00Z  ABORTDONE
+migration aborted: import failed
+`[1:])
+}
+
+func (s *ShowMigrationSuite) TestWatchReapFailed(c *gc.C) {
+	success := controller.MigrationPhaseTime{Phase: migration.SUCCESS, Entered: migrationStart}
+	logTransfer := controller.MigrationPhaseTime{Phase: migration.LOGTRANSFER, Entered: migrationStart.Add(time.Minute)}
+	reap := controller.MigrationPhaseTime{Phase: migration.REAP, Entered: migrationStart.Add(2 * time.Minute)}
+	reapFailed := controller.MigrationPhaseTime{Phase: migration.REAPFAILED, Entered: migrationStart.Add(3 * time.Minute)}
+	s.api.progress = []controller.MigrationProgress{{
+		Phase:      migration.REAP,
+		PhaseTimes: []controller.MigrationPhaseTime{success, logTransfer, reap},
+	}, {
+		Phase:         migration.REAPFAILED,
+		StatusMessage: "failed to remove model from source controller: boom",
+		PhaseTimes:    []controller.MigrationPhaseTime{success, logTransfer, reap, reapFailed},
+	}}
+	s.watchAPI.changes = 2
+
+	ctx, err := s.runCommand(c, "model", "--watch", "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.api.calls, gc.Equals, 2)
+	c.Check(testing.Stdout(ctx), gc.Equals, `
+2016-07-01 10:00:00Z  SUCCESS
+2016-07-01 10:01:00Z  LOGTRANSFER
+2016-07-01 10:02:00Z  REAP
+2016-07-01 10:03:00Z  REAPFAILED
+    failed to remove model from source controller: boom
+migration waiting: run "juju migrate --resume model" or "juju migrate --cleanup model"
+`[1:])
+}
+
+func (s *ShowMigrationSuite) TestWatchResumed(c *gc.C) {
+	reap := controller.MigrationPhaseTime{Phase: migration.REAP, Entered: migrationStart}
+	reapFailed := controller.MigrationPhaseTime{Phase: migration.REAPFAILED, Entered: migrationStart.Add(time.Minute)}
+	success := controller.MigrationPhaseTime{Phase: migration.SUCCESS, Entered: migrationStart.Add(2 * time.Minute)}
+	reapAgain := controller.MigrationPhaseTime{Phase: migration.REAP, Entered: migrationStart.Add(3 * time.Minute)}
+	done := controller.MigrationPhaseTime{Phase: migration.DONE, Entered: migrationStart.Add(4 * time.Minute)}
+	s.api.progress = []controller.MigrationProgress{{
+		Phase:      migration.SUCCESS,
+		PhaseTimes: []controller.MigrationPhaseTime{reap, reapFailed, success},
+	}, {
+		Phase:      migration.DONE,
+		PhaseTimes: []controller.MigrationPhaseTime{reapFailed, success, reapAgain, done},
+	}}
+	s.watchAPI.changes = 2
+
+	ctx, err := s.runCommand(c, "model", "--watch", "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.api.calls, gc.Equals, 2)
+	c.Check(testing.Stdout(ctx), gc.Equals, `
+2016-07-01 10:00:00Z  REAP
+2016-07-01 10:01:00Z  REAPFAILED
+2016-07-01 10:02:00Z  SUCCESS
+2016-07-01 10:03:00Z  REAP
+2016-07-01 10:04:00Z  DONE
+`[1:])
+}
+
+func (s *ShowMigrationSuite) TestWatchError(c *gc.C) {
+	s.api.progress = []controller.MigrationProgress{{
+		Phase: migration.QUIESCE,
+	}}
+	s.watchAPI.err = errors.New("boom")
+
+	_, err := s.runCommand(c, "model", "--watch")
+	c.Check(err, gc.ErrorMatches, "watching migration: boom")
+	c.Check(s.api.calls, gc.Equals, 0)
+	c.Check(s.watchAPI.closed, jc.IsTrue)
+}
+
+func (s *ShowMigrationSuite) runCommand(c *gc.C, args ...string) (*cmd.Context, error) {
+	cmd := &showMigrationCommand{
+		api:      s.api,
+		watchAPI: s.watchAPI,
+		clock:    s.clock,
+	}
+	cmd.SetClientStore(s.store)
+	return testing.RunCommand(c, modelcmd.WrapController(cmd), args...)
+}
+
+// fakeShowMigrationAPI returns each of its progress values in turn,
+// repeating the last one once they run out.
+type fakeShowMigrationAPI struct {
+	progress  []controller.MigrationProgress
+	err       error
+	calls     int
+	modelUUID string
+	closed    bool
+}
+
+func (a *fakeShowMigrationAPI) MigrationProgress(modelUUID string) (controller.MigrationProgress, error) {
+	a.modelUUID = modelUUID
+	if a.err != nil {
+		return controller.MigrationProgress{}, a.err
+	}
+	i := a.calls
+	if i >= len(a.progress) {
+		i = len(a.progress) - 1
+	}
+	a.calls++
+	return a.progress[i], nil
+}
+
+func (a *fakeShowMigrationAPI) Close() error {
+	a.closed = true
+	return nil
+}
+
+// fakeShowMigrationWatchAPI returns a watcher which reports the
+// given number of changes, and then dies with the given error.
+type fakeShowMigrationWatchAPI struct {
+	changes int
+	err     error
+	watcher *fakeMigrationStatusWatcher
+	closed  bool
+}
+
+func (a *fakeShowMigrationWatchAPI) WatchMigrationStatus() (watcher.MigrationStatusWatcher, error) {
+	a.watcher = &fakeMigrationStatusWatcher{
+		changes: make(chan watcher.MigrationStatus, a.changes),
+		dead:    make(chan struct{}),
+	}
+	for i := 0; i < a.changes; i++ {
+		a.watcher.changes <- watcher.MigrationStatus{}
+	}
+	if a.err != nil {
+		a.watcher.err = a.err
+		close(a.watcher.dead)
+	}
+	return a.watcher, nil
+}
+
+func (a *fakeShowMigrationWatchAPI) Close() error {
+	a.closed = true
+	return nil
+}
+
+type fakeMigrationStatusWatcher struct {
+	changes chan watcher.MigrationStatus
+	dead    chan struct{}
+	err     error
+	stopped bool
+}
+
+func (w *fakeMigrationStatusWatcher) Changes() <-chan watcher.MigrationStatus {
+	return w.changes
+}
+
+func (w *fakeMigrationStatusWatcher) Kill() {
+	if !w.stopped {
+		w.stopped = true
+		if w.err == nil {
+			close(w.dead)
+		}
+	}
+}
+
+func (w *fakeMigrationStatusWatcher) Wait() error {
+	<-w.dead
+	return w.err
+}
+
+// fakeShowClock always reports the same time.
+type fakeShowClock struct {
+	clock.Clock
+	now time.Time
+}
+
+func (c *fakeShowClock) Now() time.Time {
+	return c.now
+}
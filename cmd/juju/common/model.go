@@ -24,6 +24,14 @@ type ModelInfo struct {
 	Life           string                   `json:"life" yaml:"life"`
 	Status         ModelStatus              `json:"status" yaml:"status"`
 	Users          map[string]ModelUserInfo `json:"users" yaml:"users"`
+	LastLogPrune   *ModelLogPrune           `json:"last-log-prune,omitempty" yaml:"last-log-prune,omitempty"`
+}
+
+// ModelLogPrune describes the last pruning of a model's logs.
+type ModelLogPrune struct {
+	Time     string         `json:"time" yaml:"time"`
+	Expired  map[string]int `json:"expired,omitempty" yaml:"expired,omitempty"`
+	Oversize int            `json:"oversize,omitempty" yaml:"oversize,omitempty"`
 }
 
 // ModelStatus contains the current status of a model.
@@ -54,6 +62,14 @@ func ModelInfoFromParams(info params.ModelInfo, now time.Time) (ModelInfo, error
 	if info.Status.Since != nil {
 		status.Since = UserFriendlyDuration(*info.Status.Since, now)
 	}
+	var lastLogPrune *ModelLogPrune
+	if prune := info.LastLogPrune; prune != nil {
+		lastLogPrune = &ModelLogPrune{
+			Time:     UserFriendlyDuration(prune.Time, now),
+			Expired:  prune.Expired,
+			Oversize: prune.Oversize,
+		}
+	}
 	return ModelInfo{
 		Name:           info.Name,
 		UUID:           info.UUID,
@@ -65,6 +81,7 @@ func ModelInfoFromParams(info params.ModelInfo, now time.Time) (ModelInfo, error
 		CloudRegion:    info.CloudRegion,
 		ProviderType:   info.ProviderType,
 		Users:          ModelUserInfoFromParams(info.Users, now),
+		LastLogPrune:   lastLogPrune,
 	}, nil
 }
 
@@ -148,6 +148,22 @@ func (s *ShowCommandSuite) TestShowFormatJson(c *gc.C) {
 	c.Assert(testing.Stdout(ctx), jc.JSONEquals, s.expectedOutput)
 }
 
+func (s *ShowCommandSuite) TestShowLastLogPrune(c *gc.C) {
+	s.fake.info.LastLogPrune = &params.ModelLogPruneInfo{
+		Time:     time.Date(2016, 8, 1, 0, 0, 0, 0, time.UTC),
+		Expired:  map[string]int{"DEBUG": 20},
+		Oversize: 5,
+	}
+	s.expectedOutput["mymodel"].(attrs)["last-log-prune"] = attrs{
+		"time":     "2016-08-01",
+		"expired":  attrs{"DEBUG": 20},
+		"oversize": 5,
+	}
+	ctx, err := testing.RunCommand(c, model.NewShowCommandForTest(&s.fake, s.store), "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), jc.YAMLEquals, s.expectedOutput)
+}
+
 func (s *ShowCommandSuite) TestUnrecognizedArg(c *gc.C) {
 	_, err := testing.RunCommand(c, model.NewShowCommandForTest(&s.fake, s.store), "-m", "admin", "whoops")
 	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["whoops"\]`)
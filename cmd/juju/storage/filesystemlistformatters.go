@@ -38,7 +38,7 @@ func formatFilesystemListTabularTyped(infos map[string]FilesystemInfo) []byte {
 	print := func(values ...string) {
 		fmt.Fprintln(tw, strings.Join(values, "\t"))
 	}
-	print("MACHINE", "UNIT", "STORAGE", "ID", "VOLUME", "PROVIDER-ID", "MOUNTPOINT", "SIZE", "STATE", "MESSAGE")
+	print("MACHINE", "UNIT", "STORAGE", "ID", "VOLUME", "PROVIDER-ID", "MOUNTPOINT", "SIZE", "USAGE", "STATE", "MESSAGE")
 
 	filesystemAttachmentInfos := make(filesystemAttachmentInfos, 0, len(infos))
 	for filesystemId, info := range infos {
@@ -79,7 +79,7 @@ func formatFilesystemListTabularTyped(infos map[string]FilesystemInfo) []byte {
 		print(
 			info.MachineId, info.UnitId, info.Storage,
 			info.FilesystemId, info.Volume, info.ProviderFilesystemId,
-			info.MountPoint, size,
+			info.MountPoint, size, formatUsage(info.MachineFilesystemAttachment.Usage),
 			string(info.Status.Current), info.Status.Message,
 		)
 	}
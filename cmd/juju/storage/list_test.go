@@ -41,11 +41,11 @@ func (s *ListSuite) TestList(c *gc.C) {
 		// Default format is tabular
 		`
 \[Storage\]    
-UNIT         ID          LOCATION STATUS   MESSAGE 
-postgresql/0 db-dir/1100 hither   attached         
-transcode/0  db-dir/1000 thither  pending          
-transcode/0  shared-fs/0 there    attached         
-transcode/1  shared-fs/0 here     attached         
+UNIT         ID          LOCATION USAGE STATUS   MESSAGE 
+postgresql/0 db-dir/1100 hither         attached         
+transcode/0  db-dir/1000 thither        pending          
+transcode/0  shared-fs/0 there    25%   attached         
+transcode/1  shared-fs/0 here           attached         
 
 `[1:])
 }
@@ -86,6 +86,11 @@ storage:
       units:
         transcode/0:
           location: there
+          usage:
+            used-bytes: 256
+            free-bytes: 768
+            used-inodes: 10
+            free-inodes: 90
         transcode/1:
           location: here
 `[1:])
@@ -98,11 +103,11 @@ func (s *ListSuite) TestListOwnerStorageIdSort(c *gc.C) {
 		// Default format is tabular
 		`
 \[Storage\]    
-UNIT         ID          LOCATION STATUS   MESSAGE 
-postgresql/0 db-dir/1100 hither   attached         
-transcode/0  db-dir/1000 thither  pending          
-transcode/0  shared-fs/0 there    attached         
-transcode/1  shared-fs/0 here     attached         
+UNIT         ID          LOCATION USAGE STATUS   MESSAGE 
+postgresql/0 db-dir/1100 hither         attached         
+transcode/0  db-dir/1000 thither        pending          
+transcode/0  shared-fs/0 there    25%   attached         
+transcode/1  shared-fs/0 here           attached         
 
 `[1:])
 }
@@ -188,6 +193,12 @@ func (s mockListAPI) ListStorageDetails() ([]params.StorageDetails, error) {
 		Attachments: map[string]params.StorageAttachmentDetails{
 			"unit-transcode-0": params.StorageAttachmentDetails{
 				Location: "there",
+				Usage: &params.FilesystemUsage{
+					UsedBytes:  256,
+					FreeBytes:  768,
+					UsedInodes: 10,
+					FreeInodes: 90,
+				},
 			},
 			"unit-transcode-1": params.StorageAttachmentDetails{
 				Location: "here",
@@ -30,7 +30,7 @@ func formatStorageListTabular(value interface{}) ([]byte, error) {
 		fmt.Fprintln(tw)
 	}
 	p("[Storage]")
-	p("UNIT\tID\tLOCATION\tSTATUS\tMESSAGE")
+	p("UNIT\tID\tLOCATION\tUSAGE\tSTATUS\tMESSAGE")
 
 	byUnit := make(map[string]map[string]storageAttachmentInfo)
 	for storageId, storageInfo := range storageInfo {
@@ -60,6 +60,7 @@ func formatStorageListTabular(value interface{}) ([]byte, error) {
 				kind:       storageInfo.Kind,
 				persistent: storageInfo.Persistent,
 				location:   a.Location,
+				usage:      formatUsage(a.Usage),
 				status:     storageInfo.Status,
 			}
 		}
@@ -83,7 +84,7 @@ func formatStorageListTabular(value interface{}) ([]byte, error) {
 
 		for _, storageId := range storageIds {
 			info := byStorage[storageId]
-			p(info.unitId, info.storageId, info.location, info.status.Current, info.status.Message)
+			p(info.unitId, info.storageId, info.location, info.usage, info.status.Current, info.status.Message)
 		}
 	}
 	tw.Flush()
@@ -97,9 +98,23 @@ type storageAttachmentInfo struct {
 	kind       string
 	persistent bool
 	location   string
+	usage      string
 	status     EntityStatus
 }
 
+// formatUsage returns the percentage of a filesystem's capacity that
+// is in use, or an empty string if no usage has been reported.
+func formatUsage(usage *FilesystemUsage) string {
+	if usage == nil {
+		return ""
+	}
+	total := usage.UsedBytes + usage.FreeBytes
+	if total == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d%%", usage.UsedBytes*100/total)
+}
+
 type slashSeparatedIds []string
 
 func (s slashSeparatedIds) Len() int {
@@ -0,0 +1,78 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/storage"
+	_ "github.com/juju/juju/provider/dummy"
+	"github.com/juju/juju/testing"
+)
+
+type PoolRemoveSuite struct {
+	SubStorageSuite
+	mockAPI *mockPoolRemoveAPI
+}
+
+var _ = gc.Suite(&PoolRemoveSuite{})
+
+func (s *PoolRemoveSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+
+	s.mockAPI = &mockPoolRemoveAPI{}
+}
+
+func (s *PoolRemoveSuite) runPoolRemove(c *gc.C, args []string) (*cmd.Context, error) {
+	return testing.RunCommand(c, storage.NewPoolRemoveCommandForTest(s.mockAPI, s.store), args...)
+}
+
+func (s *PoolRemoveSuite) TestPoolRemoveNoArgs(c *gc.C) {
+	_, err := s.runPoolRemove(c, []string{})
+	c.Check(err, gc.ErrorMatches, "pool removal requires a pool name")
+}
+
+func (s *PoolRemoveSuite) TestPoolRemoveTooManyArgs(c *gc.C) {
+	_, err := s.runPoolRemove(c, []string{"sunshine", "lollypop"})
+	c.Check(err, gc.ErrorMatches, `unrecognized args: \["lollypop"\]`)
+}
+
+func (s *PoolRemoveSuite) TestPoolRemove(c *gc.C) {
+	_, err := s.runPoolRemove(c, []string{"sunshine"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mockAPI.name, gc.Equals, "sunshine")
+	c.Assert(s.mockAPI.force, jc.IsFalse)
+}
+
+func (s *PoolRemoveSuite) TestPoolRemoveForce(c *gc.C) {
+	_, err := s.runPoolRemove(c, []string{"--force", "sunshine"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mockAPI.name, gc.Equals, "sunshine")
+	c.Assert(s.mockAPI.force, jc.IsTrue)
+}
+
+func (s *PoolRemoveSuite) TestPoolRemoveInUse(c *gc.C) {
+	s.mockAPI.err = errors.New(`storage pool "sunshine" in use`)
+	_, err := s.runPoolRemove(c, []string{"sunshine"})
+	c.Assert(err, gc.ErrorMatches, `storage pool "sunshine" in use`)
+}
+
+type mockPoolRemoveAPI struct {
+	name  string
+	force bool
+	err   error
+}
+
+func (s *mockPoolRemoveAPI) RemovePool(pname string, force bool) error {
+	s.name = pname
+	s.force = force
+	return s.err
+}
+
+func (s *mockPoolRemoveAPI) Close() error {
+	return nil
+}
@@ -101,6 +101,7 @@ db-dir/1000:
     current: pending
     since: .*
   persistent: true
+  encrypted: true
   attachments:
     units:
       postgresql/0: {}
@@ -179,6 +180,7 @@ func (s mockShowAPI) StorageDetails(tags []names.StorageTag) ([]params.StorageDe
 			}
 			if i == 1 {
 				all[i].Result.Persistent = true
+				all[i].Result.Encrypted = true
 			}
 		}
 	}
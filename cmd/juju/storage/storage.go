@@ -37,6 +37,7 @@ type StorageInfo struct {
 	Kind        string              `yaml:"kind" json:"kind"`
 	Status      EntityStatus        `yaml:"status" json:"status"`
 	Persistent  bool                `yaml:"persistent" json:"persistent"`
+	Encrypted   bool                `yaml:"encrypted,omitempty" json:"encrypted,omitempty"`
 	Attachments *StorageAttachments `yaml:"attachments" json:"attachments"`
 }
 
@@ -58,9 +59,33 @@ type UnitStorageAttachment struct {
 	// Location is the location of the storage attachment.
 	Location string `yaml:"location,omitempty" json:"location,omitempty"`
 
+	// Usage is the capacity usage of the attached filesystem, if any
+	// has been reported.
+	Usage *FilesystemUsage `yaml:"usage,omitempty" json:"usage,omitempty"`
+
 	// TODO(axw) per-unit status when we have it in state.
 }
 
+// FilesystemUsage contains the capacity usage of a mounted filesystem.
+type FilesystemUsage struct {
+	UsedBytes  uint64 `yaml:"used-bytes" json:"used-bytes"`
+	FreeBytes  uint64 `yaml:"free-bytes" json:"free-bytes"`
+	UsedInodes uint64 `yaml:"used-inodes" json:"used-inodes"`
+	FreeInodes uint64 `yaml:"free-inodes" json:"free-inodes"`
+}
+
+func createFilesystemUsage(usage *params.FilesystemUsage) *FilesystemUsage {
+	if usage == nil {
+		return nil
+	}
+	return &FilesystemUsage{
+		UsedBytes:  usage.UsedBytes,
+		FreeBytes:  usage.FreeBytes,
+		UsedInodes: usage.UsedInodes,
+		FreeInodes: usage.FreeInodes,
+	}
+}
+
 // formatStorageDetails takes a set of StorageDetail and
 // creates a mapping from storage ID to storage details.
 func formatStorageDetails(storages []params.StorageDetails) (map[string]StorageInfo, error) {
@@ -93,6 +118,7 @@ func createStorageInfo(details params.StorageDetails) (names.StorageTag, Storage
 			common.FormatTime(details.Status.Since, false),
 		},
 		Persistent: details.Persistent,
+		Encrypted:  details.Encrypted,
 	}
 
 	if len(details.Attachments) > 0 {
@@ -111,8 +137,9 @@ func createStorageInfo(details params.StorageDetails) (names.StorageTag, Storage
 				machineId = machineTag.Id()
 			}
 			unitStorageAttachments[unitTag.Id()] = UnitStorageAttachment{
-				machineId,
-				attachmentDetails.Location,
+				MachineId: machineId,
+				Location:  attachmentDetails.Location,
+				Usage:     createFilesystemUsage(attachmentDetails.Usage),
 			}
 		}
 		info.Attachments = &StorageAttachments{unitStorageAttachments}
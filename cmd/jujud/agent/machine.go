@@ -52,6 +52,7 @@ import (
 	cmdutil "github.com/juju/juju/cmd/jujud/util"
 	"github.com/juju/juju/container"
 	"github.com/juju/juju/container/kvm"
+	corebackups "github.com/juju/juju/core/backups"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/simplestreams"
 	"github.com/juju/juju/instance"
@@ -61,6 +62,7 @@ import (
 	"github.com/juju/juju/service"
 	"github.com/juju/juju/service/common"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
 	"github.com/juju/juju/state/multiwatcher"
 	"github.com/juju/juju/storage/looputil"
 	"github.com/juju/juju/upgrades"
@@ -68,6 +70,7 @@ import (
 	"github.com/juju/juju/watcher"
 	"github.com/juju/juju/worker"
 	"github.com/juju/juju/worker/apicaller"
+	"github.com/juju/juju/worker/backupscheduler"
 	"github.com/juju/juju/worker/certupdater"
 	"github.com/juju/juju/worker/conv2state"
 	"github.com/juju/juju/worker/dblogpruner"
@@ -955,6 +958,29 @@ func (a *MachineAgent) startStateWorkers(st *state.State) (worker.Worker, error)
 			a.startWorkerAfterUpgrade(singularRunner, "txnpruner", func() (worker.Worker, error) {
 				return txnpruner.New(st, time.Hour*2), nil
 			})
+
+			controllerConfig, err := st.ControllerConfig()
+			if err != nil {
+				return nil, errors.Annotate(err, "cannot read controller config")
+			}
+			if spec := controllerConfig.BackupSchedule(); spec != "" {
+				a.startWorkerAfterUpgrade(singularRunner, "backupscheduler", func() (worker.Worker, error) {
+					schedule, err := corebackups.ParseSchedule(spec)
+					if err != nil {
+						return nil, errors.Trace(err)
+					}
+					paths := backups.Paths{
+						DataDir: agentConfig.DataDir(),
+						LogsDir: agentConfig.LogDir(),
+					}
+					return backupscheduler.NewWorker(backupscheduler.Config{
+						Backend:   backupscheduler.NewStateBackend(st, paths, a.machineId),
+						Clock:     clock.WallClock,
+						Schedule:  schedule,
+						Retention: controllerConfig.BackupRetentionPolicy(),
+					})
+				})
+			}
 		default:
 			return nil, errors.Errorf("unknown job type %q", job)
 		}
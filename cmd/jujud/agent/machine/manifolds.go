@@ -312,6 +312,14 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			APICallerName: apiCallerName,
 		})),
 
+		// The disk usage reporter periodically records the capacity
+		// usage of the Juju-managed filesystems mounted on the machine
+		// it runs on, so that users can be warned before they fill.
+		diskUsageReporterName: ifFullyUpgraded(diskmanager.UsageManifold(diskmanager.ManifoldConfig{
+			AgentName:     agentName,
+			APICallerName: apiCallerName,
+		})),
+
 		// The proxy config updater is a leaf worker that sets http/https/apt/etc
 		// proxy settings.
 		proxyConfigUpdater: ifFullyUpgraded(proxyupdater.Manifold(proxyupdater.ManifoldConfig{
@@ -406,8 +414,9 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			APICallerName: apiCallerName,
 			Sinks: []logforwarder.LogSinkSpec{{
 				Name:   "juju-log-forward",
-				OpenFn: sinks.OpenSyslog,
+				OpenFn: sinks.Open,
 			}},
+			Clock: config.Clock,
 		})),
 	}
 }
@@ -442,6 +451,7 @@ const (
 	rebootName               = "reboot-executor"
 	loggingConfigUpdaterName = "logging-config-updater"
 	diskManagerName          = "disk-manager"
+	diskUsageReporterName    = "disk-usage-reporter"
 	proxyConfigUpdater       = "proxy-config-updater"
 	apiAddressUpdaterName    = "api-address-updater"
 	machinerName             = "machiner"
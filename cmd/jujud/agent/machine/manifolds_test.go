@@ -47,6 +47,7 @@ func (*ManifoldsSuite) TestManifoldNames(c *gc.C) {
 		"api-caller",
 		"api-config-watcher",
 		"disk-manager",
+		"disk-usage-reporter",
 		"host-key-reporter",
 		"log-forwarder",
 		"log-sender",
@@ -6,6 +6,7 @@ package controller
 import (
 	"fmt"
 	"net/url"
+	"path"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -14,6 +15,7 @@ import (
 	"gopkg.in/macaroon-bakery.v1/bakery"
 
 	"github.com/juju/juju/cert"
+	"github.com/juju/juju/core/backups"
 )
 
 var logger = loggo.GetLogger("juju.controller")
@@ -40,6 +42,64 @@ const (
 	// NumaControlPolicyKey stores the value for this setting
 	SetNumaControlPolicyKey = "set-numa-control-policy"
 
+	// BackupSchedule is the cron-like schedule on which the controller
+	// creates backups. If unset, backups are only created on demand.
+	BackupSchedule = "backup-schedule"
+
+	// BackupKeepLast is the number of most recent scheduled backups
+	// to keep.
+	BackupKeepLast = "backup-keep-last"
+
+	// BackupKeepDaily is the number of days for which the most recent
+	// scheduled backup of the day is kept.
+	BackupKeepDaily = "backup-keep-daily"
+
+	// BackupKeepWeekly is the number of weeks for which the most recent
+	// scheduled backup of the week is kept.
+	BackupKeepWeekly = "backup-keep-weekly"
+
+	// BackupStorage is the kind of storage in which new backups are
+	// stored: "controller" (the default), "directory" or "s3".
+	BackupStorage = "backup-storage"
+
+	// BackupStorageDirectory is the directory in which backups are
+	// stored when backup-storage is "directory". It is expected to be
+	// a mounted filesystem that outlives the controller machines.
+	BackupStorageDirectory = "backup-storage-directory"
+
+	// BackupStorageS3Endpoint is the URL of the S3-compatible object
+	// store used when backup-storage is "s3".
+	BackupStorageS3Endpoint = "backup-storage-s3-endpoint"
+
+	// BackupStorageS3Region is the region of the S3-compatible object
+	// store. It defaults to "us-east-1".
+	BackupStorageS3Region = "backup-storage-s3-region"
+
+	// BackupStorageS3Bucket is the bucket in which backups are stored.
+	BackupStorageS3Bucket = "backup-storage-s3-bucket"
+
+	// BackupStorageS3AccessKey is the access key used to authenticate
+	// with the S3-compatible object store.
+	BackupStorageS3AccessKey = "backup-storage-s3-access-key"
+
+	// BackupStorageS3SecretKey is the secret key used to authenticate
+	// with the S3-compatible object store. It is only accepted at
+	// bootstrap; the controller stores it apart from the rest of the
+	// controller config, so it is never returned by ControllerConfig.
+	BackupStorageS3SecretKey = "backup-storage-s3-secret-key"
+
+	// ControllerBackupStorage is the backup-storage value for storing
+	// backups in the controller's database.
+	ControllerBackupStorage = "controller"
+
+	// DirectoryBackupStorage is the backup-storage value for storing
+	// backups in a directory on the controller machines.
+	DirectoryBackupStorage = "directory"
+
+	// S3BackupStorage is the backup-storage value for storing backups
+	// in an S3-compatible object store.
+	S3BackupStorage = "s3"
+
 	// Attribute Defaults
 
 	// DefaultNumaControlPolicy should not be used by default.
@@ -51,6 +111,10 @@ const (
 
 	// DefaultApiPort is the default port the API server is listening on.
 	DefaultAPIPort int = 17070
+
+	// DefaultBackupStorageS3Region is the default region of the
+	// S3-compatible object store in which backups are stored.
+	DefaultBackupStorageS3Region = "us-east-1"
 )
 
 // ControllerOnlyConfigAttributes are attributes which are only relevant
@@ -63,6 +127,17 @@ var ControllerOnlyConfigAttributes = []string{
 	IdentityURL,
 	IdentityPublicKey,
 	SetNumaControlPolicyKey,
+	BackupSchedule,
+	BackupKeepLast,
+	BackupKeepDaily,
+	BackupKeepWeekly,
+	BackupStorage,
+	BackupStorageDirectory,
+	BackupStorageS3Endpoint,
+	BackupStorageS3Region,
+	BackupStorageS3Bucket,
+	BackupStorageS3AccessKey,
+	BackupStorageS3SecretKey,
 }
 
 // ControllerOnlyAttribute returns true if the specified attribute name
@@ -116,6 +191,17 @@ func (c Config) mustInt(name string) int {
 	return value
 }
 
+// asInt returns the named attribute as an integer,
+// returning 0 if it isn't found.
+func (c Config) asInt(name string) int {
+	// Values obtained over the api are encoded as float64.
+	if value, ok := c[name].(float64); ok {
+		return int(value)
+	}
+	value, _ := c[name].(int)
+	return value
+}
+
 // asString is a private helper method to keep the ugly string casting
 // in once place. It returns the given named attribute as a string,
 // returning "" if it isn't found.
@@ -187,6 +273,63 @@ func (c Config) NumaCtlPreference() bool {
 	return DefaultNumaControlPolicy
 }
 
+// BackupSchedule returns the schedule on which the controller creates
+// backups, or "" if backups are only created on demand.
+func (c Config) BackupSchedule() string {
+	return c.asString(BackupSchedule)
+}
+
+// BackupRetentionPolicy returns the policy determining which scheduled
+// backups are kept.
+func (c Config) BackupRetentionPolicy() backups.RetentionPolicy {
+	return backups.RetentionPolicy{
+		KeepLast:   c.asInt(BackupKeepLast),
+		KeepDaily:  c.asInt(BackupKeepDaily),
+		KeepWeekly: c.asInt(BackupKeepWeekly),
+	}
+}
+
+// BackupStorage returns the kind of storage in which new backups
+// are stored.
+func (c Config) BackupStorage() string {
+	if storage := c.asString(BackupStorage); storage != "" {
+		return storage
+	}
+	return ControllerBackupStorage
+}
+
+// BackupStorageDirectory returns the directory in which backups are
+// stored when the backup storage is "directory".
+func (c Config) BackupStorageDirectory() string {
+	return c.asString(BackupStorageDirectory)
+}
+
+// BackupS3Config holds the settings for storing backups in an
+// S3-compatible object store.
+type BackupS3Config struct {
+	Endpoint  string
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+}
+
+// BackupStorageS3 returns the settings for storing backups in an
+// S3-compatible object store.
+func (c Config) BackupStorageS3() BackupS3Config {
+	region := c.asString(BackupStorageS3Region)
+	if region == "" {
+		region = DefaultBackupStorageS3Region
+	}
+	return BackupS3Config{
+		Endpoint:  c.asString(BackupStorageS3Endpoint),
+		Region:    region,
+		Bucket:    c.asString(BackupStorageS3Bucket),
+		AccessKey: c.asString(BackupStorageS3AccessKey),
+		SecretKey: c.asString(BackupStorageS3SecretKey),
+	}
+}
+
 // Validate ensures that config is a valid configuration.
 func Validate(c Config) error {
 	if v, ok := c[IdentityURL].(string); ok {
@@ -219,6 +362,53 @@ func Validate(c Config) error {
 		return errors.Errorf("controller-uuid: expected UUID, got string(%q)", uuid)
 	}
 
+	if v, ok := c[BackupSchedule].(string); ok && v != "" {
+		if _, err := backups.ParseSchedule(v); err != nil {
+			return errors.Annotate(err, "invalid backup schedule")
+		}
+	}
+	if err := c.BackupRetentionPolicy().Validate(); err != nil {
+		return errors.Annotate(err, "invalid backup retention policy")
+	}
+	if err := validateBackupStorage(c); err != nil {
+		return errors.Annotate(err, "invalid backup storage")
+	}
+
+	return nil
+}
+
+func validateBackupStorage(c Config) error {
+	switch storage := c.BackupStorage(); storage {
+	case ControllerBackupStorage:
+	case DirectoryBackupStorage:
+		dir := c.BackupStorageDirectory()
+		if dir == "" {
+			return errors.Errorf("%s must be set", BackupStorageDirectory)
+		}
+		if !path.IsAbs(dir) {
+			return errors.Errorf("%s %q is not an absolute path", BackupStorageDirectory, dir)
+		}
+	case S3BackupStorage:
+		s3 := c.BackupStorageS3()
+		if s3.Endpoint == "" {
+			return errors.Errorf("%s must be set", BackupStorageS3Endpoint)
+		}
+		u, err := url.Parse(s3.Endpoint)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return errors.Errorf("%s %q is not an http or https URL", BackupStorageS3Endpoint, s3.Endpoint)
+		}
+		if s3.Bucket == "" {
+			return errors.Errorf("%s must be set", BackupStorageS3Bucket)
+		}
+		if (s3.AccessKey == "") != (s3.SecretKey == "") {
+			return errors.Errorf(
+				"%s and %s must be set together",
+				BackupStorageS3AccessKey, BackupStorageS3SecretKey,
+			)
+		}
+	default:
+		return errors.NotValidf("%s %q", BackupStorage, storage)
+	}
 	return nil
 }
 
@@ -229,15 +419,37 @@ func GenerateControllerCertAndKey(caCert, caKey string, hostAddresses []string)
 }
 
 var configChecker = schema.FieldMap(schema.Fields{
-	ApiPort:                 schema.ForceInt(),
-	StatePort:               schema.ForceInt(),
-	IdentityURL:             schema.String(),
-	IdentityPublicKey:       schema.String(),
-	SetNumaControlPolicyKey: schema.Bool(),
+	ApiPort:                  schema.ForceInt(),
+	StatePort:                schema.ForceInt(),
+	IdentityURL:              schema.String(),
+	IdentityPublicKey:        schema.String(),
+	SetNumaControlPolicyKey:  schema.Bool(),
+	BackupSchedule:           schema.String(),
+	BackupKeepLast:           schema.ForceInt(),
+	BackupKeepDaily:          schema.ForceInt(),
+	BackupKeepWeekly:         schema.ForceInt(),
+	BackupStorage:            schema.String(),
+	BackupStorageDirectory:   schema.String(),
+	BackupStorageS3Endpoint:  schema.String(),
+	BackupStorageS3Region:    schema.String(),
+	BackupStorageS3Bucket:    schema.String(),
+	BackupStorageS3AccessKey: schema.String(),
+	BackupStorageS3SecretKey: schema.String(),
 }, schema.Defaults{
-	ApiPort:                 DefaultAPIPort,
-	StatePort:               DefaultStatePort,
-	IdentityURL:             schema.Omit,
-	IdentityPublicKey:       schema.Omit,
-	SetNumaControlPolicyKey: DefaultNumaControlPolicy,
+	ApiPort:                  DefaultAPIPort,
+	StatePort:                DefaultStatePort,
+	IdentityURL:              schema.Omit,
+	IdentityPublicKey:        schema.Omit,
+	SetNumaControlPolicyKey:  DefaultNumaControlPolicy,
+	BackupSchedule:           schema.Omit,
+	BackupKeepLast:           schema.Omit,
+	BackupKeepDaily:          schema.Omit,
+	BackupKeepWeekly:         schema.Omit,
+	BackupStorage:            schema.Omit,
+	BackupStorageDirectory:   schema.Omit,
+	BackupStorageS3Endpoint:  schema.Omit,
+	BackupStorageS3Region:    schema.Omit,
+	BackupStorageS3Bucket:    schema.Omit,
+	BackupStorageS3AccessKey: schema.Omit,
+	BackupStorageS3SecretKey: schema.Omit,
 })
@@ -14,6 +14,7 @@ import (
 
 	"github.com/juju/juju/cert"
 	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/backups"
 	"github.com/juju/juju/testing"
 )
 
@@ -72,3 +73,112 @@ func (s *ConfigSuite) TestGenerateControllerCertAndKey(c *gc.C) {
 		c.Assert(sanIPs, jc.SameContents, test.sanValues)
 	}
 }
+
+func (s *ConfigSuite) TestBackupConfig(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ModelTag.Id(), testing.CACert, map[string]interface{}{
+		controller.BackupSchedule:   "@daily",
+		controller.BackupKeepLast:   3,
+		controller.BackupKeepDaily:  7,
+		controller.BackupKeepWeekly: float64(4),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.BackupSchedule(), gc.Equals, "@daily")
+	c.Assert(cfg.BackupRetentionPolicy(), jc.DeepEquals, backups.RetentionPolicy{
+		KeepLast:   3,
+		KeepDaily:  7,
+		KeepWeekly: 4,
+	})
+}
+
+func (s *ConfigSuite) TestBackupConfigDefaults(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ModelTag.Id(), testing.CACert, map[string]interface{}{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.BackupSchedule(), gc.Equals, "")
+	c.Assert(cfg.BackupRetentionPolicy(), jc.DeepEquals, backups.RetentionPolicy{})
+}
+
+func (s *ConfigSuite) TestBackupStorageDefault(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ModelTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.BackupStorage(), gc.Equals, controller.ControllerBackupStorage)
+	c.Assert(cfg.BackupStorageS3().Region, gc.Equals, controller.DefaultBackupStorageS3Region)
+}
+
+func (s *ConfigSuite) TestBackupStorageS3(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ModelTag.Id(), testing.CACert, map[string]interface{}{
+		controller.BackupStorage:            "s3",
+		controller.BackupStorageS3Endpoint:  "https://s3.example.com",
+		controller.BackupStorageS3Region:    "eu-west-1",
+		controller.BackupStorageS3Bucket:    "backups",
+		controller.BackupStorageS3AccessKey: "access",
+		controller.BackupStorageS3SecretKey: "secret",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.BackupStorage(), gc.Equals, controller.S3BackupStorage)
+	c.Assert(cfg.BackupStorageS3(), jc.DeepEquals, controller.BackupS3Config{
+		Endpoint:  "https://s3.example.com",
+		Region:    "eu-west-1",
+		Bucket:    "backups",
+		AccessKey: "access",
+		SecretKey: "secret",
+	})
+}
+
+func (s *ConfigSuite) TestBackupConfigInvalid(c *gc.C) {
+	for i, test := range []struct {
+		attrs map[string]interface{}
+		err   string
+	}{{
+		attrs: map[string]interface{}{controller.BackupSchedule: "daily"},
+		err:   `invalid backup schedule: schedule "daily" \(expected 5 fields, got 1\) not valid`,
+	}, {
+		attrs: map[string]interface{}{controller.BackupSchedule: "0 25 * * *"},
+		err:   `invalid backup schedule: parsing schedule .*: hour "25" \(expected 0-23\) not valid`,
+	}, {
+		attrs: map[string]interface{}{controller.BackupKeepDaily: -1},
+		err:   `invalid backup retention policy: keep-daily -1 not valid`,
+	}, {
+		attrs: map[string]interface{}{controller.BackupStorage: "ftp"},
+		err:   `invalid backup storage: backup-storage "ftp" not valid`,
+	}, {
+		attrs: map[string]interface{}{controller.BackupStorage: "directory"},
+		err:   `invalid backup storage: backup-storage-directory must be set`,
+	}, {
+		attrs: map[string]interface{}{
+			controller.BackupStorage:          "directory",
+			controller.BackupStorageDirectory: "backups",
+		},
+		err: `invalid backup storage: backup-storage-directory "backups" is not an absolute path`,
+	}, {
+		attrs: map[string]interface{}{
+			controller.BackupStorage:         "s3",
+			controller.BackupStorageS3Bucket: "backups",
+		},
+		err: `invalid backup storage: backup-storage-s3-endpoint must be set`,
+	}, {
+		attrs: map[string]interface{}{
+			controller.BackupStorage:           "s3",
+			controller.BackupStorageS3Endpoint: "s3.example.com",
+			controller.BackupStorageS3Bucket:   "backups",
+		},
+		err: `invalid backup storage: backup-storage-s3-endpoint "s3.example.com" is not an http or https URL`,
+	}, {
+		attrs: map[string]interface{}{
+			controller.BackupStorage:           "s3",
+			controller.BackupStorageS3Endpoint: "https://s3.example.com",
+		},
+		err: `invalid backup storage: backup-storage-s3-bucket must be set`,
+	}, {
+		attrs: map[string]interface{}{
+			controller.BackupStorage:            "s3",
+			controller.BackupStorageS3Endpoint:  "https://s3.example.com",
+			controller.BackupStorageS3Bucket:    "backups",
+			controller.BackupStorageS3AccessKey: "access",
+		},
+		err: `invalid backup storage: backup-storage-s3-access-key and backup-storage-s3-secret-key must be set together`,
+	}} {
+		c.Logf("test %d: %v", i, test.attrs)
+		_, err := controller.NewConfig(testing.ModelTag.Id(), testing.CACert, test.attrs)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
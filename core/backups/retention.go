@@ -0,0 +1,102 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// RetentionPolicy determines which scheduled backups are kept. A backup
+// is kept if any of the rules retains it; a policy with no rules keeps
+// every backup.
+type RetentionPolicy struct {
+	// KeepLast is the number of most recent backups to keep.
+	KeepLast int
+
+	// KeepDaily is the number of days for which the most recent
+	// backup of the day is kept.
+	KeepDaily int
+
+	// KeepWeekly is the number of weeks for which the most recent
+	// backup of the week is kept. Weeks are ISO 8601 weeks.
+	KeepWeekly int
+}
+
+// Validate returns an error if the policy is not valid.
+func (p RetentionPolicy) Validate() error {
+	if p.KeepLast < 0 {
+		return errors.NotValidf("keep-last %d", p.KeepLast)
+	}
+	if p.KeepDaily < 0 {
+		return errors.NotValidf("keep-daily %d", p.KeepDaily)
+	}
+	if p.KeepWeekly < 0 {
+		return errors.NotValidf("keep-weekly %d", p.KeepWeekly)
+	}
+	return nil
+}
+
+// Backup identifies a backup to which a retention policy is applied.
+type Backup struct {
+	ID      string
+	Started time.Time
+}
+
+// Expired returns the IDs of the backups that are not retained by the
+// policy, oldest first. Days and weeks are determined in UTC.
+func (p RetentionPolicy) Expired(backups []Backup) []string {
+	if p == (RetentionPolicy{}) {
+		return nil
+	}
+	sorted := make([]Backup, len(backups))
+	copy(sorted, backups)
+	sort.Sort(byStartedDesc(sorted))
+
+	keep := make(map[string]bool)
+	for i := 0; i < p.KeepLast && i < len(sorted); i++ {
+		keep[sorted[i].ID] = true
+	}
+	keepPeriodic(sorted, p.KeepDaily, keep, func(t time.Time) string {
+		return t.Format("2006-01-02")
+	})
+	keepPeriodic(sorted, p.KeepWeekly, keep, func(t time.Time) string {
+		year, week := t.ISOWeek()
+		return fmt.Sprintf("%d-%d", year, week)
+	})
+
+	var expired []string
+	for i := len(sorted) - 1; i >= 0; i-- {
+		if !keep[sorted[i].ID] {
+			expired = append(expired, sorted[i].ID)
+		}
+	}
+	return expired
+}
+
+// keepPeriodic marks the most recent backup in each of the n most
+// recent periods as kept. The backups must be sorted newest first.
+func keepPeriodic(sorted []Backup, n int, keep map[string]bool, period func(time.Time) string) {
+	seen := make(map[string]bool)
+	for _, b := range sorted {
+		if len(seen) >= n {
+			break
+		}
+		key := period(b.Started.UTC())
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		keep[b.ID] = true
+	}
+}
+
+type byStartedDesc []Backup
+
+func (b byStartedDesc) Len() int           { return len(b) }
+func (b byStartedDesc) Swap(i, j int)      { b[i], b[j] = b[j], b[i] }
+func (b byStartedDesc) Less(i, j int) bool { return b[i].Started.After(b[j].Started) }
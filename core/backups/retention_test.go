@@ -0,0 +1,98 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/backups"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type RetentionSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&RetentionSuite{})
+
+// retentionBackups returns backups taken at 00:00 and 12:00 each day
+// from Monday 2016-05-30 to Sunday 2016-06-12, oldest first, with
+// IDs of the form "<day>-<hour>".
+func retentionBackups() []backups.Backup {
+	var result []backups.Backup
+	start := time.Date(2016, 5, 30, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 28; i++ {
+		t := start.Add(time.Duration(i) * 12 * time.Hour)
+		result = append(result, backups.Backup{
+			ID:      t.Format("0102-15"),
+			Started: t,
+		})
+	}
+	return result
+}
+
+// keptIDs returns the IDs of the backups that are not expired.
+func keptIDs(all []backups.Backup, expired []string) []string {
+	isExpired := make(map[string]bool)
+	for _, id := range expired {
+		isExpired[id] = true
+	}
+	var kept []string
+	for _, b := range all {
+		if !isExpired[b.ID] {
+			kept = append(kept, b.ID)
+		}
+	}
+	return kept
+}
+
+func (s *RetentionSuite) TestValidate(c *gc.C) {
+	c.Assert(backups.RetentionPolicy{}.Validate(), jc.ErrorIsNil)
+	c.Assert(backups.RetentionPolicy{KeepLast: -1}.Validate(), gc.ErrorMatches, "keep-last -1 not valid")
+	c.Assert(backups.RetentionPolicy{KeepDaily: -1}.Validate(), gc.ErrorMatches, "keep-daily -1 not valid")
+	c.Assert(backups.RetentionPolicy{KeepWeekly: -1}.Validate(), gc.ErrorMatches, "keep-weekly -1 not valid")
+}
+
+func (s *RetentionSuite) TestExpiredNoRules(c *gc.C) {
+	c.Assert(backups.RetentionPolicy{}.Expired(retentionBackups()), gc.HasLen, 0)
+}
+
+func (s *RetentionSuite) TestExpiredKeepLast(c *gc.C) {
+	all := retentionBackups()
+	expired := backups.RetentionPolicy{KeepLast: 3}.Expired(all)
+	c.Assert(expired, gc.HasLen, len(all)-3)
+	c.Assert(expired[0], gc.Equals, "0530-00")
+	c.Assert(expired[len(expired)-1], gc.Equals, "0611-00")
+}
+
+func (s *RetentionSuite) TestExpiredKeepDaily(c *gc.C) {
+	all := retentionBackups()
+	expired := backups.RetentionPolicy{KeepDaily: 2}.Expired(all)
+	c.Assert(expired, gc.HasLen, len(all)-2)
+	c.Assert(expired[len(expired)-2:], jc.DeepEquals, []string{"0611-00", "0612-00"})
+}
+
+func (s *RetentionSuite) TestExpiredKeepWeekly(c *gc.C) {
+	all := retentionBackups()
+	expired := backups.RetentionPolicy{KeepWeekly: 3}.Expired(all)
+	// There are only two weeks of backups; the last
+	// of each week is kept.
+	c.Assert(expired, gc.HasLen, len(all)-2)
+	c.Assert(keptIDs(all, expired), jc.SameContents, []string{"0605-12", "0612-12"})
+}
+
+func (s *RetentionSuite) TestExpiredCombined(c *gc.C) {
+	all := retentionBackups()
+	// Shuffle the input order, to check that it is sorted.
+	all[0], all[len(all)-1] = all[len(all)-1], all[0]
+	expired := backups.RetentionPolicy{
+		KeepLast:   1,
+		KeepDaily:  2,
+		KeepWeekly: 2,
+	}.Expired(all)
+	c.Assert(keptIDs(all, expired), jc.SameContents, []string{"0612-12", "0611-12", "0605-12"})
+}
@@ -0,0 +1,215 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// maxScheduleSearch bounds the search for the next scheduled time, so
+// that schedules which can never fire (e.g. "0 0 30 2 *") do not cause
+// Next to loop forever.
+const maxScheduleSearch = 5 * 366 * 24 * time.Hour
+
+// scheduleDescriptors maps the supported "@" shorthands to their
+// equivalent schedule specifications.
+var scheduleDescriptors = map[string]string{
+	"@hourly":   "0 * * * *",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@weekly":   "0 0 * * 0",
+	"@monthly":  "0 0 1 * *",
+}
+
+// Schedule is a cron-like schedule, which determines the times at
+// which scheduled backups are created.
+//
+// A schedule is specified with five space-separated fields:
+//
+//	minute hour day-of-month month day-of-week
+//
+// Each field may be "*", a value, a range of values ("1-5"), or a
+// comma-separated list of these, optionally followed by a step
+// ("*/15", "0-30/10"). Days of the week are numbered from 0 (Sunday)
+// to 6, with 7 also accepted for Sunday. As with cron, if both the
+// day-of-month and day-of-week fields are restricted, then a time
+// matches if either field matches.
+//
+// The shorthands "@hourly", "@daily", "@midnight", "@weekly" and
+// "@monthly" are also accepted.
+type Schedule struct {
+	spec     string
+	minutes  uint64
+	hours    uint64
+	days     uint64
+	months   uint64
+	weekdays uint64
+
+	// anyDay and anyWeekday record whether the day-of-month
+	// and day-of-week fields are unrestricted.
+	anyDay     bool
+	anyWeekday bool
+}
+
+// scheduleField describes the range of values permitted in a field
+// of a schedule specification.
+type scheduleField struct {
+	name     string
+	min, max int
+}
+
+var (
+	minuteField  = scheduleField{"minute", 0, 59}
+	hourField    = scheduleField{"hour", 0, 23}
+	dayField     = scheduleField{"day-of-month", 1, 31}
+	monthField   = scheduleField{"month", 1, 12}
+	weekdayField = scheduleField{"day-of-week", 0, 7}
+)
+
+// ParseSchedule parses a schedule specification, returning an error
+// satisfying errors.IsNotValid if the specification is invalid.
+func ParseSchedule(spec string) (*Schedule, error) {
+	fieldsSpec := strings.TrimSpace(spec)
+	if expanded, ok := scheduleDescriptors[fieldsSpec]; ok {
+		fieldsSpec = expanded
+	}
+	fields := strings.Fields(fieldsSpec)
+	if len(fields) != 5 {
+		return nil, errors.NotValidf("schedule %q (expected 5 fields, got %d)", spec, len(fields))
+	}
+	s := &Schedule{spec: spec}
+	var err error
+	if s.minutes, err = parseScheduleField(fields[0], minuteField); err != nil {
+		return nil, errors.Annotatef(err, "parsing schedule %q", spec)
+	}
+	if s.hours, err = parseScheduleField(fields[1], hourField); err != nil {
+		return nil, errors.Annotatef(err, "parsing schedule %q", spec)
+	}
+	if s.days, err = parseScheduleField(fields[2], dayField); err != nil {
+		return nil, errors.Annotatef(err, "parsing schedule %q", spec)
+	}
+	if s.months, err = parseScheduleField(fields[3], monthField); err != nil {
+		return nil, errors.Annotatef(err, "parsing schedule %q", spec)
+	}
+	if s.weekdays, err = parseScheduleField(fields[4], weekdayField); err != nil {
+		return nil, errors.Annotatef(err, "parsing schedule %q", spec)
+	}
+	// Sunday may be specified as either 0 or 7.
+	if s.weekdays&(1<<7) != 0 {
+		s.weekdays |= 1
+	}
+	s.anyDay = fields[2] == "*"
+	s.anyWeekday = fields[4] == "*"
+	return s, nil
+}
+
+// parseScheduleField parses a single field of a schedule specification,
+// returning a bit set of the matching values.
+func parseScheduleField(spec string, field scheduleField) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(spec, ",") {
+		step := 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			n, err := strconv.Atoi(part[i+1:])
+			if err != nil || n <= 0 {
+				return 0, errors.NotValidf("%s step %q", field.name, part[i+1:])
+			}
+			step = n
+			part = part[:i]
+		}
+		min, max := field.min, field.max
+		switch {
+		case part == "*":
+		case strings.Contains(part, "-"):
+			bounds := strings.SplitN(part, "-", 2)
+			var err error
+			if min, err = parseScheduleValue(bounds[0], field); err != nil {
+				return 0, errors.Trace(err)
+			}
+			if max, err = parseScheduleValue(bounds[1], field); err != nil {
+				return 0, errors.Trace(err)
+			}
+			if min > max {
+				return 0, errors.NotValidf("%s range %q", field.name, part)
+			}
+		default:
+			value, err := parseScheduleValue(part, field)
+			if err != nil {
+				return 0, errors.Trace(err)
+			}
+			min = value
+			if step == 1 {
+				max = value
+			}
+		}
+		for v := min; v <= max; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+func parseScheduleValue(s string, field scheduleField) (int, error) {
+	value, err := strconv.Atoi(s)
+	if err != nil || value < field.min || value > field.max {
+		return 0, errors.NotValidf(
+			"%s %q (expected %d-%d)", field.name, s, field.min, field.max,
+		)
+	}
+	return value, nil
+}
+
+// String returns the schedule specification.
+func (s *Schedule) String() string {
+	return s.spec
+}
+
+// Next returns the first time strictly after t that matches the
+// schedule, in t's location. If there is no such time within the
+// next five years, the zero time is returned.
+func (s *Schedule) Next(t time.Time) time.Time {
+	next := t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(maxScheduleSearch)
+	for next.Before(limit) {
+		if !hasBit(s.months, int(next.Month())) {
+			year, month, _ := next.Date()
+			next = time.Date(year, month+1, 1, 0, 0, 0, 0, next.Location())
+			continue
+		}
+		if !s.matchesDay(next) {
+			year, month, day := next.Date()
+			next = time.Date(year, month, day+1, 0, 0, 0, 0, next.Location())
+			continue
+		}
+		if !hasBit(s.hours, next.Hour()) {
+			next = next.Truncate(time.Hour).Add(time.Hour)
+			continue
+		}
+		if !hasBit(s.minutes, next.Minute()) {
+			next = next.Add(time.Minute)
+			continue
+		}
+		return next
+	}
+	return time.Time{}
+}
+
+// matchesDay reports whether the day of the specified time
+// matches the schedule's day-of-month and day-of-week fields.
+func (s *Schedule) matchesDay(t time.Time) bool {
+	dayMatches := hasBit(s.days, t.Day())
+	weekdayMatches := hasBit(s.weekdays, int(t.Weekday()))
+	if s.anyDay || s.anyWeekday {
+		return dayMatches && weekdayMatches
+	}
+	return dayMatches || weekdayMatches
+}
+
+func hasBit(bits uint64, n int) bool {
+	return bits&(1<<uint(n)) != 0
+}
@@ -0,0 +1,123 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/backups"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ScheduleSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&ScheduleSuite{})
+
+// 2016-06-01 was a Wednesday.
+var scheduleEpoch = time.Date(2016, 6, 1, 10, 30, 15, 0, time.UTC)
+
+func (s *ScheduleSuite) TestNext(c *gc.C) {
+	for i, test := range []struct {
+		spec   string
+		expect time.Time
+	}{{
+		spec:   "* * * * *",
+		expect: time.Date(2016, 6, 1, 10, 31, 0, 0, time.UTC),
+	}, {
+		spec:   "@hourly",
+		expect: time.Date(2016, 6, 1, 11, 0, 0, 0, time.UTC),
+	}, {
+		spec:   "@daily",
+		expect: time.Date(2016, 6, 2, 0, 0, 0, 0, time.UTC),
+	}, {
+		spec:   "@weekly",
+		expect: time.Date(2016, 6, 5, 0, 0, 0, 0, time.UTC),
+	}, {
+		spec:   "@monthly",
+		expect: time.Date(2016, 7, 1, 0, 0, 0, 0, time.UTC),
+	}, {
+		spec:   "*/20 * * * *",
+		expect: time.Date(2016, 6, 1, 10, 40, 0, 0, time.UTC),
+	}, {
+		spec:   "15 2,14 * * *",
+		expect: time.Date(2016, 6, 1, 14, 15, 0, 0, time.UTC),
+	}, {
+		spec:   "0 3 * * 1-5",
+		expect: time.Date(2016, 6, 2, 3, 0, 0, 0, time.UTC),
+	}, {
+		spec:   "0 3 * * 7",
+		expect: time.Date(2016, 6, 5, 3, 0, 0, 0, time.UTC),
+	}, {
+		spec:   "0 0 29 2 *",
+		expect: time.Date(2020, 2, 29, 0, 0, 0, 0, time.UTC),
+	}, {
+		// Both day fields restricted: either may match.
+		spec:   "0 0 15 * 5",
+		expect: time.Date(2016, 6, 3, 0, 0, 0, 0, time.UTC),
+	}, {
+		spec:   "0 0 30 2 *",
+		expect: time.Time{},
+	}} {
+		c.Logf("test %d: %q", i, test.spec)
+		schedule, err := backups.ParseSchedule(test.spec)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(schedule.String(), gc.Equals, test.spec)
+		c.Check(schedule.Next(scheduleEpoch), gc.Equals, test.expect)
+	}
+}
+
+func (s *ScheduleSuite) TestNextOnSchedule(c *gc.C) {
+	schedule, err := backups.ParseSchedule("@hourly")
+	c.Assert(err, jc.ErrorIsNil)
+	t := time.Date(2016, 6, 1, 11, 0, 0, 0, time.UTC)
+	c.Assert(schedule.Next(t), gc.Equals, t.Add(time.Hour))
+}
+
+func (s *ScheduleSuite) TestParseInvalid(c *gc.C) {
+	for i, test := range []struct {
+		spec string
+		err  string
+	}{{
+		spec: "",
+		err:  `schedule "" \(expected 5 fields, got 0\) not valid`,
+	}, {
+		spec: "@yearly",
+		err:  `schedule "@yearly" \(expected 5 fields, got 1\) not valid`,
+	}, {
+		spec: "* * * *",
+		err:  `schedule "\* \* \* \*" \(expected 5 fields, got 4\) not valid`,
+	}, {
+		spec: "60 * * * *",
+		err:  `parsing schedule "60 \* \* \* \*": minute "60" \(expected 0-59\) not valid`,
+	}, {
+		spec: "* 24 * * *",
+		err:  `parsing schedule .*: hour "24" \(expected 0-23\) not valid`,
+	}, {
+		spec: "* * 0 * *",
+		err:  `parsing schedule .*: day-of-month "0" \(expected 1-31\) not valid`,
+	}, {
+		spec: "* * * jan *",
+		err:  `parsing schedule .*: month "jan" \(expected 1-12\) not valid`,
+	}, {
+		spec: "* * * * 8",
+		err:  `parsing schedule .*: day-of-week "8" \(expected 0-7\) not valid`,
+	}, {
+		spec: "*/0 * * * *",
+		err:  `parsing schedule .*: minute step "0" not valid`,
+	}, {
+		spec: "30-10 * * * *",
+		err:  `parsing schedule .*: minute range "30-10" not valid`,
+	}} {
+		c.Logf("test %d: %q", i, test.spec)
+		_, err := backups.ParseSchedule(test.spec)
+		c.Check(err, gc.ErrorMatches, test.err)
+		c.Check(errors.Cause(err), jc.Satisfies, errors.IsNotValid)
+	}
+}
@@ -0,0 +1,236 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+)
+
+type actions struct {
+	Version  int       `yaml:"version"`
+	Actions_ []*action `yaml:"actions"`
+}
+
+type action struct {
+	Id_         string                 `yaml:"id"`
+	Receiver_   string                 `yaml:"receiver"`
+	Name_       string                 `yaml:"name"`
+	Parameters_ map[string]interface{} `yaml:"parameters,omitempty"`
+	Enqueued_   time.Time              `yaml:"enqueued"`
+	// Can't use omitempty with time.Time, so use pointers. Pending
+	// actions haven't started, and running actions haven't completed.
+	Started_   *time.Time             `yaml:"started,omitempty"`
+	Completed_ *time.Time             `yaml:"completed,omitempty"`
+	Status_    string                 `yaml:"status"`
+	Message_   string                 `yaml:"message,omitempty"`
+	Results_   map[string]interface{} `yaml:"results,omitempty"`
+}
+
+// ActionArgs is an argument struct used to add an action to the Model.
+type ActionArgs struct {
+	Id         string
+	Receiver   string
+	Name       string
+	Parameters map[string]interface{}
+	Enqueued   time.Time
+	Started    time.Time
+	Completed  time.Time
+	Status     string
+	Message    string
+	Results    map[string]interface{}
+}
+
+func newAction(args ActionArgs) *action {
+	a := &action{
+		Id_:         args.Id,
+		Receiver_:   args.Receiver,
+		Name_:       args.Name,
+		Parameters_: args.Parameters,
+		Enqueued_:   args.Enqueued,
+		Status_:     args.Status,
+		Message_:    args.Message,
+		Results_:    args.Results,
+	}
+	if !args.Started.IsZero() {
+		started := args.Started
+		a.Started_ = &started
+	}
+	if !args.Completed.IsZero() {
+		completed := args.Completed
+		a.Completed_ = &completed
+	}
+	return a
+}
+
+// Id implements Action.
+func (a *action) Id() string {
+	return a.Id_
+}
+
+// Receiver implements Action.
+func (a *action) Receiver() string {
+	return a.Receiver_
+}
+
+// Name implements Action.
+func (a *action) Name() string {
+	return a.Name_
+}
+
+// Parameters implements Action.
+func (a *action) Parameters() map[string]interface{} {
+	return a.Parameters_
+}
+
+// Enqueued implements Action.
+func (a *action) Enqueued() time.Time {
+	return a.Enqueued_
+}
+
+// Started implements Action.
+func (a *action) Started() time.Time {
+	var zero time.Time
+	if a.Started_ == nil {
+		return zero
+	}
+	return *a.Started_
+}
+
+// Completed implements Action.
+func (a *action) Completed() time.Time {
+	var zero time.Time
+	if a.Completed_ == nil {
+		return zero
+	}
+	return *a.Completed_
+}
+
+// Status implements Action.
+func (a *action) Status() string {
+	return a.Status_
+}
+
+// Message implements Action.
+func (a *action) Message() string {
+	return a.Message_
+}
+
+// Results implements Action.
+func (a *action) Results() map[string]interface{} {
+	return a.Results_
+}
+
+// Validate implements Action.
+func (a *action) Validate() error {
+	if a.Id_ == "" {
+		return errors.NotValidf("action missing id")
+	}
+	if a.Receiver_ == "" {
+		return errors.NotValidf("action %q missing receiver", a.Id_)
+	}
+	if a.Name_ == "" {
+		return errors.NotValidf("action %q missing name", a.Id_)
+	}
+	if a.Status_ == "" {
+		return errors.NotValidf("action %q missing status", a.Id_)
+	}
+	return nil
+}
+
+func importActions(source map[string]interface{}) ([]*action, error) {
+	checker := versionedChecker("actions")
+	coerced, err := checker.Coerce(source, nil)
+	if err != nil {
+		return nil, errors.Annotatef(err, "actions version schema check failed")
+	}
+	valid := coerced.(map[string]interface{})
+
+	version := int(valid["version"].(int64))
+	importFunc, ok := actionDeserializationFuncs[version]
+	if !ok {
+		return nil, errors.NotValidf("version %d", version)
+	}
+	sourceList := valid["actions"].([]interface{})
+	return importActionList(sourceList, importFunc)
+}
+
+func importActionList(sourceList []interface{}, importFunc actionDeserializationFunc) ([]*action, error) {
+	result := make([]*action, 0, len(sourceList))
+	for i, value := range sourceList {
+		source, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, errors.Errorf("unexpected value for action %d, %T", i, value)
+		}
+		action, err := importFunc(source)
+		if err != nil {
+			return nil, errors.Annotatef(err, "action %d", i)
+		}
+		result = append(result, action)
+	}
+	return result, nil
+}
+
+type actionDeserializationFunc func(map[string]interface{}) (*action, error)
+
+var actionDeserializationFuncs = map[int]actionDeserializationFunc{
+	1: importActionV1,
+}
+
+func importActionV1(source map[string]interface{}) (*action, error) {
+	fields := schema.Fields{
+		"id":         schema.String(),
+		"receiver":   schema.String(),
+		"name":       schema.String(),
+		"parameters": schema.StringMap(schema.Any()),
+		"enqueued":   schema.Time(),
+		"started":    schema.Time(),
+		"completed":  schema.Time(),
+		"status":     schema.String(),
+		"message":    schema.String(),
+		"results":    schema.StringMap(schema.Any()),
+	}
+	defaults := schema.Defaults{
+		"parameters": schema.Omit,
+		"started":    time.Time{},
+		"completed":  time.Time{},
+		"message":    "",
+		"results":    schema.Omit,
+	}
+	checker := schema.FieldMap(fields, defaults)
+
+	coerced, err := checker.Coerce(source, nil)
+	if err != nil {
+		return nil, errors.Annotatef(err, "action v1 schema check failed")
+	}
+	valid := coerced.(map[string]interface{})
+	// From here we know that the map returned from the schema coercion
+	// contains fields of the right type.
+
+	result := &action{
+		Id_:       valid["id"].(string),
+		Receiver_: valid["receiver"].(string),
+		Name_:     valid["name"].(string),
+		Enqueued_: valid["enqueued"].(time.Time),
+		Status_:   valid["status"].(string),
+		Message_:  valid["message"].(string),
+	}
+	if parameters, ok := valid["parameters"]; ok {
+		result.Parameters_ = parameters.(map[string]interface{})
+	}
+	if results, ok := valid["results"]; ok {
+		result.Results_ = results.(map[string]interface{})
+	}
+	started := valid["started"].(time.Time)
+	if !started.IsZero() {
+		result.Started_ = &started
+	}
+	completed := valid["completed"].(time.Time)
+	if !completed.IsZero() {
+		result.Completed_ = &completed
+	}
+	return result, nil
+}
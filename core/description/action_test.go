@@ -0,0 +1,128 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/yaml.v2"
+)
+
+type ActionSerializationSuite struct {
+	SliceSerializationSuite
+}
+
+var _ = gc.Suite(&ActionSerializationSuite{})
+
+func (s *ActionSerializationSuite) SetUpTest(c *gc.C) {
+	s.SliceSerializationSuite.SetUpTest(c)
+	s.importName = "actions"
+	s.sliceName = "actions"
+	s.importFunc = func(m map[string]interface{}) (interface{}, error) {
+		return importActions(m)
+	}
+	s.testFields = func(m map[string]interface{}) {
+		m["actions"] = []interface{}{}
+	}
+}
+
+func completedActionArgs() ActionArgs {
+	return ActionArgs{
+		Id:         "some-uuid",
+		Receiver:   "ubuntu/0",
+		Name:       "backup",
+		Parameters: map[string]interface{}{"outfile": "foo.tgz"},
+		Enqueued:   time.Date(2016, 10, 18, 2, 3, 4, 0, time.UTC),
+		Started:    time.Date(2016, 10, 18, 2, 3, 5, 0, time.UTC),
+		Completed:  time.Date(2016, 10, 18, 2, 3, 6, 0, time.UTC),
+		Status:     "completed",
+		Message:    "all done",
+		Results:    map[string]interface{}{"size": "42"},
+	}
+}
+
+func (s *ActionSerializationSuite) TestNewAction(c *gc.C) {
+	args := completedActionArgs()
+	a := newAction(args)
+
+	c.Check(a.Id(), gc.Equals, args.Id)
+	c.Check(a.Receiver(), gc.Equals, args.Receiver)
+	c.Check(a.Name(), gc.Equals, args.Name)
+	c.Check(a.Parameters(), jc.DeepEquals, args.Parameters)
+	c.Check(a.Enqueued(), gc.Equals, args.Enqueued)
+	c.Check(a.Started(), gc.Equals, args.Started)
+	c.Check(a.Completed(), gc.Equals, args.Completed)
+	c.Check(a.Status(), gc.Equals, args.Status)
+	c.Check(a.Message(), gc.Equals, args.Message)
+	c.Check(a.Results(), jc.DeepEquals, args.Results)
+}
+
+func (s *ActionSerializationSuite) TestPendingActionHasNoTimes(c *gc.C) {
+	a := newAction(ActionArgs{
+		Id:       "some-uuid",
+		Receiver: "0",
+		Name:     "reboot",
+		Enqueued: time.Date(2016, 10, 18, 2, 3, 4, 0, time.UTC),
+		Status:   "pending",
+	})
+	c.Check(a.Started_, gc.IsNil)
+	c.Check(a.Completed_, gc.IsNil)
+	c.Check(a.Started().IsZero(), jc.IsTrue)
+	c.Check(a.Completed().IsZero(), jc.IsTrue)
+}
+
+func (s *ActionSerializationSuite) TestValidate(c *gc.C) {
+	for i, test := range []struct {
+		modify func(*ActionArgs)
+		err    string
+	}{{
+		modify: func(args *ActionArgs) { args.Id = "" },
+		err:    `action missing id not valid`,
+	}, {
+		modify: func(args *ActionArgs) { args.Receiver = "" },
+		err:    `action "some-uuid" missing receiver not valid`,
+	}, {
+		modify: func(args *ActionArgs) { args.Name = "" },
+		err:    `action "some-uuid" missing name not valid`,
+	}, {
+		modify: func(args *ActionArgs) { args.Status = "" },
+		err:    `action "some-uuid" missing status not valid`,
+	}} {
+		c.Logf("test %d", i)
+		args := completedActionArgs()
+		test.modify(&args)
+		err := newAction(args).Validate()
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *ActionSerializationSuite) TestParsingSerializedData(c *gc.C) {
+	initial := actions{
+		Version: 1,
+		Actions_: []*action{
+			newAction(completedActionArgs()),
+			newAction(ActionArgs{
+				Id:       "other-uuid",
+				Receiver: "0",
+				Name:     "reboot",
+				Enqueued: time.Date(2016, 10, 18, 2, 3, 4, 0, time.UTC),
+				Status:   "pending",
+			}),
+		},
+	}
+
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var source map[string]interface{}
+	err = yaml.Unmarshal(bytes, &source)
+	c.Assert(err, jc.ErrorIsNil)
+
+	actions, err := importActions(source)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(actions, jc.DeepEquals, initial.Actions_)
+}
@@ -43,14 +43,20 @@ type application struct {
 
 	MetricsCredentials_ string `yaml:"metrics-creds,omitempty"`
 
+	// EndpointBindings maps each endpoint name to the name of the
+	// space it is bound to.
+	EndpointBindings_ map[string]string `yaml:"endpoint-bindings,omitempty"`
+
 	// unit count will be assumed by the number of units associated.
 	Units_ units `yaml:"units"`
 
+	Resources_ resources `yaml:"resources"`
+
 	Annotations_ `yaml:"annotations,omitempty"`
 
 	Constraints_ *constraints `yaml:"constraints,omitempty"`
 
-	// Storage Constraints
+	StorageConstraints_ map[string]*storageconstraint `yaml:"storage-constraints,omitempty"`
 }
 
 // ApplicationArgs is an argument struct used to add an application to the Model.
@@ -69,6 +75,8 @@ type ApplicationArgs struct {
 	Leader               string
 	LeadershipSettings   map[string]interface{}
 	MetricsCredentials   []byte
+	EndpointBindings     map[string]string
+	StorageConstraints   map[string]StorageConstraintArgs
 }
 
 func newApplication(args ApplicationArgs) *application {
@@ -88,9 +96,17 @@ func newApplication(args ApplicationArgs) *application {
 		Leader_:               args.Leader,
 		LeadershipSettings_:   args.LeadershipSettings,
 		MetricsCredentials_:   creds,
+		EndpointBindings_:     args.EndpointBindings,
 		StatusHistory_:        newStatusHistory(),
 	}
+	if len(args.StorageConstraints) > 0 {
+		svc.StorageConstraints_ = make(map[string]*storageconstraint)
+		for key, value := range args.StorageConstraints {
+			svc.StorageConstraints_[key] = newStorageConstraint(value)
+		}
+	}
 	svc.setUnits(nil)
+	svc.setResources(nil)
 	return svc
 }
 
@@ -174,6 +190,11 @@ func (s *application) MetricsCredentials() []byte {
 	return creds
 }
 
+// EndpointBindings implements Application.
+func (s *application) EndpointBindings() map[string]string {
+	return s.EndpointBindings_
+}
+
 // Status implements Application.
 func (s *application) Status() Status {
 	// To avoid typed nils check nil here.
@@ -214,11 +235,43 @@ func (s *application) AddUnit(args UnitArgs) Unit {
 
 func (s *application) setUnits(unitList []*unit) {
 	s.Units_ = units{
-		Version: 1,
+		Version: 2,
 		Units_:  unitList,
 	}
 }
 
+// Resources implements Application.
+func (s *application) Resources() []Resource {
+	result := make([]Resource, len(s.Resources_.Resources_))
+	for i, r := range s.Resources_.Resources_ {
+		result[i] = r
+	}
+	return result
+}
+
+// AddResource implements Application.
+func (s *application) AddResource(args ResourceArgs) Resource {
+	r := newResource(args)
+	s.Resources_.Resources_ = append(s.Resources_.Resources_, r)
+	return r
+}
+
+func (s *application) setResources(resourceList []*resource) {
+	s.Resources_ = resources{
+		Version:    1,
+		Resources_: resourceList,
+	}
+}
+
+// StorageConstraints implements Application.
+func (s *application) StorageConstraints() map[string]StorageConstraint {
+	result := make(map[string]StorageConstraint)
+	for key, value := range s.StorageConstraints_ {
+		result[key] = value
+	}
+	return result
+}
+
 // Constraints implements HasConstraints.
 func (s *application) Constraints() Constraints {
 	if s.Constraints_ == nil {
@@ -255,6 +308,21 @@ func (s *application) Validate() error {
 	if s.Leader_ != "" && !leaderFound {
 		return errors.NotValidf("missing unit for leader %q", s.Leader_)
 	}
+	resourceNames := set.NewStrings()
+	for _, r := range s.Resources_.Resources_ {
+		if err := r.Validate(); err != nil {
+			return errors.Annotatef(err, "application %q", s.Name_)
+		}
+		resourceNames.Add(r.Name_)
+	}
+	// Units can only be using resources that the application has.
+	for _, u := range s.Units_.Units_ {
+		for _, r := range u.UnitResources_.UnitResources_ {
+			if !resourceNames.Contains(r.Name_) {
+				return errors.NotValidf("unit %q resource %q not in application", u.Name_, r.Name_)
+			}
+		}
+	}
 	return nil
 }
 
@@ -295,9 +363,20 @@ type applicationDeserializationFunc func(map[string]interface{}) (*application,
 
 var applicationDeserializationFuncs = map[int]applicationDeserializationFunc{
 	1: importApplicationV1,
+	2: importApplicationV2,
 }
 
 func importApplicationV1(source map[string]interface{}) (*application, error) {
+	fields, defaults := applicationV1Fields()
+	return importApplication(fields, defaults, 1, source)
+}
+
+func importApplicationV2(source map[string]interface{}) (*application, error) {
+	fields, defaults := applicationV2Fields()
+	return importApplication(fields, defaults, 2, source)
+}
+
+func applicationV1Fields() (schema.Fields, schema.Defaults) {
 	fields := schema.Fields{
 		"name":                schema.String(),
 		"series":              schema.String(),
@@ -328,11 +407,27 @@ func importApplicationV1(source map[string]interface{}) (*application, error) {
 	addAnnotationSchema(fields, defaults)
 	addConstraintsSchema(fields, defaults)
 	addStatusHistorySchema(fields)
+	return fields, defaults
+}
+
+// applicationV2Fields adds the endpoint bindings, resources and storage
+// constraints.
+func applicationV2Fields() (schema.Fields, schema.Defaults) {
+	fields, defaults := applicationV1Fields()
+	fields["endpoint-bindings"] = schema.StringMap(schema.String())
+	fields["resources"] = schema.StringMap(schema.Any())
+	fields["storage-constraints"] = schema.StringMap(schema.StringMap(schema.Any()))
+	defaults["endpoint-bindings"] = schema.Omit
+	defaults["storage-constraints"] = schema.Omit
+	return fields, defaults
+}
+
+func importApplication(fields schema.Fields, defaults schema.Defaults, importVersion int, source map[string]interface{}) (*application, error) {
 	checker := schema.FieldMap(fields, defaults)
 
 	coerced, err := checker.Coerce(source, nil)
 	if err != nil {
-		return nil, errors.Annotatef(err, "application v1 schema check failed")
+		return nil, errors.Annotatef(err, "application v%d schema check failed", importVersion)
 	}
 	valid := coerced.(map[string]interface{})
 	// From here we know that the map returned from the schema coercion
@@ -366,6 +461,18 @@ func importApplicationV1(source map[string]interface{}) (*application, error) {
 		result.Constraints_ = constraints
 	}
 
+	if bindings, ok := valid["endpoint-bindings"]; ok {
+		result.EndpointBindings_ = convertToStringMap(bindings)
+	}
+
+	if constraintsMap, ok := valid["storage-constraints"]; ok {
+		constraints, err := importStorageConstraints(constraintsMap.(map[string]interface{}))
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result.StorageConstraints_ = constraints
+	}
+
 	encodedCreds := valid["metrics-creds"].(string)
 	// The model stores the creds encoded, but we want to make sure that
 	// we are storing something that can be decoded.
@@ -386,5 +493,15 @@ func importApplicationV1(source map[string]interface{}) (*application, error) {
 	}
 	result.setUnits(units)
 
+	// Version 1 applications have no resources section.
+	result.setResources(nil)
+	if source, ok := valid["resources"]; ok {
+		resources, err := importResources(source.(map[string]interface{}))
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result.setResources(resources)
+	}
+
 	return result, nil
 }
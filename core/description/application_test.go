@@ -54,11 +54,12 @@ func minimalApplicationMap() map[interface{}]interface{} {
 		},
 		"metrics-creds": "c2Vrcml0", // base64 encoded
 		"units": map[interface{}]interface{}{
-			"version": 1,
+			"version": 2,
 			"units": []interface{}{
 				minimalUnitMap(),
 			},
 		},
+		"resources": emptyResourcesMap(),
 	}
 }
 
@@ -120,6 +121,12 @@ func (s *ApplicationSerializationSuite) TestNewApplication(c *gc.C) {
 			"leader": true,
 		},
 		MetricsCredentials: []byte("sekrit"),
+		EndpointBindings: map[string]string{
+			"rel-name": "some-space",
+		},
+		StorageConstraints: map[string]StorageConstraintArgs{
+			"data": {Pool: "fast", Size: 2048, Count: 1},
+		},
 	}
 	application := newApplication(args)
 
@@ -138,6 +145,12 @@ func (s *ApplicationSerializationSuite) TestNewApplication(c *gc.C) {
 	c.Assert(application.Leader(), gc.Equals, "magic/1")
 	c.Assert(application.LeadershipSettings(), jc.DeepEquals, args.LeadershipSettings)
 	c.Assert(application.MetricsCredentials(), jc.DeepEquals, []byte("sekrit"))
+	c.Assert(application.EndpointBindings(), jc.DeepEquals, args.EndpointBindings)
+	constraints := application.StorageConstraints()
+	c.Assert(constraints, gc.HasLen, 1)
+	c.Assert(constraints["data"].Pool(), gc.Equals, "fast")
+	c.Assert(constraints["data"].Size(), gc.Equals, uint64(2048))
+	c.Assert(constraints["data"].Count(), gc.Equals, uint64(1))
 }
 
 func (s *ApplicationSerializationSuite) TestMinimalApplicationValid(c *gc.C) {
@@ -157,7 +170,7 @@ func (s *ApplicationSerializationSuite) TestMinimalMatches(c *gc.C) {
 
 func (s *ApplicationSerializationSuite) exportImport(c *gc.C, application_ *application) *application {
 	initial := applications{
-		Version:       1,
+		Version:       2,
 		Applications_: []*application{application_},
 	}
 
@@ -205,6 +218,109 @@ func (s *ApplicationSerializationSuite) TestConstraints(c *gc.C) {
 	c.Assert(application.Constraints(), jc.DeepEquals, newConstraints(args))
 }
 
+func (s *ApplicationSerializationSuite) TestEndpointBindings(c *gc.C) {
+	bindings := map[string]string{
+		"rel-name": "some-space",
+		"other":    "",
+	}
+	initial := minimalApplication()
+	initial.EndpointBindings_ = bindings
+
+	application := s.exportImport(c, initial)
+	c.Assert(application.EndpointBindings(), jc.DeepEquals, bindings)
+}
+
+func (s *ApplicationSerializationSuite) TestStorageConstraints(c *gc.C) {
+	initial := minimalApplication()
+	initial.StorageConstraints_ = map[string]*storageconstraint{
+		"data": newStorageConstraint(StorageConstraintArgs{Pool: "fast", Size: 2048, Count: 1}),
+		"logs": newStorageConstraint(StorageConstraintArgs{Pool: "loop", Size: 512, Count: 2}),
+	}
+
+	application := s.exportImport(c, initial)
+	c.Assert(application.StorageConstraints_, jc.DeepEquals, initial.StorageConstraints_)
+}
+
+func (s *ApplicationSerializationSuite) TestResources(c *gc.C) {
+	initial := minimalApplication()
+	r := initial.AddResource(ResourceArgs{Name: "data"})
+	r.SetApplicationRevision(testResourceRevisionArgs())
+	initial.Units()[0].AddResource(UnitResourceArgs{
+		Name:     "data",
+		Revision: testResourceRevisionArgs(),
+	})
+	c.Assert(initial.Validate(), jc.ErrorIsNil)
+
+	application := s.exportImport(c, initial)
+	c.Assert(application.Resources(), jc.DeepEquals, initial.Resources())
+	c.Assert(application.Units()[0].Resources(), jc.DeepEquals, initial.Units()[0].Resources())
+}
+
+func (s *ApplicationSerializationSuite) TestV1NoBindingsOrResources(c *gc.C) {
+	// Version 1 applications, and their units, have neither endpoint
+	// bindings nor resources.
+	source := minimalApplicationMap()
+	delete(source, "resources")
+	unitSource := minimalUnitMap()
+	delete(unitSource, "resources")
+	delete(unitSource, "payloads")
+	source["units"] = map[interface{}]interface{}{
+		"version": 1,
+		"units":   []interface{}{unitSource},
+	}
+	bytes, err := yaml.Marshal(map[string]interface{}{
+		"version":      1,
+		"applications": []interface{}{source},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	var data map[string]interface{}
+	err = yaml.Unmarshal(bytes, &data)
+	c.Assert(err, jc.ErrorIsNil)
+
+	applications, err := importApplications(data)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(applications[0].EndpointBindings(), gc.HasLen, 0)
+	c.Assert(applications[0].Resources(), gc.HasLen, 0)
+	c.Assert(applications[0].Units()[0].Resources(), gc.HasLen, 0)
+}
+
+func (s *ApplicationSerializationSuite) TestV2RequiresResources(c *gc.C) {
+	source := minimalApplicationMap()
+	delete(source, "resources")
+	bytes, err := yaml.Marshal(map[string]interface{}{
+		"version":      2,
+		"applications": []interface{}{source},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	var data map[string]interface{}
+	err = yaml.Unmarshal(bytes, &data)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = importApplications(data)
+	c.Assert(err, gc.ErrorMatches, "application 0: application v2 schema check failed: resources: expected map, got nothing")
+}
+
+func (s *ApplicationSerializationSuite) TestResourceValid(c *gc.C) {
+	application := minimalApplication()
+	application.AddResource(ResourceArgs{Name: "data"})
+
+	err := application.Validate()
+	c.Assert(err, gc.ErrorMatches, `application "ubuntu": resource "data" missing application revision not valid`)
+}
+
+func (s *ApplicationSerializationSuite) TestUnitResourceValid(c *gc.C) {
+	application := minimalApplication()
+	application.Units()[0].AddResource(UnitResourceArgs{
+		Name:     "data",
+		Revision: testResourceRevisionArgs(),
+	})
+
+	err := application.Validate()
+	c.Assert(err, gc.ErrorMatches, `unit "ubuntu/0" resource "data" not in application not valid`)
+}
+
 func (s *ApplicationSerializationSuite) TestLeaderValid(c *gc.C) {
 	args := minimalApplicationArgs()
 	args.Leader = "ubuntu/1"
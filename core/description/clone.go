@@ -0,0 +1,140 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+)
+
+// CloneArgs is an argument struct used to create a copy of a model
+// with Clone.
+type CloneArgs struct {
+	// UUID is the UUID of the new model.
+	UUID string
+
+	// Name is the name of the new model.
+	Name string
+}
+
+// Clone returns a copy of the model under a new identity. The copy can
+// be imported into the same controller as the original to create a new
+// model with the same topology and configuration.
+//
+// Everything that belongs to the cloud resources or agents of the
+// original model is left out of the copy. Machines have no instances
+// or addresses, volumes and filesystems are unprovisioned, and the
+// machine and unit agents have no credentials, so the new model
+// provisions its own machines and storage and its agents set their own
+// passwords. Actions, payloads, SSH host keys and link-layer devices
+// are dropped for the same reason. As its machines have no instances,
+// the copy doesn't pass Validate, which checks models exported for
+// migration.
+func Clone(m Model, args CloneArgs) (Model, error) {
+	if !names.IsValidModel(args.UUID) {
+		return nil, errors.NotValidf("model UUID %q", args.UUID)
+	}
+	if args.Name == "" {
+		return nil, errors.NotValidf("empty model name")
+	}
+
+	// The round trip makes a deep copy, so the original is untouched.
+	bytes, err := Serialize(m)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	copied, err := Deserialize(bytes)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	clone := copied.(*model)
+	clone.Config_["uuid"] = args.UUID
+	clone.Config_["name"] = args.Name
+
+	now := time.Now()
+	for _, m := range clone.Machines_.Machines_ {
+		resetMachine(m, now)
+	}
+	for _, a := range clone.Applications_.Applications_ {
+		for _, u := range a.Units_.Units_ {
+			resetUnit(u, now)
+		}
+	}
+	for _, v := range clone.Volumes_.Volumes_ {
+		resetVolume(v, now)
+	}
+	for _, f := range clone.Filesystems_.Filesystems_ {
+		resetFilesystem(f, now)
+	}
+	clone.LinkLayerDevices_.LinkLayerDevices_ = nil
+	clone.IPAddresses_.IPAddresses_ = nil
+	clone.Actions_.Actions_ = nil
+	clone.SSHHostKeys_.SSHHostKeys_ = nil
+	return clone, nil
+}
+
+// resetMachine makes the machine, and its containers, look as if they
+// had just been added.
+func resetMachine(m *machine, now time.Time) {
+	m.Nonce_ = ""
+	m.PasswordHash_ = ""
+	m.Instance_ = nil
+	m.ProviderAddresses_ = nil
+	m.MachineAddresses_ = nil
+	m.PreferredPublicAddress_ = nil
+	m.PreferredPrivateAddress_ = nil
+	m.SupportedContainers_ = nil
+	m.OpenedPorts_ = nil
+	m.Status_ = newStatus(StatusArgs{Value: "pending", Updated: now})
+	m.StatusHistory_ = newStatusHistory()
+	for _, container := range m.Containers_ {
+		resetMachine(container, now)
+	}
+}
+
+// resetUnit makes the unit look as if it had just been added, and is
+// waiting for its machine.
+func resetUnit(u *unit, now time.Time) {
+	u.PasswordHash_ = ""
+	u.AgentStatus_ = newStatus(StatusArgs{Value: "allocating", Updated: now})
+	u.AgentStatusHistory_ = newStatusHistory()
+	u.WorkloadStatus_ = newStatus(StatusArgs{
+		Value:   "waiting",
+		Message: "waiting for machine",
+		Updated: now,
+	})
+	u.WorkloadStatusHistory_ = newStatusHistory()
+	u.WorkloadVersion_ = ""
+	u.WorkloadVersionHistory_ = newStatusHistory()
+	u.UnitResources_.UnitResources_ = nil
+	u.Payloads_.Payloads_ = nil
+}
+
+func resetVolume(v *volume, now time.Time) {
+	v.Provisioned_ = false
+	v.HardwareID_ = ""
+	v.VolumeID_ = ""
+	v.Status_ = newStatus(StatusArgs{Value: "pending", Updated: now})
+	v.StatusHistory_ = newStatusHistory()
+	for _, a := range v.Attachments_.Attachments_ {
+		a.Provisioned_ = false
+		a.DeviceName_ = ""
+		a.DeviceLink_ = ""
+		a.BusAddress_ = ""
+	}
+}
+
+func resetFilesystem(f *filesystem, now time.Time) {
+	f.Provisioned_ = false
+	f.FilesystemID_ = ""
+	f.Status_ = newStatus(StatusArgs{Value: "pending", Updated: now})
+	f.StatusHistory_ = newStatusHistory()
+	// The mount point is kept; it becomes the requested location of
+	// the attachment.
+	for _, a := range f.Attachments_.Attachments_ {
+		a.Provisioned_ = false
+	}
+}
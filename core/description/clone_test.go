@@ -0,0 +1,259 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/testing"
+)
+
+type CloneSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&CloneSuite{})
+
+const cloneUUID = "bd3fae18-5ea1-4bc5-8837-45400cf1f8f6"
+
+// productionModel returns a model with a deployed application, storage
+// and the records of a running model.
+func (s *CloneSuite) productionModel() Model {
+	model := NewModel(ModelArgs{
+		Owner: names.NewUserTag("owner"),
+		Config: map[string]interface{}{
+			"name":      "production",
+			"uuid":      "aaaaaaaa-5ea1-4bc5-8837-45400cf1f8f6",
+			"logging":   "<root>=DEBUG",
+			"test-mode": true,
+		},
+		CloudRegion: "some-region",
+	})
+	machine := model.AddMachine(MachineArgs{
+		Id:           names.NewMachineTag("0"),
+		Nonce:        "a-nonce",
+		PasswordHash: "machine-hash",
+		Series:       "xenial",
+		Jobs:         []string{"host-units"},
+	})
+	machine.SetInstance(minimalCloudInstanceArgs())
+	machine.SetTools(minimalAgentToolsArgs())
+	machine.SetStatus(minimalStatusArgs())
+	machine.SetAddresses(
+		[]AddressArgs{{Value: "10.0.0.4", Type: "ipv4"}},
+		[]AddressArgs{{Value: "54.1.2.3", Type: "ipv4"}},
+	)
+	container := machine.AddContainer(MachineArgs{
+		Id:           names.NewMachineTag("0/lxd/0"),
+		Nonce:        "another-nonce",
+		PasswordHash: "container-hash",
+		Series:       "xenial",
+		Jobs:         []string{"host-units"},
+	})
+	container.SetInstance(CloudInstanceArgs{InstanceId: "juju-0-lxd-0"})
+	container.SetTools(minimalAgentToolsArgs())
+	container.SetStatus(minimalStatusArgs())
+
+	application := model.AddApplication(ApplicationArgs{
+		Tag:                names.NewApplicationTag("ubuntu"),
+		Series:             "xenial",
+		CharmURL:           "cs:xenial/ubuntu-8",
+		Settings:           map[string]interface{}{"hostname": "web"},
+		LeadershipSettings: map[string]interface{}{},
+	})
+	application.SetStatus(minimalStatusArgs())
+	unit := application.AddUnit(UnitArgs{
+		Tag:             names.NewUnitTag("ubuntu/0"),
+		Machine:         names.NewMachineTag("0"),
+		PasswordHash:    "unit-hash",
+		WorkloadVersion: "16.04",
+	})
+	unit.SetTools(minimalAgentToolsArgs())
+	unit.SetAgentStatus(minimalStatusArgs())
+	unit.SetWorkloadStatus(minimalStatusArgs())
+	unit.SetWorkloadStatusHistory([]StatusArgs{minimalStatusArgs()})
+	unit.AddPayload(PayloadArgs{
+		Name:  "db",
+		Type:  "docker",
+		RawID: "d34db33f",
+		State: "running",
+	})
+
+	model.AddStoragePool(StoragePoolArgs{
+		Name:     "fast",
+		Provider: "ebs",
+	})
+	model.AddStorage(StorageArgs{
+		Tag:         names.NewStorageTag("data/0"),
+		Kind:        "filesystem",
+		Owner:       names.NewUnitTag("ubuntu/0"),
+		Name:        "data",
+		Attachments: []names.UnitTag{names.NewUnitTag("ubuntu/0")},
+	})
+	volume := model.AddVolume(VolumeArgs{
+		Tag:         names.NewVolumeTag("0"),
+		Storage:     names.NewStorageTag("data/0"),
+		Provisioned: true,
+		Size:        1024,
+		Pool:        "fast",
+		HardwareID:  "hw-1",
+		VolumeID:    "vol-1234",
+	})
+	volume.SetStatus(minimalStatusArgs())
+	volume.AddAttachment(VolumeAttachmentArgs{
+		Machine:     names.NewMachineTag("0"),
+		Provisioned: true,
+		DeviceName:  "xvdf",
+	})
+	filesystem := model.AddFilesystem(FilesystemArgs{
+		Tag:          names.NewFilesystemTag("0/0"),
+		Storage:      names.NewStorageTag("data/0"),
+		Volume:       names.NewVolumeTag("0"),
+		Provisioned:  true,
+		Size:         1024,
+		Pool:         "fast",
+		FilesystemID: "fs-1234",
+	})
+	filesystem.SetStatus(minimalStatusArgs())
+	filesystem.AddAttachment(FilesystemAttachmentArgs{
+		Machine:     names.NewMachineTag("0"),
+		Provisioned: true,
+		MountPoint:  "/srv/data",
+	})
+
+	model.AddLinkLayerDevice(LinkLayerDeviceArgs{
+		Name:      "eth0",
+		MachineID: "0",
+		Type:      "ethernet",
+		IsUp:      true,
+	})
+	model.AddSSHHostKey(SSHHostKeyArgs{
+		MachineID: "0",
+		Keys:      []string{"rsa-key"},
+	})
+	return model
+}
+
+func (s *CloneSuite) clone(c *gc.C) (Model, Model) {
+	original := s.productionModel()
+	c.Assert(original.Validate(), jc.ErrorIsNil)
+	clone, err := Clone(original, CloneArgs{UUID: cloneUUID, Name: "staging"})
+	c.Assert(err, jc.ErrorIsNil)
+	return original, clone
+}
+
+func (s *CloneSuite) TestIdentity(c *gc.C) {
+	original, clone := s.clone(c)
+	c.Check(clone.Tag(), gc.Equals, names.NewModelTag(cloneUUID))
+	c.Check(clone.Config(), jc.DeepEquals, map[string]interface{}{
+		"name":      "staging",
+		"uuid":      cloneUUID,
+		"logging":   "<root>=DEBUG",
+		"test-mode": true,
+	})
+	c.Check(clone.Owner(), gc.Equals, original.Owner())
+	c.Check(clone.CloudRegion(), gc.Equals, "some-region")
+}
+
+func (s *CloneSuite) TestOriginalUntouched(c *gc.C) {
+	original, _ := s.clone(c)
+	c.Check(original.Tag().Id(), gc.Equals, "aaaaaaaa-5ea1-4bc5-8837-45400cf1f8f6")
+	c.Check(original.Machines()[0].Instance(), gc.NotNil)
+	c.Check(original.Applications()[0].Units()[0].PasswordHash(), gc.Equals, "unit-hash")
+	c.Check(original.LinkLayerDevices(), gc.HasLen, 1)
+}
+
+func (s *CloneSuite) TestMachinesReset(c *gc.C) {
+	_, clone := s.clone(c)
+	machines := clone.Machines()
+	c.Assert(machines, gc.HasLen, 1)
+	machine := machines[0]
+	c.Check(machine.Id(), gc.Equals, "0")
+	c.Check(machine.Series(), gc.Equals, "xenial")
+	c.Check(machine.Instance(), gc.IsNil)
+	c.Check(machine.Nonce(), gc.Equals, "")
+	c.Check(machine.PasswordHash(), gc.Equals, "")
+	c.Check(machine.ProviderAddresses(), gc.HasLen, 0)
+	c.Check(machine.MachineAddresses(), gc.HasLen, 0)
+	c.Check(machine.Status().Value(), gc.Equals, "pending")
+	c.Check(machine.StatusHistory(), gc.HasLen, 0)
+
+	containers := machine.Containers()
+	c.Assert(containers, gc.HasLen, 1)
+	c.Check(containers[0].Id(), gc.Equals, "0/lxd/0")
+	c.Check(containers[0].Instance(), gc.IsNil)
+	c.Check(containers[0].PasswordHash(), gc.Equals, "")
+	c.Check(containers[0].Status().Value(), gc.Equals, "pending")
+}
+
+func (s *CloneSuite) TestUnitsReset(c *gc.C) {
+	_, clone := s.clone(c)
+	application := clone.Applications()[0]
+	c.Check(application.CharmURL(), gc.Equals, "cs:xenial/ubuntu-8")
+	c.Check(application.Settings(), jc.DeepEquals, map[string]interface{}{"hostname": "web"})
+
+	unit := application.Units()[0]
+	c.Check(unit.Name(), gc.Equals, "ubuntu/0")
+	c.Check(unit.Machine(), gc.Equals, names.NewMachineTag("0"))
+	c.Check(unit.PasswordHash(), gc.Equals, "")
+	c.Check(unit.AgentStatus().Value(), gc.Equals, "allocating")
+	c.Check(unit.WorkloadStatus().Value(), gc.Equals, "waiting")
+	c.Check(unit.WorkloadStatus().Message(), gc.Equals, "waiting for machine")
+	c.Check(unit.WorkloadStatusHistory(), gc.HasLen, 0)
+	c.Check(unit.WorkloadVersion(), gc.Equals, "")
+	c.Check(unit.Payloads(), gc.HasLen, 0)
+}
+
+func (s *CloneSuite) TestStorageUnprovisioned(c *gc.C) {
+	_, clone := s.clone(c)
+	c.Check(clone.Storages(), gc.HasLen, 1)
+	c.Check(clone.StoragePools(), gc.HasLen, 1)
+
+	volume := clone.Volumes()[0]
+	c.Check(volume.Provisioned(), jc.IsFalse)
+	c.Check(volume.Size(), gc.Equals, uint64(1024))
+	c.Check(volume.Pool(), gc.Equals, "fast")
+	c.Check(volume.VolumeID(), gc.Equals, "")
+	c.Check(volume.HardwareID(), gc.Equals, "")
+	c.Check(volume.Status().Value(), gc.Equals, "pending")
+	volumeAttachment := volume.Attachments()[0]
+	c.Check(volumeAttachment.Provisioned(), jc.IsFalse)
+	c.Check(volumeAttachment.DeviceName(), gc.Equals, "")
+
+	filesystem := clone.Filesystems()[0]
+	c.Check(filesystem.Provisioned(), jc.IsFalse)
+	c.Check(filesystem.FilesystemID(), gc.Equals, "")
+	filesystemAttachment := filesystem.Attachments()[0]
+	c.Check(filesystemAttachment.Provisioned(), jc.IsFalse)
+	c.Check(filesystemAttachment.MountPoint(), gc.Equals, "/srv/data")
+}
+
+func (s *CloneSuite) TestInstanceRecordsDropped(c *gc.C) {
+	_, clone := s.clone(c)
+	c.Check(clone.LinkLayerDevices(), gc.HasLen, 0)
+	c.Check(clone.IPAddresses(), gc.HasLen, 0)
+	c.Check(clone.SSHHostKeys(), gc.HasLen, 0)
+	c.Check(clone.Actions(), gc.HasLen, 0)
+}
+
+func (s *CloneSuite) TestCloneSerializes(c *gc.C) {
+	_, clone := s.clone(c)
+	bytes, err := Serialize(clone)
+	c.Assert(err, jc.ErrorIsNil)
+	model, err := Deserialize(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(model.Tag(), gc.Equals, names.NewModelTag(cloneUUID))
+}
+
+func (s *CloneSuite) TestInvalidUUID(c *gc.C) {
+	_, err := Clone(s.productionModel(), CloneArgs{UUID: "foo", Name: "staging"})
+	c.Check(err, gc.ErrorMatches, `model UUID "foo" not valid`)
+}
+
+func (s *CloneSuite) TestMissingName(c *gc.C) {
+	_, err := Clone(s.productionModel(), CloneArgs{UUID: cloneUUID})
+	c.Check(err, gc.ErrorMatches, "empty model name not valid")
+}
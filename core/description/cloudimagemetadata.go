@@ -0,0 +1,210 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+)
+
+type cloudImageMetadataSet struct {
+	Version             int                   `yaml:"version"`
+	CloudImageMetadata_ []*cloudImageMetadata `yaml:"cloud-image-metadata"`
+}
+
+type cloudImageMetadata struct {
+	Stream_          string `yaml:"stream"`
+	Region_          string `yaml:"region"`
+	Version_         string `yaml:"version"`
+	Series_          string `yaml:"series"`
+	Arch_            string `yaml:"arch"`
+	VirtType_        string `yaml:"virt-type,omitempty"`
+	RootStorageType_ string `yaml:"root-storage-type,omitempty"`
+	// RootStorageSize_ is a pointer as zero is distinct from unknown.
+	RootStorageSize_ *uint64 `yaml:"root-storage-size,omitempty"`
+	Source_          string  `yaml:"source"`
+	Priority_        int     `yaml:"priority"`
+	ImageId_         string  `yaml:"image-id"`
+}
+
+// CloudImageMetadataArgs is an argument struct used to add cloud image
+// metadata to the Model.
+type CloudImageMetadataArgs struct {
+	Stream          string
+	Region          string
+	Version         string
+	Series          string
+	Arch            string
+	VirtType        string
+	RootStorageType string
+	RootStorageSize *uint64
+	Source          string
+	Priority        int
+	ImageId         string
+}
+
+func newCloudImageMetadata(args CloudImageMetadataArgs) *cloudImageMetadata {
+	m := &cloudImageMetadata{
+		Stream_:          args.Stream,
+		Region_:          args.Region,
+		Version_:         args.Version,
+		Series_:          args.Series,
+		Arch_:            args.Arch,
+		VirtType_:        args.VirtType,
+		RootStorageType_: args.RootStorageType,
+		Source_:          args.Source,
+		Priority_:        args.Priority,
+		ImageId_:         args.ImageId,
+	}
+	if args.RootStorageSize != nil {
+		size := *args.RootStorageSize
+		m.RootStorageSize_ = &size
+	}
+	return m
+}
+
+// Stream implements CloudImageMetadata.
+func (m *cloudImageMetadata) Stream() string {
+	return m.Stream_
+}
+
+// Region implements CloudImageMetadata.
+func (m *cloudImageMetadata) Region() string {
+	return m.Region_
+}
+
+// Version implements CloudImageMetadata.
+func (m *cloudImageMetadata) Version() string {
+	return m.Version_
+}
+
+// Series implements CloudImageMetadata.
+func (m *cloudImageMetadata) Series() string {
+	return m.Series_
+}
+
+// Arch implements CloudImageMetadata.
+func (m *cloudImageMetadata) Arch() string {
+	return m.Arch_
+}
+
+// VirtType implements CloudImageMetadata.
+func (m *cloudImageMetadata) VirtType() string {
+	return m.VirtType_
+}
+
+// RootStorageType implements CloudImageMetadata.
+func (m *cloudImageMetadata) RootStorageType() string {
+	return m.RootStorageType_
+}
+
+// RootStorageSize implements CloudImageMetadata.
+func (m *cloudImageMetadata) RootStorageSize() (uint64, bool) {
+	if m.RootStorageSize_ == nil {
+		return 0, false
+	}
+	return *m.RootStorageSize_, true
+}
+
+// Source implements CloudImageMetadata.
+func (m *cloudImageMetadata) Source() string {
+	return m.Source_
+}
+
+// Priority implements CloudImageMetadata.
+func (m *cloudImageMetadata) Priority() int {
+	return m.Priority_
+}
+
+// ImageId implements CloudImageMetadata.
+func (m *cloudImageMetadata) ImageId() string {
+	return m.ImageId_
+}
+
+func importCloudImageMetadata(source map[string]interface{}) ([]*cloudImageMetadata, error) {
+	checker := versionedChecker("cloud-image-metadata")
+	coerced, err := checker.Coerce(source, nil)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cloud-image-metadata version schema check failed")
+	}
+	valid := coerced.(map[string]interface{})
+
+	version := int(valid["version"].(int64))
+	importFunc, ok := cloudImageMetadataDeserializationFuncs[version]
+	if !ok {
+		return nil, errors.NotValidf("version %d", version)
+	}
+	sourceList := valid["cloud-image-metadata"].([]interface{})
+	return importCloudImageMetadataList(sourceList, importFunc)
+}
+
+func importCloudImageMetadataList(sourceList []interface{}, importFunc cloudImageMetadataDeserializationFunc) ([]*cloudImageMetadata, error) {
+	result := make([]*cloudImageMetadata, 0, len(sourceList))
+	for i, value := range sourceList {
+		source, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, errors.Errorf("unexpected value for cloud-image-metadata %d, %T", i, value)
+		}
+		metadata, err := importFunc(source)
+		if err != nil {
+			return nil, errors.Annotatef(err, "cloud-image-metadata %d", i)
+		}
+		result = append(result, metadata)
+	}
+	return result, nil
+}
+
+type cloudImageMetadataDeserializationFunc func(map[string]interface{}) (*cloudImageMetadata, error)
+
+var cloudImageMetadataDeserializationFuncs = map[int]cloudImageMetadataDeserializationFunc{
+	1: importCloudImageMetadataV1,
+}
+
+func importCloudImageMetadataV1(source map[string]interface{}) (*cloudImageMetadata, error) {
+	fields := schema.Fields{
+		"stream":            schema.String(),
+		"region":            schema.String(),
+		"version":           schema.String(),
+		"series":            schema.String(),
+		"arch":              schema.String(),
+		"virt-type":         schema.String(),
+		"root-storage-type": schema.String(),
+		"root-storage-size": schema.Uint(),
+		"source":            schema.String(),
+		"priority":          schema.Int(),
+		"image-id":          schema.String(),
+	}
+	defaults := schema.Defaults{
+		"virt-type":         "",
+		"root-storage-type": "",
+		"root-storage-size": schema.Omit,
+	}
+	checker := schema.FieldMap(fields, defaults)
+
+	coerced, err := checker.Coerce(source, nil)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cloud-image-metadata v1 schema check failed")
+	}
+	valid := coerced.(map[string]interface{})
+	// From here we know that the map returned from the schema coercion
+	// contains fields of the right type.
+
+	result := &cloudImageMetadata{
+		Stream_:          valid["stream"].(string),
+		Region_:          valid["region"].(string),
+		Version_:         valid["version"].(string),
+		Series_:          valid["series"].(string),
+		Arch_:            valid["arch"].(string),
+		VirtType_:        valid["virt-type"].(string),
+		RootStorageType_: valid["root-storage-type"].(string),
+		Source_:          valid["source"].(string),
+		Priority_:        int(valid["priority"].(int64)),
+		ImageId_:         valid["image-id"].(string),
+	}
+	if size, ok := valid["root-storage-size"]; ok {
+		rootStorageSize := size.(uint64)
+		result.RootStorageSize_ = &rootStorageSize
+	}
+	return result, nil
+}
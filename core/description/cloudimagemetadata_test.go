@@ -0,0 +1,97 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/yaml.v2"
+)
+
+type CloudImageMetadataSerializationSuite struct {
+	SliceSerializationSuite
+}
+
+var _ = gc.Suite(&CloudImageMetadataSerializationSuite{})
+
+func (s *CloudImageMetadataSerializationSuite) SetUpTest(c *gc.C) {
+	s.SliceSerializationSuite.SetUpTest(c)
+	s.importName = "cloud-image-metadata"
+	s.sliceName = "cloud-image-metadata"
+	s.importFunc = func(m map[string]interface{}) (interface{}, error) {
+		return importCloudImageMetadata(m)
+	}
+	s.testFields = func(m map[string]interface{}) {
+		m["cloud-image-metadata"] = []interface{}{}
+	}
+}
+
+func cloudImageMetadataArgs() CloudImageMetadataArgs {
+	size := uint64(8192)
+	return CloudImageMetadataArgs{
+		Stream:          "released",
+		Region:          "us-east-1",
+		Version:         "16.04",
+		Series:          "xenial",
+		Arch:            "amd64",
+		VirtType:        "hvm",
+		RootStorageType: "ebs",
+		RootStorageSize: &size,
+		Source:          "custom",
+		Priority:        50,
+		ImageId:         "ami-1234",
+	}
+}
+
+func (s *CloudImageMetadataSerializationSuite) TestNewCloudImageMetadata(c *gc.C) {
+	args := cloudImageMetadataArgs()
+	m := newCloudImageMetadata(args)
+
+	c.Check(m.Stream(), gc.Equals, args.Stream)
+	c.Check(m.Region(), gc.Equals, args.Region)
+	c.Check(m.Version(), gc.Equals, args.Version)
+	c.Check(m.Series(), gc.Equals, args.Series)
+	c.Check(m.Arch(), gc.Equals, args.Arch)
+	c.Check(m.VirtType(), gc.Equals, args.VirtType)
+	c.Check(m.RootStorageType(), gc.Equals, args.RootStorageType)
+	size, ok := m.RootStorageSize()
+	c.Check(ok, jc.IsTrue)
+	c.Check(size, gc.Equals, uint64(8192))
+	c.Check(m.Source(), gc.Equals, args.Source)
+	c.Check(m.Priority(), gc.Equals, args.Priority)
+	c.Check(m.ImageId(), gc.Equals, args.ImageId)
+}
+
+func (s *CloudImageMetadataSerializationSuite) TestUnknownRootStorageSize(c *gc.C) {
+	args := cloudImageMetadataArgs()
+	args.RootStorageSize = nil
+	m := newCloudImageMetadata(args)
+	_, ok := m.RootStorageSize()
+	c.Check(ok, jc.IsFalse)
+}
+
+func (s *CloudImageMetadataSerializationSuite) TestParsingSerializedData(c *gc.C) {
+	args := cloudImageMetadataArgs()
+	args.RootStorageSize = nil
+	args.VirtType = ""
+	initial := cloudImageMetadataSet{
+		Version: 1,
+		CloudImageMetadata_: []*cloudImageMetadata{
+			newCloudImageMetadata(cloudImageMetadataArgs()),
+			newCloudImageMetadata(args),
+		},
+	}
+
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var source map[string]interface{}
+	err = yaml.Unmarshal(bytes, &source)
+	c.Assert(err, jc.ErrorIsNil)
+
+	metadata, err := importCloudImageMetadata(source)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(metadata, jc.DeepEquals, initial.CloudImageMetadata_)
+}
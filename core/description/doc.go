@@ -4,6 +4,10 @@
 // The description package defines the structure and representation and
 // serialisation of models to facilitate the import and export of
 // models from different controllers.
+//
+// The model and each of its sections carry a format version, and every
+// version that has been written can still be read, so a controller can
+// import models exported by controllers running older versions of Juju.
 package description
 
 // NOTES:
@@ -63,6 +63,39 @@ type Model interface {
 	Relations() []Relation
 	AddRelation(RelationArgs) Relation
 
+	Storages() []Storage
+	AddStorage(StorageArgs) Storage
+
+	StoragePools() []StoragePool
+	AddStoragePool(StoragePoolArgs) StoragePool
+
+	Volumes() []Volume
+	AddVolume(VolumeArgs) Volume
+
+	Filesystems() []Filesystem
+	AddFilesystem(FilesystemArgs) Filesystem
+
+	Spaces() []Space
+	AddSpace(SpaceArgs) Space
+
+	Subnets() []Subnet
+	AddSubnet(SubnetArgs) Subnet
+
+	LinkLayerDevices() []LinkLayerDevice
+	AddLinkLayerDevice(LinkLayerDeviceArgs) LinkLayerDevice
+
+	IPAddresses() []IPAddress
+	AddIPAddress(IPAddressArgs) IPAddress
+
+	Actions() []Action
+	AddAction(ActionArgs) Action
+
+	SSHHostKeys() []SSHHostKey
+	AddSSHHostKey(SSHHostKeyArgs) SSHHostKey
+
+	CloudImageMetadata() []CloudImageMetadata
+	AddCloudImageMetadata(CloudImageMetadataArgs) CloudImageMetadata
+
 	Sequences() map[string]int
 	SetSequence(name string, value int)
 
@@ -231,12 +264,23 @@ type Application interface {
 
 	MetricsCredentials() []byte
 
+	// EndpointBindings returns the name of the space that each of the
+	// application's endpoints is bound to.
+	EndpointBindings() map[string]string
+
+	// StorageConstraints returns the constraints for the storage of
+	// the application's units, keyed by storage name.
+	StorageConstraints() map[string]StorageConstraint
+
 	Status() Status
 	SetStatus(StatusArgs)
 
 	Units() []Unit
 	AddUnit(UnitArgs) Unit
 
+	Resources() []Resource
+	AddResource(ResourceArgs) Resource
+
 	Validate() error
 }
 
@@ -279,6 +323,12 @@ type Unit interface {
 	AgentStatusHistory() []Status
 	SetAgentStatusHistory([]StatusArgs)
 
+	Resources() []UnitResource
+	AddResource(UnitResourceArgs) UnitResource
+
+	Payloads() []Payload
+	AddPayload(PayloadArgs) Payload
+
 	Validate() error
 }
 
@@ -314,3 +364,261 @@ type Endpoint interface {
 	Settings(unitName string) map[string]interface{}
 	SetUnitSettings(unitName string, settings map[string]interface{})
 }
+
+// Storage represents the state of a unit or application-wide storage
+// instance in the model.
+type Storage interface {
+	Tag() names.StorageTag
+	Kind() string
+	// Owner returns the tag of the application or unit that owns this
+	// storage instance.
+	Owner() (names.Tag, error)
+	Name() string
+	// CharmURL returns the URL of the charm the storage instance was
+	// created with.
+	CharmURL() string
+
+	Attachments() []names.UnitTag
+
+	Validate() error
+}
+
+// StorageConstraint represents the user-specified constraints for
+// provisioning storage instances for an application unit.
+type StorageConstraint interface {
+	// Pool is the name of the storage pool from which to provision the
+	// storage instances.
+	Pool() string
+	// Size is the required size of the storage instances, in MiB.
+	Size() uint64
+	// Count is the required number of storage instances.
+	Count() uint64
+}
+
+// StoragePool represents a named storage pool and its settings.
+type StoragePool interface {
+	Name() string
+	Provider() string
+	Attributes() map[string]interface{}
+}
+
+// Volume represents a volume (disk, logical volume, etc.) in the model.
+type Volume interface {
+	HasStatusHistory
+
+	Tag() names.VolumeTag
+	Storage() names.StorageTag
+	// Binding returns the tag of the entity that the life of the volume
+	// is bound to, if any.
+	Binding() (names.Tag, error)
+
+	// Provisioned reports whether the volume has been created by the
+	// provider. The size and pool of unprovisioned volumes are those
+	// requested.
+	Provisioned() bool
+
+	Size() uint64
+	Pool() string
+
+	HardwareID() string
+	VolumeID() string
+	Persistent() bool
+	Encrypted() bool
+
+	Status() Status
+	SetStatus(StatusArgs)
+
+	Attachments() []VolumeAttachment
+	AddAttachment(VolumeAttachmentArgs) VolumeAttachment
+
+	Validate() error
+}
+
+// VolumeAttachment represents a volume attached to a machine.
+type VolumeAttachment interface {
+	Machine() names.MachineTag
+	Provisioned() bool
+	ReadOnly() bool
+	DeviceName() string
+	DeviceLink() string
+	BusAddress() string
+}
+
+// Filesystem represents a filesystem in the model.
+type Filesystem interface {
+	HasStatusHistory
+
+	Tag() names.FilesystemTag
+	Volume() names.VolumeTag
+	Storage() names.StorageTag
+	// Binding returns the tag of the entity that the life of the
+	// filesystem is bound to, if any.
+	Binding() (names.Tag, error)
+
+	// Provisioned reports whether the filesystem has been created by the
+	// provider. The size and pool of unprovisioned filesystems are those
+	// requested.
+	Provisioned() bool
+
+	Size() uint64
+	Pool() string
+
+	FilesystemID() string
+	Encrypted() bool
+
+	Status() Status
+	SetStatus(StatusArgs)
+
+	Attachments() []FilesystemAttachment
+	AddAttachment(FilesystemAttachmentArgs) FilesystemAttachment
+
+	Validate() error
+}
+
+// FilesystemAttachment represents a filesystem attached to a machine.
+type FilesystemAttachment interface {
+	Machine() names.MachineTag
+	Provisioned() bool
+	MountPoint() string
+	ReadOnly() bool
+}
+
+// Space represents a network space, which is a named collection of
+// subnets.
+type Space interface {
+	Name() string
+	Public() bool
+	ProviderID() string
+}
+
+// Subnet represents a network subnet known to the model.
+type Subnet interface {
+	CIDR() string
+	ProviderID() string
+	VLANTag() int
+	AvailabilityZone() string
+	// SpaceName returns the name of the space the subnet is in, or an
+	// empty string if the subnet is not in a space.
+	SpaceName() string
+}
+
+// LinkLayerDevice represents a network device on a machine.
+type LinkLayerDevice interface {
+	Name() string
+	MTU() uint
+	ProviderID() string
+	MachineID() string
+	Type() string
+	MACAddress() string
+	IsAutoStart() bool
+	IsUp() bool
+	// ParentName returns the name of the parent device on the same
+	// machine, or the global key of a bridge device on the host machine
+	// of a container.
+	ParentName() string
+}
+
+// IPAddress represents an IP address assigned to a link-layer device.
+type IPAddress interface {
+	ProviderID() string
+	DeviceName() string
+	MachineID() string
+	SubnetCIDR() string
+	ConfigMethod() string
+	Value() string
+	DNSServers() []string
+	DNSSearchDomains() []string
+	GatewayAddress() string
+}
+
+// Resource represents a resource of an application.
+type Resource interface {
+	Name() string
+
+	// ApplicationRevision returns the revision of the resource that
+	// the application uses.
+	ApplicationRevision() ResourceRevision
+	SetApplicationRevision(ResourceRevisionArgs) ResourceRevision
+
+	// CharmStoreRevision returns the latest revision of the resource
+	// known to the charm store, or nil if it isn't known.
+	CharmStoreRevision() ResourceRevision
+	SetCharmStoreRevision(ResourceRevisionArgs) ResourceRevision
+
+	Validate() error
+}
+
+// ResourceRevision represents a specific revision of a resource.
+type ResourceRevision interface {
+	Revision() int
+	Type() string
+	Path() string
+	Description() string
+	Origin() string
+	FingerprintHex() string
+	Size() int64
+	// Timestamp returns when the resource was added, or the zero time
+	// if the resource has not been uploaded yet.
+	Timestamp() time.Time
+	Username() string
+}
+
+// UnitResource represents the revision of a resource that a unit is
+// using.
+type UnitResource interface {
+	Name() string
+	Revision() ResourceRevision
+}
+
+// Payload represents a workload payload registered by a unit.
+type Payload interface {
+	Name() string
+	Type() string
+	RawID() string
+	State() string
+	Labels() []string
+}
+
+// Action represents an action queued for, running on, or completed by
+// a unit or machine.
+type Action interface {
+	Id() string
+	// Receiver is the name of the unit or the id of the machine that
+	// the action runs on.
+	Receiver() string
+	Name() string
+	Parameters() map[string]interface{}
+	Enqueued() time.Time
+	// Started returns the zero time if the action hasn't started.
+	Started() time.Time
+	// Completed returns the zero time if the action hasn't completed.
+	Completed() time.Time
+	Status() string
+	Message() string
+	Results() map[string]interface{}
+
+	Validate() error
+}
+
+// SSHHostKey represents the SSH host keys reported by a machine.
+type SSHHostKey interface {
+	MachineID() string
+	Keys() []string
+}
+
+// CloudImageMetadata represents cloud image metadata that has been
+// recorded for the model.
+type CloudImageMetadata interface {
+	Stream() string
+	Region() string
+	Version() string
+	Series() string
+	Arch() string
+	VirtType() string
+	RootStorageType() string
+	// RootStorageSize returns false if the size isn't known.
+	RootStorageSize() (uint64, bool)
+	Source() string
+	Priority() int
+	ImageId() string
+}
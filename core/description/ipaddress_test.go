@@ -0,0 +1,90 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/yaml.v2"
+)
+
+type IPAddressSerializationSuite struct {
+	SliceSerializationSuite
+}
+
+var _ = gc.Suite(&IPAddressSerializationSuite{})
+
+func (s *IPAddressSerializationSuite) SetUpTest(c *gc.C) {
+	s.SliceSerializationSuite.SetUpTest(c)
+	s.importName = "ip addresses"
+	s.sliceName = "ip-addresses"
+	s.importFunc = func(m map[string]interface{}) (interface{}, error) {
+		return importIPAddresses(m)
+	}
+	s.testFields = func(m map[string]interface{}) {
+		m["ip-addresses"] = []interface{}{}
+	}
+}
+
+func (s *IPAddressSerializationSuite) TestNewIPAddress(c *gc.C) {
+	address := newIPAddress(IPAddressArgs{
+		ProviderID:       "magic",
+		DeviceName:       "eth0",
+		MachineID:        "42",
+		SubnetCIDR:       "10.0.0.0/24",
+		ConfigMethod:     "static",
+		Value:            "10.0.0.4",
+		DNSServers:       []string{"10.1.0.1", "10.2.0.1"},
+		DNSSearchDomains: []string{"bam", "mam"},
+		GatewayAddress:   "10.0.0.1",
+	})
+
+	c.Check(address.ProviderID(), gc.Equals, "magic")
+	c.Check(address.DeviceName(), gc.Equals, "eth0")
+	c.Check(address.MachineID(), gc.Equals, "42")
+	c.Check(address.SubnetCIDR(), gc.Equals, "10.0.0.0/24")
+	c.Check(address.ConfigMethod(), gc.Equals, "static")
+	c.Check(address.Value(), gc.Equals, "10.0.0.4")
+	c.Check(address.DNSServers(), jc.DeepEquals, []string{"10.1.0.1", "10.2.0.1"})
+	c.Check(address.DNSSearchDomains(), jc.DeepEquals, []string{"bam", "mam"})
+	c.Check(address.GatewayAddress(), gc.Equals, "10.0.0.1")
+}
+
+func (s *IPAddressSerializationSuite) TestParsingSerializedData(c *gc.C) {
+	initial := ipaddresses{
+		Version: 1,
+		IPAddresses_: []*ipaddress{
+			newIPAddress(IPAddressArgs{
+				ProviderID:       "magic",
+				DeviceName:       "eth0",
+				MachineID:        "42",
+				SubnetCIDR:       "10.0.0.0/24",
+				ConfigMethod:     "static",
+				Value:            "10.0.0.4",
+				DNSServers:       []string{"10.1.0.1", "10.2.0.1"},
+				DNSSearchDomains: []string{"bam", "mam"},
+				GatewayAddress:   "10.0.0.1",
+			}),
+			newIPAddress(IPAddressArgs{
+				DeviceName:   "lo",
+				MachineID:    "42",
+				SubnetCIDR:   "127.0.0.0/8",
+				ConfigMethod: "loopback",
+				Value:        "127.0.0.1",
+			}),
+		},
+	}
+
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var source map[string]interface{}
+	err = yaml.Unmarshal(bytes, &source)
+	c.Assert(err, jc.ErrorIsNil)
+
+	addresses, err := importIPAddresses(source)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(addresses, jc.DeepEquals, initial.IPAddresses_)
+}
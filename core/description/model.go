@@ -29,7 +29,7 @@ type ModelArgs struct {
 // NewModel returns a Model based on the args specified.
 func NewModel(args ModelArgs) Model {
 	m := &model{
-		Version:             1,
+		Version:             currentModelVersion,
 		Owner_:              args.Owner.Id(),
 		Config_:             args.Config,
 		LatestToolsVersion_: args.LatestToolsVersion,
@@ -43,6 +43,17 @@ func NewModel(args ModelArgs) Model {
 	m.setMachines(nil)
 	m.setApplications(nil)
 	m.setRelations(nil)
+	m.setStorages(nil)
+	m.setStoragePools(nil)
+	m.setVolumes(nil)
+	m.setFilesystems(nil)
+	m.setSpaces(nil)
+	m.setSubnets(nil)
+	m.setLinkLayerDevices(nil)
+	m.setIPAddresses(nil)
+	m.setActions(nil)
+	m.setSSHHostKeys(nil)
+	m.setCloudImageMetadata(nil)
 	return m
 }
 
@@ -82,6 +93,19 @@ type model struct {
 	Machines_     machines     `yaml:"machines"`
 	Applications_ applications `yaml:"applications"`
 	Relations_    relations    `yaml:"relations"`
+	Storages_     storages     `yaml:"storages"`
+	StoragePools_ storagepools `yaml:"storage-pools"`
+	Volumes_      volumes      `yaml:"volumes"`
+	Filesystems_  filesystems  `yaml:"filesystems"`
+
+	Spaces_           spaces           `yaml:"spaces"`
+	Subnets_          subnets          `yaml:"subnets"`
+	LinkLayerDevices_ linklayerdevices `yaml:"link-layer-devices"`
+	IPAddresses_      ipaddresses      `yaml:"ip-addresses"`
+
+	Actions_            actions               `yaml:"actions"`
+	SSHHostKeys_        sshHostKeys           `yaml:"ssh-host-keys"`
+	CloudImageMetadata_ cloudImageMetadataSet `yaml:"cloud-image-metadata"`
 
 	Sequences_ map[string]int `yaml:"sequences"`
 
@@ -92,10 +116,6 @@ type model struct {
 	Cloud_           string `yaml:"cloud"`
 	CloudRegion_     string `yaml:"cloud-region,omitempty"`
 	CloudCredential_ string `yaml:"cloud-credential,omitempty"`
-
-	// TODO:
-	// Spaces
-	// Storage
 }
 
 func (m *model) Tag() names.ModelTag {
@@ -216,7 +236,7 @@ func (m *model) AddApplication(args ApplicationArgs) Application {
 
 func (m *model) setApplications(applicationList []*application) {
 	m.Applications_ = applications{
-		Version:       1,
+		Version:       2,
 		Applications_: applicationList,
 	}
 }
@@ -244,6 +264,259 @@ func (m *model) setRelations(relationList []*relation) {
 	}
 }
 
+// Storages implements Model.
+func (m *model) Storages() []Storage {
+	var result []Storage
+	for _, storage := range m.Storages_.Storages_ {
+		result = append(result, storage)
+	}
+	return result
+}
+
+// AddStorage implements Model.
+func (m *model) AddStorage(args StorageArgs) Storage {
+	storage := newStorage(args)
+	m.Storages_.Storages_ = append(m.Storages_.Storages_, storage)
+	return storage
+}
+
+func (m *model) setStorages(storageList []*storage) {
+	m.Storages_ = storages{
+		Version:   1,
+		Storages_: storageList,
+	}
+}
+
+// StoragePools implements Model.
+func (m *model) StoragePools() []StoragePool {
+	var result []StoragePool
+	for _, pool := range m.StoragePools_.Pools_ {
+		result = append(result, pool)
+	}
+	return result
+}
+
+// AddStoragePool implements Model.
+func (m *model) AddStoragePool(args StoragePoolArgs) StoragePool {
+	pool := newStoragePool(args)
+	m.StoragePools_.Pools_ = append(m.StoragePools_.Pools_, pool)
+	return pool
+}
+
+func (m *model) setStoragePools(poolList []*storagepool) {
+	m.StoragePools_ = storagepools{
+		Version: 1,
+		Pools_:  poolList,
+	}
+}
+
+// Volumes implements Model.
+func (m *model) Volumes() []Volume {
+	var result []Volume
+	for _, volume := range m.Volumes_.Volumes_ {
+		result = append(result, volume)
+	}
+	return result
+}
+
+// AddVolume implements Model.
+func (m *model) AddVolume(args VolumeArgs) Volume {
+	volume := newVolume(args)
+	m.Volumes_.Volumes_ = append(m.Volumes_.Volumes_, volume)
+	return volume
+}
+
+func (m *model) setVolumes(volumeList []*volume) {
+	m.Volumes_ = volumes{
+		Version:  1,
+		Volumes_: volumeList,
+	}
+}
+
+// Filesystems implements Model.
+func (m *model) Filesystems() []Filesystem {
+	var result []Filesystem
+	for _, filesystem := range m.Filesystems_.Filesystems_ {
+		result = append(result, filesystem)
+	}
+	return result
+}
+
+// AddFilesystem implements Model.
+func (m *model) AddFilesystem(args FilesystemArgs) Filesystem {
+	filesystem := newFilesystem(args)
+	m.Filesystems_.Filesystems_ = append(m.Filesystems_.Filesystems_, filesystem)
+	return filesystem
+}
+
+func (m *model) setFilesystems(filesystemList []*filesystem) {
+	m.Filesystems_ = filesystems{
+		Version:      1,
+		Filesystems_: filesystemList,
+	}
+}
+
+// Spaces implements Model.
+func (m *model) Spaces() []Space {
+	var result []Space
+	for _, space := range m.Spaces_.Spaces_ {
+		result = append(result, space)
+	}
+	return result
+}
+
+// AddSpace implements Model.
+func (m *model) AddSpace(args SpaceArgs) Space {
+	space := newSpace(args)
+	m.Spaces_.Spaces_ = append(m.Spaces_.Spaces_, space)
+	return space
+}
+
+func (m *model) setSpaces(spaceList []*space) {
+	m.Spaces_ = spaces{
+		Version: 1,
+		Spaces_: spaceList,
+	}
+}
+
+// Subnets implements Model.
+func (m *model) Subnets() []Subnet {
+	var result []Subnet
+	for _, subnet := range m.Subnets_.Subnets_ {
+		result = append(result, subnet)
+	}
+	return result
+}
+
+// AddSubnet implements Model.
+func (m *model) AddSubnet(args SubnetArgs) Subnet {
+	subnet := newSubnet(args)
+	m.Subnets_.Subnets_ = append(m.Subnets_.Subnets_, subnet)
+	return subnet
+}
+
+func (m *model) setSubnets(subnetList []*subnet) {
+	m.Subnets_ = subnets{
+		Version:  1,
+		Subnets_: subnetList,
+	}
+}
+
+// LinkLayerDevices implements Model.
+func (m *model) LinkLayerDevices() []LinkLayerDevice {
+	var result []LinkLayerDevice
+	for _, device := range m.LinkLayerDevices_.LinkLayerDevices_ {
+		result = append(result, device)
+	}
+	return result
+}
+
+// AddLinkLayerDevice implements Model.
+func (m *model) AddLinkLayerDevice(args LinkLayerDeviceArgs) LinkLayerDevice {
+	device := newLinkLayerDevice(args)
+	m.LinkLayerDevices_.LinkLayerDevices_ = append(m.LinkLayerDevices_.LinkLayerDevices_, device)
+	return device
+}
+
+func (m *model) setLinkLayerDevices(deviceList []*linklayerdevice) {
+	m.LinkLayerDevices_ = linklayerdevices{
+		Version:           1,
+		LinkLayerDevices_: deviceList,
+	}
+}
+
+// IPAddresses implements Model.
+func (m *model) IPAddresses() []IPAddress {
+	var result []IPAddress
+	for _, address := range m.IPAddresses_.IPAddresses_ {
+		result = append(result, address)
+	}
+	return result
+}
+
+// AddIPAddress implements Model.
+func (m *model) AddIPAddress(args IPAddressArgs) IPAddress {
+	address := newIPAddress(args)
+	m.IPAddresses_.IPAddresses_ = append(m.IPAddresses_.IPAddresses_, address)
+	return address
+}
+
+func (m *model) setIPAddresses(addressList []*ipaddress) {
+	m.IPAddresses_ = ipaddresses{
+		Version:      1,
+		IPAddresses_: addressList,
+	}
+}
+
+// Actions implements Model.
+func (m *model) Actions() []Action {
+	var result []Action
+	for _, action := range m.Actions_.Actions_ {
+		result = append(result, action)
+	}
+	return result
+}
+
+// AddAction implements Model.
+func (m *model) AddAction(args ActionArgs) Action {
+	action := newAction(args)
+	m.Actions_.Actions_ = append(m.Actions_.Actions_, action)
+	return action
+}
+
+func (m *model) setActions(actionList []*action) {
+	m.Actions_ = actions{
+		Version:  1,
+		Actions_: actionList,
+	}
+}
+
+// SSHHostKeys implements Model.
+func (m *model) SSHHostKeys() []SSHHostKey {
+	var result []SSHHostKey
+	for _, key := range m.SSHHostKeys_.SSHHostKeys_ {
+		result = append(result, key)
+	}
+	return result
+}
+
+// AddSSHHostKey implements Model.
+func (m *model) AddSSHHostKey(args SSHHostKeyArgs) SSHHostKey {
+	key := newSSHHostKey(args)
+	m.SSHHostKeys_.SSHHostKeys_ = append(m.SSHHostKeys_.SSHHostKeys_, key)
+	return key
+}
+
+func (m *model) setSSHHostKeys(keyList []*sshHostKey) {
+	m.SSHHostKeys_ = sshHostKeys{
+		Version:      1,
+		SSHHostKeys_: keyList,
+	}
+}
+
+// CloudImageMetadata implements Model.
+func (m *model) CloudImageMetadata() []CloudImageMetadata {
+	var result []CloudImageMetadata
+	for _, metadata := range m.CloudImageMetadata_.CloudImageMetadata_ {
+		result = append(result, metadata)
+	}
+	return result
+}
+
+// AddCloudImageMetadata implements Model.
+func (m *model) AddCloudImageMetadata(args CloudImageMetadataArgs) CloudImageMetadata {
+	metadata := newCloudImageMetadata(args)
+	m.CloudImageMetadata_.CloudImageMetadata_ = append(m.CloudImageMetadata_.CloudImageMetadata_, metadata)
+	return metadata
+}
+
+func (m *model) setCloudImageMetadata(metadataList []*cloudImageMetadata) {
+	m.CloudImageMetadata_ = cloudImageMetadataSet{
+		Version:             1,
+		CloudImageMetadata_: metadataList,
+	}
+}
+
 // Sequences implements Model.
 func (m *model) Sequences() map[string]int {
 	return m.Sequences_
@@ -314,7 +587,134 @@ func (m *model) Validate() error {
 		return errors.Errorf("unknown unit names in open ports: %s", unknownUnitsWithPorts.SortedValues())
 	}
 
-	return m.validateRelations()
+	if err := m.validateRelations(); err != nil {
+		return errors.Trace(err)
+	}
+	if err := m.validateStorage(allUnits); err != nil {
+		return errors.Trace(err)
+	}
+	if err := m.validateNetworking(); err != nil {
+		return errors.Trace(err)
+	}
+	return m.validateMachineAndUnitRecords()
+}
+
+// validateMachineAndUnitRecords makes sure that actions are complete and
+// that SSH host keys belong to known machines. The receivers of actions
+// aren't checked, as the history of removed units and machines is kept.
+func (m *model) validateMachineAndUnitRecords() error {
+	for _, action := range m.Actions_.Actions_ {
+		if err := action.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	allMachines := set.NewStrings()
+	for _, machine := range m.Machines_.Machines_ {
+		addMachineIDs(machine, allMachines)
+	}
+	for _, key := range m.SSHHostKeys_.SSHHostKeys_ {
+		if !allMachines.Contains(key.MachineID_) {
+			return errors.Errorf("ssh host keys for unknown machine %q", key.MachineID_)
+		}
+	}
+	return nil
+}
+
+func (m *model) validateNetworking() error {
+	allSpaces := set.NewStrings()
+	for _, space := range m.Spaces_.Spaces_ {
+		if space.Name_ == "" {
+			return errors.NotValidf("space missing name")
+		}
+		allSpaces.Add(space.Name_)
+	}
+	for _, subnet := range m.Subnets_.Subnets_ {
+		if subnet.CIDR_ == "" {
+			return errors.NotValidf("subnet missing cidr")
+		}
+		if subnet.SpaceName_ != "" && !allSpaces.Contains(subnet.SpaceName_) {
+			return errors.Errorf("subnet %q refers to unknown space %q", subnet.CIDR_, subnet.SpaceName_)
+		}
+	}
+	for _, application := range m.Applications_.Applications_ {
+		for endpoint, space := range application.EndpointBindings_ {
+			// An empty space name binds the endpoint to the default space.
+			if space != "" && !allSpaces.Contains(space) {
+				return errors.Errorf("application %q endpoint %q bound to unknown space %q", application.Name_, endpoint, space)
+			}
+		}
+	}
+
+	allMachines := set.NewStrings()
+	for _, machine := range m.Machines_.Machines_ {
+		addMachineIDs(machine, allMachines)
+	}
+	allDevices := set.NewStrings()
+	for _, device := range m.LinkLayerDevices_.LinkLayerDevices_ {
+		if device.Name_ == "" {
+			return errors.NotValidf("link-layer device missing name")
+		}
+		if !allMachines.Contains(device.MachineID_) {
+			return errors.Errorf("link-layer device %q on unknown machine %q", device.Name_, device.MachineID_)
+		}
+		allDevices.Add(device.MachineID_ + "/" + device.Name_)
+	}
+	for _, address := range m.IPAddresses_.IPAddresses_ {
+		if address.Value_ == "" {
+			return errors.NotValidf("ip address missing value")
+		}
+		if !allDevices.Contains(address.MachineID_ + "/" + address.DeviceName_) {
+			return errors.Errorf("ip address %q refers to unknown device %q on machine %q", address.Value_, address.DeviceName_, address.MachineID_)
+		}
+	}
+	return nil
+}
+
+func addMachineIDs(machine *machine, ids set.Strings) {
+	ids.Add(machine.Id_)
+	for _, container := range machine.Containers_ {
+		addMachineIDs(container, ids)
+	}
+}
+
+// validateStorage makes sure that the storage instances are attached to
+// known units, and that volumes and filesystems only refer to known
+// storage instances and volumes.
+func (m *model) validateStorage(allUnits set.Strings) error {
+	allStorage := set.NewStrings()
+	for _, storage := range m.Storages_.Storages_ {
+		if err := storage.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+		allStorage.Add(storage.ID_)
+		for _, unit := range storage.Attachments_ {
+			if !allUnits.Contains(unit) {
+				return errors.Errorf("storage %q attached to unknown unit %q", storage.ID_, unit)
+			}
+		}
+	}
+	allVolumes := set.NewStrings()
+	for _, volume := range m.Volumes_.Volumes_ {
+		if err := volume.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+		allVolumes.Add(volume.ID_)
+		if volume.StorageID_ != "" && !allStorage.Contains(volume.StorageID_) {
+			return errors.Errorf("volume %q refers to unknown storage %q", volume.ID_, volume.StorageID_)
+		}
+	}
+	for _, filesystem := range m.Filesystems_.Filesystems_ {
+		if err := filesystem.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+		if filesystem.StorageID_ != "" && !allStorage.Contains(filesystem.StorageID_) {
+			return errors.Errorf("filesystem %q refers to unknown storage %q", filesystem.ID_, filesystem.StorageID_)
+		}
+		if filesystem.VolumeID_ != "" && !allVolumes.Contains(filesystem.VolumeID_) {
+			return errors.Errorf("filesystem %q refers to unknown volume %q", filesystem.ID_, filesystem.VolumeID_)
+		}
+	}
+	return nil
 }
 
 // validateRelations makes sure that for each endpoint in each relation there
@@ -341,6 +741,10 @@ func (m *model) validateRelations() error {
 	return nil
 }
 
+// currentModelVersion is the version of the model format written by
+// this package.
+const currentModelVersion = 2
+
 // importModel constructs a new Model from a map that in normal usage situations
 // will be the result of interpreting a large YAML document.
 //
@@ -363,42 +767,79 @@ type modelDeserializationFunc func(map[string]interface{}) (*model, error)
 
 var modelDeserializationFuncs = map[int]modelDeserializationFunc{
 	1: importModelV1,
+	2: importModelV2,
 }
 
 func importModelV1(source map[string]interface{}) (*model, error) {
+	fields, defaults := modelV1Fields()
+	return importModelVersion(fields, defaults, 1, source)
+}
+
+func importModelV2(source map[string]interface{}) (*model, error) {
+	fields, defaults := modelV2Fields()
+	return importModelVersion(fields, defaults, 2, source)
+}
+
+func modelV1Fields() (schema.Fields, schema.Defaults) {
 	fields := schema.Fields{
-		"owner":        schema.String(),
-		"cloud":        schema.String(),
-		"cloud-region": schema.String(),
-		"config":       schema.StringMap(schema.Any()),
-		"latest-tools": schema.String(),
-		"blocks":       schema.StringMap(schema.String()),
-		"users":        schema.StringMap(schema.Any()),
-		"machines":     schema.StringMap(schema.Any()),
-		"applications": schema.StringMap(schema.Any()),
-		"relations":    schema.StringMap(schema.Any()),
-		"sequences":    schema.StringMap(schema.Int()),
+		"owner":            schema.String(),
+		"cloud":            schema.String(),
+		"cloud-region":     schema.String(),
+		"cloud-credential": schema.String(),
+		"config":           schema.StringMap(schema.Any()),
+		"latest-tools":     schema.String(),
+		"blocks":           schema.StringMap(schema.String()),
+		"users":            schema.StringMap(schema.Any()),
+		"machines":         schema.StringMap(schema.Any()),
+		"applications":     schema.StringMap(schema.Any()),
+		"relations":        schema.StringMap(schema.Any()),
+		"sequences":        schema.StringMap(schema.Int()),
 	}
 	// Some values don't have to be there.
 	defaults := schema.Defaults{
-		"latest-tools": schema.Omit,
-		"blocks":       schema.Omit,
-		"cloud-region": schema.Omit,
+		"latest-tools":     schema.Omit,
+		"blocks":           schema.Omit,
+		"cloud-region":     schema.Omit,
+		"cloud-credential": schema.Omit,
 	}
 	addAnnotationSchema(fields, defaults)
 	addConstraintsSchema(fields, defaults)
+	return fields, defaults
+}
+
+// modelV2Fields adds the storage, networking, actions, SSH host key
+// and cloud image metadata sections.
+func modelV2Fields() (schema.Fields, schema.Defaults) {
+	fields, defaults := modelV1Fields()
+	fields["storages"] = schema.StringMap(schema.Any())
+	fields["storage-pools"] = schema.StringMap(schema.Any())
+	fields["volumes"] = schema.StringMap(schema.Any())
+	fields["filesystems"] = schema.StringMap(schema.Any())
+	fields["spaces"] = schema.StringMap(schema.Any())
+	fields["subnets"] = schema.StringMap(schema.Any())
+	fields["link-layer-devices"] = schema.StringMap(schema.Any())
+	fields["ip-addresses"] = schema.StringMap(schema.Any())
+	fields["actions"] = schema.StringMap(schema.Any())
+	fields["ssh-host-keys"] = schema.StringMap(schema.Any())
+	fields["cloud-image-metadata"] = schema.StringMap(schema.Any())
+	return fields, defaults
+}
+
+func importModelVersion(fields schema.Fields, defaults schema.Defaults, importVersion int, source map[string]interface{}) (*model, error) {
 	checker := schema.FieldMap(fields, defaults)
 
 	coerced, err := checker.Coerce(source, nil)
 	if err != nil {
-		return nil, errors.Annotatef(err, "model v1 schema check failed")
+		return nil, errors.Annotatef(err, "model v%d schema check failed", importVersion)
 	}
 	valid := coerced.(map[string]interface{})
 	// From here we know that the map returned from the schema coercion
 	// contains fields of the right type.
 
+	// The imported model is written out again in the current
+	// version, with empty sections for anything the source lacked.
 	result := &model{
-		Version:    1,
+		Version:    currentModelVersion,
 		Owner_:     valid["owner"].(string),
 		Config_:    valid["config"].(map[string]interface{}),
 		Sequences_: make(map[string]int),
@@ -463,5 +904,105 @@ func importModelV1(source map[string]interface{}) (*model, error) {
 	}
 	result.setRelations(relations)
 
+	// Version 1 models have none of the following sections.
+	result.setStorages(nil)
+	if storageMap, ok := valid["storages"]; ok {
+		storages, err := importStorages(storageMap.(map[string]interface{}))
+		if err != nil {
+			return nil, errors.Annotate(err, "storages")
+		}
+		result.setStorages(storages)
+	}
+
+	result.setStoragePools(nil)
+	if poolMap, ok := valid["storage-pools"]; ok {
+		pools, err := importStoragePools(poolMap.(map[string]interface{}))
+		if err != nil {
+			return nil, errors.Annotate(err, "storage-pools")
+		}
+		result.setStoragePools(pools)
+	}
+
+	result.setVolumes(nil)
+	if volumeMap, ok := valid["volumes"]; ok {
+		volumes, err := importVolumes(volumeMap.(map[string]interface{}))
+		if err != nil {
+			return nil, errors.Annotate(err, "volumes")
+		}
+		result.setVolumes(volumes)
+	}
+
+	result.setFilesystems(nil)
+	if filesystemMap, ok := valid["filesystems"]; ok {
+		filesystems, err := importFilesystems(filesystemMap.(map[string]interface{}))
+		if err != nil {
+			return nil, errors.Annotate(err, "filesystems")
+		}
+		result.setFilesystems(filesystems)
+	}
+
+	result.setSpaces(nil)
+	if spaceMap, ok := valid["spaces"]; ok {
+		spaces, err := importSpaces(spaceMap.(map[string]interface{}))
+		if err != nil {
+			return nil, errors.Annotate(err, "spaces")
+		}
+		result.setSpaces(spaces)
+	}
+
+	result.setSubnets(nil)
+	if subnetMap, ok := valid["subnets"]; ok {
+		subnets, err := importSubnets(subnetMap.(map[string]interface{}))
+		if err != nil {
+			return nil, errors.Annotate(err, "subnets")
+		}
+		result.setSubnets(subnets)
+	}
+
+	result.setLinkLayerDevices(nil)
+	if deviceMap, ok := valid["link-layer-devices"]; ok {
+		devices, err := importLinkLayerDevices(deviceMap.(map[string]interface{}))
+		if err != nil {
+			return nil, errors.Annotate(err, "link-layer-devices")
+		}
+		result.setLinkLayerDevices(devices)
+	}
+
+	result.setIPAddresses(nil)
+	if addressMap, ok := valid["ip-addresses"]; ok {
+		addresses, err := importIPAddresses(addressMap.(map[string]interface{}))
+		if err != nil {
+			return nil, errors.Annotate(err, "ip-addresses")
+		}
+		result.setIPAddresses(addresses)
+	}
+
+	result.setActions(nil)
+	if actionMap, ok := valid["actions"]; ok {
+		actions, err := importActions(actionMap.(map[string]interface{}))
+		if err != nil {
+			return nil, errors.Annotate(err, "actions")
+		}
+		result.setActions(actions)
+	}
+
+	result.setSSHHostKeys(nil)
+	if keyMap, ok := valid["ssh-host-keys"]; ok {
+		keys, err := importSSHHostKeys(keyMap.(map[string]interface{}))
+		if err != nil {
+			return nil, errors.Annotate(err, "ssh-host-keys")
+		}
+		result.setSSHHostKeys(keys)
+	}
+
+	result.setCloudImageMetadata(nil)
+	if metadataMap, ok := valid["cloud-image-metadata"]; ok {
+		metadata, err := importCloudImageMetadata(metadataMap.(map[string]interface{}))
+		if err != nil {
+			return nil, errors.Annotate(err, "cloud-image-metadata")
+		}
+		result.setCloudImageMetadata(metadata)
+	}
+
 	return result, nil
 }
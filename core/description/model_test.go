@@ -331,3 +331,321 @@ func (s *ModelSerializationSuite) TestModelSerializationWithRelations(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(model, jc.DeepEquals, initial)
 }
+
+func (s *ModelSerializationSuite) addStorageToModel(model Model) {
+	s.addApplicationToModel(model, "ubuntu", 1)
+	model.AddStoragePool(StoragePoolArgs{
+		Name:     "fast",
+		Provider: "ebs",
+	})
+	model.AddStorage(StorageArgs{
+		Tag:         names.NewStorageTag("data/0"),
+		Kind:        "filesystem",
+		Owner:       names.NewUnitTag("ubuntu/0"),
+		Name:        "data",
+		Attachments: []names.UnitTag{names.NewUnitTag("ubuntu/0")},
+	})
+	volume := model.AddVolume(VolumeArgs{
+		Tag:         names.NewVolumeTag("0"),
+		Storage:     names.NewStorageTag("data/0"),
+		Provisioned: true,
+		Size:        1024,
+		Pool:        "fast",
+		VolumeID:    "vol-1234",
+	})
+	volume.SetStatus(minimalStatusArgs())
+	volume.AddAttachment(VolumeAttachmentArgs{
+		Machine:     names.NewMachineTag("0"),
+		Provisioned: true,
+		DeviceName:  "xvdf",
+	})
+	filesystem := model.AddFilesystem(FilesystemArgs{
+		Tag:         names.NewFilesystemTag("0/0"),
+		Storage:     names.NewStorageTag("data/0"),
+		Volume:      names.NewVolumeTag("0"),
+		Provisioned: true,
+		Size:        1024,
+		Pool:        "fast",
+	})
+	filesystem.SetStatus(minimalStatusArgs())
+	filesystem.AddAttachment(FilesystemAttachmentArgs{
+		Machine:     names.NewMachineTag("0"),
+		Provisioned: true,
+		MountPoint:  "/srv/data",
+	})
+}
+
+func (s *ModelSerializationSuite) TestModelValidationChecksStorage(c *gc.C) {
+	model := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	s.addStorageToModel(model)
+	err := model.Validate()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ModelSerializationSuite) TestModelValidationChecksStorageUnits(c *gc.C) {
+	model := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	model.AddStorage(StorageArgs{
+		Tag:         names.NewStorageTag("data/0"),
+		Kind:        "block",
+		Attachments: []names.UnitTag{names.NewUnitTag("missing/0")},
+	})
+	err := model.Validate()
+	c.Assert(err, gc.ErrorMatches, `storage "data/0" attached to unknown unit "missing/0"`)
+}
+
+func (s *ModelSerializationSuite) TestModelValidationChecksVolumeStorage(c *gc.C) {
+	model := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	volume := model.AddVolume(VolumeArgs{
+		Tag:     names.NewVolumeTag("0"),
+		Storage: names.NewStorageTag("missing/0"),
+		Size:    1024,
+	})
+	volume.SetStatus(minimalStatusArgs())
+	err := model.Validate()
+	c.Assert(err, gc.ErrorMatches, `volume "0" refers to unknown storage "missing/0"`)
+}
+
+func (s *ModelSerializationSuite) TestModelValidationChecksFilesystemVolume(c *gc.C) {
+	model := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	filesystem := model.AddFilesystem(FilesystemArgs{
+		Tag:    names.NewFilesystemTag("0"),
+		Volume: names.NewVolumeTag("1"),
+		Size:   1024,
+	})
+	filesystem.SetStatus(minimalStatusArgs())
+	err := model.Validate()
+	c.Assert(err, gc.ErrorMatches, `filesystem "0" refers to unknown volume "1"`)
+}
+
+func (s *ModelSerializationSuite) TestModelSerializationWithStorage(c *gc.C) {
+	initial := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	s.addStorageToModel(initial)
+	model := s.exportImport(c, initial)
+	c.Assert(model, jc.DeepEquals, initial)
+	c.Assert(model.Storages(), gc.HasLen, 1)
+	c.Assert(model.StoragePools(), gc.HasLen, 1)
+	c.Assert(model.Volumes(), gc.HasLen, 1)
+	c.Assert(model.Filesystems(), gc.HasLen, 1)
+}
+
+func (s *ModelSerializationSuite) TestModelWithoutStorageSections(c *gc.C) {
+	initial := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	bytes, err := Serialize(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Version 1 models have no storage sections at all.
+	var source map[string]interface{}
+	err = yaml.Unmarshal(bytes, &source)
+	c.Assert(err, jc.ErrorIsNil)
+	for _, key := range []string{"storages", "storage-pools", "volumes", "filesystems"} {
+		delete(source, key)
+	}
+	source["version"] = 1
+
+	model, err := importModel(source)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Storages(), gc.HasLen, 0)
+	c.Assert(model.StoragePools(), gc.HasLen, 0)
+	c.Assert(model.Volumes(), gc.HasLen, 0)
+	c.Assert(model.Filesystems(), gc.HasLen, 0)
+}
+
+func (s *ModelSerializationSuite) addNetworkingToModel(model Model) {
+	s.addMachineToModel(model, "0")
+	model.AddSpace(SpaceArgs{
+		Name:       "db",
+		ProviderID: "space-1",
+	})
+	model.AddSubnet(SubnetArgs{
+		CIDR:      "10.0.0.0/24",
+		SpaceName: "db",
+	})
+	model.AddLinkLayerDevice(LinkLayerDeviceArgs{
+		Name:      "eth0",
+		MachineID: "0",
+		Type:      "ethernet",
+		IsUp:      true,
+	})
+	model.AddIPAddress(IPAddressArgs{
+		DeviceName:   "eth0",
+		MachineID:    "0",
+		SubnetCIDR:   "10.0.0.0/24",
+		ConfigMethod: "static",
+		Value:        "10.0.0.4",
+	})
+}
+
+func (s *ModelSerializationSuite) TestModelValidationChecksNetworking(c *gc.C) {
+	model := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	s.addNetworkingToModel(model)
+	err := model.Validate()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ModelSerializationSuite) TestModelValidationChecksSubnetSpace(c *gc.C) {
+	model := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	model.AddSubnet(SubnetArgs{
+		CIDR:      "10.0.0.0/24",
+		SpaceName: "missing",
+	})
+	err := model.Validate()
+	c.Assert(err, gc.ErrorMatches, `subnet "10.0.0.0/24" refers to unknown space "missing"`)
+}
+
+func (s *ModelSerializationSuite) TestModelValidationChecksEndpointBindings(c *gc.C) {
+	model := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	application := model.AddApplication(ApplicationArgs{
+		Tag:                names.NewApplicationTag("ubuntu"),
+		Settings:           map[string]interface{}{},
+		LeadershipSettings: map[string]interface{}{},
+		EndpointBindings: map[string]string{
+			"juju-info": "missing",
+		},
+	})
+	application.SetStatus(minimalStatusArgs())
+	err := model.Validate()
+	c.Assert(err, gc.ErrorMatches, `application "ubuntu" endpoint "juju-info" bound to unknown space "missing"`)
+}
+
+func (s *ModelSerializationSuite) TestModelValidationChecksLinkLayerDeviceMachine(c *gc.C) {
+	model := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	model.AddLinkLayerDevice(LinkLayerDeviceArgs{
+		Name:      "eth0",
+		MachineID: "42",
+		Type:      "ethernet",
+	})
+	err := model.Validate()
+	c.Assert(err, gc.ErrorMatches, `link-layer device "eth0" on unknown machine "42"`)
+}
+
+func (s *ModelSerializationSuite) TestModelValidationChecksIPAddressDevice(c *gc.C) {
+	model := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	s.addMachineToModel(model, "0")
+	model.AddIPAddress(IPAddressArgs{
+		DeviceName:   "eth1",
+		MachineID:    "0",
+		ConfigMethod: "static",
+		Value:        "10.0.0.4",
+	})
+	err := model.Validate()
+	c.Assert(err, gc.ErrorMatches, `ip address "10.0.0.4" refers to unknown device "eth1" on machine "0"`)
+}
+
+func (s *ModelSerializationSuite) TestModelSerializationWithNetworking(c *gc.C) {
+	initial := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	s.addNetworkingToModel(initial)
+	model := s.exportImport(c, initial)
+	c.Assert(model, jc.DeepEquals, initial)
+	c.Assert(model.Spaces(), gc.HasLen, 1)
+	c.Assert(model.Subnets(), gc.HasLen, 1)
+	c.Assert(model.LinkLayerDevices(), gc.HasLen, 1)
+	c.Assert(model.IPAddresses(), gc.HasLen, 1)
+}
+
+func (s *ModelSerializationSuite) TestModelWithoutNetworkingSections(c *gc.C) {
+	initial := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	bytes, err := Serialize(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var source map[string]interface{}
+	err = yaml.Unmarshal(bytes, &source)
+	c.Assert(err, jc.ErrorIsNil)
+	for _, key := range []string{"spaces", "subnets", "link-layer-devices", "ip-addresses"} {
+		delete(source, key)
+	}
+	source["version"] = 1
+
+	model, err := importModel(source)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Spaces(), gc.HasLen, 0)
+	c.Assert(model.Subnets(), gc.HasLen, 0)
+	c.Assert(model.LinkLayerDevices(), gc.HasLen, 0)
+	c.Assert(model.IPAddresses(), gc.HasLen, 0)
+}
+
+func (s *ModelSerializationSuite) addMachineAndUnitRecordsToModel(model Model) {
+	s.addApplicationToModel(model, "ubuntu", 1)
+	model.AddAction(ActionArgs{
+		Id:         "some-uuid",
+		Receiver:   "ubuntu/0",
+		Name:       "backup",
+		Parameters: map[string]interface{}{"outfile": "foo.tgz"},
+		Enqueued:   time.Date(2016, 10, 18, 2, 3, 4, 0, time.UTC),
+		Status:     "pending",
+	})
+	model.AddSSHHostKey(SSHHostKeyArgs{
+		MachineID: "0",
+		Keys:      []string{"rsa-key"},
+	})
+	model.AddCloudImageMetadata(cloudImageMetadataArgs())
+}
+
+func (s *ModelSerializationSuite) TestModelValidationChecksMachineAndUnitRecords(c *gc.C) {
+	model := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	s.addMachineAndUnitRecordsToModel(model)
+	err := model.Validate()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ModelSerializationSuite) TestModelValidationChecksActions(c *gc.C) {
+	model := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	model.AddAction(ActionArgs{
+		Id:       "some-uuid",
+		Receiver: "ubuntu/0",
+		Status:   "completed",
+	})
+	err := model.Validate()
+	c.Assert(err, gc.ErrorMatches, `action "some-uuid" missing name not valid`)
+}
+
+func (s *ModelSerializationSuite) TestModelValidationAllowsActionsForRemovedReceivers(c *gc.C) {
+	model := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	model.AddAction(ActionArgs{
+		Id:       "some-uuid",
+		Receiver: "removed/0",
+		Name:     "backup",
+		Status:   "completed",
+	})
+	err := model.Validate()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ModelSerializationSuite) TestModelValidationChecksSSHHostKeyMachine(c *gc.C) {
+	model := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	model.AddSSHHostKey(SSHHostKeyArgs{
+		MachineID: "42",
+		Keys:      []string{"rsa-key"},
+	})
+	err := model.Validate()
+	c.Assert(err, gc.ErrorMatches, `ssh host keys for unknown machine "42"`)
+}
+
+func (s *ModelSerializationSuite) TestModelSerializationWithMachineAndUnitRecords(c *gc.C) {
+	initial := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	s.addMachineAndUnitRecordsToModel(initial)
+	model := s.exportImport(c, initial)
+	c.Assert(model, jc.DeepEquals, initial)
+	c.Assert(model.Actions(), gc.HasLen, 1)
+	c.Assert(model.SSHHostKeys(), gc.HasLen, 1)
+	c.Assert(model.CloudImageMetadata(), gc.HasLen, 1)
+}
+
+func (s *ModelSerializationSuite) TestModelWithoutMachineAndUnitRecordSections(c *gc.C) {
+	initial := NewModel(ModelArgs{Owner: names.NewUserTag("owner")})
+	bytes, err := Serialize(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var source map[string]interface{}
+	err = yaml.Unmarshal(bytes, &source)
+	c.Assert(err, jc.ErrorIsNil)
+	for _, key := range []string{"actions", "ssh-host-keys", "cloud-image-metadata"} {
+		delete(source, key)
+	}
+	source["version"] = 1
+
+	model, err := importModel(source)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Actions(), gc.HasLen, 0)
+	c.Assert(model.SSHHostKeys(), gc.HasLen, 0)
+	c.Assert(model.CloudImageMetadata(), gc.HasLen, 0)
+}
@@ -0,0 +1,195 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/testing"
+)
+
+// ModelVersionSuite checks that models exported in every version of
+// the model format can still be imported.
+type ModelVersionSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&ModelVersionSuite{})
+
+// modelFixtures holds a model exported in each version of the format,
+// keyed by version. They all describe the same model.
+var modelFixtures = map[int]string{
+	// Version 1 models have no storage, networking, actions, SSH host
+	// key or cloud image metadata sections.
+	1: `
+version: 1
+owner: admin@local
+config:
+  name: foo
+  uuid: bd3fae18-5ea1-4bc5-8837-45400cf1f8f6
+latest-tools: 2.0.1
+blocks:
+  destroy-model: no destroying
+users:
+  version: 1
+  users:
+  - name: admin@local
+    created-by: admin@local
+    date-created: 2016-07-01T10:00:00Z
+    access: admin
+machines:
+  version: 1
+  machines: []
+applications:
+  version: 1
+  applications: []
+relations:
+  version: 1
+  relations: []
+sequences:
+  machine: 2
+cloud: aws
+cloud-region: us-east-1
+cloud-credential: default
+`[1:],
+	2: `
+version: 2
+owner: admin@local
+config:
+  name: foo
+  uuid: bd3fae18-5ea1-4bc5-8837-45400cf1f8f6
+latest-tools: 2.0.1
+blocks:
+  destroy-model: no destroying
+users:
+  version: 1
+  users:
+  - name: admin@local
+    created-by: admin@local
+    date-created: 2016-07-01T10:00:00Z
+    access: admin
+machines:
+  version: 1
+  machines: []
+applications:
+  version: 2
+  applications: []
+relations:
+  version: 1
+  relations: []
+storages:
+  version: 1
+  storages: []
+storage-pools:
+  version: 1
+  pools: []
+volumes:
+  version: 1
+  volumes: []
+filesystems:
+  version: 1
+  filesystems: []
+spaces:
+  version: 1
+  spaces: []
+subnets:
+  version: 1
+  subnets: []
+link-layer-devices:
+  version: 1
+  link-layer-devices: []
+ip-addresses:
+  version: 1
+  ip-addresses: []
+actions:
+  version: 1
+  actions: []
+ssh-host-keys:
+  version: 1
+  ssh-host-keys: []
+cloud-image-metadata:
+  version: 1
+  cloud-image-metadata: []
+sequences:
+  machine: 2
+cloud: aws
+cloud-region: us-east-1
+cloud-credential: default
+`[1:],
+}
+
+func (*ModelVersionSuite) TestFixtureForEachVersion(c *gc.C) {
+	for v := 1; v <= currentModelVersion; v++ {
+		_, ok := modelFixtures[v]
+		c.Check(ok, jc.IsTrue, gc.Commentf("no fixture for model version %d", v))
+	}
+}
+
+func (*ModelVersionSuite) TestDeserializeFixtures(c *gc.C) {
+	for v, fixture := range modelFixtures {
+		c.Logf("model version %d", v)
+		model, err := Deserialize([]byte(fixture))
+		c.Assert(err, jc.ErrorIsNil)
+
+		c.Check(model.Tag(), gc.Equals, names.NewModelTag("bd3fae18-5ea1-4bc5-8837-45400cf1f8f6"))
+		c.Check(model.Owner(), gc.Equals, names.NewUserTag("admin@local"))
+		c.Check(model.LatestToolsVersion(), gc.Equals, version.MustParse("2.0.1"))
+		c.Check(model.Blocks(), jc.DeepEquals, map[string]string{
+			"destroy-model": "no destroying",
+		})
+		c.Check(model.Sequences(), jc.DeepEquals, map[string]int{"machine": 2})
+		c.Check(model.Cloud(), gc.Equals, "aws")
+		c.Check(model.CloudRegion(), gc.Equals, "us-east-1")
+		c.Check(model.CloudCredential(), gc.Equals, "default")
+
+		users := model.Users()
+		c.Assert(users, gc.HasLen, 1)
+		c.Check(users[0].Name(), gc.Equals, names.NewUserTag("admin@local"))
+		c.Check(users[0].DateCreated(), gc.Equals, time.Date(2016, 7, 1, 10, 0, 0, 0, time.UTC))
+		c.Check(model.Validate(), jc.ErrorIsNil)
+	}
+}
+
+func (*ModelVersionSuite) TestOlderModelSerializesAsCurrentVersion(c *gc.C) {
+	model, err := Deserialize([]byte(modelFixtures[1]))
+	c.Assert(err, jc.ErrorIsNil)
+
+	bytes, err := Serialize(model)
+	c.Assert(err, jc.ErrorIsNil)
+	var source map[string]interface{}
+	err = yaml.Unmarshal(bytes, &source)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(source["version"], gc.Equals, currentModelVersion)
+
+	current, err := Deserialize([]byte(modelFixtures[currentModelVersion]))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(model, jc.DeepEquals, current)
+}
+
+func (*ModelVersionSuite) TestV2RequiresSections(c *gc.C) {
+	source, err := importModelSource(modelFixtures[2])
+	c.Assert(err, jc.ErrorIsNil)
+	delete(source, "volumes")
+	_, err = importModel(source)
+	c.Check(err, gc.ErrorMatches, "model v2 schema check failed: volumes: expected map, got nothing")
+}
+
+func (*ModelVersionSuite) TestVersionZero(c *gc.C) {
+	_, err := importModel(map[string]interface{}{
+		"version": 0,
+	})
+	c.Check(err.Error(), gc.Equals, `version 0 not valid`)
+}
+
+func importModelSource(fixture string) (map[string]interface{}, error) {
+	var source map[string]interface{}
+	err := yaml.Unmarshal([]byte(fixture), &source)
+	return source, err
+}
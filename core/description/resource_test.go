@@ -0,0 +1,184 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/yaml.v2"
+)
+
+type ResourceSerializationSuite struct {
+	SliceSerializationSuite
+}
+
+var _ = gc.Suite(&ResourceSerializationSuite{})
+
+func (s *ResourceSerializationSuite) SetUpTest(c *gc.C) {
+	s.SliceSerializationSuite.SetUpTest(c)
+	s.importName = "resources"
+	s.sliceName = "resources"
+	s.importFunc = func(m map[string]interface{}) (interface{}, error) {
+		return importResources(m)
+	}
+	s.testFields = func(m map[string]interface{}) {
+		m["resources"] = []interface{}{}
+	}
+}
+
+func emptyResourcesMap() map[interface{}]interface{} {
+	return map[interface{}]interface{}{
+		"version":   1,
+		"resources": []interface{}{},
+	}
+}
+
+func testResourceRevisionArgs() ResourceRevisionArgs {
+	return ResourceRevisionArgs{
+		Revision:       3,
+		Type:           "file",
+		Path:           "big-data.tar",
+		Description:    "lots of data",
+		Origin:         "upload",
+		FingerprintHex: "aaaaaaaa",
+		Size:           1024,
+		Timestamp:      time.Date(2016, 10, 18, 2, 3, 4, 0, time.UTC),
+		Username:       "bob",
+	}
+}
+
+func (s *ResourceSerializationSuite) TestNewResource(c *gc.C) {
+	r := newResource(ResourceArgs{Name: "data"})
+	c.Check(r.Name(), gc.Equals, "data")
+	c.Check(r.ApplicationRevision(), gc.IsNil)
+	c.Check(r.CharmStoreRevision(), gc.IsNil)
+
+	args := testResourceRevisionArgs()
+	rev := r.SetApplicationRevision(args)
+	c.Check(r.ApplicationRevision(), gc.Equals, rev)
+	c.Check(rev.Revision(), gc.Equals, args.Revision)
+	c.Check(rev.Type(), gc.Equals, args.Type)
+	c.Check(rev.Path(), gc.Equals, args.Path)
+	c.Check(rev.Description(), gc.Equals, args.Description)
+	c.Check(rev.Origin(), gc.Equals, args.Origin)
+	c.Check(rev.FingerprintHex(), gc.Equals, args.FingerprintHex)
+	c.Check(rev.Size(), gc.Equals, args.Size)
+	c.Check(rev.Timestamp(), gc.Equals, args.Timestamp)
+	c.Check(rev.Username(), gc.Equals, args.Username)
+}
+
+func (s *ResourceSerializationSuite) TestValidate(c *gc.C) {
+	r := newResource(ResourceArgs{})
+	c.Check(r.Validate(), gc.ErrorMatches, "resource missing name not valid")
+
+	r = newResource(ResourceArgs{Name: "data"})
+	c.Check(r.Validate(), gc.ErrorMatches, `resource "data" missing application revision not valid`)
+
+	r.SetApplicationRevision(testResourceRevisionArgs())
+	c.Check(r.Validate(), jc.ErrorIsNil)
+}
+
+func (s *ResourceSerializationSuite) TestPlaceholderHasNoTimestamp(c *gc.C) {
+	args := testResourceRevisionArgs()
+	args.Timestamp = time.Time{}
+	rev := newResourceRevision(args)
+	c.Check(rev.Timestamp_, gc.IsNil)
+	c.Check(rev.Timestamp().IsZero(), jc.IsTrue)
+}
+
+func (s *ResourceSerializationSuite) TestParsingSerializedData(c *gc.C) {
+	uploaded := newResource(ResourceArgs{Name: "data"})
+	uploaded.SetApplicationRevision(testResourceRevisionArgs())
+	store := testResourceRevisionArgs()
+	store.Revision = 4
+	store.Origin = "store"
+	store.Username = ""
+	uploaded.SetCharmStoreRevision(store)
+
+	placeholder := newResource(ResourceArgs{Name: "config"})
+	placeholder.SetApplicationRevision(ResourceRevisionArgs{
+		Type:   "file",
+		Path:   "config.yaml",
+		Origin: "upload",
+	})
+
+	initial := resources{
+		Version:    1,
+		Resources_: []*resource{uploaded, placeholder},
+	}
+
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var source map[string]interface{}
+	err = yaml.Unmarshal(bytes, &source)
+	c.Assert(err, jc.ErrorIsNil)
+
+	resources, err := importResources(source)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(resources, jc.DeepEquals, initial.Resources_)
+}
+
+type UnitResourceSerializationSuite struct {
+	SliceSerializationSuite
+}
+
+var _ = gc.Suite(&UnitResourceSerializationSuite{})
+
+func (s *UnitResourceSerializationSuite) SetUpTest(c *gc.C) {
+	s.SliceSerializationSuite.SetUpTest(c)
+	s.importName = "unit resources"
+	s.sliceName = "resources"
+	s.importFunc = func(m map[string]interface{}) (interface{}, error) {
+		return importUnitResources(m)
+	}
+	s.testFields = func(m map[string]interface{}) {
+		m["resources"] = []interface{}{}
+	}
+}
+
+func emptyUnitResourcesMap() map[interface{}]interface{} {
+	return map[interface{}]interface{}{
+		"version":   1,
+		"resources": []interface{}{},
+	}
+}
+
+func (s *UnitResourceSerializationSuite) TestNewUnitResource(c *gc.C) {
+	args := testResourceRevisionArgs()
+	r := newUnitResource(UnitResourceArgs{
+		Name:     "data",
+		Revision: args,
+	})
+	c.Check(r.Name(), gc.Equals, "data")
+	c.Check(r.Revision().Revision(), gc.Equals, args.Revision)
+	c.Check(r.Revision().Timestamp(), gc.Equals, args.Timestamp)
+}
+
+func (s *UnitResourceSerializationSuite) TestParsingSerializedData(c *gc.C) {
+	initial := unitResources{
+		Version: 1,
+		UnitResources_: []*unitResource{
+			newUnitResource(UnitResourceArgs{
+				Name:     "data",
+				Revision: testResourceRevisionArgs(),
+			}),
+		},
+	}
+
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var source map[string]interface{}
+	err = yaml.Unmarshal(bytes, &source)
+	c.Assert(err, jc.ErrorIsNil)
+
+	resources, err := importUnitResources(source)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(resources, jc.DeepEquals, initial.UnitResources_)
+}
@@ -0,0 +1,48 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/yaml.v2"
+)
+
+type StorageConstraintSerializationSuite struct{}
+
+var _ = gc.Suite(&StorageConstraintSerializationSuite{})
+
+func (s *StorageConstraintSerializationSuite) TestMissingVersion(c *gc.C) {
+	_, err := importStorageConstraint(map[string]interface{}{})
+	c.Check(err.Error(), gc.Equals, "storageconstraint version schema check failed: version: expected int, got nothing")
+}
+
+func (s *StorageConstraintSerializationSuite) TestNewStorageConstraint(c *gc.C) {
+	constraint := newStorageConstraint(StorageConstraintArgs{
+		Pool:  "fast",
+		Size:  2048,
+		Count: 2,
+	})
+	c.Check(constraint.Pool(), gc.Equals, "fast")
+	c.Check(constraint.Size(), gc.Equals, uint64(2048))
+	c.Check(constraint.Count(), gc.Equals, uint64(2))
+}
+
+func (s *StorageConstraintSerializationSuite) TestParsingSerializedData(c *gc.C) {
+	initial := newStorageConstraint(StorageConstraintArgs{
+		Pool:  "fast",
+		Size:  2048,
+		Count: 2,
+	})
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var source map[string]interface{}
+	err = yaml.Unmarshal(bytes, &source)
+	c.Assert(err, jc.ErrorIsNil)
+
+	imported, err := importStorageConstraint(source)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(imported, jc.DeepEquals, initial)
+}
@@ -0,0 +1,85 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/yaml.v2"
+)
+
+type StoragePoolSerializationSuite struct {
+	SliceSerializationSuite
+}
+
+var _ = gc.Suite(&StoragePoolSerializationSuite{})
+
+func (s *StoragePoolSerializationSuite) SetUpTest(c *gc.C) {
+	s.SliceSerializationSuite.SetUpTest(c)
+	s.importName = "storage pools"
+	s.sliceName = "pools"
+	s.importFunc = func(m map[string]interface{}) (interface{}, error) {
+		return importStoragePools(m)
+	}
+	s.testFields = func(m map[string]interface{}) {
+		m["pools"] = []interface{}{}
+	}
+}
+
+func (s *StoragePoolSerializationSuite) TestNewStoragePool(c *gc.C) {
+	pool := newStoragePool(StoragePoolArgs{
+		Name:     "fast",
+		Provider: "ebs",
+		Attributes: map[string]interface{}{
+			"volume-type": "provisioned-iops",
+		},
+	})
+
+	c.Check(pool.Name(), gc.Equals, "fast")
+	c.Check(pool.Provider(), gc.Equals, "ebs")
+	c.Check(pool.Attributes(), jc.DeepEquals, map[string]interface{}{
+		"volume-type": "provisioned-iops",
+	})
+}
+
+func (s *StoragePoolSerializationSuite) exportImport(c *gc.C, pool *storagepool) *storagepool {
+	initial := storagepools{
+		Version: 1,
+		Pools_:  []*storagepool{pool},
+	}
+
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var source map[string]interface{}
+	err = yaml.Unmarshal(bytes, &source)
+	c.Assert(err, jc.ErrorIsNil)
+
+	pools, err := importStoragePools(source)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pools, gc.HasLen, 1)
+	return pools[0]
+}
+
+func (s *StoragePoolSerializationSuite) TestParsingSerializedData(c *gc.C) {
+	original := newStoragePool(StoragePoolArgs{
+		Name:     "fast",
+		Provider: "ebs",
+		Attributes: map[string]interface{}{
+			"volume-type": "provisioned-iops",
+			"iops":        3000,
+		},
+	})
+	pool := s.exportImport(c, original)
+	c.Assert(pool, jc.DeepEquals, original)
+}
+
+func (s *StoragePoolSerializationSuite) TestNoAttributes(c *gc.C) {
+	original := newStoragePool(StoragePoolArgs{
+		Name:     "plain",
+		Provider: "loop",
+	})
+	pool := s.exportImport(c, original)
+	c.Assert(pool, jc.DeepEquals, original)
+}
@@ -0,0 +1,72 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/yaml.v2"
+)
+
+type SubnetSerializationSuite struct {
+	SliceSerializationSuite
+}
+
+var _ = gc.Suite(&SubnetSerializationSuite{})
+
+func (s *SubnetSerializationSuite) SetUpTest(c *gc.C) {
+	s.SliceSerializationSuite.SetUpTest(c)
+	s.importName = "subnets"
+	s.sliceName = "subnets"
+	s.importFunc = func(m map[string]interface{}) (interface{}, error) {
+		return importSubnets(m)
+	}
+	s.testFields = func(m map[string]interface{}) {
+		m["subnets"] = []interface{}{}
+	}
+}
+
+func (s *SubnetSerializationSuite) TestNewSubnet(c *gc.C) {
+	subnet := newSubnet(SubnetArgs{
+		CIDR:             "10.0.0.0/24",
+		ProviderID:       "subnet-1",
+		VLANTag:          64,
+		AvailabilityZone: "bar",
+		SpaceName:        "foo",
+	})
+
+	c.Check(subnet.CIDR(), gc.Equals, "10.0.0.0/24")
+	c.Check(subnet.ProviderID(), gc.Equals, "subnet-1")
+	c.Check(subnet.VLANTag(), gc.Equals, 64)
+	c.Check(subnet.AvailabilityZone(), gc.Equals, "bar")
+	c.Check(subnet.SpaceName(), gc.Equals, "foo")
+}
+
+func (s *SubnetSerializationSuite) TestParsingSerializedData(c *gc.C) {
+	initial := subnets{
+		Version: 1,
+		Subnets_: []*subnet{
+			newSubnet(SubnetArgs{
+				CIDR:             "10.0.0.0/24",
+				ProviderID:       "subnet-1",
+				VLANTag:          64,
+				AvailabilityZone: "bar",
+				SpaceName:        "foo",
+			}),
+			newSubnet(SubnetArgs{CIDR: "10.0.1.0/24"}),
+		},
+	}
+
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var source map[string]interface{}
+	err = yaml.Unmarshal(bytes, &source)
+	c.Assert(err, jc.ErrorIsNil)
+
+	subnets, err := importSubnets(source)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(subnets, jc.DeepEquals, initial.Subnets_)
+}
@@ -44,6 +44,9 @@ type unit struct {
 	Annotations_ `yaml:"annotations,omitempty"`
 
 	Constraints_ *constraints `yaml:"constraints,omitempty"`
+
+	UnitResources_ unitResources `yaml:"resources"`
+	Payloads_      payloads      `yaml:"payloads"`
 }
 
 // UnitArgs is an argument struct used to add a Unit to a Application in the Model.
@@ -66,7 +69,7 @@ func newUnit(args UnitArgs) *unit {
 	for _, s := range args.Subordinates {
 		subordinates = append(subordinates, s.Id())
 	}
-	return &unit{
+	u := &unit{
 		Name_:                   args.Tag.Id(),
 		Machine_:                args.Machine.Id(),
 		PasswordHash_:           args.PasswordHash,
@@ -79,6 +82,9 @@ func newUnit(args UnitArgs) *unit {
 		WorkloadVersionHistory_: newStatusHistory(),
 		AgentStatusHistory_:     newStatusHistory(),
 	}
+	u.setResources(nil)
+	u.setPayloads(nil)
+	return u
 }
 
 // Tag implements Unit.
@@ -218,6 +224,52 @@ func (u *unit) SetConstraints(args ConstraintsArgs) {
 	u.Constraints_ = newConstraints(args)
 }
 
+// Resources implements Unit.
+func (u *unit) Resources() []UnitResource {
+	result := make([]UnitResource, len(u.UnitResources_.UnitResources_))
+	for i, r := range u.UnitResources_.UnitResources_ {
+		result[i] = r
+	}
+	return result
+}
+
+// AddResource implements Unit.
+func (u *unit) AddResource(args UnitResourceArgs) UnitResource {
+	r := newUnitResource(args)
+	u.UnitResources_.UnitResources_ = append(u.UnitResources_.UnitResources_, r)
+	return r
+}
+
+func (u *unit) setResources(resourceList []*unitResource) {
+	u.UnitResources_ = unitResources{
+		Version:        1,
+		UnitResources_: resourceList,
+	}
+}
+
+// Payloads implements Unit.
+func (u *unit) Payloads() []Payload {
+	result := make([]Payload, len(u.Payloads_.Payloads_))
+	for i, p := range u.Payloads_.Payloads_ {
+		result[i] = p
+	}
+	return result
+}
+
+// AddPayload implements Unit.
+func (u *unit) AddPayload(args PayloadArgs) Payload {
+	p := newPayload(args)
+	u.Payloads_.Payloads_ = append(u.Payloads_.Payloads_, p)
+	return p
+}
+
+func (u *unit) setPayloads(payloadList []*payload) {
+	u.Payloads_ = payloads{
+		Version:   1,
+		Payloads_: payloadList,
+	}
+}
+
 // Validate impelements Unit.
 func (u *unit) Validate() error {
 	if u.Name_ == "" {
@@ -232,6 +284,11 @@ func (u *unit) Validate() error {
 	if u.Tools_ == nil {
 		return errors.NotValidf("unit %q missing tools", u.Name_)
 	}
+	for _, p := range u.Payloads_.Payloads_ {
+		if p.Name_ == "" {
+			return errors.NotValidf("unit %q payload missing name", u.Name_)
+		}
+	}
 	return nil
 }
 
@@ -272,9 +329,20 @@ type unitDeserializationFunc func(map[string]interface{}) (*unit, error)
 
 var unitDeserializationFuncs = map[int]unitDeserializationFunc{
 	1: importUnitV1,
+	2: importUnitV2,
 }
 
 func importUnitV1(source map[string]interface{}) (*unit, error) {
+	fields, defaults := unitV1Fields()
+	return importUnit(fields, defaults, 1, source)
+}
+
+func importUnitV2(source map[string]interface{}) (*unit, error) {
+	fields, defaults := unitV2Fields()
+	return importUnit(fields, defaults, 2, source)
+}
+
+func unitV1Fields() (schema.Fields, schema.Defaults) {
 	fields := schema.Fields{
 		"name":    schema.String(),
 		"machine": schema.String(),
@@ -304,11 +372,23 @@ func importUnitV1(source map[string]interface{}) (*unit, error) {
 	}
 	addAnnotationSchema(fields, defaults)
 	addConstraintsSchema(fields, defaults)
+	return fields, defaults
+}
+
+// unitV2Fields adds the resources and payloads.
+func unitV2Fields() (schema.Fields, schema.Defaults) {
+	fields, defaults := unitV1Fields()
+	fields["resources"] = schema.StringMap(schema.Any())
+	fields["payloads"] = schema.StringMap(schema.Any())
+	return fields, defaults
+}
+
+func importUnit(fields schema.Fields, defaults schema.Defaults, importVersion int, source map[string]interface{}) (*unit, error) {
 	checker := schema.FieldMap(fields, defaults)
 
 	coerced, err := checker.Coerce(source, nil)
 	if err != nil {
-		return nil, errors.Annotatef(err, "unit v1 schema check failed")
+		return nil, errors.Annotatef(err, "unit v%d schema check failed", importVersion)
 	}
 	valid := coerced.(map[string]interface{})
 	// From here we know that the map returned from the schema coercion
@@ -370,5 +450,24 @@ func importUnitV1(source map[string]interface{}) (*unit, error) {
 	}
 	result.WorkloadStatus_ = workloadStatus
 
+	// Version 1 units have neither resources nor payloads.
+	result.setResources(nil)
+	if source, ok := valid["resources"]; ok {
+		resources, err := importUnitResources(source.(map[string]interface{}))
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result.setResources(resources)
+	}
+
+	result.setPayloads(nil)
+	if source, ok := valid["payloads"]; ok {
+		payloads, err := importPayloads(source.(map[string]interface{}))
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result.setPayloads(payloads)
+	}
+
 	return result, nil
 }
@@ -39,6 +39,8 @@ func minimalUnitMap() map[interface{}]interface{} {
 		"workload-version-history": emptyStatusHistoryMap(),
 		"password-hash":            "secure-hash",
 		"tools":                    minimalAgentToolsMap(),
+		"resources":                emptyUnitResourcesMap(),
+		"payloads":                 emptyPayloadsMap(),
 	}
 }
 
@@ -119,7 +121,7 @@ func (s *UnitSerializationSuite) TestMinimalMatches(c *gc.C) {
 
 func (s *UnitSerializationSuite) exportImport(c *gc.C, unit_ *unit) *unit {
 	initial := units{
-		Version: 1,
+		Version: 2,
 		Units_:  []*unit{unit_},
 	}
 
@@ -167,6 +169,49 @@ func (s *UnitSerializationSuite) TestConstraints(c *gc.C) {
 	c.Assert(unit.Constraints(), jc.DeepEquals, newConstraints(args))
 }
 
+func (s *UnitSerializationSuite) TestPayloads(c *gc.C) {
+	initial := minimalUnit()
+	initial.AddPayload(PayloadArgs{
+		Name:   "spam",
+		Type:   "docker",
+		RawID:  "idspam",
+		State:  "running",
+		Labels: []string{"a-tag"},
+	})
+
+	unit := s.exportImport(c, initial)
+	c.Assert(unit.Payloads(), jc.DeepEquals, initial.Payloads())
+}
+
+func (s *UnitSerializationSuite) TestPayloadValid(c *gc.C) {
+	unit := minimalUnit()
+	unit.AddPayload(PayloadArgs{Type: "docker"})
+
+	err := unit.Validate()
+	c.Assert(err, gc.ErrorMatches, `unit "ubuntu/0" payload missing name not valid`)
+}
+
+func (s *UnitSerializationSuite) TestV1NoResourcesOrPayloads(c *gc.C) {
+	// Version 1 units have neither resources nor payloads.
+	source := minimalUnitMap()
+	delete(source, "resources")
+	delete(source, "payloads")
+	bytes, err := yaml.Marshal(map[string]interface{}{
+		"version": 1,
+		"units":   []interface{}{source},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	var data map[string]interface{}
+	err = yaml.Unmarshal(bytes, &data)
+	c.Assert(err, jc.ErrorIsNil)
+
+	units, err := importUnits(data)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units[0].Resources(), gc.HasLen, 0)
+	c.Assert(units[0].Payloads(), gc.HasLen, 0)
+}
+
 func (s *UnitSerializationSuite) TestAgentStatusHistory(c *gc.C) {
 	initial := minimalUnit()
 	args := testStatusHistoryArgs()
@@ -24,14 +24,19 @@ const (
 	ABORTDONE
 )
 
+// phaseNames holds the name of each phase, in the same order as the
+// constants. The names are persisted in model migration status docs,
+// so changing one needs an upgrade step that rewrites the stored
+// names, as was done when IMPORT and VALIDATION were found to be named
+// the wrong way around.
 var phaseNames = []string{
 	"UNKNOWN", // To catch uninitialised fields.
 	"NONE",    // For watchers to indicate there's never been a migration attempt.
 	"QUIESCE",
 	"READONLY",
 	"PRECHECK",
-	"VALIDATION",
 	"IMPORT",
+	"VALIDATION",
 	"SUCCESS",
 	"LOGTRANSFER",
 	"REAP",
@@ -80,6 +85,12 @@ func (p Phase) IsTerminal() bool {
 //
 // The keys are the "from" states and the values enumerate the
 // possible "to" states.
+//
+// A migration that fails to reap the model from the source controller
+// waits in REAPFAILED for an operator. It can be resumed by returning
+// to SUCCESS, which gives agents still pointed at the source
+// controller another chance to move before reaping is reattempted, or
+// cleaned up by reaping the model directly and moving to DONE.
 var validTransitions = map[Phase][]Phase{
 	QUIESCE:     {READONLY, ABORT},
 	READONLY:    {PRECHECK, ABORT},
@@ -89,6 +100,7 @@ var validTransitions = map[Phase][]Phase{
 	SUCCESS:     {LOGTRANSFER},
 	LOGTRANSFER: {REAP},
 	REAP:        {DONE, REAPFAILED},
+	REAPFAILED:  {SUCCESS, DONE},
 	ABORT:       {ABORTDONE},
 }
 
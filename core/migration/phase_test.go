@@ -29,6 +29,16 @@ func (s *PhaseSuite) TestStringValid(c *gc.C) {
 	c.Check(migration.ABORT.String(), gc.Equals, "ABORT")
 }
 
+func (s *PhaseSuite) TestStringRoundTrip(c *gc.C) {
+	for p := migration.UNKNOWN; p <= migration.ABORTDONE; p++ {
+		phase, ok := migration.ParsePhase(p.String())
+		c.Check(ok, jc.IsTrue)
+		c.Check(phase, gc.Equals, p)
+	}
+	c.Check(migration.IMPORT.String(), gc.Equals, "IMPORT")
+	c.Check(migration.VALIDATION.String(), gc.Equals, "VALIDATION")
+}
+
 func (s *PhaseSuite) TestInvalid(c *gc.C) {
 	c.Check(migration.Phase(-1).String(), gc.Equals, "UNKNOWN")
 	c.Check(migration.Phase(9999).String(), gc.Equals, "UNKNOWN")
@@ -51,7 +61,7 @@ func (s *PhaseSuite) TestIsTerminal(c *gc.C) {
 	c.Check(migration.SUCCESS.IsTerminal(), jc.IsFalse)
 	c.Check(migration.ABORT.IsTerminal(), jc.IsFalse)
 	c.Check(migration.ABORTDONE.IsTerminal(), jc.IsTrue)
-	c.Check(migration.REAPFAILED.IsTerminal(), jc.IsTrue)
+	c.Check(migration.REAPFAILED.IsTerminal(), jc.IsFalse)
 	c.Check(migration.DONE.IsTerminal(), jc.IsTrue)
 }
 
@@ -63,3 +73,21 @@ func (s *PhaseSuite) TestCanTransitionTo(c *gc.C) {
 
 	c.Check(migration.ABORT.CanTransitionTo(migration.QUIESCE), jc.IsFalse)
 }
+
+func (s *PhaseSuite) TestCanTransitionAfterSUCCESS(c *gc.C) {
+	c.Check(migration.SUCCESS.CanTransitionTo(migration.ABORT), jc.IsFalse)
+	c.Check(migration.REAP.CanTransitionTo(migration.REAPFAILED), jc.IsTrue)
+	c.Check(migration.REAP.CanTransitionTo(migration.DONE), jc.IsTrue)
+	c.Check(migration.DONE.CanTransitionTo(migration.SUCCESS), jc.IsFalse)
+}
+
+func (s *PhaseSuite) TestCanTransitionFromREAPFAILED(c *gc.C) {
+	// Resuming returns to SUCCESS, and cleaning up moves to DONE.
+	c.Check(migration.REAPFAILED.CanTransitionTo(migration.SUCCESS), jc.IsTrue)
+	c.Check(migration.REAPFAILED.CanTransitionTo(migration.DONE), jc.IsTrue)
+
+	// The model has moved, so there's no going back.
+	c.Check(migration.REAPFAILED.CanTransitionTo(migration.ABORT), jc.IsFalse)
+	c.Check(migration.REAPFAILED.CanTransitionTo(migration.QUIESCE), jc.IsFalse)
+	c.Check(migration.REAPFAILED.CanTransitionTo(migration.REAP), jc.IsFalse)
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -22,6 +23,7 @@ import (
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/juju/osenv"
+	"github.com/juju/juju/logfwd"
 	"github.com/juju/juju/logfwd/syslog"
 )
 
@@ -126,6 +128,65 @@ const (
 	// forwarding.
 	LogFwdSyslogClientKey = "syslog-client-key"
 
+	// LogForwardSink sets the kind of sink to which logs are
+	// forwarded: syslog (the default), http-json, gelf or loki.
+	// The syslog sink is configured with the syslog-* attributes,
+	// and the others with the logforward-* attributes.
+	LogForwardSink = "logforward-sink"
+
+	// LogForwardEndpoint sets the URL (for http-json and loki) or
+	// hostname:port (for gelf) of the log sink.
+	LogForwardEndpoint = "logforward-endpoint"
+
+	// LogForwardCACert sets the certificate of the CA that signed the
+	// log sink's server certificate.
+	LogForwardCACert = "logforward-ca-cert"
+
+	// LogForwardClientCert sets the client certificate for log
+	// forwarding.
+	LogForwardClientCert = "logforward-client-cert"
+
+	// LogForwardClientKey sets the client key for log forwarding.
+	LogForwardClientKey = "logforward-client-key"
+
+	// LogForwardBatchSize sets the largest number of log records sent
+	// to a batching sink at once.
+	LogForwardBatchSize = "logforward-batch-size"
+
+	// LogForwardFlushInterval sets the longest that log records are
+	// held back from a batching sink while a batch fills.
+	LogForwardFlushInterval = "logforward-flush-interval"
+
+	// LogForwardMinLevel sets the lowest level of log record that is
+	// forwarded, e.g. WARNING.
+	LogForwardMinLevel = "logforward-min-level"
+
+	// LogForwardIncludeEntity sets the space-separated entities (e.g.
+	// unit-mysql-*) whose log records are forwarded.
+	LogForwardIncludeEntity = "logforward-include-entity"
+
+	// LogForwardExcludeEntity sets the space-separated entities whose
+	// log records are not forwarded.
+	LogForwardExcludeEntity = "logforward-exclude-entity"
+
+	// LogForwardIncludeModule sets the space-separated modules (e.g.
+	// juju.worker) whose log records are forwarded.
+	LogForwardIncludeModule = "logforward-include-module"
+
+	// LogForwardExcludeModule sets the space-separated modules whose
+	// log records are not forwarded.
+	LogForwardExcludeModule = "logforward-exclude-module"
+
+	// LogMaxAge sets how long the model's log records are kept in the
+	// controller's database. If it is not set, the controller's
+	// default applies.
+	LogMaxAge = "log-max-age"
+
+	// LogMaxAgeByLevel sets how long the model's log records of
+	// particular levels are kept, as space-separated LEVEL=duration
+	// pairs, e.g. "ERROR=720h DEBUG=24h".
+	LogMaxAgeByLevel = "log-max-age-by-level"
+
 	// AutomaticallyRetryHooks determines whether the uniter will
 	// automatically retry a hook that has failed
 	AutomaticallyRetryHooks = "automatically-retry-hooks"
@@ -371,10 +432,28 @@ func Validate(cfg, old *Config) error {
 		}
 	}
 
-	if lfCfg, ok := cfg.LogFwdSyslog(); ok {
-		if err := lfCfg.Validate(); err != nil {
-			return errors.Annotate(err, "invalid syslog forwarding config")
+	if _, err := cfg.logForwardFilter(); err != nil {
+		return errors.Annotate(err, "invalid log forwarding config")
+	}
+	if cfg.logForwardSinkType() == logfwd.SinkSyslog {
+		if lfCfg, ok := cfg.LogFwdSyslog(); ok {
+			if err := lfCfg.Validate(); err != nil {
+				return errors.Annotate(err, "invalid syslog forwarding config")
+			}
+		}
+	} else {
+		if _, err := cfg.logForwardFlushInterval(); err != nil {
+			return errors.Annotate(err, "invalid log forwarding config")
 		}
+		if lfCfg, ok := cfg.LogForwardSink(); ok {
+			if err := lfCfg.Validate(); err != nil {
+				return errors.Annotate(err, "invalid log forwarding config")
+			}
+		}
+	}
+
+	if _, _, err := cfg.logRetention(); err != nil {
+		return errors.Annotate(err, "invalid log retention config")
 	}
 
 	if uuid := cfg.UUID(); !utils.IsValidUUIDString(uuid) {
@@ -613,6 +692,84 @@ func (c *Config) LogFwdSyslog() (*syslog.RawConfig, bool) {
 	return &lfCfg, true
 }
 
+// LogForwardSink returns the log forwarding config. For the syslog
+// sink this is built from the syslog-* attributes.
+func (c *Config) LogForwardSink() (*logfwd.SinkConfig, bool) {
+	// An invalid filter is reported by Validate.
+	filter, _ := c.logForwardFilter()
+
+	sinkType := c.logForwardSinkType()
+	if sinkType == logfwd.SinkSyslog {
+		raw, ok := c.LogFwdSyslog()
+		if !ok {
+			return nil, false
+		}
+		return &logfwd.SinkConfig{
+			Enabled:    raw.Enabled,
+			Type:       logfwd.SinkSyslog,
+			Endpoint:   raw.Host,
+			CACert:     raw.CACert,
+			ClientCert: raw.ClientCert,
+			ClientKey:  raw.ClientKey,
+			Filter:     filter,
+		}, true
+	}
+
+	// Any other sink type has been asked for explicitly.
+	lfCfg := logfwd.SinkConfig{
+		Type:       sinkType,
+		Endpoint:   c.asString(LogForwardEndpoint),
+		CACert:     c.asString(LogForwardCACert),
+		ClientCert: c.asString(LogForwardClientCert),
+		ClientKey:  c.asString(LogForwardClientKey),
+		Filter:     filter,
+	}
+	lfCfg.Enabled, _ = c.defined[LogForwardEnabled].(bool)
+	lfCfg.BatchSize, _ = c.defined[LogForwardBatchSize].(int)
+	// An invalid flush interval is reported by Validate.
+	lfCfg.FlushInterval, _ = c.logForwardFlushInterval()
+	return &lfCfg, true
+}
+
+func (c *Config) logForwardSinkType() logfwd.SinkType {
+	if s := c.asString(LogForwardSink); s != "" {
+		return logfwd.SinkType(s)
+	}
+	return logfwd.SinkSyslog
+}
+
+func (c *Config) logForwardFilter() (logfwd.Filter, error) {
+	filter := logfwd.Filter{
+		IncludeEntity: strings.Fields(c.asString(LogForwardIncludeEntity)),
+		ExcludeEntity: strings.Fields(c.asString(LogForwardExcludeEntity)),
+		IncludeModule: strings.Fields(c.asString(LogForwardIncludeModule)),
+		ExcludeModule: strings.Fields(c.asString(LogForwardExcludeModule)),
+	}
+	if s := c.asString(LogForwardMinLevel); s != "" {
+		level, ok := loggo.ParseLevel(s)
+		if !ok {
+			return logfwd.Filter{}, errors.NotValidf("%s %q", LogForwardMinLevel, s)
+		}
+		filter.MinLevel = level
+	}
+	if err := filter.Validate(); err != nil {
+		return logfwd.Filter{}, errors.Trace(err)
+	}
+	return filter, nil
+}
+
+func (c *Config) logForwardFlushInterval() (time.Duration, error) {
+	s := c.asString(LogForwardFlushInterval)
+	if s == "" {
+		return 0, nil
+	}
+	interval, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, errors.NotValidf("%s %q", LogForwardFlushInterval, s)
+	}
+	return interval, nil
+}
+
 // FirewallMode returns whether the firewall should
 // manage ports per machine, globally, or not at all.
 // (FwInstance, FwGlobal, or FwNone).
@@ -688,6 +845,54 @@ func (c *Config) LoggingConfig() string {
 	return c.asString("logging-config")
 }
 
+// LogRetention returns how long the model's log records are kept in
+// the controller's database, which is zero if the controller's default
+// applies, along with how long records of particular levels are kept.
+func (c *Config) LogRetention() (time.Duration, map[loggo.Level]time.Duration) {
+	// Invalid retention config is reported by Validate.
+	maxAge, maxAgeByLevel, _ := c.logRetention()
+	return maxAge, maxAgeByLevel
+}
+
+func (c *Config) logRetention() (time.Duration, map[loggo.Level]time.Duration, error) {
+	parseAge := func(key, s string) (time.Duration, error) {
+		age, err := time.ParseDuration(s)
+		if err != nil || age <= 0 {
+			return 0, errors.NotValidf("%s %q", key, s)
+		}
+		return age, nil
+	}
+
+	var maxAge time.Duration
+	if s := c.asString(LogMaxAge); s != "" {
+		age, err := parseAge(LogMaxAge, s)
+		if err != nil {
+			return 0, nil, errors.Trace(err)
+		}
+		maxAge = age
+	}
+	var maxAgeByLevel map[loggo.Level]time.Duration
+	for _, pair := range strings.Fields(c.asString(LogMaxAgeByLevel)) {
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 {
+			return 0, nil, errors.NotValidf("%s %q (expected LEVEL=duration)", LogMaxAgeByLevel, pair)
+		}
+		level, ok := loggo.ParseLevel(parts[0])
+		if !ok {
+			return 0, nil, errors.NotValidf("%s level %q", LogMaxAgeByLevel, parts[0])
+		}
+		age, err := parseAge(LogMaxAgeByLevel, parts[1])
+		if err != nil {
+			return 0, nil, errors.Trace(err)
+		}
+		if maxAgeByLevel == nil {
+			maxAgeByLevel = make(map[loggo.Level]time.Duration)
+		}
+		maxAgeByLevel[level] = age
+	}
+	return maxAge, maxAgeByLevel, nil
+}
+
 // AutomaticallyRetryHooks returns whether we should automatically retry hooks.
 // By default this should be true.
 func (c *Config) AutomaticallyRetryHooks() bool {
@@ -867,6 +1072,20 @@ var alwaysOptional = schema.Defaults{
 	LogFwdSyslogCACert:           schema.Omit,
 	LogFwdSyslogClientCert:       schema.Omit,
 	LogFwdSyslogClientKey:        schema.Omit,
+	LogForwardSink:               schema.Omit,
+	LogForwardEndpoint:           schema.Omit,
+	LogForwardCACert:             schema.Omit,
+	LogForwardClientCert:         schema.Omit,
+	LogForwardClientKey:          schema.Omit,
+	LogForwardBatchSize:          schema.Omit,
+	LogForwardFlushInterval:      schema.Omit,
+	LogForwardMinLevel:           schema.Omit,
+	LogForwardIncludeEntity:      schema.Omit,
+	LogForwardExcludeEntity:      schema.Omit,
+	LogForwardIncludeModule:      schema.Omit,
+	LogForwardExcludeModule:      schema.Omit,
+	LogMaxAge:                    schema.Omit,
+	LogMaxAgeByLevel:             schema.Omit,
 	HttpProxyKey:                 schema.Omit,
 	HttpsProxyKey:                schema.Omit,
 	FtpProxyKey:                  schema.Omit,
@@ -1220,6 +1439,77 @@ global or per instance security groups.`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	LogForwardSink: {
+		Description: `The kind of sink to forward logs to.`,
+		Type:        environschema.Tstring,
+		Values:      []interface{}{"syslog", "http-json", "gelf", "loki"},
+		Group:       environschema.EnvironGroup,
+	},
+	LogForwardEndpoint: {
+		Description: `The URL (http-json, loki) or hostname:port (gelf) of the log sink.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogForwardCACert: {
+		Description: `The certificate of the CA that signed the log sink's server certificate, in PEM format.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogForwardClientCert: {
+		Description: `The log forwarding client certificate in PEM format.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogForwardClientKey: {
+		Description: `The log forwarding client key in PEM format.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogForwardBatchSize: {
+		Description: `The largest number of log records sent to the log sink at once (http-json, gelf, loki).`,
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
+	LogForwardFlushInterval: {
+		Description: `The longest that log records are held back while a batch fills, e.g. "5s" (http-json, gelf, loki).`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogForwardMinLevel: {
+		Description: `The lowest level of log record that is forwarded, e.g. WARNING.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogForwardIncludeEntity: {
+		Description: `Space-separated entities, e.g. "unit-mysql-*", whose log records are forwarded.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogForwardExcludeEntity: {
+		Description: `Space-separated entities whose log records are not forwarded.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogForwardIncludeModule: {
+		Description: `Space-separated modules, e.g. "juju.worker", whose log records are forwarded.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogForwardExcludeModule: {
+		Description: `Space-separated modules whose log records are not forwarded.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogMaxAge: {
+		Description: `How long the model's log records are kept in the controller's database, e.g. "72h". If not set, the controller's default applies.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogMaxAgeByLevel: {
+		Description: `How long the model's log records of particular levels are kept, as space-separated LEVEL=duration pairs, e.g. "ERROR=720h DEBUG=24h".`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
 	"ssl-hostname-verification": {
 		Description: "Whether SSL hostname verification is enabled (default true)",
 		Type:        environschema.Tbool,
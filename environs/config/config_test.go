@@ -24,6 +24,7 @@ import (
 
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/juju/osenv"
+	"github.com/juju/juju/logfwd"
 	"github.com/juju/juju/testing"
 )
 
@@ -533,6 +534,93 @@ var configTests = []configTest{
 			"syslog-client-cert": testing.ServerCert,
 			"syslog-client-key":  testing.ServerKey,
 		}),
+	}, {
+		about:       "Valid log forwarding config values",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"type":                      "my-type",
+			"name":                      "my-name",
+			"logforward-enabled":        true,
+			"logforward-sink":           "http-json",
+			"logforward-endpoint":       "https://logs.example.com/juju",
+			"logforward-batch-size":     50,
+			"logforward-flush-interval": "10s",
+		}),
+	}, {
+		about:       "Invalid log forwarding sink",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"type":            "my-type",
+			"name":            "my-name",
+			"logforward-sink": "carrier-pigeon",
+		}),
+		err: `logforward-sink: expected one of \[syslog http-json gelf loki\], got "carrier-pigeon"`,
+	}, {
+		about:       "Invalid log forwarding endpoint",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"type":                "my-type",
+			"name":                "my-name",
+			"logforward-sink":     "loki",
+			"logforward-endpoint": "logs.example.com:3100",
+		}),
+		err: `invalid log forwarding config: Endpoint "logs.example.com:3100" \(expected an http or https URL\) not valid`,
+	}, {
+		about:       "Invalid log forwarding flush interval",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"type":                      "my-type",
+			"name":                      "my-name",
+			"logforward-sink":           "gelf",
+			"logforward-endpoint":       "graylog.example.com:12201",
+			"logforward-flush-interval": "soon",
+		}),
+		err: `invalid log forwarding config: logforward-flush-interval "soon" not valid`,
+	}, {
+		about:       "Invalid log forwarding min level",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"type":                 "my-type",
+			"name":                 "my-name",
+			"logforward-min-level": "LOUD",
+		}),
+		err: `invalid log forwarding config: logforward-min-level "LOUD" not valid`,
+	}, {
+		about:       "Invalid log forwarding entity filter",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"type":                      "my-type",
+			"name":                      "my-name",
+			"logforward-exclude-entity": "unit-[mysql",
+		}),
+		err: `invalid log forwarding config: entity "unit-\[mysql" not valid`,
+	}, {
+		about:       "Invalid log max age",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"type":        "my-type",
+			"name":        "my-name",
+			"log-max-age": "-1h",
+		}),
+		err: `invalid log retention config: log-max-age "-1h" not valid`,
+	}, {
+		about:       "Invalid log max age level",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"type":                 "my-type",
+			"name":                 "my-name",
+			"log-max-age-by-level": "ERROR=720h LOUD=1h",
+		}),
+		err: `invalid log retention config: log-max-age-by-level level "LOUD" not valid`,
+	}, {
+		about:       "Invalid log max age pair",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"type":                 "my-type",
+			"name":                 "my-name",
+			"log-max-age-by-level": "ERROR",
+		}),
+		err: `invalid log retention config: log-max-age-by-level "ERROR" \(expected LEVEL=duration\) not valid`,
 	},
 }
 
@@ -947,6 +1035,96 @@ func (s *ConfigSuite) TestLoggingConfigFromEnvironment(c *gc.C) {
 	c.Assert(config.LoggingConfig(), gc.Equals, "<root>=INFO;unit=DEBUG")
 }
 
+func (s *ConfigSuite) TestLogForwardSinkFromSyslog(c *gc.C) {
+	config := newTestConfig(c, testing.Attrs{
+		"logforward-enabled": true,
+		"syslog-host":        "localhost:1234",
+		"syslog-ca-cert":     testing.CACert,
+		"syslog-client-cert": testing.ServerCert,
+		"syslog-client-key":  testing.ServerKey,
+	})
+
+	lfCfg, ok := config.LogForwardSink()
+	c.Assert(ok, jc.IsTrue)
+	c.Check(lfCfg, jc.DeepEquals, &logfwd.SinkConfig{
+		Enabled:    true,
+		Type:       logfwd.SinkSyslog,
+		Endpoint:   "localhost:1234",
+		CACert:     testing.CACert,
+		ClientCert: testing.ServerCert,
+		ClientKey:  testing.ServerKey,
+	})
+}
+
+func (s *ConfigSuite) TestLogForwardSinkNotSet(c *gc.C) {
+	config := newTestConfig(c, testing.Attrs{})
+	_, ok := config.LogForwardSink()
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *ConfigSuite) TestLogForwardSink(c *gc.C) {
+	config := newTestConfig(c, testing.Attrs{
+		"logforward-enabled":        true,
+		"logforward-sink":           "gelf",
+		"logforward-endpoint":       "graylog.example.com:12201",
+		"logforward-ca-cert":        testing.CACert,
+		"logforward-batch-size":     20,
+		"logforward-flush-interval": "2s",
+	})
+
+	lfCfg, ok := config.LogForwardSink()
+	c.Assert(ok, jc.IsTrue)
+	c.Check(lfCfg, jc.DeepEquals, &logfwd.SinkConfig{
+		Enabled:       true,
+		Type:          logfwd.SinkGELF,
+		Endpoint:      "graylog.example.com:12201",
+		CACert:        testing.CACert,
+		BatchSize:     20,
+		FlushInterval: 2 * time.Second,
+	})
+}
+
+func (s *ConfigSuite) TestLogForwardSinkFilter(c *gc.C) {
+	config := newTestConfig(c, testing.Attrs{
+		"logforward-enabled":        true,
+		"logforward-sink":           "loki",
+		"logforward-endpoint":       "https://loki.example.com/loki/api/v1/push",
+		"logforward-min-level":      "WARNING",
+		"logforward-include-entity": "unit-mysql-* machine-0",
+		"logforward-exclude-module": "juju.worker.uniter",
+	})
+
+	lfCfg, ok := config.LogForwardSink()
+	c.Assert(ok, jc.IsTrue)
+	c.Check(lfCfg.Filter, jc.DeepEquals, logfwd.Filter{
+		MinLevel:      loggo.WARNING,
+		IncludeEntity: []string{"unit-mysql-*", "machine-0"},
+		ExcludeModule: []string{"juju.worker.uniter"},
+	})
+}
+
+func (s *ConfigSuite) TestLogRetention(c *gc.C) {
+	config := newTestConfig(c, testing.Attrs{
+		"log-max-age":          "48h",
+		"log-max-age-by-level": "ERROR=720h debug=24h",
+	})
+
+	maxAge, maxAgeByLevel := config.LogRetention()
+	c.Check(maxAge, gc.Equals, 48*time.Hour)
+	c.Check(maxAgeByLevel, jc.DeepEquals, map[loggo.Level]time.Duration{
+		loggo.ERROR: 720 * time.Hour,
+		loggo.DEBUG: 24 * time.Hour,
+	})
+}
+
+func (s *ConfigSuite) TestLogRetentionDefault(c *gc.C) {
+	config := newTestConfig(c, testing.Attrs{})
+
+	maxAge, maxAgeByLevel := config.LogRetention()
+	c.Check(maxAge, gc.Equals, time.Duration(0))
+	c.Check(maxAgeByLevel, gc.HasLen, 0)
+}
+
 func (s *ConfigSuite) TestAutoHookRetryDefault(c *gc.C) {
 	config := newTestConfig(c, testing.Attrs{})
 	c.Assert(config.AutomaticallyRetryHooks(), gc.Equals, true)
@@ -0,0 +1,177 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logfwd
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"net/url"
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/cert"
+)
+
+// SinkType identifies the kind of log sink to which log records
+// are forwarded.
+type SinkType string
+
+// These are the supported kinds of log sink.
+const (
+	// SinkSyslog sends each record to a syslog (RFC 5424) host over TLS.
+	SinkSyslog SinkType = "syslog"
+
+	// SinkHTTPJSON posts batches of records, as a JSON array, to an
+	// HTTP endpoint.
+	SinkHTTPJSON SinkType = "http-json"
+
+	// SinkGELF sends batches of records, as GELF messages, to a
+	// Graylog input over TCP.
+	SinkGELF SinkType = "gelf"
+
+	// SinkLoki pushes batches of records to a Loki-compatible
+	// push endpoint.
+	SinkLoki SinkType = "loki"
+)
+
+// Validate ensures that the sink type is supported.
+func (st SinkType) Validate() error {
+	switch st {
+	case SinkSyslog, SinkHTTPJSON, SinkGELF, SinkLoki:
+		return nil
+	}
+	return errors.NotValidf("sink type %q", st)
+}
+
+// Batched reports whether records are sent to the sink in batches.
+func (st SinkType) Batched() bool {
+	return st != SinkSyslog
+}
+
+const (
+	// DefaultBatchSize is the number of records sent to a batched
+	// sink at once, unless configured otherwise.
+	DefaultBatchSize = 100
+
+	// DefaultFlushInterval is how long records may be held back
+	// from a batched sink while a batch fills, unless configured
+	// otherwise.
+	DefaultFlushInterval = 5 * time.Second
+)
+
+// SinkConfig holds the configuration of the log sink to which log
+// records are forwarded.
+type SinkConfig struct {
+	// Enabled is true if the log forwarding feature is enabled.
+	Enabled bool
+
+	// Type is the kind of log sink.
+	Type SinkType
+
+	// Endpoint locates the log sink. It is a URL for the HTTP based
+	// sinks, and a host-port for the others.
+	Endpoint string
+
+	// CACert is the TLS CA certificate (x.509, PEM-encoded) to use
+	// for validating the server certificate when connecting. If it
+	// is not set, the system's root CAs are used.
+	CACert string
+
+	// ClientCert and ClientKey are the TLS certificate and private key
+	// (x.509, PEM-encoded) to use when connecting. Both or neither
+	// must be set.
+	ClientCert string
+	ClientKey  string
+
+	// BatchSize is the largest number of records sent to a batched
+	// sink at once. If zero, DefaultBatchSize is used.
+	BatchSize int
+
+	// FlushInterval is the longest that records are held back from a
+	// batched sink while a batch fills. If zero, DefaultFlushInterval
+	// is used.
+	FlushInterval time.Duration
+
+	// Filter selects the records that are forwarded to the sink.
+	Filter Filter
+}
+
+// Validate ensures that the config is currently valid.
+func (cfg SinkConfig) Validate() error {
+	if err := cfg.Type.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	if err := cfg.validateEndpoint(); err != nil {
+		return errors.Trace(err)
+	}
+	if cfg.BatchSize < 0 {
+		return errors.NotValidf("negative batch size %d", cfg.BatchSize)
+	}
+	if cfg.FlushInterval < 0 {
+		return errors.NotValidf("negative flush interval %v", cfg.FlushInterval)
+	}
+	if _, err := cfg.TLSConfig(); err != nil {
+		return errors.Annotate(err, "validating TLS config")
+	}
+	if err := cfg.Filter.Validate(); err != nil {
+		return errors.Annotate(err, "invalid Filter")
+	}
+	return nil
+}
+
+func (cfg SinkConfig) validateEndpoint() error {
+	switch cfg.Type {
+	case SinkHTTPJSON, SinkLoki:
+		u, err := url.Parse(cfg.Endpoint)
+		if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+			return errors.NotValidf("Endpoint %q (expected an http or https URL)", cfg.Endpoint)
+		}
+	default:
+		host, _, err := net.SplitHostPort(cfg.Endpoint)
+		if err != nil {
+			host = cfg.Endpoint
+		}
+		if host == "" {
+			return errors.NotValidf("Endpoint %q", cfg.Endpoint)
+		}
+	}
+	return nil
+}
+
+// Batching returns the batch size and flush interval to use, with the
+// defaults applied.
+func (cfg SinkConfig) Batching() (int, time.Duration) {
+	size, interval := cfg.BatchSize, cfg.FlushInterval
+	if size == 0 {
+		size = DefaultBatchSize
+	}
+	if interval == 0 {
+		interval = DefaultFlushInterval
+	}
+	return size, interval
+}
+
+// TLSConfig returns the TLS configuration to use when connecting to
+// the sink.
+func (cfg SinkConfig) TLSConfig() (*tls.Config, error) {
+	tlsCfg := &tls.Config{}
+	if cfg.ClientCert != "" || cfg.ClientKey != "" {
+		clientCert, err := tls.X509KeyPair([]byte(cfg.ClientCert), []byte(cfg.ClientKey))
+		if err != nil {
+			return nil, errors.Annotate(err, "parsing client key pair")
+		}
+		tlsCfg.Certificates = []tls.Certificate{clientCert}
+	}
+	if cfg.CACert != "" {
+		caCert, err := cert.ParseCert(cfg.CACert)
+		if err != nil {
+			return nil, errors.Annotate(err, "parsing CA certificate")
+		}
+		tlsCfg.RootCAs = x509.NewCertPool()
+		tlsCfg.RootCAs.AddCert(caCert)
+	}
+	return tlsCfg, nil
+}
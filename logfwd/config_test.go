@@ -0,0 +1,210 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logfwd_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type SinkConfigSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&SinkConfigSuite{})
+
+func (s *SinkConfigSuite) TestSinkTypeValidate(c *gc.C) {
+	for _, st := range []logfwd.SinkType{
+		logfwd.SinkSyslog,
+		logfwd.SinkHTTPJSON,
+		logfwd.SinkGELF,
+		logfwd.SinkLoki,
+	} {
+		c.Check(st.Validate(), jc.ErrorIsNil)
+	}
+
+	err := logfwd.SinkType("carrier-pigeon").Validate()
+
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `sink type "carrier-pigeon" not valid`)
+}
+
+func (s *SinkConfigSuite) TestSinkTypeBatched(c *gc.C) {
+	c.Check(logfwd.SinkSyslog.Batched(), jc.IsFalse)
+	c.Check(logfwd.SinkHTTPJSON.Batched(), jc.IsTrue)
+	c.Check(logfwd.SinkGELF.Batched(), jc.IsTrue)
+	c.Check(logfwd.SinkLoki.Batched(), jc.IsTrue)
+}
+
+func (s *SinkConfigSuite) TestValidateFull(c *gc.C) {
+	cfg := logfwd.SinkConfig{
+		Type:          logfwd.SinkGELF,
+		Endpoint:      "graylog.example.com:12201",
+		CACert:        coretesting.CACert,
+		ClientCert:    coretesting.ServerCert,
+		ClientKey:     coretesting.ServerKey,
+		BatchSize:     10,
+		FlushInterval: time.Second,
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *SinkConfigSuite) TestValidateWithoutTLS(c *gc.C) {
+	cfg := logfwd.SinkConfig{
+		Type:     logfwd.SinkHTTPJSON,
+		Endpoint: "https://logs.example.com/juju",
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *SinkConfigSuite) TestValidateZeroValue(c *gc.C) {
+	var cfg logfwd.SinkConfig
+
+	err := cfg.Validate()
+
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *SinkConfigSuite) TestValidateMissingHostname(c *gc.C) {
+	cfg := logfwd.SinkConfig{
+		Type:     logfwd.SinkGELF,
+		Endpoint: ":12201",
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, gc.ErrorMatches, `Endpoint ":12201" not valid`)
+}
+
+func (s *SinkConfigSuite) TestValidateBadURL(c *gc.C) {
+	for _, endpoint := range []string{
+		"",
+		"logs.example.com:8080",
+		"ftp://logs.example.com/juju",
+		"https:///juju",
+	} {
+		c.Logf("endpoint %q", endpoint)
+		cfg := logfwd.SinkConfig{
+			Type:     logfwd.SinkLoki,
+			Endpoint: endpoint,
+		}
+
+		err := cfg.Validate()
+
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, `Endpoint ".*" \(expected an http or https URL\) not valid`)
+	}
+}
+
+func (s *SinkConfigSuite) TestValidateNegativeBatching(c *gc.C) {
+	cfg := logfwd.SinkConfig{
+		Type:      logfwd.SinkHTTPJSON,
+		Endpoint:  "https://logs.example.com/juju",
+		BatchSize: -1,
+	}
+	err := cfg.Validate()
+	c.Check(err, gc.ErrorMatches, `negative batch size -1 not valid`)
+
+	cfg.BatchSize = 0
+	cfg.FlushInterval = -time.Second
+	err = cfg.Validate()
+	c.Check(err, gc.ErrorMatches, `negative flush interval -1s not valid`)
+}
+
+func (s *SinkConfigSuite) TestValidateClientKeyWithoutCert(c *gc.C) {
+	cfg := logfwd.SinkConfig{
+		Type:      logfwd.SinkGELF,
+		Endpoint:  "graylog.example.com:12201",
+		ClientKey: coretesting.ServerKey,
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, gc.ErrorMatches, `validating TLS config: parsing client key pair: .*`)
+}
+
+func (s *SinkConfigSuite) TestValidateBadCACert(c *gc.C) {
+	cfg := logfwd.SinkConfig{
+		Type:     logfwd.SinkGELF,
+		Endpoint: "graylog.example.com:12201",
+		CACert:   "abc",
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, gc.ErrorMatches, `validating TLS config: parsing CA certificate: no certificates found`)
+}
+
+func (s *SinkConfigSuite) TestValidateBadFilter(c *gc.C) {
+	cfg := logfwd.SinkConfig{
+		Type:     logfwd.SinkGELF,
+		Endpoint: "graylog.example.com:12201",
+		Filter: logfwd.Filter{
+			IncludeModule: []string{""},
+		},
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `invalid Filter: empty module not valid`)
+}
+
+func (s *SinkConfigSuite) TestBatchingDefaults(c *gc.C) {
+	var cfg logfwd.SinkConfig
+
+	size, interval := cfg.Batching()
+
+	c.Check(size, gc.Equals, logfwd.DefaultBatchSize)
+	c.Check(interval, gc.Equals, logfwd.DefaultFlushInterval)
+}
+
+func (s *SinkConfigSuite) TestBatchingConfigured(c *gc.C) {
+	cfg := logfwd.SinkConfig{
+		BatchSize:     5,
+		FlushInterval: time.Minute,
+	}
+
+	size, interval := cfg.Batching()
+
+	c.Check(size, gc.Equals, 5)
+	c.Check(interval, gc.Equals, time.Minute)
+}
+
+func (s *SinkConfigSuite) TestTLSConfig(c *gc.C) {
+	cfg := logfwd.SinkConfig{
+		CACert:     coretesting.CACert,
+		ClientCert: coretesting.ServerCert,
+		ClientKey:  coretesting.ServerKey,
+	}
+
+	tlsCfg, err := cfg.TLSConfig()
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(tlsCfg.Certificates, gc.HasLen, 1)
+	c.Check(tlsCfg.RootCAs, gc.NotNil)
+}
+
+func (s *SinkConfigSuite) TestTLSConfigEmpty(c *gc.C) {
+	var cfg logfwd.SinkConfig
+
+	tlsCfg, err := cfg.TLSConfig()
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(tlsCfg.Certificates, gc.HasLen, 0)
+	c.Check(tlsCfg.RootCAs, gc.IsNil)
+}
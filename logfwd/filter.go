@@ -0,0 +1,118 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logfwd
+
+import (
+	"path"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/names.v2"
+)
+
+// Filter selects the log records that are forwarded to a log sink.
+// The zero value selects every record.
+type Filter struct {
+	// MinLevel is the lowest level of record that is selected.
+	MinLevel loggo.Level
+
+	// IncludeEntity, if set, holds the entities (e.g. "unit-mysql-0")
+	// whose records are selected. The "*" wildcard may be used.
+	IncludeEntity []string
+
+	// ExcludeEntity holds the entities whose records are not selected.
+	// The "*" wildcard may be used.
+	ExcludeEntity []string
+
+	// IncludeModule, if set, holds the modules (e.g. "juju.worker")
+	// whose records are selected. A module includes its submodules.
+	IncludeModule []string
+
+	// ExcludeModule holds the modules whose records are not selected.
+	// A module includes its submodules.
+	ExcludeModule []string
+}
+
+// Validate ensures that the filter is correct.
+func (f Filter) Validate() error {
+	if f.MinLevel > loggo.CRITICAL {
+		return errors.NotValidf("MinLevel %d", f.MinLevel)
+	}
+	for _, entities := range [][]string{f.IncludeEntity, f.ExcludeEntity} {
+		for _, entity := range entities {
+			if _, err := path.Match(entity, ""); err != nil || entity == "" {
+				return errors.NotValidf("entity %q", entity)
+			}
+		}
+	}
+	for _, modules := range [][]string{f.IncludeModule, f.ExcludeModule} {
+		for _, module := range modules {
+			if module == "" {
+				return errors.NotValidf("empty module")
+			}
+		}
+	}
+	return nil
+}
+
+// Match reports whether the record is selected by the filter.
+func (f Filter) Match(rec Record) bool {
+	if f.MinLevel > loggo.UNSPECIFIED && rec.Level < f.MinLevel {
+		return false
+	}
+	entity := originEntity(rec.Origin)
+	if len(f.IncludeEntity) > 0 && !matchEntity(f.IncludeEntity, entity) {
+		return false
+	}
+	if matchEntity(f.ExcludeEntity, entity) {
+		return false
+	}
+	module := rec.Location.Module
+	if len(f.IncludeModule) > 0 && !matchModule(f.IncludeModule, module) {
+		return false
+	}
+	if matchModule(f.ExcludeModule, module) {
+		return false
+	}
+	return true
+}
+
+// originEntity returns the tag string of the entity that created
+// a record with the given origin, or "" if it is not known.
+func originEntity(origin Origin) string {
+	switch origin.Type {
+	case OriginTypeMachine:
+		if names.IsValidMachine(origin.Name) {
+			return names.NewMachineTag(origin.Name).String()
+		}
+	case OriginTypeUnit:
+		if names.IsValidUnit(origin.Name) {
+			return names.NewUnitTag(origin.Name).String()
+		}
+	case OriginTypeUser:
+		if names.IsValidUser(origin.Name) {
+			return names.NewUserTag(origin.Name).String()
+		}
+	}
+	return ""
+}
+
+func matchEntity(patterns []string, entity string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, entity); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func matchModule(modules []string, module string) bool {
+	for _, m := range modules {
+		if module == m || strings.HasPrefix(module, m+".") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,115 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logfwd_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd"
+)
+
+type FilterSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&FilterSuite{})
+
+func (s *FilterSuite) TestValidateZero(c *gc.C) {
+	var f logfwd.Filter
+
+	err := f.Validate()
+
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *FilterSuite) TestValidateFull(c *gc.C) {
+	f := logfwd.Filter{
+		MinLevel:      loggo.WARNING,
+		IncludeEntity: []string{"unit-mysql-*"},
+		ExcludeEntity: []string{"machine-0"},
+		IncludeModule: []string{"juju.worker"},
+		ExcludeModule: []string{"juju.worker.uniter"},
+	}
+
+	err := f.Validate()
+
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *FilterSuite) TestValidateBadLevel(c *gc.C) {
+	f := logfwd.Filter{MinLevel: loggo.Level(99)}
+
+	err := f.Validate()
+
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `MinLevel 99 not valid`)
+}
+
+func (s *FilterSuite) TestValidateBadEntity(c *gc.C) {
+	f := logfwd.Filter{ExcludeEntity: []string{"unit-[mysql"}}
+
+	err := f.Validate()
+
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `entity "unit-\[mysql" not valid`)
+}
+
+func (s *FilterSuite) TestValidateEmptyModule(c *gc.C) {
+	f := logfwd.Filter{IncludeModule: []string{""}}
+
+	err := f.Validate()
+
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `empty module not valid`)
+}
+
+func (s *FilterSuite) TestMatch(c *gc.C) {
+	rec := validRecord
+	rec.Origin.Type = logfwd.OriginTypeUnit
+	rec.Origin.Name = "mysql/0"
+	rec.Level = loggo.WARNING
+	rec.Location.Module = "juju.worker.uniter.operation"
+
+	for i, test := range []struct {
+		filter   logfwd.Filter
+		expected bool
+	}{{
+		filter:   logfwd.Filter{},
+		expected: true,
+	}, {
+		filter:   logfwd.Filter{MinLevel: loggo.WARNING},
+		expected: true,
+	}, {
+		filter:   logfwd.Filter{MinLevel: loggo.ERROR},
+		expected: false,
+	}, {
+		filter:   logfwd.Filter{IncludeEntity: []string{"unit-mysql-*"}},
+		expected: true,
+	}, {
+		filter:   logfwd.Filter{IncludeEntity: []string{"machine-*"}},
+		expected: false,
+	}, {
+		filter:   logfwd.Filter{ExcludeEntity: []string{"unit-mysql-0"}},
+		expected: false,
+	}, {
+		filter:   logfwd.Filter{IncludeModule: []string{"juju.worker"}},
+		expected: true,
+	}, {
+		filter:   logfwd.Filter{IncludeModule: []string{"juju.work"}},
+		expected: false,
+	}, {
+		filter:   logfwd.Filter{ExcludeModule: []string{"juju.worker.uniter"}},
+		expected: false,
+	}, {
+		filter:   logfwd.Filter{ExcludeModule: []string{"juju.apiserver"}},
+		expected: true,
+	}} {
+		c.Logf("test %d: %+v", i, test.filter)
+		c.Check(test.filter.Match(rec), gc.Equals, test.expected)
+	}
+}
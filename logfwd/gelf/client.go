@@ -0,0 +1,145 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package gelf
+
+import (
+	"bufio"
+	"crypto/tls"
+	"encoding/json"
+	"net"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+
+	"github.com/juju/juju/logfwd"
+)
+
+const (
+	// dialTimeout is how long connecting to the input may take.
+	dialTimeout = 30 * time.Second
+
+	// writeTimeout is how long sending a batch may take.
+	writeTimeout = 30 * time.Second
+)
+
+// Message is a GELF 1.1 message. The fields prefixed with an
+// underscore are additional fields.
+type Message struct {
+	Version        string  `json:"version"`
+	Host           string  `json:"host"`
+	ShortMessage   string  `json:"short_message"`
+	Timestamp      float64 `json:"timestamp"`
+	Level          int     `json:"level"`
+	RecordID       int64   `json:"_record_id"`
+	ControllerUUID string  `json:"_controller_uuid"`
+	ModelUUID      string  `json:"_model_uuid"`
+	OriginType     string  `json:"_origin_type"`
+	OriginName     string  `json:"_origin_name,omitempty"`
+	Module         string  `json:"_module"`
+	Location       string  `json:"_location"`
+	JujuLevel      string  `json:"_juju_level"`
+}
+
+// MessageFromRecord returns the GELF message for the record.
+func MessageFromRecord(rec logfwd.Record) Message {
+	host := rec.Origin.Hostname
+	if host == "" {
+		host = rec.Origin.Type.String() + "-" + rec.Origin.Name
+	}
+	return Message{
+		Version:        "1.1",
+		Host:           host,
+		ShortMessage:   rec.Message,
+		Timestamp:      float64(rec.Timestamp.UnixNano()/int64(time.Millisecond)) / 1000,
+		Level:          severity(rec.Level),
+		RecordID:       rec.ID,
+		ControllerUUID: rec.Origin.ControllerUUID,
+		ModelUUID:      rec.Origin.ModelUUID,
+		OriginType:     rec.Origin.Type.String(),
+		OriginName:     rec.Origin.Name,
+		Module:         rec.Location.Module,
+		Location:       rec.Location.String(),
+		JujuLevel:      rec.Level.String(),
+	}
+}
+
+// severity returns the syslog severity for the level, which is what
+// GELF levels are.
+func severity(level loggo.Level) int {
+	switch level {
+	case loggo.CRITICAL:
+		return 2
+	case loggo.ERROR:
+		return 3
+	case loggo.WARNING:
+		return 4
+	case loggo.INFO:
+		return 6
+	default:
+		return 7
+	}
+}
+
+// Client sends batches of log records to a GELF TCP input.
+type Client struct {
+	conn net.Conn
+	w    *bufio.Writer
+}
+
+// Open connects to the input at the endpoint in the config. The
+// connection uses TLS if any TLS options are set.
+func Open(cfg logfwd.SinkConfig) (*Client, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	dialer := &net.Dialer{Timeout: dialTimeout}
+	var conn net.Conn
+	var err error
+	if cfg.CACert != "" || cfg.ClientCert != "" {
+		var tlsCfg *tls.Config
+		tlsCfg, err = cfg.TLSConfig()
+		if err != nil {
+			return nil, errors.Annotate(err, "constructing TLS config")
+		}
+		conn, err = tls.DialWithDialer(dialer, "tcp", cfg.Endpoint, tlsCfg)
+	} else {
+		conn, err = dialer.Dial("tcp", cfg.Endpoint)
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot connect to %q", cfg.Endpoint)
+	}
+	return NewClient(conn), nil
+}
+
+// NewClient returns a client that sends messages over the connection.
+func NewClient(conn net.Conn) *Client {
+	return &Client{
+		conn: conn,
+		w:    bufio.NewWriter(conn),
+	}
+}
+
+// SendBatch sends the records to the input. Each message is a JSON
+// object terminated by a null byte.
+func (client *Client) SendBatch(recs []logfwd.Record) error {
+	if err := client.conn.SetWriteDeadline(time.Now().Add(writeTimeout)); err != nil {
+		return errors.Trace(err)
+	}
+	for _, rec := range recs {
+		data, err := json.Marshal(MessageFromRecord(rec))
+		if err != nil {
+			return errors.Annotate(err, "cannot marshal message")
+		}
+		if _, err := client.w.Write(append(data, 0)); err != nil {
+			return errors.Annotate(err, "cannot send message")
+		}
+	}
+	return errors.Annotate(client.w.Flush(), "cannot send message")
+}
+
+// Close closes the client's connection.
+func (client *Client) Close() error {
+	return errors.Trace(client.conn.Close())
+}
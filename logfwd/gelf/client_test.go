@@ -0,0 +1,123 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package gelf_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"time"
+
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/gelf"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ClientSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ClientSuite{})
+
+func (s *ClientSuite) TestMessageFromRecord(c *gc.C) {
+	msg := gelf.MessageFromRecord(record)
+	c.Check(msg, jc.DeepEquals, gelf.Message{
+		Version:        "1.1",
+		Host:           "machine-1.example.com",
+		ShortMessage:   "hook failed",
+		Timestamp:      1476309600.25,
+		Level:          3,
+		RecordID:       10,
+		ControllerUUID: "9f484882-2f18-4fd2-967d-db9663db7bea",
+		ModelUUID:      "deadbeef-2f18-4fd2-967d-db9663db7bea",
+		OriginType:     "unit",
+		OriginName:     "mysql/0",
+		Module:         "juju.worker.uniter",
+		Location:       "uniter.go:42",
+		JujuLevel:      "ERROR",
+	})
+}
+
+func (s *ClientSuite) TestSendBatch(c *gc.C) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	c.Assert(err, jc.ErrorIsNil)
+	defer listener.Close()
+	received := make(chan []string)
+	go func() {
+		conn, err := listener.Accept()
+		c.Check(err, jc.ErrorIsNil)
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		var msgs []string
+		for len(msgs) < 2 {
+			msg, err := r.ReadString(0)
+			if !c.Check(err, jc.ErrorIsNil) {
+				break
+			}
+			msgs = append(msgs, msg[:len(msg)-1])
+		}
+		received <- msgs
+	}()
+
+	client, err := gelf.Open(logfwd.SinkConfig{
+		Enabled:  true,
+		Type:     logfwd.SinkGELF,
+		Endpoint: listener.Addr().String(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer client.Close()
+	rec2 := record
+	rec2.Message = "hook failed again"
+	err = client.SendBatch([]logfwd.Record{record, rec2})
+	c.Assert(err, jc.ErrorIsNil)
+
+	select {
+	case msgs := <-received:
+		c.Assert(msgs, gc.HasLen, 2)
+		var msg gelf.Message
+		err := json.Unmarshal([]byte(msgs[1]), &msg)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(msg, jc.DeepEquals, gelf.MessageFromRecord(rec2))
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for messages")
+	}
+}
+
+func (s *ClientSuite) TestOpenConnectionRefused(c *gc.C) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	c.Assert(err, jc.ErrorIsNil)
+	addr := listener.Addr().String()
+	listener.Close()
+
+	_, err = gelf.Open(logfwd.SinkConfig{
+		Enabled:  true,
+		Type:     logfwd.SinkGELF,
+		Endpoint: addr,
+	})
+	c.Check(err, gc.ErrorMatches, `cannot connect to ".*": .*`)
+}
+
+var record = logfwd.Record{
+	ID: 10,
+	Origin: logfwd.Origin{
+		ControllerUUID: "9f484882-2f18-4fd2-967d-db9663db7bea",
+		ModelUUID:      "deadbeef-2f18-4fd2-967d-db9663db7bea",
+		Hostname:       "machine-1.example.com",
+		Type:           logfwd.OriginTypeUnit,
+		Name:           "mysql/0",
+	},
+	Timestamp: time.Date(2016, 10, 12, 22, 0, 0, 250000000, time.UTC),
+	Level:     loggo.ERROR,
+	Location: logfwd.SourceLocation{
+		Module:   "juju.worker.uniter",
+		Filename: "uniter.go",
+		Line:     42,
+	},
+	Message: "hook failed",
+}
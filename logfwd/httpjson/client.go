@@ -0,0 +1,148 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package httpjson
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/logfwd"
+)
+
+// requestTimeout is how long a request to the endpoint may take.
+const requestTimeout = 30 * time.Second
+
+// maxErrorBody is how much of an error response is reported.
+const maxErrorBody = 512
+
+// Doer sends HTTP requests. It is implemented by *http.Client.
+type Doer interface {
+	Do(*http.Request) (*http.Response, error)
+}
+
+// Message is the JSON representation of a log record.
+type Message struct {
+	ID              int64     `json:"id"`
+	Timestamp       time.Time `json:"timestamp"`
+	ControllerUUID  string    `json:"controller-uuid"`
+	ModelUUID       string    `json:"model-uuid"`
+	Hostname        string    `json:"hostname,omitempty"`
+	OriginType      string    `json:"origin-type"`
+	OriginName      string    `json:"origin-name,omitempty"`
+	Software        string    `json:"software,omitempty"`
+	SoftwareVersion string    `json:"software-version,omitempty"`
+	Level           string    `json:"level"`
+	Module          string    `json:"module"`
+	Location        string    `json:"location"`
+	Message         string    `json:"message"`
+}
+
+// MessageFromRecord returns the JSON representation of the record.
+func MessageFromRecord(rec logfwd.Record) Message {
+	msg := Message{
+		ID:             rec.ID,
+		Timestamp:      rec.Timestamp.UTC(),
+		ControllerUUID: rec.Origin.ControllerUUID,
+		ModelUUID:      rec.Origin.ModelUUID,
+		Hostname:       rec.Origin.Hostname,
+		OriginType:     rec.Origin.Type.String(),
+		OriginName:     rec.Origin.Name,
+		Software:       rec.Origin.Software.Name,
+		Level:          rec.Level.String(),
+		Module:         rec.Location.Module,
+		Location:       rec.Location.String(),
+		Message:        rec.Message,
+	}
+	if msg.Software != "" {
+		msg.SoftwareVersion = rec.Origin.Software.Version.String()
+	}
+	return msg
+}
+
+// Client sends batches of log records to an HTTP endpoint.
+type Client struct {
+	// URL is the endpoint to which batches are posted.
+	URL string
+
+	// Doer sends the requests.
+	Doer Doer
+}
+
+// Open returns a client for the endpoint in the config.
+func Open(cfg logfwd.SinkConfig) (*Client, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	doer, err := NewHTTPClient(cfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &Client{
+		URL:  cfg.Endpoint,
+		Doer: doer,
+	}, nil
+}
+
+// NewHTTPClient returns an HTTP client which connects with the TLS
+// options in the config.
+func NewHTTPClient(cfg logfwd.SinkConfig) (*http.Client, error) {
+	tlsCfg, err := cfg.TLSConfig()
+	if err != nil {
+		return nil, errors.Annotate(err, "constructing TLS config")
+	}
+	return &http.Client{
+		Transport: &http.Transport{
+			Proxy:               http.ProxyFromEnvironment,
+			TLSClientConfig:     tlsCfg,
+			TLSHandshakeTimeout: 10 * time.Second,
+		},
+		Timeout: requestTimeout,
+	}, nil
+}
+
+// SendBatch posts the records to the endpoint as a JSON array.
+func (client *Client) SendBatch(recs []logfwd.Record) error {
+	msgs := make([]Message, len(recs))
+	for i, rec := range recs {
+		msgs[i] = MessageFromRecord(rec)
+	}
+	return errors.Trace(Post(client.Doer, client.URL, msgs))
+}
+
+// Close implements io.Closer. There is no connection to close.
+func (client *Client) Close() error {
+	return nil
+}
+
+// Post sends the JSON encoding of the body to the URL. Any response
+// other than a 2xx one is an error.
+func Post(doer Doer, url string, body interface{}) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return errors.Annotate(err, "cannot marshal request")
+	}
+	req, err := http.NewRequest("POST", url, bytes.NewReader(data))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := doer.Do(req)
+	if err != nil {
+		return errors.Annotatef(err, "cannot post to %q", url)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		return errors.Errorf("cannot post to %q: %s: %s", url, resp.Status, bytes.TrimSpace(msg))
+	}
+	// Drain the body so the connection may be reused.
+	io.Copy(ioutil.Discard, resp.Body)
+	return nil
+}
@@ -0,0 +1,137 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package httpjson_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/httpjson"
+)
+
+type ClientSuite struct {
+	testing.IsolationSuite
+
+	requests []*http.Request
+	bodies   [][]byte
+	status   int
+}
+
+var _ = gc.Suite(&ClientSuite{})
+
+func (s *ClientSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.requests = nil
+	s.bodies = nil
+	s.status = http.StatusOK
+}
+
+func (s *ClientSuite) newServer(c *gc.C) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, err := ioutil.ReadAll(req.Body)
+		c.Check(err, jc.ErrorIsNil)
+		s.requests = append(s.requests, req)
+		s.bodies = append(s.bodies, body)
+		w.WriteHeader(s.status)
+		if s.status != http.StatusOK {
+			w.Write([]byte("no space left\n"))
+		}
+	}))
+	s.AddCleanup(func(*gc.C) { server.Close() })
+	return server
+}
+
+func (s *ClientSuite) TestSendBatch(c *gc.C) {
+	server := s.newServer(c)
+	client, err := httpjson.Open(logfwd.SinkConfig{
+		Enabled:  true,
+		Type:     logfwd.SinkHTTPJSON,
+		Endpoint: server.URL + "/logs",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	rec := record
+	rec2 := record
+	rec2.ID = 11
+	rec2.Message = "sorted"
+	err = client.SendBatch([]logfwd.Record{rec, rec2})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.requests, gc.HasLen, 1)
+	c.Check(s.requests[0].Method, gc.Equals, "POST")
+	c.Check(s.requests[0].URL.Path, gc.Equals, "/logs")
+	c.Check(s.requests[0].Header.Get("Content-Type"), gc.Equals, "application/json")
+	var msgs []map[string]interface{}
+	err = json.Unmarshal(s.bodies[0], &msgs)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(msgs, gc.HasLen, 2)
+	c.Check(msgs[0], jc.DeepEquals, map[string]interface{}{
+		"id":               float64(10),
+		"timestamp":        "2016-10-12T22:00:00Z",
+		"controller-uuid":  "9f484882-2f18-4fd2-967d-db9663db7bea",
+		"model-uuid":       "deadbeef-2f18-4fd2-967d-db9663db7bea",
+		"hostname":         "machine-1.example.com",
+		"origin-type":      "machine",
+		"origin-name":      "1",
+		"software":         "jujud-machine-agent",
+		"software-version": "2.0.1",
+		"level":            "ERROR",
+		"module":           "juju.worker.uniter",
+		"location":         "uniter.go:42",
+		"message":          "hook failed",
+	})
+	c.Check(msgs[1]["message"], gc.Equals, "sorted")
+}
+
+func (s *ClientSuite) TestSendBatchErrorResponse(c *gc.C) {
+	server := s.newServer(c)
+	s.status = http.StatusInsufficientStorage
+	client := &httpjson.Client{URL: server.URL, Doer: http.DefaultClient}
+
+	err := client.SendBatch([]logfwd.Record{record})
+	c.Check(err, gc.ErrorMatches, `cannot post to ".*": 507 Insufficient Storage: no space left`)
+}
+
+func (s *ClientSuite) TestOpenInvalidConfig(c *gc.C) {
+	_, err := httpjson.Open(logfwd.SinkConfig{
+		Enabled:  true,
+		Type:     logfwd.SinkHTTPJSON,
+		Endpoint: "logs.example.com:8080",
+	})
+	c.Check(err, gc.ErrorMatches, `Endpoint "logs.example.com:8080" \(expected an http or https URL\) not valid`)
+}
+
+var record = logfwd.Record{
+	ID: 10,
+	Origin: logfwd.Origin{
+		ControllerUUID: "9f484882-2f18-4fd2-967d-db9663db7bea",
+		ModelUUID:      "deadbeef-2f18-4fd2-967d-db9663db7bea",
+		Hostname:       "machine-1.example.com",
+		Type:           logfwd.OriginTypeMachine,
+		Name:           "1",
+		Software: logfwd.Software{
+			PrivateEnterpriseNumber: 28978,
+			Name:                    "jujud-machine-agent",
+			Version:                 version.MustParse("2.0.1"),
+		},
+	},
+	Timestamp: time.Date(2016, 10, 12, 22, 0, 0, 0, time.UTC),
+	Level:     loggo.ERROR,
+	Location: logfwd.SourceLocation{
+		Module:   "juju.worker.uniter",
+		Filename: "uniter.go",
+		Line:     42,
+	},
+	Message: "hook failed",
+}
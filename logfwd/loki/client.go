@@ -0,0 +1,123 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package loki
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/httpjson"
+)
+
+// PushRequest is the body of a request to the push endpoint.
+type PushRequest struct {
+	Streams []Stream `json:"streams"`
+}
+
+// Stream holds the entries of a single log stream, which is
+// identified by its labels.
+type Stream struct {
+	// Labels identify the stream.
+	Labels map[string]string `json:"stream"`
+
+	// Values holds the entries in the stream, each of which is
+	// a timestamp in nanoseconds since the epoch and a log line.
+	Values [][2]string `json:"values"`
+}
+
+// Labels returns the labels of the stream to which the record
+// belongs. Only fields with few distinct values are labels, to keep
+// the number of streams down.
+func Labels(rec logfwd.Record) map[string]string {
+	labels := map[string]string{
+		"job":             "juju",
+		"controller_uuid": rec.Origin.ControllerUUID,
+		"model_uuid":      rec.Origin.ModelUUID,
+		"level":           rec.Level.String(),
+	}
+	if rec.Origin.Name != "" {
+		labels["origin_type"] = rec.Origin.Type.String()
+		labels["origin_name"] = rec.Origin.Name
+	}
+	return labels
+}
+
+// Line returns the log line for the record, in the format of
+// juju debug-log.
+func Line(rec logfwd.Record) string {
+	return fmt.Sprintf("%s %s %s", rec.Location.Module, rec.Location, rec.Message)
+}
+
+// NewPushRequest groups the records into streams by their labels.
+// Within each stream the records keep their order.
+func NewPushRequest(recs []logfwd.Record) PushRequest {
+	var req PushRequest
+	streams := make(map[string]int)
+	for _, rec := range recs {
+		labels := Labels(rec)
+		key := labelsKey(labels)
+		i, ok := streams[key]
+		if !ok {
+			i = len(req.Streams)
+			streams[key] = i
+			req.Streams = append(req.Streams, Stream{Labels: labels})
+		}
+		req.Streams[i].Values = append(req.Streams[i].Values, [2]string{
+			strconv.FormatInt(rec.Timestamp.UnixNano(), 10),
+			Line(rec),
+		})
+	}
+	return req
+}
+
+func labelsKey(labels map[string]string) string {
+	pairs := make([]string, 0, len(labels))
+	for name, value := range labels {
+		pairs = append(pairs, name+"="+strconv.Quote(value))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// Client pushes batches of log records to a Loki-compatible push
+// endpoint.
+type Client struct {
+	// URL is the push endpoint, for example
+	// https://loki.example.com:3100/loki/api/v1/push.
+	URL string
+
+	// Doer sends the requests.
+	Doer httpjson.Doer
+}
+
+// Open returns a client for the push endpoint in the config.
+func Open(cfg logfwd.SinkConfig) (*Client, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	doer, err := httpjson.NewHTTPClient(cfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &Client{
+		URL:  cfg.Endpoint,
+		Doer: doer,
+	}, nil
+}
+
+// SendBatch pushes the records to the endpoint.
+func (client *Client) SendBatch(recs []logfwd.Record) error {
+	err := httpjson.Post(client.Doer, client.URL, NewPushRequest(recs))
+	return errors.Trace(err)
+}
+
+// Close implements io.Closer. There is no connection to close.
+func (client *Client) Close() error {
+	return nil
+}
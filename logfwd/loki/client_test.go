@@ -0,0 +1,110 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package loki_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/loki"
+)
+
+type ClientSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ClientSuite{})
+
+func (s *ClientSuite) TestNewPushRequest(c *gc.C) {
+	rec2 := record
+	rec2.Timestamp = rec2.Timestamp.Add(time.Second)
+	rec2.Level = loggo.INFO
+	rec3 := record
+	rec3.Timestamp = rec3.Timestamp.Add(2 * time.Second)
+	rec3.Message = "hook failed again"
+
+	req := loki.NewPushRequest([]logfwd.Record{record, rec2, rec3})
+	c.Check(req, jc.DeepEquals, loki.PushRequest{
+		Streams: []loki.Stream{{
+			Labels: map[string]string{
+				"job":             "juju",
+				"controller_uuid": "9f484882-2f18-4fd2-967d-db9663db7bea",
+				"model_uuid":      "deadbeef-2f18-4fd2-967d-db9663db7bea",
+				"level":           "ERROR",
+				"origin_type":     "unit",
+				"origin_name":     "mysql/0",
+			},
+			Values: [][2]string{
+				{"1476309600000000000", "juju.worker.uniter uniter.go:42 hook failed"},
+				{"1476309602000000000", "juju.worker.uniter uniter.go:42 hook failed again"},
+			},
+		}, {
+			Labels: map[string]string{
+				"job":             "juju",
+				"controller_uuid": "9f484882-2f18-4fd2-967d-db9663db7bea",
+				"model_uuid":      "deadbeef-2f18-4fd2-967d-db9663db7bea",
+				"level":           "INFO",
+				"origin_type":     "unit",
+				"origin_name":     "mysql/0",
+			},
+			Values: [][2]string{
+				{"1476309601000000000", "juju.worker.uniter uniter.go:42 hook failed"},
+			},
+		}},
+	})
+}
+
+func (s *ClientSuite) TestSendBatch(c *gc.C) {
+	var bodies [][]byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		c.Check(req.URL.Path, gc.Equals, "/loki/api/v1/push")
+		body, err := ioutil.ReadAll(req.Body)
+		c.Check(err, jc.ErrorIsNil)
+		bodies = append(bodies, body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client, err := loki.Open(logfwd.SinkConfig{
+		Enabled:  true,
+		Type:     logfwd.SinkLoki,
+		Endpoint: server.URL + "/loki/api/v1/push",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = client.SendBatch([]logfwd.Record{record})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(bodies, gc.HasLen, 1)
+	var req loki.PushRequest
+	err = json.Unmarshal(bodies[0], &req)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(req, jc.DeepEquals, loki.NewPushRequest([]logfwd.Record{record}))
+}
+
+var record = logfwd.Record{
+	ID: 10,
+	Origin: logfwd.Origin{
+		ControllerUUID: "9f484882-2f18-4fd2-967d-db9663db7bea",
+		ModelUUID:      "deadbeef-2f18-4fd2-967d-db9663db7bea",
+		Type:           logfwd.OriginTypeUnit,
+		Name:           "mysql/0",
+	},
+	Timestamp: time.Date(2016, 10, 12, 22, 0, 0, 0, time.UTC),
+	Level:     loggo.ERROR,
+	Location: logfwd.SourceLocation{
+		Module:   "juju.worker.uniter",
+		Filename: "uniter.go",
+		Line:     42,
+	},
+	Message: "hook failed",
+}
@@ -0,0 +1,271 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/version"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/core/description"
+	"github.com/juju/juju/tools"
+)
+
+// A model archive is a gzipped tarball holding a serialized model,
+// followed by the tools, charms and resources that the model uses. It
+// allows a model to be moved between controllers that can't reach
+// each other. The model comes first so that it can be imported before
+// any of its binaries are uploaded.
+const (
+	archiveModelFile    = "model.yaml"
+	archiveToolsDir     = "tools"
+	archiveCharmsDir    = "charms"
+	archiveResourcesDir = "resources"
+)
+
+// ArchiveBackend is implemented by *state.State for the model being
+// exported, but defined as an interface for easier testing.
+type ArchiveBackend interface {
+	StateExporter
+	UploadBackend
+}
+
+// ExportArchive writes a model archive for the model of the backend to
+// w. The archive holds the serialized model, as returned by
+// ExportModel, and the binaries that UploadBinaries would send to a
+// migration's target controller. It can be imported into another
+// controller with ImportArchive.
+func ExportArchive(backend ArchiveBackend, w io.Writer) error {
+	modelBytes, err := ExportModel(backend)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	model, err := description.Deserialize(modelBytes)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// There's no target controller; the binaries are written to the
+	// archive instead.
+	config := NewUploadBinariesConfig(backend, model, nil)
+	return errors.Trace(writeArchive(w, modelBytes, config))
+}
+
+func writeArchive(w io.Writer, modelBytes []byte, config UploadBinariesConfig) error {
+	archive := newArchiveWriter(w)
+	if err := archive.writeFile(archiveModelFile, int64(len(modelBytes)), bytes.NewReader(modelBytes)); err != nil {
+		return errors.Annotate(err, "writing model")
+	}
+	if err := sendBinaries(config, archive, archive, archive); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(archive.Close())
+}
+
+// archiveWriter writes the files of a model archive. It implements
+// the uploader interfaces so that the binaries of a model can be
+// written to an archive by the same code that sends them to a
+// migration's target controller.
+type archiveWriter struct {
+	gzip *gzip.Writer
+	tar  *tar.Writer
+}
+
+func newArchiveWriter(w io.Writer) *archiveWriter {
+	gzipWriter := gzip.NewWriter(w)
+	return &archiveWriter{
+		gzip: gzipWriter,
+		tar:  tar.NewWriter(gzipWriter),
+	}
+}
+
+// UploadTools implements ToolsUploader.
+func (w *archiveWriter) UploadTools(r io.ReadSeeker, vers version.Binary, _ ...string) (tools.List, error) {
+	if err := w.writeSeeker(path.Join(archiveToolsDir, vers.String()), r); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return tools.List{{Version: vers}}, nil
+}
+
+// UploadCharm implements CharmUploader.
+func (w *archiveWriter) UploadCharm(curl *charm.URL, r io.ReadSeeker) (*charm.URL, error) {
+	// Charm URLs contain slashes, so they're escaped to make a
+	// single path element.
+	name := path.Join(archiveCharmsDir, url.QueryEscape(curl.String()))
+	if err := w.writeSeeker(name, r); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return curl, nil
+}
+
+// UploadResource implements ResourceUploader. The model UUID isn't
+// recorded; resources are imported into the model of the archive.
+func (w *archiveWriter) UploadResource(_, application, name string, r io.ReadSeeker) error {
+	return errors.Trace(w.writeSeeker(path.Join(archiveResourcesDir, application, name), r))
+}
+
+func (w *archiveWriter) writeSeeker(name string, r io.ReadSeeker) error {
+	size, err := r.Seek(0, os.SEEK_END)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := r.Seek(0, os.SEEK_SET); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Annotatef(w.writeFile(name, size, r), "writing %s", name)
+}
+
+func (w *archiveWriter) writeFile(name string, size int64, r io.Reader) error {
+	header := &tar.Header{
+		Name:    name,
+		Mode:    0644,
+		Size:    size,
+		ModTime: time.Now(),
+	}
+	if err := w.tar.WriteHeader(header); err != nil {
+		return errors.Trace(err)
+	}
+	_, err := io.Copy(w.tar, r)
+	return errors.Trace(err)
+}
+
+// Close flushes the archive. It doesn't close the underlying writer.
+func (w *archiveWriter) Close() error {
+	if err := w.tar.Close(); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(w.gzip.Close())
+}
+
+// ArchiveTarget defines the operations needed to import a model
+// archive into a controller. It is typically implemented by the
+// migrationtarget facade, for the import, and an API client, for the
+// uploads.
+type ArchiveTarget interface {
+	// Import creates a model from its serialized description.
+	Import(bytes []byte) error
+
+	// Abort removes a model that has been imported but not
+	// activated.
+	Abort(modelUUID string) error
+
+	// UploadModelTools and UploadModelCharm store the tools and
+	// charms used by the imported model in that model.
+	UploadModelTools(modelUUID string, vers version.Binary, content io.ReadSeeker) error
+	UploadModelCharm(modelUUID string, curl *charm.URL, content io.ReadSeeker) error
+
+	ResourceUploader
+}
+
+// ImportArchive reads a model archive, as written by ExportArchive,
+// from r. The model in the archive is imported into the target, and
+// then the model's binaries are uploaded to it. If the binaries can't
+// be uploaded, the imported model is removed again. The tag of the
+// imported model is returned; the model still needs to be activated
+// once the import is complete.
+func ImportArchive(r io.Reader, target ArchiveTarget) (names.ModelTag, error) {
+	gzipReader, err := gzip.NewReader(r)
+	if err != nil {
+		return names.ModelTag{}, errors.Annotate(err, "reading model archive")
+	}
+	defer gzipReader.Close()
+	tarReader := tar.NewReader(gzipReader)
+
+	header, err := tarReader.Next()
+	if err == io.EOF {
+		return names.ModelTag{}, errors.New("model archive is empty")
+	} else if err != nil {
+		return names.ModelTag{}, errors.Annotate(err, "reading model archive")
+	}
+	if header.Name != archiveModelFile {
+		return names.ModelTag{}, errors.Errorf("expected %s at start of model archive, got %q", archiveModelFile, header.Name)
+	}
+	modelBytes, err := ioutil.ReadAll(tarReader)
+	if err != nil {
+		return names.ModelTag{}, errors.Annotate(err, "reading model")
+	}
+	model, err := description.Deserialize(modelBytes)
+	if err != nil {
+		return names.ModelTag{}, errors.Annotate(err, "reading model")
+	}
+	if err := target.Import(modelBytes); err != nil {
+		return names.ModelTag{}, errors.Annotate(err, "importing model")
+	}
+
+	modelUUID := model.Tag().Id()
+	if err := uploadArchiveFiles(tarReader, target, modelUUID); err != nil {
+		if abortErr := target.Abort(modelUUID); abortErr != nil {
+			logger.Errorf("failed to remove imported model: %v", abortErr)
+		}
+		return names.ModelTag{}, errors.Trace(err)
+	}
+	return model.Tag(), nil
+}
+
+func uploadArchiveFiles(tarReader *tar.Reader, target ArchiveTarget, modelUUID string) error {
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return errors.Annotate(err, "reading model archive")
+		}
+		if err := uploadArchiveFile(target, modelUUID, header.Name, tarReader); err != nil {
+			return errors.Annotatef(err, "uploading %s", header.Name)
+		}
+	}
+}
+
+func uploadArchiveFile(target ArchiveTarget, modelUUID, name string, r io.Reader) error {
+	parts := strings.Split(name, "/")
+	switch {
+	case len(parts) == 2 && parts[0] == archiveToolsDir:
+		vers, err := version.ParseBinary(parts[1])
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return uploadArchiveContent(r, func(content io.ReadSeeker) error {
+			return target.UploadModelTools(modelUUID, vers, content)
+		})
+	case len(parts) == 2 && parts[0] == archiveCharmsDir:
+		curlStr, err := url.QueryUnescape(parts[1])
+		if err != nil {
+			return errors.Trace(err)
+		}
+		curl, err := charm.ParseURL(curlStr)
+		if err != nil {
+			return errors.Annotate(err, "bad charm URL")
+		}
+		return uploadArchiveContent(r, func(content io.ReadSeeker) error {
+			return target.UploadModelCharm(modelUUID, curl, content)
+		})
+	case len(parts) == 3 && parts[0] == archiveResourcesDir:
+		return uploadArchiveContent(r, func(content io.ReadSeeker) error {
+			return target.UploadResource(modelUUID, parts[1], parts[2], content)
+		})
+	}
+	return errors.NotValidf("model archive file %q", name)
+}
+
+// uploadArchiveContent copies the content of an archive file to a
+// temporary file, because the uploaders need to be able to seek.
+func uploadArchiveContent(r io.Reader, upload func(io.ReadSeeker) error) error {
+	content, _, cleanup, err := streamThroughTempFile(r)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer cleanup()
+	return errors.Trace(upload(content))
+}
@@ -0,0 +1,168 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"github.com/juju/version"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/core/description"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/binarystorage"
+	"github.com/juju/juju/state/storage"
+	"github.com/juju/juju/tools"
+)
+
+// CloneModel creates a copy of the model of st in the same controller,
+// under the identity given by args. The model is exported, stripped of
+// its instances and agent credentials by description.Clone, and
+// imported as a new model, which provisions machines of its own. The
+// charms, tools and resources used by the model are then copied into
+// the new model.
+//
+// The new model and its state are returned; the caller is responsible
+// for closing the state.
+func CloneModel(st *state.State, args description.CloneArgs) (_ *state.Model, _ *state.State, err error) {
+	model, err := st.Export()
+	if err != nil {
+		return nil, nil, errors.Annotate(err, "exporting model")
+	}
+	clone, err := description.Clone(model, args)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	dbModel, dbState, err := st.Import(clone)
+	if err != nil {
+		return nil, nil, errors.Annotate(err, "importing model")
+	}
+	defer func() {
+		if err != nil {
+			if err := dbState.RemoveImportingModelDocs(); err != nil {
+				logger.Errorf("failed to remove cloned model: %v", err)
+			}
+			dbState.Close()
+		}
+	}()
+
+	config := NewUploadBinariesConfig(st, clone, nil)
+	copier := &cloneCopier{source: st, target: dbState}
+	if err := sendBinaries(config, copier, copier, copier); err != nil {
+		return nil, nil, errors.Annotate(err, "copying binaries")
+	}
+	if err := dbModel.SetMigrationMode(state.MigrationModeActive); err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return dbModel, dbState, nil
+}
+
+// cloneCopier implements the uploader interfaces by storing the
+// binaries directly in the state of a cloned model.
+type cloneCopier struct {
+	source UploadBackend
+	target *state.State
+}
+
+// UploadTools implements ToolsUploader. Tools already available to the
+// cloned model, such as those in the controller's catalogue, aren't
+// copied.
+func (c *cloneCopier) UploadTools(r io.ReadSeeker, vers version.Binary, _ ...string) (tools.List, error) {
+	result := tools.List{{Version: vers}}
+	toolsStorage, err := c.target.ToolsStorage()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer toolsStorage.Close()
+	if _, err := toolsStorage.Metadata(vers.String()); err == nil {
+		return result, nil
+	} else if !errors.IsNotFound(err) {
+		return nil, errors.Trace(err)
+	}
+
+	hash := sha256.New()
+	size, err := io.Copy(hash, r)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if _, err := r.Seek(0, os.SEEK_SET); err != nil {
+		return nil, errors.Trace(err)
+	}
+	metadata := binarystorage.Metadata{
+		Version: vers.String(),
+		Size:    size,
+		SHA256:  hex.EncodeToString(hash.Sum(nil)),
+	}
+	if err := toolsStorage.Add(r, metadata); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result, nil
+}
+
+// UploadCharm implements CharmUploader. The charm's metadata is taken
+// from the model being cloned.
+func (c *cloneCopier) UploadCharm(curl *charm.URL, r io.ReadSeeker) (*charm.URL, error) {
+	ch, err := c.source.Charm(curl)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	macaroon, err := ch.Macaroon()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	size, err := r.Seek(0, os.SEEK_END)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if _, err := r.Seek(0, os.SEEK_SET); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	uuid, err := utils.NewUUID()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	storagePath := fmt.Sprintf("charms/%s-%s", curl.String(), uuid)
+	charmStorage := storage.NewStorage(c.target.ModelUUID(), c.target.MongoSession())
+	if err := charmStorage.Put(storagePath, r, size); err != nil {
+		return nil, errors.Annotate(err, "cannot add charm to storage")
+	}
+	_, err = c.target.AddCharm(state.CharmInfo{
+		Charm:       ch,
+		ID:          curl,
+		StoragePath: storagePath,
+		SHA256:      ch.BundleSha256(),
+		Macaroon:    macaroon,
+	})
+	if err != nil {
+		if err := charmStorage.Remove(storagePath); err != nil {
+			logger.Errorf("cannot remove unrecorded charm archive from storage: %v", err)
+		}
+		return nil, errors.Trace(err)
+	}
+	return curl, nil
+}
+
+// UploadResource implements ResourceUploader. The resource metadata
+// was imported with the model, so only the content is stored.
+func (c *cloneCopier) UploadResource(_, application, name string, r io.ReadSeeker) error {
+	resources, err := c.target.Resources()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	res, err := resources.GetResource(application, name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := resources.SetResource(application, res.Username, res.Resource, r); err != nil {
+		return errors.Annotatef(err, "storing resource %q", name)
+	}
+	return nil
+}
@@ -5,4 +5,5 @@ package migration
 
 var (
 	GetCharmStoragePath = getCharmStoragePath
+	WriteArchive        = writeArchive
 )
@@ -4,22 +4,29 @@
 package migration
 
 import (
+	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
+	"strings"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/utils/set"
 	"github.com/juju/version"
 	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
 	"gopkg.in/mgo.v2"
 
 	"github.com/juju/juju/api"
+	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/core/description"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/binarystorage"
 	"github.com/juju/juju/state/storage"
+	jujustorage "github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/provider/registry"
 	"github.com/juju/juju/tools"
 )
 
@@ -72,6 +79,7 @@ type UploadBackend interface {
 	ModelUUID() string
 	MongoSession() *mgo.Session
 	ToolsStorage() (binarystorage.StorageCloser, error)
+	Resources() (state.Resources, error)
 }
 
 // CharmUploader defines a simple single method interface that is used to
@@ -86,6 +94,12 @@ type ToolsUploader interface {
 	UploadTools(io.ReadSeeker, version.Binary, ...string) (tools.List, error)
 }
 
+// ResourceUploader defines a simple single method interface that is used
+// to upload resources to the target controller
+type ResourceUploader interface {
+	UploadResource(modelUUID, application, name string, content io.ReadSeeker) error
+}
+
 // UploadBinariesConfig provides all the configuration that the UploadBinaries
 // function needs to operate. The functions are configurable for testing
 // purposes. To construct the config with the default functions, use
@@ -95,11 +109,18 @@ type UploadBinariesConfig struct {
 	Model  description.Model
 	Target api.Connection
 
-	GetCharmUploader func(api.Connection) CharmUploader
-	GetToolsUploader func(api.Connection) ToolsUploader
+	GetCharmUploader    func(api.Connection) CharmUploader
+	GetToolsUploader    func(api.Connection) ToolsUploader
+	GetResourceUploader func(api.Connection) ResourceUploader
 
 	GetStateStorage     func(UploadBackend) storage.Storage
 	GetCharmStoragePath func(UploadBackend, *charm.URL) (string, error)
+	GetResourceContent  func(UploadBackend, string, string) (io.ReadCloser, error)
+
+	// SetProgress, if not nil, is called after each charm or tools
+	// upload with the total number of bytes of charms and tools sent
+	// so far.
+	SetProgress func(charmBytes, toolsBytes int64) error
 }
 
 // NewUploadBinariesConfig constructs a `UploadBinariesConfig` with the default
@@ -114,7 +135,9 @@ func NewUploadBinariesConfig(backend UploadBackend, model description.Model, tar
 		GetCharmUploader:    getCharmUploader,
 		GetStateStorage:     getStateStorage,
 		GetToolsUploader:    getToolsUploader,
+		GetResourceUploader: getResourceUploader,
 		GetCharmStoragePath: getCharmStoragePath,
+		GetResourceContent:  getResourceContent,
 	}
 }
 
@@ -141,6 +164,12 @@ func (c *UploadBinariesConfig) Validate() error {
 	if c.GetCharmStoragePath == nil {
 		return errors.NotValidf("missing GetCharmStoragePath")
 	}
+	if c.GetResourceUploader == nil {
+		return errors.NotValidf("missing GetResourceUploader")
+	}
+	if c.GetResourceContent == nil {
+		return errors.NotValidf("missing GetResourceContent")
+	}
 	return nil
 }
 
@@ -150,17 +179,65 @@ func UploadBinaries(config UploadBinariesConfig) error {
 	if err := config.Validate(); err != nil {
 		return errors.Trace(err)
 	}
-	if err := uploadTools(config); err != nil {
+	return sendBinaries(config,
+		config.GetToolsUploader(config.Target),
+		config.GetCharmUploader(config.Target),
+		config.GetResourceUploader(config.Target),
+	)
+}
+
+// sendBinaries reads the binaries used by the model from the source
+// controller and passes them to the uploaders, which may send them to
+// another controller or write them to a model archive.
+func sendBinaries(
+	config UploadBinariesConfig,
+	toolsUploader ToolsUploader,
+	charmUploader CharmUploader,
+	resourceUploader ResourceUploader,
+) error {
+	progress := &uploadProgress{set: config.SetProgress}
+	if err := uploadTools(config, toolsUploader, progress); err != nil {
+		return errors.Trace(err)
+	}
+
+	if err := uploadCharms(config, charmUploader, progress); err != nil {
 		return errors.Trace(err)
 	}
 
-	if err := uploadCharms(config); err != nil {
+	// Resources are uploaded after the charms that define them.
+	if err := uploadResources(config, resourceUploader); err != nil {
 		return errors.Trace(err)
 	}
 
 	return nil
 }
 
+// uploadProgress keeps the running totals of the bytes of charms and
+// tools that have been sent, and reports them as they change.
+type uploadProgress struct {
+	set        func(charmBytes, toolsBytes int64) error
+	charmBytes int64
+	toolsBytes int64
+}
+
+func (p *uploadProgress) addCharm(size int64) error {
+	p.charmBytes += size
+	return p.report()
+}
+
+func (p *uploadProgress) addTools(size int64) error {
+	p.toolsBytes += size
+	return p.report()
+}
+
+func (p *uploadProgress) report() error {
+	if p.set == nil {
+		return nil
+	}
+	err := p.set(p.charmBytes, p.toolsBytes)
+	return errors.Annotate(err, "recording upload progress")
+}
+
 func getStateStorage(backend UploadBackend) storage.Storage {
 	return storage.NewStorage(backend.ModelUUID(), backend.MongoSession())
 }
@@ -173,7 +250,11 @@ func getCharmUploader(target api.Connection) CharmUploader {
 	return target.Client()
 }
 
-func uploadTools(config UploadBinariesConfig) error {
+func getResourceUploader(target api.Connection) ResourceUploader {
+	return target.Client()
+}
+
+func uploadTools(config UploadBinariesConfig, toolsUploader ToolsUploader, progress *uploadProgress) error {
 	storage, err := config.State.ToolsStorage()
 	if err != nil {
 		return errors.Trace(err)
@@ -181,7 +262,6 @@ func uploadTools(config UploadBinariesConfig) error {
 	defer storage.Close()
 
 	usedVersions := getUsedToolsVersions(config.Model)
-	toolsUploader := config.GetToolsUploader(config.Target)
 
 	for toolsVersion := range usedVersions {
 		logger.Debugf("send tools version %s to target", toolsVersion)
@@ -191,7 +271,7 @@ func uploadTools(config UploadBinariesConfig) error {
 		}
 		defer reader.Close()
 
-		content, cleanup, err := streamThroughTempFile(reader)
+		content, size, cleanup, err := streamThroughTempFile(reader)
 		if err != nil {
 			return errors.Trace(err)
 		}
@@ -202,24 +282,27 @@ func uploadTools(config UploadBinariesConfig) error {
 		if _, err := toolsUploader.UploadTools(content, toolsVersion); err != nil {
 			return errors.Trace(err)
 		}
+		if err := progress.addTools(size); err != nil {
+			return errors.Trace(err)
+		}
 	}
 
 	return nil
 }
 
-func streamThroughTempFile(r io.Reader) (_ io.ReadSeeker, cleanup func(), err error) {
+func streamThroughTempFile(r io.Reader) (_ io.ReadSeeker, size int64, cleanup func(), err error) {
 	tempFile, err := ioutil.TempFile("", "juju-tools")
 	if err != nil {
-		return nil, nil, errors.Trace(err)
+		return nil, 0, nil, errors.Trace(err)
 	}
 	defer func() {
 		if err != nil {
 			os.Remove(tempFile.Name())
 		}
 	}()
-	_, err = io.Copy(tempFile, r)
+	size, err = io.Copy(tempFile, r)
 	if err != nil {
-		return nil, nil, errors.Trace(err)
+		return nil, 0, nil, errors.Trace(err)
 	}
 	tempFile.Seek(0, 0)
 	rmTempFile := func() {
@@ -228,7 +311,7 @@ func streamThroughTempFile(r io.Reader) (_ io.ReadSeeker, cleanup func(), err er
 		os.Remove(filename)
 	}
 
-	return tempFile, rmTempFile, nil
+	return tempFile, size, rmTempFile, nil
 }
 
 func getUsedToolsVersions(model description.Model) map[version.Binary]bool {
@@ -257,10 +340,9 @@ func addToolsVersionForMachine(machine description.Machine, usedVersions map[ver
 	}
 }
 
-func uploadCharms(config UploadBinariesConfig) error {
+func uploadCharms(config UploadBinariesConfig, charmUploader CharmUploader, progress *uploadProgress) error {
 	storage := config.GetStateStorage(config.State)
 	usedCharms := getUsedCharms(config.Model)
-	charmUploader := config.GetCharmUploader(config.Target)
 
 	for _, charmUrl := range usedCharms.Values() {
 		logger.Debugf("send charm %s to target", charmUrl)
@@ -281,7 +363,7 @@ func uploadCharms(config UploadBinariesConfig) error {
 		}
 		defer reader.Close()
 
-		content, cleanup, err := streamThroughTempFile(reader)
+		content, size, cleanup, err := streamThroughTempFile(reader)
 		if err != nil {
 			return errors.Trace(err)
 		}
@@ -290,6 +372,9 @@ func uploadCharms(config UploadBinariesConfig) error {
 		if _, err := charmUploader.UploadCharm(curl, content); err != nil {
 			return errors.Annotate(err, "cannot upload charm")
 		}
+		if err := progress.addCharm(size); err != nil {
+			return errors.Trace(err)
+		}
 	}
 	return nil
 }
@@ -311,21 +396,452 @@ func getCharmStoragePath(st UploadBackend, curl *charm.URL) (string, error) {
 	return ch.StoragePath(), nil
 }
 
+func uploadResources(config UploadBinariesConfig, resourceUploader ResourceUploader) error {
+	modelUUID := config.Model.Tag().Id()
+
+	for _, application := range config.Model.Applications() {
+		for _, res := range application.Resources() {
+			// A resource that has never been uploaded on the source
+			// has no content to send.
+			if res.ApplicationRevision().Timestamp().IsZero() {
+				continue
+			}
+			logger.Debugf("send resource %s/%s to target", application.Name(), res.Name())
+			if err := uploadResource(config, resourceUploader, modelUUID, application.Name(), res.Name()); err != nil {
+				return errors.Annotatef(err, "resource %s/%s", application.Name(), res.Name())
+			}
+		}
+	}
+	return nil
+}
+
+func uploadResource(config UploadBinariesConfig, uploader ResourceUploader, modelUUID, application, name string) error {
+	reader, err := config.GetResourceContent(config.State, application, name)
+	if err != nil {
+		return errors.Annotate(err, "cannot get resource content")
+	}
+	defer reader.Close()
+
+	content, _, cleanup, err := streamThroughTempFile(reader)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer cleanup()
+
+	if err := uploader.UploadResource(modelUUID, application, name, content); err != nil {
+		return errors.Annotate(err, "cannot upload resource")
+	}
+	return nil
+}
+
+func getResourceContent(st UploadBackend, application, name string) (io.ReadCloser, error) {
+	resources, err := st.Resources()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	_, reader, err := resources.OpenResource(application, name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return reader, nil
+}
+
 // PrecheckBackend is implemented by *state.State but defined as an interface
 // for easier testing.
 type PrecheckBackend interface {
 	NeedsCleanup() (bool, error)
+	Export() (description.Model, error)
+}
+
+// PrecheckTarget is implemented by the MigrationTarget API client of
+// the controller that a model is being migrated to, which checks the
+// volumes against its own cloud. It is defined as an interface for
+// easier testing.
+type PrecheckTarget interface {
+	// MissingVolumes returns the provider volume IDs, of those given,
+	// that can not be seen by the target cloud using the storage pool.
+	MissingVolumes(pool *jujustorage.Config, volumeIDs []string) ([]string, error)
 }
 
 // Precheck checks the database state to make sure that the preconditions
-// for model migration are met.
-func Precheck(backend PrecheckBackend) error {
+// for model migration are met, and that the provider volumes of the
+// model can be seen by the target cloud. All of the unmet preconditions
+// are reported in the returned error.
+func Precheck(backend PrecheckBackend, target PrecheckTarget) error {
+	blockers, err := precheck(backend, target)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(blockers) > 0 {
+		return errors.Errorf("precheck failed: %s", strings.Join(blockers, "; "))
+	}
+	return nil
+}
+
+// precheck returns every precondition for model migration that isn't
+// met. An error is only returned if the checks couldn't be run.
+func precheck(backend PrecheckBackend, target PrecheckTarget) ([]string, error) {
+	var blockers []string
 	cleanupNeeded, err := backend.NeedsCleanup()
 	if err != nil {
-		return errors.Annotate(err, "precheck cleanups")
+		return nil, errors.Annotate(err, "precheck cleanups")
 	}
 	if cleanupNeeded {
-		return errors.New("precheck failed: cleanup needed")
+		blockers = append(blockers, "cleanup needed")
+	}
+
+	model, err := backend.Export()
+	if err != nil {
+		return nil, errors.Annotate(err, "precheck export")
+	}
+	volumeBlockers, err := precheckVolumes(model, target)
+	if err != nil {
+		return nil, errors.Annotate(err, "precheck volumes")
+	}
+	return append(blockers, volumeBlockers...), nil
+}
+
+func precheckVolumes(model description.Model, target PrecheckTarget) ([]string, error) {
+	// Unprovisioned volumes will be created by the target controller,
+	// so only those the provider has already created are checked.
+	volumeIDs := make(map[string][]string)
+	var poolNames []string
+	for _, volume := range model.Volumes() {
+		if !volume.Provisioned() {
+			continue
+		}
+		pool := volume.Pool()
+		if _, ok := volumeIDs[pool]; !ok {
+			poolNames = append(poolNames, pool)
+		}
+		volumeIDs[pool] = append(volumeIDs[pool], volume.VolumeID())
+	}
+	if len(poolNames) == 0 {
+		return nil, nil
+	}
+
+	pools := make(map[string]description.StoragePool)
+	for _, pool := range model.StoragePools() {
+		pools[pool.Name()] = pool
+	}
+	var blockers, missing []string
+	for _, poolName := range poolNames {
+		poolConfig, err := precheckPoolConfig(poolName, pools)
+		if err != nil {
+			blockers = append(blockers, err.Error())
+			continue
+		}
+		poolMissing, err := target.MissingVolumes(poolConfig, volumeIDs[poolName])
+		if err != nil {
+			return nil, errors.Annotatef(err, "checking volumes in pool %q", poolName)
+		}
+		missing = append(missing, poolMissing...)
+	}
+	if len(missing) > 0 {
+		blockers = append(blockers, fmt.Sprintf("volumes not found in target cloud: %s", strings.Join(missing, ", ")))
+	}
+	return blockers, nil
+}
+
+// precheckPoolConfig returns the storage config for the named pool. As in
+// state, a pool name that isn't a model pool names a storage provider.
+func precheckPoolConfig(name string, pools map[string]description.StoragePool) (*jujustorage.Config, error) {
+	providerType := jujustorage.ProviderType(name)
+	var attrs map[string]interface{}
+	if pool, ok := pools[name]; ok {
+		providerType = jujustorage.ProviderType(pool.Provider())
+		attrs = pool.Attributes()
+	}
+	config, err := jujustorage.NewConfig(name, providerType, attrs)
+	if err != nil {
+		return nil, errors.Annotatef(err, "pool %q", name)
+	}
+	return config, nil
+}
+
+// NewPrecheckTarget returns a PrecheckTarget that looks for volumes in the
+// cloud with the given config, using the registered storage providers.
+// It is used by the target controller to answer the checks of a
+// migration's source controller.
+func NewPrecheckTarget(environConfig *config.Config) PrecheckTarget {
+	return &precheckTarget{environConfig: environConfig}
+}
+
+type precheckTarget struct {
+	environConfig *config.Config
+}
+
+// MissingVolumes implements PrecheckTarget.
+func (t *precheckTarget) MissingVolumes(pool *jujustorage.Config, volumeIDs []string) ([]string, error) {
+	provider, err := registry.StorageProvider(pool.Provider())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if provider.Scope() != jujustorage.ScopeEnviron || !provider.Supports(jujustorage.StorageKindBlock) {
+		// Volumes that are not managed by the cloud can't be seen
+		// by it, and stay on the machines they are attached to.
+		return nil, nil
+	}
+	source, err := provider.VolumeSource(t.environConfig, pool)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	results, err := source.DescribeVolumes(volumeIDs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results) != len(volumeIDs) {
+		return nil, errors.Errorf("expected %d results, got %d", len(volumeIDs), len(results))
+	}
+	var missing []string
+	for i, result := range results {
+		if errors.IsNotFound(result.Error) {
+			missing = append(missing, volumeIDs[i])
+		} else if result.Error != nil {
+			return nil, errors.Annotatef(result.Error, "describing volume %q", volumeIDs[i])
+		}
+	}
+	return missing, nil
+}
+
+// DryRunBackend is implemented by *state.State but defined as an
+// interface for easier testing.
+type DryRunBackend interface {
+	PrecheckBackend
+	Charm(*charm.URL) (*state.Charm, error)
+}
+
+// ImportChecker is implemented by the MigrationTarget API client of the
+// controller that a model is being migrated to.
+type ImportChecker interface {
+	// CheckImport returns the reasons, if any, that the serialized
+	// model can't be imported into the target controller.
+	CheckImport([]byte) ([]string, error)
+}
+
+// DryRun runs the checks of a model migration without starting one,
+// returning everything that would block the migration. Neither the
+// source nor the target controller is changed. An error is only
+// returned if the checks couldn't be run.
+func DryRun(backend DryRunBackend, precheckTarget PrecheckTarget, target ImportChecker) ([]string, error) {
+	blockers, err := precheck(backend, precheckTarget)
+	if err != nil {
+		return nil, errors.Annotate(err, "dry run")
+	}
+
+	model, err := backend.Export()
+	if err != nil {
+		return nil, errors.Annotate(err, "dry run export")
+	}
+	charmBlockers, err := precheckCharms(backend, model)
+	if err != nil {
+		return nil, errors.Annotate(err, "dry run charms")
+	}
+	blockers = append(blockers, charmBlockers...)
+
+	bytes, err := description.Serialize(model)
+	if err != nil {
+		return nil, errors.Annotate(err, "dry run serialize")
+	}
+	targetBlockers, err := target.CheckImport(bytes)
+	if err != nil {
+		return nil, errors.Annotate(err, "dry run import check")
+	}
+	return append(blockers, targetBlockers...), nil
+}
+
+// precheckCharms returns a blocker for each charm used by the model
+// that can't be sent to the target controller.
+func precheckCharms(backend DryRunBackend, model description.Model) ([]string, error) {
+	var blockers []string
+	for _, charmURL := range getUsedCharms(model).SortedValues() {
+		curl, err := charm.ParseURL(charmURL)
+		if err != nil {
+			return nil, errors.Annotate(err, "bad charm URL")
+		}
+		ch, err := backend.Charm(curl)
+		if errors.IsNotFound(err) {
+			blockers = append(blockers, fmt.Sprintf("charm %q not found", charmURL))
+			continue
+		} else if err != nil {
+			return nil, errors.Annotatef(err, "charm %q", charmURL)
+		}
+		if !ch.IsUploaded() {
+			blockers = append(blockers, fmt.Sprintf("charm %q has not been uploaded", charmURL))
+		}
+	}
+	return blockers, nil
+}
+
+// ImportCheckBackend is implemented by *state.State for the controller
+// that a model is being migrated to, but defined as an interface for
+// easier testing.
+type ImportCheckBackend interface {
+	GetModel(names.ModelTag) (*state.Model, error)
+	User(names.UserTag) (*state.User, error)
+	Cloud(name string) (cloud.Cloud, error)
+	CloudCredentials(user names.UserTag, cloudName string) (map[string]cloud.Credential, error)
+	ValidateImport(description.Model) error
+}
+
+// CheckImport returns the reasons, if any, that the serialized model
+// can't be imported into a controller running the given version. If
+// nothing else blocks it, the documents the import would write are
+// built and checked without being written; nothing is imported.
+func CheckImport(backend ImportCheckBackend, bytes []byte, controllerVersion version.Number) ([]string, error) {
+	model, err := description.Deserialize(bytes)
+	if err != nil {
+		// Most likely the model was exported by a newer controller
+		// with features this one doesn't support.
+		return []string{fmt.Sprintf("model description not supported: %v", err)}, nil
+	}
+
+	var blockers []string
+	if err := model.Validate(); err != nil {
+		blockers = append(blockers, fmt.Sprintf("model description not valid: %v", err))
+	}
+	if agentVersion, ok := model.Config()["agent-version"].(string); ok {
+		modelVersion, err := version.Parse(agentVersion)
+		if err != nil {
+			blockers = append(blockers, fmt.Sprintf("model agent version %q not valid", agentVersion))
+		} else if modelVersion.Compare(controllerVersion) > 0 {
+			blockers = append(blockers, fmt.Sprintf(
+				"model agent version %s is newer than target controller version %s",
+				modelVersion, controllerVersion))
+		}
+	}
+
+	if _, err := backend.GetModel(model.Tag()); err == nil {
+		blockers = append(blockers, fmt.Sprintf("model %s already exists on target controller", model.Tag().Id()))
+	} else if !errors.IsNotFound(err) {
+		return nil, errors.Annotate(err, "checking for existing model")
+	}
+
+	cloudBlockers, err := checkImportCloud(backend, model)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	blockers = append(blockers, cloudBlockers...)
+
+	userBlockers, err := checkImportUsers(backend, model)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	blockers = append(blockers, userBlockers...)
+	if len(blockers) > 0 {
+		// The import would fail for the reasons already found.
+		return blockers, nil
+	}
+
+	if err := backend.ValidateImport(model); err != nil {
+		return []string{fmt.Sprintf("model import failed: %v", err)}, nil
+	}
+	return nil, nil
+}
+
+// checkImportCloud makes sure that the cloud, region and credential of
+// the model exist on the target controller, as they are not migrated.
+func checkImportCloud(backend ImportCheckBackend, model description.Model) ([]string, error) {
+	modelCloud, err := backend.Cloud(model.Cloud())
+	if errors.IsNotFound(err) {
+		return []string{fmt.Sprintf("cloud %q not found on target controller", model.Cloud())}, nil
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cloud %q", model.Cloud())
+	}
+
+	var blockers []string
+	if region := model.CloudRegion(); region != "" {
+		found := false
+		for _, r := range modelCloud.Regions {
+			if r.Name == region {
+				found = true
+				break
+			}
+		}
+		if !found {
+			blockers = append(blockers, fmt.Sprintf("cloud region %q not found on target controller", region))
+		}
+	}
+	if credential := model.CloudCredential(); credential != "" {
+		credentials, err := backend.CloudCredentials(model.Owner(), model.Cloud())
+		if err != nil {
+			return nil, errors.Annotate(err, "cloud credentials")
+		}
+		if _, ok := credentials[credential]; !ok {
+			blockers = append(blockers, fmt.Sprintf(
+				"cloud credential %q for %q not found on target controller",
+				credential, model.Owner().Canonical()))
+		}
+	}
+	return blockers, nil
+}
+
+// checkImportUsers makes sure that the local users with access to the
+// model exist on the target controller, as users are not migrated.
+func checkImportUsers(backend ImportCheckBackend, model description.Model) ([]string, error) {
+	users := []names.UserTag{model.Owner()}
+	for _, user := range model.Users() {
+		if user.Name() != model.Owner() {
+			users = append(users, user.Name())
+		}
+	}
+	var blockers []string
+	for _, user := range users {
+		if !user.IsLocal() {
+			// External users are managed outside of the controller.
+			continue
+		}
+		_, err := backend.User(user)
+		if errors.IsNotFound(err) {
+			blockers = append(blockers, fmt.Sprintf("user %q not found on target controller", user.Canonical()))
+		} else if err != nil {
+			return nil, errors.Annotatef(err, "user %q", user.Canonical())
+		}
+	}
+	return blockers, nil
+}
+
+// ValidationBackend is implemented by *state.State for an imported model
+// but defined as an interface for easier testing.
+type ValidationBackend interface {
+	AllEndpointBindings() (map[string]map[string]string, error)
+	Space(name string) (*state.Space, error)
+}
+
+// ValidateEndpointBindings checks that every application endpoint of an
+// imported model is bound to a space that exists in the model on the
+// target controller. Endpoints bound to the default space are not checked.
+func ValidateEndpointBindings(backend ValidationBackend) error {
+	bindings, err := backend.AllEndpointBindings()
+	if err != nil {
+		return errors.Annotate(err, "validate endpoint bindings")
+	}
+	applications := set.NewStrings()
+	for application := range bindings {
+		applications.Add(application)
+	}
+	known := set.NewStrings()
+	for _, application := range applications.SortedValues() {
+		endpoints := bindings[application]
+		names := set.NewStrings()
+		for endpoint := range endpoints {
+			names.Add(endpoint)
+		}
+		for _, endpoint := range names.SortedValues() {
+			space := endpoints[endpoint]
+			if space == "" || known.Contains(space) {
+				continue
+			}
+			_, err := backend.Space(space)
+			if errors.IsNotFound(err) {
+				return errors.Errorf(
+					"application %q endpoint %q bound to unknown space %q",
+					application, endpoint, space)
+			} else if err != nil {
+				return errors.Annotatef(err, "space %q", space)
+			}
+			known.Add(space)
+		}
 	}
 	return nil
 }
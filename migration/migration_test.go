@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"time"
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
@@ -19,6 +20,7 @@ import (
 	"gopkg.in/mgo.v2"
 
 	"github.com/juju/juju/api"
+	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/core/description"
 	"github.com/juju/juju/environs/bootstrap"
@@ -30,7 +32,9 @@ import (
 	"github.com/juju/juju/state/binarystorage"
 	"github.com/juju/juju/state/storage"
 	statetesting "github.com/juju/juju/state/testing"
+	jujustorage "github.com/juju/juju/storage"
 	"github.com/juju/juju/testing"
+	"github.com/juju/juju/testing/factory"
 	"github.com/juju/juju/tools"
 )
 
@@ -98,6 +102,54 @@ func (s *ImportSuite) TestImportModel(c *gc.C) {
 	c.Assert(dbConfig.Name(), gc.Equals, "new-model")
 }
 
+func (s *ImportSuite) TestCloneModel(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, &factory.MachineParams{
+		Password: "machine-password-1234567890",
+	})
+	agentTools, err := machine.AgentTools()
+	c.Assert(err, jc.ErrorIsNil)
+	s.addTools(c, agentTools.Version)
+
+	uuid := utils.MustNewUUID().String()
+	dbModel, dbState, err := migration.CloneModel(s.State, description.CloneArgs{
+		UUID: uuid,
+		Name: "staging",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer dbState.Close()
+
+	c.Check(dbModel.UUID(), gc.Equals, uuid)
+	c.Check(dbModel.Name(), gc.Equals, "staging")
+	c.Check(dbModel.MigrationMode(), gc.Equals, state.MigrationModeActive)
+
+	cloned, err := dbState.Machine(machine.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = cloned.InstanceId()
+	c.Check(err, jc.Satisfies, errors.IsNotProvisioned)
+	c.Check(cloned.PasswordValid("machine-password-1234567890"), jc.IsFalse)
+}
+
+func (s *ImportSuite) TestCloneModelExistingUUID(c *gc.C) {
+	_, _, err := migration.CloneModel(s.State, description.CloneArgs{
+		UUID: s.State.ModelUUID(),
+		Name: "staging",
+	})
+	c.Assert(err, gc.ErrorMatches, "importing model: model with UUID .* already exists")
+}
+
+func (s *ImportSuite) addTools(c *gc.C, vers version.Binary) {
+	toolsStorage, err := s.State.ToolsStorage()
+	c.Assert(err, jc.ErrorIsNil)
+	defer toolsStorage.Close()
+	content := "fake tools " + vers.String()
+	err = toolsStorage.Add(bytes.NewReader([]byte(content)), binarystorage.Metadata{
+		Version: vers.String(),
+		Size:    int64(len(content)),
+		SHA256:  "fake-sha256",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *ImportSuite) TestUploadBinariesTools(c *gc.C) {
 	// Create a model that has three different tools versions:
 	// one for a machine, one for a container, and one for a unit agent.
@@ -137,8 +189,10 @@ func (s *ImportSuite) TestUploadBinariesTools(c *gc.C) {
 		GetToolsUploader: func(target api.Connection) migration.ToolsUploader {
 			return uploader
 		},
+		GetResourceUploader: func(api.Connection) migration.ResourceUploader { return &noOpUploader{} },
 		GetStateStorage:     func(migration.UploadBackend) storage.Storage { return &fakeCharmsStorage{} },
 		GetCharmStoragePath: func(migration.UploadBackend, *charm.URL) (string, error) { return "", nil },
+		GetResourceContent:  getFakeResourceContent,
 	}
 	err := migration.UploadBinaries(config)
 	c.Assert(err, jc.ErrorIsNil)
@@ -174,6 +228,8 @@ func (s *ImportSuite) TestStreamCharmsTools(c *gc.C) {
 		GetCharmStoragePath: func(_ migration.UploadBackend, u *charm.URL) (string, error) {
 			return "/path/for/" + u.String(), nil
 		},
+		GetResourceUploader: func(api.Connection) migration.ResourceUploader { return &noOpUploader{} },
+		GetResourceContent:  getFakeResourceContent,
 	}
 	err := migration.UploadBinaries(config)
 	c.Assert(err, jc.ErrorIsNil)
@@ -184,6 +240,123 @@ func (s *ImportSuite) TestStreamCharmsTools(c *gc.C) {
 	})
 }
 
+func (s *ImportSuite) TestUploadBinariesProgress(c *gc.C) {
+	model := description.NewModel(description.ModelArgs{
+		Owner: names.NewUserTag("me"),
+	})
+	machine := model.AddMachine(description.MachineArgs{
+		Id: names.NewMachineTag("0"),
+	})
+	machine.SetTools(description.AgentToolsArgs{
+		Version: version.MustParseBinary("2.0.1-trusty-amd64"),
+	})
+	model.AddApplication(description.ApplicationArgs{
+		Tag:      names.NewApplicationTag("magic"),
+		CharmURL: "local:trusty/magic",
+	})
+
+	var progress [][2]int64
+	config := migration.UploadBinariesConfig{
+		State:            &fakeStateStorage{},
+		Model:            model,
+		Target:           &fakeAPIConnection{},
+		GetCharmUploader: func(api.Connection) migration.CharmUploader { return &noOpUploader{} },
+		GetToolsUploader: func(target api.Connection) migration.ToolsUploader { return &noOpUploader{} },
+		GetStateStorage:  func(migration.UploadBackend) storage.Storage { return &fakeCharmsStorage{} },
+		GetCharmStoragePath: func(_ migration.UploadBackend, u *charm.URL) (string, error) {
+			return "/path/for/" + u.String(), nil
+		},
+		GetResourceUploader: func(api.Connection) migration.ResourceUploader { return &noOpUploader{} },
+		GetResourceContent:  getFakeResourceContent,
+		SetProgress: func(charmBytes, toolsBytes int64) error {
+			progress = append(progress, [2]int64{charmBytes, toolsBytes})
+			return nil
+		},
+	}
+	err := migration.UploadBinaries(config)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// "fake tools 2.0.1-trusty-amd64" is sent first, followed by
+	// "fake file at /path/for/local:trusty/magic".
+	c.Assert(progress, jc.DeepEquals, [][2]int64{{0, 29}, {41, 29}})
+}
+
+func (s *ImportSuite) TestUploadBinariesProgressError(c *gc.C) {
+	model := description.NewModel(description.ModelArgs{
+		Owner: names.NewUserTag("me"),
+	})
+	model.AddApplication(description.ApplicationArgs{
+		Tag:      names.NewApplicationTag("magic"),
+		CharmURL: "local:trusty/magic",
+	})
+
+	config := migration.UploadBinariesConfig{
+		State:               &fakeStateStorage{},
+		Model:               model,
+		Target:              &fakeAPIConnection{},
+		GetCharmUploader:    func(api.Connection) migration.CharmUploader { return &noOpUploader{} },
+		GetToolsUploader:    func(target api.Connection) migration.ToolsUploader { return &noOpUploader{} },
+		GetStateStorage:     func(migration.UploadBackend) storage.Storage { return &fakeCharmsStorage{} },
+		GetCharmStoragePath: func(migration.UploadBackend, *charm.URL) (string, error) { return "", nil },
+		GetResourceUploader: func(api.Connection) migration.ResourceUploader { return &noOpUploader{} },
+		GetResourceContent:  getFakeResourceContent,
+		SetProgress: func(int64, int64) error {
+			return errors.New("boom")
+		},
+	}
+	err := migration.UploadBinaries(config)
+	c.Assert(err, gc.ErrorMatches, "recording upload progress: boom")
+}
+
+func (s *ImportSuite) TestStreamResources(c *gc.C) {
+	model := description.NewModel(description.ModelArgs{
+		Owner:  names.NewUserTag("me"),
+		Config: map[string]interface{}{"uuid": "model-uuid"},
+	})
+	application := model.AddApplication(description.ApplicationArgs{
+		Tag:      names.NewApplicationTag("magic"),
+		CharmURL: "local:trusty/magic",
+	})
+	uploaded := application.AddResource(description.ResourceArgs{Name: "spam"})
+	uploaded.SetApplicationRevision(description.ResourceRevisionArgs{
+		Type:      "file",
+		Path:      "spam.tgz",
+		Origin:    "upload",
+		Timestamp: time.Now(),
+	})
+	// A resource that has never been uploaded has no content to send.
+	placeholder := application.AddResource(description.ResourceArgs{Name: "eggs"})
+	placeholder.SetApplicationRevision(description.ResourceRevisionArgs{
+		Type:   "file",
+		Path:   "eggs.tgz",
+		Origin: "upload",
+	})
+
+	uploader := &fakeUploader{resources: make(map[string]string)}
+	config := migration.UploadBinariesConfig{
+		State:               &fakeStateStorage{},
+		Model:               model,
+		Target:              &fakeAPIConnection{},
+		GetCharmUploader:    func(api.Connection) migration.CharmUploader { return &noOpUploader{} },
+		GetToolsUploader:    func(target api.Connection) migration.ToolsUploader { return &noOpUploader{} },
+		GetResourceUploader: func(api.Connection) migration.ResourceUploader { return uploader },
+		GetStateStorage:     func(migration.UploadBackend) storage.Storage { return &fakeCharmsStorage{} },
+		GetCharmStoragePath: func(migration.UploadBackend, *charm.URL) (string, error) { return "", nil },
+		GetResourceContent:  getFakeResourceContent,
+	}
+	err := migration.UploadBinaries(config)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(uploader.resources, jc.DeepEquals, map[string]string{
+		"model-uuid/magic/spam": "fake resource magic/spam",
+	})
+}
+
+func getFakeResourceContent(_ migration.UploadBackend, application, name string) (io.ReadCloser, error) {
+	buff := bytes.NewBufferString(fmt.Sprintf("fake resource %s/%s", application, name))
+	return ioutil.NopCloser(buff), nil
+}
+
 type fakeStateStorage struct {
 	tools  fakeToolsStorage
 	charms fakeCharmsStorage
@@ -218,6 +391,10 @@ func (f *fakeStateStorage) Charm(*charm.URL) (*state.Charm, error) {
 	return nil, nil
 }
 
+func (f *fakeStateStorage) Resources() (state.Resources, error) {
+	return nil, nil
+}
+
 func (f *fakeToolsStorage) Open(v string) (binarystorage.Metadata, io.ReadCloser, error) {
 	buff := bytes.NewBufferString(fmt.Sprintf("fake tools %s", v))
 	return binarystorage.Metadata{}, ioutil.NopCloser(buff), nil
@@ -234,8 +411,9 @@ func (f *fakeCharmsStorage) Get(path string) (io.ReadCloser, int64, error) {
 }
 
 type fakeUploader struct {
-	tools  map[version.Binary]string
-	charms map[string]string
+	tools     map[version.Binary]string
+	charms    map[string]string
+	resources map[string]string
 }
 
 func (f *fakeUploader) UploadTools(r io.ReadSeeker, v version.Binary, _ ...string) (tools.List, error) {
@@ -262,6 +440,16 @@ func (f *fakeUploader) UploadCharm(u *charm.URL, r io.ReadSeeker) (*charm.URL, e
 	return u, nil
 }
 
+func (f *fakeUploader) UploadResource(modelUUID, application, name string, r io.ReadSeeker) error {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	f.resources[modelUUID+"/"+application+"/"+name] = string(data)
+	return nil
+}
+
 type noOpUploader struct{}
 
 func (*noOpUploader) UploadCharm(*charm.URL, io.ReadSeeker) (*charm.URL, error) {
@@ -272,6 +460,10 @@ func (*noOpUploader) UploadTools(io.ReadSeeker, version.Binary, ...string) (tool
 	return nil, nil
 }
 
+func (*noOpUploader) UploadResource(string, string, string, io.ReadSeeker) error {
+	return nil
+}
+
 type ExportSuite struct {
 	statetesting.StateSuite
 }
@@ -286,6 +478,190 @@ func (s *ExportSuite) TestExportModel(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *ExportSuite) TestExportArchive(c *gc.C) {
+	var buf bytes.Buffer
+	err := migration.ExportArchive(s.State, &buf)
+	c.Assert(err, jc.ErrorIsNil)
+
+	target := newFakeArchiveTarget()
+	modelTag, err := migration.ImportArchive(&buf, target)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(modelTag, gc.Equals, s.State.ModelTag())
+	model, err := description.Deserialize(target.imported)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(model.Tag(), gc.Equals, s.State.ModelTag())
+}
+
+type ArchiveSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&ArchiveSuite{})
+
+func (s *ArchiveSuite) TestRoundTrip(c *gc.C) {
+	model := description.NewModel(description.ModelArgs{
+		Owner:  names.NewUserTag("me"),
+		Config: map[string]interface{}{"uuid": "bd3fae18-5ea1-4bc5-8837-45400cf1f8f6"},
+	})
+	machine := model.AddMachine(description.MachineArgs{
+		Id: names.NewMachineTag("0"),
+	})
+	machine.SetTools(description.AgentToolsArgs{
+		Version: version.MustParseBinary("2.0.1-trusty-amd64"),
+	})
+	application := model.AddApplication(description.ApplicationArgs{
+		Tag:      names.NewApplicationTag("magic"),
+		CharmURL: "cs:trusty/magic-2",
+	})
+	resource := application.AddResource(description.ResourceArgs{Name: "spam"})
+	resource.SetApplicationRevision(description.ResourceRevisionArgs{
+		Type:      "file",
+		Path:      "spam.tgz",
+		Origin:    "upload",
+		Timestamp: time.Now(),
+	})
+	modelBytes, err := description.Serialize(model)
+	c.Assert(err, jc.ErrorIsNil)
+
+	config := migration.UploadBinariesConfig{
+		State:           &fakeStateStorage{},
+		Model:           model,
+		GetStateStorage: func(migration.UploadBackend) storage.Storage { return &fakeCharmsStorage{} },
+		GetCharmStoragePath: func(_ migration.UploadBackend, u *charm.URL) (string, error) {
+			return "/path/for/" + u.String(), nil
+		},
+		GetResourceContent: getFakeResourceContent,
+	}
+	var buf bytes.Buffer
+	err = migration.WriteArchive(&buf, modelBytes, config)
+	c.Assert(err, jc.ErrorIsNil)
+
+	target := newFakeArchiveTarget()
+	modelTag, err := migration.ImportArchive(&buf, target)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(modelTag, gc.Equals, names.NewModelTag("bd3fae18-5ea1-4bc5-8837-45400cf1f8f6"))
+	c.Check(string(target.imported), gc.Equals, string(modelBytes))
+	c.Check(target.tools, jc.DeepEquals, map[version.Binary]string{
+		version.MustParseBinary("2.0.1-trusty-amd64"): "fake tools 2.0.1-trusty-amd64",
+	})
+	c.Check(target.charms, jc.DeepEquals, map[string]string{
+		"cs:trusty/magic-2": "fake file at /path/for/cs:trusty/magic-2",
+	})
+	c.Check(target.resources, jc.DeepEquals, map[string]string{
+		"bd3fae18-5ea1-4bc5-8837-45400cf1f8f6/magic/spam": "fake resource magic/spam",
+	})
+	c.Check(target.uploadedFor, jc.DeepEquals, map[string]string{
+		"2.0.1-trusty-amd64": "bd3fae18-5ea1-4bc5-8837-45400cf1f8f6",
+		"cs:trusty/magic-2":  "bd3fae18-5ea1-4bc5-8837-45400cf1f8f6",
+	})
+}
+
+func (s *ArchiveSuite) TestImportArchiveNotAnArchive(c *gc.C) {
+	_, err := migration.ImportArchive(bytes.NewBufferString("foo"), newFakeArchiveTarget())
+	c.Check(err, gc.ErrorMatches, "reading model archive: .*")
+}
+
+func (s *ArchiveSuite) TestImportArchiveImportError(c *gc.C) {
+	model := description.NewModel(description.ModelArgs{
+		Owner: names.NewUserTag("me"),
+	})
+	modelBytes, err := description.Serialize(model)
+	c.Assert(err, jc.ErrorIsNil)
+	config := migration.UploadBinariesConfig{
+		State:           &fakeStateStorage{},
+		Model:           model,
+		GetStateStorage: func(migration.UploadBackend) storage.Storage { return &fakeCharmsStorage{} },
+	}
+	var buf bytes.Buffer
+	err = migration.WriteArchive(&buf, modelBytes, config)
+	c.Assert(err, jc.ErrorIsNil)
+
+	target := newFakeArchiveTarget()
+	target.importErr = errors.New("boom")
+	_, err = migration.ImportArchive(&buf, target)
+	c.Check(err, gc.ErrorMatches, "importing model: boom")
+	c.Check(target.aborted, gc.Equals, "")
+}
+
+func (s *ArchiveSuite) TestImportArchiveUploadError(c *gc.C) {
+	model := description.NewModel(description.ModelArgs{
+		Owner:  names.NewUserTag("me"),
+		Config: map[string]interface{}{"uuid": "bd3fae18-5ea1-4bc5-8837-45400cf1f8f6"},
+	})
+	machine := model.AddMachine(description.MachineArgs{
+		Id: names.NewMachineTag("0"),
+	})
+	machine.SetTools(description.AgentToolsArgs{
+		Version: version.MustParseBinary("2.0.1-trusty-amd64"),
+	})
+	modelBytes, err := description.Serialize(model)
+	c.Assert(err, jc.ErrorIsNil)
+	config := migration.UploadBinariesConfig{
+		State:           &fakeStateStorage{},
+		Model:           model,
+		GetStateStorage: func(migration.UploadBackend) storage.Storage { return &fakeCharmsStorage{} },
+	}
+	var buf bytes.Buffer
+	err = migration.WriteArchive(&buf, modelBytes, config)
+	c.Assert(err, jc.ErrorIsNil)
+
+	target := newFakeArchiveTarget()
+	target.uploadErr = errors.New("boom")
+	_, err = migration.ImportArchive(&buf, target)
+	c.Check(err, gc.ErrorMatches, "uploading tools/2.0.1-trusty-amd64: boom")
+
+	// The partially imported model is removed.
+	c.Check(target.aborted, gc.Equals, "bd3fae18-5ea1-4bc5-8837-45400cf1f8f6")
+}
+
+func newFakeArchiveTarget() *fakeArchiveTarget {
+	return &fakeArchiveTarget{
+		fakeUploader: fakeUploader{
+			tools:     make(map[version.Binary]string),
+			charms:    make(map[string]string),
+			resources: make(map[string]string),
+		},
+		uploadedFor: make(map[string]string),
+	}
+}
+
+type fakeArchiveTarget struct {
+	fakeUploader
+	imported  []byte
+	importErr error
+	uploadErr error
+	aborted   string
+
+	// uploadedFor records the model each tools and charm upload
+	// was made for.
+	uploadedFor map[string]string
+}
+
+func (f *fakeArchiveTarget) Import(bytes []byte) error {
+	f.imported = bytes
+	return f.importErr
+}
+
+func (f *fakeArchiveTarget) UploadModelTools(modelUUID string, v version.Binary, r io.ReadSeeker) error {
+	if f.uploadErr != nil {
+		return f.uploadErr
+	}
+	f.uploadedFor[v.String()] = modelUUID
+	_, err := f.fakeUploader.UploadTools(r, v)
+	return err
+}
+
+func (f *fakeArchiveTarget) UploadModelCharm(modelUUID string, u *charm.URL, r io.ReadSeeker) error {
+	f.uploadedFor[u.String()] = modelUUID
+	_, err := f.fakeUploader.UploadCharm(u, r)
+	return err
+}
+
+func (f *fakeArchiveTarget) Abort(modelUUID string) error {
+	f.aborted = modelUUID
+	return nil
+}
+
 type PrecheckSuite struct {
 	testing.BaseSuite
 }
@@ -297,7 +673,7 @@ var _ migration.PrecheckBackend = (*state.State)(nil)
 
 func (*PrecheckSuite) TestPrecheckCleanups(c *gc.C) {
 	backend := &fakePrecheckBackend{}
-	err := migration.Precheck(backend)
+	err := migration.Precheck(backend, &fakePrecheckTarget{})
 	c.Assert(err, jc.ErrorIsNil)
 }
 
@@ -305,7 +681,7 @@ func (*PrecheckSuite) TestPrecheckCleanupsError(c *gc.C) {
 	backend := &fakePrecheckBackend{
 		cleanupError: errors.New("boom"),
 	}
-	err := migration.Precheck(backend)
+	err := migration.Precheck(backend, &fakePrecheckTarget{})
 	c.Assert(err, gc.ErrorMatches, "precheck cleanups: boom")
 }
 
@@ -313,19 +689,522 @@ func (*PrecheckSuite) TestPrecheckCleanupsNeeded(c *gc.C) {
 	backend := &fakePrecheckBackend{
 		cleanupNeeded: true,
 	}
-	err := migration.Precheck(backend)
+	err := migration.Precheck(backend, &fakePrecheckTarget{})
 	c.Assert(err, gc.ErrorMatches, "precheck failed: cleanup needed")
 }
 
+func (*PrecheckSuite) TestPrecheckExportError(c *gc.C) {
+	backend := &fakePrecheckBackend{
+		exportError: errors.New("boom"),
+	}
+	err := migration.Precheck(backend, &fakePrecheckTarget{})
+	c.Assert(err, gc.ErrorMatches, "precheck export: boom")
+}
+
+func (*PrecheckSuite) TestPrecheckVolumes(c *gc.C) {
+	model := newPrecheckModel()
+	model.AddStoragePool(description.StoragePoolArgs{
+		Name:       "fast",
+		Provider:   "ebs",
+		Attributes: map[string]interface{}{"volume-type": "ssd"},
+	})
+	addPrecheckVolume(model, "0", "fast", "vol-0")
+	addPrecheckVolume(model, "1", "ebs", "vol-1")
+	addPrecheckVolume(model, "2", "fast", "vol-2")
+	// Unprovisioned volumes are created by the target.
+	model.AddVolume(description.VolumeArgs{
+		Tag:  names.NewVolumeTag("3"),
+		Size: 1024,
+		Pool: "fast",
+	})
+	target := &fakePrecheckTarget{}
+
+	err := migration.Precheck(&fakePrecheckBackend{model: model}, target)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(target.calls, jc.DeepEquals, []precheckTargetCall{{
+		pool:      "fast",
+		provider:  "ebs",
+		attrs:     map[string]interface{}{"volume-type": "ssd"},
+		volumeIDs: []string{"vol-0", "vol-2"},
+	}, {
+		pool:      "ebs",
+		provider:  "ebs",
+		volumeIDs: []string{"vol-1"},
+	}})
+}
+
+func (*PrecheckSuite) TestPrecheckVolumesMissing(c *gc.C) {
+	model := newPrecheckModel()
+	addPrecheckVolume(model, "0", "ebs", "vol-0")
+	addPrecheckVolume(model, "1", "ebs", "vol-1")
+	addPrecheckVolume(model, "2", "ebs", "vol-2")
+	target := &fakePrecheckTarget{
+		missing: []string{"vol-0", "vol-2"},
+	}
+
+	err := migration.Precheck(&fakePrecheckBackend{model: model}, target)
+	c.Assert(err, gc.ErrorMatches, "precheck failed: volumes not found in target cloud: vol-0, vol-2")
+}
+
+func (*PrecheckSuite) TestPrecheckReportsAllBlockers(c *gc.C) {
+	model := newPrecheckModel()
+	addPrecheckVolume(model, "0", "ebs", "vol-0")
+	backend := &fakePrecheckBackend{
+		cleanupNeeded: true,
+		model:         model,
+	}
+	target := &fakePrecheckTarget{
+		missing: []string{"vol-0"},
+	}
+
+	err := migration.Precheck(backend, target)
+	c.Assert(err, gc.ErrorMatches, "precheck failed: cleanup needed; volumes not found in target cloud: vol-0")
+}
+
+func (*PrecheckSuite) TestPrecheckVolumesError(c *gc.C) {
+	model := newPrecheckModel()
+	addPrecheckVolume(model, "0", "ebs", "vol-0")
+	target := &fakePrecheckTarget{
+		err: errors.New("boom"),
+	}
+
+	err := migration.Precheck(&fakePrecheckBackend{model: model}, target)
+	c.Assert(err, gc.ErrorMatches, `precheck volumes: checking volumes in pool "ebs": boom`)
+}
+
+func newPrecheckModel() description.Model {
+	return description.NewModel(description.ModelArgs{
+		Owner: names.NewUserTag("owner"),
+	})
+}
+
+func addPrecheckVolume(model description.Model, id, pool, volumeID string) {
+	model.AddVolume(description.VolumeArgs{
+		Tag:         names.NewVolumeTag(id),
+		Provisioned: true,
+		Size:        1024,
+		Pool:        pool,
+		VolumeID:    volumeID,
+	})
+}
+
 type fakePrecheckBackend struct {
 	cleanupNeeded bool
 	cleanupError  error
+	model         description.Model
+	exportError   error
 }
 
 func (f *fakePrecheckBackend) NeedsCleanup() (bool, error) {
 	return f.cleanupNeeded, f.cleanupError
 }
 
+func (f *fakePrecheckBackend) Export() (description.Model, error) {
+	if f.exportError != nil {
+		return nil, f.exportError
+	}
+	if f.model == nil {
+		return newPrecheckModel(), nil
+	}
+	return f.model, nil
+}
+
+type precheckTargetCall struct {
+	pool      string
+	provider  string
+	attrs     map[string]interface{}
+	volumeIDs []string
+}
+
+type fakePrecheckTarget struct {
+	calls   []precheckTargetCall
+	missing []string
+	err     error
+}
+
+func (f *fakePrecheckTarget) MissingVolumes(pool *jujustorage.Config, volumeIDs []string) ([]string, error) {
+	f.calls = append(f.calls, precheckTargetCall{
+		pool:      pool.Name(),
+		provider:  string(pool.Provider()),
+		attrs:     pool.Attrs(),
+		volumeIDs: volumeIDs,
+	})
+	if f.err != nil {
+		return nil, f.err
+	}
+	var missing []string
+	for _, id := range volumeIDs {
+		for _, m := range f.missing {
+			if id == m {
+				missing = append(missing, id)
+			}
+		}
+	}
+	return missing, nil
+}
+
+type DryRunSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&DryRunSuite{})
+
+// Assert that *state.State implements the DryRunBackend
+var _ migration.DryRunBackend = (*state.State)(nil)
+
+func newDryRunModel() description.Model {
+	model := newPrecheckModel()
+	model.AddApplication(description.ApplicationArgs{
+		Tag:      names.NewApplicationTag("mysql"),
+		CharmURL: "cs:trusty/mysql-1",
+	})
+	return model
+}
+
+func (*DryRunSuite) TestDryRun(c *gc.C) {
+	model := newDryRunModel()
+	backend := &fakeDryRunBackend{
+		fakePrecheckBackend: fakePrecheckBackend{model: model},
+		charms:              []string{"cs:trusty/mysql-1"},
+	}
+	target := &fakeImportChecker{}
+
+	blockers, err := migration.DryRun(backend, &fakePrecheckTarget{}, target)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(blockers, gc.HasLen, 0)
+
+	expected, err := description.Serialize(model)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(target.bytes), gc.Equals, string(expected))
+}
+
+func (*DryRunSuite) TestDryRunReportsAllBlockers(c *gc.C) {
+	backend := &fakeDryRunBackend{
+		fakePrecheckBackend: fakePrecheckBackend{
+			cleanupNeeded: true,
+			model:         newDryRunModel(),
+		},
+	}
+	target := &fakeImportChecker{
+		blockers: []string{`user "bob@local" not found on target controller`},
+	}
+
+	blockers, err := migration.DryRun(backend, &fakePrecheckTarget{}, target)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(blockers, jc.DeepEquals, []string{
+		"cleanup needed",
+		`charm "cs:trusty/mysql-1" not found`,
+		`user "bob@local" not found on target controller`,
+	})
+}
+
+func (*DryRunSuite) TestDryRunExportError(c *gc.C) {
+	backend := &fakeDryRunBackend{
+		fakePrecheckBackend: fakePrecheckBackend{exportError: errors.New("boom")},
+	}
+	_, err := migration.DryRun(backend, &fakePrecheckTarget{}, &fakeImportChecker{})
+	c.Assert(err, gc.ErrorMatches, "dry run: precheck export: boom")
+}
+
+func (*DryRunSuite) TestDryRunCheckImportError(c *gc.C) {
+	backend := &fakeDryRunBackend{}
+	target := &fakeImportChecker{err: errors.New("boom")}
+	_, err := migration.DryRun(backend, &fakePrecheckTarget{}, target)
+	c.Assert(err, gc.ErrorMatches, "dry run import check: boom")
+}
+
+type fakeDryRunBackend struct {
+	fakePrecheckBackend
+	charms []string
+}
+
+func (f *fakeDryRunBackend) Charm(curl *charm.URL) (*state.Charm, error) {
+	for _, ch := range f.charms {
+		if ch == curl.String() {
+			return &state.Charm{}, nil
+		}
+	}
+	return nil, errors.NotFoundf("charm %q", curl)
+}
+
+type fakeImportChecker struct {
+	bytes    []byte
+	blockers []string
+	err      error
+}
+
+func (f *fakeImportChecker) CheckImport(bytes []byte) ([]string, error) {
+	f.bytes = bytes
+	return f.blockers, f.err
+}
+
+type CheckImportSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&CheckImportSuite{})
+
+// Assert that *state.State implements the ImportCheckBackend
+var _ migration.ImportCheckBackend = (*state.State)(nil)
+
+func serializedCheckImportModel(c *gc.C, agentVersion string) []byte {
+	model := description.NewModel(description.ModelArgs{
+		Owner: names.NewUserTag("owner"),
+		Config: map[string]interface{}{
+			"uuid":          utils.MustNewUUID().String(),
+			"name":          "model",
+			"agent-version": agentVersion,
+		},
+		Cloud:           "dummy",
+		CloudRegion:     "dummy-region",
+		CloudCredential: "cred",
+	})
+	model.AddUser(description.UserArgs{
+		Name:      names.NewUserTag("bob"),
+		CreatedBy: names.NewUserTag("owner"),
+	})
+	model.AddUser(description.UserArgs{
+		Name:      names.NewUserTag("mary@external"),
+		CreatedBy: names.NewUserTag("owner"),
+	})
+	bytes, err := description.Serialize(model)
+	c.Assert(err, jc.ErrorIsNil)
+	return bytes
+}
+
+func newFakeImportCheckBackend() *fakeImportCheckBackend {
+	return &fakeImportCheckBackend{
+		users: []string{"owner@local", "bob@local"},
+		clouds: map[string]cloud.Cloud{
+			"dummy": {Regions: []cloud.Region{{Name: "dummy-region"}}},
+		},
+		credentials: map[string]cloud.Credential{"cred": {}},
+	}
+}
+
+func (*CheckImportSuite) TestCheckImport(c *gc.C) {
+	bytes := serializedCheckImportModel(c, "2.0.0")
+	backend := newFakeImportCheckBackend()
+	blockers, err := migration.CheckImport(backend, bytes, version.MustParse("2.0.1"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(blockers, gc.HasLen, 0)
+	c.Check(backend.validated, gc.NotNil)
+}
+
+func (*CheckImportSuite) TestCheckImportValidateImportFailure(c *gc.C) {
+	bytes := serializedCheckImportModel(c, "2.0.0")
+	backend := newFakeImportCheckBackend()
+	backend.validateError = errors.New("boom")
+
+	blockers, err := migration.CheckImport(backend, bytes, version.MustParse("2.0.1"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(blockers, jc.DeepEquals, []string{"model import failed: boom"})
+}
+
+func (*CheckImportSuite) TestCheckImportBadBytes(c *gc.C) {
+	blockers, err := migration.CheckImport(newFakeImportCheckBackend(), []byte("foo"), version.MustParse("2.0.1"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(blockers, gc.HasLen, 1)
+	c.Check(blockers[0], gc.Matches, "model description not supported: .*")
+}
+
+func (*CheckImportSuite) TestCheckImportOlderModelFormat(c *gc.C) {
+	// A model exported by an older controller, in version 1 of the
+	// model format, is upgraded rather than rejected.
+	bytes := []byte(`
+version: 1
+owner: owner@local
+config:
+  uuid: bd3fae18-5ea1-4bc5-8837-45400cf1f8f6
+  name: model
+  agent-version: 2.0.0
+users:
+  version: 1
+  users: []
+machines:
+  version: 1
+  machines: []
+applications:
+  version: 1
+  applications: []
+relations:
+  version: 1
+  relations: []
+sequences: {}
+cloud: dummy
+cloud-region: dummy-region
+cloud-credential: missing
+`[1:])
+	blockers, err := migration.CheckImport(newFakeImportCheckBackend(), bytes, version.MustParse("2.0.1"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(blockers, jc.DeepEquals, []string{
+		`cloud credential "missing" for "owner@local" not found on target controller`,
+	})
+}
+
+func (*CheckImportSuite) TestCheckImportReportsAllBlockers(c *gc.C) {
+	bytes := serializedCheckImportModel(c, "2.1.0")
+	backend := newFakeImportCheckBackend()
+	backend.modelExists = true
+	backend.users = []string{"owner@local"}
+	backend.clouds["dummy"] = cloud.Cloud{}
+	backend.credentials = nil
+
+	blockers, err := migration.CheckImport(backend, bytes, version.MustParse("2.0.1"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(blockers, gc.HasLen, 5)
+	c.Check(blockers[0], gc.Equals, "model agent version 2.1.0 is newer than target controller version 2.0.1")
+	c.Check(blockers[1], gc.Matches, "model .* already exists on target controller")
+	c.Check(blockers[2:], jc.DeepEquals, []string{
+		`cloud region "dummy-region" not found on target controller`,
+		`cloud credential "cred" for "owner@local" not found on target controller`,
+		`user "bob@local" not found on target controller`,
+	})
+	// The import isn't tried when it's known to fail.
+	c.Check(backend.validated, gc.IsNil)
+}
+
+func (*CheckImportSuite) TestCheckImportMissingCloud(c *gc.C) {
+	bytes := serializedCheckImportModel(c, "2.0.0")
+	backend := newFakeImportCheckBackend()
+	backend.clouds = nil
+
+	blockers, err := migration.CheckImport(backend, bytes, version.MustParse("2.0.1"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(blockers, jc.DeepEquals, []string{`cloud "dummy" not found on target controller`})
+}
+
+func (*CheckImportSuite) TestCheckImportUserError(c *gc.C) {
+	bytes := serializedCheckImportModel(c, "2.0.0")
+	backend := newFakeImportCheckBackend()
+	backend.userError = errors.New("boom")
+
+	_, err := migration.CheckImport(backend, bytes, version.MustParse("2.0.1"))
+	c.Assert(err, gc.ErrorMatches, `user "owner@local": boom`)
+}
+
+type fakeImportCheckBackend struct {
+	modelExists   bool
+	users         []string
+	userError     error
+	clouds        map[string]cloud.Cloud
+	credentials   map[string]cloud.Credential
+	validated     description.Model
+	validateError error
+}
+
+func (f *fakeImportCheckBackend) ValidateImport(model description.Model) error {
+	f.validated = model
+	return f.validateError
+}
+
+func (f *fakeImportCheckBackend) GetModel(tag names.ModelTag) (*state.Model, error) {
+	if f.modelExists {
+		return &state.Model{}, nil
+	}
+	return nil, errors.NotFoundf("model %q", tag.Id())
+}
+
+func (f *fakeImportCheckBackend) User(tag names.UserTag) (*state.User, error) {
+	if f.userError != nil {
+		return nil, f.userError
+	}
+	for _, user := range f.users {
+		if user == tag.Canonical() {
+			return &state.User{}, nil
+		}
+	}
+	return nil, errors.NotFoundf("user %q", tag.Canonical())
+}
+
+func (f *fakeImportCheckBackend) Cloud(name string) (cloud.Cloud, error) {
+	if c, ok := f.clouds[name]; ok {
+		return c, nil
+	}
+	return cloud.Cloud{}, errors.NotFoundf("cloud %q", name)
+}
+
+func (f *fakeImportCheckBackend) CloudCredentials(names.UserTag, string) (map[string]cloud.Credential, error) {
+	return f.credentials, nil
+}
+
+type ValidateSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&ValidateSuite{})
+
+// Assert that *state.State implements the ValidationBackend
+var _ migration.ValidationBackend = (*state.State)(nil)
+
+func (*ValidateSuite) TestValidateEndpointBindings(c *gc.C) {
+	backend := &fakeValidationBackend{
+		bindings: map[string]map[string]string{
+			"wordpress": {"db": "db", "url": "public", "logging-dir": ""},
+			"mysql":     {"server": "db"},
+		},
+		spaces: []string{"db", "public"},
+	}
+	err := migration.ValidateEndpointBindings(backend)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(backend.checked, jc.DeepEquals, []string{"db", "public"})
+}
+
+func (*ValidateSuite) TestValidateEndpointBindingsUnknownSpace(c *gc.C) {
+	backend := &fakeValidationBackend{
+		bindings: map[string]map[string]string{
+			"wordpress": {"db": "db", "url": "public"},
+		},
+		spaces: []string{"db"},
+	}
+	err := migration.ValidateEndpointBindings(backend)
+	c.Assert(err, gc.ErrorMatches, `application "wordpress" endpoint "url" bound to unknown space "public"`)
+}
+
+func (*ValidateSuite) TestValidateEndpointBindingsError(c *gc.C) {
+	backend := &fakeValidationBackend{
+		bindingsError: errors.New("boom"),
+	}
+	err := migration.ValidateEndpointBindings(backend)
+	c.Assert(err, gc.ErrorMatches, "validate endpoint bindings: boom")
+}
+
+func (*ValidateSuite) TestValidateEndpointBindingsSpaceError(c *gc.C) {
+	backend := &fakeValidationBackend{
+		bindings: map[string]map[string]string{
+			"wordpress": {"db": "db"},
+		},
+		spaceError: errors.New("boom"),
+	}
+	err := migration.ValidateEndpointBindings(backend)
+	c.Assert(err, gc.ErrorMatches, `space "db": boom`)
+}
+
+type fakeValidationBackend struct {
+	bindings      map[string]map[string]string
+	bindingsError error
+	spaces        []string
+	spaceError    error
+	checked       []string
+}
+
+func (f *fakeValidationBackend) AllEndpointBindings() (map[string]map[string]string, error) {
+	return f.bindings, f.bindingsError
+}
+
+func (f *fakeValidationBackend) Space(name string) (*state.Space, error) {
+	f.checked = append(f.checked, name)
+	if f.spaceError != nil {
+		return nil, f.spaceError
+	}
+	for _, space := range f.spaces {
+		if space == name {
+			return &state.Space{}, nil
+		}
+	}
+	return nil, errors.NotFoundf("space %q", name)
+}
+
 type CharmInternalSuite struct {
 	statetesting.StateSuite
 }
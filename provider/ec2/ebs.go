@@ -42,6 +42,11 @@ const (
 	// Specifies whether the volume should be encrypted.
 	EBS_Encrypted = "encrypted"
 
+	// The ID of the customer-managed KMS key with which to
+	// encrypt the volume. Only valid for encrypted volumes;
+	// if unspecified, the account's default EBS key is used.
+	EBS_KMSKeyId = "kms-key-id"
+
 	volumeTypeMagnetic        = "magnetic"         // standard
 	volumeTypeSsd             = "ssd"              // gp2
 	volumeTypeProvisionedIops = "provisioned-iops" // io1
@@ -142,6 +147,7 @@ var ebsConfigFields = schema.Fields{
 	),
 	EBS_IOPS:      schema.ForceInt(),
 	EBS_Encrypted: schema.Bool(),
+	EBS_KMSKeyId:  schema.String(),
 }
 
 var ebsConfigChecker = schema.FieldMap(
@@ -150,6 +156,7 @@ var ebsConfigChecker = schema.FieldMap(
 		EBS_VolumeType: volumeTypeMagnetic,
 		EBS_IOPS:       schema.Omit,
 		EBS_Encrypted:  false,
+		EBS_KMSKeyId:   schema.Omit,
 	},
 )
 
@@ -157,6 +164,7 @@ type ebsConfig struct {
 	volumeType string
 	iops       int
 	encrypted  bool
+	kmsKeyId   string
 }
 
 func newEbsConfig(attrs map[string]interface{}) (*ebsConfig, error) {
@@ -172,6 +180,7 @@ func newEbsConfig(attrs map[string]interface{}) (*ebsConfig, error) {
 		iops:       iops,
 		encrypted:  coerced[EBS_Encrypted].(bool),
 	}
+	ebsConfig.kmsKeyId, _ = coerced[EBS_KMSKeyId].(string)
 	switch ebsConfig.volumeType {
 	case volumeTypeMagnetic:
 		ebsConfig.volumeType = volumeTypeStandard
@@ -185,6 +194,9 @@ func newEbsConfig(attrs map[string]interface{}) (*ebsConfig, error) {
 	} else if ebsConfig.iops == 0 && ebsConfig.volumeType == volumeTypeIo1 {
 		return nil, errors.Errorf("volume type is %q, IOPS unspecified or zero", volumeTypeIo1)
 	}
+	if ebsConfig.kmsKeyId != "" && !ebsConfig.encrypted {
+		return nil, errors.Errorf("KMS key ID specified, but volume is not encrypted")
+	}
 	return ebsConfig, nil
 }
 
@@ -259,6 +271,7 @@ func parseVolumeOptions(size uint64, attrs map[string]interface{}) (_ ec2.Create
 		VolumeSize: int(sizeInGib),
 		VolumeType: ebsConfig.volumeType,
 		Encrypted:  ebsConfig.encrypted,
+		KmsKeyId:   ebsConfig.kmsKeyId,
 		IOPS:       int64(iops),
 	}
 	return vol, nil
@@ -330,7 +343,10 @@ func (v *ebsVolumeSource) createVolume(p storage.VolumeParams, instances instanc
 		// because we need to know what its AZ is.
 		return nil, nil, errors.Trace(err)
 	}
-	vol, _ := parseVolumeOptions(p.Size, p.Attributes)
+	vol, err := parseVolumeOptions(p.Size, p.Attributes)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
 	vol.AvailZone = inst.AvailZone
 	resp, err := v.ec2.CreateVolume(vol)
 	if err != nil {
@@ -354,6 +370,7 @@ func (v *ebsVolumeSource) createVolume(p storage.VolumeParams, instances instanc
 			VolumeId:   volumeId,
 			Size:       gibToMib(uint64(resp.Size)),
 			Persistent: true,
+			Encrypted:  resp.Encrypted,
 		},
 	}
 	return &volume, nil, nil
@@ -417,6 +434,7 @@ func (v *ebsVolumeSource) DescribeVolumes(volIds []string) ([]storage.DescribeVo
 			Size:       gibToMib(uint64(vol.Size)),
 			VolumeId:   vol.Id,
 			Persistent: true,
+			Encrypted:  vol.Encrypted,
 		}
 		for _, attachment := range vol.Attachments {
 			if attachment.DeleteOnTermination {
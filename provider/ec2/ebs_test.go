@@ -51,6 +51,39 @@ func (*storageSuite) TestValidateConfigUnknownConfig(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil) // unknown attrs ignored
 }
 
+func (*storageSuite) TestValidateConfigKMSKeyId(c *gc.C) {
+	p := ec2.EBSProvider()
+	cfg, err := storage.NewConfig("foo", ec2.EBS_ProviderType, map[string]interface{}{
+		"kms-key-id": "arn:aws:kms:us-east-1:123456789012:key/abcd",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = p.ValidateConfig(cfg)
+	c.Assert(err, gc.ErrorMatches, "KMS key ID specified, but volume is not encrypted")
+
+	cfg, err = storage.NewConfig("foo", ec2.EBS_ProviderType, map[string]interface{}{
+		"encrypted":  true,
+		"kms-key-id": "arn:aws:kms:us-east-1:123456789012:key/abcd",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = p.ValidateConfig(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (*storageSuite) TestParseVolumeOptionsKMSKeyId(c *gc.C) {
+	vol, err := ec2.ParseVolumeOptions(1024, map[string]interface{}{
+		"encrypted":  true,
+		"kms-key-id": "arn:aws:kms:us-east-1:123456789012:key/abcd",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(vol.Encrypted, jc.IsTrue)
+	c.Assert(vol.KmsKeyId, gc.Equals, "arn:aws:kms:us-east-1:123456789012:key/abcd")
+
+	_, err = ec2.ParseVolumeOptions(1024, map[string]interface{}{
+		"kms-key-id": "arn:aws:kms:us-east-1:123456789012:key/abcd",
+	})
+	c.Assert(err, gc.ErrorMatches, "KMS key ID specified, but volume is not encrypted")
+}
+
 func (s *storageSuite) TestSupports(c *gc.C) {
 	p := ec2.EBSProvider()
 	c.Assert(p.Supports(storage.StorageKindBlock), jc.IsTrue)
@@ -252,6 +285,29 @@ func (s *ebsVolumeSuite) TestCreateVolumes(c *gc.C) {
 	s.assertCreateVolumes(c, vs, "")
 }
 
+func (s *ebsVolumeSuite) TestCreateVolumesEncrypted(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	results, err := vs.CreateVolumes([]storage.VolumeParams{{
+		Tag:      names.NewVolumeTag("0"),
+		Size:     10 * 1000,
+		Provider: ec2.EBS_ProviderType,
+		Attributes: map[string]interface{}{
+			"encrypted": true,
+		},
+		Attachment: &storage.VolumeAttachmentParams{
+			AttachmentParams: storage.AttachmentParams{
+				InstanceId: instance.Id(
+					s.srv.ec2srv.NewInstances(1, "m1.medium", imageId, ec2test.Running, nil)[0],
+				),
+			},
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].Volume.Encrypted, jc.IsTrue)
+}
+
 func (s *ebsVolumeSuite) TestVolumeTags(c *gc.C) {
 	vs := s.volumeSource(c, nil)
 	results, err := s.createVolumes(vs, "")
@@ -59,6 +59,7 @@ var (
 	GetBlockDeviceMappings      = getBlockDeviceMappings
 	IsVPCNotUsableError         = isVPCNotUsableError
 	IsVPCNotRecommendedError    = isVPCNotRecommendedError
+	ParseVolumeOptions          = parseVolumeOptions
 )
 
 const VPCIDNone = vpcIDNone
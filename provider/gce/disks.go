@@ -9,6 +9,7 @@ import (
 	"sync"
 
 	"github.com/juju/errors"
+	"github.com/juju/schema"
 	"github.com/juju/utils"
 	"github.com/juju/utils/set"
 
@@ -19,8 +20,51 @@ import (
 
 const (
 	storageProviderType = storage.ProviderType("gce")
+
+	// diskEncrypted is the name of the pool config attribute
+	// specifying whether disks are encrypted with a customer-managed
+	// key. GCE always encrypts persistent disks at rest, but by
+	// default with Google-managed keys, which we don't report as
+	// encrypted.
+	diskEncrypted = "encrypted"
+
+	// diskEncryptionKey is the name of the pool config attribute
+	// specifying the Cloud KMS key with which to encrypt disks.
+	diskEncryptionKey = "disk-encryption-key"
+)
+
+var diskConfigChecker = schema.FieldMap(
+	schema.Fields{
+		diskEncrypted:     schema.Bool(),
+		diskEncryptionKey: schema.String(),
+	},
+	schema.Defaults{
+		diskEncrypted:     false,
+		diskEncryptionKey: "",
+	},
 )
 
+type diskConfig struct {
+	encryptionKey string
+}
+
+func newDiskConfig(attrs map[string]interface{}) (*diskConfig, error) {
+	out, err := diskConfigChecker.Coerce(attrs, nil)
+	if err != nil {
+		return nil, errors.Annotate(err, "validating GCE storage config")
+	}
+	coerced := out.(map[string]interface{})
+	encrypted := coerced[diskEncrypted].(bool)
+	key := coerced[diskEncryptionKey].(string)
+	if key != "" && !encrypted {
+		return nil, errors.Errorf("%s specified, but disk is not encrypted", diskEncryptionKey)
+	}
+	if encrypted && key == "" {
+		return nil, errors.Errorf("disk is encrypted, but %s unspecified", diskEncryptionKey)
+	}
+	return &diskConfig{encryptionKey: key}, nil
+}
+
 func init() {
 	//TODO(perrito666) Add explicit pools.
 }
@@ -30,7 +74,8 @@ type storageProvider struct{}
 var _ storage.Provider = (*storageProvider)(nil)
 
 func (g *storageProvider) ValidateConfig(cfg *storage.Config) error {
-	return nil
+	_, err := newDiskConfig(cfg.Attrs())
+	return errors.Trace(err)
 }
 
 func (g *storageProvider) Supports(k storage.StorageKind) bool {
@@ -182,6 +227,10 @@ func (v *volumeSource) createOneVolume(p storage.VolumeParams, instances instanc
 	if !ok {
 		persistentType = google.DiskPersistentStandard
 	}
+	diskConfig, err := newDiskConfig(p.Attributes)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
 
 	zone = inst.ZoneName
 	volumeName, err = nameVolume(zone)
@@ -195,6 +244,7 @@ func (v *volumeSource) createOneVolume(p storage.VolumeParams, instances instanc
 		Name:               volumeName,
 		PersistentDiskType: persistentType,
 		Description:        v.modelUUID,
+		EncryptionKey:      diskConfig.encryptionKey,
 	}
 
 	gceDisks, err := v.gce.CreateDisks(zone, []google.DiskSpec{disk})
@@ -218,6 +268,7 @@ func (v *volumeSource) createOneVolume(p storage.VolumeParams, instances instanc
 			VolumeId:   gceDisk.Name,
 			Size:       gceDisk.Size,
 			Persistent: true,
+			Encrypted:  gceDisk.EncryptionKey != "",
 		},
 	}
 
@@ -326,8 +377,9 @@ func (v *volumeSource) describeOneVolume(volName string) (storage.DescribeVolume
 	}
 	desc := storage.DescribeVolumesResult{
 		&storage.VolumeInfo{
-			Size:     disk.Size,
-			VolumeId: disk.Name,
+			Size:      disk.Size,
+			VolumeId:  disk.Name,
+			Encrypted: disk.EncryptionKey != "",
 		},
 		nil,
 	}
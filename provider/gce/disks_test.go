@@ -36,6 +36,34 @@ func (s *storageProviderSuite) TestValidateConfig(c *gc.C) {
 	c.Check(err, jc.ErrorIsNil)
 }
 
+func (s *storageProviderSuite) TestValidateConfigEncrypted(c *gc.C) {
+	cfg, err := storage.NewConfig("foo", "gce", map[string]interface{}{
+		"encrypted":           true,
+		"disk-encryption-key": "projects/p/locations/l/keyRings/r/cryptoKeys/k",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.provider.ValidateConfig(cfg)
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *storageProviderSuite) TestValidateConfigDiskEncryptionKeyNotEncrypted(c *gc.C) {
+	cfg, err := storage.NewConfig("foo", "gce", map[string]interface{}{
+		"disk-encryption-key": "projects/p/locations/l/keyRings/r/cryptoKeys/k",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.provider.ValidateConfig(cfg)
+	c.Check(err, gc.ErrorMatches, "disk-encryption-key specified, but disk is not encrypted")
+}
+
+func (s *storageProviderSuite) TestValidateConfigEncryptedNoKey(c *gc.C) {
+	cfg, err := storage.NewConfig("foo", "gce", map[string]interface{}{
+		"encrypted": true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.provider.ValidateConfig(cfg)
+	c.Check(err, gc.ErrorMatches, "disk is encrypted, but disk-encryption-key unspecified")
+}
+
 func (s *storageProviderSuite) TestBlockStorageSupport(c *gc.C) {
 	supports := s.provider.Supports(storage.StorageKindBlock)
 	c.Check(supports, jc.IsTrue)
@@ -133,6 +161,7 @@ func (s *volumeSourceSuite) TestCreateVolumes(c *gc.C) {
 	// Volume was created
 	c.Assert(res[0].Error, jc.ErrorIsNil)
 	c.Assert(res[0].Volume.VolumeId, gc.Equals, s.BaseDisk.Name)
+	c.Assert(res[0].Volume.Encrypted, jc.IsFalse)
 	c.Assert(res[0].Volume.HardwareId, gc.Equals, "")
 
 	// Volume was also attached as indicated by Attachment in params.
@@ -166,6 +195,32 @@ func (s *volumeSourceSuite) TestCreateVolumes(c *gc.C) {
 	c.Assert(call[0].InstanceId, gc.Equals, string(s.instId))
 }
 
+func (s *volumeSourceSuite) TestCreateVolumesEncrypted(c *gc.C) {
+	key := "projects/p/locations/l/keyRings/r/cryptoKeys/k"
+	disk := *s.BaseDisk
+	disk.EncryptionKey = key
+	s.FakeConn.Insts = []google.Instance{*s.BaseInstance}
+	s.FakeConn.GoogleDisks = []*google.Disk{&disk}
+	s.FakeConn.AttachedDisk = &google.AttachedDisk{
+		VolumeName: disk.Name,
+		DeviceName: "home-zone-1234567",
+		Mode:       "READ_WRITE",
+	}
+	s.params[0].Attributes = map[string]interface{}{
+		"encrypted":           true,
+		"disk-encryption-key": key,
+	}
+	res, err := s.source.CreateVolumes(s.params)
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(res, gc.HasLen, 1)
+	c.Assert(res[0].Error, jc.ErrorIsNil)
+	c.Assert(res[0].Volume.Encrypted, jc.IsTrue)
+
+	_, call := s.FakeConn.WasCalled("CreateDisks")
+	c.Assert(call, gc.HasLen, 1)
+	c.Assert(call[0].Disks[0].EncryptionKey, gc.Equals, key)
+}
+
 func (s *volumeSourceSuite) TestDestroyVolumes(c *gc.C) {
 	errs, err := s.source.DestroyVolumes([]string{"a--volume-name"})
 	c.Check(err, jc.ErrorIsNil)
@@ -253,6 +308,7 @@ func (s *volumeSourceSuite) TestDescribeVolumes(c *gc.C) {
 	c.Assert(res, gc.HasLen, 1)
 	c.Assert(res[0].VolumeInfo.Size, gc.Equals, uint64(1024))
 	c.Assert(res[0].VolumeInfo.VolumeId, gc.Equals, volName)
+	c.Assert(res[0].VolumeInfo.Encrypted, jc.IsFalse)
 
 	diskCalled, call := s.FakeConn.WasCalled("Disk")
 	c.Check(call, gc.HasLen, 1)
@@ -4,6 +4,8 @@
 package google
 
 import (
+	"net/http"
+
 	"github.com/juju/errors"
 	"google.golang.org/api/compute/v1"
 )
@@ -54,13 +56,17 @@ type rawConnectionWrapper interface {
 	ListAvailabilityZones(projectID, region string) ([]*compute.Zone, error)
 	// CreateDisk will create a gce Persistent Block device that matches
 	// the specified in spec.
-	CreateDisk(project, zone string, spec *compute.Disk) error
+	CreateDisk(project, zone string, spec *compute.Disk, encryptionKey string) error
 	// ListDisks returns a list of disks available for a given project.
 	ListDisks(project, zone string) ([]*compute.Disk, error)
 	// RemoveDisk will delete the disk identified by id.
 	RemoveDisk(project, zone, id string) error
 	// GetDisk will return the disk correspondent to the passed id.
 	GetDisk(project, zone, id string) (*compute.Disk, error)
+	// GetDiskEncryptionKey returns the name of the Cloud KMS key
+	// with which the disk identified by id is encrypted, or "" if
+	// the disk is encrypted with a Google-managed key.
+	GetDiskEncryptionKey(project, zone, id string) (string, error)
 	// AttachDisk will attach the disk described in attachedDisks (if it exists) into
 	// the instance with id instanceId.
 	AttachDisk(project, zone, instanceId string, attachedDisk *compute.AttachedDisk) error
@@ -94,20 +100,20 @@ type Connection struct {
 // result in an error. All errors that happen while authenticating and
 // connecting are returned by Connect.
 func Connect(connCfg ConnectionConfig, creds *Credentials) (*Connection, error) {
-	raw, err := newRawConnection(creds)
+	service, client, err := newRawConnection(creds)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 
 	conn := &Connection{
-		raw:       &rawConn{raw},
+		raw:       &rawConn{Service: service, client: client},
 		region:    connCfg.Region,
 		projectID: connCfg.ProjectID,
 	}
 	return conn, nil
 }
 
-var newRawConnection = func(creds *Credentials) (*compute.Service, error) {
+var newRawConnection = func(creds *Credentials) (*compute.Service, *http.Client, error) {
 	return newConnection(creds)
 }
 
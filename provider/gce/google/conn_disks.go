@@ -19,18 +19,15 @@ func (gce *Connection) CreateDisks(zone string, disks []DiskSpec) ([]*Disk, erro
 		if err != nil {
 			return []*Disk{}, errors.Annotate(err, "cannot create disk spec")
 		}
-		if err := gce.createDisk(zone, d); err != nil {
+		if err := gce.raw.CreateDisk(gce.projectID, zone, d, disk.EncryptionKey); err != nil {
 			return []*Disk{}, errors.Annotatef(err, "cannot create disk %q", disk.Name)
 		}
 		results[i] = NewDisk(d)
+		results[i].EncryptionKey = disk.EncryptionKey
 	}
 	return results, nil
 }
 
-func (gce *Connection) createDisk(zone string, disk *compute.Disk) error {
-	return gce.raw.CreateDisk(gce.projectID, zone, disk)
-}
-
 // Disks implements storage section of gceConnection.
 func (gce *Connection) Disks(zone string) ([]*Disk, error) {
 	computeDisks, err := gce.raw.ListDisks(gce.projectID, zone)
@@ -56,7 +53,13 @@ func (gce *Connection) Disk(zone, name string) (*Disk, error) {
 	if err != nil {
 		return nil, errors.Annotatef(err, "cannot get disk %q in zone %q", name, zone)
 	}
-	return NewDisk(d), nil
+	key, err := gce.raw.GetDiskEncryptionKey(gce.projectID, zone, name)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get encryption key of disk %q in zone %q", name, zone)
+	}
+	disk := NewDisk(d)
+	disk.EncryptionKey = key
+	return disk, nil
 }
 
 // deviceName will generate a device name from the passed
@@ -40,6 +40,21 @@ func (s *connSuite) TestConnectionCreateDisks(c *gc.C) {
 	c.Check(s.FakeConn.Calls[0].ComputeDisk.Name, gc.Equals, fakeVolName)
 }
 
+func (s *connSuite) TestConnectionCreateDisksEncrypted(c *gc.C) {
+	spec, _, err := fakeDiskAndSpec()
+	c.Check(err, jc.ErrorIsNil)
+	spec.EncryptionKey = "projects/spam/locations/global/keyRings/ring/cryptoKeys/key"
+
+	disks, err := s.Conn.CreateDisks("home-zone", []google.DiskSpec{spec})
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(disks, gc.HasLen, 1)
+	c.Assert(disks[0].EncryptionKey, gc.Equals, spec.EncryptionKey)
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "CreateDisk")
+	c.Check(s.FakeConn.Calls[0].Key, gc.Equals, spec.EncryptionKey)
+}
+
 func (s *connSuite) TestConnectionDisks(c *gc.C) {
 	_, fakeDisk, err := fakeDiskAndSpec()
 	c.Check(err, jc.ErrorIsNil)
@@ -66,10 +81,22 @@ func (s *connSuite) TestConnectionDisk(c *gc.C) {
 	fakeGoogleDisk := google.NewDisk(fakeDisk)
 	c.Assert(disk, gc.DeepEquals, fakeGoogleDisk)
 
-	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls, gc.HasLen, 2)
 	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "GetDisk")
 	c.Check(s.FakeConn.Calls[0].ProjectID, gc.Equals, "spam")
 	c.Check(s.FakeConn.Calls[0].ZoneName, gc.Equals, "home-zone")
+	c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "GetDiskEncryptionKey")
+	c.Check(s.FakeConn.Calls[1].ID, gc.Equals, fakeVolName)
+}
+
+func (s *connSuite) TestConnectionDiskEncrypted(c *gc.C) {
+	_, fakeDisk, err := fakeDiskAndSpec()
+	c.Check(err, jc.ErrorIsNil)
+	s.FakeConn.Disk = fakeDisk
+	s.FakeConn.DiskEncryptionKey = "projects/spam/locations/global/keyRings/ring/cryptoKeys/key"
+	disk, err := s.Conn.Disk("home-zone", fakeVolName)
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(disk.EncryptionKey, gc.Equals, s.FakeConn.DiskEncryptionKey)
 }
 
 func (s *connSuite) TestConnectionAttachDisk(c *gc.C) {
@@ -114,6 +114,10 @@ type DiskSpec struct {
 	// Description was picked because it is not mutable (actually no field is) for disks.
 	// There is a metadata API but it is not supported for disks for the moment.
 	Description string
+	// EncryptionKey is the name of the Cloud KMS key with which the
+	// disk should be encrypted. If empty, GCE encrypts the disk with
+	// a Google-managed key.
+	EncryptionKey string
 }
 
 // TooSmall checks the spec's size hint and indicates whether or not
@@ -214,6 +218,9 @@ type Disk struct {
 	Zone string
 	// DiskStatus holds the status of he aforementioned disk.
 	Status DiskStatus
+	// EncryptionKey is the name of the Cloud KMS key with which the
+	// disk is encrypted, or empty if it uses a Google-managed key.
+	EncryptionKey string
 }
 
 func NewDisk(cd *compute.Disk) *Disk {
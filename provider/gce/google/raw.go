@@ -4,7 +4,9 @@
 package google
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"path"
 	"strings"
@@ -39,6 +41,10 @@ func convertRawAPIError(err error) error {
 
 type rawConn struct {
 	*compute.Service
+
+	// client is the HTTP client used by Service. It's used directly
+	// for requests the compute API client doesn't support.
+	client *http.Client
 }
 
 func (rc *rawConn) GetProject(projectID string) (*compute.Project, error) {
@@ -198,11 +204,15 @@ func formatDiskType(project, zone string, spec *compute.Disk) {
 	spec.Type = fmt.Sprintf(diskTypesBase, project, zone, spec.Type)
 }
 
-func (rc *rawConn) CreateDisk(project, zone string, spec *compute.Disk) error {
-	ds := rc.Service.Disks
+func (rc *rawConn) CreateDisk(project, zone string, spec *compute.Disk, encryptionKey string) error {
 	formatDiskType(project, zone, spec)
-	call := ds.Insert(project, zone, spec)
-	op, err := call.Do()
+	var op *compute.Operation
+	var err error
+	if encryptionKey == "" {
+		op, err = rc.Service.Disks.Insert(project, zone, spec).Do()
+	} else {
+		op, err = rc.insertEncryptedDisk(project, zone, spec, encryptionKey)
+	}
 	if err != nil {
 		return errors.Annotate(err, "could not create a new disk")
 	}
@@ -249,6 +259,80 @@ func (rc *rawConn) GetDisk(project, zone, id string) (*compute.Disk, error) {
 	return disk, nil
 }
 
+// customerEncryptionKey identifies the Cloud KMS key with which a disk
+// is encrypted.
+type customerEncryptionKey struct {
+	KmsKeyName string `json:"kmsKeyName,omitempty"`
+}
+
+// encryptedDisk is a disk encrypted with a customer-managed key. The
+// compute API client predates customer-managed encryption keys, so
+// such disks are created with requests of our own.
+type encryptedDisk struct {
+	*compute.Disk
+	DiskEncryptionKey *customerEncryptionKey `json:"diskEncryptionKey,omitempty"`
+}
+
+func (rc *rawConn) insertEncryptedDisk(project, zone string, spec *compute.Disk, encryptionKey string) (*compute.Operation, error) {
+	disk := encryptedDisk{
+		Disk:              spec,
+		DiskEncryptionKey: &customerEncryptionKey{KmsKeyName: encryptionKey},
+	}
+	var op *compute.Operation
+	err := rc.do("POST", "{project}/zones/{zone}/disks", map[string]string{
+		"project": project,
+		"zone":    zone,
+	}, disk, &op)
+	return op, errors.Trace(err)
+}
+
+func (rc *rawConn) GetDiskEncryptionKey(project, zone, id string) (string, error) {
+	var disk encryptedDisk
+	err := rc.do("GET", "{project}/zones/{zone}/disks/{disk}", map[string]string{
+		"project": project,
+		"zone":    zone,
+		"disk":    id,
+	}, nil, &disk)
+	if err != nil {
+		return "", errors.Annotatef(err, "cannot get disk %q at zone %q in project %q", id, zone, project)
+	}
+	if disk.DiskEncryptionKey == nil {
+		return "", nil
+	}
+	return disk.DiskEncryptionKey.KmsKeyName, nil
+}
+
+// do sends a request to the compute API in the same way as the
+// compute API client, decoding the response into result.
+func (rc *rawConn) do(method, relPath string, expansions map[string]string, body, result interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		var err error
+		reader, err = googleapi.WithoutDataWrapper.JSONReader(body)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	urls := googleapi.ResolveRelative(rc.BasePath, relPath) + "?alt=json"
+	req, err := http.NewRequest(method, urls, reader)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	googleapi.Expand(req.URL, expansions)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	res, err := rc.client.Do(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer googleapi.CloseBody(res)
+	if err := googleapi.CheckResponse(res); err != nil {
+		return err
+	}
+	return errors.Trace(json.NewDecoder(res.Body).Decode(result))
+}
+
 func (rc *rawConn) AttachDisk(project, zone, instanceId string, disk *compute.AttachedDisk) error {
 	call := rc.Instances.AttachDisk(project, zone, instanceId, disk)
 	_, err := call.Do() // Perhaps return something from the Op
@@ -4,6 +4,11 @@
 package google
 
 import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
@@ -35,7 +40,7 @@ func (s *rawConnSuite) SetUpTest(c *gc.C) {
 	service.ZoneOperations = compute.NewZoneOperationsService(service)
 	service.RegionOperations = compute.NewRegionOperationsService(service)
 	service.GlobalOperations = compute.NewGlobalOperationsService(service)
-	s.rawConn = &rawConn{service}
+	s.rawConn = &rawConn{Service: service}
 	s.strategy.Min = 4
 
 	s.callCount = 0
@@ -148,3 +153,60 @@ func (s *rawConnSuite) TestConnectionWaitOperationError(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, `.* "testing-wait-operation-error" .*`)
 	c.Check(s.callCount, gc.Equals, 1)
 }
+
+func (s *rawConnSuite) serveCompute(c *gc.C, handler http.HandlerFunc) {
+	srv := httptest.NewServer(handler)
+	s.AddCleanup(func(*gc.C) { srv.Close() })
+	s.rawConn.BasePath = srv.URL + "/"
+	s.rawConn.client = http.DefaultClient
+}
+
+func (s *rawConnSuite) TestCreateDiskEncrypted(c *gc.C) {
+	var body map[string]interface{}
+	s.serveCompute(c, func(w http.ResponseWriter, req *http.Request) {
+		c.Check(req.Method, gc.Equals, "POST")
+		c.Check(req.URL.Path, gc.Equals, "/proj/zones/home-zone/disks")
+		c.Check(json.NewDecoder(req.Body).Decode(&body), jc.ErrorIsNil)
+		fmt.Fprint(w, `{"name": "some_op", "status": "DONE"}`)
+	})
+
+	spec := &compute.Disk{Name: "disk", SizeGb: 10}
+	err := s.rawConn.CreateDisk("proj", "home-zone", spec, "projects/proj/keyRings/ring/cryptoKeys/key")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(body["name"], gc.Equals, "disk")
+	c.Check(body["diskEncryptionKey"], jc.DeepEquals, map[string]interface{}{
+		"kmsKeyName": "projects/proj/keyRings/ring/cryptoKeys/key",
+	})
+}
+
+func (s *rawConnSuite) TestGetDiskEncryptionKey(c *gc.C) {
+	s.serveCompute(c, func(w http.ResponseWriter, req *http.Request) {
+		c.Check(req.Method, gc.Equals, "GET")
+		c.Check(req.URL.Path, gc.Equals, "/proj/zones/home-zone/disks/disk")
+		fmt.Fprint(w, `{"name": "disk", "diskEncryptionKey": {"kmsKeyName": "some-key"}}`)
+	})
+
+	key, err := s.rawConn.GetDiskEncryptionKey("proj", "home-zone", "disk")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(key, gc.Equals, "some-key")
+}
+
+func (s *rawConnSuite) TestGetDiskEncryptionKeyGoogleManaged(c *gc.C) {
+	s.serveCompute(c, func(w http.ResponseWriter, req *http.Request) {
+		fmt.Fprint(w, `{"name": "disk"}`)
+	})
+
+	key, err := s.rawConn.GetDiskEncryptionKey("proj", "home-zone", "disk")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(key, gc.Equals, "")
+}
+
+func (s *rawConnSuite) TestGetDiskEncryptionKeyError(c *gc.C) {
+	s.serveCompute(c, func(w http.ResponseWriter, req *http.Request) {
+		http.Error(w, "{}", http.StatusNotFound)
+	})
+
+	_, err := s.rawConn.GetDiskEncryptionKey("proj", "home-zone", "disk")
+	c.Check(err, gc.ErrorMatches, `cannot get disk "disk" at zone "home-zone" in project "proj": .*`)
+}
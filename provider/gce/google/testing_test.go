@@ -158,6 +158,7 @@ type fakeCall struct {
 	AttachedDisk *compute.AttachedDisk
 	DeviceName   string
 	ComputeDisk  *compute.Disk
+	Key          string
 }
 
 type fakeConn struct {
@@ -173,6 +174,8 @@ type fakeConn struct {
 	Disks         []*compute.Disk
 	Disk          *compute.Disk
 	AttachedDisks []*compute.AttachedDisk
+
+	DiskEncryptionKey string
 }
 
 func (rc *fakeConn) GetProject(projectID string) (*compute.Project, error) {
@@ -330,12 +333,13 @@ func (rc *fakeConn) ListAvailabilityZones(projectID, region string) ([]*compute.
 	return rc.Zones, err
 }
 
-func (rc *fakeConn) CreateDisk(project, zone string, spec *compute.Disk) error {
+func (rc *fakeConn) CreateDisk(project, zone string, spec *compute.Disk, encryptionKey string) error {
 	call := fakeCall{
 		FuncName:    "CreateDisk",
 		ProjectID:   project,
 		ZoneName:    zone,
 		ComputeDisk: spec,
+		Key:         encryptionKey,
 	}
 	rc.Calls = append(rc.Calls, call)
 
@@ -393,6 +397,22 @@ func (rc *fakeConn) GetDisk(project, zone, id string) (*compute.Disk, error) {
 	return rc.Disk, err
 }
 
+func (rc *fakeConn) GetDiskEncryptionKey(project, zone, id string) (string, error) {
+	call := fakeCall{
+		FuncName:  "GetDiskEncryptionKey",
+		ProjectID: project,
+		ZoneName:  zone,
+		ID:        id,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	return rc.DiskEncryptionKey, err
+}
+
 func (rc *fakeConn) AttachDisk(project, zone, instanceId string, attachedDisk *compute.AttachedDisk) error {
 	call := fakeCall{
 		FuncName:     "AttachDisk",
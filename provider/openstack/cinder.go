@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/juju/errors"
+	"github.com/juju/schema"
 	"github.com/juju/utils"
 	"gopkg.in/goose.v1/cinder"
 	"gopkg.in/goose.v1/identity"
@@ -31,8 +32,57 @@ const (
 	volumeStatusDeleting  = "deleting"
 	volumeStatusError     = "error"
 	volumeStatusInUse     = "in-use"
+
+	// CinderVolumeType is the name of the pool config attribute
+	// specifying the Cinder volume type with which volumes are
+	// created.
+	CinderVolumeType = "volume-type"
+
+	// CinderEncrypted is the name of the pool config attribute
+	// specifying whether or not volumes are encrypted. Cinder
+	// encrypts volumes according to their volume type, so an
+	// encrypted pool must specify a volume type that the cloud
+	// operator has configured with an encryption provider.
+	CinderEncrypted = "encrypted"
+)
+
+var cinderConfigFields = schema.Fields{
+	CinderVolumeType: schema.String(),
+	CinderEncrypted:  schema.Bool(),
+}
+
+var cinderConfigChecker = schema.FieldMap(
+	cinderConfigFields,
+	schema.Defaults{
+		CinderVolumeType: "",
+		CinderEncrypted:  false,
+	},
 )
 
+type cinderConfig struct {
+	volumeType string
+	encrypted  bool
+}
+
+func newCinderConfig(attrs map[string]interface{}) (*cinderConfig, error) {
+	out, err := cinderConfigChecker.Coerce(attrs, nil)
+	if err != nil {
+		return nil, errors.Annotate(err, "validating Cinder storage config")
+	}
+	coerced := out.(map[string]interface{})
+	cinderConfig := &cinderConfig{
+		volumeType: coerced[CinderVolumeType].(string),
+		encrypted:  coerced[CinderEncrypted].(bool),
+	}
+	if cinderConfig.encrypted && cinderConfig.volumeType == "" {
+		return nil, errors.Errorf(
+			"%s requires %s to be set to an encrypted volume type",
+			CinderEncrypted, CinderVolumeType,
+		)
+	}
+	return cinderConfig, nil
+}
+
 type cinderProvider struct {
 	newStorageAdapter func(*config.Config) (openstackStorage, error)
 }
@@ -84,7 +134,8 @@ func (s *cinderProvider) Scope() storage.Scope {
 func (p *cinderProvider) ValidateConfig(cfg *storage.Config) error {
 	// TODO(axw) 2015-05-01 #1450737
 	// Reject attempts to create non-persistent volumes.
-	return nil
+	_, err := newCinderConfig(cfg.Attrs())
+	return errors.Trace(err)
 }
 
 // Dynamic implements storage.Provider.
@@ -115,6 +166,10 @@ func (s *cinderVolumeSource) CreateVolumes(args []storage.VolumeParams) ([]stora
 }
 
 func (s *cinderVolumeSource) createVolume(arg storage.VolumeParams) (*storage.Volume, error) {
+	cinderConfig, err := newCinderConfig(arg.Attributes)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	var metadata interface{}
 	if len(arg.ResourceTags) > 0 {
 		metadata = arg.ResourceTags
@@ -127,6 +182,7 @@ func (s *cinderVolumeSource) createVolume(arg storage.VolumeParams) (*storage.Vo
 		// TODO(axw) use the AZ of the initially attached machine.
 		AvailabilityZone: "",
 		Metadata:         metadata,
+		VolumeType:       cinderConfig.volumeType,
 	})
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -146,7 +202,9 @@ func (s *cinderVolumeSource) createVolume(arg storage.VolumeParams) (*storage.Vo
 		return nil, errors.Errorf("waiting for volume to be provisioned: %s", err)
 	}
 	logger.Debugf("created volume: %+v", cinderVolume)
-	return &storage.Volume{arg.Tag, cinderToJujuVolumeInfo(cinderVolume)}, nil
+	volumeInfo := cinderToJujuVolumeInfo(cinderVolume)
+	volumeInfo.Encrypted = cinderConfig.encrypted
+	return &storage.Volume{arg.Tag, volumeInfo}, nil
 }
 
 // ListVolumes is specified on the storage.VolumeSource interface.
@@ -158,6 +158,79 @@ func (s *cinderVolumeSourceSuite) TestCreateVolume(c *gc.C) {
 	c.Check(getVolumeCalls, gc.Equals, 2)
 }
 
+func (s *cinderVolumeSourceSuite) TestCreateVolumeEncrypted(c *gc.C) {
+	mockAdapter := &mockAdapter{
+		createVolume: func(args cinder.CreateVolumeVolumeParams) (*cinder.Volume, error) {
+			c.Assert(args, jc.DeepEquals, cinder.CreateVolumeVolumeParams{
+				Size:       1,
+				Name:       "juju-testenv-volume-123",
+				VolumeType: "luks",
+			})
+			return &cinder.Volume{ID: mockVolId}, nil
+		},
+		getVolume: func(volumeId string) (*cinder.Volume, error) {
+			return &cinder.Volume{
+				ID:     volumeId,
+				Size:   1,
+				Status: "available",
+			}, nil
+		},
+	}
+
+	volSource := openstack.NewCinderVolumeSource(mockAdapter)
+	results, err := volSource.CreateVolumes([]storage.VolumeParams{{
+		Provider: openstack.CinderProviderType,
+		Tag:      mockVolumeTag,
+		Size:     1024,
+		Attributes: map[string]interface{}{
+			"volume-type": "luks",
+			"encrypted":   true,
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Check(results[0].Volume, jc.DeepEquals, &storage.Volume{
+		mockVolumeTag,
+		storage.VolumeInfo{
+			VolumeId:   mockVolId,
+			Size:       1024,
+			Persistent: true,
+			Encrypted:  true,
+		},
+	})
+}
+
+func (s *cinderVolumeSourceSuite) TestValidateConfig(c *gc.C) {
+	p := openstack.NewCinderProvider(&mockAdapter{})
+	for i, test := range []struct {
+		attrs map[string]interface{}
+		err   string
+	}{{
+		attrs: map[string]interface{}{},
+	}, {
+		attrs: map[string]interface{}{"volume-type": "ssd"},
+	}, {
+		attrs: map[string]interface{}{"volume-type": "luks", "encrypted": "true"},
+	}, {
+		attrs: map[string]interface{}{"encrypted": true},
+		err:   "encrypted requires volume-type to be set to an encrypted volume type",
+	}, {
+		attrs: map[string]interface{}{"encrypted": "perhaps"},
+		err:   `validating Cinder storage config: encrypted: expected bool, got string\("perhaps"\)`,
+	}} {
+		c.Logf("test %d: %v", i, test.attrs)
+		cfg, err := storage.NewConfig("name", openstack.CinderProviderType, test.attrs)
+		c.Assert(err, jc.ErrorIsNil)
+		err = p.ValidateConfig(cfg)
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.err)
+		}
+	}
+}
+
 func (s *cinderVolumeSourceSuite) TestResourceTags(c *gc.C) {
 	var created bool
 	mockAdapter := &mockAdapter{
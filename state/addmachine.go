@@ -11,6 +11,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/replicaset"
 	jujutxn "github.com/juju/txn"
+	"github.com/juju/utils/set"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
@@ -588,8 +589,32 @@ func (st *State) machineStorageOps(
 		})
 	}
 
-	// TODO(axw) handle args.filesystemAttachments, args.volumeAttachments
-	// when we handle attaching to existing (e.g. shared) storage.
+	// Create attachments to existing (shared) filesystems. Shared
+	// filesystems are attached once per machine, so we skip any that
+	// are already attached for another unit of the application.
+	attachedFilesystems := set.NewStrings(mdoc.Filesystems...)
+	filesystemAttachmentIds := set.NewStrings()
+	for tag := range args.filesystemAttachments {
+		filesystemAttachmentIds.Add(tag.Id())
+	}
+	for _, id := range filesystemAttachmentIds.SortedValues() {
+		if attachedFilesystems.Contains(id) {
+			continue
+		}
+		tag := names.NewFilesystemTag(id)
+		filesystemOps = append(filesystemOps, txn.Op{
+			C:      filesystemsC,
+			Id:     tag.Id(),
+			Assert: isAliveDoc,
+			Update: bson.D{{"$inc", bson.D{{"attachmentcount", 1}}}},
+		})
+		fsAttachments = append(fsAttachments, filesystemAttachmentTemplate{
+			tag: tag, params: args.filesystemAttachments[tag],
+		})
+	}
+
+	// TODO(axw) handle args.volumeAttachments when we handle
+	// attaching to existing (e.g. shared) volumes.
 
 	ops := make([]txn.Op, 0, len(filesystemOps)+len(volumeOps)+len(fsAttachments)+len(volumeAttachments))
 	if len(fsAttachments) > 0 {
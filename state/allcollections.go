@@ -119,6 +119,15 @@ func allCollections() collectionSchema {
 		// This collection tracks the progress of model migrations.
 		migrationsStatusC: {global: true},
 
+		// This collection records the reports from migration minion
+		// workers about their progress through each migration phase.
+		migrationsMinionSyncC: {
+			global: true,
+			indexes: []mgo.Index{{
+				Key: []string{"migration-id", "phase"},
+			}},
+		},
+
 		// This collection records the model migrations which
 		// are currently in progress. It is used to ensure that only
 		// one model migration document exists per environment.
@@ -408,6 +417,7 @@ const (
 	minUnitsC                = "minunits"
 	migrationsStatusC        = "migrations.status"
 	migrationsActiveC        = "migrations.active"
+	migrationsMinionSyncC    = "migrations.minionsync"
 	migrationsC              = "migrations"
 	modelSettingsSourcesC    = "modelSettingsSources"
 	modelUserLastConnectionC = "modelUserLastConnection"
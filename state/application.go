@@ -191,7 +191,11 @@ func (s *Application) destroyOps() ([]txn.Op, error) {
 	// removed, the application can also be removed.
 	if s.doc.UnitCount == 0 && s.doc.RelationCount == removeCount {
 		hasLastRefs := bson.D{{"life", Alive}, {"unitcount", 0}, {"relationcount", removeCount}}
-		return append(ops, s.removeOps(hasLastRefs)...), nil
+		removeOps, err := s.removeOps(hasLastRefs)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return append(ops, removeOps...), nil
 	}
 	// In all other cases, application removal will be handled as a consequence
 	// of the removal of the last unit or relation referencing it. If any
@@ -245,7 +249,7 @@ func removeResourcesOps(st *State, serviceID string) ([]txn.Op, error) {
 
 // removeOps returns the operations required to remove the service. Supplied
 // asserts will be included in the operation on the application document.
-func (s *Application) removeOps(asserts bson.D) []txn.Op {
+func (s *Application) removeOps(asserts bson.D) ([]txn.Op, error) {
 	settingsDocID := s.st.docID(s.settingsKey())
 	ops := []txn.Op{
 		{
@@ -276,7 +280,14 @@ func (s *Application) removeOps(asserts bson.D) []txn.Op {
 	if s.doc.CharmURL.Schema == "local" {
 		ops = append(ops, s.st.newCleanupOp(cleanupCharmForDyingService, s.doc.CharmURL.String()))
 	}
-	return ops
+	// Any shared storage is removed along with the service, destroying
+	// the shared filesystems.
+	storageOps, err := removeSharedStorageInstancesOps(s.st, s.ApplicationTag())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops = append(ops, storageOps...)
+	return ops, nil
 }
 
 // IsExposed returns whether this application is exposed. The explicitly open
@@ -891,15 +902,21 @@ func (s *Application) addUnitOps(principalName string, asserts bson.D) (string,
 	if err != nil {
 		return "", nil, err
 	}
+	sharedStorage, err := sharedStorageInstances(s.st, s.ApplicationTag())
+	if err != nil {
+		return "", nil, err
+	}
 	args := applicationAddUnitOpsArgs{
 		cons:          cons,
 		principalName: principalName,
 		storageCons:   storageCons,
+		sharedStorage: sharedStorage,
 	}
 	names, ops, err := s.addUnitOpsWithCons(args)
 	if err != nil {
 		return names, ops, err
 	}
+	ops = append(ops, sharedStorageIncrefOps(sharedStorage)...)
 	// we verify the application is alive
 	asserts = append(isAliveDoc, asserts...)
 	ops = append(ops, s.incUnitCountOp(asserts))
@@ -910,6 +927,12 @@ type applicationAddUnitOpsArgs struct {
 	principalName string
 	cons          constraints.Value
 	storageCons   map[string]StorageConstraints
+
+	// sharedStorage holds the tags of the application's shared
+	// storage instances, to which the unit will be attached. The
+	// caller is responsible for updating the attachment counts
+	// of the storage instances.
+	sharedStorage []names.StorageTag
 }
 
 // addServiceUnitOps is just like addUnitOps but explicitly takes a
@@ -939,6 +962,11 @@ func (s *Application) addUnitOpsWithCons(args applicationAddUnitOpsArgs) (string
 	if err != nil {
 		return "", nil, errors.Trace(err)
 	}
+	unitTag := names.NewUnitTag(name)
+	for _, storageTag := range args.sharedStorage {
+		storageOps = append(storageOps, createStorageAttachmentOp(storageTag, unitTag))
+		numStorageAttachments++
+	}
 
 	docID := s.st.docID(name)
 	globalKey := unitGlobalKey(name)
@@ -1113,7 +1141,11 @@ func (s *Application) removeUnitOps(u *Unit, asserts bson.D) ([]txn.Op, error) {
 	}
 	if s.doc.Life == Dying && s.doc.RelationCount == 0 && s.doc.UnitCount == 1 {
 		hasLastRef := bson.D{{"life", Dying}, {"relationcount", 0}, {"unitcount", 1}}
-		return append(ops, s.removeOps(hasLastRef)...), nil
+		removeOps, err := s.removeOps(hasLastRef)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return append(ops, removeOps...), nil
 	}
 	svcOp := txn.Op{
 		C:      applicationsC,
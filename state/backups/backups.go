@@ -83,9 +83,9 @@ func StoreArchive(stor filestorage.FileStorage, meta *Metadata, file io.Reader)
 
 // Backups is an abstraction around all juju backup-related functionality.
 type Backups interface {
-	// Create creates and stores a new juju backup archive. It updates
-	// the provided metadata.
-	Create(meta *Metadata, paths *Paths, dbInfo *DBInfo) error
+	// Create creates and stores a new juju backup archive, encrypted
+	// as specified. It updates the provided metadata.
+	Create(meta *Metadata, paths *Paths, dbInfo *DBInfo, encryption EncryptArgs) error
 
 	// Add stores the backup archive and returns its new ID.
 	Add(archive io.Reader, meta *Metadata) (string, error)
@@ -103,6 +103,18 @@ type Backups interface {
 	// it returns the tag string for the machine where the backup originated
 	// or error if the process fails.
 	Restore(backupId string, dbInfo *DBInfo, args RestoreArgs) (names.Tag, error)
+
+	// CreateModel creates and stores a new backup archive of a single
+	// model, encrypted as specified. It updates the provided metadata.
+	CreateModel(meta *Metadata, st ModelBackend, encryption EncryptArgs) error
+
+	// RestoreModel re-creates the model captured by a model backup in
+	// the controller, and returns the tag of the new model.
+	RestoreModel(backupId string, st ModelImporter, decryption DecryptArgs) (names.ModelTag, error)
+
+	// Verify checks that the backup archive is usable, and describes
+	// its content.
+	Verify(backupId string, decryption DecryptArgs) (*Verification, error)
 }
 
 type backups struct {
@@ -117,9 +129,13 @@ func NewBackups(stor filestorage.FileStorage) Backups {
 	return &b
 }
 
-// Create creates and stores a new juju backup archive and updates the
-// provided metadata.
-func (b *backups) Create(meta *Metadata, paths *Paths, dbInfo *DBInfo) error {
+// Create creates and stores a new juju backup archive, encrypted as
+// specified, and updates the provided metadata.
+func (b *backups) Create(meta *Metadata, paths *Paths, dbInfo *DBInfo, encryption EncryptArgs) error {
+	if err := encryption.Validate(); err != nil {
+		return errors.Annotate(err, "invalid encryption")
+	}
+
 	// TODO(fwereade): 2016-03-17 lp:1558657
 	meta.Started = time.Now().UTC()
 
@@ -149,6 +165,17 @@ func (b *backups) Create(meta *Metadata, paths *Paths, dbInfo *DBInfo) error {
 	}
 	defer result.archiveFile.Close()
 
+	// Encrypt the archive.
+	if scheme := encryption.Scheme(); scheme != "" {
+		encrypted, err := encryptResult(result, encryption)
+		if err != nil {
+			return errors.Annotate(err, "while encrypting backup archive")
+		}
+		defer encrypted.archiveFile.Close()
+		result = encrypted
+		meta.Encryption = scheme
+	}
+
 	// Finalize the metadata.
 	err = finishMeta(meta, result)
 	if err != nil {
@@ -6,6 +6,7 @@
 package backups
 
 import (
+	"io"
 	"net"
 	"strconv"
 
@@ -68,8 +69,19 @@ func (b *backups) Restore(backupId string, dbInfo *DBInfo, args RestoreArgs) (na
 	}
 
 	defer backupReader.Close()
+	if meta.ModelUUID != "" {
+		return nil, errors.Errorf("backup %q is a model backup, and cannot replace the controller", backupId)
+	}
+
+	var archive io.Reader = backupReader
+	if meta.Encryption != "" {
+		archive, err = DecryptArchive(backupReader, meta.Encryption, args.Decryption)
+		if err != nil {
+			return nil, errors.Annotatef(err, "could not decrypt backup %q", backupId)
+		}
+	}
 
-	workspace, err := NewArchiveWorkspaceReader(backupReader)
+	workspace, err := NewArchiveWorkspaceReader(archive)
 	if err != nil {
 		return nil, errors.Annotate(err, "cannot unpack backup file")
 	}
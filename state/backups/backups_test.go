@@ -5,11 +5,13 @@ package backups_test
 
 import (
 	"bytes"
+	"io"
 	"io/ioutil"
 	"time"
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/filestorage"
 	"github.com/juju/utils/set"
 	gc "gopkg.in/check.v1"
 
@@ -56,7 +58,7 @@ func (s *backupsSuite) checkFailure(c *gc.C, expected string) {
 	dbInfo := backups.DBInfo{"a", "b", "c", targets}
 	meta := backupstesting.NewMetadataStarted()
 	meta.Notes = "some notes"
-	err := s.api.Create(meta, &paths, &dbInfo)
+	err := s.api.Create(meta, &paths, &dbInfo, backups.EncryptArgs{})
 
 	c.Check(err, gc.ErrorMatches, expected)
 }
@@ -96,7 +98,7 @@ func (s *backupsSuite) TestCreateOkay(c *gc.C) {
 	meta := backupstesting.NewMetadataStarted()
 	backupstesting.SetOrigin(meta, "<model ID>", "<machine ID>", "<hostname>")
 	meta.Notes = "some notes"
-	err := s.api.Create(meta, &paths, &dbInfo)
+	err := s.api.Create(meta, &paths, &dbInfo, backups.EncryptArgs{})
 
 	// Test the call values.
 	s.Storage.CheckCalled(c, "spam", meta, archiveFile, "Add", "Metadata")
@@ -132,6 +134,53 @@ func (s *backupsSuite) TestCreateOkay(c *gc.C) {
 	c.Check(string(data), gc.Equals, "<compressed tarball>")
 }
 
+func (s *backupsSuite) TestCreateEncrypted(c *gc.C) {
+	archiveFile := ioutil.NopCloser(bytes.NewBufferString("<compressed tarball>"))
+	result := backups.NewTestCreateResult(archiveFile, 20, "<checksum>")
+	_, testCreate := backups.NewTestCreate(result)
+	s.PatchValue(backups.RunCreate, testCreate)
+	s.PatchValue(backups.TestGetFilesToBackUp, func(root string, paths *backups.Paths, oldmachine string) ([]string, error) {
+		return []string{"<some file>"}, nil
+	})
+	s.PatchValue(backups.GetDBDumper, func(info *backups.DBInfo) (backups.DBDumper, error) {
+		return nil, nil
+	})
+	var stored []byte
+	s.PatchValue(backups.StoreArchiveRef, func(stor filestorage.FileStorage, meta *backups.Metadata, archive io.Reader) error {
+		var err error
+		stored, err = ioutil.ReadAll(archive)
+		return err
+	})
+
+	paths := backups.Paths{DataDir: "/var/lib/juju"}
+	dbInfo := backups.DBInfo{"a", "b", "c", set.NewStrings("juju", "admin")}
+	meta := backupstesting.NewMetadataStarted()
+	err := s.api.Create(meta, &paths, &dbInfo, backups.EncryptArgs{Passphrase: "secret"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(meta.Encryption, gc.Equals, backups.PassphraseEncryption)
+	c.Check(meta.Size(), gc.Equals, int64(len(stored)))
+	c.Check(meta.Checksum(), gc.Not(gc.Equals), "<checksum>")
+	c.Check(string(stored), gc.Not(gc.Equals), "<compressed tarball>")
+
+	decrypted, err := backups.DecryptArchive(bytes.NewReader(stored), meta.Encryption, backups.DecryptArgs{Passphrase: "secret"})
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := ioutil.ReadAll(decrypted)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, "<compressed tarball>")
+}
+
+func (s *backupsSuite) TestCreateInvalidEncryption(c *gc.C) {
+	paths := backups.Paths{DataDir: "/var/lib/juju"}
+	dbInfo := backups.DBInfo{"a", "b", "c", set.NewStrings("juju", "admin")}
+	meta := backupstesting.NewMetadataStarted()
+	err := s.api.Create(meta, &paths, &dbInfo, backups.EncryptArgs{
+		Passphrase: "secret",
+		PublicKey:  "<public key>",
+	})
+	c.Check(err, gc.ErrorMatches, "invalid encryption: cannot encrypt with both a passphrase and a public key")
+}
+
 func (s *backupsSuite) TestCreateFailToListFiles(c *gc.C) {
 	s.PatchValue(backups.TestGetFilesToBackUp, func(root string, paths *backups.Paths, oldmachine string) ([]string, error) {
 		return nil, errors.New("failed!")
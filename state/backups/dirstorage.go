@@ -0,0 +1,113 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/filestorage"
+)
+
+// NewDirectoryStorage returns a new FileStorage that stores backup
+// archives (and metadata) as files in the given directory, which is
+// created if necessary. The directory is expected to be on a mounted
+// filesystem that outlives the controller machines.
+func NewDirectoryStorage(dir string) filestorage.FileStorage {
+	return newExternalStorage(&dirStore{dir})
+}
+
+// dirStore is an objectStore that stores objects as files in a
+// directory.
+type dirStore struct {
+	dir string
+}
+
+func (s *dirStore) path(name string) (string, error) {
+	if name == "" || strings.ContainsAny(name, `/\`) || strings.HasPrefix(name, ".") {
+		return "", errors.NotValidf("object name %q", name)
+	}
+	return filepath.Join(s.dir, name), nil
+}
+
+// get implements objectStore.
+func (s *dirStore) get(name string) (io.ReadCloser, error) {
+	path, err := s.path(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, errors.NotFoundf("%q", path)
+	}
+	return f, errors.Trace(err)
+}
+
+// put implements objectStore. The content is first written to a
+// temporary file, so that a partially written object is never seen.
+func (s *dirStore) put(name string, r io.Reader, size int64) (err error) {
+	path, err := s.path(name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := os.MkdirAll(s.dir, 0700); err != nil {
+		return errors.Trace(err)
+	}
+	// Temporary files are hidden, and so never listed.
+	f, err := ioutil.TempFile(s.dir, "."+name+".")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() {
+		if err != nil {
+			os.Remove(f.Name())
+		}
+	}()
+	n, err := io.Copy(f, r)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if n != size {
+		return errors.Errorf("expected %d bytes, got %d", size, n)
+	}
+	return errors.Trace(os.Rename(f.Name(), path))
+}
+
+// remove implements objectStore.
+func (s *dirStore) remove(name string) error {
+	path, err := s.path(name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = os.Remove(path)
+	if os.IsNotExist(err) {
+		return errors.NotFoundf("%q", path)
+	}
+	return errors.Trace(err)
+}
+
+// list implements objectStore.
+func (s *dirStore) list() ([]string, error) {
+	infos, err := ioutil.ReadDir(s.dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var names []string
+	for _, info := range infos {
+		if info.IsDir() || strings.HasPrefix(info.Name(), ".") {
+			continue
+		}
+		names = append(names, info.Name())
+	}
+	return names, nil
+}
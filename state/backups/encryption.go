@@ -0,0 +1,214 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"crypto/sha1"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/hash"
+	"golang.org/x/crypto/openpgp"
+	// OpenPGP requires RIPEMD160 when encrypting to keys that do not
+	// state their preferred hash functions.
+	_ "golang.org/x/crypto/ripemd160"
+)
+
+// Encrypted backup archives are OpenPGP messages, so they may also be
+// decrypted with standard tools such as gpg.
+const (
+	// PassphraseEncryption is the encryption scheme of backup archives
+	// encrypted with a passphrase.
+	PassphraseEncryption = "openpgp-passphrase"
+
+	// PublicKeyEncryption is the encryption scheme of backup archives
+	// encrypted to an OpenPGP public key.
+	PublicKeyEncryption = "openpgp-public-key"
+)
+
+// EncryptArgs holds the key with which a backup archive is encrypted.
+// At most one of the fields may be set; if neither is set, the archive
+// is not encrypted.
+type EncryptArgs struct {
+	// Passphrase is the passphrase with which the archive is encrypted.
+	Passphrase string
+
+	// PublicKey is the ASCII-armored OpenPGP public key to which the
+	// archive is encrypted.
+	PublicKey string
+}
+
+// Scheme returns the encryption scheme used for the args, or "" if the
+// archive is not to be encrypted.
+func (args EncryptArgs) Scheme() string {
+	switch {
+	case args.Passphrase != "":
+		return PassphraseEncryption
+	case args.PublicKey != "":
+		return PublicKeyEncryption
+	}
+	return ""
+}
+
+// Validate returns an error if the args are not valid.
+func (args EncryptArgs) Validate() error {
+	if args.Passphrase != "" && args.PublicKey != "" {
+		return errors.New("cannot encrypt with both a passphrase and a public key")
+	}
+	if args.PublicKey != "" {
+		if _, err := readEncryptionKeys(args.PublicKey); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// DecryptArgs holds the key with which an encrypted backup archive is
+// decrypted.
+type DecryptArgs struct {
+	// Passphrase is the passphrase with which an archive encrypted
+	// with PassphraseEncryption is decrypted, or with which the
+	// private key is unlocked if it is itself encrypted.
+	Passphrase string
+
+	// PrivateKey is the ASCII-armored OpenPGP private key with which
+	// an archive encrypted with PublicKeyEncryption is decrypted.
+	PrivateKey string
+}
+
+func readEncryptionKeys(armored string) (openpgp.EntityList, error) {
+	keyring, err := openpgp.ReadArmoredKeyRing(strings.NewReader(armored))
+	if err != nil {
+		return nil, errors.Annotate(err, "invalid OpenPGP key")
+	}
+	return keyring, nil
+}
+
+// EncryptArchive writes the archive, encrypted as specified by the
+// args, to w.
+func EncryptArchive(w io.Writer, archive io.Reader, args EncryptArgs) error {
+	if err := args.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	hints := &openpgp.FileHints{IsBinary: true}
+	var plaintext io.WriteCloser
+	var err error
+	switch args.Scheme() {
+	case PassphraseEncryption:
+		plaintext, err = openpgp.SymmetricallyEncrypt(w, []byte(args.Passphrase), hints, nil)
+	case PublicKeyEncryption:
+		keyring, _ := readEncryptionKeys(args.PublicKey)
+		plaintext, err = openpgp.Encrypt(w, keyring, nil, hints, nil)
+	default:
+		return errors.New("no passphrase or public key specified")
+	}
+	if err != nil {
+		return errors.Annotate(err, "cannot encrypt backup archive")
+	}
+	if _, err := io.Copy(plaintext, archive); err != nil {
+		plaintext.Close()
+		return errors.Annotate(err, "cannot encrypt backup archive")
+	}
+	return errors.Annotate(plaintext.Close(), "cannot encrypt backup archive")
+}
+
+// DecryptArchive returns a reader of the decrypted content of an
+// archive encrypted with the given scheme. The integrity of the
+// archive is checked once its content has been read in full, so
+// a read error must be treated as a failure to decrypt it.
+func DecryptArchive(archive io.Reader, scheme string, args DecryptArgs) (io.Reader, error) {
+	keyring := openpgp.EntityList{}
+	switch scheme {
+	case PassphraseEncryption:
+		if args.Passphrase == "" {
+			return nil, errors.New("backup archive is encrypted with a passphrase, but no passphrase was specified")
+		}
+	case PublicKeyEncryption:
+		if args.PrivateKey == "" {
+			return nil, errors.New("backup archive is encrypted to a public key, but no private key was specified")
+		}
+		var err error
+		if keyring, err = readEncryptionKeys(args.PrivateKey); err != nil {
+			return nil, errors.Trace(err)
+		}
+	default:
+		return nil, errors.NotSupportedf("backup encryption scheme %q", scheme)
+	}
+
+	// The prompt is called until the archive can be decrypted, so
+	// only supply the passphrase once.
+	prompted := false
+	prompt := func(keys []openpgp.Key, symmetric bool) ([]byte, error) {
+		if prompted {
+			return nil, errors.New("incorrect passphrase or key")
+		}
+		prompted = true
+		if symmetric {
+			return []byte(args.Passphrase), nil
+		}
+		for _, key := range keys {
+			key.PrivateKey.Decrypt([]byte(args.Passphrase))
+		}
+		return nil, nil
+	}
+	md, err := openpgp.ReadMessage(archive, keyring, prompt, nil)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot decrypt backup archive")
+	}
+	return &stickyReader{r: md.UnverifiedBody}, nil
+}
+
+// stickyReader returns the first error it reads, including io.EOF, from
+// every later read. The integrity of an OpenPGP message is checked each
+// time the end of its content is read, and that check fails if repeated.
+type stickyReader struct {
+	r   io.Reader
+	err error
+}
+
+func (r *stickyReader) Read(p []byte) (int, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+	n, err := r.r.Read(p)
+	r.err = err
+	return n, err
+}
+
+// encryptResult returns a new result holding the encrypted archive of
+// the given result.
+func encryptResult(result *createResult, args EncryptArgs) (_ *createResult, err error) {
+	file, err := ioutil.TempFile("", tempPrefix)
+	if err != nil {
+		return nil, errors.Annotate(err, "while creating encrypted archive file")
+	}
+	// As with the unencrypted archive, the file is removed while it
+	// is still open, so that it is deleted once the caller closes it.
+	defer os.Remove(file.Name())
+	defer func() {
+		if err != nil {
+			file.Close()
+		}
+	}()
+
+	hasher := hash.NewHashingWriter(file, sha1.New())
+	if err := EncryptArchive(hasher, result.archiveFile, args); err != nil {
+		return nil, errors.Trace(err)
+	}
+	size, err := file.Seek(0, os.SEEK_CUR)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if _, err := file.Seek(0, os.SEEK_SET); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &createResult{
+		archiveFile: file,
+		size:        size,
+		checksum:    hasher.Base64Sum(),
+	}, nil
+}
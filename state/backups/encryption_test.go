@@ -0,0 +1,150 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/openpgp/armor"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state/backups"
+	"github.com/juju/juju/testing"
+)
+
+const plainArchiveData = "<compressed tarball>"
+
+type encryptionSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&encryptionSuite{})
+
+// newKeyPair returns a new ASCII-armored OpenPGP public and private
+// key pair.
+func newKeyPair(c *gc.C) (string, string) {
+	entity, err := openpgp.NewEntity("backups", "", "backups@example.com", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The private key must be serialized first, to sign the identities.
+	var private, public bytes.Buffer
+	w, err := armor.Encode(&private, openpgp.PrivateKeyType, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entity.SerializePrivate(w, nil), jc.ErrorIsNil)
+	c.Assert(w.Close(), jc.ErrorIsNil)
+
+	w, err = armor.Encode(&public, openpgp.PublicKeyType, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entity.Serialize(w), jc.ErrorIsNil)
+	c.Assert(w.Close(), jc.ErrorIsNil)
+	return public.String(), private.String()
+}
+
+func (s *encryptionSuite) encrypt(c *gc.C, args backups.EncryptArgs) []byte {
+	var buf bytes.Buffer
+	err := backups.EncryptArchive(&buf, bytes.NewBufferString(plainArchiveData), args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(buf.String(), gc.Not(gc.Equals), plainArchiveData)
+	return buf.Bytes()
+}
+
+func (s *encryptionSuite) decrypt(encrypted []byte, scheme string, args backups.DecryptArgs) (string, error) {
+	r, err := backups.DecryptArchive(bytes.NewReader(encrypted), scheme, args)
+	if err != nil {
+		return "", err
+	}
+	data, err := ioutil.ReadAll(r)
+	return string(data), err
+}
+
+func (s *encryptionSuite) TestScheme(c *gc.C) {
+	c.Check(backups.EncryptArgs{}.Scheme(), gc.Equals, "")
+	c.Check(backups.EncryptArgs{Passphrase: "secret"}.Scheme(), gc.Equals, backups.PassphraseEncryption)
+	c.Check(backups.EncryptArgs{PublicKey: "<key>"}.Scheme(), gc.Equals, backups.PublicKeyEncryption)
+}
+
+func (s *encryptionSuite) TestValidate(c *gc.C) {
+	public, _ := newKeyPair(c)
+	c.Check(backups.EncryptArgs{}.Validate(), jc.ErrorIsNil)
+	c.Check(backups.EncryptArgs{Passphrase: "secret"}.Validate(), jc.ErrorIsNil)
+	c.Check(backups.EncryptArgs{PublicKey: public}.Validate(), jc.ErrorIsNil)
+
+	err := backups.EncryptArgs{Passphrase: "secret", PublicKey: public}.Validate()
+	c.Check(err, gc.ErrorMatches, "cannot encrypt with both a passphrase and a public key")
+	err = backups.EncryptArgs{PublicKey: "<key>"}.Validate()
+	c.Check(err, gc.ErrorMatches, "invalid OpenPGP key: .*")
+}
+
+func (s *encryptionSuite) TestPassphrase(c *gc.C) {
+	encrypted := s.encrypt(c, backups.EncryptArgs{Passphrase: "secret"})
+	data, err := s.decrypt(encrypted, backups.PassphraseEncryption, backups.DecryptArgs{Passphrase: "secret"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(data, gc.Equals, plainArchiveData)
+}
+
+func (s *encryptionSuite) TestReadAfterEnd(c *gc.C) {
+	encrypted := s.encrypt(c, backups.EncryptArgs{Passphrase: "secret"})
+	r, err := backups.DecryptArchive(bytes.NewReader(encrypted), backups.PassphraseEncryption, backups.DecryptArgs{Passphrase: "secret"})
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := ioutil.ReadAll(r)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, plainArchiveData)
+
+	// Reading again must not repeat the integrity check.
+	n, err := r.Read(make([]byte, 1))
+	c.Check(n, gc.Equals, 0)
+	c.Check(err, gc.Equals, io.EOF)
+}
+
+func (s *encryptionSuite) TestPassphraseIncorrect(c *gc.C) {
+	encrypted := s.encrypt(c, backups.EncryptArgs{Passphrase: "secret"})
+	_, err := s.decrypt(encrypted, backups.PassphraseEncryption, backups.DecryptArgs{Passphrase: "guess"})
+	c.Check(err, gc.ErrorMatches, "cannot decrypt backup archive: incorrect passphrase or key")
+}
+
+func (s *encryptionSuite) TestPassphraseMissing(c *gc.C) {
+	encrypted := s.encrypt(c, backups.EncryptArgs{Passphrase: "secret"})
+	_, err := s.decrypt(encrypted, backups.PassphraseEncryption, backups.DecryptArgs{})
+	c.Check(err, gc.ErrorMatches, "backup archive is encrypted with a passphrase, but no passphrase was specified")
+}
+
+func (s *encryptionSuite) TestPublicKey(c *gc.C) {
+	public, private := newKeyPair(c)
+	encrypted := s.encrypt(c, backups.EncryptArgs{PublicKey: public})
+	data, err := s.decrypt(encrypted, backups.PublicKeyEncryption, backups.DecryptArgs{PrivateKey: private})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(data, gc.Equals, plainArchiveData)
+}
+
+func (s *encryptionSuite) TestPublicKeyIncorrect(c *gc.C) {
+	public, _ := newKeyPair(c)
+	_, private := newKeyPair(c)
+	encrypted := s.encrypt(c, backups.EncryptArgs{PublicKey: public})
+	_, err := s.decrypt(encrypted, backups.PublicKeyEncryption, backups.DecryptArgs{PrivateKey: private})
+	c.Check(err, gc.ErrorMatches, "cannot decrypt backup archive: .*")
+}
+
+func (s *encryptionSuite) TestPrivateKeyMissing(c *gc.C) {
+	public, _ := newKeyPair(c)
+	encrypted := s.encrypt(c, backups.EncryptArgs{PublicKey: public})
+	_, err := s.decrypt(encrypted, backups.PublicKeyEncryption, backups.DecryptArgs{Passphrase: "secret"})
+	c.Check(err, gc.ErrorMatches, "backup archive is encrypted to a public key, but no private key was specified")
+}
+
+func (s *encryptionSuite) TestUnknownScheme(c *gc.C) {
+	_, err := s.decrypt(nil, "rot13", backups.DecryptArgs{Passphrase: "secret"})
+	c.Check(err, gc.ErrorMatches, `backup encryption scheme "rot13" not supported`)
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *encryptionSuite) TestEncryptWithoutKey(c *gc.C) {
+	var buf bytes.Buffer
+	err := backups.EncryptArchive(&buf, bytes.NewBufferString(plainArchiveData), backups.EncryptArgs{})
+	c.Check(err, gc.ErrorMatches, "no passphrase or public key specified")
+}
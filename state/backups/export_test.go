@@ -30,10 +30,15 @@ var (
 	RunCommand            = &runCommandFn
 	ReplaceableFolders    = &replaceableFolders
 	MongoInstalledVersion = &mongoInstalledVersion
+
+	NewMultiStorage = newMultiStorage
 )
 
 var _ filestorage.DocStorage = (*backupsDocStorage)(nil)
 var _ filestorage.RawFileStorage = (*backupBlobStorage)(nil)
+var _ filestorage.DocStorage = (*externalDocStorage)(nil)
+var _ filestorage.MetadataStorage = (*externalMetadataStorage)(nil)
+var _ filestorage.RawFileStorage = (*externalFileStorage)(nil)
 
 func getBackupDBWrapper(st *state.State) *storageDBWrapper {
 	modelUUID := st.ModelTag().Id()
@@ -0,0 +1,362 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/filestorage"
+
+	"github.com/juju/juju/controller"
+)
+
+const (
+	// externalMetadataSuffix is appended to a backup's ID to name the
+	// object holding its metadata in external storage.
+	externalMetadataSuffix = ".json"
+
+	// externalArchiveSuffix is appended to a backup's ID to name the
+	// object holding its archive in external storage.
+	externalArchiveSuffix = ".tar.gz"
+)
+
+// objectStore is a flat store of named objects, in which backups are
+// stored off the controller.
+type objectStore interface {
+	// get returns the content of the named object. If there is no such
+	// object, an error satisfying errors.IsNotFound is returned.
+	get(name string) (io.ReadCloser, error)
+
+	// put stores the content as the named object, replacing any
+	// existing object of the same name.
+	put(name string, r io.Reader, size int64) error
+
+	// remove removes the named object. If there is no such object,
+	// an error satisfying errors.IsNotFound is returned.
+	remove(name string) error
+
+	// list returns the names of all the stored objects.
+	list() ([]string, error)
+}
+
+// newExternalStorage returns a FileStorage that stores backup archives
+// and their metadata as objects in the given store.
+func newExternalStorage(store objectStore) filestorage.FileStorage {
+	docs := &externalMetadataStorage{
+		MetadataDocStorage: filestorage.MetadataDocStorage{&externalDocStorage{store}},
+		store:              store,
+	}
+	files := &externalFileStorage{store}
+	return filestorage.NewFileStorage(docs, files)
+}
+
+//---------------------------
+// metadata storage
+
+type externalDocStorage struct {
+	store objectStore
+}
+
+type externalMetadataStorage struct {
+	filestorage.MetadataDocStorage
+	store objectStore
+}
+
+// AddDoc adds the document to storage and returns the new ID.
+func (s *externalDocStorage) AddDoc(doc filestorage.Document) (string, error) {
+	metadata, ok := doc.(*Metadata)
+	if !ok {
+		return "", errors.Errorf("doc must be of type *backups.Metadata")
+	}
+	// As with the controller's storage, any ID already set on the
+	// metadata is ignored in favour of a new one.
+	metaDoc := newStorageMetaDoc(metadata)
+	id := newStorageID(&metaDoc)
+	metaDoc.ID = id
+	if err := metaDoc.validate(); err != nil {
+		return "", errors.Trace(err)
+	}
+
+	_, err := s.Doc(id)
+	if err == nil {
+		return "", errors.AlreadyExistsf("backup metadata %q", id)
+	} else if !errors.IsNotFound(err) {
+		return "", errors.Trace(err)
+	}
+
+	fileMeta := *metadata.FileMetadata
+	fileMeta.Doc = filestorage.Doc{}
+	fileMeta.SetID(id)
+	fileMeta.SetStored(nil)
+	stored := *metadata
+	stored.FileMetadata = &fileMeta
+	if err := putExternalMetadata(s.store, &stored); err != nil {
+		return "", errors.Trace(err)
+	}
+	return id, nil
+}
+
+// Doc returns the stored document associated with the given ID.
+func (s *externalDocStorage) Doc(id string) (filestorage.Document, error) {
+	r, err := s.store.get(id + externalMetadataSuffix)
+	if errors.IsNotFound(err) {
+		return nil, errors.NotFoundf("backup metadata %q", id)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer r.Close()
+
+	metadata, err := NewMetadataJSONReader(r)
+	if err != nil {
+		return nil, errors.Annotatef(err, "reading backup metadata %q", id)
+	}
+	return metadata, nil
+}
+
+// ListDocs returns the list of all stored documents.
+func (s *externalDocStorage) ListDocs() ([]filestorage.Document, error) {
+	names, err := s.store.list()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var list []filestorage.Document
+	for _, name := range names {
+		if !strings.HasSuffix(name, externalMetadataSuffix) {
+			continue
+		}
+		doc, err := s.Doc(strings.TrimSuffix(name, externalMetadataSuffix))
+		if errors.IsNotFound(err) {
+			// The backup was removed after we listed it.
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		list = append(list, doc)
+	}
+	return list, nil
+}
+
+// RemoveDoc removes the identified document from storage.
+func (s *externalDocStorage) RemoveDoc(id string) error {
+	err := s.store.remove(id + externalMetadataSuffix)
+	if errors.IsNotFound(err) {
+		return errors.NotFoundf("backup metadata %q", id)
+	}
+	return errors.Trace(err)
+}
+
+// Close implements io.Closer.
+func (s *externalDocStorage) Close() error {
+	return nil
+}
+
+// SetStored records in the metadata the fact that the file was stored.
+func (s *externalMetadataStorage) SetStored(id string) error {
+	doc, err := s.Doc(id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	metadata := doc.(*Metadata)
+	stored := time.Now().UTC()
+	metadata.SetStored(&stored)
+	return errors.Trace(putExternalMetadata(s.store, metadata))
+}
+
+func putExternalMetadata(store objectStore, metadata *Metadata) error {
+	buf, err := metadata.AsJSONBuffer()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	data, err := ioutil.ReadAll(buf)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	name := metadata.ID() + externalMetadataSuffix
+	err = store.put(name, bytes.NewReader(data), int64(len(data)))
+	return errors.Annotatef(err, "storing backup metadata %q", metadata.ID())
+}
+
+//---------------------------
+// raw file storage
+
+type externalFileStorage struct {
+	store objectStore
+}
+
+// File returns the identified file from storage.
+func (s *externalFileStorage) File(id string) (io.ReadCloser, error) {
+	r, err := s.store.get(id + externalArchiveSuffix)
+	if errors.IsNotFound(err) {
+		return nil, errors.NotFoundf("backup archive %q", id)
+	}
+	return r, errors.Trace(err)
+}
+
+// AddFile adds the file to storage.
+func (s *externalFileStorage) AddFile(id string, file io.Reader, size int64) error {
+	r, err := s.store.get(id + externalArchiveSuffix)
+	if err == nil {
+		r.Close()
+		return errors.AlreadyExistsf("backup archive %q", id)
+	} else if !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	err = s.store.put(id+externalArchiveSuffix, file, size)
+	return errors.Annotatef(err, "storing backup archive %q", id)
+}
+
+// RemoveFile removes the identified file from storage.
+func (s *externalFileStorage) RemoveFile(id string) error {
+	err := s.store.remove(id + externalArchiveSuffix)
+	if errors.IsNotFound(err) {
+		return errors.NotFoundf("backup archive %q", id)
+	}
+	return errors.Trace(err)
+}
+
+// Close implements io.Closer.
+func (s *externalFileStorage) Close() error {
+	return nil
+}
+
+//---------------------------
+// multiple storage
+
+// multiStorage is a FileStorage that spans several storages. New
+// backups are added to the first; existing backups are found in
+// whichever storage holds them.
+type multiStorage struct {
+	storages []filestorage.FileStorage
+}
+
+func newMultiStorage(storages ...filestorage.FileStorage) filestorage.FileStorage {
+	return &multiStorage{storages}
+}
+
+// find returns the storage holding the identified backup.
+func (s *multiStorage) find(id string) (filestorage.FileStorage, error) {
+	for _, stor := range s.storages {
+		_, err := stor.Metadata(id)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return stor, nil
+	}
+	return nil, errors.NotFoundf("backup %q", id)
+}
+
+// Metadata implements filestorage.FileStorage.
+func (s *multiStorage) Metadata(id string) (filestorage.Metadata, error) {
+	stor, err := s.find(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	meta, err := stor.Metadata(id)
+	return meta, errors.Trace(err)
+}
+
+// Get implements filestorage.FileStorage.
+func (s *multiStorage) Get(id string) (filestorage.Metadata, io.ReadCloser, error) {
+	stor, err := s.find(id)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	meta, file, err := stor.Get(id)
+	return meta, file, errors.Trace(err)
+}
+
+// List implements filestorage.FileStorage. Backups held in more than
+// one storage are listed once.
+func (s *multiStorage) List() ([]filestorage.Metadata, error) {
+	var list []filestorage.Metadata
+	seen := make(map[string]bool)
+	for _, stor := range s.storages {
+		metaList, err := stor.List()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, meta := range metaList {
+			if seen[meta.ID()] {
+				continue
+			}
+			seen[meta.ID()] = true
+			list = append(list, meta)
+		}
+	}
+	return list, nil
+}
+
+// Add implements filestorage.FileStorage.
+func (s *multiStorage) Add(meta filestorage.Metadata, archive io.Reader) (string, error) {
+	id, err := s.storages[0].Add(meta, archive)
+	return id, errors.Trace(err)
+}
+
+// SetFile implements filestorage.FileStorage.
+func (s *multiStorage) SetFile(id string, file io.Reader) error {
+	stor, err := s.find(id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(stor.SetFile(id, file))
+}
+
+// Remove implements filestorage.FileStorage.
+func (s *multiStorage) Remove(id string) error {
+	stor, err := s.find(id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(stor.Remove(id))
+}
+
+// Close implements io.Closer.
+func (s *multiStorage) Close() error {
+	var firstErr error
+	for _, stor := range s.storages {
+		if err := stor.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return errors.Trace(firstErr)
+}
+
+// NewBackupStorage returns a new FileStorage to use for storing backup
+// archives (and metadata), as determined by the controller config.
+// Backups are always found in the controller's own storage; if another
+// kind of storage is configured then new backups are stored there, and
+// the backups stored there are found too.
+func NewBackupStorage(st DB) (filestorage.FileStorage, error) {
+	cfg, err := st.ControllerConfig()
+	if err != nil {
+		return nil, errors.Annotate(err, "getting controller config")
+	}
+	var external filestorage.FileStorage
+	switch storage := cfg.BackupStorage(); storage {
+	case controller.ControllerBackupStorage:
+		return NewStorage(st), nil
+	case controller.DirectoryBackupStorage:
+		external = NewDirectoryStorage(cfg.BackupStorageDirectory())
+	case controller.S3BackupStorage:
+		s3cfg := cfg.BackupStorageS3()
+		s3cfg.SecretKey, err = st.BackupStorageS3SecretKey()
+		if err != nil {
+			return nil, errors.Annotate(err, "getting S3 backup storage credentials")
+		}
+		external, err = NewS3Storage(s3cfg)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	default:
+		return nil, errors.NotValidf("backup storage %q", storage)
+	}
+	return newMultiStorage(external, NewStorage(st)), nil
+}
@@ -0,0 +1,214 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/filestorage"
+	"gopkg.in/amz.v3/s3/s3test"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state/backups"
+	"github.com/juju/juju/testing"
+)
+
+const externalArchiveData = "<compressed archive data>"
+
+type externalStorageSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&externalStorageSuite{})
+
+func (s *externalStorageSuite) metadata(c *gc.C, started time.Time) *backups.Metadata {
+	meta := backups.NewMetadata()
+	meta.Started = started
+	meta.Origin.Model = testing.ModelTag.Id()
+	meta.Origin.Machine = "0"
+	meta.Origin.Hostname = "localhost"
+	meta.Notes = "some notes"
+	err := meta.MarkComplete(int64(len(externalArchiveData)), "some hash")
+	c.Assert(err, jc.ErrorIsNil)
+	return meta
+}
+
+func (s *externalStorageSuite) add(c *gc.C, stor filestorage.FileStorage, started time.Time) string {
+	meta := s.metadata(c, started)
+	id, err := stor.Add(meta, bytes.NewBufferString(externalArchiveData))
+	c.Assert(err, jc.ErrorIsNil)
+	return id
+}
+
+// checkStorage exercises the whole life cycle of a backup in the
+// given storage.
+func (s *externalStorageSuite) checkStorage(c *gc.C, stor filestorage.FileStorage) {
+	list, err := stor.List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(list, gc.HasLen, 0)
+
+	started := time.Date(2016, 6, 1, 12, 30, 0, 0, time.UTC)
+	original := s.metadata(c, started)
+	original.SetID("ignored")
+	id, err := stor.Add(original, bytes.NewBufferString(externalArchiveData))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(id, gc.Equals, "20160601-123000."+testing.ModelTag.Id())
+
+	_, err = stor.Add(s.metadata(c, started), bytes.NewBufferString(externalArchiveData))
+	c.Check(err, jc.Satisfies, errors.IsAlreadyExists)
+
+	meta, archive, err := stor.Get(id)
+	c.Assert(err, jc.ErrorIsNil)
+	defer archive.Close()
+	data, err := ioutil.ReadAll(archive)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, externalArchiveData)
+
+	stored := meta.(*backups.Metadata)
+	c.Check(stored.ID(), gc.Equals, id)
+	c.Check(stored.Stored(), gc.NotNil)
+	c.Check(stored.Started.Equal(started), jc.IsTrue)
+	c.Check(stored.Notes, gc.Equals, "some notes")
+	c.Check(stored.Checksum(), gc.Equals, "some hash")
+	c.Check(stored.Size(), gc.Equals, int64(len(externalArchiveData)))
+	c.Check(stored.Origin.Model, gc.Equals, testing.ModelTag.Id())
+
+	list, err = stor.List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(list, gc.HasLen, 1)
+	c.Check(list[0].ID(), gc.Equals, id)
+
+	err = stor.Remove(id)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = stor.Metadata(id)
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+	err = stor.Remove(id)
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+	list, err = stor.List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(list, gc.HasLen, 0)
+}
+
+func (s *externalStorageSuite) TestDirectoryStorage(c *gc.C) {
+	dir := filepath.Join(c.MkDir(), "backups")
+	stor := backups.NewDirectoryStorage(dir)
+	defer stor.Close()
+	s.checkStorage(c, stor)
+}
+
+func (s *externalStorageSuite) TestDirectoryStorageFiles(c *gc.C) {
+	dir := c.MkDir()
+	stor := backups.NewDirectoryStorage(dir)
+	defer stor.Close()
+
+	id := s.add(c, stor, time.Date(2016, 6, 1, 12, 30, 0, 0, time.UTC))
+
+	data, err := ioutil.ReadFile(filepath.Join(dir, id+".tar.gz"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, externalArchiveData)
+
+	_, err = os.Stat(filepath.Join(dir, id+".json"))
+	c.Check(err, jc.ErrorIsNil)
+
+	infos, err := ioutil.ReadDir(dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(infos, gc.HasLen, 2)
+}
+
+func (s *externalStorageSuite) TestDirectoryStorageIgnoresOtherFiles(c *gc.C) {
+	dir := c.MkDir()
+	err := ioutil.WriteFile(filepath.Join(dir, "README"), []byte("hello"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	stor := backups.NewDirectoryStorage(dir)
+	defer stor.Close()
+	list, err := stor.List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(list, gc.HasLen, 0)
+}
+
+func (s *externalStorageSuite) TestS3Storage(c *gc.C) {
+	srv, err := s3test.NewServer(&s3test.Config{})
+	c.Assert(err, jc.ErrorIsNil)
+	defer srv.Quit()
+
+	stor, err := backups.NewS3Storage(controller.BackupS3Config{
+		Endpoint:  srv.URL(),
+		Region:    "eu-west-1",
+		Bucket:    "juju-backups",
+		AccessKey: "access",
+		SecretKey: "secret",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer stor.Close()
+	s.checkStorage(c, stor)
+}
+
+func (s *externalStorageSuite) TestS3StorageBadBucket(c *gc.C) {
+	_, err := backups.NewS3Storage(controller.BackupS3Config{
+		Endpoint: "http://localhost",
+		Bucket:   "juju/backups",
+	})
+	c.Assert(err, gc.ErrorMatches, `bad S3 bucket: "juju/backups"`)
+}
+
+func (s *externalStorageSuite) TestMultiStorage(c *gc.C) {
+	primary := backups.NewDirectoryStorage(c.MkDir())
+	secondary := backups.NewDirectoryStorage(c.MkDir())
+	stor := backups.NewMultiStorage(primary, secondary)
+	defer stor.Close()
+
+	oldID := s.add(c, secondary, time.Date(2016, 6, 1, 0, 0, 0, 0, time.UTC))
+	newID := s.add(c, stor, time.Date(2016, 6, 2, 0, 0, 0, 0, time.UTC))
+
+	// New backups are added to the primary storage.
+	_, err := primary.Metadata(newID)
+	c.Check(err, jc.ErrorIsNil)
+	_, err = secondary.Metadata(newID)
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+
+	list, err := stor.List()
+	c.Assert(err, jc.ErrorIsNil)
+	var ids []string
+	for _, meta := range list {
+		ids = append(ids, meta.ID())
+	}
+	c.Check(ids, jc.SameContents, []string{oldID, newID})
+
+	_, archive, err := stor.Get(oldID)
+	c.Assert(err, jc.ErrorIsNil)
+	archive.Close()
+
+	err = stor.Remove(oldID)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = secondary.Metadata(oldID)
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+
+	_, err = stor.Metadata(oldID)
+	c.Check(err, gc.ErrorMatches, `backup ".*" not found`)
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *externalStorageSuite) TestMultiStorageListsDuplicatesOnce(c *gc.C) {
+	primary := backups.NewDirectoryStorage(c.MkDir())
+	secondary := backups.NewDirectoryStorage(c.MkDir())
+	stor := backups.NewMultiStorage(primary, secondary)
+	defer stor.Close()
+
+	started := time.Date(2016, 6, 1, 0, 0, 0, 0, time.UTC)
+	id := s.add(c, primary, started)
+	s.add(c, secondary, started)
+
+	list, err := stor.List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(list, gc.HasLen, 1)
+	c.Check(list[0].ID(), gc.Equals, id)
+}
@@ -67,6 +67,18 @@ type Metadata struct {
 	// Notes is an optional user-supplied annotation.
 	Notes string
 
+	// Schedule is the schedule on which the backup was created,
+	// or "" if the backup was created on demand.
+	Schedule string
+
+	// Encryption is the scheme with which the backup archive is
+	// encrypted, or "" if it is not encrypted.
+	Encryption string
+
+	// ModelUUID is the UUID of the model captured by a model backup,
+	// or "" if the backup captures the whole controller.
+	ModelUUID string
+
 	// TODO(wallyworld) - remove these ASAP
 	// These are only used by the restore CLI when re-bootstrapping.
 	// We will use a better solution but the way restore currently
@@ -161,6 +173,9 @@ type flatMetadata struct {
 	Started     time.Time
 	Finished    time.Time
 	Notes       string
+	Schedule    string
+	Encryption  string
+	ModelUUID   string
 	Environment string
 	Machine     string
 	Hostname    string
@@ -184,6 +199,9 @@ func (m *Metadata) AsJSONBuffer() (io.Reader, error) {
 
 		Started:      m.Started,
 		Notes:        m.Notes,
+		Schedule:     m.Schedule,
+		Encryption:   m.Encryption,
+		ModelUUID:    m.ModelUUID,
 		Environment:  m.Origin.Model,
 		Machine:      m.Origin.Machine,
 		Hostname:     m.Origin.Hostname,
@@ -233,6 +251,9 @@ func NewMetadataJSONReader(in io.Reader) (*Metadata, error) {
 		meta.Finished = &flat.Finished
 	}
 	meta.Notes = flat.Notes
+	meta.Schedule = flat.Schedule
+	meta.Encryption = flat.Encryption
+	meta.ModelUUID = flat.ModelUUID
 	meta.Origin = Origin{
 		Model:    flat.Environment,
 		Machine:  flat.Machine,
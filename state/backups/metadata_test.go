@@ -56,6 +56,9 @@ func (s *metadataSuite) TestAsJSONBuffer(c *gc.C) {
 		`"Started":"2014-09-09T11:59:34Z",`+
 		`"Finished":"2014-09-09T12:00:34Z",`+
 		`"Notes":"",`+
+		`"Schedule":"",`+
+		`"Encryption":"",`+
+		`"ModelUUID":"",`+
 		`"Environment":"asdf-zxcv-qwe",`+
 		`"Machine":"0",`+
 		`"Hostname":"myhost",`+
@@ -0,0 +1,454 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"github.com/juju/utils/hash"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+
+	"github.com/juju/juju/core/description"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/storage"
+)
+
+// A model backup archive holds the serialized description of the model,
+// the archives of the charms it uses and the content of its
+// applications' resources, next to the metadata file.
+const (
+	modelFile    = "model.yaml"
+	charmsDir    = "charms"
+	resourcesDir = "resources"
+)
+
+// ModelBackend exposes the state functionality needed to back up a
+// single model.
+type ModelBackend interface {
+	// Export generates an abstract representation of the model.
+	Export() (description.Model, error)
+	// Charm returns the charm with the given URL.
+	Charm(*charm.URL) (*state.Charm, error)
+	// ModelUUID returns the UUID of the model.
+	ModelUUID() string
+	// MongoSession returns the session in which charm archives are
+	// stored.
+	MongoSession() *mgo.Session
+	// Resources returns the model's resources, for reading their
+	// content.
+	Resources() (state.Resources, error)
+}
+
+// ModelImporter exposes the state functionality needed to re-create a
+// backed up model in a controller.
+type ModelImporter interface {
+	// Import creates a new model from the description.
+	Import(description.Model) (*state.Model, *state.State, error)
+}
+
+var getModelStorage = func(modelUUID string, session *mgo.Session) storage.Storage {
+	return storage.NewStorage(modelUUID, session)
+}
+
+// CreateModel creates and stores a new backup archive of the single
+// model, encrypted as specified, and updates the provided metadata.
+func (b *backups) CreateModel(meta *Metadata, st ModelBackend, encryption EncryptArgs) error {
+	if err := encryption.Validate(); err != nil {
+		return errors.Annotate(err, "invalid encryption")
+	}
+
+	meta.Started = time.Now().UTC()
+	meta.ModelUUID = st.ModelUUID()
+	// The controller's secrets are not part of a model, and must not
+	// be given to those who may only back up one.
+	meta.CACert = ""
+	meta.CAPrivateKey = ""
+
+	metadataFile, err := meta.AsJSONBuffer()
+	if err != nil {
+		return errors.Annotate(err, "while preparing the metadata")
+	}
+	result, err := createModelArchive(st, metadataFile)
+	if err != nil {
+		return errors.Annotate(err, "while creating model backup archive")
+	}
+	defer result.archiveFile.Close()
+
+	if scheme := encryption.Scheme(); scheme != "" {
+		encrypted, err := encryptResult(result, encryption)
+		if err != nil {
+			return errors.Annotate(err, "while encrypting backup archive")
+		}
+		defer encrypted.archiveFile.Close()
+		result = encrypted
+		meta.Encryption = scheme
+	}
+
+	if err := finishMeta(meta, result); err != nil {
+		return errors.Annotate(err, "while updating metadata")
+	}
+	if err := storeArchive(b.storage, meta, result.archiveFile); err != nil {
+		return errors.Annotate(err, "while storing backup archive")
+	}
+	return nil
+}
+
+// createModelArchive writes the model backup archive to a temporary
+// file, which is removed once the result's file is closed.
+func createModelArchive(st ModelBackend, metadataFile io.Reader) (_ *createResult, err error) {
+	model, err := st.Export()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot export model")
+	}
+	modelBytes, err := description.Serialize(model)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot serialize model")
+	}
+	metadataBytes, err := ioutil.ReadAll(metadataFile)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	file, err := ioutil.TempFile("", tempPrefix)
+	if err != nil {
+		return nil, errors.Annotate(err, "while creating archive file")
+	}
+	defer os.Remove(file.Name())
+	defer func() {
+		if err != nil {
+			file.Close()
+		}
+	}()
+
+	// As with controller backups, the checksum is of the compressed
+	// archive.
+	hasher := hash.NewHashingWriter(file, sha1.New())
+	gzw := gzip.NewWriter(hasher)
+	tw := tar.NewWriter(gzw)
+	paths := NewCanonicalArchivePaths()
+	// Directories are written explicitly, as the archive may be
+	// unpacked by tools that do not create missing parents.
+	for _, dir := range []string{
+		paths.ContentDir,
+		path.Join(paths.ContentDir, charmsDir),
+		path.Join(paths.ContentDir, resourcesDir),
+	} {
+		if err := writeArchiveDir(tw, dir); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	if err := writeArchiveEntry(tw, paths.MetadataFile, bytes.NewReader(metadataBytes), int64(len(metadataBytes))); err != nil {
+		return nil, errors.Trace(err)
+	}
+	modelPath := path.Join(paths.ContentDir, modelFile)
+	if err := writeArchiveEntry(tw, modelPath, bytes.NewReader(modelBytes), int64(len(modelBytes))); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := writeModelCharms(tw, st, model); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := writeModelResources(tw, st, model); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := tw.Close(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := gzw.Close(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	size, err := file.Seek(0, os.SEEK_CUR)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if _, err := file.Seek(0, os.SEEK_SET); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &createResult{
+		archiveFile: file,
+		size:        size,
+		checksum:    hasher.Base64Sum(),
+	}, nil
+}
+
+// writeModelCharms adds the archive of each charm used by the model to
+// the backup archive.
+func writeModelCharms(tw *tar.Writer, st ModelBackend, model description.Model) error {
+	stor := getModelStorage(st.ModelUUID(), st.MongoSession())
+	written := make(map[string]bool)
+	for _, application := range model.Applications() {
+		charmURL := application.CharmURL()
+		if written[charmURL] {
+			continue
+		}
+		written[charmURL] = true
+
+		curl, err := charm.ParseURL(charmURL)
+		if err != nil {
+			return errors.Annotate(err, "bad charm URL")
+		}
+		ch, err := st.Charm(curl)
+		if err != nil {
+			return errors.Annotatef(err, "cannot get charm %q", charmURL)
+		}
+		r, size, err := stor.Get(ch.StoragePath())
+		if err != nil {
+			return errors.Annotatef(err, "cannot get charm %q from storage", charmURL)
+		}
+		err = writeArchiveEntry(tw, charmArchivePath(charmURL), r, size)
+		r.Close()
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// writeModelResources adds the content of each resource of the
+// model's applications to the backup archive.
+func writeModelResources(tw *tar.Writer, st ModelBackend, model description.Model) error {
+	resources, err := st.Resources()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, application := range model.Applications() {
+		for _, res := range application.Resources() {
+			// A resource that has never been uploaded has no
+			// content to back up.
+			if res.ApplicationRevision().Timestamp().IsZero() {
+				continue
+			}
+			if err := writeModelResource(tw, resources, application.Name(), res.Name()); err != nil {
+				return errors.Annotatef(err, "resource %s/%s", application.Name(), res.Name())
+			}
+		}
+	}
+	return nil
+}
+
+func writeModelResource(tw *tar.Writer, resources state.Resources, application, name string) error {
+	res, r, err := resources.OpenResource(application, name)
+	if err != nil {
+		return errors.Annotate(err, "cannot get resource content")
+	}
+	defer r.Close()
+	return errors.Trace(writeArchiveEntry(tw, resourceArchivePath(application, name), r, res.Size))
+}
+
+// resourceArchivePath returns the path of a resource's content in a
+// model backup archive.
+func resourceArchivePath(application, name string) string {
+	return path.Join(contentDir, resourcesDir, resourceFileName(application, name))
+}
+
+func resourceFileName(application, name string) string {
+	return url.QueryEscape(application + "/" + name)
+}
+
+// charmArchivePath returns the path of the charm's archive in a model
+// backup archive. Charm URLs contain slashes, so they are escaped.
+func charmArchivePath(charmURL string) string {
+	return path.Join(contentDir, charmsDir, url.QueryEscape(charmURL))
+}
+
+func writeArchiveDir(tw *tar.Writer, name string) error {
+	header := &tar.Header{
+		Name:     name,
+		Typeflag: tar.TypeDir,
+		Mode:     0700,
+		ModTime:  time.Now(),
+	}
+	return errors.Annotatef(tw.WriteHeader(header), "cannot write %q to archive", name)
+}
+
+func writeArchiveEntry(tw *tar.Writer, name string, r io.Reader, size int64) error {
+	header := &tar.Header{
+		Name:    name,
+		Mode:    0600,
+		Size:    size,
+		ModTime: time.Now(),
+	}
+	if err := tw.WriteHeader(header); err != nil {
+		return errors.Annotatef(err, "cannot write %q to archive", name)
+	}
+	if _, err := io.Copy(tw, r); err != nil {
+		return errors.Annotatef(err, "cannot write %q to archive", name)
+	}
+	return nil
+}
+
+// RestoreModel re-creates the model captured by the model backup in
+// the controller, and returns the tag of the new model. The model must
+// not already exist in the controller.
+func (b *backups) RestoreModel(backupId string, st ModelImporter, decryption DecryptArgs) (_ names.ModelTag, err error) {
+	var none names.ModelTag
+	meta, archive, err := b.Get(backupId)
+	if err != nil {
+		return none, errors.Trace(err)
+	}
+	defer archive.Close()
+	if meta.ModelUUID == "" {
+		return none, errors.Errorf("backup %q is not a model backup", backupId)
+	}
+
+	var content io.Reader = archive
+	if meta.Encryption != "" {
+		content, err = DecryptArchive(archive, meta.Encryption, decryption)
+		if err != nil {
+			return none, errors.Annotatef(err, "could not decrypt backup %q", backupId)
+		}
+	}
+	ws, err := NewArchiveWorkspaceReader(content)
+	if err != nil {
+		return none, errors.Annotate(err, "cannot unpack backup archive")
+	}
+	defer ws.Close()
+
+	modelBytes, err := ioutil.ReadFile(filepath.Join(ws.ContentDir, modelFile))
+	if err != nil {
+		return none, errors.Annotate(err, "cannot read model from backup archive")
+	}
+	backedUp, err := description.Deserialize(modelBytes)
+	if err != nil {
+		return none, errors.Trace(err)
+	}
+	// The model's instances were destroyed along with it, so it is
+	// restored as a clone under its own identity: its machines are
+	// provisioned afresh and its agents set new passwords.
+	modelName, _ := backedUp.Config()["name"].(string)
+	model, err := description.Clone(backedUp, description.CloneArgs{
+		UUID: backedUp.Tag().Id(),
+		Name: modelName,
+	})
+	if err != nil {
+		return none, errors.Trace(err)
+	}
+	dbModel, newSt, err := st.Import(model)
+	if errors.IsAlreadyExists(err) {
+		return none, errors.Annotatef(err, "cannot restore model %q while it exists", model.Tag().Id())
+	} else if err != nil {
+		return none, errors.Annotate(err, "cannot import model")
+	}
+	defer newSt.Close()
+	defer func() {
+		if err != nil {
+			if err := newSt.RemoveImportingModelDocs(); err != nil {
+				logger.Errorf("cannot remove partially restored model %q: %v", newSt.ModelUUID(), err)
+			}
+		}
+	}()
+
+	restored := make(map[string]bool)
+	for _, application := range model.Applications() {
+		charmURL := application.CharmURL()
+		if restored[charmURL] {
+			continue
+		}
+		if err := restoreCharm(newSt, ws, charmURL); err != nil {
+			return none, errors.Trace(err)
+		}
+		restored[charmURL] = true
+	}
+	if err := restoreResources(newSt, ws, model); err != nil {
+		return none, errors.Trace(err)
+	}
+
+	// The model is only imported once its charms and resources are
+	// in place.
+	if err := dbModel.SetMigrationMode(state.MigrationModeNone); err != nil {
+		return none, errors.Trace(err)
+	}
+	return dbModel.ModelTag(), nil
+}
+
+// restoreCharm adds the charm's archive, in the unpacked backup archive,
+// to the restored model.
+func restoreCharm(st *state.State, ws *ArchiveWorkspace, charmURL string) error {
+	curl, err := charm.ParseURL(charmURL)
+	if err != nil {
+		return errors.Annotate(err, "bad charm URL")
+	}
+	archivePath := filepath.Join(ws.ContentDir, charmsDir, url.QueryEscape(charmURL))
+	ch, err := charm.ReadCharmArchive(archivePath)
+	if err != nil {
+		return errors.Annotatef(err, "cannot read charm %q from backup archive", charmURL)
+	}
+	data, err := ioutil.ReadFile(archivePath)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	sum := sha256.Sum256(data)
+
+	uuid, err := utils.NewUUID()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	storagePath := fmt.Sprintf("charms/%s-%s", charmURL, uuid)
+	stor := getModelStorage(st.ModelUUID(), st.MongoSession())
+	if err := stor.Put(storagePath, bytes.NewReader(data), int64(len(data))); err != nil {
+		return errors.Annotatef(err, "cannot store charm %q", charmURL)
+	}
+	_, err = st.AddCharm(state.CharmInfo{
+		Charm:       ch,
+		ID:          curl,
+		StoragePath: storagePath,
+		SHA256:      hex.EncodeToString(sum[:]),
+	})
+	return errors.Annotatef(err, "cannot add charm %q", charmURL)
+}
+
+// restoreResources stores the content of the resources, in the
+// unpacked backup archive, against the resource metadata imported with
+// the restored model.
+func restoreResources(st *state.State, ws *ArchiveWorkspace, model description.Model) error {
+	resources, err := st.Resources()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, application := range model.Applications() {
+		for _, res := range application.Resources() {
+			if res.ApplicationRevision().Timestamp().IsZero() {
+				continue
+			}
+			if err := restoreResource(resources, ws, application.Name(), res.Name()); err != nil {
+				return errors.Annotatef(err, "cannot restore resource %s/%s", application.Name(), res.Name())
+			}
+		}
+	}
+	return nil
+}
+
+func restoreResource(resources state.Resources, ws *ArchiveWorkspace, application, name string) error {
+	f, err := os.Open(filepath.Join(ws.ContentDir, resourcesDir, resourceFileName(application, name)))
+	if err != nil {
+		return errors.Annotate(err, "cannot read resource from backup archive")
+	}
+	defer f.Close()
+	res, err := resources.GetResource(application, name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// As when migrating a model, the imported metadata is kept, apart
+	// from the timestamp which SetResource sets to when the content
+	// is stored.
+	_, err = resources.SetResource(application, res.Username, res.Resource, f)
+	return errors.Trace(err)
+}
@@ -0,0 +1,206 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/component/all"
+	"github.com/juju/juju/resource/resourcetesting"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+	"github.com/juju/juju/state/storage"
+	statetesting "github.com/juju/juju/state/testing"
+	"github.com/juju/juju/testcharms"
+	"github.com/juju/juju/testing/factory"
+)
+
+func init() {
+	if err := all.RegisterForServer(); err != nil {
+		panic(err)
+	}
+}
+
+type modelBackupSuite struct {
+	statetesting.StateSuite
+	hosted *state.State
+	stor   backups.Backups
+}
+
+var _ = gc.Suite(&modelBackupSuite{})
+
+func (s *modelBackupSuite) SetUpTest(c *gc.C) {
+	s.StateSuite.SetUpTest(c)
+	s.hosted = s.Factory.MakeModel(c, nil)
+	s.AddCleanup(func(*gc.C) { s.hosted.Close() })
+
+	// The factory does not store charm archives, so store one where
+	// the factory says it is.
+	f := factory.NewFactory(s.hosted)
+	ch := f.MakeCharm(c, &factory.CharmParams{Name: "dummy", URL: "cs:quantal/dummy-1"})
+	f.MakeApplication(c, &factory.ApplicationParams{Name: "dummy", Charm: ch})
+	f.MakeApplication(c, &factory.ApplicationParams{Name: "another-dummy", Charm: ch})
+	archive := testcharms.Repo.CharmArchive(c.MkDir(), "dummy")
+	data, err := ioutil.ReadFile(archive.Path)
+	c.Assert(err, jc.ErrorIsNil)
+	stor := storage.NewStorage(s.hosted.ModelUUID(), s.hosted.MongoSession())
+	err = stor.Put(ch.StoragePath(), bytes.NewReader(data), int64(len(data)))
+	c.Assert(err, jc.ErrorIsNil)
+
+	resources, err := s.hosted.Resources()
+	c.Assert(err, jc.ErrorIsNil)
+	res := resourcetesting.NewCharmResource(c, "spam", "spamspamspam")
+	_, err = resources.SetResource("dummy", "bob", res, strings.NewReader("spamspamspam"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stor = backups.NewBackups(backups.NewDirectoryStorage(c.MkDir()))
+}
+
+func (s *modelBackupSuite) newMetadata() *backups.Metadata {
+	meta := backups.NewMetadata()
+	meta.Origin.Model = s.State.ModelUUID()
+	meta.Origin.Machine = "0"
+	meta.Origin.Hostname = "localhost"
+	meta.CACert = "ca-cert"
+	meta.CAPrivateKey = "ca-private-key"
+	return meta
+}
+
+// removeHostedModel removes the backed up model from the controller,
+// so that it may be restored.
+func (s *modelBackupSuite) removeHostedModel(c *gc.C) {
+	model, err := s.hosted.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	err = model.SetMigrationMode(state.MigrationModeImporting)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.hosted.RemoveImportingModelDocs()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *modelBackupSuite) TestCreateModel(c *gc.C) {
+	meta := s.newMetadata()
+	err := s.stor.CreateModel(meta, s.hosted, backups.EncryptArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(meta.ModelUUID, gc.Equals, s.hosted.ModelUUID())
+	c.Check(strings.HasSuffix(meta.ID(), "."+s.hosted.ModelUUID()), jc.IsTrue)
+	c.Check(meta.Stored(), gc.NotNil)
+	c.Check(meta.Encryption, gc.Equals, "")
+	c.Check(meta.CACert, gc.Equals, "")
+	c.Check(meta.CAPrivateKey, gc.Equals, "")
+
+	stored, archive, err := s.stor.Get(meta.ID())
+	c.Assert(err, jc.ErrorIsNil)
+	defer archive.Close()
+	c.Check(stored.ModelUUID, gc.Equals, s.hosted.ModelUUID())
+
+	ws, err := backups.NewArchiveWorkspaceReader(archive)
+	c.Assert(err, jc.ErrorIsNil)
+	defer ws.Close()
+	archived, err := ws.Metadata()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(archived.ModelUUID, gc.Equals, s.hosted.ModelUUID())
+
+	content, err := ioutil.ReadFile(filepath.Join(ws.ContentDir, "resources", "dummy%2Fspam"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(content), gc.Equals, "spamspamspam")
+}
+
+func (s *modelBackupSuite) TestVerifyModel(c *gc.C) {
+	meta := s.newMetadata()
+	err := s.stor.CreateModel(meta, s.hosted, backups.EncryptArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.stor.Verify(meta.ID(), backups.DecryptArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.ModelCount, gc.Equals, 1)
+	c.Check(result.Collections, gc.Equals, 0)
+}
+
+func (s *modelBackupSuite) TestRestoreModel(c *gc.C) {
+	meta := s.newMetadata()
+	err := s.stor.CreateModel(meta, s.hosted, backups.EncryptArgs{Passphrase: "secret"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(meta.Encryption, gc.Equals, backups.PassphraseEncryption)
+	s.removeHostedModel(c)
+
+	tag, err := s.stor.RestoreModel(meta.ID(), s.State, backups.DecryptArgs{Passphrase: "secret"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(tag, gc.Equals, s.hosted.ModelTag())
+
+	restored, err := s.State.ForModel(tag)
+	c.Assert(err, jc.ErrorIsNil)
+	defer restored.Close()
+	model, err := restored.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(model.MigrationMode(), gc.Equals, state.MigrationModeNone)
+
+	app, err := restored.Application("dummy")
+	c.Assert(err, jc.ErrorIsNil)
+	curl, _ := app.CharmURL()
+	ch, err := restored.Charm(curl)
+	c.Assert(err, jc.ErrorIsNil)
+	r, _, err := storage.NewStorage(restored.ModelUUID(), restored.MongoSession()).Get(ch.StoragePath())
+	c.Assert(err, jc.ErrorIsNil)
+	r.Close()
+
+	resources, err := restored.Resources()
+	c.Assert(err, jc.ErrorIsNil)
+	res, reader, err := resources.OpenResource("dummy", "spam")
+	c.Assert(err, jc.ErrorIsNil)
+	defer reader.Close()
+	c.Check(res.Username, gc.Equals, "bob")
+	content, err := ioutil.ReadAll(reader)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(content), gc.Equals, "spamspamspam")
+}
+
+func (s *modelBackupSuite) TestRestoreModelUnprovisionsMachines(c *gc.C) {
+	machine := factory.NewFactory(s.hosted).MakeMachine(c, nil)
+	_, err := machine.InstanceId()
+	c.Assert(err, jc.ErrorIsNil)
+	meta := s.newMetadata()
+	err = s.stor.CreateModel(meta, s.hosted, backups.EncryptArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+	s.removeHostedModel(c)
+
+	tag, err := s.stor.RestoreModel(meta.ID(), s.State, backups.DecryptArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+	restored, err := s.State.ForModel(tag)
+	c.Assert(err, jc.ErrorIsNil)
+	defer restored.Close()
+	machine, err = restored.Machine(machine.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = machine.InstanceId()
+	c.Check(err, jc.Satisfies, errors.IsNotProvisioned)
+	addrs := machine.Addresses()
+	c.Check(addrs, gc.HasLen, 0)
+}
+
+func (s *modelBackupSuite) TestRestoreModelExists(c *gc.C) {
+	meta := s.newMetadata()
+	err := s.stor.CreateModel(meta, s.hosted, backups.EncryptArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.stor.RestoreModel(meta.ID(), s.State, backups.DecryptArgs{})
+	c.Check(err, gc.ErrorMatches, `cannot restore model ".*" while it exists: .*`)
+}
+
+func (s *modelBackupSuite) TestRestoreModelNotModelBackup(c *gc.C) {
+	meta := s.newMetadata()
+	err := meta.MarkComplete(10, "<checksum>")
+	c.Assert(err, jc.ErrorIsNil)
+	id, err := s.stor.Add(bytes.NewBufferString("<compressed tarball>"), meta)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.stor.RestoreModel(id, s.State, backups.DecryptArgs{})
+	c.Check(err, gc.ErrorMatches, `backup ".*" is not a model backup`)
+}
@@ -16,4 +16,8 @@ type RestoreArgs struct {
 	NewInstId      instance.Id
 	NewInstTag     names.Tag
 	NewInstSeries  string
+
+	// Decryption holds the key with which the backup archive is
+	// decrypted, if it is encrypted.
+	Decryption DecryptArgs
 }
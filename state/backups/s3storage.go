@@ -0,0 +1,134 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/filestorage"
+	"gopkg.in/amz.v3/aws"
+	"gopkg.in/amz.v3/s3"
+
+	"github.com/juju/juju/controller"
+)
+
+// NewS3Storage returns a new FileStorage that stores backup archives
+// (and metadata) as objects in a bucket of an S3-compatible object
+// store. The bucket is created if necessary.
+func NewS3Storage(cfg controller.BackupS3Config) (filestorage.FileStorage, error) {
+	auth := aws.Auth{
+		AccessKey: cfg.AccessKey,
+		SecretKey: cfg.SecretKey,
+	}
+	region := aws.Region{
+		Name:       cfg.Region,
+		S3Endpoint: strings.TrimSuffix(cfg.Endpoint, "/"),
+		// Buckets in the default region must be created without
+		// a location constraint; all other regions require one.
+		S3LocationConstraint: cfg.Region != controller.DefaultBackupStorageS3Region,
+	}
+	bucket, err := s3.New(auth, region).Bucket(cfg.Bucket)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return newExternalStorage(&s3Store{bucket: bucket}), nil
+}
+
+// s3Store is an objectStore that stores objects in an S3 bucket.
+type s3Store struct {
+	mu         sync.Mutex
+	madeBucket bool
+	bucket     *s3.Bucket
+}
+
+// makeBucket makes the bucket in which backups are stored. To avoid
+// two round trips on every put, we do this only once.
+func (s *s3Store) makeBucket() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.madeBucket {
+		return nil
+	}
+	// PutBucket always returns a 200 if we recreate an existing bucket
+	// for the original s3.amazonaws.com endpoint. For all other
+	// endpoints PutBucket returns 409 with a known subcode.
+	if err := s.bucket.PutBucket(s3.Private); err != nil && s3ErrCode(err) != "BucketAlreadyOwnedByYou" {
+		return errors.Trace(err)
+	}
+	s.madeBucket = true
+	return nil
+}
+
+// get implements objectStore.
+func (s *s3Store) get(name string) (io.ReadCloser, error) {
+	r, err := s.bucket.GetReader(name)
+	if s3ErrorStatusCode(err) == http.StatusNotFound {
+		return nil, errors.NotFoundf("object %q in bucket %q", name, s.bucket.Name)
+	}
+	return r, errors.Trace(err)
+}
+
+// put implements objectStore.
+func (s *s3Store) put(name string, r io.Reader, size int64) error {
+	if err := s.makeBucket(); err != nil {
+		return errors.Annotatef(err, "cannot make bucket %q", s.bucket.Name)
+	}
+	err := s.bucket.PutReader(name, r, size, "binary/octet-stream", s3.Private)
+	return errors.Annotatef(err, "cannot write object %q to bucket %q", name, s.bucket.Name)
+}
+
+// remove implements objectStore.
+func (s *s3Store) remove(name string) error {
+	// S3 does not report whether a deleted object existed, so
+	// check first to honour the objectStore contract.
+	r, err := s.get(name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	r.Close()
+	return errors.Trace(s.bucket.Del(name))
+}
+
+// list implements objectStore.
+func (s *s3Store) list() ([]string, error) {
+	var names []string
+	marker := ""
+	for {
+		resp, err := s.bucket.List("", "", marker, 0)
+		if s3ErrorStatusCode(err) == http.StatusNotFound {
+			// The bucket has not been made yet.
+			return nil, nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, key := range resp.Contents {
+			names = append(names, key.Key)
+		}
+		if !resp.IsTruncated || len(resp.Contents) == 0 {
+			return names, nil
+		}
+		marker = resp.Contents[len(resp.Contents)-1].Key
+	}
+}
+
+// s3ErrorStatusCode returns the HTTP status of the S3 request error,
+// if it is an error from an S3 operation, or 0 if it was not.
+func s3ErrorStatusCode(err error) int {
+	if err, _ := err.(*s3.Error); err != nil {
+		return err.StatusCode
+	}
+	return 0
+}
+
+// s3ErrCode returns the text status code of the S3 error code.
+func s3ErrCode(err error) string {
+	if err, ok := err.(*s3.Error); ok {
+		return err.Code
+	}
+	return ""
+}
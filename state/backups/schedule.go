@@ -0,0 +1,107 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+)
+
+// storageScheduleName is the name of the collection in the backups
+// database that records the outcome of scheduled backups.
+const storageScheduleName = "schedule"
+
+// ScheduleStatus records the outcome of the most recent scheduled
+// backup, and when the next one is due.
+type ScheduleStatus struct {
+	// Schedule is the schedule on which backups are created.
+	Schedule string
+
+	// LastRun records when the most recent scheduled backup was
+	// started, or is zero if no scheduled backup has been run.
+	LastRun time.Time
+
+	// LastBackupID is the ID of the backup created by the most
+	// recent scheduled run, or "" if the run failed.
+	LastBackupID string
+
+	// LastError is the error message from the most recent scheduled
+	// run, or "" if the run succeeded.
+	LastError string
+
+	// NextRun records when the next scheduled backup is due.
+	NextRun time.Time
+}
+
+// scheduleStatusDoc is a mirror of ScheduleStatus, used just for
+// DB storage. There is one document per controller model.
+type scheduleStatusDoc struct {
+	ID           string `bson:"_id"`
+	Schedule     string `bson:"schedule"`
+	LastRun      int64  `bson:"last-run,minsize"`
+	LastBackupID string `bson:"last-backup-id,omitempty"`
+	LastError    string `bson:"last-error,omitempty"`
+	NextRun      int64  `bson:"next-run,minsize"`
+}
+
+func scheduleTimeToUnix(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return metadocTimeToUnix(t)
+}
+
+func scheduleUnixToTime(t int64) time.Time {
+	if t == 0 {
+		return time.Time{}
+	}
+	return metadocUnixToTime(t)
+}
+
+// SetScheduleStatus records the status of scheduled backups.
+func SetScheduleStatus(st DB, status ScheduleStatus) error {
+	session := st.MongoSession().Copy()
+	defer session.Close()
+
+	modelUUID := st.ModelTag().Id()
+	coll := session.DB(storageDBName).C(storageScheduleName)
+	doc := scheduleStatusDoc{
+		ID:           modelUUID,
+		Schedule:     status.Schedule,
+		LastRun:      scheduleTimeToUnix(status.LastRun),
+		LastBackupID: status.LastBackupID,
+		LastError:    status.LastError,
+		NextRun:      scheduleTimeToUnix(status.NextRun),
+	}
+	if _, err := coll.UpsertId(modelUUID, &doc); err != nil {
+		return errors.Annotate(err, "recording backup schedule status")
+	}
+	return nil
+}
+
+// GetScheduleStatus returns the status of scheduled backups. If no
+// status has been recorded, an error satisfying errors.IsNotFound
+// is returned.
+func GetScheduleStatus(st DB) (*ScheduleStatus, error) {
+	session := st.MongoSession().Copy()
+	defer session.Close()
+
+	coll := session.DB(storageDBName).C(storageScheduleName)
+	var doc scheduleStatusDoc
+	err := coll.FindId(st.ModelTag().Id()).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("backup schedule status")
+	} else if err != nil {
+		return nil, errors.Annotate(err, "getting backup schedule status")
+	}
+	return &ScheduleStatus{
+		Schedule:     doc.Schedule,
+		LastRun:      scheduleUnixToTime(doc.LastRun),
+		LastBackupID: doc.LastBackupID,
+		LastError:    doc.LastError,
+		NextRun:      scheduleUnixToTime(doc.NextRun),
+	}, nil
+}
@@ -43,9 +43,12 @@ type storageMetaDoc struct {
 
 	// backup
 
-	Started  int64  `bson:"started,minsize"`
-	Finished int64  `bson:"finished,minsize"`
-	Notes    string `bson:"notes,omitempty"`
+	Started    int64  `bson:"started,minsize"`
+	Finished   int64  `bson:"finished,minsize"`
+	Notes      string `bson:"notes,omitempty"`
+	Schedule   string `bson:"schedule,omitempty"`
+	Encryption string `bson:"encryption,omitempty"`
+	ModelUUID  string `bson:"model-uuid,omitempty"`
 
 	// origin
 
@@ -123,6 +126,9 @@ func docAsMetadata(doc *storageMetaDoc) *Metadata {
 	meta := NewMetadata()
 	meta.Started = metadocUnixToTime(doc.Started)
 	meta.Notes = doc.Notes
+	meta.Schedule = doc.Schedule
+	meta.Encryption = doc.Encryption
+	meta.ModelUUID = doc.ModelUUID
 
 	meta.Origin.Model = doc.Model
 	meta.Origin.Machine = doc.Machine
@@ -174,6 +180,9 @@ func newStorageMetaDoc(meta *Metadata) storageMetaDoc {
 		doc.Finished = metadocTimeToUnix(*meta.Finished)
 	}
 	doc.Notes = meta.Notes
+	doc.Schedule = meta.Schedule
+	doc.Encryption = meta.Encryption
+	doc.ModelUUID = meta.ModelUUID
 
 	doc.Model = meta.Origin.Model
 	doc.Machine = meta.Origin.Machine
@@ -324,10 +333,14 @@ func getStorageMetadata(dbWrap *storageDBWrapper, id string) (*storageMetaDoc, e
 // "YYYYMMDD-hhmmss.<model ID>".  This makes the ID a little more human-
 // consumable (in contrast to a plain UUID string).  Ideally we would
 // use some form of model name rather than the UUID, but for now
-// the raw env ID is sufficient.
+// the raw env ID is sufficient. The ID of a model backup uses the ID
+// of the backed up model instead.
 var newStorageID = func(doc *storageMetaDoc) string {
 	started := metadocUnixToTime(doc.Started)
 	timestamp := started.Format(backupIDTimestamp)
+	if doc.ModelUUID != "" {
+		return timestamp + "." + doc.ModelUUID
+	}
 	return timestamp + "." + doc.Model
 }
 
@@ -546,6 +559,10 @@ type DB interface {
 
 	// StateServingInfo is the secrets of the controller.
 	StateServingInfo() (state.StateServingInfo, error)
+
+	// BackupStorageS3SecretKey is the secret key used to access
+	// S3 backup storage.
+	BackupStorageS3SecretKey() (string, error)
 }
 
 // NewStorage returns a new FileStorage to use for storing backup
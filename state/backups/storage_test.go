@@ -66,6 +66,7 @@ func (s *storageSuite) checkMeta(c *gc.C, meta, expected *backups.Metadata, id s
 		c.Check(meta.ID(), gc.Equals, id)
 	}
 	c.Check(meta.Notes, gc.Equals, expected.Notes)
+	c.Check(meta.Schedule, gc.Equals, expected.Schedule)
 	c.Check(meta.Started.Unix(), gc.Equals, expected.Started.Unix())
 	c.Check(meta.Checksum(), gc.Equals, expected.Checksum())
 	c.Check(meta.ChecksumFormat(), gc.Equals, expected.ChecksumFormat())
@@ -118,6 +119,18 @@ func (s *storageSuite) TestAddBackupMetadataSuccess(c *gc.C) {
 	s.checkMeta(c, meta, original, id)
 }
 
+func (s *storageSuite) TestAddBackupMetadataSchedule(c *gc.C) {
+	original := s.metadata(c)
+	original.Schedule = "@daily"
+	id, err := backups.AddBackupMetadata(s.State, original)
+	c.Assert(err, jc.ErrorIsNil)
+
+	meta, err := backups.GetBackupMetadata(s.State, id)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.checkMeta(c, meta, original, id)
+}
+
 func (s *storageSuite) TestAddBackupMetadataGeneratedID(c *gc.C) {
 	original := s.metadata(c)
 	original.SetID("spam")
@@ -166,3 +179,36 @@ func (s *storageSuite) TestSetBackupStoredTimeNotFound(c *gc.C) {
 
 	c.Check(err, jc.Satisfies, errors.IsNotFound)
 }
+
+func (s *storageSuite) TestScheduleStatus(c *gc.C) {
+	status := backups.ScheduleStatus{
+		Schedule:     "@daily",
+		LastRun:      time.Date(2016, 6, 1, 0, 0, 0, 0, time.UTC),
+		LastBackupID: "20160601-000000.spam",
+		NextRun:      time.Date(2016, 6, 2, 0, 0, 0, 0, time.UTC),
+	}
+	err := backups.SetScheduleStatus(s.State, status)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := backups.GetScheduleStatus(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(*result, jc.DeepEquals, status)
+
+	// Subsequent runs replace the recorded status.
+	status.LastRun = status.NextRun
+	status.LastBackupID = ""
+	status.LastError = "boom"
+	status.NextRun = time.Date(2016, 6, 3, 0, 0, 0, 0, time.UTC)
+	err = backups.SetScheduleStatus(s.State, status)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err = backups.GetScheduleStatus(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(*result, jc.DeepEquals, status)
+}
+
+func (s *storageSuite) TestScheduleStatusNotFound(c *gc.C) {
+	_, err := backups.GetScheduleStatus(s.State)
+	c.Check(err, gc.ErrorMatches, "backup schedule status not found")
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
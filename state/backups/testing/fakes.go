@@ -38,24 +38,37 @@ type FakeBackups struct {
 	DBInfoArg *backups.DBInfo
 	// MetaArg holds the backup metadata that was passed in.
 	MetaArg *backups.Metadata
+	// EncryptionArg holds the encryption args that were passed in.
+	EncryptionArg backups.EncryptArgs
 	// PrivateAddr Holds the address for the internal network of the machine.
 	PrivateAddr string
 	// InstanceId Is the id of the machine to be restored.
 	InstanceId instance.Id
 	// ArchiveArg holds the backup archive that was passed in.
 	ArchiveArg io.Reader
+	// DecryptionArg holds the decryption args that were passed in.
+	DecryptionArg backups.DecryptArgs
+	// ModelBackendArg holds the model backend that was passed in.
+	ModelBackendArg backups.ModelBackend
+	// ModelImporterArg holds the model importer that was passed in.
+	ModelImporterArg backups.ModelImporter
+	// ModelTag is the tag of the restored model to return.
+	ModelTag names.ModelTag
+	// Verification is the verification result to return.
+	Verification *backups.Verification
 }
 
 var _ backups.Backups = (*FakeBackups)(nil)
 
 // Create creates and stores a new juju backup archive and returns
 // its associated metadata.
-func (b *FakeBackups) Create(meta *backups.Metadata, paths *backups.Paths, dbInfo *backups.DBInfo) error {
+func (b *FakeBackups) Create(meta *backups.Metadata, paths *backups.Paths, dbInfo *backups.DBInfo, encryption backups.EncryptArgs) error {
 	b.Calls = append(b.Calls, "Create")
 
 	b.PathsArg = paths
 	b.DBInfoArg = dbInfo
 	b.MetaArg = meta
+	b.EncryptionArg = encryption
 
 	if b.Meta != nil {
 		*meta = *b.Meta
@@ -101,9 +114,46 @@ func (b *FakeBackups) Restore(bkpId string, dbInfo *backups.DBInfo, args backups
 	b.Calls = append(b.Calls, "Restore")
 	b.PrivateAddr = args.PrivateAddress
 	b.InstanceId = args.NewInstId
+	b.DecryptionArg = args.Decryption
 	return nil, errors.Trace(b.Error)
 }
 
+// CreateModel creates and stores a new backup archive of a single model
+// and returns its associated metadata.
+func (b *FakeBackups) CreateModel(meta *backups.Metadata, st backups.ModelBackend, encryption backups.EncryptArgs) error {
+	b.Calls = append(b.Calls, "CreateModel")
+
+	b.MetaArg = meta
+	b.ModelBackendArg = st
+	b.EncryptionArg = encryption
+
+	if b.Meta != nil {
+		*meta = *b.Meta
+	}
+
+	return b.Error
+}
+
+// RestoreModel re-creates a backed up model.
+func (b *FakeBackups) RestoreModel(backupId string, st backups.ModelImporter, decryption backups.DecryptArgs) (names.ModelTag, error) {
+	b.Calls = append(b.Calls, "RestoreModel")
+	b.IDArg = backupId
+	b.ModelImporterArg = st
+	b.DecryptionArg = decryption
+	return b.ModelTag, errors.Trace(b.Error)
+}
+
+// Verify checks a backup archive.
+func (b *FakeBackups) Verify(backupId string, decryption backups.DecryptArgs) (*backups.Verification, error) {
+	b.Calls = append(b.Calls, "Verify")
+	b.IDArg = backupId
+	b.DecryptionArg = decryption
+	if b.Error != nil {
+		return nil, errors.Trace(b.Error)
+	}
+	return b.Verification, nil
+}
+
 // TODO(ericsnow) FakeStorage should probably move over to the utils repo.
 
 // FakeStorage is a FileStorage implementation to use when testing
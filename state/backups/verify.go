@@ -0,0 +1,292 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"archive/tar"
+	"bufio"
+	"crypto/sha1"
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/hash"
+	"github.com/juju/version"
+	"gopkg.in/juju/charm.v6-unstable"
+	charmresource "gopkg.in/juju/charm.v6-unstable/resource"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/core/description"
+)
+
+const (
+	// jujuDB and modelsCollection locate the models in the database
+	// dump of a controller backup.
+	jujuDB           = "juju"
+	modelsCollection = "models"
+
+	// maxBSONDocumentSize is the largest document mongo will store,
+	// with some headroom for the internal documents of the oplog.
+	maxBSONDocumentSize = 16*1024*1024 + 16*1024
+)
+
+// Verification describes the content of a verified backup archive.
+type Verification struct {
+	// Version is the version of Juju that created the backup.
+	Version version.Number
+
+	// ModelCount is the number of models in the backup.
+	ModelCount int
+
+	// Collections is the number of collections in the database dump
+	// of a controller backup, and Documents is the number of documents
+	// in them. Both are zero for model backups.
+	Collections int
+	Documents   int
+}
+
+// Verify checks that the stored backup archive is usable, decrypting
+// it if necessary, and describes its content.
+func (b *backups) Verify(backupId string, decryption DecryptArgs) (*Verification, error) {
+	meta, archive, err := b.Get(backupId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer archive.Close()
+
+	result, err := VerifyArchive(archive, meta, decryption)
+	return result, errors.Annotatef(err, "backup %q failed verification", backupId)
+}
+
+// VerifyArchive checks that the backup archive is usable, and describes
+// its content. If the metadata records the archive's size and checksum,
+// they are checked first; if it records that the archive is encrypted,
+// it is decrypted with the given args. The metadata may be nil for an
+// archive that is not encrypted.
+//
+// The archive must unpack to the expected layout. The database dump of
+// a controller backup is read as mongorestore would read it, without
+// restoring it; a model backup must hold a model description and the
+// archives of the charms used by the model.
+func VerifyArchive(archive io.Reader, meta *Metadata, decryption DecryptArgs) (*Verification, error) {
+	hasher := hash.NewHashingWriter(ioutil.Discard, sha1.New())
+	counter := &countingWriter{}
+	content := io.TeeReader(archive, io.MultiWriter(hasher, counter))
+
+	var plain io.Reader = content
+	if meta != nil && meta.Encryption != "" {
+		var err error
+		plain, err = DecryptArchive(content, meta.Encryption, decryption)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	ws, err := NewArchiveWorkspaceReader(plain)
+	if ws != nil {
+		defer ws.Close()
+	}
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot unpack archive")
+	}
+	// The integrity of encrypted archives is only checked once they
+	// are read in full, and the checksum is of the whole archive.
+	if _, err := io.Copy(ioutil.Discard, plain); err != nil {
+		return nil, errors.Annotate(err, "cannot read archive")
+	}
+	if _, err := io.Copy(ioutil.Discard, content); err != nil {
+		return nil, errors.Annotate(err, "cannot read archive")
+	}
+
+	if meta != nil && meta.Checksum() != "" {
+		if counter.size != meta.Size() {
+			return nil, errors.Errorf("archive size %d does not match metadata (%d)", counter.size, meta.Size())
+		}
+		if sum := hasher.Base64Sum(); sum != meta.Checksum() {
+			return nil, errors.Errorf("archive checksum %q does not match metadata (%q)", sum, meta.Checksum())
+		}
+	}
+
+	archived, err := ws.Metadata()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot read archived metadata")
+	}
+	result := &Verification{Version: archived.Origin.Version}
+	if archived.ModelUUID != "" {
+		err = verifyModelContent(ws, result)
+	} else {
+		err = verifyControllerContent(ws, result)
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result, nil
+}
+
+// verifyControllerContent checks the files bundle and database dump of
+// a controller backup.
+func verifyControllerContent(ws *ArchiveWorkspace, result *Verification) error {
+	if err := verifyFilesBundle(ws.FilesBundle); err != nil {
+		return errors.Annotate(err, "invalid files bundle")
+	}
+	databases, err := listDatabases(ws.DBDumpDir)
+	if err != nil {
+		return errors.Annotate(err, "cannot read database dump")
+	}
+	if !databases.Contains(jujuDB) {
+		return errors.Errorf("database dump has no %q database", jujuDB)
+	}
+	for _, dbName := range databases.SortedValues() {
+		dbDir := filepath.Join(ws.DBDumpDir, dbName)
+		files, err := ioutil.ReadDir(dbDir)
+		if err != nil {
+			return errors.Annotate(err, "cannot read database dump")
+		}
+		for _, info := range files {
+			collection := strings.TrimSuffix(info.Name(), ".bson")
+			if info.IsDir() || collection == info.Name() {
+				// mongodump also writes the indexes of each
+				// collection to a separate metadata file.
+				continue
+			}
+			count, err := countBSONDocuments(filepath.Join(dbDir, info.Name()))
+			if err != nil {
+				return errors.Annotatef(err, "invalid dump of %s.%s", dbName, collection)
+			}
+			result.Collections++
+			result.Documents += count
+			if dbName == jujuDB && collection == modelsCollection {
+				result.ModelCount = count
+			}
+		}
+	}
+	if result.ModelCount == 0 {
+		return errors.New("database dump holds no models")
+	}
+	return nil
+}
+
+// verifyFilesBundle checks that the files bundle is a complete tar file.
+func verifyFilesBundle(filename string) error {
+	f, err := os.Open(filename)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer f.Close()
+	tr := tar.NewReader(f)
+	for {
+		_, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		if _, err := io.Copy(ioutil.Discard, tr); err != nil {
+			return errors.Trace(err)
+		}
+	}
+}
+
+// countBSONDocuments reads the BSON documents in the file written by
+// mongodump, and returns how many there are.
+func countBSONDocuments(filename string) (int, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	defer f.Close()
+
+	r := bufio.NewReader(f)
+	count := 0
+	for {
+		var size int32
+		if err := binary.Read(r, binary.LittleEndian, &size); err == io.EOF {
+			return count, nil
+		} else if err != nil {
+			return 0, errors.Annotatef(err, "cannot read document %d", count)
+		}
+		// A document holds at least its size and terminating null.
+		if size < 5 || size > maxBSONDocumentSize {
+			return 0, errors.Errorf("document %d has invalid size %d", count, size)
+		}
+		data := make([]byte, size)
+		binary.LittleEndian.PutUint32(data, uint32(size))
+		if _, err := io.ReadFull(r, data[4:]); err != nil {
+			return 0, errors.Annotatef(err, "cannot read document %d", count)
+		}
+		var doc bson.D
+		if err := bson.Unmarshal(data, &doc); err != nil {
+			return 0, errors.Annotatef(err, "cannot parse document %d", count)
+		}
+		count++
+	}
+}
+
+// verifyModelContent checks the model description, charm archives and
+// resource content of a model backup.
+func verifyModelContent(ws *ArchiveWorkspace, result *Verification) error {
+	modelBytes, err := ioutil.ReadFile(filepath.Join(ws.ContentDir, modelFile))
+	if err != nil {
+		return errors.Annotate(err, "cannot read model")
+	}
+	model, err := description.Deserialize(modelBytes)
+	if err != nil {
+		return errors.Annotate(err, "invalid model")
+	}
+	for _, application := range model.Applications() {
+		charmURL := application.CharmURL()
+		archivePath := filepath.Join(ws.ContentDir, charmsDir, url.QueryEscape(charmURL))
+		if _, err := charm.ReadCharmArchive(archivePath); err != nil {
+			return errors.Annotatef(err, "invalid archive of charm %q", charmURL)
+		}
+		for _, res := range application.Resources() {
+			rev := res.ApplicationRevision()
+			if rev.Timestamp().IsZero() {
+				continue
+			}
+			filename := filepath.Join(ws.ContentDir, resourcesDir, resourceFileName(application.Name(), res.Name()))
+			if err := verifyResourceContent(filename, rev); err != nil {
+				return errors.Annotatef(err, "invalid content of resource %s/%s", application.Name(), res.Name())
+			}
+		}
+	}
+	result.ModelCount = 1
+	return nil
+}
+
+// verifyResourceContent checks that the resource content in the file
+// matches the size and fingerprint of the resource revision.
+func verifyResourceContent(filename string, rev description.ResourceRevision) error {
+	f, err := os.Open(filename)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer f.Close()
+	counter := &countingWriter{}
+	fp, err := charmresource.GenerateFingerprint(io.TeeReader(f, counter))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if counter.size != rev.Size() {
+		return errors.Errorf("size %d does not match model (%d)", counter.size, rev.Size())
+	}
+	if fp.String() != rev.FingerprintHex() {
+		return errors.Errorf("fingerprint %q does not match model (%q)", fp.String(), rev.FingerprintHex())
+	}
+	return nil
+}
+
+// countingWriter counts the bytes written to it.
+type countingWriter struct {
+	size int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.size += int64(len(p))
+	return len(p), nil
+}
@@ -0,0 +1,191 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/base64"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/state/backups"
+	bt "github.com/juju/juju/state/backups/testing"
+)
+
+type verifySuite struct {
+	testing.IsolationSuite
+	meta *backups.Metadata
+}
+
+var _ = gc.Suite(&verifySuite{})
+
+func (s *verifySuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.meta = bt.NewMetadataStarted()
+	s.meta.Origin.Version = version.MustParse("2.0.0")
+}
+
+// bsonDump returns the content of a collection dump holding the docs.
+func bsonDump(c *gc.C, docs ...bson.M) string {
+	var dump bytes.Buffer
+	for _, doc := range docs {
+		data, err := bson.Marshal(doc)
+		c.Assert(err, jc.ErrorIsNil)
+		dump.Write(data)
+	}
+	return dump.String()
+}
+
+func (s *verifySuite) newArchive(c *gc.C, dump ...bt.File) []byte {
+	files := []bt.File{{
+		Name:    "var/lib/juju/system-identity",
+		Content: "<an ssh key goes here>",
+	}}
+	archive, err := bt.NewArchive(s.meta, files, dump)
+	c.Assert(err, jc.ErrorIsNil)
+	return archive.Bytes()
+}
+
+func (s *verifySuite) newValidArchive(c *gc.C) []byte {
+	return s.newArchive(c,
+		bt.File{Name: "juju", IsDir: true},
+		bt.File{
+			Name:    "juju/models.bson",
+			Content: bsonDump(c, bson.M{"_id": "model-1"}, bson.M{"_id": "model-2"}),
+		},
+		bt.File{Name: "juju/models.metadata.json", Content: `{"indexes":[]}`},
+		bt.File{
+			Name:    "juju/machines.bson",
+			Content: bsonDump(c, bson.M{"_id": "0"}, bson.M{"_id": "1"}, bson.M{"_id": "2"}),
+		},
+		bt.File{Name: "oplog.bson", Content: bsonDump(c, bson.M{"op": "n"})},
+	)
+}
+
+// complete records the archive's size and checksum in the metadata.
+func (s *verifySuite) complete(c *gc.C, archive []byte) {
+	sum := sha1.Sum(archive)
+	err := s.meta.MarkComplete(int64(len(archive)), base64.StdEncoding.EncodeToString(sum[:]))
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *verifySuite) TestVerifyArchive(c *gc.C) {
+	archive := s.newValidArchive(c)
+	s.complete(c, archive)
+
+	result, err := backups.VerifyArchive(bytes.NewReader(archive), s.meta, backups.DecryptArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, jc.DeepEquals, &backups.Verification{
+		Version:     version.MustParse("2.0.0"),
+		ModelCount:  2,
+		Collections: 2,
+		Documents:   5,
+	})
+}
+
+func (s *verifySuite) TestVerifyArchiveWithoutMetadata(c *gc.C) {
+	archive := s.newValidArchive(c)
+
+	result, err := backups.VerifyArchive(bytes.NewReader(archive), nil, backups.DecryptArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.ModelCount, gc.Equals, 2)
+}
+
+func (s *verifySuite) TestVerifyArchiveChecksumMismatch(c *gc.C) {
+	archive := s.newValidArchive(c)
+	err := s.meta.MarkComplete(int64(len(archive)), "<checksum>")
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = backups.VerifyArchive(bytes.NewReader(archive), s.meta, backups.DecryptArgs{})
+	c.Check(err, gc.ErrorMatches, `archive checksum ".*" does not match metadata \("<checksum>"\)`)
+}
+
+func (s *verifySuite) TestVerifyArchiveSizeMismatch(c *gc.C) {
+	archive := s.newValidArchive(c)
+	s.complete(c, archive)
+
+	_, err := backups.VerifyArchive(bytes.NewReader(archive[:len(archive)-1]), s.meta, backups.DecryptArgs{})
+	c.Check(err, gc.ErrorMatches, `archive size \d+ does not match metadata \(\d+\)`)
+}
+
+func (s *verifySuite) TestVerifyArchiveNotAnArchive(c *gc.C) {
+	_, err := backups.VerifyArchive(bytes.NewBufferString("<not an archive>"), nil, backups.DecryptArgs{})
+	c.Check(err, gc.ErrorMatches, "cannot unpack archive: .*")
+}
+
+func (s *verifySuite) TestVerifyArchiveInvalidDump(c *gc.C) {
+	archive := s.newArchive(c,
+		bt.File{Name: "juju", IsDir: true},
+		bt.File{Name: "juju/models.bson", Content: bsonDump(c, bson.M{"_id": "model-1"})},
+		bt.File{Name: "juju/machines.bson", Content: "<BSON data goes here>"},
+	)
+
+	_, err := backups.VerifyArchive(bytes.NewReader(archive), nil, backups.DecryptArgs{})
+	c.Check(err, gc.ErrorMatches, `invalid dump of juju.machines: document 0 has invalid size \d+`)
+}
+
+func (s *verifySuite) TestVerifyArchiveNoJujuDatabase(c *gc.C) {
+	archive := s.newArchive(c,
+		bt.File{Name: "admin", IsDir: true},
+		bt.File{Name: "admin/system.users.bson", Content: bsonDump(c, bson.M{"_id": "admin"})},
+	)
+
+	_, err := backups.VerifyArchive(bytes.NewReader(archive), nil, backups.DecryptArgs{})
+	c.Check(err, gc.ErrorMatches, `database dump has no "juju" database`)
+}
+
+func (s *verifySuite) TestVerifyArchiveNoModels(c *gc.C) {
+	archive := s.newArchive(c,
+		bt.File{Name: "juju", IsDir: true},
+		bt.File{Name: "juju/machines.bson", Content: bsonDump(c, bson.M{"_id": "0"})},
+	)
+
+	_, err := backups.VerifyArchive(bytes.NewReader(archive), nil, backups.DecryptArgs{})
+	c.Check(err, gc.ErrorMatches, "database dump holds no models")
+}
+
+func (s *verifySuite) TestVerifyArchiveEncrypted(c *gc.C) {
+	var encrypted bytes.Buffer
+	err := backups.EncryptArchive(&encrypted, bytes.NewReader(s.newValidArchive(c)), backups.EncryptArgs{
+		Passphrase: "secret",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.meta.Encryption = backups.PassphraseEncryption
+	s.complete(c, encrypted.Bytes())
+
+	result, err := backups.VerifyArchive(bytes.NewReader(encrypted.Bytes()), s.meta, backups.DecryptArgs{Passphrase: "secret"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.ModelCount, gc.Equals, 2)
+
+	_, err = backups.VerifyArchive(bytes.NewReader(encrypted.Bytes()), s.meta, backups.DecryptArgs{Passphrase: "guess"})
+	c.Check(err, gc.ErrorMatches, "cannot decrypt backup archive: .*")
+}
+
+func (s *verifySuite) TestVerify(c *gc.C) {
+	archive := s.newValidArchive(c)
+	s.complete(c, archive)
+	stor := backups.NewBackups(backups.NewDirectoryStorage(c.MkDir()))
+	id, err := stor.Add(bytes.NewReader(archive), s.meta)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := stor.Verify(id, backups.DecryptArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.ModelCount, gc.Equals, 2)
+}
+
+func (s *verifySuite) TestVerifyFailed(c *gc.C) {
+	err := s.meta.MarkComplete(10, "<checksum>")
+	c.Assert(err, jc.ErrorIsNil)
+	stor := backups.NewBackups(backups.NewDirectoryStorage(c.MkDir()))
+	id, err := stor.Add(bytes.NewBufferString("<compressed tarball>"), s.meta)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = stor.Verify(id, backups.DecryptArgs{})
+	c.Check(err, gc.ErrorMatches, `backup ".*" failed verification: cannot unpack archive: .*`)
+}
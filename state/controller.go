@@ -5,6 +5,8 @@ package state
 
 import (
 	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/txn"
 
 	jujucontroller "github.com/juju/juju/controller"
 )
@@ -15,8 +17,62 @@ const (
 
 	// controllerInheritedSettingsGlobalKey is the key for default settings shared across models.
 	controllerInheritedSettingsGlobalKey = "controllerInheritedSettings"
+
+	// backupStorageCredentialsKey is the key for the credentials
+	// used to access external backup storage.
+	backupStorageCredentialsKey = "backupStorageCredentials"
 )
 
+// backupStorageCredentialsDoc holds the secrets used to access
+// external backup storage. They are kept apart from the controller
+// settings, so that they are not revealed to clients that may read
+// the controller config.
+type backupStorageCredentialsDoc struct {
+	S3SecretKey string `bson:"s3-secret-key"`
+}
+
+// splitBackupStorageCredentials returns a copy of the given controller
+// config without the backup storage secrets, along with a doc holding
+// those secrets.
+func splitBackupStorageCredentials(cfg jujucontroller.Config) (jujucontroller.Config, backupStorageCredentialsDoc) {
+	var doc backupStorageCredentialsDoc
+	stripped := make(jujucontroller.Config)
+	for k, v := range cfg {
+		if k == jujucontroller.BackupStorageS3SecretKey {
+			doc.S3SecretKey, _ = v.(string)
+			continue
+		}
+		stripped[k] = v
+	}
+	return stripped, doc
+}
+
+func createBackupStorageCredentialsOp(doc backupStorageCredentialsDoc) txn.Op {
+	return txn.Op{
+		C:      controllersC,
+		Id:     backupStorageCredentialsKey,
+		Assert: txn.DocMissing,
+		Insert: &doc,
+	}
+}
+
+// BackupStorageS3SecretKey returns the secret key used to authenticate
+// with S3 backup storage, or "" if there is none. The key is not part
+// of the config returned by ControllerConfig.
+func (st *State) BackupStorageS3SecretKey() (string, error) {
+	controllers, closer := st.getCollection(controllersC)
+	defer closer()
+
+	var doc backupStorageCredentialsDoc
+	err := controllers.FindId(backupStorageCredentialsKey).One(&doc)
+	if err == mgo.ErrNotFound {
+		return "", nil
+	} else if err != nil {
+		return "", errors.Annotate(err, "cannot get backup storage credentials")
+	}
+	return doc.S3SecretKey, nil
+}
+
 // ControllerConfig returns the config values for the controller.
 func (st *State) ControllerConfig() (jujucontroller.Config, error) {
 	settings, err := readSettings(st, controllersC, controllerSettingsGlobalKey)
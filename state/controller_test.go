@@ -24,7 +24,17 @@ func (s *ControllerConfigSuite) TestControllerAndModelConfigInitialisation(c *gc
 	c.Assert(err, jc.ErrorIsNil)
 
 	optional := func(attr string) bool {
-		return attr == controller.IdentityURL || attr == controller.IdentityPublicKey
+		switch attr {
+		case controller.IdentityURL, controller.IdentityPublicKey,
+			controller.BackupSchedule, controller.BackupKeepLast,
+			controller.BackupKeepDaily, controller.BackupKeepWeekly,
+			controller.BackupStorage, controller.BackupStorageDirectory,
+			controller.BackupStorageS3Endpoint, controller.BackupStorageS3Region,
+			controller.BackupStorageS3Bucket, controller.BackupStorageS3AccessKey,
+			controller.BackupStorageS3SecretKey:
+			return true
+		}
+		return false
 	}
 	for _, controllerAttr := range controller.ControllerOnlyConfigAttributes {
 		v, ok := controllerSettings.Get(controllerAttr)
@@ -285,3 +285,21 @@ func DefaultEndpointBindingsForCharm(charmMeta *charm.Meta) map[string]string {
 	}
 	return bindings
 }
+
+// AllEndpointBindings returns the effective endpoint bindings of every
+// application in the model, keyed by application name.
+func (st *State) AllEndpointBindings() (map[string]map[string]string, error) {
+	applications, err := st.AllApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make(map[string]map[string]string, len(applications))
+	for _, application := range applications {
+		bindings, err := application.EndpointBindings()
+		if err != nil {
+			return nil, errors.Annotatef(err, "application %q", application.Name())
+		}
+		result[application.Name()] = bindings
+	}
+	return result, nil
+}
@@ -198,6 +198,32 @@ func (s *BindingsSuite) TestMergeBindings(c *gc.C) {
 	}
 }
 
+func (s *BindingsSuite) TestAllEndpointBindings(c *gc.C) {
+	bindings, err := s.State.AllEndpointBindings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(bindings, gc.HasLen, 0)
+
+	ch, err := s.State.Charm(charm.MustParseURL("local:quantal/quantal-dummy-1"))
+	c.Assert(err, jc.ErrorIsNil)
+	state.AddTestingServiceWithBindings(c, s.State, "bound", ch, map[string]string{
+		"foo1": "client",
+		"bar1": "apps",
+	})
+	state.AddTestingService(c, s.State, "unbound", ch)
+
+	bindings, err = s.State.AllEndpointBindings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(bindings, jc.DeepEquals, map[string]map[string]string{
+		"bound": {
+			"foo1":      "client",
+			"bar1":      "apps",
+			"self":      "",
+			"one-extra": "",
+		},
+		"unbound": s.oldDefaults,
+	})
+}
+
 func (s *BindingsSuite) copyMap(input map[string]string) map[string]string {
 	output := make(map[string]string, len(input))
 	for key, value := range input {
@@ -85,6 +85,11 @@ type FilesystemAttachment interface {
 	// if it has not already been made. Params returns true if the returned
 	// parameters are usable for creating an attachment, otherwise false.
 	Params() (FilesystemAttachmentParams, bool)
+
+	// Usage returns the capacity usage of the attached filesystem, as
+	// last reported by the machine agent. Usage returns true if usage
+	// has been reported, otherwise false.
+	Usage() (FilesystemUsage, bool)
 }
 
 type filesystem struct {
@@ -123,6 +128,7 @@ type filesystemAttachmentDoc struct {
 	Life       Life                        `bson:"life"`
 	Info       *FilesystemAttachmentInfo   `bson:"info,omitempty"`
 	Params     *FilesystemAttachmentParams `bson:"params,omitempty"`
+	Usage      *FilesystemUsage            `bson:"usage,omitempty"`
 }
 
 // FilesystemParams records parameters for provisioning a new filesystem.
@@ -148,6 +154,10 @@ type FilesystemInfo struct {
 	// filesystem. This will be unspecified for filesystems
 	// backed by volumes.
 	FilesystemId string `bson:"filesystemid"`
+
+	// Encrypted records whether the filesystem is encrypted at
+	// rest, independently of any volume backing it.
+	Encrypted bool `bson:"encrypted,omitempty"`
 }
 
 // FilesystemAttachmentInfo describes information about a filesystem attachment.
@@ -159,6 +169,17 @@ type FilesystemAttachmentInfo struct {
 	ReadOnly   bool   `bson:"read-only"`
 }
 
+// FilesystemUsage describes the capacity usage of a mounted filesystem.
+type FilesystemUsage struct {
+	UsedBytes  uint64 `bson:"usedbytes"`
+	FreeBytes  uint64 `bson:"freebytes"`
+	UsedInodes uint64 `bson:"usedinodes"`
+	FreeInodes uint64 `bson:"freeinodes"`
+
+	// Updated is the time at which the usage was recorded.
+	Updated time.Time `bson:"updated"`
+}
+
 // FilesystemAttachmentParams records parameters for attaching a filesystem to a
 // machine.
 type FilesystemAttachmentParams struct {
@@ -310,6 +331,14 @@ func (f *filesystemAttachment) Params() (FilesystemAttachmentParams, bool) {
 	return *f.doc.Params, true
 }
 
+// Usage is required to implement FilesystemAttachment.
+func (f *filesystemAttachment) Usage() (FilesystemUsage, bool) {
+	if f.doc.Usage == nil {
+		return FilesystemUsage{}, false
+	}
+	return *f.doc.Usage, true
+}
+
 // Filesystem returns the Filesystem with the specified name.
 func (st *State) Filesystem(tag names.FilesystemTag) (Filesystem, error) {
 	f, err := st.filesystemByTag(tag)
@@ -460,6 +489,21 @@ func (st *State) removeMachineFilesystemsOps(machine names.MachineTag) ([]txn.Op
 			Assert: txn.DocExists,
 			Remove: true,
 		})
+		shared, err := isSharedFilesystem(st, filesystemTag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if shared {
+			// Shared filesystems outlive the machines they are
+			// attached to, so we just drop the machine's reference.
+			ops = append(ops, txn.Op{
+				C:      filesystemsC,
+				Id:     filesystemTag.Id(),
+				Assert: bson.D{{"attachmentcount", bson.D{{"$gt", 0}}}},
+				Update: bson.D{{"$inc", bson.D{{"attachmentcount", -1}}}},
+			})
+			continue
+		}
 		canRemove, err := isFilesystemInherentlyMachineBound(st, filesystemTag)
 		if err != nil {
 			return nil, errors.Trace(err)
@@ -487,6 +531,27 @@ func isFilesystemInherentlyMachineBound(st *State, tag names.FilesystemTag) (boo
 	return true, nil
 }
 
+// isSharedFilesystem reports whether or not the filesystem with the
+// specified tag is assigned to a storage instance shared by the units
+// of an application, and so may be attached to multiple machines.
+func isSharedFilesystem(st *State, tag names.FilesystemTag) (bool, error) {
+	f, err := st.filesystemByTag(tag)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	if f.doc.StorageId == "" {
+		return false, nil
+	}
+	si, err := st.storageInstance(names.NewStorageTag(f.doc.StorageId))
+	if errors.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, errors.Trace(err)
+	}
+	_, ok := si.Owner().(names.ApplicationTag)
+	return ok, nil
+}
+
 // DetachFilesystem marks the filesystem attachment identified by the specified machine
 // and filesystem tags as Dying, if it is Alive.
 func (st *State) DetachFilesystem(machine names.MachineTag, filesystem names.FilesystemTag) (err error) {
@@ -714,6 +779,27 @@ func (st *State) addFilesystemOps(params FilesystemParams, machineId string) ([]
 	if params.binding == nil {
 		params.binding = names.NewMachineTag(machineId)
 	}
+	// Every filesystem is created with one attachment.
+	return st.newFilesystemOps(params, machineId, 1)
+}
+
+// addSharedFilesystemOps returns txn.Ops to create a new model-scoped
+// filesystem for a shared storage instance. Shared filesystems are
+// created without attachments; an attachment is added for each machine
+// that a unit of the owning application is assigned to.
+func (st *State) addSharedFilesystemOps(params FilesystemParams) ([]txn.Op, names.FilesystemTag, error) {
+	ops, filesystemTag, _, err := st.newFilesystemOps(params, "", 0)
+	if err != nil {
+		return nil, names.FilesystemTag{}, errors.Trace(err)
+	}
+	return ops, filesystemTag, nil
+}
+
+// newFilesystemOps returns txn.Ops to create a new filesystem with the
+// specified parameters and initial attachment count.
+func (st *State) newFilesystemOps(
+	params FilesystemParams, machineId string, attachmentCount int,
+) ([]txn.Op, names.FilesystemTag, names.VolumeTag, error) {
 	params, err := st.filesystemParamsWithDefaults(params)
 	if err != nil {
 		return nil, names.FilesystemTag{}, names.VolumeTag{}, errors.Trace(err)
@@ -764,13 +850,12 @@ func (st *State) addFilesystemOps(params FilesystemParams, machineId string) ([]
 			Id:     filesystemId,
 			Assert: txn.DocMissing,
 			Insert: &filesystemDoc{
-				FilesystemId: filesystemId,
-				VolumeId:     volumeId,
-				StorageId:    params.storage.Id(),
-				Binding:      params.binding.String(),
-				Params:       &params,
-				// Every filesystem is created with one attachment.
-				AttachmentCount: 1,
+				FilesystemId:    filesystemId,
+				VolumeId:        volumeId,
+				StorageId:       params.storage.Id(),
+				Binding:         params.binding.String(),
+				Params:          &params,
+				AttachmentCount: attachmentCount,
 			},
 		},
 	}
@@ -971,6 +1056,36 @@ func (st *State) SetFilesystemAttachmentInfo(
 	return st.run(buildTxn)
 }
 
+// SetFilesystemAttachmentUsage records the capacity usage of the filesystem
+// attached to the specified machine. The attachment must be provisioned.
+func (st *State) SetFilesystemAttachmentUsage(
+	machineTag names.MachineTag,
+	filesystemTag names.FilesystemTag,
+	usage FilesystemUsage,
+) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set usage for filesystem attachment %s:%s", filesystemTag.Id(), machineTag.Id())
+	usage.Updated = nowToTheSecond()
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		fsa, err := st.FilesystemAttachment(machineTag, filesystemTag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if fsa.Life() != Alive {
+			return nil, errors.New("filesystem attachment is not alive")
+		}
+		if _, err := fsa.Info(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{{
+			C:      filesystemAttachmentsC,
+			Id:     filesystemAttachmentId(machineTag.Id(), filesystemTag.Id()),
+			Assert: append(isAliveDoc, bson.DocElem{"info", bson.D{{"$exists", true}}}),
+			Update: bson.D{{"$set", bson.D{{"usage", &usage}}}},
+		}}, nil
+	}
+	return st.run(buildTxn)
+}
+
 func setFilesystemAttachmentInfoOps(
 	machine names.MachineTag,
 	filesystem names.FilesystemTag,
@@ -4,6 +4,8 @@
 package state_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -404,17 +406,18 @@ func (s *FilesystemStateSuite) TestWatchMachineFilesystemAttachments(c *gc.C) {
 	w := s.State.WatchMachineFilesystemAttachments(names.NewMachineTag("0"))
 	defer testing.AssertStop(c, w)
 	wc := testing.NewStringsWatcherC(c, s.State, w)
-	wc.AssertChangeInSingleEvent("0:0/1", "0:0/2") // initial
+	wc.AssertChangeInSingleEvent("0:0", "0:0/1", "0:0/2") // initial
 	wc.AssertNoChange()
 
 	addUnit(nil)
 	// no change, since we're only interested in the one machine.
 	wc.AssertNoChange()
 
+	// Attachments of environ-scoped filesystems are reported too,
+	// as they may be shared filesystems attached by the machine.
 	err := s.State.DetachFilesystem(names.NewMachineTag("0"), names.NewFilesystemTag("0"))
 	c.Assert(err, jc.ErrorIsNil)
-	// no change, since we're only interested in attachments of
-	// machine-scoped volumes.
+	wc.AssertChangeInSingleEvent("0:0") // dying
 	wc.AssertNoChange()
 
 	err = s.State.DetachFilesystem(names.NewMachineTag("0"), names.NewFilesystemTag("0/1"))
@@ -428,7 +431,7 @@ func (s *FilesystemStateSuite) TestWatchMachineFilesystemAttachments(c *gc.C) {
 	wc.AssertNoChange()
 
 	addUnit(m0)
-	wc.AssertChangeInSingleEvent("0:0/7", "0:0/8")
+	wc.AssertChangeInSingleEvent("0:6", "0:0/7", "0:0/8")
 	wc.AssertNoChange()
 }
 
@@ -512,6 +515,53 @@ func (s *FilesystemStateSuite) TestSetFilesystemAttachmentInfoMachineNotProvisio
 	c.Assert(err, gc.ErrorMatches, `cannot set info for filesystem attachment 0/0:0: machine 0 not provisioned`)
 }
 
+func (s *FilesystemStateSuite) TestSetFilesystemAttachmentUsage(c *gc.C) {
+	filesystem, machine := s.setupFilesystemAttachment(c, "rootfs")
+	err := machine.SetProvisioned("inst-id", "fake_nonce", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetFilesystemInfo(
+		filesystem.FilesystemTag(),
+		state.FilesystemInfo{Size: 123, FilesystemId: "fs-id"},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetFilesystemAttachmentInfo(
+		machine.MachineTag(),
+		filesystem.FilesystemTag(),
+		state.FilesystemAttachmentInfo{MountPoint: "/srv"},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	attachment := s.filesystemAttachment(c, machine.MachineTag(), filesystem.FilesystemTag())
+	_, ok := attachment.Usage()
+	c.Assert(ok, jc.IsFalse)
+
+	err = s.State.SetFilesystemAttachmentUsage(
+		machine.MachineTag(),
+		filesystem.FilesystemTag(),
+		state.FilesystemUsage{UsedBytes: 1, FreeBytes: 2, UsedInodes: 3, FreeInodes: 4},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	attachment = s.filesystemAttachment(c, machine.MachineTag(), filesystem.FilesystemTag())
+	usage, ok := attachment.Usage()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(usage.Updated.IsZero(), jc.IsFalse)
+	usage.Updated = time.Time{}
+	c.Assert(usage, jc.DeepEquals, state.FilesystemUsage{
+		UsedBytes: 1, FreeBytes: 2, UsedInodes: 3, FreeInodes: 4,
+	})
+}
+
+func (s *FilesystemStateSuite) TestSetFilesystemAttachmentUsageNotProvisioned(c *gc.C) {
+	filesystem, machine := s.setupFilesystemAttachment(c, "rootfs")
+	err := s.State.SetFilesystemAttachmentUsage(
+		machine.MachineTag(),
+		filesystem.FilesystemTag(),
+		state.FilesystemUsage{UsedBytes: 1},
+	)
+	c.Assert(err, gc.ErrorMatches, `cannot set usage for filesystem attachment 0/0:0: filesystem attachment "0/0" on "0" not provisioned`)
+}
+
 func (s *FilesystemStateSuite) TestSetFilesystemInfoVolumeAttachmentNotProvisioned(c *gc.C) {
 	filesystemAttachment, _ := s.addUnitWithFilesystem(c, "loop", true)
 	err := s.State.SetFilesystemInfo(
@@ -749,6 +799,108 @@ func (s *FilesystemStateSuite) TestRemoveMachineRemovesFilesystems(c *gc.C) {
 	c.Assert(attachments, gc.HasLen, 0)
 }
 
+func (s *FilesystemStateSuite) setupSharedFilesystemService(c *gc.C, pool string) *state.Application {
+	ch := s.createStorageCharm(c, "shared-fs", charm.Storage{
+		Name:     "data",
+		Type:     charm.StorageFilesystem,
+		Shared:   true,
+		CountMin: 1,
+		CountMax: 1,
+		Location: "/srv/shared",
+	})
+	storage := map[string]state.StorageConstraints{
+		"data": makeStorageCons(pool, 1024, 1),
+	}
+	return s.AddTestingServiceWithStorage(c, "shared-fs", ch, storage)
+}
+
+func (s *FilesystemStateSuite) addSharedFilesystemUnit(c *gc.C, service *state.Application) (*state.Unit, names.MachineTag) {
+	u, err := service.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	machineId, err := u.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	return u, names.NewMachineTag(machineId)
+}
+
+func (s *FilesystemStateSuite) TestAddServiceSharedStorage(c *gc.C) {
+	service := s.setupSharedFilesystemService(c, "environscoped-shared")
+	u0, m0 := s.addSharedFilesystemUnit(c, service)
+	u1, m1 := s.addSharedFilesystemUnit(c, service)
+
+	// There is a single storage instance, owned by the application
+	// and attached to each of its units.
+	storageTag := names.NewStorageTag("data/0")
+	storageInstance, err := s.State.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(storageInstance.Owner(), gc.Equals, service.Tag())
+	for _, u := range []*state.Unit{u0, u1} {
+		_, err := s.State.StorageAttachment(storageTag, u.UnitTag())
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	// The storage instance's filesystem is model-scoped, and
+	// attached to the machine of each unit.
+	filesystem := s.storageInstanceFilesystem(c, storageTag)
+	c.Assert(filesystem.FilesystemTag(), gc.Equals, names.NewFilesystemTag("0"))
+	for _, m := range []names.MachineTag{m0, m1} {
+		s.assertFilesystemAttachmentUnprovisioned(c, m, filesystem.FilesystemTag())
+		attachment := s.filesystemAttachment(c, m, filesystem.FilesystemTag())
+		params, ok := attachment.Params()
+		c.Assert(ok, jc.IsTrue)
+		c.Assert(params.Location, gc.Equals, "/srv/shared")
+	}
+}
+
+func (s *FilesystemStateSuite) TestAddServiceSharedStorageUnsupportedPool(c *gc.C) {
+	ch := s.createStorageCharm(c, "shared-fs", charm.Storage{
+		Name:     "data",
+		Type:     charm.StorageFilesystem,
+		Shared:   true,
+		CountMin: 1,
+		CountMax: 1,
+	})
+	storage := map[string]state.StorageConstraints{
+		"data": makeStorageCons("environscoped", 1024, 1),
+	}
+	_, err := s.State.AddApplication(state.AddApplicationArgs{Name: "shared-fs", Charm: ch, Storage: storage})
+	c.Assert(err, gc.ErrorMatches, `.* charm "shared-fs" store "data": "environscoped" provider does not support shared filesystems`)
+}
+
+func (s *FilesystemStateSuite) TestAddServiceSharedBlockStorage(c *gc.C) {
+	ch := s.createStorageCharm(c, "shared-block", charm.Storage{
+		Name:     "data",
+		Type:     charm.StorageBlock,
+		Shared:   true,
+		CountMin: 1,
+		CountMax: 1,
+	})
+	storage := map[string]state.StorageConstraints{
+		"data": makeStorageCons("environscoped-shared", 1024, 1),
+	}
+	_, err := s.State.AddApplication(state.AddApplicationArgs{Name: "shared-block", Charm: ch, Storage: storage})
+	c.Assert(err, gc.ErrorMatches, `.* charm "shared-block" store "data": shared block storage not supported`)
+}
+
+func (s *FilesystemStateSuite) TestRemoveSharedStorageAttachmentDetachesFilesystem(c *gc.C) {
+	service := s.setupSharedFilesystemService(c, "environscoped-shared")
+	u0, m0 := s.addSharedFilesystemUnit(c, service)
+	_, m1 := s.addSharedFilesystemUnit(c, service)
+	storageTag := names.NewStorageTag("data/0")
+	filesystemTag := names.NewFilesystemTag("0")
+
+	err := s.State.DestroyStorageAttachment(storageTag, u0.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.RemoveStorageAttachment(storageTag, u0.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The filesystem is detached from the removed unit's machine only.
+	c.Assert(s.filesystemAttachment(c, m0, filesystemTag).Life(), gc.Equals, state.Dying)
+	c.Assert(s.filesystemAttachment(c, m1, filesystemTag).Life(), gc.Equals, state.Alive)
+	c.Assert(s.filesystem(c, filesystemTag).Life(), gc.Equals, state.Alive)
+}
+
 func (s *FilesystemStateSuite) TestFilesystemBindingMachine(c *gc.C) {
 	// Filesystems created unassigned to a storage instance are
 	// bound to the initially attached machine.
@@ -234,6 +234,47 @@ func (s *InitializeSuite) TestInitializeWithControllerinheritedconfig(c *gc.C) {
 	c.Assert(cfg.AllAttrs(), jc.DeepEquals, initial)
 }
 
+func (s *InitializeSuite) TestInitializeWithBackupStorageS3SecretKey(c *gc.C) {
+	cfg := testing.ModelConfig(c)
+	owner := names.NewLocalUserTag("initialize-admin")
+	controllerCfg := testing.FakeControllerConfig()
+	controllerCfg["controller-uuid"] = cfg.UUID()
+	controllerCfg[controller.BackupStorageS3AccessKey] = "access"
+	controllerCfg[controller.BackupStorageS3SecretKey] = "secret"
+
+	st, err := state.Initialize(state.InitializeParams{
+		ControllerConfig: controllerCfg,
+		ControllerModelArgs: state.ModelArgs{
+			CloudName: "dummy",
+			Owner:     owner,
+			Config:    cfg,
+		},
+		CloudName: "dummy",
+		Cloud: cloud.Cloud{
+			Type:      "dummy",
+			AuthTypes: []cloud.AuthType{cloud.EmptyAuthType},
+		},
+		MongoInfo:     statetesting.NewMongoInfo(),
+		MongoDialOpts: mongotest.DialOpts(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = st.Close()
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.openState(c, names.NewModelTag(cfg.UUID()))
+
+	// The secret key is kept out of the controller config.
+	readCfg, err := s.State.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(readCfg[controller.BackupStorageS3AccessKey], gc.Equals, "access")
+	_, ok := readCfg[controller.BackupStorageS3SecretKey]
+	c.Assert(ok, jc.IsFalse)
+
+	secretKey, err := s.State.BackupStorageS3SecretKey()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(secretKey, gc.Equals, "secret")
+}
+
 func (s *InitializeSuite) TestDoubleInitializeConfig(c *gc.C) {
 	cfg := testing.ModelConfig(c)
 	owner := names.NewLocalUserTag("initialize-admin")
@@ -30,6 +30,7 @@ const (
 	logsDB     = "logs"
 	logsC      = "logs"
 	forwardedC = "forwarded"
+	prunedC    = "pruned"
 )
 
 // ErrNeverForwarded signals to the caller that the ID of a
@@ -62,7 +63,9 @@ type ModelSessioner interface {
 // be called as state is opened. It is idempotent.
 func InitDbLogs(session *mgo.Session) error {
 	logsColl := session.DB(logsDB).C(logsC)
-	for _, key := range [][]string{{"e", "t"}, {"e", "n"}} {
+	// The index on time alone serves queries over a time window
+	// across all models, and log pruning.
+	for _, key := range [][]string{{"e", "t"}, {"e", "n"}, {"t"}} {
 		err := logsColl.EnsureIndex(mgo.Index{Key: key})
 		if err != nil {
 			return errors.Annotate(err, "cannot create index for logs collection")
@@ -284,8 +287,11 @@ type LogRecord struct {
 // LogTailerParams specifies the filtering a LogTailer should apply to
 // logs in order to decide which to return.
 type LogTailerParams struct {
-	StartID       int64
-	StartTime     time.Time
+	StartID   int64
+	StartTime time.Time
+	// EndTime, if set, is the time of the last logs to return. The
+	// LogTailer stops once all logs up to then have been returned.
+	EndTime       time.Time
 	MinLevel      loggo.Level
 	InitialLines  int
 	NoTail        bool
@@ -293,8 +299,11 @@ type LogTailerParams struct {
 	ExcludeEntity []string
 	IncludeModule []string
 	ExcludeModule []string
-	Oplog         *mgo.Collection // For testing only
-	AllModels     bool
+	// MessagePattern is a regular expression which, if set, the
+	// message of each returned log must match.
+	MessagePattern string
+	Oplog          *mgo.Collection // For testing only
+	AllModels      bool
 }
 
 // oplogOverlap is used to decide on the initial oplog timestamp to
@@ -329,6 +338,11 @@ func NewLogTailer(st LogTailerState, params *LogTailerParams) (LogTailer, error)
 	if !st.IsController() && params.AllModels {
 		return nil, errors.NewNotValid(nil, "not allowed to tail logs from all models: not a controller")
 	}
+	if params.MessagePattern != "" {
+		if _, err := regexp.Compile(params.MessagePattern); err != nil {
+			return nil, errors.NewNotValid(err, "invalid message pattern")
+		}
+	}
 
 	session := st.MongoSession().Copy()
 	t := &logTailer{
@@ -391,6 +405,10 @@ func (t *logTailer) loop() error {
 	if t.params.NoTail {
 		return nil
 	}
+	if !t.params.EndTime.IsZero() && !t.params.EndTime.After(time.Now()) {
+		// All the logs that will be returned have been.
+		return nil
+	}
 
 	err = t.tailOplog()
 	return errors.Trace(err)
@@ -466,11 +484,19 @@ func (t *logTailer) tailOplog() error {
 	logger.Tracef("LogTailer starting oplog tailing: recent id count=%d, lastTime=%s, minOplogTs=%s",
 		recentIds.Length(), t.lastTime, minOplogTs)
 
+	var endReached <-chan time.Time
+	if !t.params.EndTime.IsZero() {
+		endReached = time.After(t.params.EndTime.Sub(time.Now()))
+	}
+
 	skipCount := 0
 	for {
 		select {
 		case <-t.tomb.Dying():
 			return errors.Trace(tomb.ErrDying)
+		case <-endReached:
+			logger.Tracef("LogTailer end time %s reached", t.params.EndTime)
+			return nil
 		case oplogDoc, ok := <-oplogTailer.Out():
 			if !ok {
 				return errors.Annotate(oplogTailer.Err(), "oplog tailer died")
@@ -504,13 +530,16 @@ func (t *logTailer) tailOplog() error {
 }
 
 func (t *logTailer) paramsToSelector(params *LogTailerParams, prefix string) bson.D {
-	sel := bson.D{
-		// "t" -> "_id" once it is a sequential int.
-		{"t", bson.M{"$gte": params.StartID}},
+	// "t" -> "_id" once it is a sequential int.
+	start := params.StartID
+	if !params.StartTime.IsZero() && params.StartTime.UnixNano() > start {
+		start = params.StartTime.UnixNano()
 	}
-	if !params.StartTime.IsZero() {
-		sel = append(sel, bson.DocElem{"t", bson.M{"$gte": params.StartTime.UnixNano()}})
+	timeSel := bson.M{"$gte": start}
+	if !params.EndTime.IsZero() {
+		timeSel["$lte"] = params.EndTime.UnixNano()
 	}
+	sel := bson.D{{"t", timeSel}}
 	if !params.AllModels {
 		sel = append(sel, bson.DocElem{"e", t.modelUUID})
 	}
@@ -533,6 +562,9 @@ func (t *logTailer) paramsToSelector(params *LogTailerParams, prefix string) bso
 		sel = append(sel,
 			bson.DocElem{"m", bson.M{"$not": bson.RegEx{Pattern: makeModulePattern(params.ExcludeModule)}}})
 	}
+	if params.MessagePattern != "" {
+		sel = append(sel, bson.DocElem{"x", bson.RegEx{Pattern: params.MessagePattern}})
+	}
 	if prefix != "" {
 		for i, elem := range sel {
 			sel[i].Name = prefix + elem.Name
@@ -643,39 +675,127 @@ func logDocToRecord(doc *logDoc) (*LogRecord, error) {
 	return rec, nil
 }
 
+// LogRetention specifies which of a model's log records are old
+// enough to be removed by PruneLogs.
+type LogRetention struct {
+	// MinLogTime is the time before which the model's records are
+	// removed, unless overridden for their level. If it is zero, the
+	// minimum log time passed to PruneLogs applies.
+	MinLogTime time.Time
+
+	// MinLogTimeByLevel holds the time before which the model's
+	// records of particular levels are removed.
+	MinLogTimeByLevel map[loggo.Level]time.Time
+}
+
+// minLogTime returns the time before which the model's records are
+// removed, unless their level has its own retention.
+func (r LogRetention) minLogTime(defaultTime time.Time) time.Time {
+	if !r.MinLogTime.IsZero() {
+		return r.MinLogTime
+	}
+	return defaultTime
+}
+
+// LogPruneStats holds the numbers of log records removed by PruneLogs.
+type LogPruneStats struct {
+	// Expired holds the number of records of each model, by level,
+	// that were removed for being older than their retention allows.
+	// Records removed under the model-wide retention, rather than
+	// the retention of their level, are counted under
+	// loggo.UNSPECIFIED.
+	Expired map[string]map[loggo.Level]int
+
+	// Oversize holds the number of records of each model that were
+	// removed to bring the logs collection within its maximum size.
+	Oversize map[string]int
+}
+
+func (s *LogPruneStats) addExpired(modelUUID string, level loggo.Level, count int) {
+	if count == 0 {
+		return
+	}
+	if s.Expired[modelUUID] == nil {
+		s.Expired[modelUUID] = make(map[loggo.Level]int)
+	}
+	s.Expired[modelUUID][level] += count
+}
+
+// Removed returns the number of the model's records that were removed.
+func (s *LogPruneStats) Removed(modelUUID string) int {
+	removed := s.Oversize[modelUUID]
+	for _, count := range s.Expired[modelUUID] {
+		removed += count
+	}
+	return removed
+}
+
+func pruneLogsByTime(logsColl *mgo.Collection, query bson.M) (int, error) {
+	removeInfo, err := logsColl.RemoveAll(query)
+	if err != nil {
+		return 0, errors.Annotate(err, "failed to prune logs by time")
+	}
+	return removeInfo.Removed, nil
+}
+
 // PruneLogs removes old log documents in order to control the size of
-// logs collection. All logs older than minLogTime are
-// removed. Further removal is also performed if the logs collection
-// size is greater than maxLogsMB.
-func PruneLogs(st MongoSessioner, minLogTime time.Time, maxLogsMB int) error {
+// logs collection. All logs older than minLogTime are removed, unless
+// the retention of their model (keyed by model UUID) says otherwise.
+// Further removal is also performed if the logs collection size is
+// greater than maxLogsMB. The numbers of records removed are returned.
+func PruneLogs(st MongoSessioner, minLogTime time.Time, maxLogsMB int, retention map[string]LogRetention) (*LogPruneStats, error) {
 	session, logsColl := initLogsSession(st)
 	defer session.Close()
 
 	modelUUIDs, err := getEnvsInLogs(logsColl)
 	if err != nil {
-		return errors.Annotate(err, "failed to get log counts")
+		return nil, errors.Annotate(err, "failed to get log counts")
 	}
 
-	pruneCounts := make(map[string]int)
+	stats := &LogPruneStats{
+		Expired:  make(map[string]map[loggo.Level]int),
+		Oversize: make(map[string]int),
+	}
 
 	// Remove old log entries (per model UUID to take advantage
-	// of indexes on the logs collection).
+	// of indexes on the logs collection). Levels with their own
+	// retention are pruned one at a time; the rest are pruned
+	// together, so a model without level retention needs a single
+	// query.
 	for _, modelUUID := range modelUUIDs {
-		removeInfo, err := logsColl.RemoveAll(bson.M{
+		modelRetention := retention[modelUUID]
+		var overridden []int
+		for level, minTime := range modelRetention.MinLogTimeByLevel {
+			overridden = append(overridden, int(level))
+			removed, err := pruneLogsByTime(logsColl, bson.M{
+				"e": modelUUID,
+				"t": bson.M{"$lt": minTime.UnixNano()},
+				"v": int(level),
+			})
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			stats.addExpired(modelUUID, level, removed)
+		}
+		query := bson.M{
 			"e": modelUUID,
-			"t": bson.M{"$lt": minLogTime.UnixNano()},
-		})
+			"t": bson.M{"$lt": modelRetention.minLogTime(minLogTime).UnixNano()},
+		}
+		if len(overridden) > 0 {
+			query["v"] = bson.M{"$nin": overridden}
+		}
+		removed, err := pruneLogsByTime(logsColl, query)
 		if err != nil {
-			return errors.Annotate(err, "failed to prune logs by time")
+			return nil, errors.Trace(err)
 		}
-		pruneCounts[modelUUID] = removeInfo.Removed
+		stats.addExpired(modelUUID, loggo.UNSPECIFIED, removed)
 	}
 
 	// Do further pruning if the logs collection is over the maximum size.
 	for {
 		collMB, err := getCollectionMB(logsColl)
 		if err != nil {
-			return errors.Annotate(err, "failed to retrieve log counts")
+			return nil, errors.Annotate(err, "failed to retrieve log counts")
 		}
 		if collMB <= maxLogsMB {
 			break
@@ -683,7 +803,7 @@ func PruneLogs(st MongoSessioner, minLogTime time.Time, maxLogsMB int) error {
 
 		modelUUID, count, err := findEnvWithMostLogs(logsColl, modelUUIDs)
 		if err != nil {
-			return errors.Annotate(err, "log count query failed")
+			return nil, errors.Annotate(err, "log count query failed")
 		}
 		if count < 5000 {
 			break // Pruning is not worthwhile
@@ -702,7 +822,7 @@ func PruneLogs(st MongoSessioner, minLogTime time.Time, maxLogsMB int) error {
 		var doc bson.M
 		err = tsQuery.One(&doc)
 		if err != nil {
-			return errors.Annotate(err, "log pruning timestamp query failed")
+			return nil, errors.Annotate(err, "log pruning timestamp query failed")
 		}
 		thresholdTs := doc["t"]
 
@@ -712,19 +832,116 @@ func PruneLogs(st MongoSessioner, minLogTime time.Time, maxLogsMB int) error {
 			"t": bson.M{"$lt": thresholdTs},
 		})
 		if err != nil {
-			return errors.Annotate(err, "log pruning failed")
+			return nil, errors.Annotate(err, "log pruning failed")
 		}
-		pruneCounts[modelUUID] += removeInfo.Removed
+		stats.Oversize[modelUUID] += removeInfo.Removed
 	}
 
-	for modelUUID, count := range pruneCounts {
-		if count > 0 {
+	for _, modelUUID := range modelUUIDs {
+		if count := stats.Removed(modelUUID); count > 0 {
 			logger.Debugf("pruned %d logs for model %s", count, modelUUID)
 		}
 	}
+	return stats, nil
+}
+
+// LogPrune describes the last pruning of a model's logs.
+type LogPrune struct {
+	// Time is when the logs were pruned.
+	Time time.Time
+
+	// Expired holds the number of records, by level, that were
+	// removed for being older than the model's retention allows, as
+	// in LogPruneStats.
+	Expired map[loggo.Level]int
+
+	// Oversize holds the number of records that were removed to
+	// bring the logs collection within its maximum size.
+	Oversize int
+}
+
+// Removed returns the number of records that were removed.
+func (p *LogPrune) Removed() int {
+	removed := p.Oversize
+	for _, count := range p.Expired {
+		removed += count
+	}
+	return removed
+}
+
+// logPruneDoc records the last pruning of a model's logs.
+type logPruneDoc struct {
+	ModelUUID string `bson:"_id"`
+	Time      int64  `bson:"time"`
+
+	// Expired is keyed by the name of the level.
+	Expired  map[string]int `bson:"expired"`
+	Oversize int            `bson:"oversize"`
+}
+
+// RecordLogPrune records, for each model whose logs were pruned at the
+// given time, how many records were removed, so that it may be
+// reported by LastLogPrune.
+func RecordLogPrune(st MongoSessioner, when time.Time, stats *LogPruneStats) error {
+	session, logsColl := initLogsSession(st)
+	defer session.Close()
+	prunedColl := logsColl.Database.C(prunedC)
+
+	modelUUIDs := set.NewStrings()
+	for modelUUID := range stats.Expired {
+		modelUUIDs.Add(modelUUID)
+	}
+	for modelUUID := range stats.Oversize {
+		modelUUIDs.Add(modelUUID)
+	}
+	for _, modelUUID := range modelUUIDs.Values() {
+		if stats.Removed(modelUUID) == 0 {
+			continue
+		}
+		doc := logPruneDoc{
+			ModelUUID: modelUUID,
+			Time:      when.UnixNano(),
+			Expired:   make(map[string]int),
+			Oversize:  stats.Oversize[modelUUID],
+		}
+		for level, count := range stats.Expired[modelUUID] {
+			doc.Expired[level.String()] = count
+		}
+		if _, err := prunedColl.UpsertId(modelUUID, doc); err != nil {
+			return errors.Annotatef(err, "cannot record log pruning for model %s", modelUUID)
+		}
+	}
 	return nil
 }
 
+// LastLogPrune returns the last pruning of the model's logs that
+// removed any records. It returns a NotFound error if none has.
+func (st *State) LastLogPrune() (*LogPrune, error) {
+	session, logsColl := initLogsSession(st)
+	defer session.Close()
+
+	var doc logPruneDoc
+	err := logsColl.Database.C(prunedC).FindId(st.ModelUUID()).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("log pruning for model %s", st.ModelUUID())
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	prune := &LogPrune{
+		Time:     time.Unix(0, doc.Time).UTC(),
+		Expired:  make(map[loggo.Level]int),
+		Oversize: doc.Oversize,
+	}
+	for name, count := range doc.Expired {
+		level, ok := loggo.ParseLevel(name)
+		if !ok {
+			return nil, errors.Errorf("invalid log level %q", name)
+		}
+		prune.Expired[level] = count
+	}
+	return prune, nil
+}
+
 // initLogsSession creates a new session suitable for logging updates,
 // returning the session and a logs mgo.Collection connected to that
 // session.
@@ -9,6 +9,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/version"
@@ -147,6 +148,7 @@ func (s *LogsSuite) TestIndexesCreated(c *gc.C) {
 		"_id", // default index
 		"e-t", // model-uuid and timestamp
 		"e-n", // model-uuid and entity
+		"t",   // timestamp
 	})
 }
 
@@ -197,8 +199,12 @@ func (s *LogsSuite) TestPruneLogsByTime(c *gc.C) {
 	log(maxLogTime.Add(-(2 * time.Second)), "prune")
 
 	noPruneMB := 100
-	err := state.PruneLogs(s.State, maxLogTime, noPruneMB)
+	stats, err := state.PruneLogs(s.State, maxLogTime, noPruneMB, nil)
 	c.Assert(err, jc.ErrorIsNil)
+	c.Check(stats.Expired, jc.DeepEquals, map[string]map[loggo.Level]int{
+		s.State.ModelUUID(): {loggo.UNSPECIFIED: 2},
+	})
+	c.Check(stats.Oversize, gc.HasLen, 0)
 
 	// After pruning there should just be 3 "keep" messages left.
 	var docs []bson.M
@@ -231,8 +237,12 @@ func (s *LogsSuite) TestPruneLogsBySize(c *gc.C) {
 
 	// Prune logs collection back to 1 MiB.
 	tsNoPrune := time.Now().Add(-3 * 24 * time.Hour)
-	err := state.PruneLogs(s.State, tsNoPrune, 1)
+	stats, err := state.PruneLogs(s.State, tsNoPrune, 1, nil)
 	c.Assert(err, jc.ErrorIsNil)
+	c.Check(stats.Expired, gc.HasLen, 0)
+	c.Check(stats.Removed(s0.ModelUUID()), gc.Equals, 0)
+	c.Check(stats.Removed(s1.ModelUUID()), gc.Equals, startingLogsS1-s.countLogs(c, s1))
+	c.Check(stats.Removed(s2.ModelUUID()), gc.Equals, startingLogsS2-s.countLogs(c, s2))
 
 	// Logs for first env should not be touched.
 	c.Assert(s.countLogs(c, s0), gc.Equals, startingLogsS0)
@@ -256,6 +266,99 @@ func (s *LogsSuite) TestPruneLogsBySize(c *gc.C) {
 	assertLatestTs(s2)
 }
 
+func (s *LogsSuite) TestPruneLogsByRetention(c *gc.C) {
+	other := s.Factory.MakeModel(c, nil)
+	defer other.Close()
+	log := func(st *state.State, t time.Time, level loggo.Level, msg string) {
+		dbLogger := state.NewDbLogger(st, names.NewMachineTag("22"), jujuversion.Current)
+		defer dbLogger.Close()
+		err := dbLogger.Log(t, "module", "loc", level, msg)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	now := time.Now()
+	hourAgo := now.Add(-time.Hour)
+	dayAgo := now.Add(-24 * time.Hour)
+	log(s.State, dayAgo, loggo.ERROR, "keep")
+	log(s.State, dayAgo, loggo.INFO, "prune")
+	log(s.State, hourAgo, loggo.INFO, "keep")
+	log(s.State, hourAgo, loggo.DEBUG, "prune")
+	log(other, dayAgo, loggo.INFO, "keep")
+
+	retention := map[string]state.LogRetention{
+		s.State.ModelUUID(): {
+			MinLogTime: now.Add(-2 * time.Hour),
+			MinLogTimeByLevel: map[loggo.Level]time.Time{
+				loggo.ERROR: now.Add(-48 * time.Hour),
+				loggo.DEBUG: now.Add(-time.Minute),
+			},
+		},
+	}
+	noPruneMB := 100
+	stats, err := state.PruneLogs(s.State, now.Add(-48*time.Hour), noPruneMB, retention)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(stats.Expired, jc.DeepEquals, map[string]map[loggo.Level]int{
+		s.State.ModelUUID(): {loggo.UNSPECIFIED: 1, loggo.DEBUG: 1},
+	})
+	c.Check(stats.Removed(s.State.ModelUUID()), gc.Equals, 2)
+	c.Check(stats.Removed(other.ModelUUID()), gc.Equals, 0)
+	var docs []bson.M
+	err = s.logsColl.Find(nil).All(&docs)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(docs, gc.HasLen, 3)
+	for _, doc := range docs {
+		c.Check(doc["x"], gc.Equals, "keep")
+	}
+}
+
+func (s *LogsSuite) TestRecordLogPrune(c *gc.C) {
+	other := s.Factory.MakeModel(c, nil)
+	defer other.Close()
+
+	_, err := s.State.LastLogPrune()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	when := time.Date(2016, 8, 1, 12, 0, 0, 0, time.UTC)
+	err = state.RecordLogPrune(s.State, when, &state.LogPruneStats{
+		Expired: map[string]map[loggo.Level]int{
+			s.State.ModelUUID(): {loggo.INFO: 3, loggo.DEBUG: 2},
+		},
+		Oversize: map[string]int{
+			s.State.ModelUUID(): 5,
+			other.ModelUUID():   0,
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	prune, err := s.State.LastLogPrune()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(prune, jc.DeepEquals, &state.LogPrune{
+		Time:     when,
+		Expired:  map[loggo.Level]int{loggo.INFO: 3, loggo.DEBUG: 2},
+		Oversize: 5,
+	})
+	c.Check(prune.Removed(), gc.Equals, 10)
+
+	// Nothing was removed from the other model's logs.
+	_, err = other.LastLogPrune()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	// A later pruning replaces the earlier one.
+	later := when.Add(time.Hour)
+	err = state.RecordLogPrune(s.State, later, &state.LogPruneStats{
+		Oversize: map[string]int{s.State.ModelUUID(): 1},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	prune, err = s.State.LastLogPrune()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(prune, jc.DeepEquals, &state.LogPrune{
+		Time:     later,
+		Expired:  map[loggo.Level]int{},
+		Oversize: 1,
+	})
+}
+
 func (s *LogsSuite) generateLogs(c *gc.C, st *state.State, endTime time.Time, count int) {
 	dbLogger := state.NewDbLogger(st, names.NewMachineTag("0"), jujuversion.Current)
 	defer dbLogger.Close()
@@ -330,6 +433,47 @@ func (s *LogTailerSuite) TestTimeFiltering(c *gc.C) {
 
 }
 
+func (s *LogTailerSuite) TestEndTimeFiltering(c *gc.C) {
+	threshT := time.Now().Add(-time.Minute)
+	want := logTemplate{Message: "want"}
+	s.writeLogsT(c, threshT.Add(-5*time.Second), threshT, 5, want)
+	s.writeLogsT(c,
+		threshT.Add(time.Millisecond), threshT.Add(5*time.Second), 5,
+		logTemplate{Message: "dont want"},
+	)
+
+	tailer, err := state.NewLogTailer(s.otherState, &state.LogTailerParams{
+		StartTime: threshT.Add(-3 * time.Second),
+		EndTime:   threshT,
+		Oplog:     s.oplogColl,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer tailer.Stop()
+
+	// The end time has passed, so the tailer stops itself once the
+	// logs collection has been read.
+	s.assertTailer(c, tailer, 3, want)
+	s.assertTailerStops(c, tailer)
+}
+
+func (s *LogTailerSuite) TestEndTimeTailing(c *gc.C) {
+	endT := time.Now().Add(time.Second)
+	tailer, err := state.NewLogTailer(s.otherState, &state.LogTailerParams{
+		EndTime: endT,
+		Oplog:   s.oplogColl,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer tailer.Stop()
+
+	// Logs up to the end time are read from the oplog; the tailer
+	// stops itself once the end time is reached.
+	want := logTemplate{Message: "want"}
+	s.writeLogs(c, 2, want)
+	s.writeLogsT(c, endT.Add(time.Second), endT.Add(time.Second), 1, logTemplate{Message: "dont want"})
+	s.assertTailer(c, tailer, 2, want)
+	s.assertTailerStops(c, tailer)
+}
+
 func (s *LogTailerSuite) TestOplogTransition(c *gc.C) {
 	// Ensure that logs aren't repeated as the log tailer moves from
 	// reading from the logs collection to tailing the oplog.
@@ -716,6 +860,30 @@ func (s *LogTailerSuite) TestIncludeExcludeModule(c *gc.C) {
 	s.checkLogTailerFiltering(c, s.otherState, params, writeLogs, assert)
 }
 
+func (s *LogTailerSuite) TestMessagePattern(c *gc.C) {
+	started := logTemplate{Message: "hook \"install\" started"}
+	failed := logTemplate{Message: "hook \"install\" failed: exit status 1"}
+	other := logTemplate{Message: "connection established"}
+	writeLogs := func() {
+		s.writeLogs(c, 1, started)
+		s.writeLogs(c, 1, other)
+		s.writeLogs(c, 1, failed)
+	}
+	params := &state.LogTailerParams{
+		MessagePattern: `^hook ".*" (failed|errored)`,
+	}
+	assert := func(tailer state.LogTailer) {
+		s.assertTailer(c, tailer, 1, failed)
+	}
+	s.checkLogTailerFiltering(c, s.otherState, params, writeLogs, assert)
+}
+
+func (s *LogTailerSuite) TestInvalidMessagePattern(c *gc.C) {
+	_, err := state.NewLogTailer(s.otherState, &state.LogTailerParams{MessagePattern: "(foo"})
+	c.Assert(err, gc.ErrorMatches, "invalid message pattern: .*")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
 func (s *LogTailerSuite) checkLogTailerFiltering(
 	c *gc.C,
 	st *state.State,
@@ -821,6 +989,20 @@ func (s *LogTailerSuite) logTemplateToDoc(lt logTemplate, t time.Time) interface
 	)
 }
 
+// assertTailerStops checks that the tailer stops itself without
+// returning any further logs.
+func (s *LogTailerSuite) assertTailerStops(c *gc.C, tailer state.LogTailer) {
+	select {
+	case _, ok := <-tailer.Logs():
+		if ok {
+			c.Fatal("shouldn't be any further logs")
+		}
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for logs channel to close")
+	}
+	c.Assert(tailer.Err(), jc.ErrorIsNil)
+}
+
 func (s *LogTailerSuite) assertTailer(c *gc.C, tailer state.LogTailer, expectedCount int, lt logTemplate) {
 	s.normaliseLogTemplate(&lt)
 
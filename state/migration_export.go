@@ -4,16 +4,23 @@
 package state
 
 import (
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/utils/set"
+	"gopkg.in/juju/charm.v6-unstable"
+	charmresource "gopkg.in/juju/charm.v6-unstable/resource"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/mgo.v2/bson"
 
 	"github.com/juju/juju/core/description"
+	"github.com/juju/juju/payload"
+	"github.com/juju/juju/resource"
+	"github.com/juju/juju/state/cloudimagemetadata"
+	"github.com/juju/juju/storage/poolmanager"
 )
 
 // Export the current model for the State.
@@ -57,6 +64,7 @@ func (st *State) Export() (description.Model, error) {
 	args := description.ModelArgs{
 		Cloud:              dbModel.Cloud(),
 		CloudRegion:        dbModel.CloudRegion(),
+		CloudCredential:    dbModel.CloudCredential(),
 		Owner:              dbModel.Owner(),
 		Config:             modelConfig.Settings,
 		LatestToolsVersion: dbModel.LatestToolsVersion(),
@@ -86,6 +94,30 @@ func (st *State) Export() (description.Model, error) {
 	if err := export.relations(); err != nil {
 		return nil, errors.Trace(err)
 	}
+	if err := export.storage(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := export.spaces(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := export.subnets(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := export.linkLayerDevices(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := export.ipAddresses(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := export.actions(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := export.sshHostKeys(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := export.cloudImageMetadata(); err != nil {
+		return nil, errors.Trace(err)
+	}
 
 	if err := export.model.Validate(); err != nil {
 		return nil, errors.Trace(err)
@@ -110,6 +142,9 @@ type exporter struct {
 	// Map of application name to units. Populated as part
 	// of the applications export.
 	units map[string][]*Unit
+	// Map of unit name to payloads. Populated as part of the
+	// applications export.
+	payloads map[string][]payload.FullPayloadInfo
 }
 
 func (e *exporter) sequences() error {
@@ -413,6 +448,11 @@ func (e *exporter) applications() error {
 		return errors.Trace(err)
 	}
 
+	e.payloads, err = e.readAllPayloads()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
 	for _, application := range applications {
 		applicationUnits := e.units[application.Name()]
 		leader := leaders[application.Name()]
@@ -423,6 +463,21 @@ func (e *exporter) applications() error {
 	return nil
 }
 
+func (e *exporter) storageConstraintsArgs(cons map[string]StorageConstraints) map[string]description.StorageConstraintArgs {
+	if len(cons) == 0 {
+		return nil
+	}
+	result := make(map[string]description.StorageConstraintArgs, len(cons))
+	for name, value := range cons {
+		result[name] = description.StorageConstraintArgs{
+			Pool:  value.Pool,
+			Size:  value.Size,
+			Count: value.Count,
+		}
+	}
+	return result
+}
+
 func (e *exporter) readApplicationLeaders() (map[string]string, error) {
 	client, err := e.st.getLeadershipLeaseClient()
 	if err != nil {
@@ -453,6 +508,16 @@ func (e *exporter) addApplication(application *Application, refcounts map[string
 		return errors.Errorf("missing leadership settings for application %q", application.Name())
 	}
 
+	bindings, err := application.EndpointBindings()
+	if err != nil {
+		return errors.Annotatef(err, "endpoint bindings for application %q", application.Name())
+	}
+
+	storageConstraints, err := readStorageConstraints(e.st, application.globalKey())
+	if err != nil {
+		return errors.Annotatef(err, "storage constraints for application %q", application.Name())
+	}
+
 	args := description.ApplicationArgs{
 		Tag:                  application.ApplicationTag(),
 		Series:               application.doc.Series,
@@ -468,6 +533,8 @@ func (e *exporter) addApplication(application *Application, refcounts map[string
 		Leader:               leader,
 		LeadershipSettings:   leadershipSettingsDoc.Settings,
 		MetricsCredentials:   application.doc.MetricCredentials,
+		EndpointBindings:     bindings,
+		StorageConstraints:   e.storageConstraintsArgs(storageConstraints),
 	}
 	exApplication := e.model.AddApplication(args)
 	// Find the current application status.
@@ -486,6 +553,16 @@ func (e *exporter) addApplication(application *Application, refcounts map[string
 	}
 	exApplication.SetConstraints(constraintsArgs)
 
+	resources, err := NewResourcePersistence(e.st.newPersistence()).ListResources(application.Name())
+	if err != nil {
+		return errors.Annotatef(err, "resources for application %q", application.Name())
+	}
+	e.addResources(exApplication, resources)
+	unitResources := make(map[string][]resource.Resource)
+	for _, ur := range resources.UnitResources {
+		unitResources[ur.Tag.Id()] = ur.Resources
+	}
+
 	for _, unit := range units {
 		agentKey := unit.globalAgentKey()
 		unitMeterStatus, found := meterStatus[agentKey]
@@ -552,11 +629,57 @@ func (e *exporter) addApplication(application *Application, refcounts map[string
 			return errors.Trace(err)
 		}
 		exUnit.SetConstraints(constraintsArgs)
+
+		for _, res := range unitResources[unit.Name()] {
+			exUnit.AddResource(description.UnitResourceArgs{
+				Name:     res.Name,
+				Revision: e.resourceRevisionArgs(res.Resource, res.Username, res.Timestamp),
+			})
+		}
+		for _, p := range e.payloads[unit.Name()] {
+			exUnit.AddPayload(description.PayloadArgs{
+				Name:   p.Name,
+				Type:   p.Type,
+				RawID:  p.ID,
+				State:  p.Status,
+				Labels: p.Labels,
+			})
+		}
 	}
 
 	return nil
 }
 
+func (e *exporter) addResources(exApplication description.Application, resources resource.ServiceResources) {
+	storeResources := make(map[string]charmresource.Resource)
+	for _, res := range resources.CharmStoreResources {
+		storeResources[res.Name] = res
+	}
+	for _, res := range resources.Resources {
+		exResource := exApplication.AddResource(description.ResourceArgs{
+			Name: res.Name,
+		})
+		exResource.SetApplicationRevision(e.resourceRevisionArgs(res.Resource, res.Username, res.Timestamp))
+		if storeRes, found := storeResources[res.Name]; found {
+			exResource.SetCharmStoreRevision(e.resourceRevisionArgs(storeRes, "", time.Time{}))
+		}
+	}
+}
+
+func (e *exporter) resourceRevisionArgs(res charmresource.Resource, username string, timestamp time.Time) description.ResourceRevisionArgs {
+	return description.ResourceRevisionArgs{
+		Revision:       res.Revision,
+		Type:           res.Type.String(),
+		Path:           res.Path,
+		Description:    res.Description,
+		Origin:         res.Origin.String(),
+		FingerprintHex: res.Fingerprint.String(),
+		Size:           res.Size,
+		Timestamp:      timestamp,
+		Username:       username,
+	}
+}
+
 func (e *exporter) relations() error {
 	rels, err := e.st.AllRelations()
 	if err != nil {
@@ -607,6 +730,492 @@ func (e *exporter) relations() error {
 	return nil
 }
 
+func (e *exporter) spaces() error {
+	coll, closer := e.st.getCollection(spacesC)
+	defer closer()
+
+	var docs []spaceDoc
+	if err := coll.Find(nil).Sort("name").All(&docs); err != nil {
+		return errors.Annotate(err, "cannot get all spaces")
+	}
+	e.logger.Debugf("found %d spaces", len(docs))
+
+	for _, doc := range docs {
+		e.model.AddSpace(description.SpaceArgs{
+			Name:       doc.Name,
+			Public:     doc.IsPublic,
+			ProviderID: doc.ProviderId,
+		})
+	}
+	return nil
+}
+
+func (e *exporter) subnets() error {
+	coll, closer := e.st.getCollection(subnetsC)
+	defer closer()
+
+	var docs []subnetDoc
+	if err := coll.Find(nil).Sort("cidr").All(&docs); err != nil {
+		return errors.Annotate(err, "cannot get all subnets")
+	}
+	e.logger.Debugf("found %d subnets", len(docs))
+
+	for _, doc := range docs {
+		e.model.AddSubnet(description.SubnetArgs{
+			CIDR:             doc.CIDR,
+			ProviderID:       doc.ProviderId,
+			VLANTag:          doc.VLANTag,
+			AvailabilityZone: doc.AvailabilityZone,
+			SpaceName:        doc.SpaceName,
+		})
+	}
+	return nil
+}
+
+func (e *exporter) linkLayerDevices() error {
+	coll, closer := e.st.getCollection(linkLayerDevicesC)
+	defer closer()
+
+	var docs []linkLayerDeviceDoc
+	if err := coll.Find(nil).Sort("_id").All(&docs); err != nil {
+		return errors.Annotate(err, "cannot get all link-layer devices")
+	}
+	e.logger.Debugf("found %d link-layer devices", len(docs))
+
+	for _, doc := range docs {
+		device := newLinkLayerDevice(e.st, doc)
+		e.model.AddLinkLayerDevice(description.LinkLayerDeviceArgs{
+			Name:        device.Name(),
+			MTU:         device.MTU(),
+			ProviderID:  string(device.ProviderID()),
+			MachineID:   device.MachineID(),
+			Type:        string(device.Type()),
+			MACAddress:  device.MACAddress(),
+			IsAutoStart: device.IsAutoStart(),
+			IsUp:        device.IsUp(),
+			ParentName:  device.ParentName(),
+		})
+	}
+	return nil
+}
+
+func (e *exporter) ipAddresses() error {
+	coll, closer := e.st.getCollection(ipAddressesC)
+	defer closer()
+
+	var docs []ipAddressDoc
+	if err := coll.Find(nil).Sort("_id").All(&docs); err != nil {
+		return errors.Annotate(err, "cannot get all ip addresses")
+	}
+	e.logger.Debugf("found %d ip addresses", len(docs))
+
+	for _, doc := range docs {
+		e.model.AddIPAddress(description.IPAddressArgs{
+			ProviderID:       doc.ProviderID,
+			DeviceName:       doc.DeviceName,
+			MachineID:        doc.MachineID,
+			SubnetCIDR:       doc.SubnetCIDR,
+			ConfigMethod:     string(doc.ConfigMethod),
+			Value:            doc.Value,
+			DNSServers:       doc.DNSServers,
+			DNSSearchDomains: doc.DNSSearchDomains,
+			GatewayAddress:   doc.GatewayAddress,
+		})
+	}
+	return nil
+}
+
+func (e *exporter) actions() error {
+	coll, closer := e.st.getCollection(actionsC)
+	defer closer()
+
+	var docs []actionDoc
+	if err := coll.Find(nil).Sort("enqueued", "_id").All(&docs); err != nil {
+		return errors.Annotate(err, "cannot get all actions")
+	}
+	e.logger.Debugf("found %d actions", len(docs))
+
+	for _, doc := range docs {
+		e.model.AddAction(description.ActionArgs{
+			Id:         e.st.localID(doc.DocId),
+			Receiver:   doc.Receiver,
+			Name:       doc.Name,
+			Parameters: doc.Parameters,
+			Enqueued:   doc.Enqueued,
+			Started:    doc.Started,
+			Completed:  doc.Completed,
+			Status:     string(doc.Status),
+			Message:    doc.Message,
+			Results:    doc.Results,
+		})
+	}
+	return nil
+}
+
+func (e *exporter) sshHostKeys() error {
+	coll, closer := e.st.getCollection(sshHostKeysC)
+	defer closer()
+
+	// The sshHostKeysDoc doesn't include the document id, which
+	// holds the machine's global key.
+	var docs []struct {
+		DocID string   `bson:"_id"`
+		Keys  []string `bson:"keys"`
+	}
+	if err := coll.Find(nil).Sort("_id").All(&docs); err != nil {
+		return errors.Annotate(err, "cannot get all ssh host keys")
+	}
+	e.logger.Debugf("found %d ssh host keys", len(docs))
+
+	for _, doc := range docs {
+		key := e.st.localID(doc.DocID)
+		if !strings.HasPrefix(key, machineGlobalKey("")) {
+			// Only machines have SSH host keys at this stage.
+			e.logger.Warningf("ignoring ssh host keys for %q", key)
+			continue
+		}
+		e.model.AddSSHHostKey(description.SSHHostKeyArgs{
+			MachineID: strings.TrimPrefix(key, machineGlobalKey("")),
+			Keys:      doc.Keys,
+		})
+	}
+	return nil
+}
+
+func (e *exporter) cloudImageMetadata() error {
+	metadata, err := e.st.CloudImageMetadataStorage.FindMetadata(cloudimagemetadata.MetadataFilter{})
+	if errors.IsNotFound(err) {
+		e.logger.Debugf("found no cloud image metadata")
+		return nil
+	} else if err != nil {
+		return errors.Annotate(err, "cannot get all cloud image metadata")
+	}
+
+	// The metadata is grouped by source; sort the sources so the
+	// exported order is stable.
+	var sources []string
+	for source := range metadata {
+		sources = append(sources, source)
+	}
+	sort.Strings(sources)
+	for _, source := range sources {
+		e.logger.Debugf("found %d %s cloud image metadata", len(metadata[source]), source)
+		for _, m := range metadata[source] {
+			e.model.AddCloudImageMetadata(description.CloudImageMetadataArgs{
+				Stream:          m.Stream,
+				Region:          m.Region,
+				Version:         m.Version,
+				Series:          m.Series,
+				Arch:            m.Arch,
+				VirtType:        m.VirtType,
+				RootStorageType: m.RootStorageType,
+				RootStorageSize: m.RootStorageSize,
+				Source:          m.Source,
+				Priority:        m.Priority,
+				ImageId:         m.ImageId,
+			})
+		}
+	}
+	return nil
+}
+
+func (e *exporter) storage() error {
+	if err := e.storageInstances(); err != nil {
+		return errors.Annotate(err, "storage instances")
+	}
+	if err := e.volumes(); err != nil {
+		return errors.Annotate(err, "volumes")
+	}
+	if err := e.filesystems(); err != nil {
+		return errors.Annotate(err, "filesystems")
+	}
+	if err := e.storagePools(); err != nil {
+		return errors.Annotate(err, "storage pools")
+	}
+	return nil
+}
+
+func (e *exporter) storageInstances() error {
+	coll, closer := e.st.getCollection(storageInstancesC)
+	defer closer()
+
+	var docs []storageInstanceDoc
+	if err := coll.Find(nil).Sort("_id").All(&docs); err != nil {
+		return errors.Annotate(err, "cannot get all storage instances")
+	}
+	e.logger.Debugf("found %d storage instance docs", len(docs))
+
+	attachments, err := e.readStorageAttachments()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	for _, doc := range docs {
+		instance := &storageInstance{e.st, doc}
+		kind, err := storageKindName(doc.Kind)
+		if err != nil {
+			return errors.Annotatef(err, "storage %q", doc.Id)
+		}
+		var units []names.UnitTag
+		for _, unit := range attachments[doc.Id] {
+			units = append(units, names.NewUnitTag(unit))
+		}
+		args := description.StorageArgs{
+			Tag:         instance.StorageTag(),
+			Kind:        kind,
+			Owner:       instance.Owner(),
+			Name:        doc.StorageName,
+			Attachments: units,
+		}
+		if doc.CharmURL != nil {
+			args.CharmURL = doc.CharmURL.String()
+		}
+		e.model.AddStorage(args)
+	}
+	return nil
+}
+
+// storageKindName returns the name used for the storage kind in the
+// model description. These match the charm storage types.
+func storageKindName(kind StorageKind) (string, error) {
+	switch kind {
+	case StorageKindBlock:
+		return string(charm.StorageBlock), nil
+	case StorageKindFilesystem:
+		return string(charm.StorageFilesystem), nil
+	}
+	return "", errors.Errorf("unknown storage kind %d", kind)
+}
+
+// readStorageAttachments returns a map of storage instance id to the
+// names of the units the storage is attached to.
+func (e *exporter) readStorageAttachments() (map[string][]string, error) {
+	coll, closer := e.st.getCollection(storageAttachmentsC)
+	defer closer()
+
+	var docs []storageAttachmentDoc
+	if err := coll.Find(nil).Sort("_id").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get all storage attachments")
+	}
+	e.logger.Debugf("found %d storage attachment docs", len(docs))
+
+	result := make(map[string][]string)
+	for _, doc := range docs {
+		result[doc.StorageInstance] = append(result[doc.StorageInstance], doc.Unit)
+	}
+	return result, nil
+}
+
+func (e *exporter) volumes() error {
+	coll, closer := e.st.getCollection(volumesC)
+	defer closer()
+
+	var docs []volumeDoc
+	if err := coll.Find(nil).Sort("_id").All(&docs); err != nil {
+		return errors.Annotate(err, "cannot get all volumes")
+	}
+	e.logger.Debugf("found %d volume docs", len(docs))
+
+	attachments, err := e.readVolumeAttachments()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	for _, doc := range docs {
+		if err := e.addVolume(doc, attachments[doc.Name]); err != nil {
+			return errors.Annotatef(err, "volume %q", doc.Name)
+		}
+	}
+	return nil
+}
+
+func (e *exporter) addVolume(doc volumeDoc, attachments []volumeAttachmentDoc) error {
+	args := description.VolumeArgs{
+		Tag: names.NewVolumeTag(doc.Name),
+	}
+	if doc.StorageId != "" {
+		args.Storage = names.NewStorageTag(doc.StorageId)
+	}
+	if doc.Binding != "" {
+		binding, err := names.ParseTag(doc.Binding)
+		if err != nil {
+			return errors.Annotate(err, "parsing binding")
+		}
+		args.Binding = binding
+	}
+	if info := doc.Info; info != nil {
+		args.Provisioned = true
+		args.Size = info.Size
+		args.Pool = info.Pool
+		args.HardwareID = info.HardwareId
+		args.VolumeID = info.VolumeId
+		args.Persistent = info.Persistent
+		args.Encrypted = info.Encrypted
+	} else if params := doc.Params; params != nil {
+		args.Size = params.Size
+		args.Pool = params.Pool
+	}
+	exVolume := e.model.AddVolume(args)
+
+	globalKey := volumeGlobalKey(doc.Name)
+	statusArgs, err := e.statusArgs(globalKey)
+	if err != nil {
+		return errors.Annotate(err, "status")
+	}
+	exVolume.SetStatus(statusArgs)
+	exVolume.SetStatusHistory(e.statusHistoryArgs(globalKey))
+
+	for _, attachment := range attachments {
+		attachmentArgs := description.VolumeAttachmentArgs{
+			Machine: names.NewMachineTag(attachment.Machine),
+		}
+		if info := attachment.Info; info != nil {
+			attachmentArgs.Provisioned = true
+			attachmentArgs.ReadOnly = info.ReadOnly
+			attachmentArgs.DeviceName = info.DeviceName
+			attachmentArgs.DeviceLink = info.DeviceLink
+			attachmentArgs.BusAddress = info.BusAddress
+		} else if params := attachment.Params; params != nil {
+			attachmentArgs.ReadOnly = params.ReadOnly
+		}
+		exVolume.AddAttachment(attachmentArgs)
+	}
+	return nil
+}
+
+// readVolumeAttachments returns a map of volume name to the attachments
+// of that volume.
+func (e *exporter) readVolumeAttachments() (map[string][]volumeAttachmentDoc, error) {
+	coll, closer := e.st.getCollection(volumeAttachmentsC)
+	defer closer()
+
+	var docs []volumeAttachmentDoc
+	if err := coll.Find(nil).Sort("_id").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get all volume attachments")
+	}
+	e.logger.Debugf("found %d volume attachment docs", len(docs))
+
+	result := make(map[string][]volumeAttachmentDoc)
+	for _, doc := range docs {
+		result[doc.Volume] = append(result[doc.Volume], doc)
+	}
+	return result, nil
+}
+
+func (e *exporter) filesystems() error {
+	coll, closer := e.st.getCollection(filesystemsC)
+	defer closer()
+
+	var docs []filesystemDoc
+	if err := coll.Find(nil).Sort("_id").All(&docs); err != nil {
+		return errors.Annotate(err, "cannot get all filesystems")
+	}
+	e.logger.Debugf("found %d filesystem docs", len(docs))
+
+	attachments, err := e.readFilesystemAttachments()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	for _, doc := range docs {
+		if err := e.addFilesystem(doc, attachments[doc.FilesystemId]); err != nil {
+			return errors.Annotatef(err, "filesystem %q", doc.FilesystemId)
+		}
+	}
+	return nil
+}
+
+func (e *exporter) addFilesystem(doc filesystemDoc, attachments []filesystemAttachmentDoc) error {
+	args := description.FilesystemArgs{
+		Tag: names.NewFilesystemTag(doc.FilesystemId),
+	}
+	if doc.StorageId != "" {
+		args.Storage = names.NewStorageTag(doc.StorageId)
+	}
+	if doc.VolumeId != "" {
+		args.Volume = names.NewVolumeTag(doc.VolumeId)
+	}
+	if doc.Binding != "" {
+		binding, err := names.ParseTag(doc.Binding)
+		if err != nil {
+			return errors.Annotate(err, "parsing binding")
+		}
+		args.Binding = binding
+	}
+	if info := doc.Info; info != nil {
+		args.Provisioned = true
+		args.Size = info.Size
+		args.Pool = info.Pool
+		args.FilesystemID = info.FilesystemId
+		args.Encrypted = info.Encrypted
+	} else if params := doc.Params; params != nil {
+		args.Size = params.Size
+		args.Pool = params.Pool
+	}
+	exFilesystem := e.model.AddFilesystem(args)
+
+	globalKey := filesystemGlobalKey(doc.FilesystemId)
+	statusArgs, err := e.statusArgs(globalKey)
+	if err != nil {
+		return errors.Annotate(err, "status")
+	}
+	exFilesystem.SetStatus(statusArgs)
+	exFilesystem.SetStatusHistory(e.statusHistoryArgs(globalKey))
+
+	for _, attachment := range attachments {
+		attachmentArgs := description.FilesystemAttachmentArgs{
+			Machine: names.NewMachineTag(attachment.Machine),
+		}
+		if info := attachment.Info; info != nil {
+			attachmentArgs.Provisioned = true
+			attachmentArgs.MountPoint = info.MountPoint
+			attachmentArgs.ReadOnly = info.ReadOnly
+		} else if params := attachment.Params; params != nil {
+			attachmentArgs.MountPoint = params.Location
+			attachmentArgs.ReadOnly = params.ReadOnly
+		}
+		exFilesystem.AddAttachment(attachmentArgs)
+	}
+	return nil
+}
+
+// readFilesystemAttachments returns a map of filesystem id to the
+// attachments of that filesystem.
+func (e *exporter) readFilesystemAttachments() (map[string][]filesystemAttachmentDoc, error) {
+	coll, closer := e.st.getCollection(filesystemAttachmentsC)
+	defer closer()
+
+	var docs []filesystemAttachmentDoc
+	if err := coll.Find(nil).Sort("_id").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get all filesystem attachments")
+	}
+	e.logger.Debugf("found %d filesystem attachment docs", len(docs))
+
+	result := make(map[string][]filesystemAttachmentDoc)
+	for _, doc := range docs {
+		result[doc.Filesystem] = append(result[doc.Filesystem], doc)
+	}
+	return result, nil
+}
+
+func (e *exporter) storagePools() error {
+	pm := poolmanager.New(NewStateSettings(e.st))
+	pools, err := pm.List()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	e.logger.Debugf("found %d storage pools", len(pools))
+
+	for _, pool := range pools {
+		e.model.AddStoragePool(description.StoragePoolArgs{
+			Name:       pool.Name(),
+			Provider:   string(pool.Provider()),
+			Attributes: pool.Attrs(),
+		})
+	}
+	return nil
+}
+
 func (e *exporter) readAllRelationScopes() (set.Strings, error) {
 	relationScopes, closer := e.st.getCollection(relationScopesC)
 	defer closer()
@@ -643,6 +1252,29 @@ func (e *exporter) readAllUnits() (map[string][]*Unit, error) {
 	return result, nil
 }
 
+// readAllPayloads returns the payloads in the model keyed by unit name.
+func (e *exporter) readAllPayloads() (map[string][]payload.FullPayloadInfo, error) {
+	result := make(map[string][]payload.FullPayloadInfo)
+	if newEnvPayloads == nil {
+		// Without the payloads component nothing can have
+		// registered a payload.
+		e.logger.Debugf("payloads not supported, skipping")
+		return result, nil
+	}
+	envPayloads, err := e.st.EnvPayloads()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	payloads, err := envPayloads.ListAll()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, p := range payloads {
+		result[p.Unit] = append(result[p.Unit], p)
+	}
+	return result, nil
+}
+
 func (e *exporter) readAllMeterStatus() (map[string]*meterStatusDoc, error) {
 	meterStatuses, closer := e.st.getCollection(meterStatusC)
 	defer closer()
@@ -4,18 +4,28 @@
 package state_test
 
 import (
+	"bytes"
+	"io/ioutil"
 	"math/rand"
 	"time"
 
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+	charmresource "gopkg.in/juju/charm.v6-unstable/resource"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/core/description"
+	"github.com/juju/juju/payload"
+	"github.com/juju/juju/resource"
+	"github.com/juju/juju/resource/resourcetesting"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/cloudimagemetadata"
 	"github.com/juju/juju/status"
+	"github.com/juju/juju/storage/poolmanager"
+	"github.com/juju/juju/storage/provider"
 	"github.com/juju/juju/testing/factory"
 )
 
@@ -83,6 +93,176 @@ func (s *MigrationSuite) makeApplicationWithLeader(c *gc.C, applicationname stri
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *MigrationSuite) makeUnitWithStorage(c *gc.C) (*state.Unit, names.MachineTag, names.VolumeTag) {
+	pm := poolmanager.New(state.NewStateSettings(s.State))
+	_, err := pm.Create("loop-pool", provider.LoopProviderType, map[string]interface{}{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	ch := s.AddTestingCharm(c, "storage-block")
+	storage := map[string]state.StorageConstraints{
+		"data": makeStorageCons("loop-pool", 1024, 1),
+	}
+	application := s.AddTestingServiceWithStorage(c, "storage-block", ch, storage)
+	unit, err := application.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AssignUnit(unit, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	machineId, err := unit.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	machineTag := names.NewMachineTag(machineId)
+
+	volume, err := s.State.StorageInstanceVolume(names.NewStorageTag("data/0"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetVolumeInfo(volume.VolumeTag(), state.VolumeInfo{
+		HardwareId: "magic",
+		Size:       1500,
+		VolumeId:   "volume id",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetVolumeAttachmentInfo(machineTag, volume.VolumeTag(), state.VolumeAttachmentInfo{
+		DeviceName: "sdd",
+		ReadOnly:   true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return unit, machineTag, volume.VolumeTag()
+}
+
+// makeNetworking adds a space and subnet, a machine with a bridged
+// device and address, and an application bound to the space.
+func (s *MigrationSuite) makeNetworking(c *gc.C) *state.Machine {
+	_, err := s.State.AddSpace("db", "space-id", nil, true)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSubnet(state.SubnetInfo{
+		CIDR:             "10.0.0.0/24",
+		ProviderId:       "subnet-id",
+		VLANTag:          42,
+		AvailabilityZone: "zone-1",
+		SpaceName:        "db",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	machine := s.Factory.MakeMachine(c, nil)
+	err = machine.SetLinkLayerDevices(state.LinkLayerDeviceArgs{
+		Name:        "br-eth0",
+		Type:        state.BridgeDevice,
+		MACAddress:  "aa:bb:cc:dd:ee:ff",
+		IsAutoStart: true,
+		IsUp:        true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.SetLinkLayerDevices(state.LinkLayerDeviceArgs{
+		Name:        "eth0",
+		MTU:         1500,
+		ProviderID:  "eth0-id",
+		Type:        state.EthernetDevice,
+		MACAddress:  "aa:bb:cc:dd:ee:ff",
+		IsAutoStart: true,
+		IsUp:        true,
+		ParentName:  "br-eth0",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.SetDevicesAddresses(state.LinkLayerDeviceAddress{
+		DeviceName:       "br-eth0",
+		ConfigMethod:     state.StaticAddress,
+		ProviderID:       "address-id",
+		CIDRAddress:      "10.0.0.5/24",
+		DNSServers:       []string{"10.0.0.1"},
+		DNSSearchDomains: []string{"example.com"},
+		GatewayAddress:   "10.0.0.1",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.AddTestingServiceWithBindings(c, "wordpress", s.AddTestingCharm(c, "wordpress"), map[string]string{
+		"db": "db",
+	})
+	return machine
+}
+
+// makeResourcesAndPayloads adds an application with a resource that
+// has been downloaded by its unit, and a payload for that unit.
+func (s *MigrationSuite) makeResourcesAndPayloads(c *gc.C) (*state.Unit, resource.Resource) {
+	unit := addUnit(c, s.ConnSuite, unitArgs{
+		charm:    "dummy",
+		service:  "a-application",
+		metadata: payloadsMetaYAML,
+		machine:  "0",
+	})
+
+	resources, err := s.State.Resources()
+	c.Assert(err, jc.ErrorIsNil)
+	data := "spamspamspam"
+	res, err := resources.SetResource("a-application", "a-user", resourcetesting.NewCharmResource(c, "spam", data), bytes.NewBufferString(data))
+	c.Assert(err, jc.ErrorIsNil)
+	err = resources.SetCharmStoreResources("a-application", []charmresource.Resource{res.Resource}, time.Now())
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, reader, err := resources.OpenResourceForUniter(unit, "spam")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = ioutil.ReadAll(reader)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(reader.Close(), jc.ErrorIsNil)
+
+	// Read the resource back so the timestamp has the resolution
+	// that is stored in the database.
+	res, err = resources.GetResource("a-application", "spam")
+	c.Assert(err, jc.ErrorIsNil)
+
+	payloads, err := s.State.UnitPayloads(unit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = payloads.Track(payload.Payload{
+		PayloadClass: charm.PayloadClass{
+			Name: "payloadA",
+			Type: "docker",
+		},
+		Status: payload.StateRunning,
+		ID:     "xyz",
+		Labels: []string{"a-tag"},
+		Unit:   unit.Name(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return unit, res
+}
+
+// makeMachineRecords adds a machine with SSH host keys, a completed
+// and a pending action, along with some custom cloud image metadata.
+func (s *MigrationSuite) makeMachineRecords(c *gc.C) (*state.Machine, state.Action, state.Action) {
+	machine := s.Factory.MakeMachine(c, nil)
+	err := s.State.SetSSHHostKeys(machine.MachineTag(), state.SSHHostKeys{"rsa-key", "dsa-key"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	params := map[string]interface{}{"command": "ls", "timeout": 10}
+	completed, err := machine.AddAction("juju-run", params)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = completed.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	completed, err = completed.Finish(state.ActionResults{
+		Status:  state.ActionCompleted,
+		Results: map[string]interface{}{"stdout": "foo"},
+		Message: "done",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	pending, err := machine.AddAction("juju-run", params)
+	c.Assert(err, jc.ErrorIsNil)
+
+	size := uint64(8192)
+	err = s.State.CloudImageMetadataStorage.SaveMetadata([]cloudimagemetadata.Metadata{{
+		MetadataAttributes: cloudimagemetadata.MetadataAttributes{
+			Stream:          "released",
+			Region:          "us-east-1",
+			Series:          "trusty",
+			Arch:            "amd64",
+			VirtType:        "hvm",
+			RootStorageType: "ebs",
+			RootStorageSize: &size,
+			Source:          "custom",
+		},
+		Priority: 50,
+		ImageId:  "ami-1234",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	return machine, completed, pending
+}
+
 type MigrationExportSuite struct {
 	MigrationSuite
 }
@@ -118,6 +298,7 @@ func (s *MigrationExportSuite) TestModelInfo(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(model.Tag(), gc.Equals, dbModel.ModelTag())
 	c.Assert(model.Owner(), gc.Equals, dbModel.Owner())
+	c.Assert(model.CloudCredential(), gc.Equals, dbModel.CloudCredential())
 	dbModelCfg, err := dbModel.Config()
 	c.Assert(err, jc.ErrorIsNil)
 	modelAttrs := dbModelCfg.AllAttrs()
@@ -442,6 +623,207 @@ func (s *MigrationExportSuite) TestRelations(c *gc.C) {
 	checkEndpoint(exEps[1], wordpress_0.Name(), wpEp, wordpressSettings)
 }
 
+func (s *MigrationExportSuite) TestStorage(c *gc.C) {
+	unit, machineTag, volumeTag := s.makeUnitWithStorage(c)
+
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	storages := model.Storages()
+	c.Assert(storages, gc.HasLen, 1)
+	storage := storages[0]
+	c.Check(storage.Tag(), gc.Equals, names.NewStorageTag("data/0"))
+	c.Check(storage.Kind(), gc.Equals, "block")
+	c.Check(storage.Name(), gc.Equals, "data")
+	owner, err := storage.Owner()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(owner, gc.Equals, unit.Tag())
+	c.Check(storage.Attachments(), jc.DeepEquals, []names.UnitTag{unit.UnitTag()})
+
+	volumes := model.Volumes()
+	c.Assert(volumes, gc.HasLen, 1)
+	volume := volumes[0]
+	c.Check(volume.Tag(), gc.Equals, volumeTag)
+	c.Check(volume.Storage(), gc.Equals, storage.Tag())
+	c.Check(volume.Provisioned(), jc.IsTrue)
+	c.Check(volume.Size(), gc.Equals, uint64(1500))
+	c.Check(volume.Pool(), gc.Equals, "loop-pool")
+	c.Check(volume.HardwareID(), gc.Equals, "magic")
+	c.Check(volume.VolumeID(), gc.Equals, "volume id")
+	c.Check(volume.Status().Value(), gc.Equals, string(status.StatusPending))
+
+	attachments := volume.Attachments()
+	c.Assert(attachments, gc.HasLen, 1)
+	attachment := attachments[0]
+	c.Check(attachment.Machine(), gc.Equals, machineTag)
+	c.Check(attachment.Provisioned(), jc.IsTrue)
+	c.Check(attachment.ReadOnly(), jc.IsTrue)
+	c.Check(attachment.DeviceName(), gc.Equals, "sdd")
+
+	c.Check(model.Filesystems(), gc.HasLen, 0)
+
+	pools := model.StoragePools()
+	c.Assert(pools, gc.HasLen, 1)
+	c.Check(pools[0].Name(), gc.Equals, "loop-pool")
+	c.Check(pools[0].Provider(), gc.Equals, string(provider.LoopProviderType))
+
+	applications := model.Applications()
+	c.Assert(applications, gc.HasLen, 1)
+	cons := applications[0].StorageConstraints()
+	c.Assert(cons, gc.HasLen, 1)
+	data := cons["data"]
+	c.Check(data.Pool(), gc.Equals, "loop-pool")
+	c.Check(data.Size(), gc.Equals, uint64(1024))
+	c.Check(data.Count(), gc.Equals, uint64(1))
+}
+
+func (s *MigrationExportSuite) TestNetworking(c *gc.C) {
+	machine := s.makeNetworking(c)
+
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	spaces := model.Spaces()
+	c.Assert(spaces, gc.HasLen, 1)
+	c.Check(spaces[0].Name(), gc.Equals, "db")
+	c.Check(spaces[0].Public(), jc.IsTrue)
+	c.Check(spaces[0].ProviderID(), gc.Equals, "space-id")
+
+	subnets := model.Subnets()
+	c.Assert(subnets, gc.HasLen, 1)
+	c.Check(subnets[0].CIDR(), gc.Equals, "10.0.0.0/24")
+	c.Check(subnets[0].ProviderID(), gc.Equals, "subnet-id")
+	c.Check(subnets[0].VLANTag(), gc.Equals, 42)
+	c.Check(subnets[0].AvailabilityZone(), gc.Equals, "zone-1")
+	c.Check(subnets[0].SpaceName(), gc.Equals, "db")
+
+	devices := model.LinkLayerDevices()
+	c.Assert(devices, gc.HasLen, 2)
+	bridge, device := devices[0], devices[1]
+	c.Check(bridge.Name(), gc.Equals, "br-eth0")
+	c.Check(bridge.Type(), gc.Equals, string(state.BridgeDevice))
+	c.Check(bridge.MachineID(), gc.Equals, machine.Id())
+	c.Check(device.Name(), gc.Equals, "eth0")
+	c.Check(device.MTU(), gc.Equals, uint(1500))
+	c.Check(device.ProviderID(), gc.Equals, "eth0-id")
+	c.Check(device.MachineID(), gc.Equals, machine.Id())
+	c.Check(device.Type(), gc.Equals, string(state.EthernetDevice))
+	c.Check(device.MACAddress(), gc.Equals, "aa:bb:cc:dd:ee:ff")
+	c.Check(device.IsAutoStart(), jc.IsTrue)
+	c.Check(device.IsUp(), jc.IsTrue)
+	c.Check(device.ParentName(), gc.Equals, "br-eth0")
+
+	addresses := model.IPAddresses()
+	c.Assert(addresses, gc.HasLen, 1)
+	addr := addresses[0]
+	c.Check(addr.ProviderID(), gc.Equals, "address-id")
+	c.Check(addr.DeviceName(), gc.Equals, "br-eth0")
+	c.Check(addr.MachineID(), gc.Equals, machine.Id())
+	c.Check(addr.SubnetCIDR(), gc.Equals, "10.0.0.0/24")
+	c.Check(addr.ConfigMethod(), gc.Equals, string(state.StaticAddress))
+	c.Check(addr.Value(), gc.Equals, "10.0.0.5")
+	c.Check(addr.DNSServers(), jc.DeepEquals, []string{"10.0.0.1"})
+	c.Check(addr.DNSSearchDomains(), jc.DeepEquals, []string{"example.com"})
+	c.Check(addr.GatewayAddress(), gc.Equals, "10.0.0.1")
+
+	applications := model.Applications()
+	c.Assert(applications, gc.HasLen, 1)
+	c.Check(applications[0].EndpointBindings()["db"], gc.Equals, "db")
+	c.Check(applications[0].EndpointBindings()["url"], gc.Equals, "")
+}
+
+func (s *MigrationExportSuite) TestResourcesAndPayloads(c *gc.C) {
+	_, res := s.makeResourcesAndPayloads(c)
+
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	applications := model.Applications()
+	c.Assert(applications, gc.HasLen, 1)
+	resources := applications[0].Resources()
+	c.Assert(resources, gc.HasLen, 1)
+	exResource := resources[0]
+	c.Check(exResource.Name(), gc.Equals, "spam")
+	appRevision := exResource.ApplicationRevision()
+	c.Check(appRevision.Revision(), gc.Equals, res.Revision)
+	c.Check(appRevision.Type(), gc.Equals, "file")
+	c.Check(appRevision.Path(), gc.Equals, "spam.tgz")
+	c.Check(appRevision.Origin(), gc.Equals, "upload")
+	c.Check(appRevision.FingerprintHex(), gc.Equals, res.Fingerprint.String())
+	c.Check(appRevision.Size(), gc.Equals, res.Size)
+	c.Check(appRevision.Timestamp().Equal(res.Timestamp), jc.IsTrue)
+	c.Check(appRevision.Username(), gc.Equals, "a-user")
+	storeRevision := exResource.CharmStoreRevision()
+	c.Assert(storeRevision, gc.NotNil)
+	c.Check(storeRevision.FingerprintHex(), gc.Equals, res.Fingerprint.String())
+
+	units := applications[0].Units()
+	c.Assert(units, gc.HasLen, 1)
+	unitResources := units[0].Resources()
+	c.Assert(unitResources, gc.HasLen, 1)
+	c.Check(unitResources[0].Name(), gc.Equals, "spam")
+	c.Check(unitResources[0].Revision().FingerprintHex(), gc.Equals, res.Fingerprint.String())
+
+	payloads := units[0].Payloads()
+	c.Assert(payloads, gc.HasLen, 1)
+	c.Check(payloads[0].Name(), gc.Equals, "payloadA")
+	c.Check(payloads[0].Type(), gc.Equals, "docker")
+	c.Check(payloads[0].RawID(), gc.Equals, "xyz")
+	c.Check(payloads[0].State(), gc.Equals, payload.StateRunning)
+	c.Check(payloads[0].Labels(), jc.DeepEquals, []string{"a-tag"})
+}
+
+func (s *MigrationExportSuite) TestMachineRecords(c *gc.C) {
+	machine, completed, pending := s.makeMachineRecords(c)
+
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	actions := model.Actions()
+	c.Assert(actions, gc.HasLen, 2)
+	exCompleted, exPending := actions[0], actions[1]
+	if exCompleted.Id() != completed.Id() {
+		// Both actions may have been enqueued in the same second.
+		exCompleted, exPending = exPending, exCompleted
+	}
+	c.Check(exCompleted.Id(), gc.Equals, completed.Id())
+	c.Check(exCompleted.Receiver(), gc.Equals, machine.Id())
+	c.Check(exCompleted.Name(), gc.Equals, "juju-run")
+	c.Check(exCompleted.Parameters(), jc.DeepEquals, completed.Parameters())
+	c.Check(exCompleted.Enqueued().Equal(completed.Enqueued()), jc.IsTrue)
+	c.Check(exCompleted.Started().Equal(completed.Started()), jc.IsTrue)
+	c.Check(exCompleted.Completed().Equal(completed.Completed()), jc.IsTrue)
+	c.Check(exCompleted.Status(), gc.Equals, string(state.ActionCompleted))
+	c.Check(exCompleted.Message(), gc.Equals, "done")
+	c.Check(exCompleted.Results(), jc.DeepEquals, map[string]interface{}{"stdout": "foo"})
+
+	c.Check(exPending.Id(), gc.Equals, pending.Id())
+	c.Check(exPending.Status(), gc.Equals, string(state.ActionPending))
+	c.Check(exPending.Started().IsZero(), jc.IsTrue)
+	c.Check(exPending.Completed().IsZero(), jc.IsTrue)
+
+	keys := model.SSHHostKeys()
+	c.Assert(keys, gc.HasLen, 1)
+	c.Check(keys[0].MachineID(), gc.Equals, machine.Id())
+	c.Check(keys[0].Keys(), jc.DeepEquals, []string{"rsa-key", "dsa-key"})
+
+	metadata := model.CloudImageMetadata()
+	c.Assert(metadata, gc.HasLen, 1)
+	c.Check(metadata[0].Stream(), gc.Equals, "released")
+	c.Check(metadata[0].Region(), gc.Equals, "us-east-1")
+	c.Check(metadata[0].Version(), gc.Equals, "14.04")
+	c.Check(metadata[0].Series(), gc.Equals, "trusty")
+	c.Check(metadata[0].Arch(), gc.Equals, "amd64")
+	c.Check(metadata[0].VirtType(), gc.Equals, "hvm")
+	c.Check(metadata[0].RootStorageType(), gc.Equals, "ebs")
+	size, ok := metadata[0].RootStorageSize()
+	c.Check(ok, jc.IsTrue)
+	c.Check(size, gc.Equals, uint64(8192))
+	c.Check(metadata[0].Source(), gc.Equals, "custom")
+	c.Check(metadata[0].Priority(), gc.Equals, 50)
+	c.Check(metadata[0].ImageId(), gc.Equals, "ami-1234")
+}
+
 type goodToken struct{}
 
 // Check implements leadership.Token
@@ -4,12 +4,15 @@
 package state
 
 import (
+	"path"
 	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/version"
 	"gopkg.in/juju/charm.v6-unstable"
+	charmresource "gopkg.in/juju/charm.v6-unstable/resource"
+	"gopkg.in/juju/names.v2"
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
 
@@ -17,7 +20,13 @@ import (
 	"github.com/juju/juju/core/description"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/payload"
+	"github.com/juju/juju/resource"
+	"github.com/juju/juju/state/cloudimagemetadata"
 	"github.com/juju/juju/status"
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/poolmanager"
 	"github.com/juju/juju/tools"
 )
 
@@ -49,11 +58,12 @@ func (st *State) Import(model description.Model) (_ *Model, _ *State, err error)
 		return nil, nil, errors.Trace(err)
 	}
 	dbModel, newSt, err := st.NewModel(ModelArgs{
-		CloudName:     model.Cloud(),
-		CloudRegion:   model.CloudRegion(),
-		Config:        cfg,
-		Owner:         model.Owner(),
-		MigrationMode: MigrationModeImporting,
+		CloudName:       model.Cloud(),
+		CloudRegion:     model.CloudRegion(),
+		CloudCredential: model.CloudCredential(),
+		Config:          cfg,
+		Owner:           model.Owner(),
+		MigrationMode:   MigrationModeImporting,
 	})
 	if err != nil {
 		return nil, nil, errors.Trace(err)
@@ -90,12 +100,38 @@ func (st *State) Import(model description.Model) (_ *Model, _ *State, err error)
 	if err := restore.machines(); err != nil {
 		return nil, nil, errors.Annotate(err, "machines")
 	}
+	// Spaces need to exist before the applications that bind their
+	// endpoints to them.
+	if err := restore.spaces(); err != nil {
+		return nil, nil, errors.Annotate(err, "spaces")
+	}
+	if err := restore.subnets(); err != nil {
+		return nil, nil, errors.Annotate(err, "subnets")
+	}
+	if err := restore.linkLayerDevices(); err != nil {
+		return nil, nil, errors.Annotate(err, "link-layer devices")
+	}
+	if err := restore.ipAddresses(); err != nil {
+		return nil, nil, errors.Annotate(err, "ip addresses")
+	}
 	if err := restore.applications(); err != nil {
 		return nil, nil, errors.Annotate(err, "applications")
 	}
 	if err := restore.relations(); err != nil {
 		return nil, nil, errors.Annotate(err, "relations")
 	}
+	if err := restore.storage(); err != nil {
+		return nil, nil, errors.Annotate(err, "storage")
+	}
+	if err := restore.actions(); err != nil {
+		return nil, nil, errors.Annotate(err, "actions")
+	}
+	if err := restore.sshHostKeys(); err != nil {
+		return nil, nil, errors.Annotate(err, "ssh host keys")
+	}
+	if err := restore.cloudImageMetadata(); err != nil {
+		return nil, nil, errors.Annotate(err, "cloud image metadata")
+	}
 
 	// NOTE: at the end of the import make sure that the mode of the model
 	// is set to "imported" not "active" (or whatever we call it). This way
@@ -108,6 +144,53 @@ func (st *State) Import(model description.Model) (_ *Model, _ *State, err error)
 	return dbModel, newSt, nil
 }
 
+// ValidateImport checks whether Import would be able to import the
+// model, without writing anything. The checks made before a model is
+// created are run, and the documents that Import would write are
+// built from the model description and then discarded.
+func (st *State) ValidateImport(model description.Model) error {
+	tag := model.Tag()
+	if _, err := st.GetModel(tag); err == nil {
+		return errors.AlreadyExistsf("model with UUID %s", tag.Id())
+	} else if !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+
+	cfg, err := config.New(config.NoDefaults, model.Config())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	_, err = st.newModelPrereqOps(ModelArgs{
+		CloudName:       model.Cloud(),
+		CloudRegion:     model.CloudRegion(),
+		CloudCredential: model.CloudCredential(),
+		Config:          cfg,
+		Owner:           model.Owner(),
+		MigrationMode:   MigrationModeImporting,
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	models, closer := st.getCollection(modelsC)
+	defer closer()
+	count, err := models.Find(bson.D{
+		{"owner", model.Owner().Canonical()},
+		{"name", cfg.Name()},
+	}).Count()
+	if err != nil {
+		return errors.Trace(err)
+	} else if count > 0 {
+		return errors.AlreadyExistsf("model %q for %s", cfg.Name(), model.Owner().Canonical())
+	}
+
+	check := importer{
+		st:     st,
+		model:  model,
+		logger: loggo.GetLogger("juju.state.import-model"),
+	}
+	return errors.Trace(check.validate())
+}
+
 type importer struct {
 	st      *State
 	dbModel *Model
@@ -131,22 +214,30 @@ func (i *importer) modelExtras() error {
 		}
 	}
 
-	blockType := map[string]BlockType{
-		"destroy-model": DestroyBlock,
-		"remove-object": RemoveBlock,
-		"all-changes":   ChangeBlock,
-	}
-
 	for blockName, message := range i.model.Blocks() {
-		block, ok := blockType[blockName]
-		if !ok {
-			return errors.Errorf("unknown block type: %q", blockName)
+		block, err := importBlockType(blockName)
+		if err != nil {
+			return errors.Trace(err)
 		}
 		i.st.SwitchBlockOn(block, message)
 	}
 	return nil
 }
 
+// importBlockType returns the block type with the given migration
+// value.
+func importBlockType(name string) (BlockType, error) {
+	switch name {
+	case "destroy-model":
+		return DestroyBlock, nil
+	case "remove-object":
+		return RemoveBlock, nil
+	case "all-changes":
+		return ChangeBlock, nil
+	}
+	return 0, errors.Errorf("unknown block type: %q", name)
+}
+
 func (i *importer) sequences() error {
 	sequenceValues := i.model.Sequences()
 	docs := make([]interface{}, 0, len(sequenceValues))
@@ -426,9 +517,39 @@ func (i *importer) makeMachineDoc(m description.Machine) (*machineDoc, error) {
 		SupportedContainersKnown: supportedSet,
 		SupportedContainers:      supportedContainers,
 		Placement:                m.Placement(),
+		Volumes:                  i.machineVolumes(id),
+		Filesystems:              i.machineFilesystems(id),
 	}, nil
 }
 
+// machineVolumes returns the names of the volumes attached to the
+// machine.
+func (i *importer) machineVolumes(machineId string) []string {
+	var result []string
+	for _, v := range i.model.Volumes() {
+		for _, attachment := range v.Attachments() {
+			if attachment.Machine().Id() == machineId {
+				result = append(result, v.Tag().Id())
+			}
+		}
+	}
+	return result
+}
+
+// machineFilesystems returns the ids of the filesystems attached to
+// the machine.
+func (i *importer) machineFilesystems(machineId string) []string {
+	var result []string
+	for _, f := range i.model.Filesystems() {
+		for _, attachment := range f.Attachments() {
+			if attachment.Machine().Id() == machineId {
+				result = append(result, f.Tag().Id())
+			}
+		}
+	}
+	return result
+}
+
 func (i *importer) makeMachineJobs(jobs []string) ([]MachineJob, error) {
 	// At time of writing, there are three valid jobs. If any jobs gets
 	// deprecated or changed in the future, older models that specify those
@@ -544,14 +665,32 @@ func (i *importer) application(s description.Application) error {
 	// TODO: update never set malarky... maybe...
 
 	ops := addApplicationOps(i.st, addApplicationOpsArgs{
-		applicationDoc: sdoc,
-		statusDoc:      statusDoc,
-		constraints:    i.constraints(s.Constraints()),
-		// storage          TODO,
+		applicationDoc:     sdoc,
+		statusDoc:          statusDoc,
+		constraints:        i.constraints(s.Constraints()),
+		storage:            i.storageConstraints(s.StorageConstraints()),
 		settings:           s.Settings(),
 		settingsRefCount:   s.SettingsRefCount(),
 		leadershipSettings: s.LeadershipSettings(),
 	})
+	if bindings := s.EndpointBindings(); len(bindings) > 0 {
+		// The bindings were validated against the charm on the source
+		// controller, so they are inserted as they are.
+		ops = append(ops, txn.Op{
+			C:      endpointBindingsC,
+			Id:     applicationGlobalKey(s.Name()),
+			Assert: txn.DocMissing,
+			Insert: endpointBindingsDoc{
+				Bindings: bindings,
+			},
+		})
+	}
+
+	resourceOps, err := i.resourceOps(s)
+	if err != nil {
+		return errors.Annotatef(err, "resources for application %q", s.Name())
+	}
+	ops = append(ops, resourceOps...)
 
 	if err := i.st.runTransaction(ops); err != nil {
 		return errors.Trace(err)
@@ -646,6 +785,15 @@ func (i *importer) unit(s description.Application, u description.Unit) error {
 		ops = append(ops, createConstraintsOp(i.st, agentGlobalKey, i.constraints(cons)))
 	}
 
+	for _, r := range u.Resources() {
+		res, err := i.makeResource(s.Name(), r.Name(), r.Revision())
+		if err != nil {
+			return errors.Annotatef(err, "resource %q for unit %q", r.Name(), u.Name())
+		}
+		stored := storedResource{Resource: res}
+		ops = append(ops, newInsertUnitResourceOps(u.Name(), stored, nil)...)
+	}
+
 	if err := i.st.runTransaction(ops); err != nil {
 		return errors.Trace(err)
 	}
@@ -665,7 +813,114 @@ func (i *importer) unit(s description.Application, u description.Unit) error {
 	if err := i.importStatusHistory(unit.globalWorkloadVersionKey(), u.WorkloadVersionHistory()); err != nil {
 		return errors.Trace(err)
 	}
+	if err := i.unitPayloads(unit, u.Payloads()); err != nil {
+		return errors.Annotatef(err, "payloads for unit %q", u.Name())
+	}
+
+	return nil
+}
 
+// resourceOps returns the operations to add the application's
+// resources. Only the resource metadata is imported here, the resource
+// blobs are uploaded separately as part of the migration.
+func (i *importer) resourceOps(s description.Application) ([]txn.Op, error) {
+	var ops []txn.Op
+	for _, r := range s.Resources() {
+		appRevision := r.ApplicationRevision()
+		if appRevision == nil {
+			return nil, errors.NotValidf("resource %q missing application revision", r.Name())
+		}
+		res, err := i.makeResource(s.Name(), r.Name(), appRevision)
+		if err != nil {
+			return nil, errors.Annotatef(err, "resource %q", r.Name())
+		}
+		ops = append(ops, newInsertResourceOps(storedResource{
+			Resource: res,
+			// This matches the path used by resource/state when the
+			// blob is stored.
+			storagePath: path.Join("application-"+s.Name(), "resources", r.Name()),
+		})...)
+
+		if storeRevision := r.CharmStoreRevision(); storeRevision != nil {
+			storeRes, err := i.makeResource(s.Name(), r.Name(), storeRevision)
+			if err != nil {
+				return nil, errors.Annotatef(err, "charm store resource %q", r.Name())
+			}
+			ops = append(ops, newInsertCharmStoreResourceOps(charmStoreResource{
+				Resource:      storeRes.Resource,
+				id:            storeRes.ID,
+				applicationID: storeRes.ApplicationID,
+				// The time the source last polled the charm store
+				// isn't exported, so the store is treated as having
+				// been polled as part of the import.
+				lastPolled: time.Now().UTC(),
+			})...)
+		}
+	}
+	return ops, nil
+}
+
+func (i *importer) makeResource(appName, name string, rev description.ResourceRevision) (resource.Resource, error) {
+	var res resource.Resource
+	resType, err := charmresource.ParseType(rev.Type())
+	if err != nil {
+		return res, errors.Trace(err)
+	}
+	origin, err := charmresource.ParseOrigin(rev.Origin())
+	if err != nil {
+		return res, errors.Trace(err)
+	}
+	var fingerprint charmresource.Fingerprint
+	if hex := rev.FingerprintHex(); hex != "" {
+		fingerprint, err = charmresource.ParseFingerprint(hex)
+		if err != nil {
+			return res, errors.Trace(err)
+		}
+	}
+	return resource.Resource{
+		Resource: charmresource.Resource{
+			Meta: charmresource.Meta{
+				Name:        name,
+				Type:        resType,
+				Path:        rev.Path(),
+				Description: rev.Description(),
+			},
+			Origin:      origin,
+			Revision:    rev.Revision(),
+			Fingerprint: fingerprint,
+			Size:        rev.Size(),
+		},
+		// This matches the ID used by resource/state.
+		ID:            appName + "/" + name,
+		ApplicationID: appName,
+		Username:      rev.Username(),
+		Timestamp:     rev.Timestamp(),
+	}, nil
+}
+
+func (i *importer) unitPayloads(unit *Unit, payloads []description.Payload) error {
+	if len(payloads) == 0 {
+		return nil
+	}
+	unitPayloads, err := i.st.UnitPayloads(unit)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, p := range payloads {
+		err := unitPayloads.Track(payload.Payload{
+			PayloadClass: charm.PayloadClass{
+				Name: p.Name(),
+				Type: p.Type(),
+			},
+			ID:     p.RawID(),
+			Status: p.State(),
+			Labels: p.Labels(),
+			Unit:   unit.Name(),
+		})
+		if err != nil {
+			return errors.Annotatef(err, "payload %q", p.Name())
+		}
+	}
 	return nil
 }
 
@@ -725,20 +980,32 @@ func (i *importer) makeUnitDoc(s description.Application, u description.Unit) (*
 	}
 
 	return &unitDoc{
-		Name:         u.Name(),
-		Application:  s.Name(),
-		Series:       s.Series(),
-		CharmURL:     charmUrl,
-		Principal:    u.Principal().Id(),
-		Subordinates: subordinates,
-		// StorageAttachmentCount int `bson:"storageattachmentcount"`
-		MachineId:    u.Machine().Id(),
-		Tools:        i.makeTools(u.Tools()),
-		Life:         Alive,
-		PasswordHash: u.PasswordHash(),
+		Name:                   u.Name(),
+		Application:            s.Name(),
+		Series:                 s.Series(),
+		CharmURL:               charmUrl,
+		Principal:              u.Principal().Id(),
+		Subordinates:           subordinates,
+		StorageAttachmentCount: i.unitStorageAttachmentCount(u.Tag()),
+		MachineId:              u.Machine().Id(),
+		Tools:                  i.makeTools(u.Tools()),
+		Life:                   Alive,
+		PasswordHash:           u.PasswordHash(),
 	}, nil
 }
 
+func (i *importer) unitStorageAttachmentCount(unit names.UnitTag) int {
+	count := 0
+	for _, s := range i.model.Storages() {
+		for _, tag := range s.Attachments() {
+			if tag == unit {
+				count++
+			}
+		}
+	}
+	return count
+}
+
 func (i *importer) relations() error {
 	i.logger.Debugf("importing relations")
 	for _, r := range i.model.Relations() {
@@ -820,6 +1087,547 @@ func (i *importer) makeRelationDoc(rel description.Relation) *relationDoc {
 	return doc
 }
 
+func (i *importer) spaces() error {
+	i.logger.Debugf("importing spaces")
+	for _, s := range i.model.Spaces() {
+		doc := spaceDoc{
+			DocID:      i.st.docID(s.Name()),
+			ModelUUID:  i.st.ModelUUID(),
+			Life:       Alive,
+			Name:       s.Name(),
+			IsPublic:   s.Public(),
+			ProviderId: s.ProviderID(),
+		}
+		ops := []txn.Op{{
+			C:      spacesC,
+			Id:     doc.DocID,
+			Assert: txn.DocMissing,
+			Insert: doc,
+		}}
+		if s.ProviderID() != "" {
+			id := network.Id(s.ProviderID())
+			ops = append(ops, i.st.networkEntityGlobalKeyOp("space", id))
+		}
+		if err := i.st.runTransaction(ops); err != nil {
+			i.logger.Errorf("error importing space %s: %s", s.Name(), err)
+			return errors.Annotate(err, s.Name())
+		}
+	}
+	i.logger.Debugf("importing spaces succeeded")
+	return nil
+}
+
+func (i *importer) subnets() error {
+	i.logger.Debugf("importing subnets")
+	for _, s := range i.model.Subnets() {
+		doc := subnetDoc{
+			DocID:            i.st.docID(s.CIDR()),
+			ModelUUID:        i.st.ModelUUID(),
+			Life:             Alive,
+			CIDR:             s.CIDR(),
+			VLANTag:          s.VLANTag(),
+			ProviderId:       s.ProviderID(),
+			AvailabilityZone: s.AvailabilityZone(),
+			SpaceName:        s.SpaceName(),
+		}
+		ops := []txn.Op{{
+			C:      subnetsC,
+			Id:     doc.DocID,
+			Assert: txn.DocMissing,
+			Insert: doc,
+		}}
+		if s.ProviderID() != "" {
+			id := network.Id(s.ProviderID())
+			ops = append(ops, i.st.networkEntityGlobalKeyOp("subnet", id))
+		}
+		if err := i.st.runTransaction(ops); err != nil {
+			i.logger.Errorf("error importing subnet %s: %s", s.CIDR(), err)
+			return errors.Annotate(err, s.CIDR())
+		}
+	}
+	i.logger.Debugf("importing subnets succeeded")
+	return nil
+}
+
+func (i *importer) linkLayerDevices() error {
+	i.logger.Debugf("importing link-layer devices")
+	docs := make([]linkLayerDeviceDoc, 0, len(i.model.LinkLayerDevices()))
+	numChildren := make(map[string]int)
+	for _, d := range i.model.LinkLayerDevices() {
+		doc := i.makeLinkLayerDeviceDoc(d)
+		if parentDocID := newLinkLayerDevice(i.st, doc).parentDocID(); parentDocID != "" {
+			numChildren[parentDocID]++
+		}
+		docs = append(docs, doc)
+	}
+
+	// The parent references are all known up front, so the refs docs are
+	// written with their final child counts rather than incremented as each
+	// child device is added.
+	for _, doc := range docs {
+		ops := []txn.Op{
+			insertLinkLayerDeviceDocOp(&doc),
+			{
+				C:      linkLayerDevicesRefsC,
+				Id:     doc.DocID,
+				Assert: txn.DocMissing,
+				Insert: linkLayerDevicesRefsDoc{
+					DocID:       doc.DocID,
+					ModelUUID:   doc.ModelUUID,
+					NumChildren: numChildren[doc.DocID],
+				},
+			},
+		}
+		if doc.ProviderID != "" {
+			id := network.Id(doc.ProviderID)
+			ops = append(ops, i.st.networkEntityGlobalKeyOp("linklayerdevice", id))
+		}
+		if err := i.st.runTransaction(ops); err != nil {
+			i.logger.Errorf("error importing link-layer device %s: %s", doc.DocID, err)
+			return errors.Annotatef(err, "device %q on machine %q", doc.Name, doc.MachineID)
+		}
+	}
+	i.logger.Debugf("importing link-layer devices succeeded")
+	return nil
+}
+
+func (i *importer) makeLinkLayerDeviceDoc(d description.LinkLayerDevice) linkLayerDeviceDoc {
+	globalKey := linkLayerDeviceGlobalKey(d.MachineID(), d.Name())
+	return linkLayerDeviceDoc{
+		DocID:       i.st.docID(globalKey),
+		Name:        d.Name(),
+		ModelUUID:   i.st.ModelUUID(),
+		MTU:         d.MTU(),
+		ProviderID:  d.ProviderID(),
+		MachineID:   d.MachineID(),
+		Type:        LinkLayerDeviceType(d.Type()),
+		MACAddress:  d.MACAddress(),
+		IsAutoStart: d.IsAutoStart(),
+		IsUp:        d.IsUp(),
+		ParentName:  d.ParentName(),
+	}
+}
+
+func (i *importer) ipAddresses() error {
+	i.logger.Debugf("importing ip addresses")
+	for _, a := range i.model.IPAddresses() {
+		globalKey := ipAddressGlobalKey(a.MachineID(), a.DeviceName(), a.Value())
+		doc := ipAddressDoc{
+			DocID:            i.st.docID(globalKey),
+			ModelUUID:        i.st.ModelUUID(),
+			ProviderID:       a.ProviderID(),
+			DeviceName:       a.DeviceName(),
+			MachineID:        a.MachineID(),
+			SubnetCIDR:       a.SubnetCIDR(),
+			ConfigMethod:     AddressConfigMethod(a.ConfigMethod()),
+			Value:            a.Value(),
+			DNSServers:       a.DNSServers(),
+			DNSSearchDomains: a.DNSSearchDomains(),
+			GatewayAddress:   a.GatewayAddress(),
+		}
+		ops := []txn.Op{insertIPAddressDocOp(&doc)}
+		if a.ProviderID() != "" {
+			id := network.Id(a.ProviderID())
+			ops = append(ops, i.st.networkEntityGlobalKeyOp("address", id))
+		}
+		if err := i.st.runTransaction(ops); err != nil {
+			i.logger.Errorf("error importing ip address %s: %s", a.Value(), err)
+			return errors.Annotate(err, a.Value())
+		}
+	}
+	i.logger.Debugf("importing ip addresses succeeded")
+	return nil
+}
+
+func (i *importer) actions() error {
+	i.logger.Debugf("importing actions")
+	for _, a := range i.model.Actions() {
+		if err := i.action(a); err != nil {
+			i.logger.Errorf("error importing action %s: %s", a.Id(), err)
+			return errors.Annotate(err, a.Id())
+		}
+	}
+	i.logger.Debugf("importing actions succeeded")
+	return nil
+}
+
+func (i *importer) action(a description.Action) error {
+	modelUUID := i.st.ModelUUID()
+	doc := actionDoc{
+		DocId:      i.st.docID(a.Id()),
+		ModelUUID:  modelUUID,
+		Receiver:   a.Receiver(),
+		Name:       a.Name(),
+		Parameters: a.Parameters(),
+		Enqueued:   a.Enqueued(),
+		Started:    a.Started(),
+		Completed:  a.Completed(),
+		Status:     ActionStatus(a.Status()),
+		Message:    a.Message(),
+		Results:    a.Results(),
+	}
+	// An action that was running on the source controller can never
+	// report its results here, so it is recorded as failed rather
+	// than left running forever.
+	if doc.Status == ActionRunning {
+		doc.Status = ActionFailed
+		doc.Message = "action was running when the model was migrated"
+		doc.Completed = nowToTheSecond()
+	}
+	ops := []txn.Op{{
+		C:      actionsC,
+		Id:     doc.DocId,
+		Assert: txn.DocMissing,
+		Insert: doc,
+	}}
+	// Pending actions are queued again by adding the notification
+	// that the receiver watches for. Everything else is history.
+	if doc.Status == ActionPending {
+		notification := actionNotificationDoc{
+			DocId:     i.st.docID(ensureActionMarker(a.Receiver()) + a.Id()),
+			ModelUUID: modelUUID,
+			Receiver:  a.Receiver(),
+			ActionID:  a.Id(),
+		}
+		ops = append(ops, txn.Op{
+			C:      actionNotificationsC,
+			Id:     notification.DocId,
+			Assert: txn.DocMissing,
+			Insert: notification,
+		})
+	}
+	return i.st.runTransaction(ops)
+}
+
+func (i *importer) sshHostKeys() error {
+	i.logger.Debugf("importing ssh host keys")
+	for _, key := range i.model.SSHHostKeys() {
+		ops := []txn.Op{{
+			C:      sshHostKeysC,
+			Id:     machineGlobalKey(key.MachineID()),
+			Assert: txn.DocMissing,
+			Insert: sshHostKeysDoc{Keys: key.Keys()},
+		}}
+		if err := i.st.runTransaction(ops); err != nil {
+			i.logger.Errorf("error importing ssh host keys for machine %s: %s", key.MachineID(), err)
+			return errors.Annotate(err, key.MachineID())
+		}
+	}
+	i.logger.Debugf("importing ssh host keys succeeded")
+	return nil
+}
+
+func (i *importer) cloudImageMetadata() error {
+	i.logger.Debugf("importing cloud image metadata")
+	var metadata []cloudimagemetadata.Metadata
+	for _, m := range i.model.CloudImageMetadata() {
+		one := cloudimagemetadata.Metadata{
+			MetadataAttributes: cloudimagemetadata.MetadataAttributes{
+				Stream:          m.Stream(),
+				Region:          m.Region(),
+				Version:         m.Version(),
+				Series:          m.Series(),
+				Arch:            m.Arch(),
+				VirtType:        m.VirtType(),
+				RootStorageType: m.RootStorageType(),
+				Source:          m.Source(),
+			},
+			Priority: m.Priority(),
+			ImageId:  m.ImageId(),
+		}
+		if size, ok := m.RootStorageSize(); ok {
+			one.RootStorageSize = &size
+		}
+		metadata = append(metadata, one)
+	}
+	if err := i.st.CloudImageMetadataStorage.SaveMetadata(metadata); err != nil {
+		return errors.Trace(err)
+	}
+	i.logger.Debugf("importing cloud image metadata succeeded")
+	return nil
+}
+
+func (i *importer) storage() error {
+	if err := i.storagePools(); err != nil {
+		return errors.Annotate(err, "storage pools")
+	}
+	if err := i.storageInstances(); err != nil {
+		return errors.Annotate(err, "storage instances")
+	}
+	if err := i.volumes(); err != nil {
+		return errors.Annotate(err, "volumes")
+	}
+	if err := i.filesystems(); err != nil {
+		return errors.Annotate(err, "filesystems")
+	}
+	return nil
+}
+
+func (i *importer) storagePools() error {
+	pm := poolmanager.New(NewStateSettings(i.st))
+	for _, pool := range i.model.StoragePools() {
+		attrs := make(map[string]interface{})
+		for key, value := range pool.Attributes() {
+			attrs[key] = value
+		}
+		providerType := storage.ProviderType(pool.Provider())
+		if _, err := pm.Create(pool.Name(), providerType, attrs); err != nil {
+			return errors.Annotatef(err, "pool %q", pool.Name())
+		}
+	}
+	return nil
+}
+
+func (i *importer) storageInstances() error {
+	i.logger.Debugf("importing storage instances")
+	for _, s := range i.model.Storages() {
+		if err := i.storageInstance(s); err != nil {
+			i.logger.Errorf("error importing storage %s: %s", s.Tag().Id(), err)
+			return errors.Annotate(err, s.Tag().Id())
+		}
+	}
+	i.logger.Debugf("importing storage instances succeeded")
+	return nil
+}
+
+func (i *importer) storageInstance(s description.Storage) error {
+	kind, err := parseStorageKind(s.Kind())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	owner, err := s.Owner()
+	if err != nil {
+		return errors.Annotate(err, "owner")
+	}
+	attachments := s.Attachments()
+	doc := &storageInstanceDoc{
+		Id:              s.Tag().Id(),
+		Kind:            kind,
+		StorageName:     s.Name(),
+		AttachmentCount: len(attachments),
+	}
+	if owner != nil {
+		doc.Owner = owner.String()
+	}
+	if s.CharmURL() != "" {
+		curl, err := charm.ParseURL(s.CharmURL())
+		if err != nil {
+			return errors.Trace(err)
+		}
+		doc.CharmURL = curl
+	}
+	ops := []txn.Op{{
+		C:      storageInstancesC,
+		Id:     doc.Id,
+		Assert: txn.DocMissing,
+		Insert: doc,
+	}}
+	for _, unit := range attachments {
+		ops = append(ops, createStorageAttachmentOp(s.Tag(), unit))
+	}
+	if err := i.st.runTransaction(ops); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// parseStorageKind is the inverse of storageKindName.
+func parseStorageKind(name string) (StorageKind, error) {
+	switch charm.StorageType(name) {
+	case charm.StorageBlock:
+		return StorageKindBlock, nil
+	case charm.StorageFilesystem:
+		return StorageKindFilesystem, nil
+	}
+	return StorageKindUnknown, errors.NotValidf("storage kind %q", name)
+}
+
+func (i *importer) volumes() error {
+	i.logger.Debugf("importing volumes")
+	for _, v := range i.model.Volumes() {
+		if err := i.volume(v); err != nil {
+			i.logger.Errorf("error importing volume %s: %s", v.Tag().Id(), err)
+			return errors.Annotate(err, v.Tag().Id())
+		}
+	}
+	i.logger.Debugf("importing volumes succeeded")
+	return nil
+}
+
+func (i *importer) volume(v description.Volume) error {
+	attachments := v.Attachments()
+	tag := v.Tag()
+	doc := &volumeDoc{
+		Name:            tag.Id(),
+		StorageId:       v.Storage().Id(),
+		AttachmentCount: len(attachments),
+	}
+	binding, err := v.Binding()
+	if err != nil {
+		return errors.Annotate(err, "binding")
+	}
+	if binding != nil {
+		doc.Binding = binding.String()
+	}
+	if v.Provisioned() {
+		doc.Info = &VolumeInfo{
+			HardwareId: v.HardwareID(),
+			Size:       v.Size(),
+			Pool:       v.Pool(),
+			VolumeId:   v.VolumeID(),
+			Persistent: v.Persistent(),
+			Encrypted:  v.Encrypted(),
+		}
+	} else {
+		doc.Params = &VolumeParams{
+			Size: v.Size(),
+			Pool: v.Pool(),
+		}
+	}
+	status := v.Status()
+	if status == nil {
+		return errors.NotValidf("missing status")
+	}
+	globalKey := volumeGlobalKey(tag.Id())
+	ops := []txn.Op{
+		createStatusOp(i.st, globalKey, i.makeStatusDoc(status)),
+		{
+			C:      volumesC,
+			Id:     tag.Id(),
+			Assert: txn.DocMissing,
+			Insert: doc,
+		},
+	}
+	for _, attachment := range attachments {
+		ops = append(ops, i.volumeAttachmentOp(tag.Id(), attachment))
+	}
+	if err := i.st.runTransaction(ops); err != nil {
+		return errors.Trace(err)
+	}
+	if err := i.importStatusHistory(globalKey, v.StatusHistory()); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+func (i *importer) volumeAttachmentOp(volumeName string, attachment description.VolumeAttachment) txn.Op {
+	machineId := attachment.Machine().Id()
+	doc := &volumeAttachmentDoc{
+		Volume:  volumeName,
+		Machine: machineId,
+	}
+	if attachment.Provisioned() {
+		doc.Info = &VolumeAttachmentInfo{
+			DeviceName: attachment.DeviceName(),
+			DeviceLink: attachment.DeviceLink(),
+			BusAddress: attachment.BusAddress(),
+			ReadOnly:   attachment.ReadOnly(),
+		}
+	} else {
+		doc.Params = &VolumeAttachmentParams{
+			ReadOnly: attachment.ReadOnly(),
+		}
+	}
+	return txn.Op{
+		C:      volumeAttachmentsC,
+		Id:     volumeAttachmentId(machineId, volumeName),
+		Assert: txn.DocMissing,
+		Insert: doc,
+	}
+}
+
+func (i *importer) filesystems() error {
+	i.logger.Debugf("importing filesystems")
+	for _, f := range i.model.Filesystems() {
+		if err := i.filesystem(f); err != nil {
+			i.logger.Errorf("error importing filesystem %s: %s", f.Tag().Id(), err)
+			return errors.Annotate(err, f.Tag().Id())
+		}
+	}
+	i.logger.Debugf("importing filesystems succeeded")
+	return nil
+}
+
+func (i *importer) filesystem(f description.Filesystem) error {
+	attachments := f.Attachments()
+	tag := f.Tag()
+	doc := &filesystemDoc{
+		FilesystemId:    tag.Id(),
+		StorageId:       f.Storage().Id(),
+		VolumeId:        f.Volume().Id(),
+		AttachmentCount: len(attachments),
+	}
+	binding, err := f.Binding()
+	if err != nil {
+		return errors.Annotate(err, "binding")
+	}
+	if binding != nil {
+		doc.Binding = binding.String()
+	}
+	if f.Provisioned() {
+		doc.Info = &FilesystemInfo{
+			Size:         f.Size(),
+			Pool:         f.Pool(),
+			FilesystemId: f.FilesystemID(),
+			Encrypted:    f.Encrypted(),
+		}
+	} else {
+		doc.Params = &FilesystemParams{
+			Size: f.Size(),
+			Pool: f.Pool(),
+		}
+	}
+	status := f.Status()
+	if status == nil {
+		return errors.NotValidf("missing status")
+	}
+	globalKey := filesystemGlobalKey(tag.Id())
+	ops := []txn.Op{
+		createStatusOp(i.st, globalKey, i.makeStatusDoc(status)),
+		{
+			C:      filesystemsC,
+			Id:     tag.Id(),
+			Assert: txn.DocMissing,
+			Insert: doc,
+		},
+	}
+	for _, attachment := range attachments {
+		ops = append(ops, i.filesystemAttachmentOp(tag.Id(), attachment))
+	}
+	if err := i.st.runTransaction(ops); err != nil {
+		return errors.Trace(err)
+	}
+	if err := i.importStatusHistory(globalKey, f.StatusHistory()); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+func (i *importer) filesystemAttachmentOp(filesystemId string, attachment description.FilesystemAttachment) txn.Op {
+	machineId := attachment.Machine().Id()
+	doc := &filesystemAttachmentDoc{
+		Filesystem: filesystemId,
+		Machine:    machineId,
+	}
+	if attachment.Provisioned() {
+		doc.Info = &FilesystemAttachmentInfo{
+			MountPoint: attachment.MountPoint(),
+			ReadOnly:   attachment.ReadOnly(),
+		}
+	} else {
+		doc.Params = &FilesystemAttachmentParams{
+			Location: attachment.MountPoint(),
+			ReadOnly: attachment.ReadOnly(),
+		}
+	}
+	return txn.Op{
+		C:      filesystemAttachmentsC,
+		Id:     filesystemAttachmentId(machineId, filesystemId),
+		Assert: txn.DocMissing,
+		Insert: doc,
+	}
+}
+
 func (i *importer) importStatusHistory(globalKey string, history []description.Status) error {
 	docs := make([]interface{}, len(history))
 	for i, statusVal := range history {
@@ -841,6 +1649,21 @@ func (i *importer) importStatusHistory(globalKey string, history []description.S
 	return nil
 }
 
+func (i *importer) storageConstraints(cons map[string]description.StorageConstraint) map[string]StorageConstraints {
+	if len(cons) == 0 {
+		return nil
+	}
+	result := make(map[string]StorageConstraints, len(cons))
+	for name, value := range cons {
+		result[name] = StorageConstraints{
+			Pool:  value.Pool(),
+			Size:  value.Size(),
+			Count: value.Count(),
+		}
+	}
+	return result
+}
+
 func (i *importer) constraints(cons description.Constraints) constraints.Value {
 	var result constraints.Value
 	if cons == nil {
@@ -876,3 +1699,120 @@ func (i *importer) constraints(cons description.Constraints) constraints.Value {
 	}
 	return result
 }
+
+// validate builds the documents that the import would write for the
+// model, to find anything in the model description that would make
+// the import fail. Nothing is written. Errors are annotated as the
+// import would annotate them.
+func (i *importer) validate() error {
+	for blockName := range i.model.Blocks() {
+		if _, err := importBlockType(blockName); err != nil {
+			return errors.Annotate(err, "base model aspects")
+		}
+	}
+	for _, m := range i.model.Machines() {
+		if err := i.validateMachine(m); err != nil {
+			return errors.Annotate(errors.Annotate(err, m.Id()), "machines")
+		}
+	}
+	for _, s := range i.model.Applications() {
+		if err := i.validateApplication(s); err != nil {
+			return errors.Annotate(errors.Annotate(err, s.Name()), "applications")
+		}
+	}
+	for _, s := range i.model.Storages() {
+		if err := i.validateStorageInstance(s); err != nil {
+			return errors.Annotate(errors.Annotate(err, s.Tag().Id()), "storage: storage instances")
+		}
+	}
+	for _, v := range i.model.Volumes() {
+		if err := i.validateVolume(v); err != nil {
+			return errors.Annotate(errors.Annotate(err, v.Tag().Id()), "storage: volumes")
+		}
+	}
+	for _, f := range i.model.Filesystems() {
+		if err := i.validateFilesystem(f); err != nil {
+			return errors.Annotate(errors.Annotate(err, f.Tag().Id()), "storage: filesystems")
+		}
+	}
+	return nil
+}
+
+func (i *importer) validateMachine(m description.Machine) error {
+	if _, err := i.makeMachineDoc(m); err != nil {
+		return errors.Annotatef(err, "machine %s", m.Id())
+	}
+	if m.Status() == nil {
+		return errors.NotValidf("missing status")
+	}
+	for _, container := range m.Containers() {
+		if err := i.validateMachine(container); err != nil {
+			return errors.Annotate(err, container.Id())
+		}
+	}
+	return nil
+}
+
+func (i *importer) validateApplication(s description.Application) error {
+	if _, err := i.makeApplicationDoc(s); err != nil {
+		return errors.Trace(err)
+	}
+	if s.Status() == nil {
+		return errors.NotValidf("missing status")
+	}
+	if _, err := i.resourceOps(s); err != nil {
+		return errors.Annotatef(err, "resources for application %q", s.Name())
+	}
+	for _, u := range s.Units() {
+		if _, err := i.makeUnitDoc(s, u); err != nil {
+			return errors.Trace(err)
+		}
+		if u.AgentStatus() == nil {
+			return errors.NotValidf("missing agent status")
+		}
+		if u.WorkloadStatus() == nil {
+			return errors.NotValidf("missing workload status")
+		}
+		for _, r := range u.Resources() {
+			if _, err := i.makeResource(s.Name(), r.Name(), r.Revision()); err != nil {
+				return errors.Annotatef(err, "resource %q for unit %q", r.Name(), u.Name())
+			}
+		}
+	}
+	return nil
+}
+
+func (i *importer) validateStorageInstance(s description.Storage) error {
+	if _, err := parseStorageKind(s.Kind()); err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := s.Owner(); err != nil {
+		return errors.Annotate(err, "owner")
+	}
+	if s.CharmURL() != "" {
+		if _, err := charm.ParseURL(s.CharmURL()); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+func (i *importer) validateVolume(v description.Volume) error {
+	if _, err := v.Binding(); err != nil {
+		return errors.Annotate(err, "binding")
+	}
+	if v.Status() == nil {
+		return errors.NotValidf("missing status")
+	}
+	return nil
+}
+
+func (i *importer) validateFilesystem(f description.Filesystem) error {
+	if _, err := f.Binding(); err != nil {
+		return errors.Annotate(err, "binding")
+	}
+	if f.Status() == nil {
+		return errors.NotValidf("missing status")
+	}
+	return nil
+}
@@ -11,13 +11,20 @@ import (
 	"github.com/juju/utils"
 	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+	charmresource "gopkg.in/juju/charm.v6-unstable/resource"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/core/description"
 	"github.com/juju/juju/network"
+	"github.com/juju/juju/payload"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/cloudimagemetadata"
+	statetesting "github.com/juju/juju/state/testing"
 	"github.com/juju/juju/status"
+	"github.com/juju/juju/storage/poolmanager"
+	"github.com/juju/juju/storage/provider"
 	"github.com/juju/juju/testing/factory"
 )
 
@@ -48,6 +55,72 @@ func (s *MigrationImportSuite) TestExisting(c *gc.C) {
 	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
 }
 
+func (s *MigrationImportSuite) TestValidateImport(c *gc.C) {
+	s.Factory.MakeMachine(c, nil)
+	out, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+	uuid := utils.MustNewUUID().String()
+	models, err := s.State.AllModels()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.ValidateImport(newModel(out, uuid, "new"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Nothing was written.
+	_, err = s.State.GetModel(names.NewModelTag(uuid))
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	after, err := s.State.AllModels()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(after, gc.HasLen, len(models))
+	model, newSt, err := s.State.Import(newModel(out, uuid, "new"))
+	c.Assert(err, jc.ErrorIsNil)
+	defer newSt.Close()
+	c.Assert(model.Name(), gc.Equals, "new")
+}
+
+func (s *MigrationImportSuite) TestValidateImportFailure(c *gc.C) {
+	out, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+	uuid := utils.MustNewUUID().String()
+	in := &badBlocksModel{newModel(out, uuid, "new")}
+
+	err = s.State.ValidateImport(in)
+	c.Assert(err, gc.ErrorMatches, `base model aspects: unknown block type: "bad-block"`)
+	_, err = s.State.GetModel(names.NewModelTag(uuid))
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *MigrationImportSuite) TestValidateImportBadMachine(c *gc.C) {
+	s.Factory.MakeMachine(c, nil)
+	out, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+	in := &badJobsModel{newModel(out, utils.MustNewUUID().String(), "new")}
+
+	err = s.State.ValidateImport(in)
+	c.Assert(err, gc.ErrorMatches, `machines: 0: machine 0: unknown machine job: "bad-job"`)
+}
+
+func (s *MigrationImportSuite) TestValidateImportNameExists(c *gc.C) {
+	out, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+	model, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	in := newModel(out, utils.MustNewUUID().String(), model.Name())
+
+	err = s.State.ValidateImport(in)
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *MigrationImportSuite) TestValidateImportExisting(c *gc.C) {
+	out, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.ValidateImport(out)
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+	_, err = s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *MigrationImportSuite) importModel(c *gc.C) (*state.Model, *state.State) {
 	out, err := s.State.Export()
 	c.Assert(err, jc.ErrorIsNil)
@@ -92,6 +165,7 @@ func (s *MigrationImportSuite) TestNewModel(c *gc.C) {
 	defer newSt.Close()
 
 	c.Assert(newModel.Owner(), gc.Equals, original.Owner())
+	c.Assert(newModel.CloudCredential(), gc.Equals, original.CloudCredential())
 	c.Assert(newModel.LatestToolsVersion(), gc.Equals, latestTools)
 	c.Assert(newModel.MigrationMode(), gc.Equals, state.MigrationModeImporting)
 	s.assertAnnotations(c, newSt, newModel)
@@ -447,6 +521,247 @@ func (s *MigrationImportSuite) TestRelations(c *gc.C) {
 	c.Assert(settings.Map(), gc.DeepEquals, relSettings)
 }
 
+func (s *MigrationImportSuite) TestStorage(c *gc.C) {
+	unit, machineTag, volumeTag := s.makeUnitWithStorage(c)
+	storageTag := names.NewStorageTag("data/0")
+
+	_, newSt := s.importModel(c)
+	defer newSt.Close()
+
+	storage, err := newSt.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(storage.Kind(), gc.Equals, state.StorageKindBlock)
+	c.Check(storage.StorageName(), gc.Equals, "data")
+	c.Check(storage.Owner(), gc.Equals, unit.Tag())
+	attachments, err := newSt.StorageAttachments(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(attachments, gc.HasLen, 1)
+	c.Check(attachments[0].Unit(), gc.Equals, unit.UnitTag())
+
+	volume, err := newSt.Volume(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+	volumeStorage, err := volume.StorageInstance()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(volumeStorage, gc.Equals, storageTag)
+	info, err := volume.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(info, jc.DeepEquals, state.VolumeInfo{
+		HardwareId: "magic",
+		Size:       1500,
+		Pool:       "loop-pool",
+		VolumeId:   "volume id",
+	})
+	_, ok := volume.Params()
+	c.Check(ok, jc.IsFalse)
+
+	attachment, err := newSt.VolumeAttachment(machineTag, volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+	attachmentInfo, err := attachment.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(attachmentInfo, jc.DeepEquals, state.VolumeAttachmentInfo{
+		DeviceName: "sdd",
+		ReadOnly:   true,
+	})
+
+	pm := poolmanager.New(state.NewStateSettings(newSt))
+	pool, err := pm.Get("loop-pool")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(pool.Provider(), gc.Equals, provider.LoopProviderType)
+
+	application, err := newSt.Application("storage-block")
+	c.Assert(err, jc.ErrorIsNil)
+	cons, err := application.StorageConstraints()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cons, jc.DeepEquals, map[string]state.StorageConstraints{
+		"data": {Pool: "loop-pool", Size: 1024, Count: 1},
+	})
+}
+
+func (s *MigrationImportSuite) TestNetworking(c *gc.C) {
+	machine := s.makeNetworking(c)
+
+	_, newSt := s.importModel(c)
+	defer newSt.Close()
+
+	space, err := newSt.Space("db")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(space.ProviderId(), gc.Equals, network.Id("space-id"))
+
+	subnet, err := newSt.Subnet("10.0.0.0/24")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(subnet.ProviderId(), gc.Equals, network.Id("subnet-id"))
+	c.Check(subnet.VLANTag(), gc.Equals, 42)
+	c.Check(subnet.AvailabilityZone(), gc.Equals, "zone-1")
+	c.Check(subnet.SpaceName(), gc.Equals, "db")
+
+	newMachine, err := newSt.Machine(machine.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	device, err := newMachine.LinkLayerDevice("eth0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(device.MTU(), gc.Equals, uint(1500))
+	c.Check(device.ProviderID(), gc.Equals, network.Id("eth0-id"))
+	c.Check(device.Type(), gc.Equals, state.EthernetDevice)
+	c.Check(device.MACAddress(), gc.Equals, "aa:bb:cc:dd:ee:ff")
+	c.Check(device.IsAutoStart(), jc.IsTrue)
+	c.Check(device.IsUp(), jc.IsTrue)
+	parent, err := device.ParentDevice()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(parent.Name(), gc.Equals, "br-eth0")
+
+	// The bridge still has a child, so the refcount came across.
+	err = parent.Remove()
+	c.Assert(err, gc.ErrorMatches, `.*parent device "br-eth0" has 1 children`)
+
+	addresses, err := parent.Addresses()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addresses, gc.HasLen, 1)
+	addr := addresses[0]
+	c.Check(addr.ProviderID(), gc.Equals, network.Id("address-id"))
+	c.Check(addr.SubnetCIDR(), gc.Equals, "10.0.0.0/24")
+	c.Check(addr.ConfigMethod(), gc.Equals, state.StaticAddress)
+	c.Check(addr.Value(), gc.Equals, "10.0.0.5")
+	c.Check(addr.DNSServers(), jc.DeepEquals, []string{"10.0.0.1"})
+	c.Check(addr.DNSSearchDomains(), jc.DeepEquals, []string{"example.com"})
+	c.Check(addr.GatewayAddress(), gc.Equals, "10.0.0.1")
+
+	application, err := newSt.Application("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	bindings, err := application.EndpointBindings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(bindings["db"], gc.Equals, "db")
+}
+
+func (s *MigrationImportSuite) TestResourcesAndPayloads(c *gc.C) {
+	unit, res := s.makeResourcesAndPayloads(c)
+
+	_, newSt := s.importModel(c)
+	defer newSt.Close()
+
+	resources, err := newSt.Resources()
+	c.Assert(err, jc.ErrorIsNil)
+	imported, err := resources.ListResources("a-application")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(imported.Resources, gc.HasLen, 1)
+	importedRes := imported.Resources[0]
+	c.Check(importedRes.ID, gc.Equals, res.ID)
+	c.Check(importedRes.ApplicationID, gc.Equals, "a-application")
+	c.Check(importedRes.Resource, jc.DeepEquals, res.Resource)
+	c.Check(importedRes.Username, gc.Equals, res.Username)
+	c.Check(importedRes.Timestamp.Equal(res.Timestamp), jc.IsTrue)
+	c.Check(imported.CharmStoreResources, jc.DeepEquals, []charmresource.Resource{res.Resource})
+
+	c.Assert(imported.UnitResources, gc.HasLen, 1)
+	c.Check(imported.UnitResources[0].Tag, gc.Equals, unit.UnitTag())
+	c.Assert(imported.UnitResources[0].Resources, gc.HasLen, 1)
+	c.Check(imported.UnitResources[0].Resources[0].Resource, jc.DeepEquals, res.Resource)
+
+	newUnit, err := newSt.Unit(unit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	unitPayloads, err := newSt.UnitPayloads(newUnit)
+	c.Assert(err, jc.ErrorIsNil)
+	results, err := unitPayloads.List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Payload, gc.NotNil)
+	c.Check(results[0].Payload.Payload, jc.DeepEquals, payload.Payload{
+		PayloadClass: charm.PayloadClass{
+			Name: "payloadA",
+			Type: "docker",
+		},
+		ID:     "xyz",
+		Status: payload.StateRunning,
+		Labels: []string{"a-tag"},
+		Unit:   unit.Name(),
+	})
+}
+
+func (s *MigrationImportSuite) TestMachineRecords(c *gc.C) {
+	machine, completed, pending := s.makeMachineRecords(c)
+
+	_, newSt := s.importModel(c)
+	defer newSt.Close()
+
+	newMachine, err := newSt.Machine(machine.Id())
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := newMachine.CompletedActions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 1)
+	c.Check(history[0].Id(), gc.Equals, completed.Id())
+	c.Check(history[0].Name(), gc.Equals, "juju-run")
+	c.Check(history[0].Parameters(), jc.DeepEquals, completed.Parameters())
+	c.Check(history[0].Enqueued().Equal(completed.Enqueued()), jc.IsTrue)
+	c.Check(history[0].Completed().Equal(completed.Completed()), jc.IsTrue)
+	results, message := history[0].Results()
+	c.Check(results, jc.DeepEquals, map[string]interface{}{"stdout": "foo"})
+	c.Check(message, gc.Equals, "done")
+
+	queued, err := newMachine.PendingActions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(queued, gc.HasLen, 1)
+	c.Check(queued[0].Id(), gc.Equals, pending.Id())
+
+	// The pending action has been queued again for the machine.
+	w := newMachine.WatchActionNotifications()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewStringsWatcherC(c, newSt, w)
+	wc.AssertChange(pending.Id())
+	wc.AssertNoChange()
+
+	keys, err := newSt.GetSSHHostKeys(newMachine.MachineTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(keys, jc.DeepEquals, state.SSHHostKeys{"rsa-key", "dsa-key"})
+
+	metadata, err := newSt.CloudImageMetadataStorage.FindMetadata(cloudimagemetadata.MetadataFilter{})
+	c.Assert(err, jc.ErrorIsNil)
+	size := uint64(8192)
+	c.Check(metadata, jc.DeepEquals, map[string][]cloudimagemetadata.Metadata{
+		"custom": {{
+			MetadataAttributes: cloudimagemetadata.MetadataAttributes{
+				Stream:          "released",
+				Region:          "us-east-1",
+				Version:         "14.04",
+				Series:          "trusty",
+				Arch:            "amd64",
+				VirtType:        "hvm",
+				RootStorageType: "ebs",
+				RootStorageSize: &size,
+				Source:          "custom",
+			},
+			Priority: 50,
+			ImageId:  "ami-1234",
+		}},
+	})
+}
+
+func (s *MigrationImportSuite) TestRunningActionFailed(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, nil)
+	running, err := machine.AddAction("juju-run", map[string]interface{}{"command": "ls"})
+	c.Assert(err, jc.ErrorIsNil)
+	running, err = running.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, newSt := s.importModel(c)
+	defer newSt.Close()
+
+	newMachine, err := newSt.Machine(machine.Id())
+	c.Assert(err, jc.ErrorIsNil)
+
+	actions, err := newMachine.RunningActions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(actions, gc.HasLen, 0)
+
+	history, err := newMachine.CompletedActions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 1)
+	c.Check(history[0].Id(), gc.Equals, running.Id())
+	c.Check(history[0].Status(), gc.Equals, state.ActionFailed)
+	c.Check(history[0].Started().Equal(running.Started()), jc.IsTrue)
+	c.Check(history[0].Completed().IsZero(), jc.IsFalse)
+	_, message := history[0].Results()
+	c.Check(message, gc.Equals, "action was running when the model was migrated")
+}
+
 func (s *MigrationImportSuite) TestUnitsOpenPorts(c *gc.C) {
 	unit := s.Factory.MakeUnit(c, nil)
 	err := unit.OpenPorts("tcp", 1234, 2345)
@@ -501,6 +816,34 @@ func (s *MigrationImportSuite) assertDestroyModelAdvancesLife(c *gc.C, m *state.
 // newModel replaces the uuid and name of the config attributes so we
 // can use all the other data to validate imports. An owner and name of the
 // model are unique together in a controller.
+type badBlocksModel struct {
+	description.Model
+}
+
+func (m *badBlocksModel) Blocks() map[string]string {
+	return map[string]string{"bad-block": "boom"}
+}
+
+type badJobsModel struct {
+	description.Model
+}
+
+func (m *badJobsModel) Machines() []description.Machine {
+	var result []description.Machine
+	for _, machine := range m.Model.Machines() {
+		result = append(result, &badJobsMachine{machine})
+	}
+	return result
+}
+
+type badJobsMachine struct {
+	description.Machine
+}
+
+func (m *badJobsMachine) Jobs() []string {
+	return []string{"bad-job"}
+}
+
 func newModel(m description.Model, uuid, name string) description.Model {
 	return &mockModel{m, uuid, name}
 }
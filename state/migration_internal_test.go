@@ -46,6 +46,42 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		// relation
 		relationsC,
 		relationScopesC,
+
+		// storage
+		storageConstraintsC,
+		storageInstancesC,
+		storageAttachmentsC,
+		volumesC,
+		volumeAttachmentsC,
+		filesystemsC,
+		filesystemAttachmentsC,
+
+		// resources and payloads
+		resourcesC,
+		// Payload docs are defined in payload/persistence so the
+		// fields can't be checked here.
+		"payloads",
+
+		// network
+		spacesC,
+		subnetsC,
+		linkLayerDevicesC,
+		linkLayerDevicesRefsC,
+		ipAddressesC,
+		providerIDsC,
+		endpointBindingsC,
+
+		// actions
+		actionsC,
+		actionNotificationsC,
+
+		// SSH host keys are migrated so that clients don't see
+		// changed host key warnings after the migration.
+		sshHostKeysC,
+
+		// Cloud image metadata docs are defined in
+		// state/cloudimagemetadata so the fields can't be checked here.
+		cloudimagemetadataC,
 	)
 
 	ignoredCollections := set.NewStrings(
@@ -91,6 +127,7 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		migrationsC,
 		migrationsStatusC,
 		migrationsActiveC,
+		migrationsMinionSyncC,
 
 		// The container ref document is primarily there to keep track
 		// of a particular machine's containers. The migration format
@@ -110,9 +147,13 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		// separately.
 		modelEntityRefsC,
 
-		// The SSH host keys for each machine will be reported as each
+		// This collection was deprecated before multi-model support
+		// was implemented, so there is nothing in it to migrate.
+		actionresultsC,
+
+		// The block devices of each machine will be reported as each
 		// machine agent starts up.
-		sshHostKeysC,
+		blockDevicesC,
 	)
 
 	// THIS SET WILL BE REMOVED WHEN MIGRATIONS ARE COMPLETE
@@ -121,40 +162,11 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		modelSettingsSourcesC,
 		globalSettingsC,
 
-		// model
-		cloudimagemetadataC,
-
 		// machine
 		rebootC,
 
 		// service / unit
 		charmsC,
-		"payloads",
-		"resources",
-		endpointBindingsC,
-
-		// storage
-		blockDevicesC,
-		filesystemsC,
-		filesystemAttachmentsC,
-		storageInstancesC,
-		storageAttachmentsC,
-		storageConstraintsC,
-		volumesC,
-		volumeAttachmentsC,
-
-		// network
-		ipAddressesC,
-		providerIDsC,
-		linkLayerDevicesC,
-		linkLayerDevicesRefsC,
-		subnetsC,
-		spacesC,
-
-		// actions
-		actionsC,
-		actionNotificationsC,
-		actionresultsC,
 
 		// uncategorised
 		metricsManagerC, // should really be copied across
@@ -263,16 +275,18 @@ func (s *MigrationSuite) TestMachineDocFields(c *gc.C) {
 		"SupportedContainers",
 		"SupportedContainersKnown",
 		"Tools",
+		// Volumes and Filesystems are recreated from the volume and
+		// filesystem attachments.
+		"Volumes",
+		"Filesystems",
 
 		// Ignored at this stage, could be an issue if mongo 3.0 isn't
 		// available.
 		"StopMongoUntilVersion",
 	)
 	todo := set.NewStrings(
-		"Volumes",
 		"NoVote",
 		"Clean",
-		"Filesystems",
 		"HasVote",
 	)
 	s.AssertExportedFields(c, machineDoc{}, fields.Union(todo))
@@ -367,12 +381,11 @@ func (s *MigrationSuite) TestUnitDocFields(c *gc.C) {
 		// TxnRevno isn't migrated.
 		"TxnRevno",
 		"PasswordHash",
-	)
-	todo := set.NewStrings(
+		// StorageAttachmentCount is recreated from the storage
+		// attachments.
 		"StorageAttachmentCount",
 	)
-
-	s.AssertExportedFields(c, unitDoc{}, fields.Union(todo))
+	s.AssertExportedFields(c, unitDoc{}, fields)
 }
 
 func (s *MigrationSuite) TestPortsDocFields(c *gc.C) {
@@ -526,6 +539,324 @@ func (s *MigrationSuite) TestHistoricalStatusDocFields(c *gc.C) {
 	s.AssertExportedFields(c, historicalStatusDoc{}, fields)
 }
 
+func (s *MigrationSuite) TestStorageConstraintsDocFields(c *gc.C) {
+	fields := set.NewStrings(
+		// DocID is the application global key, which is recreated
+		// on import.
+		"DocID",
+		// ModelUUID shouldn't be exported, and is inherited
+		// from the model definition.
+		"ModelUUID",
+		"Constraints",
+	)
+	s.AssertExportedFields(c, storageConstraintsDoc{}, fields)
+	consFields := set.NewStrings(
+		"Pool",
+		"Size",
+		"Count",
+	)
+	s.AssertExportedFields(c, StorageConstraints{}, consFields)
+}
+
+func (s *MigrationSuite) TestStorageInstanceDocFields(c *gc.C) {
+	fields := set.NewStrings(
+		// DocID itself isn't migrated
+		"DocID",
+		// ModelUUID shouldn't be exported, and is inherited
+		// from the model definition.
+		"ModelUUID",
+		// Life isn't exported, only alive.
+		"Life",
+		// AttachmentCount is recreated from the storage attachments.
+		"AttachmentCount",
+
+		"Id",
+		"Kind",
+		"Owner",
+		"StorageName",
+		"CharmURL",
+	)
+	s.AssertExportedFields(c, storageInstanceDoc{}, fields)
+}
+
+func (s *MigrationSuite) TestStorageAttachmentDocFields(c *gc.C) {
+	fields := set.NewStrings(
+		// DocID itself isn't migrated
+		"DocID",
+		// ModelUUID shouldn't be exported, and is inherited
+		// from the model definition.
+		"ModelUUID",
+		// Life isn't exported, only alive.
+		"Life",
+
+		"Unit",
+		"StorageInstance",
+	)
+	s.AssertExportedFields(c, storageAttachmentDoc{}, fields)
+}
+
+func (s *MigrationSuite) TestVolumeDocFields(c *gc.C) {
+	fields := set.NewStrings(
+		// DocID itself isn't migrated
+		"DocID",
+		// ModelUUID shouldn't be exported, and is inherited
+		// from the model definition.
+		"ModelUUID",
+		// Life isn't exported, only alive.
+		"Life",
+		// AttachmentCount is recreated from the volume attachments.
+		"AttachmentCount",
+
+		"Name",
+		"StorageId",
+		"Binding",
+		"Info",
+		"Params",
+	)
+	s.AssertExportedFields(c, volumeDoc{}, fields)
+}
+
+func (s *MigrationSuite) TestVolumeAttachmentDocFields(c *gc.C) {
+	fields := set.NewStrings(
+		// DocID itself isn't migrated
+		"DocID",
+		// ModelUUID shouldn't be exported, and is inherited
+		// from the model definition.
+		"ModelUUID",
+		// Life isn't exported, only alive.
+		"Life",
+
+		"Volume",
+		"Machine",
+		"Info",
+		"Params",
+	)
+	s.AssertExportedFields(c, volumeAttachmentDoc{}, fields)
+}
+
+func (s *MigrationSuite) TestFilesystemDocFields(c *gc.C) {
+	fields := set.NewStrings(
+		// DocID itself isn't migrated
+		"DocID",
+		// ModelUUID shouldn't be exported, and is inherited
+		// from the model definition.
+		"ModelUUID",
+		// Life isn't exported, only alive.
+		"Life",
+		// AttachmentCount is recreated from the filesystem attachments.
+		"AttachmentCount",
+
+		"FilesystemId",
+		"StorageId",
+		"VolumeId",
+		"Binding",
+		"Info",
+		"Params",
+	)
+	s.AssertExportedFields(c, filesystemDoc{}, fields)
+}
+
+func (s *MigrationSuite) TestFilesystemAttachmentDocFields(c *gc.C) {
+	fields := set.NewStrings(
+		// DocID itself isn't migrated
+		"DocID",
+		// ModelUUID shouldn't be exported, and is inherited
+		// from the model definition.
+		"ModelUUID",
+		// Life isn't exported, only alive.
+		"Life",
+		// Usage will be reported by the machine agent.
+		"Usage",
+
+		"Filesystem",
+		"Machine",
+		"Info",
+		"Params",
+	)
+	s.AssertExportedFields(c, filesystemAttachmentDoc{}, fields)
+}
+
+func (s *MigrationSuite) TestSpaceDocFields(c *gc.C) {
+	fields := set.NewStrings(
+		// DocID itself isn't migrated
+		"DocID",
+		// ModelUUID shouldn't be exported, and is inherited
+		// from the model definition.
+		"ModelUUID",
+		// Life isn't exported, only alive.
+		"Life",
+
+		"Name",
+		"IsPublic",
+		"ProviderId",
+	)
+	s.AssertExportedFields(c, spaceDoc{}, fields)
+}
+
+func (s *MigrationSuite) TestSubnetDocFields(c *gc.C) {
+	fields := set.NewStrings(
+		// DocID itself isn't migrated
+		"DocID",
+		// ModelUUID shouldn't be exported, and is inherited
+		// from the model definition.
+		"ModelUUID",
+		// Life isn't exported, only alive.
+		"Life",
+		// IsPublic is never set for subnets.
+		"IsPublic",
+
+		"ProviderId",
+		"CIDR",
+		"VLANTag",
+		"AvailabilityZone",
+		"SpaceName",
+	)
+	s.AssertExportedFields(c, subnetDoc{}, fields)
+}
+
+func (s *MigrationSuite) TestLinkLayerDeviceDocFields(c *gc.C) {
+	fields := set.NewStrings(
+		// DocID itself isn't migrated
+		"DocID",
+		// ModelUUID shouldn't be exported, and is inherited
+		// from the model definition.
+		"ModelUUID",
+
+		"Name",
+		"MTU",
+		"ProviderID",
+		"MachineID",
+		"Type",
+		"MACAddress",
+		"IsAutoStart",
+		"IsUp",
+		"ParentName",
+	)
+	s.AssertExportedFields(c, linkLayerDeviceDoc{}, fields)
+}
+
+func (s *MigrationSuite) TestLinkLayerDevicesRefsDocFields(c *gc.C) {
+	fields := set.NewStrings(
+		// DocID itself isn't migrated
+		"DocID",
+		// ModelUUID shouldn't be exported, and is inherited
+		// from the model definition.
+		"ModelUUID",
+		// NumChildren is recreated from the parent names of the
+		// link-layer devices.
+		"NumChildren",
+	)
+	s.AssertExportedFields(c, linkLayerDevicesRefsDoc{}, fields)
+}
+
+func (s *MigrationSuite) TestIPAddressDocFields(c *gc.C) {
+	fields := set.NewStrings(
+		// DocID itself isn't migrated
+		"DocID",
+		// ModelUUID shouldn't be exported, and is inherited
+		// from the model definition.
+		"ModelUUID",
+
+		"ProviderID",
+		"DeviceName",
+		"MachineID",
+		"SubnetCIDR",
+		"ConfigMethod",
+		"Value",
+		"DNSServers",
+		"DNSSearchDomains",
+		"GatewayAddress",
+	)
+	s.AssertExportedFields(c, ipAddressDoc{}, fields)
+}
+
+func (s *MigrationSuite) TestEndpointBindingsDocFields(c *gc.C) {
+	fields := set.NewStrings(
+		// DocID itself isn't migrated
+		"DocID",
+		// TxnRevno isn't useful for migrations.
+		"TxnRevno",
+
+		"Bindings",
+	)
+	s.AssertExportedFields(c, endpointBindingsDoc{}, fields)
+}
+
+func (s *MigrationSuite) TestResourceDocFields(c *gc.C) {
+	fields := set.NewStrings(
+		// DocID and ID are derived from the application and
+		// resource names.
+		"DocID",
+		"ID",
+		// Pending resources are not migrated, they only exist while
+		// an application is being deployed.
+		"PendingID",
+		// ApplicationID and UnitID come from where the resource is
+		// in the model description.
+		"ApplicationID",
+		"UnitID",
+		// StoragePath is rebuilt on import from the application and
+		// resource names.
+		"StoragePath",
+		// Downloads to units are restarted after migration.
+		"DownloadProgress",
+		// The charm store is considered polled when the model is
+		// imported.
+		"LastPolled",
+
+		"Name",
+		"Type",
+		"Path",
+		"Description",
+		"Origin",
+		"Revision",
+		"Fingerprint",
+		"Size",
+		"Username",
+		"Timestamp",
+	)
+	s.AssertExportedFields(c, resourceDoc{}, fields)
+}
+
+func (s *MigrationSuite) TestActionDocFields(c *gc.C) {
+	fields := set.NewStrings(
+		// DocId is the action id, ModelUUID is inherited from the
+		// model definition.
+		"DocId",
+		"ModelUUID",
+
+		"Receiver",
+		"Name",
+		"Parameters",
+		"Enqueued",
+		"Started",
+		"Completed",
+		"Status",
+		"Message",
+		"Results",
+	)
+	s.AssertExportedFields(c, actionDoc{}, fields)
+}
+
+func (s *MigrationSuite) TestActionNotificationDocFields(c *gc.C) {
+	fields := set.NewStrings(
+		// Notifications are recreated on import for pending actions,
+		// so all of the fields come from the action.
+		"DocId",
+		"ModelUUID",
+		"Receiver",
+		"ActionID",
+	)
+	s.AssertExportedFields(c, actionNotificationDoc{}, fields)
+}
+
+func (s *MigrationSuite) TestSSHHostKeysDocFields(c *gc.C) {
+	fields := set.NewStrings(
+		"Keys",
+	)
+	s.AssertExportedFields(c, sshHostKeysDoc{}, fields)
+}
+
 func (s *MigrationSuite) AssertExportedFields(c *gc.C, doc interface{}, fields set.Strings) {
 	expected := getExportedFields(doc)
 	unknown := expected.Difference(fields)
@@ -205,50 +205,10 @@ func (m ModelArgs) Validate() error {
 // models, perhaps for future use around cross model
 // relations.
 func (st *State) NewModel(args ModelArgs) (_ *Model, _ *State, err error) {
-	if err := args.Validate(); err != nil {
-		return nil, nil, errors.Trace(err)
-	}
-	// For now, the model cloud must be the same as the controller cloud.
-	controllerInfo, err := st.ControllerInfo()
-	if err != nil {
-		return nil, nil, errors.Trace(err)
-	}
-	if controllerInfo.CloudName != args.CloudName {
-		return nil, nil, errors.NewNotValid(
-			nil, fmt.Sprintf("controller cloud %s does not match model cloud %s", controllerInfo.CloudName, args.CloudName))
-	}
-
-	// Ensure that the cloud region is valid, or if one is not specified,
-	// that the cloud does not support regions.
-	controllerCloud, err := st.Cloud(args.CloudName)
-	if err != nil {
-		return nil, nil, errors.Trace(err)
-	}
-	assertCloudRegionOp, err := validateCloudRegion(controllerCloud, args.CloudName, args.CloudRegion)
-	if err != nil {
-		return nil, nil, errors.Trace(err)
-	}
-
-	// Ensure that the cloud credential is valid, or if one is not
-	// specified, that the cloud supports the "empty" authentication
-	// type.
-	owner := args.Owner
-	cloudCredentials, err := st.CloudCredentials(owner, args.CloudName)
+	prereqOps, err := st.newModelPrereqOps(args)
 	if err != nil {
 		return nil, nil, errors.Trace(err)
 	}
-	assertCloudCredentialOp, err := validateCloudCredential(
-		controllerCloud, args.CloudName, cloudCredentials, args.CloudCredential, owner,
-	)
-	if err != nil {
-		return nil, nil, errors.Trace(err)
-	}
-
-	if owner.IsLocal() {
-		if _, err := st.User(owner); err != nil {
-			return nil, nil, errors.Annotate(err, "cannot create model")
-		}
-	}
 
 	uuid := args.Config.UUID()
 	session := st.session.Copy()
@@ -268,10 +228,6 @@ func (st *State) NewModel(args ModelArgs) (_ *Model, _ *State, err error) {
 		return nil, nil, errors.Annotate(err, "failed to create new model")
 	}
 
-	prereqOps := []txn.Op{
-		assertCloudRegionOp,
-		assertCloudCredentialOp,
-	}
 	ops := append(prereqOps, modelOps...)
 	err = newSt.runTransaction(ops)
 	if err == txn.ErrAborted {
@@ -281,6 +237,7 @@ func (st *State) NewModel(args ModelArgs) (_ *Model, _ *State, err error) {
 		// the same "owner" and "name" in the collection. If the txn is
 		// aborted, check if it is due to the unique key restriction.
 		name := args.Config.Name()
+		owner := args.Owner
 		models, closer := st.getCollection(modelsC)
 		defer closer()
 		envCount, countErr := models.Find(bson.D{
@@ -312,6 +269,57 @@ func (st *State) NewModel(args ModelArgs) (_ *Model, _ *State, err error) {
 	return newModel, newSt, nil
 }
 
+// newModelPrereqOps checks that a model can be created from args, and
+// returns the operations that assert that the cloud region and
+// credential checked are still valid when the model is created.
+func (st *State) newModelPrereqOps(args ModelArgs) ([]txn.Op, error) {
+	if err := args.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	// For now, the model cloud must be the same as the controller cloud.
+	controllerInfo, err := st.ControllerInfo()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if controllerInfo.CloudName != args.CloudName {
+		return nil, errors.NewNotValid(
+			nil, fmt.Sprintf("controller cloud %s does not match model cloud %s", controllerInfo.CloudName, args.CloudName))
+	}
+
+	// Ensure that the cloud region is valid, or if one is not specified,
+	// that the cloud does not support regions.
+	controllerCloud, err := st.Cloud(args.CloudName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	assertCloudRegionOp, err := validateCloudRegion(controllerCloud, args.CloudName, args.CloudRegion)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	// Ensure that the cloud credential is valid, or if one is not
+	// specified, that the cloud supports the "empty" authentication
+	// type.
+	owner := args.Owner
+	cloudCredentials, err := st.CloudCredentials(owner, args.CloudName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	assertCloudCredentialOp, err := validateCloudCredential(
+		controllerCloud, args.CloudName, cloudCredentials, args.CloudCredential, owner,
+	)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	if owner.IsLocal() {
+		if _, err := st.User(owner); err != nil {
+			return nil, errors.Annotate(err, "cannot create model")
+		}
+	}
+	return []txn.Op{assertCloudRegionOp, assertCloudCredentialOp}, nil
+}
+
 // validateCloudRegion validates the given region name against the
 // provided Cloud definition, and returns a txn.Op to include in a
 // transaction to assert the same.
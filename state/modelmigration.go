@@ -41,7 +41,7 @@ type ModelMigration interface {
 	SuccessTime() time.Time
 
 	// EndTime returns the time when the migration reached DONE or
-	// REAPFAILED.
+	// ABORTDONE.
 	EndTime() time.Time
 
 	// Phase returns the migration's phase.
@@ -51,6 +51,10 @@ type ModelMigration interface {
 	// last changed.
 	PhaseChangedTime() time.Time
 
+	// PhaseTimes returns the time when the migration entered each of
+	// the phases it has reached so far.
+	PhaseTimes() map[migration.Phase]time.Time
+
 	// StatusMessage returns human readable text about the current
 	// progress of the migration.
 	StatusMessage() string
@@ -62,6 +66,11 @@ type ModelMigration interface {
 	// migration's target controller.
 	TargetInfo() (*migration.TargetInfo, error)
 
+	// Offline returns true if the model has already been imported
+	// into the target controller from a model archive, so that the
+	// migration only needs to move the model's agents to the target.
+	Offline() bool
+
 	// SetPhase sets the phase of the migration. An error will be
 	// returned if the new phase does not follow the current phase or
 	// if the migration is no longer active.
@@ -71,6 +80,32 @@ type ModelMigration interface {
 	// current progress of the migration.
 	SetStatusMessage(text string) error
 
+	// AbortReason returns the status message that was current when
+	// the migration moved to ABORT, or an empty string if the
+	// migration hasn't been aborted.
+	AbortReason() string
+
+	// CharmBytesUploaded returns the number of bytes of charm
+	// archives sent to the target controller so far.
+	CharmBytesUploaded() int64
+
+	// ToolsBytesUploaded returns the number of bytes of agent tools
+	// sent to the target controller so far.
+	ToolsBytesUploaded() int64
+
+	// SetUploadProgress records the number of bytes of charms and
+	// tools sent to the target controller so far.
+	SetUploadProgress(charmBytes, toolsBytes int64) error
+
+	// SubmitMinionReport records a report from a migration minion
+	// worker about the success or failure to complete its actions
+	// for a given migration phase.
+	SubmitMinionReport(tag names.Tag, phase migration.Phase, success bool) error
+
+	// MinionReports returns the agents that have reported success or
+	// failure for the current migration phase.
+	MinionReports() (*MinionReports, error)
+
 	// Refresh updates the contents of the ModelMigration from the
 	// underlying state.
 	Refresh() error
@@ -115,6 +150,10 @@ type modelMigDoc struct {
 	// TargetPassword holds the password to use with TargetAuthTag
 	// when authenticating.
 	TargetPassword string `bson:"target-password"`
+
+	// Offline is true when the model was imported into the target
+	// controller from a model archive rather than by the migration.
+	Offline bool `bson:"offline"`
 }
 
 // modelMigStatusDoc tracks the progress of a migration attempt for a
@@ -150,9 +189,46 @@ type modelMigStatusDoc struct {
 	// as per UnixNano).
 	PhaseChangedTime int64 `bson:"phase-changed-time"`
 
+	// PhaseTimes holds the time that each phase reached was entered
+	// (stored as per UnixNano), keyed by phase name.
+	PhaseTimes map[string]int64 `bson:"phase-times,omitempty"`
+
 	// StatusMessage holds a human readable message about the
 	// migration's progress.
 	StatusMessage string `bson:"status-message"`
+
+	// AbortReason holds the status message that was current when
+	// the migration moved to ABORT.
+	AbortReason string `bson:"abort-reason,omitempty"`
+
+	// CharmBytesUploaded and ToolsBytesUploaded hold the number of
+	// bytes of binaries sent to the target controller so far.
+	CharmBytesUploaded int64 `bson:"charm-bytes-uploaded"`
+	ToolsBytesUploaded int64 `bson:"tools-bytes-uploaded"`
+}
+
+// modelMigMinionSyncDoc records a report from a migration minion
+// worker about its progress for a migration phase. These are written
+// into migrationsMinionSyncC.
+type modelMigMinionSyncDoc struct {
+	// Id has the format "<migration id>:<phase>:<entity tag>".
+	Id string `bson:"_id"`
+
+	MigrationId string `bson:"migration-id"`
+	Phase       string `bson:"phase"`
+	EntityTag   string `bson:"entity-tag"`
+
+	// Time holds the time the report was received (stored as per
+	// UnixNano).
+	Time    int64 `bson:"time"`
+	Success bool  `bson:"success"`
+}
+
+// MinionReports indicates which migration minions have reported
+// success or failure for a migration phase.
+type MinionReports struct {
+	Succeeded []names.Tag
+	Failed    []names.Tag
 }
 
 // Id implements ModelMigration.
@@ -204,11 +280,39 @@ func (mig *modelMigration) PhaseChangedTime() time.Time {
 	return unixNanoToTime0(mig.statusDoc.PhaseChangedTime)
 }
 
+// PhaseTimes implements ModelMigration.
+func (mig *modelMigration) PhaseTimes() map[migration.Phase]time.Time {
+	result := make(map[migration.Phase]time.Time)
+	for name, t := range mig.statusDoc.PhaseTimes {
+		// Unknown phase names are skipped rather than failing
+		// the whole lookup.
+		if phase, ok := migration.ParsePhase(name); ok {
+			result[phase] = unixNanoToTime0(t)
+		}
+	}
+	return result
+}
+
 // StatusMessage implements ModelMigration.
 func (mig *modelMigration) StatusMessage() string {
 	return mig.statusDoc.StatusMessage
 }
 
+// AbortReason implements ModelMigration.
+func (mig *modelMigration) AbortReason() string {
+	return mig.statusDoc.AbortReason
+}
+
+// CharmBytesUploaded implements ModelMigration.
+func (mig *modelMigration) CharmBytesUploaded() int64 {
+	return mig.statusDoc.CharmBytesUploaded
+}
+
+// ToolsBytesUploaded implements ModelMigration.
+func (mig *modelMigration) ToolsBytesUploaded() int64 {
+	return mig.statusDoc.ToolsBytesUploaded
+}
+
 // InitiatedBy implements ModelMigration.
 func (mig *modelMigration) InitiatedBy() string {
 	return mig.doc.InitiatedBy
@@ -229,6 +333,11 @@ func (mig *modelMigration) TargetInfo() (*migration.TargetInfo, error) {
 	}, nil
 }
 
+// Offline implements ModelMigration.
+func (mig *modelMigration) Offline() bool {
+	return mig.doc.Offline
+}
+
 // SetPhase implements ModelMigration.
 func (mig *modelMigration) SetPhase(nextPhase migration.Phase) error {
 	now := GetClock().Now().UnixNano()
@@ -248,15 +357,42 @@ func (mig *modelMigration) SetPhase(nextPhase migration.Phase) error {
 	nextDoc := mig.statusDoc
 	nextDoc.Phase = nextPhase.String()
 	nextDoc.PhaseChangedTime = now
+	nextDoc.PhaseTimes = make(map[string]int64)
+	for name, t := range mig.statusDoc.PhaseTimes {
+		nextDoc.PhaseTimes[name] = t
+	}
+	nextDoc.PhaseTimes[nextDoc.Phase] = now
 	update := bson.M{
-		"phase":              nextDoc.Phase,
-		"phase-changed-time": now,
+		"phase":                        nextDoc.Phase,
+		"phase-changed-time":           now,
+		"phase-times." + nextDoc.Phase: now,
+	}
+	var ops []txn.Op
+	if nextPhase == migration.ABORT {
+		nextDoc.AbortReason = mig.statusDoc.StatusMessage
+		update["abort-reason"] = nextDoc.AbortReason
 	}
 	if nextPhase == migration.SUCCESS {
 		nextDoc.SuccessTime = now
 		update["success-time"] = now
+		// The model now belongs to the target controller, so it
+		// mustn't be changed here, and it can be reaped.
+		ops = append(ops, txn.Op{
+			C:      modelsC,
+			Id:     mig.doc.ModelUUID,
+			Assert: txn.DocExists,
+			Update: bson.M{"$set": bson.M{"migration-mode": MigrationModeExporting}},
+		})
+	}
+	if phase == migration.REAPFAILED && nextPhase == migration.SUCCESS {
+		// The migration is being resumed. Agents which failed to
+		// move to the target controller get to report again.
+		reportOps, err := mig.removeFailedMinionReportOps(migration.SUCCESS)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		ops = append(ops, reportOps...)
 	}
-	var ops []txn.Op
 	if nextPhase.IsTerminal() {
 		nextDoc.EndTime = now
 		update["end-time"] = now
@@ -301,6 +437,126 @@ func (mig *modelMigration) SetStatusMessage(text string) error {
 	return nil
 }
 
+// SetUploadProgress implements ModelMigration.
+func (mig *modelMigration) SetUploadProgress(charmBytes, toolsBytes int64) error {
+	ops := []txn.Op{{
+		C:  migrationsStatusC,
+		Id: mig.statusDoc.Id,
+		Update: bson.M{"$set": bson.M{
+			"charm-bytes-uploaded": charmBytes,
+			"tools-bytes-uploaded": toolsBytes,
+		}},
+		Assert: txn.DocExists,
+	}}
+	if err := mig.st.runTransaction(ops); err != nil {
+		return errors.Annotate(err, "failed to set upload progress")
+	}
+	mig.statusDoc.CharmBytesUploaded = charmBytes
+	mig.statusDoc.ToolsBytesUploaded = toolsBytes
+	return nil
+}
+
+// SubmitMinionReport implements ModelMigration.
+func (mig *modelMigration) SubmitMinionReport(tag names.Tag, phase migration.Phase, success bool) error {
+	docID := mig.minionReportId(phase, tag)
+	doc := modelMigMinionSyncDoc{
+		Id:          docID,
+		MigrationId: mig.Id(),
+		Phase:       phase.String(),
+		EntityTag:   tag.String(),
+		Time:        GetClock().Now().UnixNano(),
+		Success:     success,
+	}
+	ops := []txn.Op{{
+		C:      migrationsMinionSyncC,
+		Id:     docID,
+		Insert: &doc,
+		Assert: txn.DocMissing,
+	}}
+	err := mig.st.runTransaction(ops)
+	if errors.Cause(err) == txn.ErrAborted {
+		// The agent has already reported for this phase. That's
+		// fine as long as the outcome hasn't changed.
+		coll, closer := mig.st.getCollection(migrationsMinionSyncC)
+		defer closer()
+		var existingDoc modelMigMinionSyncDoc
+		err := coll.FindId(docID).Select(bson.M{"success": 1}).One(&existingDoc)
+		if err != nil {
+			return errors.Annotate(err, "checking existing report")
+		}
+		if existingDoc.Success != success {
+			return errors.Errorf("conflicting reports received for %s/%s/%s",
+				mig.Id(), phase.String(), tag)
+		}
+		return nil
+	} else if err != nil {
+		return errors.Annotate(err, "failed to record minion report")
+	}
+	return nil
+}
+
+// MinionReports implements ModelMigration.
+func (mig *modelMigration) MinionReports() (*MinionReports, error) {
+	phase, err := mig.Phase()
+	if err != nil {
+		return nil, errors.Annotate(err, "retrieving phase")
+	}
+
+	coll, closer := mig.st.getCollection(migrationsMinionSyncC)
+	defer closer()
+	query := coll.Find(bson.M{
+		"migration-id": mig.Id(),
+		"phase":        phase.String(),
+	}).Sort("_id")
+	var docs []modelMigMinionSyncDoc
+	if err := query.All(&docs); err != nil {
+		return nil, errors.Annotate(err, "retrieving minion reports")
+	}
+
+	reports := new(MinionReports)
+	for _, doc := range docs {
+		tag, err := names.ParseTag(doc.EntityTag)
+		if err != nil {
+			return nil, errors.Annotatef(err, "minion report %q", doc.Id)
+		}
+		if doc.Success {
+			reports.Succeeded = append(reports.Succeeded, tag)
+		} else {
+			reports.Failed = append(reports.Failed, tag)
+		}
+	}
+	return reports, nil
+}
+
+// removeFailedMinionReportOps returns the operations to remove the
+// reports of agents which failed to complete the given phase.
+func (mig *modelMigration) removeFailedMinionReportOps(phase migration.Phase) ([]txn.Op, error) {
+	coll, closer := mig.st.getCollection(migrationsMinionSyncC)
+	defer closer()
+	var docs []modelMigMinionSyncDoc
+	err := coll.Find(bson.M{
+		"migration-id": mig.Id(),
+		"phase":        phase.String(),
+		"success":      false,
+	}).Select(bson.M{"_id": 1}).All(&docs)
+	if err != nil {
+		return nil, errors.Annotate(err, "retrieving failed minion reports")
+	}
+	ops := make([]txn.Op, len(docs))
+	for i, doc := range docs {
+		ops[i] = txn.Op{
+			C:      migrationsMinionSyncC,
+			Id:     doc.Id,
+			Remove: true,
+		}
+	}
+	return ops, nil
+}
+
+func (mig *modelMigration) minionReportId(phase migration.Phase, tag names.Tag) string {
+	return fmt.Sprintf("%s:%s:%s", mig.Id(), phase.String(), tag.String())
+}
+
 // Refresh implements ModelMigration.
 func (mig *modelMigration) Refresh() error {
 	// Only the status document is updated. The modelMigDoc is static
@@ -324,6 +580,7 @@ func (mig *modelMigration) Refresh() error {
 type ModelMigrationSpec struct {
 	InitiatedBy names.UserTag
 	TargetInfo  migration.TargetInfo
+	Offline     bool
 }
 
 // Validate returns an error if the ModelMigrationSpec contains bad
@@ -383,12 +640,16 @@ func (st *State) CreateModelMigration(spec ModelMigrationSpec) (ModelMigration,
 			TargetCACert:     spec.TargetInfo.CACert,
 			TargetAuthTag:    spec.TargetInfo.AuthTag.String(),
 			TargetPassword:   spec.TargetInfo.Password,
+			Offline:          spec.Offline,
 		}
 		statusDoc = modelMigStatusDoc{
 			Id:               id,
 			StartTime:        now,
 			Phase:            migration.QUIESCE.String(),
 			PhaseChangedTime: now,
+			PhaseTimes: map[string]int64{
+				migration.QUIESCE.String(): now,
+			},
 		}
 		return []txn.Op{{
 			C:      migrationsC,
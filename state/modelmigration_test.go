@@ -72,13 +72,32 @@ func (s *ModelMigrationSuite) TestCreate(c *gc.C) {
 	info, err := mig.TargetInfo()
 	c.Assert(err, jc.ErrorIsNil)
 	c.Check(*info, jc.DeepEquals, s.stdSpec.TargetInfo)
+	c.Check(mig.Offline(), jc.IsFalse)
 
 	assertPhase(c, mig, migration.QUIESCE)
 	c.Check(mig.PhaseChangedTime(), gc.Equals, mig.StartTime())
+	c.Check(mig.PhaseTimes(), jc.DeepEquals, map[migration.Phase]time.Time{
+		migration.QUIESCE: mig.StartTime(),
+	})
+	c.Check(mig.AbortReason(), gc.Equals, "")
+	c.Check(mig.CharmBytesUploaded(), gc.Equals, int64(0))
+	c.Check(mig.ToolsBytesUploaded(), gc.Equals, int64(0))
 
 	assertMigrationActive(c, s.State2)
 }
 
+func (s *ModelMigrationSuite) TestCreateOffline(c *gc.C) {
+	spec := s.stdSpec
+	spec.Offline = true
+	mig, err := s.State2.CreateModelMigration(spec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mig.Offline(), jc.IsTrue)
+
+	mig2, err := s.State2.GetModelMigration()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mig2.Offline(), jc.IsTrue)
+}
+
 func (s *ModelMigrationSuite) TestIdSequencesAreIndependent(c *gc.C) {
 	st2 := s.State2
 	st3 := s.Factory.MakeModel(c, nil)
@@ -332,11 +351,70 @@ func (s *ModelMigrationSuite) TestABORTCleanup(c *gc.C) {
 	s.assertMigrationCleanedUp(c, mig)
 }
 
+func (s *ModelMigrationSuite) TestSUCCESSMarksModelExporting(c *gc.C) {
+	mig := s.advanceTo(c, migration.VALIDATION)
+	s.assertMigrationMode(c, state.MigrationModeActive)
+
+	c.Assert(mig.SetPhase(migration.SUCCESS), jc.ErrorIsNil)
+	s.assertMigrationMode(c, state.MigrationModeExporting)
+}
+
+func (s *ModelMigrationSuite) TestREAPFAILEDStaysActive(c *gc.C) {
+	mig := s.advanceTo(c, migration.REAPFAILED)
+
+	// The migration waits for an operator to resume or clean it
+	// up, so it's still active.
+	assertPhase(c, mig, migration.REAPFAILED)
+	c.Check(mig.EndTime().IsZero(), jc.IsTrue)
+	assertMigrationActive(c, s.State2)
+}
+
 func (s *ModelMigrationSuite) TestREAPFAILEDCleanup(c *gc.C) {
+	mig := s.advanceTo(c, migration.REAPFAILED)
+
+	s.clock.Advance(time.Millisecond)
+	c.Assert(mig.SetPhase(migration.DONE), jc.ErrorIsNil)
+	s.assertMigrationCleanedUp(c, mig)
+}
+
+func (s *ModelMigrationSuite) TestREAPFAILEDResume(c *gc.C) {
+	mig := s.advanceTo(c, migration.SUCCESS)
+	m0 := names.NewMachineTag("0")
+	m1 := names.NewMachineTag("1")
+	c.Assert(mig.SubmitMinionReport(m0, migration.SUCCESS, true), jc.ErrorIsNil)
+	c.Assert(mig.SubmitMinionReport(m1, migration.SUCCESS, false), jc.ErrorIsNil)
+	for _, phase := range []migration.Phase{
+		migration.LOGTRANSFER,
+		migration.REAP,
+		migration.REAPFAILED,
+	} {
+		s.clock.Advance(time.Millisecond)
+		c.Assert(mig.SetPhase(phase), jc.ErrorIsNil)
+	}
+
+	s.clock.Advance(time.Millisecond)
+	c.Assert(mig.SetPhase(migration.SUCCESS), jc.ErrorIsNil)
+	assertPhase(c, mig, migration.SUCCESS)
+	c.Check(mig.SuccessTime(), gc.Equals, s.clock.Now())
+	assertMigrationActive(c, s.State2)
+	s.assertMigrationMode(c, state.MigrationModeExporting)
+
+	// The agent which failed to move can report again, but the
+	// report of the agent which moved is kept.
+	reports, err := mig.MinionReports()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(reports.Succeeded, jc.DeepEquals, []names.Tag{m0})
+	c.Check(reports.Failed, gc.HasLen, 0)
+	c.Assert(mig.SubmitMinionReport(m1, migration.SUCCESS, true), jc.ErrorIsNil)
+}
+
+// advanceTo creates a migration and moves it through the phases of a
+// successful migration until it reaches the given phase, or
+// REAPFAILED after REAP.
+func (s *ModelMigrationSuite) advanceTo(c *gc.C, target migration.Phase) state.ModelMigration {
 	mig, err := s.State2.CreateModelMigration(s.stdSpec)
 	c.Assert(err, jc.ErrorIsNil)
 
-	// Advance the migration to REAPFAILED.
 	phases := []migration.Phase{
 		migration.READONLY,
 		migration.PRECHECK,
@@ -350,9 +428,18 @@ func (s *ModelMigrationSuite) TestREAPFAILEDCleanup(c *gc.C) {
 	for _, phase := range phases {
 		s.clock.Advance(time.Millisecond)
 		c.Assert(mig.SetPhase(phase), jc.ErrorIsNil)
+		if phase == target {
+			return mig
+		}
 	}
+	c.Fatalf("phase %s not reached", target)
+	return nil
+}
 
-	s.assertMigrationCleanedUp(c, mig)
+func (s *ModelMigrationSuite) assertMigrationMode(c *gc.C, expected state.MigrationMode) {
+	model, err := s.State2.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(model.MigrationMode(), gc.Equals, expected)
 }
 
 func (s *ModelMigrationSuite) assertMigrationCleanedUp(c *gc.C, mig state.ModelMigration) {
@@ -409,6 +496,118 @@ func (s *ModelMigrationSuite) TestStatusMessage(c *gc.C) {
 	c.Check(mig2.StatusMessage(), gc.Equals, "foo bar")
 }
 
+func (s *ModelMigrationSuite) TestPhaseTimes(c *gc.C) {
+	mig, err := s.State2.CreateModelMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	startTime := s.clock.Now()
+
+	s.clock.Advance(time.Second)
+	c.Assert(mig.SetPhase(migration.READONLY), jc.ErrorIsNil)
+	readOnlyTime := s.clock.Now()
+	s.clock.Advance(time.Second)
+	c.Assert(mig.SetPhase(migration.PRECHECK), jc.ErrorIsNil)
+	precheckTime := s.clock.Now()
+
+	expected := map[migration.Phase]time.Time{
+		migration.QUIESCE:  startTime,
+		migration.READONLY: readOnlyTime,
+		migration.PRECHECK: precheckTime,
+	}
+	c.Check(mig.PhaseTimes(), jc.DeepEquals, expected)
+
+	// Ensure the times were persisted.
+	mig2, err := s.State2.GetModelMigration()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mig2.PhaseTimes(), jc.DeepEquals, expected)
+}
+
+func (s *ModelMigrationSuite) TestAbortReason(c *gc.C) {
+	mig, err := s.State2.CreateModelMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(mig.SetStatusMessage("import failed: boom"), jc.ErrorIsNil)
+	c.Check(mig.AbortReason(), gc.Equals, "")
+
+	c.Assert(mig.SetPhase(migration.ABORT), jc.ErrorIsNil)
+	c.Check(mig.AbortReason(), gc.Equals, "import failed: boom")
+
+	// Later status messages don't change the recorded reason.
+	c.Assert(mig.SetStatusMessage("cleaning up"), jc.ErrorIsNil)
+	c.Assert(mig.SetPhase(migration.ABORTDONE), jc.ErrorIsNil)
+
+	mig2, err := s.State2.GetModelMigration()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mig2.AbortReason(), gc.Equals, "import failed: boom")
+}
+
+func (s *ModelMigrationSuite) TestUploadProgress(c *gc.C) {
+	mig, err := s.State2.CreateModelMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = mig.SetUploadProgress(1234, 5678)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mig.CharmBytesUploaded(), gc.Equals, int64(1234))
+	c.Check(mig.ToolsBytesUploaded(), gc.Equals, int64(5678))
+
+	mig2, err := s.State2.GetModelMigration()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mig2.CharmBytesUploaded(), gc.Equals, int64(1234))
+	c.Check(mig2.ToolsBytesUploaded(), gc.Equals, int64(5678))
+}
+
+func (s *ModelMigrationSuite) TestMinionReports(c *gc.C) {
+	mig, err := s.State2.CreateModelMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	reports, err := mig.MinionReports()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(reports.Succeeded, gc.HasLen, 0)
+	c.Check(reports.Failed, gc.HasLen, 0)
+
+	m0 := names.NewMachineTag("0")
+	m1 := names.NewMachineTag("1")
+	u0 := names.NewUnitTag("foo/0")
+	c.Assert(mig.SubmitMinionReport(m0, migration.QUIESCE, true), jc.ErrorIsNil)
+	c.Assert(mig.SubmitMinionReport(m1, migration.QUIESCE, false), jc.ErrorIsNil)
+	c.Assert(mig.SubmitMinionReport(u0, migration.QUIESCE, true), jc.ErrorIsNil)
+	// Reports for other phases aren't included.
+	c.Assert(mig.SubmitMinionReport(m0, migration.READONLY, true), jc.ErrorIsNil)
+
+	reports, err = mig.MinionReports()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(reports.Succeeded, jc.SameContents, []names.Tag{m0, u0})
+	c.Check(reports.Failed, jc.DeepEquals, []names.Tag{m1})
+
+	c.Assert(mig.SetPhase(migration.READONLY), jc.ErrorIsNil)
+	reports, err = mig.MinionReports()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(reports.Succeeded, jc.DeepEquals, []names.Tag{m0})
+	c.Check(reports.Failed, gc.HasLen, 0)
+}
+
+func (s *ModelMigrationSuite) TestDuplicateMinionReport(c *gc.C) {
+	mig, err := s.State2.CreateModelMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	tag := names.NewMachineTag("42")
+	c.Assert(mig.SubmitMinionReport(tag, migration.QUIESCE, true), jc.ErrorIsNil)
+	c.Assert(mig.SubmitMinionReport(tag, migration.QUIESCE, true), jc.ErrorIsNil)
+
+	reports, err := mig.MinionReports()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(reports.Succeeded, jc.DeepEquals, []names.Tag{tag})
+}
+
+func (s *ModelMigrationSuite) TestConflictingMinionReport(c *gc.C) {
+	mig, err := s.State2.CreateModelMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	tag := names.NewMachineTag("42")
+	c.Assert(mig.SubmitMinionReport(tag, migration.QUIESCE, true), jc.ErrorIsNil)
+	err = mig.SubmitMinionReport(tag, migration.QUIESCE, false)
+	c.Check(err, gc.ErrorMatches, "conflicting reports received for .+:0/QUIESCE/machine-42")
+}
+
 func (s *ModelMigrationSuite) TestWatchForModelMigration(c *gc.C) {
 	// Start watching for migration.
 	w, wc := s.createWatcher(c, s.State2)
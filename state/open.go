@@ -227,6 +227,7 @@ func Initialize(args InitializeParams) (_ *State, err error) {
 	if err != nil {
 		return nil, err
 	}
+	controllerConfig, backupStorageCredentials := splitBackupStorageCredentials(args.ControllerConfig)
 
 	ops := []txn.Op{
 		createInitialUserOp(st, args.ControllerModelArgs.Owner, args.MongoInfo.Password, salt),
@@ -258,7 +259,8 @@ func Initialize(args InitializeParams) (_ *State, err error) {
 			Assert: txn.DocMissing,
 			Insert: &hostedModelCountDoc{},
 		},
-		createSettingsOp(controllersC, controllerSettingsGlobalKey, args.ControllerConfig),
+		createSettingsOp(controllersC, controllerSettingsGlobalKey, controllerConfig),
+		createBackupStorageCredentialsOp(backupStorageCredentials),
 		createSettingsOp(globalSettingsC, controllerInheritedSettingsGlobalKey, args.ControllerInheritedConfig),
 	}
 	if len(args.CloudCredentials) > 0 {
@@ -357,6 +357,19 @@ func removeSettings(st *State, collection, key string) error {
 	return nil
 }
 
+// replaceSettings replaces the contents of the Settings for key with
+// the supplied values.
+func replaceSettings(st *State, collection, key string, values map[string]interface{}) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		op, _, err := replaceSettingsOp(st, collection, key, values)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{op}, nil
+	}
+	return st.run(buildTxn)
+}
+
 func removeSettingsOp(collection, key string) txn.Op {
 	return txn.Op{
 		C:      collection,
@@ -483,6 +496,11 @@ func (s *StateSettings) RemoveSettings(key string) error {
 	return removeSettings(s.st, s.collection, key)
 }
 
+// ReplaceSettings exposes replaceSettings on state for use outside the state package.
+func (s *StateSettings) ReplaceSettings(key string, settings map[string]interface{}) error {
+	return replaceSettings(s.st, s.collection, key, settings)
+}
+
 // ListSettings exposes listSettings on state for use outside the state package.
 func (s *StateSettings) ListSettings(keyPrefix string) (map[string]map[string]interface{}, error) {
 	return listSettings(s.st, s.collection, keyPrefix)
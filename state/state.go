@@ -156,6 +156,13 @@ func (st *State) RemoveImportingModelDocs() error {
 	return st.removeAllModelDocs(bson.D{{"migration-mode", MigrationModeImporting}})
 }
 
+// RemoveExportingModelDocs removes all documents from multi-model
+// collections for the current model. This method asserts that the
+// model's migration mode is "exporting".
+func (st *State) RemoveExportingModelDocs() error {
+	return st.removeAllModelDocs(bson.D{{"migration-mode", MigrationModeExporting}})
+}
+
 func (st *State) removeAllModelDocs(modelAssertion bson.D) error {
 	env, err := st.Model()
 	if err != nil {
@@ -1045,6 +1052,17 @@ func (st *State) AddApplication(args AddApplicationArgs) (_ *Application, err er
 	}
 	ops = append(ops, peerOps...)
 
+	// Collect shared storage addition operations. Each of the initial
+	// units is attached to the shared storage instances below.
+	sharedStorageOps, sharedStorage, err := createSharedStorageOps(
+		st, svc.ApplicationTag(), args.Charm.Meta(), args.Charm.URL(),
+		args.Storage, args.NumUnits,
+	)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops = append(ops, sharedStorageOps...)
+
 	if len(args.Resources) > 0 {
 		// Collect pending resource resolution operations.
 		resources, err := st.Resources()
@@ -1060,7 +1078,11 @@ func (st *State) AddApplication(args AddApplicationArgs) (_ *Application, err er
 
 	// Collect unit-adding operations.
 	for x := 0; x < args.NumUnits; x++ {
-		unitName, unitOps, err := svc.addServiceUnitOps(applicationAddUnitOpsArgs{cons: args.Constraints, storageCons: args.Storage})
+		unitName, unitOps, err := svc.addServiceUnitOps(applicationAddUnitOpsArgs{
+			cons:          args.Constraints,
+			storageCons:   args.Storage,
+			sharedStorage: sharedStorage,
+		})
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
@@ -433,9 +433,6 @@ func createStorageOps(
 		}
 	}
 
-	// TODO(axw) create storage attachments for each shared storage
-	// instance owned by the service.
-	//
 	// TODO(axw) prevent creation of shared storage after service
 	// creation, because the only sane time to add storage attachments
 	// is when units are added to said service.
@@ -443,6 +440,138 @@ func createStorageOps(
 	return ops, numStorageAttachments, nil
 }
 
+// createSharedStorageOps returns txn.Ops for creating the shared storage
+// instances of a new application, along with the tags of the storage
+// instances to be created. Each shared storage instance is assigned a
+// model-scoped filesystem, which is attached to the machine of each of
+// the application's units as the units are assigned.
+//
+// The storage instances are created with numUnits attachments, which
+// the caller must create for the application's initial units.
+func createSharedStorageOps(
+	st *State,
+	application names.ApplicationTag,
+	charmMeta *charm.Meta,
+	curl *charm.URL,
+	cons map[string]StorageConstraints,
+	numUnits int,
+) ([]txn.Op, []names.StorageTag, error) {
+	// Create storage instances in order of name, to simplify testing.
+	storageNames := set.NewStrings()
+	for name := range cons {
+		storageNames.Add(name)
+	}
+
+	var ops []txn.Op
+	var storageTags []names.StorageTag
+	for _, store := range storageNames.SortedValues() {
+		charmStorage, ok := charmMeta.Storage[store]
+		if !ok {
+			return nil, nil, errors.NotFoundf("charm storage %q", store)
+		}
+		if !charmStorage.Shared {
+			continue
+		}
+		if charmStorage.Type != charm.StorageFilesystem {
+			return nil, nil, errors.NotSupportedf("shared %s storage", charmStorage.Type)
+		}
+		cons := cons[store]
+		for i := uint64(0); i < cons.Count; i++ {
+			id, err := newStorageInstanceId(st, store)
+			if err != nil {
+				return nil, nil, errors.Annotate(err, "cannot generate storage instance name")
+			}
+			storageTag := names.NewStorageTag(id)
+			ops = append(ops, txn.Op{
+				C:      storageInstancesC,
+				Id:     id,
+				Assert: txn.DocMissing,
+				Insert: &storageInstanceDoc{
+					Id:              id,
+					Kind:            StorageKindFilesystem,
+					Owner:           application.String(),
+					StorageName:     store,
+					AttachmentCount: numUnits,
+					CharmURL:        curl,
+				},
+			})
+			filesystemOps, _, err := st.addSharedFilesystemOps(FilesystemParams{
+				storage: storageTag,
+				binding: storageTag,
+				Pool:    cons.Pool,
+				Size:    cons.Size,
+			})
+			if err != nil {
+				return nil, nil, errors.Annotatef(
+					err, "creating filesystem for storage %s", id,
+				)
+			}
+			ops = append(ops, filesystemOps...)
+			storageTags = append(storageTags, storageTag)
+		}
+	}
+	return ops, storageTags, nil
+}
+
+// sharedStorageInstances returns the tags of the Alive shared storage
+// instances owned by the specified application.
+func sharedStorageInstances(st *State, application names.ApplicationTag) ([]names.StorageTag, error) {
+	coll, closer := st.getCollection(storageInstancesC)
+	defer closer()
+
+	var docs []storageInstanceDoc
+	query := bson.D{{"owner", application.String()}, {"life", Alive}}
+	if err := coll.Find(query).Select(bson.D{{"id", true}}).All(&docs); err != nil {
+		return nil, errors.Annotatef(err, "cannot get shared storage instances for %s", application.Id())
+	}
+	storageTags := make([]names.StorageTag, len(docs))
+	for i, doc := range docs {
+		storageTags[i] = names.NewStorageTag(doc.Id)
+	}
+	return storageTags, nil
+}
+
+// sharedStorageIncrefOps returns txn.Ops to increment the attachment
+// count of each of the specified shared storage instances, which must
+// be Alive.
+func sharedStorageIncrefOps(storageTags []names.StorageTag) []txn.Op {
+	ops := make([]txn.Op, len(storageTags))
+	for i, tag := range storageTags {
+		ops[i] = txn.Op{
+			C:      storageInstancesC,
+			Id:     tag.Id(),
+			Assert: isAliveDoc,
+			Update: bson.D{{"$inc", bson.D{{"attachmentcount", 1}}}},
+		}
+	}
+	return ops
+}
+
+// removeSharedStorageInstancesOps returns txn.Ops to remove the shared
+// storage instances owned by the specified application, which must have
+// no remaining attachments. The filesystems bound to the storage
+// instances will be destroyed.
+func removeSharedStorageInstancesOps(st *State, application names.ApplicationTag) ([]txn.Op, error) {
+	coll, closer := st.getCollection(storageInstancesC)
+	defer closer()
+
+	var docs []storageInstanceDoc
+	query := bson.D{{"owner", application.String()}}
+	if err := coll.Find(query).Select(bson.D{{"id", true}}).All(&docs); err != nil {
+		return nil, errors.Annotatef(err, "cannot get shared storage instances for %s", application.Id())
+	}
+	var ops []txn.Op
+	for _, doc := range docs {
+		hasNoAttachments := bson.D{{"attachmentcount", 0}}
+		siOps, err := removeStorageInstanceOps(st, names.NewStorageTag(doc.Id), hasNoAttachments)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, siOps...)
+	}
+	return ops, nil
+}
+
 // unitAssignedMachineStorageOps returns ops for creating volumes, filesystems
 // and their attachments to the machine that the specified unit is assigned to,
 // corresponding to the specified storage instance.
@@ -669,6 +798,16 @@ func removeStorageAttachmentOps(
 		Assert: txn.DocExists,
 		Update: bson.D{{"$inc", bson.D{{"storageattachmentcount", -1}}}},
 	}}
+	if si.doc.Kind == StorageKindFilesystem && si.doc.Owner != names.NewUnitTag(s.doc.Unit).String() {
+		// The storage instance is shared by the units of an
+		// application, so the filesystem must be detached from
+		// the unit's machine if no other unit there needs it.
+		detachOps, err := detachSharedFilesystemOps(st, s.Unit(), si)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, detachOps...)
+	}
 	if si.doc.AttachmentCount == 1 {
 		var hasLastRef bson.D
 		if si.doc.Life == Dying {
@@ -715,6 +854,64 @@ func removeStorageAttachmentOps(
 	return ops, nil
 }
 
+// detachSharedFilesystemOps returns txn.Ops to detach the filesystem of
+// the shared storage instance from the machine that the specified unit
+// is assigned to, if no other unit on the machine is attached to the
+// storage instance.
+func detachSharedFilesystemOps(st *State, unit names.UnitTag, si *storageInstance) ([]txn.Op, error) {
+	machineId, err := assignedMachineId(st, unit)
+	if errors.IsNotFound(err) || errors.IsNotAssigned(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	filesystem, err := st.storageInstanceFilesystem(si.StorageTag())
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	attachments, err := st.StorageAttachments(si.StorageTag())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, a := range attachments {
+		if a.Unit() == unit {
+			continue
+		}
+		otherMachineId, err := assignedMachineId(st, a.Unit())
+		if errors.IsNotFound(err) || errors.IsNotAssigned(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if otherMachineId == machineId {
+			return nil, nil
+		}
+	}
+	machineTag := names.NewMachineTag(machineId)
+	attachment, err := st.FilesystemAttachment(machineTag, filesystem.FilesystemTag())
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if attachment.Life() != Alive {
+		return nil, nil
+	}
+	return detachFilesystemOps(machineTag, filesystem.FilesystemTag()), nil
+}
+
+// assignedMachineId returns the ID of the machine that the specified
+// unit is assigned to.
+func assignedMachineId(st *State, tag names.UnitTag) (string, error) {
+	u, err := st.Unit(tag.Id())
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return u.AssignedMachineId()
+}
+
 // removeStorageInstancesOps returns the transaction operations to remove all
 // storage instances owned by the specified entity.
 func removeStorageInstancesOps(st *State, owner names.Tag) ([]txn.Op, error) {
@@ -829,11 +1026,9 @@ func validateStorageConstraintsAgainstCharm(
 			return errors.Errorf("charm %q has no store called %q", charmMeta.Name, name)
 		}
 		if charmStorage.Shared {
-			// TODO(axw) implement shared storage support.
-			return errors.Errorf(
-				"charm %q store %q: shared storage support not implemented",
-				charmMeta.Name, name,
-			)
+			if err := validateSharedStoragePool(st, cons.Pool, charmStorage); err != nil {
+				return errors.Annotatef(err, "charm %q store %q", charmMeta.Name, name)
+			}
 		}
 		if cons.Count < uint64(charmStorage.CountMin) {
 			return errors.Errorf(
@@ -928,6 +1123,27 @@ func validateStoragePool(
 	return nil
 }
 
+// validateSharedStoragePool validates that the storage pool may be used
+// for the specified shared charm storage. Shared storage is only supported
+// for filesystems, using a provider whose filesystems may be attached to
+// multiple machines.
+func validateSharedStoragePool(st *State, poolName string, charmStorage charm.Storage) error {
+	if charmStorage.Type != charm.StorageFilesystem {
+		return errors.NotSupportedf("shared %s storage", charmStorage.Type)
+	}
+	if poolName == "" {
+		return errors.New("pool name is required")
+	}
+	providerType, provider, err := poolStorageProvider(st, poolName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !storage.IsSharedFilesystemProvider(provider) {
+		return errors.Errorf("%q provider does not support shared filesystems", providerType)
+	}
+	return nil
+}
+
 func poolStorageProvider(st *State, poolName string) (storage.ProviderType, storage.Provider, error) {
 	poolManager := poolmanager.New(NewStateSettings(st))
 	pool, err := poolManager.Get(poolName)
@@ -953,6 +1169,43 @@ func poolStorageProvider(st *State, poolName string) (storage.ProviderType, stor
 	return providerType, provider, nil
 }
 
+// StoragePoolInUse reports whether or not the named storage pool is
+// referenced by any volume, filesystem or storage constraints in the
+// model.
+func (st *State) StoragePoolInUse(name string) (bool, error) {
+	poolRefs := bson.D{{"$or", []bson.D{
+		{{"params.pool", name}},
+		{{"info.pool", name}},
+	}}}
+	for _, collName := range []string{volumesC, filesystemsC} {
+		coll, closer := st.getCollection(collName)
+		n, err := coll.Find(poolRefs).Count()
+		closer()
+		if err != nil {
+			return false, errors.Annotatef(err, "counting %s", collName)
+		}
+		if n > 0 {
+			return true, nil
+		}
+	}
+
+	coll, closer := st.getCollection(storageConstraintsC)
+	defer closer()
+	var doc storageConstraintsDoc
+	iter := coll.Find(nil).Iter()
+	for iter.Next(&doc) {
+		for _, cons := range doc.Constraints {
+			if cons.Pool == name {
+				return true, errors.Trace(iter.Close())
+			}
+		}
+	}
+	if err := iter.Close(); err != nil {
+		return false, errors.Annotate(err, "reading storage constraints")
+	}
+	return false, nil
+}
+
 // ErrNoDefaultStoragePool is returned when a storage pool is required but none
 // is specified nor available as a default.
 var ErrNoDefaultStoragePool = fmt.Errorf("no storage pool specifed and no default available")
@@ -54,15 +54,24 @@ func (s *StorageStateSuiteBase) SetUpSuite(c *gc.C) {
 	registry.RegisterProvider("static", &dummy.StorageProvider{
 		IsDynamic: false,
 	})
+	registry.RegisterProvider("environscoped-shared", &dummy.StorageProvider{
+		StorageScope: storage.ScopeEnviron,
+		SupportsFunc: func(k storage.StorageKind) bool {
+			return k == storage.StorageKindFilesystem
+		},
+		IsDynamic: true,
+		IsShared:  true,
+	})
 	registry.RegisterEnvironStorageProviders(
 		"someprovider", "environscoped", "machinescoped",
-		"environscoped-block", "static",
+		"environscoped-block", "static", "environscoped-shared",
 	)
 	s.AddCleanup(func(c *gc.C) {
 		registry.RegisterProvider("environscoped", nil)
 		registry.RegisterProvider("machinescoped", nil)
 		registry.RegisterProvider("environscoped-block", nil)
 		registry.RegisterProvider("static", nil)
+		registry.RegisterProvider("environscoped-shared", nil)
 	})
 }
 
@@ -923,3 +932,47 @@ func (s *StorageStateSuite) testStorageLocationConflict(c *gc.C, first, second,
 // - StorageInstance without attachments is removed by Destroy
 // - concurrent add-unit and StorageAttachment removal does not
 //   remove storage instance.
+
+func (s *StorageStateSuite) TestStoragePoolInUse(c *gc.C) {
+	inUse, err := s.State.StoragePoolInUse("loop-pool")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(inUse, jc.IsFalse)
+
+	s.setupSingleStorage(c, "block", "loop-pool")
+	inUse, err = s.State.StoragePoolInUse("loop-pool")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(inUse, jc.IsTrue)
+
+	inUse, err = s.State.StoragePoolInUse("persistent-block")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(inUse, jc.IsFalse)
+}
+
+func (s *StorageStateSuite) TestWatchStoragePools(c *gc.C) {
+	w := s.State.WatchStoragePools()
+	defer testing.AssertStop(c, w)
+	wc := testing.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChange("loop-pool", "persistent-block")
+	wc.AssertNoChange()
+
+	pm := poolmanager.New(state.NewStateSettings(s.State))
+	_, err := pm.Create("new-pool", provider.LoopProviderType, map[string]interface{}{})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange("new-pool")
+	wc.AssertNoChange()
+
+	_, err = pm.Replace("loop-pool", map[string]interface{}{"foo": "bar"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange("loop-pool")
+	wc.AssertNoChange()
+
+	err = pm.Delete("persistent-block")
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange("persistent-block")
+	wc.AssertNoChange()
+
+	// Settings that aren't storage pools are ignored.
+	err = state.NewStateSettings(s.State).CreateSettings("r#1", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+}
@@ -4,9 +4,11 @@
 package state
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/juju/errors"
+	"github.com/juju/juju/core/migration"
 	"github.com/juju/juju/status"
 	"github.com/juju/loggo"
 	"gopkg.in/juju/names.v2"
@@ -249,3 +251,78 @@ func addDefaultBindingsToServices(st *State) error {
 func AddDefaultEndpointBindingsToServices(st *State) error {
 	return runForAllEnvStates(st, addDefaultBindingsToServices)
 }
+
+// misnamedMigrationPhases maps the name each of the IMPORT and
+// VALIDATION migration phases was stored under, before the phases
+// were named correctly, to its correct name.
+var misnamedMigrationPhases = map[string]string{
+	migration.VALIDATION.String(): migration.IMPORT.String(),
+	migration.IMPORT.String():     migration.VALIDATION.String(),
+}
+
+// RenameMigrationPhases corrects the IMPORT and VALIDATION phase names
+// stored for model migrations by a controller that named those phases
+// the wrong way around. Status docs written by such a controller have
+// no phase times, as those were recorded along with the corrected
+// names. Setting them marks each doc as renamed.
+func RenameMigrationPhases(st *State) error {
+	statuses, statusCloser := st.getRawCollection(migrationsStatusC)
+	defer statusCloser()
+	reports, reportCloser := st.getRawCollection(migrationsMinionSyncC)
+	defer reportCloser()
+
+	noPhaseTimes := bson.D{{"phase-times", bson.D{{"$exists", false}}}}
+	var docs []modelMigStatusDoc
+	if err := statuses.Find(noPhaseTimes).All(&docs); err != nil {
+		return errors.Annotate(err, "reading migration statuses")
+	}
+
+	var ops []txn.Op
+	for _, doc := range docs {
+		phase := doc.Phase
+		if name, ok := misnamedMigrationPhases[phase]; ok {
+			phase = name
+		}
+		upgradesLogger.Debugf("renaming phases of migration %s", doc.Id)
+		ops = append(ops, txn.Op{
+			C:      migrationsStatusC,
+			Id:     doc.Id,
+			Assert: noPhaseTimes,
+			Update: bson.D{{"$set", bson.D{
+				{"phase", phase},
+				{"phase-times", map[string]int64{phase: doc.PhaseChangedTime}},
+			}}},
+		})
+
+		var reportDocs []modelMigMinionSyncDoc
+		err := reports.Find(bson.D{
+			{"migration-id", doc.Id},
+			{"phase", bson.D{{"$in", []string{
+				migration.IMPORT.String(),
+				migration.VALIDATION.String(),
+			}}}},
+		}).All(&reportDocs)
+		if err != nil {
+			return errors.Annotatef(err, "reading minion reports for migration %s", doc.Id)
+		}
+		for _, report := range reportDocs {
+			// The phase is part of the report's id, so the report
+			// is replaced rather than updated.
+			ops = append(ops, txn.Op{
+				C:      migrationsMinionSyncC,
+				Id:     report.Id,
+				Assert: txn.DocExists,
+				Remove: true,
+			})
+			report.Phase = misnamedMigrationPhases[report.Phase]
+			report.Id = fmt.Sprintf("%s:%s:%s", report.MigrationId, report.Phase, report.EntityTag)
+			ops = append(ops, txn.Op{
+				C:      migrationsMinionSyncC,
+				Id:     report.Id,
+				Assert: txn.DocMissing,
+				Insert: report,
+			})
+		}
+	}
+	return st.runRawTransaction(ops)
+}
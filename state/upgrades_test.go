@@ -4,6 +4,7 @@
 package state
 
 import (
+	"strings"
 	"time"
 
 	"github.com/juju/errors"
@@ -586,3 +587,75 @@ func (s *upgradesSuite) TestAddDefaultEndpointBindingsToServices(c *gc.C) {
 func (s *upgradesSuite) TestAddDefaultEndpointBindingsToServicesIdempotent(c *gc.C) {
 	s.testAddDefaultEndpointBindingsToServices(c, true)
 }
+
+func (s *upgradesSuite) TestRenameMigrationPhases(c *gc.C) {
+	s.addLegacyDoc(c, migrationsStatusC, bson.M{
+		"_id":                "uuid:0",
+		"phase":              "VALIDATION",
+		"phase-changed-time": int64(1000),
+	})
+	s.addLegacyDoc(c, migrationsStatusC, bson.M{
+		"_id":                "uuid:1",
+		"phase":              "DONE",
+		"phase-changed-time": int64(2000),
+	})
+	s.addLegacyDoc(c, migrationsStatusC, bson.M{
+		"_id":                "uuid:2",
+		"phase":              "IMPORT",
+		"phase-changed-time": int64(3000),
+		"phase-times":        bson.M{"IMPORT": int64(3000)},
+	})
+	for _, id := range []string{
+		"uuid:0:QUIESCE:machine-0",
+		"uuid:0:IMPORT:machine-0",
+		"uuid:2:VALIDATION:machine-0",
+	} {
+		parts := strings.Split(id, ":")
+		s.addLegacyDoc(c, migrationsMinionSyncC, bson.M{
+			"_id":          id,
+			"migration-id": parts[0] + ":" + parts[1],
+			"phase":        parts[2],
+			"entity-tag":   parts[3],
+			"success":      true,
+		})
+	}
+
+	statuses, closer := s.state.getRawCollection(migrationsStatusC)
+	defer closer()
+	reports, closer := s.state.getRawCollection(migrationsMinionSyncC)
+	defer closer()
+	check := func() {
+		var doc modelMigStatusDoc
+		s.FindId(c, statuses, "uuid:0", &doc)
+		c.Check(doc.Phase, gc.Equals, "IMPORT")
+		c.Check(doc.PhaseTimes, jc.DeepEquals, map[string]int64{"IMPORT": 1000})
+		s.FindId(c, statuses, "uuid:1", &doc)
+		c.Check(doc.Phase, gc.Equals, "DONE")
+		c.Check(doc.PhaseTimes, jc.DeepEquals, map[string]int64{"DONE": 2000})
+		s.FindId(c, statuses, "uuid:2", &doc)
+		c.Check(doc.Phase, gc.Equals, "IMPORT")
+		c.Check(doc.PhaseTimes, jc.DeepEquals, map[string]int64{"IMPORT": 3000})
+
+		var reportDocs []modelMigMinionSyncDoc
+		err := reports.Find(nil).Sort("_id").All(&reportDocs)
+		c.Assert(err, jc.ErrorIsNil)
+		var ids []string
+		for _, report := range reportDocs {
+			c.Check(report.Id, gc.Equals, report.MigrationId+":"+report.Phase+":"+report.EntityTag)
+			ids = append(ids, report.Id)
+		}
+		c.Check(ids, jc.DeepEquals, []string{
+			"uuid:0:QUIESCE:machine-0",
+			"uuid:0:VALIDATION:machine-0",
+			"uuid:2:VALIDATION:machine-0",
+		})
+	}
+
+	err := RenameMigrationPhases(s.state)
+	c.Assert(err, jc.ErrorIsNil)
+	check()
+
+	err = RenameMigrationPhases(s.state)
+	c.Assert(err, jc.ErrorIsNil, gc.Commentf("idempotency check failed!"))
+	check()
+}
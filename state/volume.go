@@ -126,6 +126,7 @@ type VolumeInfo struct {
 	Pool       string `bson:"pool"`
 	VolumeId   string `bson:"volumeid"`
 	Persistent bool   `bson:"persistent"`
+	Encrypted  bool   `bson:"encrypted,omitempty"`
 }
 
 // VolumeAttachmentInfo describes information about a volume attachment.
@@ -22,6 +22,7 @@ import (
 	"github.com/juju/juju/mongo"
 	"github.com/juju/juju/state/watcher"
 	"github.com/juju/juju/state/workers"
+	"github.com/juju/juju/storage/poolmanager"
 
 	// TODO(fwereade): 2015-11-18 lp:1517428
 	//
@@ -293,9 +294,28 @@ func (st *State) WatchMachineVolumeAttachments(m names.MachineTag) StringsWatche
 
 // WatchMachineFilesystemAttachments returns a StringsWatcher that notifies of
 // changes to the lifecycles of all filesystem attachments related to the specified
-// machine, for filesystems scoped to the machine.
+// machine, for filesystems scoped to the machine, and for environ-scoped
+// filesystems that may be shared by multiple machines.
+//
+// The watcher cannot distinguish shared filesystems from other environ-scoped
+// filesystems, so all attachments of environ-scoped filesystems are reported;
+// the machine storage provisioner ignores those it is not responsible for.
 func (st *State) WatchMachineFilesystemAttachments(m names.MachineTag) StringsWatcher {
-	return st.watchMachineStorageAttachments(m, filesystemAttachmentsC)
+	pattern := fmt.Sprintf("^%s:(%s/.*|%s)$", st.docID(m.Id()), m.Id(), names.NumberSnippet)
+	members := bson.D{{"_id", bson.D{{"$regex", pattern}}}}
+	prefix := m.Id() + ":"
+	filter := func(id interface{}) bool {
+		k, err := st.strictLocalID(id.(string))
+		if err != nil {
+			return false
+		}
+		if !strings.HasPrefix(k, prefix) {
+			return false
+		}
+		filesystemId := k[len(prefix):]
+		return strings.HasPrefix(filesystemId, m.Id()+"/") || !strings.Contains(filesystemId, "/")
+	}
+	return newLifecycleWatcher(st, filesystemAttachmentsC, members, filter, nil)
 }
 
 func (st *State) watchMachineStorageAttachments(m names.MachineTag, collection string) StringsWatcher {
@@ -1739,6 +1759,110 @@ func (w *cleanupWatcher) loop() (err error) {
 	}
 }
 
+// storagePoolsWatcher notifies of the names of storage pools that
+// have been created, updated or removed.
+type storagePoolsWatcher struct {
+	commonWatcher
+	out chan []string
+}
+
+var _ StringsWatcher = (*storagePoolsWatcher)(nil)
+
+// WatchStoragePools returns a StringsWatcher that notifies of the names
+// of storage pools in the model as they are created, updated or removed.
+// The initial event contains the names of all existing storage pools.
+func (st *State) WatchStoragePools() StringsWatcher {
+	return newStoragePoolsWatcher(st)
+}
+
+func newStoragePoolsWatcher(st *State) StringsWatcher {
+	w := &storagePoolsWatcher{
+		commonWatcher: newCommonWatcher(st),
+		out:           make(chan []string),
+	}
+	go func() {
+		defer w.tomb.Done()
+		defer close(w.out)
+		w.tomb.Kill(w.loop())
+	}()
+	return w
+}
+
+// Changes returns the event channel for w.
+func (w *storagePoolsWatcher) Changes() <-chan []string {
+	return w.out
+}
+
+// poolName returns the name of the storage pool with the given
+// settings document ID, and whether or not the ID refers to a
+// storage pool in this model.
+func (w *storagePoolsWatcher) poolName(id interface{}) (string, bool) {
+	docID, ok := id.(string)
+	if !ok {
+		return "", false
+	}
+	key, err := w.st.strictLocalID(docID)
+	if err != nil {
+		return "", false
+	}
+	return poolmanager.PoolName(key)
+}
+
+func (w *storagePoolsWatcher) initial() (set.Strings, error) {
+	settings, closer := w.st.getCollection(settingsC)
+	defer closer()
+
+	poolNames := make(set.Strings)
+	var doc struct {
+		DocID string `bson:"_id"`
+	}
+	iter := settings.Find(nil).Select(bson.D{{"_id", 1}}).Iter()
+	for iter.Next(&doc) {
+		if name, ok := w.poolName(doc.DocID); ok {
+			poolNames.Add(name)
+		}
+	}
+	return poolNames, errors.Trace(iter.Close())
+}
+
+func (w *storagePoolsWatcher) loop() error {
+	in := make(chan watcher.Change)
+	filter := func(id interface{}) bool {
+		_, ok := w.poolName(id)
+		return ok
+	}
+	w.watcher.WatchCollectionWithFilter(settingsC, in, filter)
+	defer w.watcher.UnwatchCollection(settingsC, in)
+
+	changes, err := w.initial()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	out := w.out
+	for {
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case <-w.watcher.Dead():
+			return stateWatcherDeadError(w.watcher.Err())
+		case ch := <-in:
+			updates, ok := collect(ch, in, w.tomb.Dying())
+			if !ok {
+				return tomb.ErrDying
+			}
+			for id := range updates {
+				if name, ok := w.poolName(id); ok {
+					changes.Add(name)
+				}
+			}
+			out = w.out
+		case out <- changes.SortedValues():
+			changes = make(set.Strings)
+			out = nil
+		}
+	}
+}
+
 // actionStatusWatcher is a StringsWatcher that filters notifications
 // to Action Id's that match the ActionReceiver and ActionStatus set
 // provided.
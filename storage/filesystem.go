@@ -26,6 +26,10 @@ type FilesystemInfo struct {
 
 	// Size is the size of the filesystem, in MiB.
 	Size uint64
+
+	// Encrypted reflects whether the filesystem is encrypted at rest,
+	// independently of any volume backing it.
+	Encrypted bool
 }
 
 // FilesystemAttachment describes machine-specific filesystem attachment information,
@@ -52,3 +56,20 @@ type FilesystemAttachmentInfo struct {
 	// ReadOnly indicates that the filesystem is mounted read-only.
 	ReadOnly bool
 }
+
+// FilesystemUsage describes the capacity usage of a filesystem mounted
+// on the local machine.
+type FilesystemUsage struct {
+	// Path is the path at which the filesystem is mounted.
+	Path string
+
+	// UsedBytes and FreeBytes are the number of bytes used and
+	// available to unprivileged users, respectively.
+	UsedBytes uint64
+	FreeBytes uint64
+
+	// UsedInodes and FreeInodes are the number of inodes used and
+	// available, respectively.
+	UsedInodes uint64
+	FreeInodes uint64
+}
@@ -62,6 +62,27 @@ type Provider interface {
 	ValidateConfig(*Config) error
 }
 
+// SharedFilesystemProvider is an interface that may be implemented by
+// a Provider whose filesystems may be attached to many machines at once,
+// such as network filesystems. Shared filesystems are created and
+// destroyed by the environment storage provisioner, and attached to
+// and detached from each machine by that machine's storage provisioner.
+type SharedFilesystemProvider interface {
+	Provider
+
+	// SharedFilesystems reports whether or not filesystems created
+	// by the provider may be attached to multiple machines.
+	SharedFilesystems() bool
+}
+
+// IsSharedFilesystemProvider reports whether or not the specified
+// provider creates filesystems that may be attached to multiple
+// machines.
+func IsSharedFilesystemProvider(p Provider) bool {
+	shared, ok := p.(SharedFilesystemProvider)
+	return ok && shared.SharedFilesystems()
+}
+
 // VolumeSource provides an interface for creating, destroying, describing,
 // attaching and detaching volumes in the environment. A VolumeSource is
 // configured in a particular way, and corresponds to a storage "pool".
@@ -12,6 +12,10 @@ type PoolManager interface {
 	// Create makes a new pool with the specified configuration and persists it to state.
 	Create(name string, providerType storage.ProviderType, attrs map[string]interface{}) (*storage.Config, error)
 
+	// Replace replaces the attributes of the pool with name, validating
+	// the new attributes against the pool's storage provider.
+	Replace(name string, attrs map[string]interface{}) (*storage.Config, error)
+
 	// Delete removes the pool with name from state.
 	Delete(name string) error
 
@@ -25,6 +29,7 @@ type PoolManager interface {
 type SettingsManager interface {
 	CreateSettings(key string, settings map[string]interface{}) error
 	ReadSettings(key string) (map[string]interface{}, error)
+	ReplaceSettings(key string, settings map[string]interface{}) error
 	RemoveSettings(key string) error
 	ListSettings(keyPrefix string) (map[string]map[string]interface{}, error)
 }
@@ -4,6 +4,8 @@
 package poolmanager
 
 import (
+	"strings"
+
 	"github.com/juju/errors"
 
 	"github.com/juju/juju/storage"
@@ -39,6 +41,16 @@ func globalKey(name string) string {
 	return globalKeyPrefix + name
 }
 
+// PoolName returns the name of the storage pool whose settings are
+// stored under the specified key, and whether or not the key refers
+// to a storage pool at all.
+func PoolName(key string) (string, bool) {
+	if !strings.HasPrefix(key, globalKeyPrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(key, globalKeyPrefix), true
+}
+
 // Create is defined on PoolManager interface.
 func (pm *poolManager) Create(name string, providerType storage.ProviderType, attrs map[string]interface{}) (*storage.Config, error) {
 	if name == "" {
@@ -69,6 +81,34 @@ func (pm *poolManager) Create(name string, providerType storage.ProviderType, at
 	return cfg, nil
 }
 
+// Replace is defined on PoolManager interface.
+func (pm *poolManager) Replace(name string, attrs map[string]interface{}) (*storage.Config, error) {
+	existing, err := pm.Get(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	providerType := existing.Provider()
+	cfg, err := storage.NewConfig(name, providerType, attrs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	p, err := registry.StorageProvider(providerType)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := provider.ValidateConfig(p, cfg); err != nil {
+		return nil, errors.Annotate(err, "validating storage provider config")
+	}
+
+	poolAttrs := cfg.Attrs()
+	poolAttrs[Name] = name
+	poolAttrs[Type] = string(providerType)
+	if err := pm.settings.ReplaceSettings(globalKey(name), poolAttrs); err != nil {
+		return nil, errors.Annotatef(err, "replacing pool %q", name)
+	}
+	return cfg, nil
+}
+
 // Delete is defined on PoolManager interface.
 func (pm *poolManager) Delete(name string) error {
 	err := pm.settings.RemoveSettings(globalKey(name))
@@ -136,3 +136,45 @@ func (s *poolSuite) TestDelete(c *gc.C) {
 	err = s.poolManager.Delete("testpool")
 	c.Assert(err, jc.ErrorIsNil)
 }
+
+func (s *poolSuite) TestReplace(c *gc.C) {
+	s.createSettings(c)
+	replaced, err := s.poolManager.Replace("testpool", map[string]interface{}{"baz": "qux"})
+	c.Assert(err, jc.ErrorIsNil)
+	p, err := s.poolManager.Get("testpool")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(replaced, gc.DeepEquals, p)
+	c.Assert(p.Attrs(), gc.DeepEquals, map[string]interface{}{"baz": "qux"})
+	c.Assert(p.Name(), gc.Equals, "testpool")
+	c.Assert(p.Provider(), gc.Equals, storage.ProviderType("loop"))
+}
+
+func (s *poolSuite) TestReplaceNotFound(c *gc.C) {
+	_, err := s.poolManager.Replace("testpool", map[string]interface{}{"baz": "qux"})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `pool "testpool" not found`)
+}
+
+func (s *poolSuite) TestReplaceInvalidConfig(c *gc.C) {
+	registry.RegisterProvider("invalid", &dummy.StorageProvider{
+		ValidateConfigFunc: func(cfg *storage.Config) error {
+			if _, ok := cfg.Attrs()["bad"]; ok {
+				return errors.New("no good")
+			}
+			return nil
+		},
+	})
+	defer registry.RegisterProvider("invalid", nil)
+	_, err := s.poolManager.Create("testpool", "invalid", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.poolManager.Replace("testpool", map[string]interface{}{"bad": true})
+	c.Assert(err, gc.ErrorMatches, "validating storage provider config: no good")
+}
+
+func (s *poolSuite) TestPoolName(c *gc.C) {
+	name, ok := poolmanager.PoolName("pool#testpool")
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(name, gc.Equals, "testpool")
+	_, ok = poolmanager.PoolName("r#1")
+	c.Assert(ok, jc.IsFalse)
+}
@@ -15,6 +15,8 @@ var errNoMountPoint = errors.New("filesystem mount point not specified")
 func CommonProviders() map[storage.ProviderType]storage.Provider {
 	return map[storage.ProviderType]storage.Provider{
 		LoopProviderType:   &loopProvider{logAndExec},
+		LVMProviderType:    &lvmProvider{logAndExec},
+		NFSProviderType:    &nfsProvider{logAndExec},
 		RootfsProviderType: &rootfsProvider{logAndExec},
 		TmpfsProviderType:  &tmpfsProvider{logAndExec},
 	}
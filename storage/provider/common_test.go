@@ -27,6 +27,8 @@ func (s *providerCommonSuite) TestCommonProvidersExported(c *gc.C) {
 	}
 	c.Assert(common, jc.SameContents, []storage.ProviderType{
 		provider.LoopProviderType,
+		provider.LVMProviderType,
+		provider.NFSProviderType,
 		provider.RootfsProviderType,
 		provider.TmpfsProviderType,
 	})
@@ -11,7 +11,7 @@ import (
 	"github.com/juju/juju/storage"
 )
 
-var _ storage.Provider = (*StorageProvider)(nil)
+var _ storage.SharedFilesystemProvider = (*StorageProvider)(nil)
 
 // StorageProvider is an implementation of storage.Provider, suitable for testing.
 // Each method's default behaviour may be overridden by setting the corresponding
@@ -26,6 +26,10 @@ type StorageProvider struct {
 	// dynamic provisioning.
 	IsDynamic bool
 
+	// IsShared defines whether or not the provider reports that its
+	// filesystems may be attached to multiple machines.
+	IsShared bool
+
 	// VolumeSourceFunc will be called by VolumeSource, if non-nil;
 	// otherwise VolumeSource will return a NotSupported error.
 	VolumeSourceFunc func(*config.Config, *storage.Config) (storage.VolumeSource, error)
@@ -90,3 +94,9 @@ func (p *StorageProvider) Dynamic() bool {
 	p.MethodCall(p, "Dynamic")
 	return p.IsDynamic
 }
+
+// SharedFilesystems is defined on storage.SharedFilesystemProvider.
+func (p *StorageProvider) SharedFilesystems() bool {
+	p.MethodCall(p, "SharedFilesystems")
+	return p.IsShared
+}
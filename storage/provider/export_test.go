@@ -33,10 +33,34 @@ func LoopProvider(
 	return &loopProvider{run}
 }
 
+func LVMProvider(
+	run func(string, ...string) (string, error),
+) storage.Provider {
+	return &lvmProvider{run}
+}
+
+func NFSProvider(
+	run func(string, ...string) (string, error),
+) storage.Provider {
+	return &nfsProvider{run}
+}
+
+func NFSFilesystemSource(
+	storageDir string,
+	run func(string, ...string) (string, error),
+) (storage.FilesystemSource, *MockDirFuncs) {
+	dirFuncs := &MockDirFuncs{
+		osDirFuncs{run},
+		set.NewStrings(),
+	}
+	return &nfsFilesystemSource{dirFuncs, run, storageDir}, dirFuncs
+}
+
 func NewMockManagedFilesystemSource(
 	run func(string, ...string) (string, error),
 	volumeBlockDevices map[names.VolumeTag]storage.BlockDevice,
 	filesystems map[names.FilesystemTag]storage.Filesystem,
+	keyDir string,
 ) (storage.FilesystemSource, *MockDirFuncs) {
 	dirFuncs := &MockDirFuncs{
 		osDirFuncs{run},
@@ -45,6 +69,7 @@ func NewMockManagedFilesystemSource(
 	return &managedFilesystemSource{
 		run, dirFuncs,
 		volumeBlockDevices, filesystems,
+		keyDir,
 	}, dirFuncs
 }
 
@@ -0,0 +1,331 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/storage"
+)
+
+const (
+	// LVM provider type.
+	LVMProviderType = storage.ProviderType("lvm")
+
+	// LVMVolumeGroup is the name of the pool config attribute
+	// specifying the volume group in which logical volumes are
+	// created.
+	LVMVolumeGroup = "volume-group"
+
+	// LVMStripes is the name of the pool config attribute specifying
+	// the number of physical volumes across which logical volumes
+	// are striped.
+	LVMStripes = "stripes"
+
+	// LVMStripeSize is the name of the pool config attribute specifying
+	// the stripe size, in KiB, of striped logical volumes.
+	LVMStripeSize = "stripe-size"
+)
+
+// lvmNameRE matches the characters permitted in LVM volume group and
+// logical volume names.
+var lvmNameRE = regexp.MustCompile(`^[a-zA-Z0-9+_.][a-zA-Z0-9+_.\-]*$`)
+
+// isValidLVMName reports whether or not the given name is a valid LVM
+// volume group or logical volume name.
+func isValidLVMName(name string) bool {
+	return name != "." && name != ".." && lvmNameRE.MatchString(name)
+}
+
+var lvmConfigFields = schema.Fields{
+	LVMVolumeGroup: schema.String(),
+	LVMStripes:     schema.ForceInt(),
+	LVMStripeSize:  schema.ForceInt(),
+}
+
+var lvmConfigChecker = schema.FieldMap(
+	lvmConfigFields,
+	schema.Defaults{
+		LVMVolumeGroup: schema.Omit,
+		LVMStripes:     schema.Omit,
+		LVMStripeSize:  schema.Omit,
+	},
+)
+
+type lvmConfig struct {
+	volumeGroup string
+	stripes     int
+	stripeSize  int
+}
+
+func newLVMConfig(attrs map[string]interface{}) (*lvmConfig, error) {
+	out, err := lvmConfigChecker.Coerce(attrs, nil)
+	if err != nil {
+		return nil, errors.Annotate(err, "validating LVM storage config")
+	}
+	coerced := out.(map[string]interface{})
+	volumeGroup, _ := coerced[LVMVolumeGroup].(string)
+	stripes, _ := coerced[LVMStripes].(int)
+	stripeSize, _ := coerced[LVMStripeSize].(int)
+	if volumeGroup == "" {
+		return nil, errors.New("volume group not specified")
+	}
+	if !isValidLVMName(volumeGroup) {
+		return nil, errors.NotValidf("volume group name %q", volumeGroup)
+	}
+	if stripes < 0 {
+		return nil, errors.NotValidf("stripes %d", stripes)
+	}
+	if stripeSize != 0 {
+		if stripes < 2 {
+			return nil, errors.New("stripe size specified, but fewer than 2 stripes")
+		}
+		// LVM requires the stripe size to be a power of 2,
+		// no smaller than the page size.
+		if stripeSize < 4 || stripeSize&(stripeSize-1) != 0 {
+			return nil, errors.NotValidf("stripe size %d (must be a power of 2, at least 4)", stripeSize)
+		}
+	}
+	return &lvmConfig{
+		volumeGroup: volumeGroup,
+		stripes:     stripes,
+		stripeSize:  stripeSize,
+	}, nil
+}
+
+// lvmProvider creates volume sources which use logical volumes
+// carved out of a pre-existing volume group on the machine.
+//
+// The volume group must be created by the operator before the
+// provider is used, e.g. on a machine's spare disks. For testing,
+// a volume group may be created on a loop device:
+//
+//	truncate -s 1G /tmp/lvm.img
+//	sudo losetup /dev/loop0 /tmp/lvm.img
+//	sudo vgcreate juju-vg /dev/loop0
+type lvmProvider struct {
+	// run is a function used for running commands on the local machine.
+	run runCommandFunc
+}
+
+var _ storage.Provider = (*lvmProvider)(nil)
+
+// ValidateConfig is defined on the Provider interface.
+func (*lvmProvider) ValidateConfig(cfg *storage.Config) error {
+	_, err := newLVMConfig(cfg.Attrs())
+	return errors.Trace(err)
+}
+
+// VolumeSource is defined on the Provider interface.
+func (p *lvmProvider) VolumeSource(
+	environConfig *config.Config,
+	sourceConfig *storage.Config,
+) (storage.VolumeSource, error) {
+	cfg, err := newLVMConfig(sourceConfig.Attrs())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &lvmVolumeSource{p.run, *cfg}, nil
+}
+
+// FilesystemSource is defined on the Provider interface.
+func (p *lvmProvider) FilesystemSource(
+	environConfig *config.Config,
+	providerConfig *storage.Config,
+) (storage.FilesystemSource, error) {
+	// Filesystems are created on logical volumes by
+	// the managed filesystem source.
+	return nil, errors.NotSupportedf("filesystems")
+}
+
+// Supports is defined on the Provider interface.
+func (*lvmProvider) Supports(k storage.StorageKind) bool {
+	return k == storage.StorageKindBlock
+}
+
+// Scope is defined on the Provider interface.
+func (*lvmProvider) Scope() storage.Scope {
+	return storage.ScopeMachine
+}
+
+// Dynamic is defined on the Provider interface.
+func (*lvmProvider) Dynamic() bool {
+	return true
+}
+
+// lvmVolumeSource is a storage.VolumeSource that creates logical
+// volumes in a volume group on the local machine.
+//
+// Volume IDs are of the form "<volume-group>/<logical-volume>".
+type lvmVolumeSource struct {
+	run    runCommandFunc
+	config lvmConfig
+}
+
+var _ storage.VolumeSource = (*lvmVolumeSource)(nil)
+
+// CreateVolumes is defined on the VolumeSource interface.
+func (s *lvmVolumeSource) CreateVolumes(args []storage.VolumeParams) ([]storage.CreateVolumesResult, error) {
+	results := make([]storage.CreateVolumesResult, len(args))
+	for i, arg := range args {
+		volume, err := s.createVolume(arg)
+		if err != nil {
+			results[i].Error = errors.Annotate(err, "creating volume")
+			continue
+		}
+		results[i].Volume = volume
+	}
+	return results, nil
+}
+
+func (s *lvmVolumeSource) createVolume(params storage.VolumeParams) (*storage.Volume, error) {
+	lvName := params.Tag.String()
+	args := []string{
+		"--name", lvName,
+		"--size", fmt.Sprintf("%dm", params.Size),
+	}
+	if s.config.stripes > 1 {
+		args = append(args, "--stripes", fmt.Sprint(s.config.stripes))
+		if s.config.stripeSize > 0 {
+			args = append(args, "--stripesize", fmt.Sprint(s.config.stripeSize))
+		}
+	}
+	args = append(args, "--yes", s.config.volumeGroup)
+	if _, err := s.run("lvcreate", args...); err != nil {
+		return nil, errors.Annotatef(err, "creating logical volume %q", lvName)
+	}
+	return &storage.Volume{
+		params.Tag,
+		storage.VolumeInfo{
+			VolumeId: path.Join(s.config.volumeGroup, lvName),
+			Size:     params.Size,
+		},
+	}, nil
+}
+
+// ListVolumes is defined on the VolumeSource interface.
+func (s *lvmVolumeSource) ListVolumes() ([]string, error) {
+	stdout, err := s.run("lvs", "--noheadings", "-o", "lv_name", s.config.volumeGroup)
+	if err != nil {
+		return nil, errors.Annotatef(err, "listing logical volumes in %q", s.config.volumeGroup)
+	}
+	var volumeIds []string
+	for _, lvName := range strings.Fields(stdout) {
+		volumeIds = append(volumeIds, path.Join(s.config.volumeGroup, lvName))
+	}
+	return volumeIds, nil
+}
+
+// DescribeVolumes is defined on the VolumeSource interface.
+func (s *lvmVolumeSource) DescribeVolumes(volumeIds []string) ([]storage.DescribeVolumesResult, error) {
+	return nil, errors.NotImplementedf("DescribeVolumes")
+}
+
+// DestroyVolumes is defined on the VolumeSource interface.
+func (s *lvmVolumeSource) DestroyVolumes(volumeIds []string) ([]error, error) {
+	results := make([]error, len(volumeIds))
+	for i, volumeId := range volumeIds {
+		if err := s.destroyVolume(volumeId); err != nil {
+			results[i] = errors.Annotatef(err, "destroying %q", volumeId)
+		}
+	}
+	return results, nil
+}
+
+func (s *lvmVolumeSource) destroyVolume(volumeId string) error {
+	if err := validateLVMVolumeId(volumeId); err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := s.run("lvremove", "--force", volumeId); err != nil {
+		return errors.Annotate(err, "removing logical volume")
+	}
+	return nil
+}
+
+// ValidateVolumeParams is defined on the VolumeSource interface.
+func (s *lvmVolumeSource) ValidateVolumeParams(params storage.VolumeParams) error {
+	// ValidateVolumeParams may be called on a machine other than the
+	// machine where the logical volume will be created, so we cannot
+	// check the volume group's free space until we get to CreateVolumes.
+	return nil
+}
+
+// AttachVolumes is defined on the VolumeSource interface.
+func (s *lvmVolumeSource) AttachVolumes(args []storage.VolumeAttachmentParams) ([]storage.AttachVolumesResult, error) {
+	results := make([]storage.AttachVolumesResult, len(args))
+	for i, arg := range args {
+		attachment, err := s.attachVolume(arg)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "attaching volume %v", arg.Volume.Id())
+			continue
+		}
+		results[i].VolumeAttachment = attachment
+	}
+	return results, nil
+}
+
+func (s *lvmVolumeSource) attachVolume(arg storage.VolumeAttachmentParams) (*storage.VolumeAttachment, error) {
+	if err := validateLVMVolumeId(arg.VolumeId); err != nil {
+		return nil, errors.Trace(err)
+	}
+	permission := "rw"
+	if arg.ReadOnly {
+		permission = "r"
+	}
+	if _, err := s.run(
+		"lvchange", "--activate", "y", "--permission", permission, arg.VolumeId,
+	); err != nil {
+		return nil, errors.Annotate(err, "activating logical volume")
+	}
+	return &storage.VolumeAttachment{
+		arg.Volume,
+		arg.Machine,
+		storage.VolumeAttachmentInfo{
+			// The logical volume's device name (e.g. "dm-0") is
+			// not known until it is activated, so we identify the
+			// block device by the link that LVM maintains.
+			DeviceLink: path.Join("/dev", arg.VolumeId),
+			ReadOnly:   arg.ReadOnly,
+		},
+	}, nil
+}
+
+// DetachVolumes is defined on the VolumeSource interface.
+func (s *lvmVolumeSource) DetachVolumes(args []storage.VolumeAttachmentParams) ([]error, error) {
+	results := make([]error, len(args))
+	for i, arg := range args {
+		if err := s.detachVolume(arg.VolumeId); err != nil {
+			results[i] = errors.Annotatef(err, "detaching volume %s", arg.Volume.Id())
+		}
+	}
+	return results, nil
+}
+
+func (s *lvmVolumeSource) detachVolume(volumeId string) error {
+	if err := validateLVMVolumeId(volumeId); err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := s.run("lvchange", "--activate", "n", volumeId); err != nil {
+		return errors.Annotate(err, "deactivating logical volume")
+	}
+	return nil
+}
+
+// validateLVMVolumeId checks that the volume ID is of the form
+// "<volume-group>/<logical-volume>", so that it may be safely
+// passed to LVM commands.
+func validateLVMVolumeId(volumeId string) error {
+	parts := strings.Split(volumeId, "/")
+	if len(parts) != 2 || !isValidLVMName(parts[0]) || !isValidLVMName(parts[1]) {
+		return errors.Errorf("invalid LVM volume ID %q", volumeId)
+	}
+	return nil
+}
@@ -0,0 +1,249 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/provider"
+	"github.com/juju/juju/testing"
+)
+
+var _ = gc.Suite(&lvmSuite{})
+
+type lvmSuite struct {
+	testing.BaseSuite
+	commands *mockRunCommand
+}
+
+func (s *lvmSuite) TearDownTest(c *gc.C) {
+	if s.commands != nil {
+		s.commands.assertDrained()
+	}
+	s.BaseSuite.TearDownTest(c)
+}
+
+func (s *lvmSuite) lvmProvider(c *gc.C) storage.Provider {
+	s.commands = &mockRunCommand{c: c}
+	return provider.LVMProvider(s.commands.run)
+}
+
+func (s *lvmSuite) lvmVolumeSource(c *gc.C, attrs map[string]interface{}) storage.VolumeSource {
+	p := s.lvmProvider(c)
+	if attrs == nil {
+		attrs = map[string]interface{}{"volume-group": "juju-vg"}
+	}
+	cfg, err := storage.NewConfig("name", provider.LVMProviderType, attrs)
+	c.Assert(err, jc.ErrorIsNil)
+	source, err := p.VolumeSource(nil, cfg)
+	c.Assert(err, jc.ErrorIsNil)
+	return source
+}
+
+func (s *lvmSuite) TestValidateConfig(c *gc.C) {
+	p := s.lvmProvider(c)
+	for i, test := range []struct {
+		attrs map[string]interface{}
+		err   string
+	}{{
+		attrs: map[string]interface{}{},
+		err:   "volume group not specified",
+	}, {
+		attrs: map[string]interface{}{"volume-group": "-vg"},
+		err:   `volume group name "-vg" not valid`,
+	}, {
+		attrs: map[string]interface{}{"volume-group": ".."},
+		err:   `volume group name ".." not valid`,
+	}, {
+		attrs: map[string]interface{}{"volume-group": "vg", "stripes": "many"},
+		err:   `validating LVM storage config: stripes: expected number, got string\("many"\)`,
+	}, {
+		attrs: map[string]interface{}{"volume-group": "vg", "stripes": -1},
+		err:   "stripes -1 not valid",
+	}, {
+		attrs: map[string]interface{}{"volume-group": "vg", "stripe-size": 64},
+		err:   "stripe size specified, but fewer than 2 stripes",
+	}, {
+		attrs: map[string]interface{}{"volume-group": "vg", "stripes": 2, "stripe-size": 48},
+		err:   `stripe size 48 \(must be a power of 2, at least 4\) not valid`,
+	}, {
+		attrs: map[string]interface{}{"volume-group": "vg"},
+	}, {
+		attrs: map[string]interface{}{"volume-group": "vg", "stripes": "2", "stripe-size": "64"},
+	}} {
+		c.Logf("test %d: %v", i, test.attrs)
+		cfg, err := storage.NewConfig("name", provider.LVMProviderType, test.attrs)
+		c.Assert(err, jc.ErrorIsNil)
+		err = p.ValidateConfig(cfg)
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.err)
+		}
+	}
+}
+
+func (s *lvmSuite) TestVolumeSourceInvalidConfig(c *gc.C) {
+	p := s.lvmProvider(c)
+	cfg, err := storage.NewConfig("name", provider.LVMProviderType, map[string]interface{}{})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = p.VolumeSource(nil, cfg)
+	c.Assert(err, gc.ErrorMatches, "volume group not specified")
+}
+
+func (s *lvmSuite) TestFilesystemSource(c *gc.C) {
+	p := s.lvmProvider(c)
+	cfg, err := storage.NewConfig("name", provider.LVMProviderType, map[string]interface{}{
+		"volume-group": "juju-vg",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = p.FilesystemSource(nil, cfg)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *lvmSuite) TestSupports(c *gc.C) {
+	p := s.lvmProvider(c)
+	c.Assert(p.Supports(storage.StorageKindBlock), jc.IsTrue)
+	c.Assert(p.Supports(storage.StorageKindFilesystem), jc.IsFalse)
+}
+
+func (s *lvmSuite) TestScope(c *gc.C) {
+	p := s.lvmProvider(c)
+	c.Assert(p.Scope(), gc.Equals, storage.ScopeMachine)
+}
+
+func (s *lvmSuite) TestDynamic(c *gc.C) {
+	p := s.lvmProvider(c)
+	c.Assert(p.Dynamic(), jc.IsTrue)
+}
+
+func (s *lvmSuite) TestCreateVolumes(c *gc.C) {
+	source := s.lvmVolumeSource(c, nil)
+	s.commands.expect("lvcreate", "--name", "volume-0", "--size", "2m", "--yes", "juju-vg")
+	cmd := s.commands.expect("lvcreate", "--name", "volume-1", "--size", "4096m", "--yes", "juju-vg")
+	cmd.respond("", errors.New("insufficient free space"))
+
+	results, err := source.CreateVolumes([]storage.VolumeParams{{
+		Tag:  names.NewVolumeTag("0"),
+		Size: 2,
+	}, {
+		Tag:  names.NewVolumeTag("1"),
+		Size: 4096,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].Volume, jc.DeepEquals, &storage.Volume{
+		names.NewVolumeTag("0"),
+		storage.VolumeInfo{
+			VolumeId: "juju-vg/volume-0",
+			Size:     2,
+		},
+	})
+	c.Assert(results[1].Error, gc.ErrorMatches,
+		`creating volume: creating logical volume "volume-1": insufficient free space`,
+	)
+}
+
+func (s *lvmSuite) TestCreateVolumesStriped(c *gc.C) {
+	source := s.lvmVolumeSource(c, map[string]interface{}{
+		"volume-group": "juju-vg",
+		"stripes":      2,
+		"stripe-size":  64,
+	})
+	s.commands.expect(
+		"lvcreate", "--name", "volume-0", "--size", "1024m",
+		"--stripes", "2", "--stripesize", "64", "--yes", "juju-vg",
+	)
+	results, err := source.CreateVolumes([]storage.VolumeParams{{
+		Tag:  names.NewVolumeTag("0"),
+		Size: 1024,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+}
+
+func (s *lvmSuite) TestListVolumes(c *gc.C) {
+	source := s.lvmVolumeSource(c, nil)
+	cmd := s.commands.expect("lvs", "--noheadings", "-o", "lv_name", "juju-vg")
+	cmd.respond("  volume-0\n  volume-1\n", nil)
+	volumeIds, err := source.ListVolumes()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(volumeIds, jc.DeepEquals, []string{"juju-vg/volume-0", "juju-vg/volume-1"})
+}
+
+func (s *lvmSuite) TestDestroyVolumes(c *gc.C) {
+	source := s.lvmVolumeSource(c, nil)
+	s.commands.expect("lvremove", "--force", "juju-vg/volume-0")
+	errs, err := source.DestroyVolumes([]string{"juju-vg/volume-0", "../super/important/stuff"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs, gc.HasLen, 2)
+	c.Assert(errs[0], jc.ErrorIsNil)
+	c.Assert(errs[1], gc.ErrorMatches, `.* invalid LVM volume ID "\.\./super/important/stuff"`)
+}
+
+func (s *lvmSuite) TestAttachVolumes(c *gc.C) {
+	source := s.lvmVolumeSource(c, nil)
+	s.commands.expect("lvchange", "--activate", "y", "--permission", "rw", "juju-vg/volume-0")
+	s.commands.expect("lvchange", "--activate", "y", "--permission", "r", "juju-vg/volume-1")
+
+	results, err := source.AttachVolumes([]storage.VolumeAttachmentParams{{
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: "juju-vg/volume-0",
+		AttachmentParams: storage.AttachmentParams{
+			Machine:    names.NewMachineTag("0"),
+			InstanceId: "inst-ance",
+		},
+	}, {
+		Volume:   names.NewVolumeTag("1"),
+		VolumeId: "juju-vg/volume-1",
+		AttachmentParams: storage.AttachmentParams{
+			Machine:    names.NewMachineTag("0"),
+			InstanceId: "inst-ance",
+			ReadOnly:   true,
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.AttachVolumesResult{{
+		VolumeAttachment: &storage.VolumeAttachment{
+			names.NewVolumeTag("0"),
+			names.NewMachineTag("0"),
+			storage.VolumeAttachmentInfo{
+				DeviceLink: "/dev/juju-vg/volume-0",
+			},
+		},
+	}, {
+		VolumeAttachment: &storage.VolumeAttachment{
+			names.NewVolumeTag("1"),
+			names.NewMachineTag("0"),
+			storage.VolumeAttachmentInfo{
+				DeviceLink: "/dev/juju-vg/volume-1",
+				ReadOnly:   true,
+			},
+		},
+	}})
+}
+
+func (s *lvmSuite) TestDetachVolumes(c *gc.C) {
+	source := s.lvmVolumeSource(c, nil)
+	cmd := s.commands.expect("lvchange", "--activate", "n", "juju-vg/volume-0")
+	cmd.respond("", errors.New("in use"))
+
+	errs, err := source.DetachVolumes([]storage.VolumeAttachmentParams{{
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: "juju-vg/volume-0",
+		AttachmentParams: storage.AttachmentParams{
+			Machine:    names.NewMachineTag("0"),
+			InstanceId: "inst-ance",
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs, gc.HasLen, 1)
+	c.Assert(errs[0], gc.ErrorMatches, "detaching volume 0: deactivating logical volume: in use")
+}
@@ -4,11 +4,15 @@
 package provider
 
 import (
+	"crypto/rand"
+	"io/ioutil"
+	"os"
 	"path"
 	"path/filepath"
 	"unicode"
 
 	"github.com/juju/errors"
+	"github.com/juju/schema"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/storage"
@@ -18,6 +22,15 @@ const (
 	// defaultFilesystemType is the default filesystem type
 	// to create for volume-backed managed filesystems.
 	defaultFilesystemType = "ext4"
+
+	// ManagedFilesystemLUKS is the name of the pool config attribute
+	// specifying whether or not volume-backed managed filesystems
+	// should be created inside a LUKS-encrypted container.
+	ManagedFilesystemLUKS = "luks"
+
+	// luksKeySize is the size, in bytes, of the randomly generated
+	// keys used to unlock LUKS containers.
+	luksKeySize = 64
 )
 
 // managedFilesystemSource is an implementation of storage.FilesystemSource
@@ -29,6 +42,7 @@ type managedFilesystemSource struct {
 	dirFuncs           dirFuncs
 	volumeBlockDevices map[names.VolumeTag]storage.BlockDevice
 	filesystems        map[names.FilesystemTag]storage.Filesystem
+	keyDir             string
 }
 
 // NewManagedFilesystemSource returns a storage.FilesystemSource that manages
@@ -37,14 +51,19 @@ type managedFilesystemSource struct {
 // The parameters are maps that the caller will update with information about
 // block devices and filesystems created by the source. The caller must not
 // update the maps during calls to the source's methods.
+//
+// keyDir is the directory in which the keys for LUKS-encrypted filesystems
+// are stored. If keyDir is empty, encrypted filesystems are not supported.
 func NewManagedFilesystemSource(
 	volumeBlockDevices map[names.VolumeTag]storage.BlockDevice,
 	filesystems map[names.FilesystemTag]storage.Filesystem,
+	keyDir string,
 ) storage.FilesystemSource {
 	return &managedFilesystemSource{
 		logAndExec,
 		&osDirFuncs{logAndExec},
 		volumeBlockDevices, filesystems,
+		keyDir,
 	}
 }
 
@@ -53,9 +72,30 @@ func (s *managedFilesystemSource) ValidateFilesystemParams(arg storage.Filesyste
 	if _, err := s.backingVolumeBlockDevice(arg.Volume); err != nil {
 		return errors.Trace(err)
 	}
+	luks, err := useLUKS(arg.Attributes)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if luks && s.keyDir == "" {
+		return errors.NotSupportedf("LUKS encryption without a key directory")
+	}
 	return nil
 }
 
+// useLUKS reports whether or not the given filesystem attributes
+// request that the filesystem be created in a LUKS container.
+func useLUKS(attrs map[string]interface{}) (bool, error) {
+	value, ok := attrs[ManagedFilesystemLUKS]
+	if !ok {
+		return false, nil
+	}
+	luks, err := schema.Bool().Coerce(value, []string{ManagedFilesystemLUKS})
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return luks.(bool), nil
+}
+
 func (s *managedFilesystemSource) backingVolumeBlockDevice(v names.VolumeTag) (storage.BlockDevice, error) {
 	blockDevice, ok := s.volumeBlockDevices[v]
 	if !ok {
@@ -95,6 +135,16 @@ func (s *managedFilesystemSource) createFilesystem(arg storage.FilesystemParams)
 		}
 		devicePath = partitionDevicePath(devicePath)
 	}
+	luks, err := useLUKS(arg.Attributes)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if luks {
+		devicePath, err = s.createLUKSContainer(arg.Tag, devicePath)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
 	if err := createFilesystem(s.run, devicePath); err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -102,18 +152,99 @@ func (s *managedFilesystemSource) createFilesystem(arg storage.FilesystemParams)
 		arg.Tag,
 		arg.Volume,
 		storage.FilesystemInfo{
-			arg.Tag.String(),
-			blockDevice.Size,
+			FilesystemId: arg.Tag.String(),
+			Size:         blockDevice.Size,
+			Encrypted:    luks,
 		},
 	}, nil
 }
 
+// createLUKSContainer formats the device with the specified path as a
+// LUKS container, using a newly generated key, and opens it. The path
+// of the device-mapper device for the opened container is returned.
+func (s *managedFilesystemSource) createLUKSContainer(tag names.FilesystemTag, devicePath string) (string, error) {
+	if s.keyDir == "" {
+		return "", errors.NotSupportedf("LUKS encryption without a key directory")
+	}
+	if err := os.MkdirAll(s.keyDir, 0700); err != nil {
+		return "", errors.Annotate(err, "creating key directory")
+	}
+	key := make([]byte, luksKeySize)
+	if _, err := rand.Read(key); err != nil {
+		return "", errors.Annotate(err, "generating LUKS key")
+	}
+	keyFile := s.luksKeyFile(tag)
+	if err := ioutil.WriteFile(keyFile, key, 0600); err != nil {
+		return "", errors.Annotate(err, "writing LUKS key")
+	}
+	logger.Debugf("formatting LUKS container on %q", devicePath)
+	if _, err := s.run(
+		"cryptsetup", "luksFormat", "--batch-mode", "--key-file", keyFile, devicePath,
+	); err != nil {
+		return "", errors.Annotate(err, "cryptsetup luksFormat failed")
+	}
+	if err := s.openLUKSContainer(tag, devicePath, false); err != nil {
+		return "", errors.Trace(err)
+	}
+	return luksDevicePath(tag), nil
+}
+
+// openLUKSContainer opens the LUKS container on the device with the
+// specified path, if it is not already open.
+func (s *managedFilesystemSource) openLUKSContainer(tag names.FilesystemTag, devicePath string, readOnly bool) error {
+	if _, err := s.dirFuncs.lstat(luksDevicePath(tag)); err == nil {
+		logger.Debugf("LUKS container on %q already open", devicePath)
+		return nil
+	}
+	args := []string{"luksOpen"}
+	if readOnly {
+		args = append(args, "--readonly")
+	}
+	args = append(args, "--key-file", s.luksKeyFile(tag), devicePath, luksMappingName(tag))
+	if _, err := s.run("cryptsetup", args...); err != nil {
+		return errors.Annotate(err, "cryptsetup luksOpen failed")
+	}
+	return nil
+}
+
+// closeLUKSContainer closes the LUKS container for the specified
+// filesystem, if it is open.
+func (s *managedFilesystemSource) closeLUKSContainer(tag names.FilesystemTag) error {
+	if _, err := s.dirFuncs.lstat(luksDevicePath(tag)); os.IsNotExist(err) {
+		return nil
+	}
+	if _, err := s.run("cryptsetup", "luksClose", luksMappingName(tag)); err != nil {
+		return errors.Annotate(err, "cryptsetup luksClose failed")
+	}
+	return nil
+}
+
+// luksKeyFile returns the path of the key file for the LUKS
+// container holding the specified filesystem.
+func (s *managedFilesystemSource) luksKeyFile(tag names.FilesystemTag) string {
+	return filepath.Join(s.keyDir, tag.String()+".key")
+}
+
 // DestroyFilesystems is defined on storage.FilesystemSource.
 func (s *managedFilesystemSource) DestroyFilesystems(filesystemIds []string) ([]error, error) {
-	// DestroyFilesystems is a no-op; there is nothing to destroy,
-	// since the filesystem is just data on a volume. The volume
-	// is destroyed separately.
-	return make([]error, len(filesystemIds)), nil
+	// There is nothing to destroy, since the filesystem is just
+	// data on a volume; the volume is destroyed separately. We
+	// do remove any LUKS key, rendering the data unrecoverable.
+	results := make([]error, len(filesystemIds))
+	if s.keyDir == "" {
+		return results, nil
+	}
+	for i, filesystemId := range filesystemIds {
+		tag, err := names.ParseFilesystemTag(filesystemId)
+		if err != nil {
+			results[i] = errors.Trace(err)
+			continue
+		}
+		if err := os.Remove(s.luksKeyFile(tag)); err != nil && !os.IsNotExist(err) {
+			results[i] = errors.Annotate(err, "removing LUKS key")
+		}
+	}
+	return results, nil
 }
 
 // AttachFilesystems is defined on storage.FilesystemSource.
@@ -143,6 +274,12 @@ func (s *managedFilesystemSource) attachFilesystem(arg storage.FilesystemAttachm
 	if isDiskDevice(devicePath) {
 		devicePath = partitionDevicePath(devicePath)
 	}
+	if filesystem.Encrypted {
+		if err := s.openLUKSContainer(arg.Filesystem, devicePath, arg.ReadOnly); err != nil {
+			return nil, errors.Trace(err)
+		}
+		devicePath = luksDevicePath(arg.Filesystem)
+	}
 	if err := mountFilesystem(s.run, s.dirFuncs, devicePath, arg.Path, arg.ReadOnly); err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -162,6 +299,12 @@ func (s *managedFilesystemSource) DetachFilesystems(args []storage.FilesystemAtt
 	for i, arg := range args {
 		if err := maybeUnmount(s.run, s.dirFuncs, arg.Path); err != nil {
 			results[i] = err
+			continue
+		}
+		if filesystem, ok := s.filesystems[arg.Filesystem]; ok && filesystem.Encrypted {
+			if err := s.closeLUKSContainer(arg.Filesystem); err != nil {
+				results[i] = err
+			}
 		}
 	}
 	return results, nil
@@ -259,6 +402,18 @@ func devicePath(dev storage.BlockDevice) string {
 	return path.Join("/dev", dev.DeviceName)
 }
 
+// luksMappingName returns the device-mapper name for the opened LUKS
+// container holding the specified filesystem.
+func luksMappingName(tag names.FilesystemTag) string {
+	return "juju-" + tag.String()
+}
+
+// luksDevicePath returns the device path for the opened LUKS container
+// holding the specified filesystem.
+func luksDevicePath(tag names.FilesystemTag) string {
+	return path.Join("/dev/mapper", luksMappingName(tag))
+}
+
 // partitionDevicePath returns the device path for the first (and only)
 // partition of the disk with the specified device path.
 func partitionDevicePath(devicePath string) string {
@@ -4,6 +4,8 @@
 package provider_test
 
 import (
+	"io/ioutil"
+	"os"
 	"path/filepath"
 
 	jc "github.com/juju/testing/checkers"
@@ -23,12 +25,14 @@ type managedfsSuite struct {
 	dirFuncs     *provider.MockDirFuncs
 	blockDevices map[names.VolumeTag]storage.BlockDevice
 	filesystems  map[names.FilesystemTag]storage.Filesystem
+	keyDir       string
 }
 
 func (s *managedfsSuite) SetUpTest(c *gc.C) {
 	s.BaseSuite.SetUpTest(c)
 	s.blockDevices = make(map[names.VolumeTag]storage.BlockDevice)
 	s.filesystems = make(map[names.FilesystemTag]storage.Filesystem)
+	s.keyDir = c.MkDir()
 }
 
 func (s *managedfsSuite) TearDownTest(c *gc.C) {
@@ -44,6 +48,7 @@ func (s *managedfsSuite) initSource(c *gc.C) storage.FilesystemSource {
 		s.commands.run,
 		s.blockDevices,
 		s.filesystems,
+		s.keyDir,
 	)
 	s.dirFuncs = mockDirFuncs
 	return source
@@ -185,3 +190,155 @@ func (s *managedfsSuite) TestDetachFilesystemsUnattached(c *gc.C) {
 	source := s.initSource(c)
 	testDetachFilesystems(c, s.commands, source, false)
 }
+
+func (s *managedfsSuite) TestCreateFilesystemsLUKS(c *gc.C) {
+	source := s.initSource(c)
+	keyFile := filepath.Join(s.keyDir, "filesystem-0-0.key")
+	s.commands.expect("sgdisk", "--zap-all", "/dev/sda")
+	s.commands.expect("sgdisk", "-n", "1:0:-1", "/dev/sda")
+	s.commands.expect("cryptsetup", "luksFormat", "--batch-mode", "--key-file", keyFile, "/dev/sda1")
+	s.commands.expect("cryptsetup", "luksOpen", "--key-file", keyFile, "/dev/sda1", "juju-filesystem-0-0")
+	s.commands.expect("mkfs.ext4", "/dev/mapper/juju-filesystem-0-0")
+
+	s.blockDevices[names.NewVolumeTag("0")] = storage.BlockDevice{
+		DeviceName: "sda",
+		HardwareId: "capncrunch",
+		Size:       2,
+	}
+	results, err := source.CreateFilesystems([]storage.FilesystemParams{{
+		Tag:        names.NewFilesystemTag("0/0"),
+		Volume:     names.NewVolumeTag("0"),
+		Size:       2,
+		Attributes: map[string]interface{}{"luks": "true"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.CreateFilesystemsResult{{
+		Filesystem: &storage.Filesystem{
+			names.NewFilesystemTag("0/0"),
+			names.NewVolumeTag("0"),
+			storage.FilesystemInfo{
+				FilesystemId: "filesystem-0-0",
+				Size:         2,
+				Encrypted:    true,
+			},
+		},
+	}})
+
+	info, err := os.Stat(keyFile)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.Mode().Perm(), gc.Equals, os.FileMode(0600))
+	c.Assert(info.Size(), gc.Equals, int64(64))
+}
+
+func (s *managedfsSuite) TestValidateFilesystemParamsLUKSNoKeyDir(c *gc.C) {
+	s.keyDir = ""
+	source := s.initSource(c)
+	s.blockDevices[names.NewVolumeTag("0")] = storage.BlockDevice{DeviceName: "sda"}
+	err := source.ValidateFilesystemParams(storage.FilesystemParams{
+		Tag:        names.NewFilesystemTag("0/0"),
+		Volume:     names.NewVolumeTag("0"),
+		Attributes: map[string]interface{}{"luks": true},
+	})
+	c.Assert(err, gc.ErrorMatches, "LUKS encryption without a key directory not supported")
+}
+
+func (s *managedfsSuite) TestValidateFilesystemParamsLUKSInvalid(c *gc.C) {
+	source := s.initSource(c)
+	s.blockDevices[names.NewVolumeTag("0")] = storage.BlockDevice{DeviceName: "sda"}
+	err := source.ValidateFilesystemParams(storage.FilesystemParams{
+		Tag:        names.NewFilesystemTag("0/0"),
+		Volume:     names.NewVolumeTag("0"),
+		Attributes: map[string]interface{}{"luks": "maybe"},
+	})
+	c.Assert(err, gc.ErrorMatches, `luks: expected bool, got string\("maybe"\)`)
+}
+
+func (s *managedfsSuite) TestAttachFilesystemsLUKS(c *gc.C) {
+	const testMountPoint = "/in/the/place"
+
+	source := s.initSource(c)
+	keyFile := filepath.Join(s.keyDir, "filesystem-0-0.key")
+	s.commands.expect(
+		"cryptsetup", "luksOpen", "--readonly", "--key-file", keyFile,
+		"/dev/sda1", "juju-filesystem-0-0",
+	)
+	cmd := s.commands.expect("df", "--output=source", filepath.Dir(testMountPoint))
+	cmd.respond("headers\n/same/as/rootfs", nil)
+	cmd = s.commands.expect("df", "--output=source", testMountPoint)
+	cmd.respond("headers\n/same/as/rootfs", nil)
+	s.commands.expect("mount", "-o", "ro", "/dev/mapper/juju-filesystem-0-0", testMountPoint)
+
+	s.blockDevices[names.NewVolumeTag("0")] = storage.BlockDevice{
+		DeviceName: "sda",
+		HardwareId: "capncrunch",
+		Size:       2,
+	}
+	s.filesystems[names.NewFilesystemTag("0/0")] = storage.Filesystem{
+		Tag:    names.NewFilesystemTag("0/0"),
+		Volume: names.NewVolumeTag("0"),
+		FilesystemInfo: storage.FilesystemInfo{
+			FilesystemId: "filesystem-0-0",
+			Encrypted:    true,
+		},
+	}
+
+	results, err := source.AttachFilesystems([]storage.FilesystemAttachmentParams{{
+		Filesystem:   names.NewFilesystemTag("0/0"),
+		FilesystemId: "filesystem-0-0",
+		AttachmentParams: storage.AttachmentParams{
+			Machine:    names.NewMachineTag("0"),
+			InstanceId: "inst-ance",
+			ReadOnly:   true,
+		},
+		Path: testMountPoint,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+}
+
+func (s *managedfsSuite) TestDetachFilesystemsLUKS(c *gc.C) {
+	source := s.initSource(c)
+	s.filesystems[names.NewFilesystemTag("0/0")] = storage.Filesystem{
+		Tag:    names.NewFilesystemTag("0/0"),
+		Volume: names.NewVolumeTag("0"),
+		FilesystemInfo: storage.FilesystemInfo{
+			FilesystemId: "filesystem-0-0",
+			Encrypted:    true,
+		},
+	}
+	s.dirFuncs.Dirs.Add("/dev/mapper/juju-filesystem-0-0")
+
+	const testMountPoint = "/in/the/place"
+	cmd := s.commands.expect("df", "--output=source", filepath.Dir(testMountPoint))
+	cmd.respond("headers\n/same/as/rootfs", nil)
+	cmd = s.commands.expect("df", "--output=source", testMountPoint)
+	cmd.respond("headers\n/different/to/rootfs", nil)
+	s.commands.expect("umount", testMountPoint)
+	s.commands.expect("cryptsetup", "luksClose", "juju-filesystem-0-0")
+
+	results, err := source.DetachFilesystems([]storage.FilesystemAttachmentParams{{
+		Filesystem:   names.NewFilesystemTag("0/0"),
+		FilesystemId: "filesystem-0-0",
+		AttachmentParams: storage.AttachmentParams{
+			Machine:    names.NewMachineTag("0"),
+			InstanceId: "inst-id",
+		},
+		Path: testMountPoint,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []error{nil})
+}
+
+func (s *managedfsSuite) TestDestroyFilesystemsLUKS(c *gc.C) {
+	source := s.initSource(c)
+	keyFile := filepath.Join(s.keyDir, "filesystem-0-0.key")
+	err := ioutil.WriteFile(keyFile, []byte("secret"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	errs, err := source.DestroyFilesystems([]string{"filesystem-0-0", "filesystem-0-1"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs, jc.DeepEquals, []error{nil, nil})
+	_, err = os.Stat(keyFile)
+	c.Assert(err, jc.Satisfies, os.IsNotExist)
+}
@@ -0,0 +1,332 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/storage"
+)
+
+const (
+	// NFS provider type.
+	NFSProviderType = storage.ProviderType("nfs")
+
+	// NFSExport is the name of the pool config attribute specifying
+	// the absolute path of the exported directory in which
+	// filesystems are created.
+	NFSExport = "export"
+
+	// NFSServer is the name of the pool config attribute specifying
+	// the NFS server that exports the directory. If unspecified,
+	// the export directory must already be mounted at the same path
+	// on every machine that the filesystems are attached to.
+	NFSServer = "server"
+)
+
+var nfsConfigFields = schema.Fields{
+	NFSExport: schema.String(),
+	NFSServer: schema.String(),
+}
+
+var nfsConfigChecker = schema.FieldMap(
+	nfsConfigFields,
+	schema.Defaults{
+		NFSExport: schema.Omit,
+		NFSServer: schema.Omit,
+	},
+)
+
+type nfsConfig struct {
+	export string
+	server string
+}
+
+func newNFSConfig(attrs map[string]interface{}) (*nfsConfig, error) {
+	out, err := nfsConfigChecker.Coerce(attrs, nil)
+	if err != nil {
+		return nil, errors.Annotate(err, "validating NFS storage config")
+	}
+	coerced := out.(map[string]interface{})
+	export, _ := coerced[NFSExport].(string)
+	server, _ := coerced[NFSServer].(string)
+	if export == "" {
+		return nil, errors.New("export directory not specified")
+	}
+	if !path.IsAbs(export) {
+		return nil, errors.NotValidf("export directory %q (must be absolute)", export)
+	}
+	if strings.ContainsAny(server, "/ ") {
+		return nil, errors.NotValidf("server %q", server)
+	}
+	return &nfsConfig{
+		export: path.Clean(export),
+		server: server,
+	}, nil
+}
+
+// nfsProvider creates filesystem sources which provide directories
+// within a shared, exported directory. The filesystems may be attached
+// to many machines at once, and so may be used for storage that is
+// shared by all units of an application.
+//
+// Filesystems are created as subdirectories of the export directory,
+// which are bind-mounted at the attachment paths. If a server is
+// specified, the export is mounted on each machine as required.
+type nfsProvider struct {
+	// run is a function used for running commands on the local machine.
+	run runCommandFunc
+}
+
+var (
+	_ storage.Provider                 = (*nfsProvider)(nil)
+	_ storage.SharedFilesystemProvider = (*nfsProvider)(nil)
+)
+
+// ValidateConfig is defined on the Provider interface.
+func (*nfsProvider) ValidateConfig(cfg *storage.Config) error {
+	_, err := newNFSConfig(cfg.Attrs())
+	return errors.Trace(err)
+}
+
+// VolumeSource is defined on the Provider interface.
+func (*nfsProvider) VolumeSource(
+	environConfig *config.Config,
+	sourceConfig *storage.Config,
+) (storage.VolumeSource, error) {
+	return nil, errors.NotSupportedf("volumes")
+}
+
+// FilesystemSource is defined on the Provider interface.
+func (p *nfsProvider) FilesystemSource(
+	environConfig *config.Config,
+	sourceConfig *storage.Config,
+) (storage.FilesystemSource, error) {
+	// The storage directory is only required for mounting exports
+	// on machines; filesystems are created by the environment
+	// storage provisioner, which has no storage directory.
+	storageDir, _ := sourceConfig.ValueString(storage.ConfigStorageDir)
+	return &nfsFilesystemSource{
+		&osDirFuncs{p.run},
+		p.run,
+		storageDir,
+	}, nil
+}
+
+// Supports is defined on the Provider interface.
+func (*nfsProvider) Supports(k storage.StorageKind) bool {
+	return k == storage.StorageKindFilesystem
+}
+
+// Scope is defined on the Provider interface.
+func (*nfsProvider) Scope() storage.Scope {
+	return storage.ScopeEnviron
+}
+
+// Dynamic is defined on the Provider interface.
+func (*nfsProvider) Dynamic() bool {
+	return true
+}
+
+// SharedFilesystems is defined on the SharedFilesystemProvider interface.
+func (*nfsProvider) SharedFilesystems() bool {
+	return true
+}
+
+// nfsFilesystemSource is a storage.FilesystemSource that creates
+// filesystems as directories within an exported directory.
+//
+// Filesystem IDs are of the form "[<server>:]<export>/<name>", so that
+// machines may attach the filesystems without reference to the pool
+// configuration.
+type nfsFilesystemSource struct {
+	dirFuncs   dirFuncs
+	run        runCommandFunc
+	storageDir string
+}
+
+var _ storage.FilesystemSource = (*nfsFilesystemSource)(nil)
+
+// ValidateFilesystemParams is defined on the FilesystemSource interface.
+func (s *nfsFilesystemSource) ValidateFilesystemParams(params storage.FilesystemParams) error {
+	_, err := newNFSConfig(params.Attributes)
+	return errors.Trace(err)
+}
+
+// CreateFilesystems is defined on the FilesystemSource interface.
+func (s *nfsFilesystemSource) CreateFilesystems(args []storage.FilesystemParams) ([]storage.CreateFilesystemsResult, error) {
+	results := make([]storage.CreateFilesystemsResult, len(args))
+	for i, arg := range args {
+		filesystem, err := s.createFilesystem(arg)
+		if err != nil {
+			results[i].Error = errors.Annotate(err, "creating filesystem")
+			continue
+		}
+		results[i].Filesystem = filesystem
+	}
+	return results, nil
+}
+
+func (s *nfsFilesystemSource) createFilesystem(params storage.FilesystemParams) (*storage.Filesystem, error) {
+	cfg, err := newNFSConfig(params.Attributes)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// The directory is created when the filesystem is first
+	// attached, as the export may not be available to the
+	// environment storage provisioner. We include the model
+	// UUID in the name, so that an export may be shared by
+	// multiple models.
+	name := params.Tag.String()
+	if modelUUID := params.ResourceTags[tags.JujuModel]; modelUUID != "" {
+		name = modelUUID + "-" + name
+	}
+	filesystemId := path.Join(cfg.export, name)
+	if cfg.server != "" {
+		filesystemId = cfg.server + ":" + filesystemId
+	}
+	// The size of the filesystem is limited only by the export,
+	// so we record the requested size.
+	return &storage.Filesystem{
+		Tag: params.Tag,
+		FilesystemInfo: storage.FilesystemInfo{
+			FilesystemId: filesystemId,
+			Size:         params.Size,
+		},
+	}, nil
+}
+
+// DestroyFilesystems is defined on the FilesystemSource interface.
+func (s *nfsFilesystemSource) DestroyFilesystems(filesystemIds []string) ([]error, error) {
+	// DestroyFilesystems is a no-op; the directories are left in the
+	// export for the operator to archive or remove, as they may not
+	// be accessible from the environment storage provisioner.
+	return make([]error, len(filesystemIds)), nil
+}
+
+// AttachFilesystems is defined on the FilesystemSource interface.
+func (s *nfsFilesystemSource) AttachFilesystems(args []storage.FilesystemAttachmentParams) ([]storage.AttachFilesystemsResult, error) {
+	results := make([]storage.AttachFilesystemsResult, len(args))
+	for i, arg := range args {
+		attachment, err := s.attachFilesystem(arg)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "attaching filesystem %v", arg.Filesystem.Id())
+			continue
+		}
+		results[i].FilesystemAttachment = attachment
+	}
+	return results, nil
+}
+
+func (s *nfsFilesystemSource) attachFilesystem(arg storage.FilesystemAttachmentParams) (*storage.FilesystemAttachment, error) {
+	mountPoint := arg.Path
+	if mountPoint == "" {
+		return nil, errNoMountPoint
+	}
+	server, exportPath, err := parseNFSFilesystemId(arg.FilesystemId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	exportDir, name := path.Split(exportPath)
+	exportDir = path.Clean(exportDir)
+	if server != "" {
+		if exportDir, err = s.mountExport(server, exportDir); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	source := filepath.Join(exportDir, name)
+	if err := ensureDir(s.dirFuncs, source); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := ensureDir(s.dirFuncs, mountPoint); err != nil {
+		return nil, errors.Trace(err)
+	}
+	mounted, _, err := isMounted(s.dirFuncs, mountPoint)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !mounted {
+		logger.Debugf("mounting %q at %q", source, mountPoint)
+		if _, err := s.run("mount", "--bind", source, mountPoint); err != nil {
+			return nil, errors.Annotate(err, "bind-mounting filesystem")
+		}
+		if arg.ReadOnly {
+			if _, err := s.run("mount", "-o", "remount,bind,ro", mountPoint); err != nil {
+				return nil, errors.Annotate(err, "remounting filesystem read-only")
+			}
+		}
+	}
+	return &storage.FilesystemAttachment{
+		Filesystem: arg.Filesystem,
+		Machine:    arg.Machine,
+		FilesystemAttachmentInfo: storage.FilesystemAttachmentInfo{
+			Path:     mountPoint,
+			ReadOnly: arg.ReadOnly,
+		},
+	}, nil
+}
+
+// mountExport ensures that the export directory on the given server
+// is mounted on the local machine, and returns the local mount point.
+// The export is mounted once per machine, and left mounted for other
+// filesystems in the same export.
+func (s *nfsFilesystemSource) mountExport(server, exportDir string) (string, error) {
+	if s.storageDir == "" {
+		return "", errors.New("storage directory not specified")
+	}
+	remote := server + ":" + exportDir
+	mountPoint := filepath.Join(s.storageDir, server, exportDir)
+	if err := ensureDir(s.dirFuncs, mountPoint); err != nil {
+		return "", errors.Trace(err)
+	}
+	mounted, source, err := isMounted(s.dirFuncs, mountPoint)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if mounted {
+		if source != remote {
+			return "", errors.Errorf(
+				"%q is already mounted from %q", mountPoint, source,
+			)
+		}
+		return mountPoint, nil
+	}
+	logger.Debugf("mounting %q at %q", remote, mountPoint)
+	if _, err := s.run("mount", "-t", "nfs", remote, mountPoint); err != nil {
+		return "", errors.Annotatef(err, "mounting %q", remote)
+	}
+	return mountPoint, nil
+}
+
+// DetachFilesystems is defined on the FilesystemSource interface.
+func (s *nfsFilesystemSource) DetachFilesystems(args []storage.FilesystemAttachmentParams) ([]error, error) {
+	results := make([]error, len(args))
+	for i, arg := range args {
+		if err := maybeUnmount(s.run, s.dirFuncs, arg.Path); err != nil {
+			results[i] = errors.Annotatef(err, "detaching filesystem %v", arg.Filesystem.Id())
+		}
+	}
+	return results, nil
+}
+
+// parseNFSFilesystemId parses a filesystem ID created by the NFS
+// filesystem source, returning the server (if any) and the path of
+// the filesystem's directory within the export.
+func parseNFSFilesystemId(filesystemId string) (server, exportPath string, _ error) {
+	exportPath = filesystemId
+	if i := strings.Index(filesystemId, ":/"); i > 0 {
+		server, exportPath = filesystemId[:i], filesystemId[i+1:]
+	}
+	if !path.IsAbs(exportPath) || path.Clean(exportPath) != exportPath || path.Dir(exportPath) == exportPath {
+		return "", "", errors.Errorf("invalid NFS filesystem ID %q", filesystemId)
+	}
+	return server, exportPath, nil
+}
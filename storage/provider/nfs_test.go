@@ -0,0 +1,261 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"path/filepath"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/provider"
+	"github.com/juju/juju/testing"
+)
+
+var _ = gc.Suite(&nfsSuite{})
+
+type nfsSuite struct {
+	testing.BaseSuite
+	storageDir string
+	commands   *mockRunCommand
+}
+
+func (s *nfsSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.storageDir = c.MkDir()
+}
+
+func (s *nfsSuite) TearDownTest(c *gc.C) {
+	if s.commands != nil {
+		s.commands.assertDrained()
+	}
+	s.BaseSuite.TearDownTest(c)
+}
+
+func (s *nfsSuite) nfsProvider(c *gc.C) storage.Provider {
+	s.commands = &mockRunCommand{c: c}
+	return provider.NFSProvider(s.commands.run)
+}
+
+func (s *nfsSuite) nfsFilesystemSource(c *gc.C) (storage.FilesystemSource, *provider.MockDirFuncs) {
+	s.commands = &mockRunCommand{c: c}
+	return provider.NFSFilesystemSource(s.storageDir, s.commands.run)
+}
+
+// expectNotMounted adds expectations for checking whether or not
+// the specified path is a mount point, responding that it is not.
+func (s *nfsSuite) expectNotMounted(path string) {
+	cmd := s.commands.expect("df", "--output=source", filepath.Dir(path))
+	cmd.respond("headers\n/dev/sda1", nil)
+	cmd = s.commands.expect("df", "--output=source", path)
+	cmd.respond("headers\n/dev/sda1", nil)
+}
+
+func (s *nfsSuite) TestValidateConfig(c *gc.C) {
+	p := s.nfsProvider(c)
+	for i, test := range []struct {
+		attrs map[string]interface{}
+		err   string
+	}{{
+		attrs: map[string]interface{}{},
+		err:   "export directory not specified",
+	}, {
+		attrs: map[string]interface{}{"export": "srv/shared"},
+		err:   `export directory "srv/shared" \(must be absolute\) not valid`,
+	}, {
+		attrs: map[string]interface{}{"export": "/srv/shared", "server": "nfs/1"},
+		err:   `server "nfs/1" not valid`,
+	}, {
+		attrs: map[string]interface{}{"export": 123},
+		err:   `validating NFS storage config: export: expected string, got int\(123\)`,
+	}, {
+		attrs: map[string]interface{}{"export": "/srv/shared"},
+	}, {
+		attrs: map[string]interface{}{"export": "/srv/shared", "server": "10.0.0.1"},
+	}} {
+		c.Logf("test %d: %v", i, test.attrs)
+		cfg, err := storage.NewConfig("name", provider.NFSProviderType, test.attrs)
+		c.Assert(err, jc.ErrorIsNil)
+		err = p.ValidateConfig(cfg)
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.err)
+		}
+	}
+}
+
+func (s *nfsSuite) TestVolumeSource(c *gc.C) {
+	p := s.nfsProvider(c)
+	cfg, err := storage.NewConfig("name", provider.NFSProviderType, map[string]interface{}{})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = p.VolumeSource(nil, cfg)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *nfsSuite) TestSupports(c *gc.C) {
+	p := s.nfsProvider(c)
+	c.Assert(p.Supports(storage.StorageKindBlock), jc.IsFalse)
+	c.Assert(p.Supports(storage.StorageKindFilesystem), jc.IsTrue)
+}
+
+func (s *nfsSuite) TestScope(c *gc.C) {
+	p := s.nfsProvider(c)
+	c.Assert(p.Scope(), gc.Equals, storage.ScopeEnviron)
+}
+
+func (s *nfsSuite) TestDynamic(c *gc.C) {
+	p := s.nfsProvider(c)
+	c.Assert(p.Dynamic(), jc.IsTrue)
+}
+
+func (s *nfsSuite) TestSharedFilesystems(c *gc.C) {
+	p := s.nfsProvider(c)
+	c.Assert(storage.IsSharedFilesystemProvider(p), jc.IsTrue)
+	c.Assert(storage.IsSharedFilesystemProvider(provider.LoopProvider(nil)), jc.IsFalse)
+}
+
+func (s *nfsSuite) TestCreateFilesystems(c *gc.C) {
+	source, _ := s.nfsFilesystemSource(c)
+	results, err := source.CreateFilesystems([]storage.FilesystemParams{{
+		Tag:        names.NewFilesystemTag("0"),
+		Size:       1024,
+		Provider:   provider.NFSProviderType,
+		Attributes: map[string]interface{}{"export": "/srv/shared/"},
+	}, {
+		Tag:        names.NewFilesystemTag("1"),
+		Size:       2048,
+		Provider:   provider.NFSProviderType,
+		Attributes: map[string]interface{}{"export": "/exports", "server": "10.0.0.1"},
+		ResourceTags: map[string]string{
+			tags.JujuModel: "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		},
+	}, {
+		Tag:        names.NewFilesystemTag("2"),
+		Size:       1024,
+		Provider:   provider.NFSProviderType,
+		Attributes: map[string]interface{}{},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 3)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].Filesystem, jc.DeepEquals, &storage.Filesystem{
+		Tag: names.NewFilesystemTag("0"),
+		FilesystemInfo: storage.FilesystemInfo{
+			FilesystemId: "/srv/shared/filesystem-0",
+			Size:         1024,
+		},
+	})
+	c.Assert(results[1].Error, jc.ErrorIsNil)
+	c.Assert(results[1].Filesystem, jc.DeepEquals, &storage.Filesystem{
+		Tag: names.NewFilesystemTag("1"),
+		FilesystemInfo: storage.FilesystemInfo{
+			FilesystemId: "10.0.0.1:/exports/deadbeef-0bad-400d-8000-4b1d0d06f00d-filesystem-1",
+			Size:         2048,
+		},
+	})
+	c.Assert(results[2].Error, gc.ErrorMatches, "creating filesystem: export directory not specified")
+}
+
+func (s *nfsSuite) TestDestroyFilesystems(c *gc.C) {
+	source, _ := s.nfsFilesystemSource(c)
+	errs, err := source.DestroyFilesystems([]string{"/srv/shared/filesystem-0"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs, jc.DeepEquals, []error{nil})
+}
+
+func (s *nfsSuite) TestAttachFilesystemsLocalExport(c *gc.C) {
+	source, dirFuncs := s.nfsFilesystemSource(c)
+	s.expectNotMounted("/srv/uploads")
+	s.commands.expect("mount", "--bind", "/srv/shared/filesystem-0", "/srv/uploads")
+
+	results, err := source.AttachFilesystems([]storage.FilesystemAttachmentParams{{
+		Filesystem:   names.NewFilesystemTag("0"),
+		FilesystemId: "/srv/shared/filesystem-0",
+		Path:         "/srv/uploads",
+		AttachmentParams: storage.AttachmentParams{
+			Machine: names.NewMachineTag("1"),
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.AttachFilesystemsResult{{
+		FilesystemAttachment: &storage.FilesystemAttachment{
+			Filesystem: names.NewFilesystemTag("0"),
+			Machine:    names.NewMachineTag("1"),
+			FilesystemAttachmentInfo: storage.FilesystemAttachmentInfo{
+				Path: "/srv/uploads",
+			},
+		},
+	}})
+	c.Assert(dirFuncs.Dirs.Contains("/srv/shared/filesystem-0"), jc.IsTrue)
+	c.Assert(dirFuncs.Dirs.Contains("/srv/uploads"), jc.IsTrue)
+}
+
+func (s *nfsSuite) TestAttachFilesystemsRemoteExportReadOnly(c *gc.C) {
+	source, dirFuncs := s.nfsFilesystemSource(c)
+	exportMountPoint := filepath.Join(s.storageDir, "10.0.0.1", "exports")
+	s.expectNotMounted(exportMountPoint)
+	s.commands.expect("mount", "-t", "nfs", "10.0.0.1:/exports", exportMountPoint)
+	s.expectNotMounted("/srv/uploads")
+	s.commands.expect("mount", "--bind", filepath.Join(exportMountPoint, "filesystem-0"), "/srv/uploads")
+	s.commands.expect("mount", "-o", "remount,bind,ro", "/srv/uploads")
+
+	results, err := source.AttachFilesystems([]storage.FilesystemAttachmentParams{{
+		Filesystem:   names.NewFilesystemTag("0"),
+		FilesystemId: "10.0.0.1:/exports/filesystem-0",
+		Path:         "/srv/uploads",
+		AttachmentParams: storage.AttachmentParams{
+			Machine:  names.NewMachineTag("1"),
+			ReadOnly: true,
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].FilesystemAttachment.ReadOnly, jc.IsTrue)
+	c.Assert(dirFuncs.Dirs.Contains(filepath.Join(exportMountPoint, "filesystem-0")), jc.IsTrue)
+}
+
+func (s *nfsSuite) TestAttachFilesystemsRemoteExportAlreadyMounted(c *gc.C) {
+	source, _ := s.nfsFilesystemSource(c)
+	exportMountPoint := filepath.Join(s.storageDir, "10.0.0.1", "exports")
+	cmd := s.commands.expect("df", "--output=source", filepath.Dir(exportMountPoint))
+	cmd.respond("headers\n/dev/sda1", nil)
+	cmd = s.commands.expect("df", "--output=source", exportMountPoint)
+	cmd.respond("headers\n10.0.0.2:/exports", nil)
+
+	results, err := source.AttachFilesystems([]storage.FilesystemAttachmentParams{{
+		Filesystem:   names.NewFilesystemTag("0"),
+		FilesystemId: "10.0.0.1:/exports/filesystem-0",
+		Path:         "/srv/uploads",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches,
+		`attaching filesystem 0: ".*" is already mounted from "10.0.0.2:/exports"`,
+	)
+}
+
+func (s *nfsSuite) TestAttachFilesystemsInvalidId(c *gc.C) {
+	source, _ := s.nfsFilesystemSource(c)
+	results, err := source.AttachFilesystems([]storage.FilesystemAttachmentParams{{
+		Filesystem:   names.NewFilesystemTag("0"),
+		FilesystemId: "/srv/shared/../../etc",
+		Path:         "/srv/uploads",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches,
+		`attaching filesystem 0: invalid NFS filesystem ID "/srv/shared/../../etc"`,
+	)
+}
+
+func (s *nfsSuite) TestDetachFilesystems(c *gc.C) {
+	source, _ := s.nfsFilesystemSource(c)
+	testDetachFilesystems(c, s.commands, source, true)
+}
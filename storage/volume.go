@@ -30,6 +30,9 @@ type VolumeInfo struct {
 	// Persistent reflects whether the volume is destroyed with the
 	// machine to which it is attached.
 	Persistent bool
+
+	// Encrypted reflects whether the volume is encrypted at rest.
+	Encrypted bool
 }
 
 // VolumeAttachment identifies and describes machine-specific volume
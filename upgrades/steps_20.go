@@ -0,0 +1,20 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package upgrades
+
+import "github.com/juju/juju/state"
+
+// stateStepsFor20 returns upgrade steps for Juju 2.0 that manipulate
+// state directly.
+func stateStepsFor20() []Step {
+	return []Step{
+		&upgradeStep{
+			description: "rename IMPORT and VALIDATION migration phases",
+			targets:     []Target{DatabaseMaster},
+			run: func(context Context) error {
+				return state.RenameMigrationPhases(context.State())
+			},
+		},
+	}
+}
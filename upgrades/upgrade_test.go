@@ -669,7 +669,7 @@ func (s *upgradeSuite) TestUpgradeOperationsOrdered(c *gc.C) {
 func (s *upgradeSuite) TestStateUpgradeOperationsVersions(c *gc.C) {
 	versions := extractUpgradeVersions(c, (*upgrades.StateUpgradeOperations)())
 	c.Assert(versions, gc.DeepEquals, []string{
-		"1.26-placeholder1",
+		"2.0.0",
 	})
 }
 
@@ -684,8 +684,11 @@ func extractUpgradeVersions(c *gc.C, ops []upgrades.Operation) []string {
 	var versions []string
 	for _, utv := range ops {
 		vers := utv.TargetVersion()
-		// Upgrade steps should only be targeted at final versions (not alpha/beta).
-		c.Check(vers.Tag, gc.Equals, "placeholder")
+		// Upgrade steps should only be targeted at final versions (not
+		// alpha/beta), or at the placeholder used when there are none.
+		if vers.Tag != "placeholder" {
+			c.Check(vers.Tag, gc.Equals, "")
+		}
 		versions = append(versions, vers.String())
 	}
 	return versions
@@ -0,0 +1,88 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/replicaset"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+)
+
+// This file contains untested shims to let us wrap state in a sensible
+// interface and avoid writing tests that depend on mongodb. If you were
+// to change any part of it so that it were no longer *obviously* and
+// *trivially* correct, you would be Doing It Wrong.
+
+// NewStateBackend returns a Backend that creates backups of the
+// controller on the machine with the given ID.
+func NewStateBackend(st *state.State, paths backups.Paths, machineID string) Backend {
+	return &stateBackend{st, paths, machineID}
+}
+
+type stateBackend struct {
+	st        *state.State
+	paths     backups.Paths
+	machineID string
+}
+
+// CreateBackup is part of the Backend interface.
+func (b *stateBackend) CreateBackup(schedule string) (*backups.Metadata, error) {
+	stor, err := backups.NewBackupStorage(b.st)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer stor.Close()
+
+	session := b.st.MongoSession().Copy()
+	defer session.Close()
+
+	// Don't go if HA isn't ready.
+	if err := replicaset.WaitUntilReady(session, 60); err != nil {
+		return nil, errors.Annotate(err, "HA not ready")
+	}
+	dbInfo, err := backups.NewDBInfo(b.st.MongoConnectionInfo(), session)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	machine, err := b.st.Machine(b.machineID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	meta, err := backups.NewMetadataState(b.st, b.machineID, machine.Series())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	meta.Schedule = schedule
+	if err := backups.NewBackups(stor).Create(meta, &b.paths, dbInfo, backups.EncryptArgs{}); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return meta, nil
+}
+
+// ListBackups is part of the Backend interface.
+func (b *stateBackend) ListBackups() ([]*backups.Metadata, error) {
+	stor, err := backups.NewBackupStorage(b.st)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer stor.Close()
+	return backups.NewBackups(stor).List()
+}
+
+// RemoveBackup is part of the Backend interface.
+func (b *stateBackend) RemoveBackup(id string) error {
+	stor, err := backups.NewBackupStorage(b.st)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer stor.Close()
+	return backups.NewBackups(stor).Remove(id)
+}
+
+// SetScheduleStatus is part of the Backend interface.
+func (b *stateBackend) SetScheduleStatus(status backups.ScheduleStatus) error {
+	return backups.SetScheduleStatus(b.st, status)
+}
@@ -0,0 +1,71 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/clock"
+	gc "gopkg.in/check.v1"
+
+	corebackups "github.com/juju/juju/core/backups"
+	"github.com/juju/juju/worker/backupscheduler"
+)
+
+type ValidateSuite struct {
+	testing.IsolationSuite
+	config backupscheduler.Config
+}
+
+var _ = gc.Suite(&ValidateSuite{})
+
+func (s *ValidateSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	schedule, err := corebackups.ParseSchedule("@daily")
+	c.Assert(err, jc.ErrorIsNil)
+	s.config = backupscheduler.Config{
+		Backend:  struct{ backupscheduler.Backend }{},
+		Clock:    struct{ clock.Clock }{},
+		Schedule: schedule,
+	}
+}
+
+func (s *ValidateSuite) TestValid(c *gc.C) {
+	err := s.config.Validate()
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *ValidateSuite) TestNilBackend(c *gc.C) {
+	s.config.Backend = nil
+	s.checkNotValid(c, "nil Backend not valid")
+}
+
+func (s *ValidateSuite) TestNilClock(c *gc.C) {
+	s.config.Clock = nil
+	s.checkNotValid(c, "nil Clock not valid")
+}
+
+func (s *ValidateSuite) TestNilSchedule(c *gc.C) {
+	s.config.Schedule = nil
+	s.checkNotValid(c, "nil Schedule not valid")
+}
+
+func (s *ValidateSuite) TestBadRetention(c *gc.C) {
+	s.config.Retention.KeepWeekly = -1
+	s.checkNotValid(c, "keep-weekly -1 not valid")
+}
+
+func (s *ValidateSuite) checkNotValid(c *gc.C, match string) {
+	check := func(err error) {
+		c.Check(err, gc.ErrorMatches, match)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	}
+	err := s.config.Validate()
+	check(err)
+
+	worker, err := backupscheduler.NewWorker(s.config)
+	c.Check(worker, gc.IsNil)
+	check(err)
+}
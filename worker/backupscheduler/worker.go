@@ -0,0 +1,177 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	"launchpad.net/tomb"
+
+	corebackups "github.com/juju/juju/core/backups"
+	"github.com/juju/juju/state/backups"
+	"github.com/juju/juju/worker"
+)
+
+var logger = loggo.GetLogger("juju.worker.backupscheduler")
+
+// Backend exposes the backup functionality required by the worker.
+type Backend interface {
+	// CreateBackup creates and stores a new backup, recording
+	// the schedule on which it was created in its metadata.
+	CreateBackup(schedule string) (*backups.Metadata, error)
+
+	// ListBackups returns the metadata for all stored backups.
+	ListBackups() ([]*backups.Metadata, error)
+
+	// RemoveBackup removes the identified backup from storage.
+	RemoveBackup(id string) error
+
+	// SetScheduleStatus records the status of scheduled backups.
+	SetScheduleStatus(backups.ScheduleStatus) error
+}
+
+// Config defines the operation of a backup scheduler worker.
+type Config struct {
+
+	// Backend is the worker's view of the controller's backups.
+	Backend Backend
+
+	// Clock is the worker's view of time.
+	Clock clock.Clock
+
+	// Schedule determines when backups are created.
+	Schedule *corebackups.Schedule
+
+	// Retention determines which scheduled backups are kept
+	// after each scheduled backup is created.
+	Retention corebackups.RetentionPolicy
+}
+
+// Validate returns an error if the configuration cannot be expected
+// to start a functional worker.
+func (config Config) Validate() error {
+	if config.Backend == nil {
+		return errors.NotValidf("nil Backend")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Schedule == nil {
+		return errors.NotValidf("nil Schedule")
+	}
+	if err := config.Retention.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// NewWorker returns a worker that creates backups on the configured
+// Schedule, recording the outcome of each run and removing scheduled
+// backups that are not kept by the Retention policy.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &backupScheduler{
+		config: config,
+	}
+	go func() {
+		defer w.tomb.Done()
+		w.tomb.Kill(w.loop())
+	}()
+	return w, nil
+}
+
+type backupScheduler struct {
+	tomb   tomb.Tomb
+	config Config
+}
+
+func (w *backupScheduler) loop() error {
+	for {
+		now := w.config.Clock.Now()
+		next := w.config.Schedule.Next(now)
+		var nextRun <-chan time.Time
+		if next.IsZero() {
+			logger.Warningf("backup schedule %q will never run", w.config.Schedule)
+		} else {
+			logger.Debugf("next scheduled backup at %s", next)
+			nextRun = w.config.Clock.After(next.Sub(now))
+		}
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case <-nextRun:
+			status := w.runBackup(next)
+			status.NextRun = w.config.Schedule.Next(w.config.Clock.Now())
+			if err := w.config.Backend.SetScheduleStatus(status); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+}
+
+// runBackup creates a scheduled backup and applies the retention
+// policy, returning the status of the run. A failure to create the
+// backup is recorded in the status rather than stopping the worker,
+// so that later runs may succeed.
+func (w *backupScheduler) runBackup(scheduled time.Time) backups.ScheduleStatus {
+	schedule := w.config.Schedule.String()
+	status := backups.ScheduleStatus{
+		Schedule: schedule,
+		LastRun:  scheduled,
+	}
+	logger.Infof("creating scheduled backup")
+	meta, err := w.config.Backend.CreateBackup(schedule)
+	if err != nil {
+		logger.Errorf("creating scheduled backup: %v", err)
+		status.LastError = err.Error()
+		return status
+	}
+	status.LastBackupID = meta.ID()
+	logger.Infof("created scheduled backup %q", meta.ID())
+	if err := w.applyRetention(); err != nil {
+		logger.Errorf("applying backup retention policy: %v", err)
+	}
+	return status
+}
+
+// applyRetention removes the scheduled backups that are not kept by
+// the retention policy. Backups created on demand are never removed.
+func (w *backupScheduler) applyRetention() error {
+	metas, err := w.config.Backend.ListBackups()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var scheduled []corebackups.Backup
+	for _, meta := range metas {
+		if meta.Schedule == "" {
+			continue
+		}
+		scheduled = append(scheduled, corebackups.Backup{
+			ID:      meta.ID(),
+			Started: meta.Started,
+		})
+	}
+	for _, id := range w.config.Retention.Expired(scheduled) {
+		logger.Infof("removing expired backup %q", id)
+		if err := w.config.Backend.RemoveBackup(id); err != nil {
+			return errors.Annotatef(err, "removing backup %q", id)
+		}
+	}
+	return nil
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *backupScheduler) Kill() {
+	w.tomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *backupScheduler) Wait() error {
+	return w.tomb.Wait()
+}
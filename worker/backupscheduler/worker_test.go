@@ -0,0 +1,286 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	corebackups "github.com/juju/juju/core/backups"
+	"github.com/juju/juju/state/backups"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/backupscheduler"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+// workerStart is the time at which the workers under test are
+// started; the first hourly backup is due 30 minutes later.
+var workerStart = time.Date(2016, 6, 1, 10, 30, 0, 0, time.UTC)
+
+func (s *WorkerSuite) TestNoBackupUntilScheduled(c *gc.C) {
+	fix := newFixture(c, "@hourly", corebackups.RetentionPolicy{})
+	fix.cleanTest(c, func(_ worker.Worker) {
+		fix.waitAlarm(c)
+		fix.clock.Advance(30*time.Minute - time.Nanosecond)
+		fix.waitNoCall(c)
+	})
+	fix.backend.stub.CheckNoCalls(c)
+}
+
+func (s *WorkerSuite) TestBackupOnSchedule(c *gc.C) {
+	fix := newFixture(c, "@hourly", corebackups.RetentionPolicy{})
+	fix.cleanTest(c, func(_ worker.Worker) {
+		fix.waitAlarm(c)
+		fix.clock.Advance(30 * time.Minute)
+		fix.waitCall(c, "SetScheduleStatus")
+		fix.waitAlarm(c)
+		fix.clock.Advance(time.Hour)
+		fix.waitCall(c, "SetScheduleStatus")
+	})
+	fix.backend.stub.CheckCallNames(c,
+		"CreateBackup", "ListBackups", "SetScheduleStatus",
+		"CreateBackup", "ListBackups", "SetScheduleStatus",
+	)
+	calls := fix.backend.stub.Calls()
+	c.Check(calls[0].Args, jc.DeepEquals, []interface{}{"@hourly"})
+	c.Check(calls[2].Args, jc.DeepEquals, []interface{}{backups.ScheduleStatus{
+		Schedule:     "@hourly",
+		LastRun:      workerStart.Add(30 * time.Minute),
+		LastBackupID: "backup-0",
+		NextRun:      workerStart.Add(90 * time.Minute),
+	}})
+	c.Check(calls[5].Args, jc.DeepEquals, []interface{}{backups.ScheduleStatus{
+		Schedule:     "@hourly",
+		LastRun:      workerStart.Add(90 * time.Minute),
+		LastBackupID: "backup-1",
+		NextRun:      workerStart.Add(150 * time.Minute),
+	}})
+}
+
+func (s *WorkerSuite) TestRetention(c *gc.C) {
+	fix := newFixture(c, "@hourly", corebackups.RetentionPolicy{KeepLast: 1})
+	fix.backend.backups = []*backups.Metadata{
+		newMetadata("scheduled-old", "@hourly", workerStart.Add(-2*time.Hour)),
+		newMetadata("on-demand", "", workerStart.Add(-3*time.Hour)),
+		newMetadata("scheduled-older", "@daily", workerStart.Add(-48*time.Hour)),
+	}
+	fix.cleanTest(c, func(_ worker.Worker) {
+		fix.waitAlarm(c)
+		fix.clock.Advance(30 * time.Minute)
+		fix.waitCall(c, "SetScheduleStatus")
+	})
+	fix.backend.stub.CheckCalls(c, []testing.StubCall{
+		{"CreateBackup", []interface{}{"@hourly"}},
+		{"ListBackups", nil},
+		{"RemoveBackup", []interface{}{"scheduled-older"}},
+		{"RemoveBackup", []interface{}{"scheduled-old"}},
+		{"SetScheduleStatus", []interface{}{backups.ScheduleStatus{
+			Schedule:     "@hourly",
+			LastRun:      workerStart.Add(30 * time.Minute),
+			LastBackupID: "backup-0",
+			NextRun:      workerStart.Add(90 * time.Minute),
+		}}},
+	})
+}
+
+func (s *WorkerSuite) TestRetentionError(c *gc.C) {
+	fix := newFixture(c, "@hourly", corebackups.RetentionPolicy{KeepLast: 1})
+	fix.backend.backups = []*backups.Metadata{
+		newMetadata("scheduled-old", "@hourly", workerStart.Add(-2*time.Hour)),
+	}
+	fix.backend.stub.SetErrors(nil, nil, errors.New("nope"))
+	fix.cleanTest(c, func(_ worker.Worker) {
+		fix.waitAlarm(c)
+		fix.clock.Advance(30 * time.Minute)
+		fix.waitCall(c, "SetScheduleStatus")
+	})
+	fix.backend.stub.CheckCallNames(c,
+		"CreateBackup", "ListBackups", "RemoveBackup", "SetScheduleStatus",
+	)
+	calls := fix.backend.stub.Calls()
+	c.Check(calls[3].Args, jc.DeepEquals, []interface{}{backups.ScheduleStatus{
+		Schedule:     "@hourly",
+		LastRun:      workerStart.Add(30 * time.Minute),
+		LastBackupID: "backup-0",
+		NextRun:      workerStart.Add(90 * time.Minute),
+	}})
+}
+
+func (s *WorkerSuite) TestCreateBackupError(c *gc.C) {
+	fix := newFixture(c, "@hourly", corebackups.RetentionPolicy{KeepLast: 1})
+	fix.backend.stub.SetErrors(errors.New("no backup for you"))
+	fix.cleanTest(c, func(_ worker.Worker) {
+		fix.waitAlarm(c)
+		fix.clock.Advance(30 * time.Minute)
+		fix.waitCall(c, "SetScheduleStatus")
+		// The worker carries on, and tries again
+		// at the next scheduled time.
+		fix.waitAlarm(c)
+	})
+	fix.backend.stub.CheckCalls(c, []testing.StubCall{
+		{"CreateBackup", []interface{}{"@hourly"}},
+		{"SetScheduleStatus", []interface{}{backups.ScheduleStatus{
+			Schedule:  "@hourly",
+			LastRun:   workerStart.Add(30 * time.Minute),
+			LastError: "no backup for you",
+			NextRun:   workerStart.Add(90 * time.Minute),
+		}}},
+	})
+}
+
+func (s *WorkerSuite) TestSetScheduleStatusError(c *gc.C) {
+	fix := newFixture(c, "@hourly", corebackups.RetentionPolicy{})
+	fix.backend.stub.SetErrors(nil, errors.New("no status for you"))
+	fix.dirtyTest(c, func(w worker.Worker) {
+		fix.waitAlarm(c)
+		fix.clock.Advance(30 * time.Minute)
+		fix.waitCall(c, "SetScheduleStatus")
+		c.Check(w.Wait(), gc.ErrorMatches, "no status for you")
+	})
+	fix.backend.stub.CheckCallNames(c, "CreateBackup", "SetScheduleStatus")
+}
+
+func newMetadata(id, schedule string, started time.Time) *backups.Metadata {
+	meta := backups.NewMetadata()
+	meta.SetID(id)
+	meta.Schedule = schedule
+	meta.Started = started
+	return meta
+}
+
+// workerFixture isolates a backupscheduler worker for testing.
+type workerFixture struct {
+	backend   *mockBackend
+	clock     *coretesting.Clock
+	schedule  *corebackups.Schedule
+	retention corebackups.RetentionPolicy
+}
+
+func newFixture(c *gc.C, spec string, retention corebackups.RetentionPolicy) workerFixture {
+	schedule, err := corebackups.ParseSchedule(spec)
+	c.Assert(err, jc.ErrorIsNil)
+	return workerFixture{
+		backend:   newMockBackend(),
+		clock:     coretesting.NewClock(workerStart),
+		schedule:  schedule,
+		retention: retention,
+	}
+}
+
+type testFunc func(worker.Worker)
+
+func (fix workerFixture) cleanTest(c *gc.C, test testFunc) {
+	fix.runTest(c, test, true)
+}
+
+func (fix workerFixture) dirtyTest(c *gc.C, test testFunc) {
+	fix.runTest(c, test, false)
+}
+
+func (fix workerFixture) runTest(c *gc.C, test testFunc, checkWaitErr bool) {
+	w, err := backupscheduler.NewWorker(backupscheduler.Config{
+		Backend:   fix.backend,
+		Clock:     fix.clock,
+		Schedule:  fix.schedule,
+		Retention: fix.retention,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer func() {
+		err := worker.Stop(w)
+		if checkWaitErr {
+			c.Check(err, jc.ErrorIsNil)
+		}
+	}()
+	test(w)
+}
+
+func (fix workerFixture) waitAlarm(c *gc.C) {
+	select {
+	case <-fix.clock.Alarms():
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for alarm")
+	}
+}
+
+func (fix workerFixture) waitCall(c *gc.C, name string) {
+	timeout := time.After(coretesting.LongWait)
+	for {
+		select {
+		case call := <-fix.backend.calls:
+			if call == name {
+				return
+			}
+		case <-timeout:
+			c.Fatalf("timed out waiting for %s call", name)
+		}
+	}
+}
+
+func (fix workerFixture) waitNoCall(c *gc.C) {
+	select {
+	case call := <-fix.backend.calls:
+		c.Fatalf("unexpected %s call", call)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+// mockBackend records (and notifies of) calls made to the backend.
+type mockBackend struct {
+	stub    *testing.Stub
+	calls   chan string
+	backups []*backups.Metadata
+	created int
+}
+
+func newMockBackend() *mockBackend {
+	return &mockBackend{
+		stub:  &testing.Stub{},
+		calls: make(chan string, 1000),
+	}
+}
+
+func (mock *mockBackend) call(name string, args ...interface{}) error {
+	mock.stub.AddCall(name, args...)
+	mock.calls <- name
+	return mock.stub.NextErr()
+}
+
+func (mock *mockBackend) CreateBackup(schedule string) (*backups.Metadata, error) {
+	if err := mock.call("CreateBackup", schedule); err != nil {
+		return nil, err
+	}
+	meta := newMetadata(
+		fmt.Sprintf("backup-%d", mock.created),
+		schedule, workerStart.Add(time.Duration(mock.created)*time.Minute),
+	)
+	mock.created++
+	mock.backups = append(mock.backups, meta)
+	return meta, nil
+}
+
+func (mock *mockBackend) ListBackups() ([]*backups.Metadata, error) {
+	if err := mock.call("ListBackups"); err != nil {
+		return nil, err
+	}
+	return mock.backups, nil
+}
+
+func (mock *mockBackend) RemoveBackup(id string) error {
+	return mock.call("RemoveBackup", id)
+}
+
+func (mock *mockBackend) SetScheduleStatus(status backups.ScheduleStatus) error {
+	return mock.call("SetScheduleStatus", status)
+}
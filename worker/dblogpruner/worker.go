@@ -4,16 +4,25 @@
 package dblogpruner
 
 import (
+	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/set"
 	"launchpad.net/tomb"
 
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/worker"
 )
 
-// LogPruneParams specifies how logs should be pruned.
+var logger = loggo.GetLogger("juju.worker.dblogpruner")
+
+// LogPruneParams specifies how logs should be pruned. MaxLogAge
+// applies to the models that do not configure their own log
+// retention.
 type LogPruneParams struct {
 	MaxLogAge       time.Duration
 	MaxCollectionMB int
@@ -58,11 +67,89 @@ func (w *pruneWorker) loop(stopCh <-chan struct{}) error {
 			return tomb.ErrDying
 		case <-time.After(p.PruneInterval):
 			// TODO(fwereade): 2016-03-17 lp:1558657
-			minLogTime := time.Now().Add(-p.MaxLogAge)
-			err := state.PruneLogs(w.st, minLogTime, p.MaxCollectionMB)
+			now := time.Now()
+			retention, err := w.modelRetention(now)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			minLogTime := now.Add(-p.MaxLogAge)
+			stats, err := state.PruneLogs(w.st, minLogTime, p.MaxCollectionMB, retention)
 			if err != nil {
 				return errors.Trace(err)
 			}
+			reportStats(stats)
+			if err := state.RecordLogPrune(w.st, now, stats); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+}
+
+// modelRetention returns the log retention of each model that has
+// configured its own.
+func (w *pruneWorker) modelRetention(now time.Time) (map[string]state.LogRetention, error) {
+	models, err := w.st.AllModels()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	retention := make(map[string]state.LogRetention)
+	for _, model := range models {
+		cfg, err := model.Config()
+		if err != nil {
+			return nil, errors.Annotatef(err, "getting config for model %q", model.UUID())
+		}
+		maxAge, maxAgeByLevel := cfg.LogRetention()
+		if maxAge == 0 && len(maxAgeByLevel) == 0 {
+			continue
+		}
+		var modelRetention state.LogRetention
+		if maxAge > 0 {
+			modelRetention.MinLogTime = now.Add(-maxAge)
+		}
+		for level, age := range maxAgeByLevel {
+			if modelRetention.MinLogTimeByLevel == nil {
+				modelRetention.MinLogTimeByLevel = make(map[loggo.Level]time.Time)
+			}
+			modelRetention.MinLogTimeByLevel[level] = now.Add(-age)
+		}
+		retention[model.UUID()] = modelRetention
+	}
+	return retention, nil
+}
+
+// reportStats logs what was pruned from each model, so that operators
+// can tell which log records have been discarded.
+func reportStats(stats *state.LogPruneStats) {
+	modelUUIDs := set.NewStrings()
+	for modelUUID := range stats.Expired {
+		modelUUIDs.Add(modelUUID)
+	}
+	for modelUUID := range stats.Oversize {
+		modelUUIDs.Add(modelUUID)
+	}
+	for _, modelUUID := range modelUUIDs.SortedValues() {
+		removed := stats.Removed(modelUUID)
+		if removed == 0 {
+			continue
+		}
+		var details []string
+		expired := stats.Expired[modelUUID]
+		var levels []int
+		for level := range expired {
+			levels = append(levels, int(level))
+		}
+		sort.Ints(levels)
+		for _, level := range levels {
+			if loggo.Level(level) == loggo.UNSPECIFIED {
+				details = append(details, fmt.Sprintf("%d expired", expired[loggo.UNSPECIFIED]))
+				continue
+			}
+			details = append(details, fmt.Sprintf("%d %s expired", expired[loggo.Level(level)], loggo.Level(level)))
+		}
+		if count := stats.Oversize[modelUUID]; count > 0 {
+			details = append(details, fmt.Sprintf("%d to limit the logs collection size", count))
 		}
+		logger.Infof("pruned %d log records for model %s (%s)",
+			removed, modelUUID, strings.Join(details, ", "))
 	}
 }
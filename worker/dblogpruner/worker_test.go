@@ -7,6 +7,7 @@ import (
 	stdtesting "testing"
 	"time"
 
+	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -107,12 +108,63 @@ func (s *suite) TestPrunesLogsBySize(c *gc.C) {
 	c.Fatal("pruning didn't happen as expected")
 }
 
+func (s *suite) TestPrunesByModelRetention(c *gc.C) {
+	err := s.State.UpdateModelConfig(map[string]interface{}{
+		"log-max-age":          "2h",
+		"log-max-age-by-level": "ERROR=48h DEBUG=1m",
+	}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	now := time.Now()
+	s.addLevelLogs(c, now.Add(-10*time.Hour), loggo.ERROR, "keep", 5)
+	s.addLevelLogs(c, now.Add(-10*time.Hour), loggo.INFO, "prune", 5)
+	s.addLevelLogs(c, now.Add(-time.Hour), loggo.INFO, "keep", 5)
+	s.addLevelLogs(c, now.Add(-time.Hour), loggo.DEBUG, "prune", 5)
+
+	// The logs are in place before the worker starts, so that they
+	// are all pruned at once.
+	noPruneMB := int(1e9)
+	s.StartWorker(c, 24*time.Hour, noPruneMB)
+
+	for attempt := testing.LongAttempt.Start(); attempt.Next(); {
+		pruneRemaining, err := s.logsColl.Find(bson.M{"x": "prune"}).Count()
+		c.Assert(err, jc.ErrorIsNil)
+		if pruneRemaining == 0 {
+			keepCount, err := s.logsColl.Find(bson.M{"x": "keep"}).Count()
+			c.Assert(err, jc.ErrorIsNil)
+			c.Assert(keepCount, gc.Equals, 10)
+			s.assertLastLogPrune(c, map[loggo.Level]int{loggo.UNSPECIFIED: 5, loggo.DEBUG: 5})
+			return
+		}
+	}
+	c.Fatal("pruning didn't happen as expected")
+}
+
+// assertLastLogPrune waits for the pruning that removed the expected
+// records to be recorded, as it happens after they are removed.
+func (s *suite) assertLastLogPrune(c *gc.C, expired map[loggo.Level]int) {
+	for attempt := testing.LongAttempt.Start(); attempt.Next(); {
+		prune, err := s.State.LastLogPrune()
+		if errors.IsNotFound(err) {
+			continue
+		}
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(prune.Expired, jc.DeepEquals, expired)
+		c.Assert(prune.Oversize, gc.Equals, 0)
+		return
+	}
+	c.Fatal("log pruning wasn't recorded")
+}
+
 func (s *suite) addLogs(c *gc.C, t0 time.Time, text string, count int) {
+	s.addLevelLogs(c, t0, loggo.INFO, text, count)
+}
+
+func (s *suite) addLevelLogs(c *gc.C, t0 time.Time, level loggo.Level, text string, count int) {
 	dbLogger := state.NewDbLogger(s.State, names.NewMachineTag("0"), version.Current)
 	defer dbLogger.Close()
 
 	for offset := 0; offset < count; offset++ {
 		t := t0.Add(-time.Duration(offset) * time.Second)
-		dbLogger.Log(t, "some.module", "foo.go:42", loggo.INFO, text)
+		dbLogger.Log(t, "some.module", "foo.go:42", level, text)
 	}
 }
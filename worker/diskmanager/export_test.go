@@ -4,8 +4,10 @@
 package diskmanager
 
 var (
-	ListBlockDevices = listBlockDevices
-	BlockDeviceInUse = &blockDeviceInUse
-	DoWork           = doWork
-	NewWorkerFunc    = newWorker
+	ListBlockDevices   = listBlockDevices
+	BlockDeviceInUse   = &blockDeviceInUse
+	DoWork             = doWork
+	NewWorkerFunc      = newWorker
+	DoUsageWork        = doUsageWork
+	NewUsageWorkerFunc = newUsageWorker
 )
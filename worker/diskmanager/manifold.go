@@ -37,3 +37,25 @@ func newWorker(a agent.Agent, apiCaller base.APICaller) (worker.Worker, error) {
 
 	return NewWorker(DefaultListBlockDevices, api), nil
 }
+
+// UsageManifold returns a dependency manifold that runs a worker
+// reporting filesystem usage, using the resource names defined in
+// the supplied config.
+func UsageManifold(config ManifoldConfig) dependency.Manifold {
+	typedConfig := engine.AgentApiManifoldConfig(config)
+	return engine.AgentApiManifold(typedConfig, newUsageWorker)
+}
+
+// newUsageWorker trivially wraps NewUsageWorker for use in a
+// engine.AgentApiManifold.
+func newUsageWorker(a agent.Agent, apiCaller base.APICaller) (worker.Worker, error) {
+	t := a.CurrentConfig().Tag()
+	tag, ok := t.(names.MachineTag)
+	if !ok {
+		return nil, errors.Errorf("expected MachineTag, got %#v", t)
+	}
+
+	api := apidiskmanager.NewState(apiCaller, tag)
+
+	return NewUsageWorker(DefaultFilesystemUsage, api), nil
+}
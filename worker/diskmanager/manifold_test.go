@@ -64,6 +64,37 @@ func (s *manifoldSuite) TestMachineDiskmanager(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *manifoldSuite) TestMachineUsageReporter(c *gc.C) {
+	called := false
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, response interface{},
+		) error {
+			return nil
+		})
+
+	s.PatchValue(&diskmanager.NewUsageWorker, func(u diskmanager.FilesystemUsageFunc, r diskmanager.FilesystemUsageReporter) worker.Worker {
+		called = true
+
+		c.Assert(u, gc.FitsTypeOf, diskmanager.DefaultFilesystemUsage)
+		api, ok := r.(*apidiskmanager.State)
+		c.Assert(ok, jc.IsTrue)
+		c.Assert(api, gc.NotNil)
+
+		return nil
+	})
+
+	a := &dummyAgent{
+		tag: names.NewMachineTag("1"),
+	}
+
+	_, err := diskmanager.NewUsageWorkerFunc(a, apiCaller)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
 type dummyAgent struct {
 	agent.Agent
 	tag  names.Tag
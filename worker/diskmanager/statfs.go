@@ -0,0 +1,35 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build linux
+
+package diskmanager
+
+import (
+	"syscall"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/storage"
+)
+
+func init() {
+	DefaultFilesystemUsage = filesystemUsage
+}
+
+// filesystemUsage returns the capacity usage of the filesystem
+// mounted at the specified path.
+func filesystemUsage(path string) (storage.FilesystemUsage, error) {
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(path, &st); err != nil {
+		return storage.FilesystemUsage{}, errors.Annotatef(err, "statfs %q", path)
+	}
+	blockSize := uint64(st.Bsize)
+	return storage.FilesystemUsage{
+		Path:       path,
+		UsedBytes:  (st.Blocks - st.Bfree) * blockSize,
+		FreeBytes:  st.Bavail * blockSize,
+		UsedInodes: st.Files - st.Ffree,
+		FreeInodes: st.Ffree,
+	}, nil
+}
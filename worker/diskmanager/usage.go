@@ -0,0 +1,101 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package diskmanager
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/worker"
+)
+
+const (
+	// reportUsagePeriod is the time period between filesystem
+	// usage reports.
+	reportUsagePeriod = time.Minute * 5
+
+	// usageWarningPercent is the percentage of a filesystem's bytes or
+	// inodes in use above which a warning is logged.
+	usageWarningPercent = 90
+)
+
+// FilesystemUsageReporter is an interface that is supplied to
+// NewUsageWorker for listing the Juju-managed filesystems mounted
+// on the local host, and reporting their capacity usage.
+type FilesystemUsageReporter interface {
+	FilesystemMountPoints() ([]string, error)
+	SetFilesystemUsage([]storage.FilesystemUsage) error
+}
+
+// FilesystemUsageFunc is the type of a function that is supplied to
+// NewUsageWorker for obtaining the capacity usage of the filesystem
+// mounted at the specified path.
+type FilesystemUsageFunc func(path string) (storage.FilesystemUsage, error)
+
+// DefaultFilesystemUsage is the default function for obtaining the
+// capacity usage of filesystems for the operating system of the
+// local host.
+var DefaultFilesystemUsage FilesystemUsageFunc
+
+// NewUsageWorker returns a worker that periodically records the
+// capacity usage of the Juju-managed filesystems mounted on the
+// machine.
+var NewUsageWorker = func(u FilesystemUsageFunc, r FilesystemUsageReporter) worker.Worker {
+	var old []storage.FilesystemUsage
+	f := func(stop <-chan struct{}) error {
+		return doUsageWork(u, r, &old)
+	}
+	return worker.NewPeriodicWorker(f, reportUsagePeriod, worker.NewTimer)
+}
+
+func doUsageWork(usagef FilesystemUsageFunc, r FilesystemUsageReporter, old *[]storage.FilesystemUsage) error {
+	mountPoints, err := r.FilesystemMountPoints()
+	if err != nil {
+		return errors.Annotate(err, "getting filesystem mount points")
+	}
+	usage := make([]storage.FilesystemUsage, 0, len(mountPoints))
+	for _, mountPoint := range mountPoints {
+		u, err := usagef(mountPoint)
+		if err != nil {
+			// The filesystem may not be mounted yet, or may
+			// have just been detached; try again next time.
+			logger.Debugf("cannot get usage for %q: %v", mountPoint, err)
+			continue
+		}
+		u.Path = mountPoint
+		checkUsage(u)
+		usage = append(usage, u)
+	}
+	if reflect.DeepEqual(usage, *old) {
+		logger.Tracef("no changes to filesystem usage detected")
+		return nil
+	}
+	if err := r.SetFilesystemUsage(usage); err != nil {
+		return errors.Annotate(err, "setting filesystem usage")
+	}
+	*old = usage
+	return nil
+}
+
+// checkUsage logs a warning if the filesystem is running out of space
+// or inodes.
+func checkUsage(u storage.FilesystemUsage) {
+	if percent, ok := usedPercent(u.UsedBytes, u.FreeBytes); ok && percent >= usageWarningPercent {
+		logger.Warningf("filesystem mounted at %q is %d%% full", u.Path, percent)
+	}
+	if percent, ok := usedPercent(u.UsedInodes, u.FreeInodes); ok && percent >= usageWarningPercent {
+		logger.Warningf("filesystem mounted at %q has used %d%% of its inodes", u.Path, percent)
+	}
+}
+
+func usedPercent(used, free uint64) (uint64, bool) {
+	total := used + free
+	if total == 0 {
+		return 0, false
+	}
+	return used * 100 / total, true
+}
@@ -0,0 +1,118 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package diskmanager_test
+
+import (
+	"errors"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/storage"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/diskmanager"
+)
+
+var _ = gc.Suite(&UsageWorkerSuite{})
+
+type UsageWorkerSuite struct {
+	coretesting.BaseSuite
+}
+
+func (s *UsageWorkerSuite) TestWorker(c *gc.C) {
+	done := make(chan struct{})
+	reporter := &mockUsageReporter{
+		mountPoints: []string{"/srv"},
+		setUsage: func([]storage.FilesystemUsage) error {
+			close(done)
+			return nil
+		},
+	}
+	usage := func(path string) (storage.FilesystemUsage, error) {
+		return storage.FilesystemUsage{UsedBytes: 1}, nil
+	}
+
+	w := diskmanager.NewUsageWorker(usage, reporter)
+	defer w.Wait()
+	defer w.Kill()
+
+	select {
+	case <-done:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for filesystem usage to be reported")
+	}
+}
+
+func (s *UsageWorkerSuite) TestUsageChanges(c *gc.C) {
+	var old []storage.FilesystemUsage
+	var usageSet [][]storage.FilesystemUsage
+	reporter := &mockUsageReporter{
+		mountPoints: []string{"/srv/a", "/srv/b"},
+		setUsage: func(usage []storage.FilesystemUsage) error {
+			usageSet = append(usageSet, usage)
+			return nil
+		},
+	}
+	var used uint64 = 10
+	usagef := func(path string) (storage.FilesystemUsage, error) {
+		if path == "/srv/b" {
+			return storage.FilesystemUsage{}, errors.New("not mounted")
+		}
+		return storage.FilesystemUsage{UsedBytes: used, FreeBytes: 90}, nil
+	}
+	for i := 0; i < 2; i++ {
+		err := diskmanager.DoUsageWork(usagef, reporter, &old)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	used = 20
+	err := diskmanager.DoUsageWork(usagef, reporter, &old)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(usageSet, jc.DeepEquals, [][]storage.FilesystemUsage{
+		{{Path: "/srv/a", UsedBytes: 10, FreeBytes: 90}},
+		{{Path: "/srv/a", UsedBytes: 20, FreeBytes: 90}},
+	})
+}
+
+func (s *UsageWorkerSuite) TestUsageMountPointsError(c *gc.C) {
+	var old []storage.FilesystemUsage
+	reporter := &mockUsageReporter{err: errors.New("boom")}
+	usagef := func(path string) (storage.FilesystemUsage, error) {
+		c.Fatalf("unexpected call")
+		return storage.FilesystemUsage{}, nil
+	}
+	err := diskmanager.DoUsageWork(usagef, reporter, &old)
+	c.Assert(err, gc.ErrorMatches, "getting filesystem mount points: boom")
+}
+
+func (s *UsageWorkerSuite) TestUsageSetError(c *gc.C) {
+	var old []storage.FilesystemUsage
+	reporter := &mockUsageReporter{
+		mountPoints: []string{"/srv"},
+		setUsage: func([]storage.FilesystemUsage) error {
+			return errors.New("boom")
+		},
+	}
+	usagef := func(path string) (storage.FilesystemUsage, error) {
+		return storage.FilesystemUsage{UsedBytes: 1}, nil
+	}
+	err := diskmanager.DoUsageWork(usagef, reporter, &old)
+	c.Assert(err, gc.ErrorMatches, "setting filesystem usage: boom")
+	c.Assert(old, gc.HasLen, 0)
+}
+
+type mockUsageReporter struct {
+	mountPoints []string
+	err         error
+	setUsage    func([]storage.FilesystemUsage) error
+}
+
+func (r *mockUsageReporter) FilesystemMountPoints() ([]string, error) {
+	return r.mountPoints, r.err
+}
+
+func (r *mockUsageReporter) SetFilesystemUsage(usage []storage.FilesystemUsage) error {
+	return r.setUsage(usage)
+}
@@ -0,0 +1,100 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logforwarder
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/logfwd"
+)
+
+// flusher is implemented by senders that hold records back before
+// sending them.
+type flusher interface {
+	// Pending reports whether any records are waiting to be sent.
+	Pending() bool
+
+	// Flush sends any records that are waiting to be sent.
+	Flush() error
+}
+
+// senderFlusher returns the flusher for the sender, if it has one.
+func senderFlusher(sender SendCloser) (flusher, bool) {
+	if sink, ok := sender.(*LogSink); ok {
+		sender = sink.SendCloser
+	}
+	f, ok := sender.(flusher)
+	return f, ok
+}
+
+// batchingSender collects records into batches, which are sent to
+// a batching sink. The last sent record is only tracked once the
+// batch holding it has been sent, so a batch that fails to send is
+// sent again when forwarding resumes.
+type batchingSender struct {
+	sink      BatchSendCloser
+	tracker   *lastSentTracker
+	allModels bool
+	batchSize int
+	pending   []logfwd.Record
+}
+
+// Send implements Sender. The record is sent once the batch is full,
+// or when the sender is flushed.
+func (s *batchingSender) Send(rec logfwd.Record) error {
+	s.pending = append(s.pending, rec)
+	if len(s.pending) < s.batchSize {
+		return nil
+	}
+	return errors.Trace(s.Flush())
+}
+
+// Pending implements flusher.
+func (s *batchingSender) Pending() bool {
+	return len(s.pending) > 0
+}
+
+// Flush implements flusher.
+func (s *batchingSender) Flush() error {
+	if len(s.pending) == 0 {
+		return nil
+	}
+	// A failed batch is dropped here; it is sent again when forwarding
+	// resumes from the last tracked record.
+	batch := s.pending
+	s.pending = nil
+	if err := s.sink.SendBatch(batch); err != nil {
+		return errors.Trace(err)
+	}
+
+	// Records arrive in ID order for each model, so the last one
+	// seen for a model is the one to track.
+	var models []string
+	lastIDs := make(map[string]int64)
+	for _, rec := range batch {
+		model := rec.Origin.ModelUUID
+		if s.allModels {
+			model = ""
+		}
+		if _, ok := lastIDs[model]; !ok {
+			models = append(models, model)
+		}
+		lastIDs[model] = rec.ID
+	}
+	for _, model := range models {
+		if err := s.tracker.setOne(model, lastIDs[model]); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// Close implements io.Closer. Any pending records are sent first.
+func (s *batchingSender) Close() error {
+	flushErr := s.Flush()
+	if err := s.sink.Close(); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(flushErr)
+}
@@ -0,0 +1,251 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logforwarder_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/logfwd"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/logforwarder"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type BatchSuite struct {
+	testing.IsolationSuite
+
+	stream    *chanStream
+	sink      *stubBatchSink
+	caller    *recordingCaller
+	filter    logfwd.Filter
+	modelUUID string
+	streamCfg params.LogStreamConfig
+}
+
+var _ = gc.Suite(&BatchSuite{})
+
+func (s *BatchSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+
+	s.stream = &chanStream{
+		records: make(chan logfwd.Record, 10),
+		calls:   make(chan struct{}, 10),
+	}
+	s.sink = &stubBatchSink{batches: make(chan []logfwd.Record, 10)}
+	s.caller = &recordingCaller{setLastSent: make(chan params.LogForwardingSetLastSentParams, 10)}
+	s.filter = logfwd.Filter{}
+	s.modelUUID = ""
+	s.streamCfg = params.LogStreamConfig{}
+}
+
+func (s *BatchSuite) newRecord(id int64) logfwd.Record {
+	return logfwd.Record{
+		Origin: logfwd.Origin{
+			ControllerUUID: "feebdaed-2f18-4fd2-967d-db9663db7bea",
+			ModelUUID:      "deadbeef-2f18-4fd2-967d-db9663db7bea",
+			Hostname:       "machine-99.deadbeef-2f18-4fd2-967d-db9663db7bea",
+			Type:           logfwd.OriginTypeMachine,
+			Name:           "99",
+		},
+		ID:        id,
+		Timestamp: time.Now(),
+		Level:     loggo.INFO,
+		Message:   "hello",
+	}
+}
+
+func (s *BatchSuite) newLogForwarder(c *gc.C, batchSize int, flushInterval time.Duration) *logforwarder.LogForwarder {
+	lf, err := logforwarder.NewLogForwarder(logforwarder.OpenLogForwarderArgs{
+		Caller: s.caller,
+		LogForwardConfig: &mockLogForwardConfig{
+			enabled:       true,
+			sinkType:      logfwd.SinkHTTPJSON,
+			host:          "https://logs.example.com/juju",
+			batchSize:     batchSize,
+			flushInterval: flushInterval,
+			filter:        s.filter,
+		},
+		AllModels:      s.modelUUID == "",
+		ModelUUID:      s.modelUUID,
+		ControllerUUID: "feebdaed-2f18-4fd2-967d-db9663db7bea",
+		Name:           "spam",
+		OpenSink: func(cfg *logfwd.SinkConfig) (*logforwarder.LogSink, error) {
+			c.Check(cfg.Type, gc.Equals, logfwd.SinkHTTPJSON)
+			return logforwarder.NewBatchLogSink(s.sink), nil
+		},
+		OpenLogStream: func(_ base.APICaller, cfg params.LogStreamConfig, _ string) (logforwarder.LogStream, error) {
+			s.streamCfg = cfg
+			return s.stream, nil
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return lf
+}
+
+func (s *BatchSuite) checkBatch(c *gc.C, ids ...int64) {
+	select {
+	case batch := <-s.sink.batches:
+		var got []int64
+		for _, rec := range batch {
+			got = append(got, rec.ID)
+		}
+		c.Check(got, jc.DeepEquals, ids)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for batch")
+	}
+}
+
+func (s *BatchSuite) checkLastSent(c *gc.C, recID int64) {
+	s.checkLastSentForModel(c, "", recID)
+}
+
+func (s *BatchSuite) checkLastSentForModel(c *gc.C, modelTag string, recID int64) {
+	select {
+	case args := <-s.caller.setLastSent:
+		c.Check(args, jc.DeepEquals, params.LogForwardingSetLastSentParams{
+			Params: []params.LogForwardingSetLastSentParam{{
+				LogForwardingID: params.LogForwardingID{
+					ModelTag: modelTag,
+					Sink:     "spam",
+				},
+				RecordID: recID,
+			}},
+		})
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for last sent")
+	}
+}
+
+func (s *BatchSuite) TestBatchSize(c *gc.C) {
+	lf := s.newLogForwarder(c, 2, time.Hour)
+	s.stream.records <- s.newRecord(10)
+	s.stream.records <- s.newRecord(11)
+	s.stream.records <- s.newRecord(12)
+
+	s.checkBatch(c, 10, 11)
+	s.checkLastSent(c, 11)
+
+	// Once the stream is asked for a fourth record, the third
+	// has been handed over. The partial batch is sent when the
+	// sink is closed.
+	s.stream.waitNext(c, 4)
+	workertest.CleanKill(c, lf)
+	s.checkBatch(c, 12)
+	s.checkLastSent(c, 12)
+	c.Check(s.sink.closed, jc.IsTrue)
+}
+
+func (s *BatchSuite) TestFlushInterval(c *gc.C) {
+	lf := s.newLogForwarder(c, 10, 10*time.Millisecond)
+	defer workertest.CleanKill(c, lf)
+	s.stream.records <- s.newRecord(10)
+
+	s.checkBatch(c, 10)
+	s.checkLastSent(c, 10)
+}
+
+func (s *BatchSuite) TestSendBatchError(c *gc.C) {
+	failure := errors.New("<failure>")
+	s.sink.err = failure
+	lf := s.newLogForwarder(c, 1, time.Hour)
+	s.stream.records <- s.newRecord(10)
+
+	err := workertest.CheckKilled(c, lf)
+	c.Check(errors.Cause(err), gc.Equals, failure)
+	s.checkBatch(c, 10)
+	select {
+	case args := <-s.caller.setLastSent:
+		c.Errorf("unexpected last sent update %v", args)
+	default:
+	}
+}
+
+func (s *BatchSuite) TestFilter(c *gc.C) {
+	s.filter = logfwd.Filter{MinLevel: loggo.WARNING}
+	lf := s.newLogForwarder(c, 1, time.Hour)
+	defer workertest.CleanKill(c, lf)
+	s.stream.records <- s.newRecord(10)
+	rec := s.newRecord(11)
+	rec.Level = loggo.ERROR
+	s.stream.records <- rec
+
+	s.checkBatch(c, 11)
+	s.checkLastSent(c, 11)
+}
+
+func (s *BatchSuite) TestSingleModel(c *gc.C) {
+	s.modelUUID = "deadbeef-2f18-4fd2-967d-db9663db7bea"
+	lf := s.newLogForwarder(c, 1, time.Hour)
+	defer workertest.CleanKill(c, lf)
+	s.stream.records <- s.newRecord(10)
+
+	s.checkBatch(c, 10)
+	s.checkLastSentForModel(c, "model-deadbeef-2f18-4fd2-967d-db9663db7bea", 10)
+	c.Check(s.streamCfg, jc.DeepEquals, params.LogStreamConfig{
+		Model: "deadbeef-2f18-4fd2-967d-db9663db7bea",
+		Sink:  "spam",
+	})
+}
+
+type chanStream struct {
+	records chan logfwd.Record
+	calls   chan struct{}
+}
+
+func (s *chanStream) waitNext(c *gc.C, n int) {
+	for i := 0; i < n; i++ {
+		select {
+		case <-s.calls:
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for Next")
+		}
+	}
+}
+
+func (s *chanStream) Next() (logfwd.Record, error) {
+	s.calls <- struct{}{}
+	return <-s.records, nil
+}
+
+type stubBatchSink struct {
+	batches chan []logfwd.Record
+	err     error
+	closed  bool
+}
+
+func (s *stubBatchSink) SendBatch(recs []logfwd.Record) error {
+	batch := make([]logfwd.Record, len(recs))
+	copy(batch, recs)
+	s.batches <- batch
+	return s.err
+}
+
+func (s *stubBatchSink) Close() error {
+	s.closed = true
+	return nil
+}
+
+type recordingCaller struct {
+	base.APICaller
+	setLastSent chan params.LogForwardingSetLastSentParams
+}
+
+func (c *recordingCaller) APICall(objType string, version int, id, request string, args, response interface{}) error {
+	if objType == "LogForwarding" && request == "SetLastSent" {
+		c.setLastSent <- args.(params.LogForwardingSetLastSentParams)
+	}
+	return nil
+}
+
+func (*recordingCaller) BestFacadeVersion(facade string) int {
+	return 0
+}
@@ -0,0 +1,12 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logforwarder
+
+import (
+	"github.com/juju/juju/worker"
+)
+
+func NewOrchestratorForController(args OrchestratorArgs) (worker.Worker, error) {
+	return newOrchestratorForController(args)
+}
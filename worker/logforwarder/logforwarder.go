@@ -6,6 +6,7 @@ package logforwarder
 import (
 	"io"
 	"sync"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -47,11 +48,13 @@ type sender interface {
 // LogForwarder is a worker that forwards log records from a source
 // to a sender.
 type LogForwarder struct {
-	catacomb  catacomb.Catacomb
-	args      OpenLogForwarderArgs
-	enabledCh chan bool
-	mu        sync.Mutex
-	enabled   bool
+	catacomb      catacomb.Catacomb
+	args          OpenLogForwarderArgs
+	enabledCh     chan bool
+	mu            sync.Mutex
+	enabled       bool
+	flushInterval time.Duration
+	filter        logfwd.Filter
 }
 
 // OpenLogForwarderArgs holds the info needed to open a LogForwarder.
@@ -59,6 +62,10 @@ type OpenLogForwarderArgs struct {
 	// AllModels indicates that the tracker is handling all models.
 	AllModels bool
 
+	// ModelUUID, if set, identifies the single model whose log
+	// records are forwarded.
+	ModelUUID string
+
 	// ControllerUUID identifies the controller.
 	ControllerUUID string
 
@@ -80,7 +87,7 @@ type OpenLogForwarderArgs struct {
 	OpenLogStream LogStreamFn
 }
 
-// processNewConfig acts on a new syslog forward config change.
+// processNewConfig acts on a new log forward config change.
 func (lf *LogForwarder) processNewConfig(currentSender SendCloser) (SendCloser, error) {
 	lf.mu.Lock()
 	defer lf.mu.Unlock()
@@ -117,6 +124,8 @@ func (lf *LogForwarder) processNewConfig(currentSender SendCloser) (SendCloser,
 	if err := closeExisting(); err != nil {
 		return nil, errors.Trace(err)
 	}
+	_, lf.flushInterval = cfg.Batching()
+	lf.filter = cfg.Filter
 	sink, err := OpenTrackingSink(TrackingSinkArgs{
 		Name:      lf.args.Name,
 		AllModels: lf.args.AllModels,
@@ -150,7 +159,7 @@ func (lf *LogForwarder) waitForEnabled() (bool, error) {
 	defer lf.mu.Unlock()
 
 	if !lf.enabled && enabled {
-		logger.Infof("log forward enabled, starting to stream logs to syslog sink")
+		logger.Infof("log forward enabled, starting to stream logs to %s sink", lf.args.Name)
 	}
 	lf.enabled = enabled
 	return enabled, nil
@@ -200,6 +209,7 @@ func (lf *LogForwarder) loop() error {
 			if stream == nil {
 				streamCfg := params.LogStreamConfig{
 					AllModels: lf.args.AllModels,
+					Model:     lf.args.ModelUUID,
 					Sink:      lf.args.Name,
 				}
 				stream, err = lf.args.OpenLogStream(lf.args.Caller, streamCfg, lf.args.ControllerUUID)
@@ -229,24 +239,45 @@ func (lf *LogForwarder) loop() error {
 		}
 	}()
 
+	// flush fires when records held back by a batching sender
+	// have waited long enough.
+	var flush <-chan time.Time
 	for {
 		select {
 		case <-lf.catacomb.Dying():
 			return lf.catacomb.ErrDying()
 		case _, ok := <-configWatcher.Changes():
 			if !ok {
-				return errors.New("syslog configuration watcher closed")
+				return errors.New("log forward configuration watcher closed")
 			}
-			if sender, err = lf.processNewConfig(sender); err != nil {
+			newSender, err := lf.processNewConfig(sender)
+			if err != nil {
+				sender = nil
 				return errors.Trace(err)
 			}
+			if newSender != sender {
+				// Any records held back by the old sender
+				// were sent when it was closed.
+				flush = nil
+			}
+			sender = newSender
 		case rec := <-records:
-			if sender == nil {
+			if sender == nil || !lf.filter.Match(rec) {
 				continue
 			}
 			if err := sender.Send(rec); err != nil {
 				return errors.Trace(err)
 			}
+			if f, ok := senderFlusher(sender); ok && f.Pending() && flush == nil {
+				flush = time.After(lf.flushInterval)
+			}
+		case <-flush:
+			flush = nil
+			if f, ok := senderFlusher(sender); ok {
+				if err := f.Flush(); err != nil {
+					return errors.Trace(err)
+				}
+			}
 		}
 	}
 }
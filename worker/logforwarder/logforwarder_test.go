@@ -15,7 +15,6 @@ import (
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/logfwd"
-	"github.com/juju/juju/logfwd/syslog"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/version"
 	"github.com/juju/juju/watcher"
@@ -90,9 +89,13 @@ func (s *LogForwarderSuite) checkClose(c *gc.C, lf worker.Worker, expected error
 }
 
 type mockLogForwardConfig struct {
-	enabled bool
-	host    string
-	changes chan struct{}
+	enabled       bool
+	sinkType      logfwd.SinkType
+	host          string
+	batchSize     int
+	flushInterval time.Duration
+	filter        logfwd.Filter
+	changes       chan struct{}
 }
 
 type mockWatcher struct {
@@ -131,13 +134,21 @@ func (c *mockLogForwardConfig) WatchForLogForwardConfigChanges() (watcher.Notify
 	}, nil
 }
 
-func (c *mockLogForwardConfig) LogForwardConfig() (*syslog.RawConfig, bool, error) {
-	return &syslog.RawConfig{
-		Enabled:    c.enabled,
-		Host:       c.host,
-		CACert:     coretesting.CACert,
-		ClientCert: coretesting.ServerCert,
-		ClientKey:  coretesting.ServerKey,
+func (c *mockLogForwardConfig) LogForwardConfig() (*logfwd.SinkConfig, bool, error) {
+	sinkType := c.sinkType
+	if sinkType == "" {
+		sinkType = logfwd.SinkSyslog
+	}
+	return &logfwd.SinkConfig{
+		Enabled:       c.enabled,
+		Type:          sinkType,
+		Endpoint:      c.host,
+		CACert:        coretesting.CACert,
+		ClientCert:    coretesting.ServerCert,
+		ClientKey:     coretesting.ServerKey,
+		BatchSize:     c.batchSize,
+		FlushInterval: c.flushInterval,
+		Filter:        c.filter,
 	}, true, nil
 }
 
@@ -155,8 +166,8 @@ func (s *LogForwarderSuite) newLogForwarderArgsWithAPI(c *gc.C, configAPI logfor
 		LogForwardConfig: configAPI,
 		AllModels:        true,
 		ControllerUUID:   "feebdaed-2f18-4fd2-967d-db9663db7bea",
-		OpenSink: func(cfg *syslog.RawConfig) (*logforwarder.LogSink, error) {
-			sender.host = cfg.Host
+		OpenSink: func(cfg *logfwd.SinkConfig) (*logforwarder.LogSink, error) {
+			sender.host = cfg.Endpoint
 			sink := &logforwarder.LogSink{
 				sender,
 			}
@@ -5,9 +5,11 @@ package logforwarder
 
 import (
 	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
 
 	apiagent "github.com/juju/juju/api/agent"
 	"github.com/juju/juju/api/base"
+	logfwdapi "github.com/juju/juju/api/logfwd"
 	"github.com/juju/juju/api/logstream"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/worker"
@@ -31,6 +33,10 @@ type ManifoldConfig struct {
 
 	// OpenLogForwarder opens each log forwarder that will be used.
 	OpenLogForwarder func(OpenLogForwarderArgs) (*LogForwarder, error)
+
+	// Clock is used to schedule checks of the hosted models'
+	// log forwarding config.
+	Clock clock.Clock
 }
 
 // Manifold returns a dependency manifold that runs a log forwarding
@@ -65,13 +71,20 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 				return nil, errors.Annotate(err, "cannot read controller config")
 			}
 
+			modelConfigs := logfwdapi.NewModelConfigClient(func(name string) logfwdapi.FacadeCaller {
+				return base.NewFacadeCaller(apiCaller, name)
+			})
+
 			orchestrator, err := newOrchestratorForController(OrchestratorArgs{
 				ControllerUUID:   controllerCfg.ControllerUUID(),
 				LogForwardConfig: agentFacade,
+				ModelConfigs:     modelConfigs,
 				Caller:           apiCaller,
 				Sinks:            config.Sinks,
 				OpenLogStream:    openLogStream,
 				OpenLogForwarder: openForwarder,
+				Clock:            config.Clock,
+				PollInterval:     ModelConfigPollInterval,
 			})
 			return orchestrator, errors.Annotate(err, "creating log forwarding orchestrator")
 		},
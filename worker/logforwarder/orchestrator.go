@@ -4,13 +4,39 @@
 package logforwarder
 
 import (
+	"reflect"
+	"sync"
+	"time"
+
 	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+	"github.com/juju/utils/set"
+	"launchpad.net/tomb"
 
 	"github.com/juju/juju/api/base"
+	logfwdapi "github.com/juju/juju/api/logfwd"
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/catacomb"
 )
 
-type orchestrator struct {
-	*LogForwarder // For now its just a single forwarder.
+// ModelConfigPollInterval is how often the log forwarding config of
+// the hosted models is checked for changes.
+const ModelConfigPollInterval = time.Minute
+
+// modelSinkSuffix is added to the sink name to give the name used
+// when forwarding the records of a single model. This keeps the
+// records tracked for a model's own target apart from those tracked
+// for the controller-wide target.
+const modelSinkSuffix = "-model"
+
+// ModelConfigs provides access to the log forwarding config of the
+// controller's hosted models.
+type ModelConfigs interface {
+	// ModelConfigs returns the log forwarding config of each hosted
+	// model that has one.
+	ModelConfigs() ([]logfwdapi.ModelConfig, error)
 }
 
 // OrchestratorArgs holds the info needed to open a log forwarding
@@ -22,6 +48,10 @@ type OrchestratorArgs struct {
 	// LogForwardConfig is the API used to access log forward config.
 	LogForwardConfig LogForwardConfig
 
+	// ModelConfigs is the API used to access the log forward config
+	// of the hosted models.
+	ModelConfigs ModelConfigs
+
 	// Caller is the API caller that will be used.
 	Caller base.APICaller
 
@@ -35,17 +65,58 @@ type OrchestratorArgs struct {
 
 	// OpenLogForwarder opens each log forwarder that will be used.
 	OpenLogForwarder func(OpenLogForwarderArgs) (*LogForwarder, error)
+
+	// Clock is used to schedule checks of the hosted models' config.
+	Clock clock.Clock
+
+	// PollInterval is how often the hosted models' config is checked.
+	PollInterval time.Duration
+}
+
+// Validate returns an error if the args cannot be expected to drive
+// a functional orchestrator.
+func (args OrchestratorArgs) Validate() error {
+	if args.LogForwardConfig == nil {
+		return errors.NotValidf("nil LogForwardConfig")
+	}
+	if args.ModelConfigs == nil {
+		return errors.NotValidf("nil ModelConfigs")
+	}
+	if args.OpenLogForwarder == nil {
+		return errors.NotValidf("nil OpenLogForwarder")
+	}
+	if args.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if args.PollInterval <= 0 {
+		return errors.NotValidf("non-positive PollInterval")
+	}
+	return nil
+}
+
+// orchestrator runs the controller-wide log forwarder, which forwards
+// the records of all models to the target in the controller model's
+// config, along with a log forwarder for each hosted model that has
+// its own target.
+type orchestrator struct {
+	catacomb catacomb.Catacomb
+	args     OrchestratorArgs
+	runner   worker.Runner
+	models   map[string]*modelLogForwardConfig
 }
 
 func newOrchestratorForController(args OrchestratorArgs) (*orchestrator, error) {
-	// For now we work with only 1 forwarder. Later we can have a proper
-	// orchestrator that spawns a sub-worker for each log sink.
+	// For now we work with only 1 log sink. Later we can have
+	// forwarders for each log sink.
 	if len(args.Sinks) == 0 {
 		return nil, nil
 	}
 	if len(args.Sinks) > 1 {
 		return nil, errors.Errorf("multiple log forwarding targets not supported (yet)")
 	}
+	if err := args.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
 	lf, err := args.OpenLogForwarder(OpenLogForwarderArgs{
 		AllModels:        true,
 		ControllerUUID:   args.ControllerUUID,
@@ -55,5 +126,204 @@ func newOrchestratorForController(args OrchestratorArgs) (*orchestrator, error)
 		OpenSink:         args.Sinks[0].OpenFn,
 		OpenLogStream:    args.OpenLogStream,
 	})
-	return &orchestrator{lf}, errors.Annotate(err, "opening log forwarder")
+	if err != nil {
+		return nil, errors.Annotate(err, "opening log forwarder")
+	}
+
+	o := &orchestrator{
+		args:   args,
+		models: make(map[string]*modelLogForwardConfig),
+	}
+	err = catacomb.Invoke(catacomb.Plan{
+		Site: &o.catacomb,
+		Work: o.loop,
+		Init: []worker.Worker{lf},
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return o, nil
+}
+
+// Kill implements Worker.Kill()
+func (o *orchestrator) Kill() {
+	o.catacomb.Kill(nil)
+}
+
+// Wait implements Worker.Wait()
+func (o *orchestrator) Wait() error {
+	return o.catacomb.Wait()
+}
+
+func (o *orchestrator) loop() error {
+	// A model's forwarder failing (e.g. because its target is not
+	// reachable) must not stop the forwarding of other models, so
+	// those forwarders are restarted rather than being fatal.
+	o.runner = worker.NewRunner(neverFatal, neverImportant, worker.RestartDelay)
+	if err := o.catacomb.Add(o.runner); err != nil {
+		return errors.Trace(err)
+	}
+
+	poll := o.args.Clock.After(0)
+	for {
+		select {
+		case <-o.catacomb.Dying():
+			return o.catacomb.ErrDying()
+		case <-poll:
+			if err := o.updateModels(); err != nil {
+				return errors.Trace(err)
+			}
+			poll = o.args.Clock.After(o.args.PollInterval)
+		}
+	}
+}
+
+// updateModels starts a forwarder for each hosted model that now has
+// log forwarding config, passes config changes on to the forwarders
+// that are already running, and stops the forwarders of models that
+// no longer have config.
+func (o *orchestrator) updateModels() error {
+	configs, err := o.args.ModelConfigs.ModelConfigs()
+	if err != nil {
+		return errors.Annotate(err, "getting model log forwarding config")
+	}
+
+	seen := set.NewStrings()
+	for _, modelCfg := range configs {
+		uuid := modelCfg.Model.Id()
+		seen.Add(uuid)
+		if cfg, ok := o.models[uuid]; ok {
+			cfg.set(modelCfg.Config)
+			continue
+		}
+		cfg := newModelLogForwardConfig(modelCfg.Config)
+		if err := o.runner.StartWorker(uuid, o.starter(uuid, cfg)); err != nil {
+			return errors.Trace(err)
+		}
+		o.models[uuid] = cfg
+	}
+	for uuid := range o.models {
+		if seen.Contains(uuid) {
+			continue
+		}
+		if err := o.runner.StopWorker(uuid); err != nil {
+			return errors.Trace(err)
+		}
+		delete(o.models, uuid)
+	}
+	return nil
+}
+
+func (o *orchestrator) starter(uuid string, cfg *modelLogForwardConfig) func() (worker.Worker, error) {
+	return func() (worker.Worker, error) {
+		lf, err := o.args.OpenLogForwarder(OpenLogForwarderArgs{
+			ModelUUID:        uuid,
+			ControllerUUID:   o.args.ControllerUUID,
+			LogForwardConfig: cfg,
+			Caller:           o.args.Caller,
+			Name:             o.args.Sinks[0].Name + modelSinkSuffix,
+			OpenSink:         o.args.Sinks[0].OpenFn,
+			OpenLogStream:    o.args.OpenLogStream,
+		})
+		if err != nil {
+			return nil, errors.Annotatef(err, "opening log forwarder for model %q", uuid)
+		}
+		return lf, nil
+	}
+}
+
+func neverFatal(error) bool {
+	return false
+}
+
+func neverImportant(error, error) bool {
+	return false
+}
+
+// modelLogForwardConfig is the LogForwardConfig of a hosted model's
+// forwarder. It holds the model's most recently polled config.
+type modelLogForwardConfig struct {
+	mu      sync.Mutex
+	config  logfwd.SinkConfig
+	watcher *configWatcher
+}
+
+func newModelLogForwardConfig(cfg logfwd.SinkConfig) *modelLogForwardConfig {
+	return &modelLogForwardConfig{config: cfg}
+}
+
+// set records the model's config, notifying the forwarder if it
+// has changed.
+func (c *modelLogForwardConfig) set(cfg logfwd.SinkConfig) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if reflect.DeepEqual(c.config, cfg) {
+		return
+	}
+	c.config = cfg
+	if c.watcher != nil {
+		c.watcher.notify()
+	}
+}
+
+// WatchForLogForwardConfigChanges implements LogForwardConfig.
+func (c *modelLogForwardConfig) WatchForLogForwardConfigChanges() (watcher.NotifyWatcher, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Only the most recently started forwarder is running.
+	c.watcher = newConfigWatcher()
+	return c.watcher, nil
+}
+
+// LogForwardConfig implements LogForwardConfig.
+func (c *modelLogForwardConfig) LogForwardConfig() (*logfwd.SinkConfig, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cfg := c.config
+	return &cfg, true, nil
+}
+
+// configWatcher is a NotifyWatcher for a modelLogForwardConfig. Like
+// other notify watchers, it sends an initial event.
+type configWatcher struct {
+	tomb    tomb.Tomb
+	changes chan struct{}
+}
+
+func newConfigWatcher() *configWatcher {
+	w := &configWatcher{
+		changes: make(chan struct{}, 1),
+	}
+	w.changes <- struct{}{}
+	go func() {
+		defer w.tomb.Done()
+		<-w.tomb.Dying()
+	}()
+	return w
+}
+
+// notify sends a change event, unless one is already pending.
+func (w *configWatcher) notify() {
+	select {
+	case w.changes <- struct{}{}:
+	default:
+	}
+}
+
+// Changes implements watcher.NotifyWatcher.
+func (w *configWatcher) Changes() watcher.NotifyChannel {
+	return w.changes
+}
+
+// Kill implements worker.Worker.
+func (w *configWatcher) Kill() {
+	w.tomb.Kill(nil)
+}
+
+// Wait implements worker.Worker.
+func (w *configWatcher) Wait() error {
+	return w.tomb.Wait()
 }
@@ -0,0 +1,155 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logforwarder_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	logfwdapi "github.com/juju/juju/api/logfwd"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/logfwd"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/logforwarder"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type OrchestratorSuite struct {
+	testing.IsolationSuite
+
+	clock        *coretesting.Clock
+	modelConfigs *stubModelConfigs
+	opened       chan openedForwarder
+}
+
+var _ = gc.Suite(&OrchestratorSuite{})
+
+type openedForwarder struct {
+	args logforwarder.OpenLogForwarderArgs
+	lf   *logforwarder.LogForwarder
+}
+
+func (s *OrchestratorSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+
+	s.clock = coretesting.NewClock(time.Now())
+	s.modelConfigs = &stubModelConfigs{}
+	s.opened = make(chan openedForwarder, 10)
+}
+
+func (s *OrchestratorSuite) newArgs() logforwarder.OrchestratorArgs {
+	return logforwarder.OrchestratorArgs{
+		ControllerUUID:   "feebdaed-2f18-4fd2-967d-db9663db7bea",
+		LogForwardConfig: &mockLogForwardConfig{},
+		ModelConfigs:     s.modelConfigs,
+		Caller:           &mockCaller{},
+		Sinks: []logforwarder.LogSinkSpec{{
+			Name: "spam",
+			OpenFn: func(*logfwd.SinkConfig) (*logforwarder.LogSink, error) {
+				return nil, errors.New("unexpected sink")
+			},
+		}},
+		OpenLogStream: func(base.APICaller, params.LogStreamConfig, string) (logforwarder.LogStream, error) {
+			return nil, errors.New("unexpected stream")
+		},
+		OpenLogForwarder: func(args logforwarder.OpenLogForwarderArgs) (*logforwarder.LogForwarder, error) {
+			lf, err := logforwarder.NewLogForwarder(args)
+			if err == nil {
+				s.opened <- openedForwarder{args, lf}
+			}
+			return lf, err
+		},
+		Clock:        s.clock,
+		PollInterval: time.Minute,
+	}
+}
+
+func (s *OrchestratorSuite) nextOpened(c *gc.C) openedForwarder {
+	select {
+	case opened := <-s.opened:
+		return opened
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for log forwarder")
+	}
+	panic("unreachable")
+}
+
+func (s *OrchestratorSuite) waitAlarms(c *gc.C, n int) {
+	for i := 0; i < n; i++ {
+		select {
+		case <-s.clock.Alarms():
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for clock")
+		}
+	}
+}
+
+func (s *OrchestratorSuite) TestValidate(c *gc.C) {
+	args := s.newArgs()
+	args.ModelConfigs = nil
+
+	_, err := logforwarder.NewOrchestratorForController(args)
+
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `nil ModelConfigs not valid`)
+}
+
+func (s *OrchestratorSuite) TestModelForwarders(c *gc.C) {
+	modelTag := names.NewModelTag("deadbeef-2f18-4fd2-967d-db9663db7bea")
+	s.modelConfigs.set([]logfwdapi.ModelConfig{{
+		Model: modelTag,
+		Config: logfwd.SinkConfig{
+			Type:     logfwd.SinkHTTPJSON,
+			Endpoint: "https://logs.example.com/juju",
+		},
+	}})
+	o, err := logforwarder.NewOrchestratorForController(s.newArgs())
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, o)
+
+	controller := s.nextOpened(c)
+	c.Check(controller.args.AllModels, jc.IsTrue)
+	c.Check(controller.args.ModelUUID, gc.Equals, "")
+	c.Check(controller.args.Name, gc.Equals, "spam")
+
+	model := s.nextOpened(c)
+	c.Check(model.args.AllModels, jc.IsFalse)
+	c.Check(model.args.ModelUUID, gc.Equals, modelTag.Id())
+	c.Check(model.args.Name, gc.Equals, "spam-model")
+	cfg, ok, err := model.args.LogForwardConfig.LogForwardConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ok, jc.IsTrue)
+	c.Check(cfg.Endpoint, gc.Equals, "https://logs.example.com/juju")
+
+	// Once the model has no config, its forwarder is stopped.
+	s.waitAlarms(c, 2)
+	s.modelConfigs.set(nil)
+	s.clock.Advance(time.Minute)
+	workertest.CheckKilled(c, model.lf)
+	workertest.CheckAlive(c, controller.lf)
+}
+
+type stubModelConfigs struct {
+	mu      sync.Mutex
+	configs []logfwdapi.ModelConfig
+}
+
+func (s *stubModelConfigs) set(configs []logfwdapi.ModelConfig) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.configs = configs
+}
+
+func (s *stubModelConfigs) ModelConfigs() ([]logfwdapi.ModelConfig, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.configs, nil
+}
@@ -4,7 +4,9 @@
 package logforwarder
 
 import (
-	"github.com/juju/juju/logfwd/syslog"
+	"io"
+
+	"github.com/juju/juju/logfwd"
 	"github.com/juju/juju/watcher"
 )
 
@@ -15,7 +17,7 @@ type LogForwardConfig interface {
 	WatchForLogForwardConfigChanges() (watcher.NotifyWatcher, error)
 
 	// LogForwardConfig returns the current log forward configuration.
-	LogForwardConfig() (*syslog.RawConfig, bool, error)
+	LogForwardConfig() (*logfwd.SinkConfig, bool, error)
 }
 
 type LogSinkSpec struct {
@@ -27,9 +29,37 @@ type LogSinkSpec struct {
 }
 
 // LogSinkFn is a function that opens a log sink.
-type LogSinkFn func(cfg *syslog.RawConfig) (*LogSink, error)
+type LogSinkFn func(cfg *logfwd.SinkConfig) (*LogSink, error)
 
 // LogSink is a single log sink, to which log records may be sent.
 type LogSink struct {
 	SendCloser
 }
+
+// BatchSendCloser is responsible for sending batches of log records
+// to a log sink.
+type BatchSendCloser interface {
+	// SendBatch sends the records, in order, to the log sink.
+	SendBatch([]logfwd.Record) error
+
+	io.Closer
+}
+
+// NewBatchLogSink returns a log sink that sends records in batches.
+// The batches are assembled by the tracking sender that wraps it.
+func NewBatchLogSink(sink BatchSendCloser) *LogSink {
+	return &LogSink{
+		SendCloser: batchSendCloser{sink},
+	}
+}
+
+// batchSendCloser adapts a BatchSendCloser to SendCloser, for
+// when it is used without batching.
+type batchSendCloser struct {
+	BatchSendCloser
+}
+
+// Send implements Sender.
+func (s batchSendCloser) Send(rec logfwd.Record) error {
+	return s.SendBatch([]logfwd.Record{rec})
+}
@@ -0,0 +1,69 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sinks
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/gelf"
+	"github.com/juju/juju/logfwd/httpjson"
+	"github.com/juju/juju/logfwd/loki"
+	"github.com/juju/juju/worker/logforwarder"
+)
+
+// Open returns a sink of the configured type, used to receive log
+// messages to be forwarded.
+func Open(cfg *logfwd.SinkConfig) (*logforwarder.LogSink, error) {
+	switch cfg.Type {
+	case logfwd.SinkSyslog, "":
+		return OpenSyslog(cfg)
+	case logfwd.SinkHTTPJSON:
+		return OpenHTTPJSON(cfg)
+	case logfwd.SinkGELF:
+		return OpenGELF(cfg)
+	case logfwd.SinkLoki:
+		return OpenLoki(cfg)
+	}
+	return nil, errors.NotSupportedf("log sink type %q", cfg.Type)
+}
+
+// OpenHTTPJSON returns a sink used to receive log messages to be
+// forwarded, in batches, to an HTTP endpoint as JSON.
+func OpenHTTPJSON(cfg *logfwd.SinkConfig) (*logforwarder.LogSink, error) {
+	if !cfg.Enabled {
+		return nil, errors.New("log forwarding not enabled")
+	}
+	client, err := httpjson.Open(*cfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return logforwarder.NewBatchLogSink(client), nil
+}
+
+// OpenGELF returns a sink used to receive log messages to be
+// forwarded, in batches, to a Graylog GELF TCP input.
+func OpenGELF(cfg *logfwd.SinkConfig) (*logforwarder.LogSink, error) {
+	if !cfg.Enabled {
+		return nil, errors.New("log forwarding not enabled")
+	}
+	client, err := gelf.Open(*cfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return logforwarder.NewBatchLogSink(client), nil
+}
+
+// OpenLoki returns a sink used to receive log messages to be
+// forwarded, in batches, to a Loki push endpoint.
+func OpenLoki(cfg *logfwd.SinkConfig) (*logforwarder.LogSink, error) {
+	if !cfg.Enabled {
+		return nil, errors.New("log forwarding not enabled")
+	}
+	client, err := loki.Open(*cfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return logforwarder.NewBatchLogSink(client), nil
+}
@@ -11,12 +11,19 @@ import (
 	"github.com/juju/juju/worker/logforwarder"
 )
 
-// OpenSyslog returns a sink used to receive log messages to be forwarded.
-func OpenSyslog(cfg *syslog.RawConfig) (*logforwarder.LogSink, error) {
+// OpenSyslog returns a sink used to receive log messages to be forwarded
+// to a syslog host.
+func OpenSyslog(cfg *logfwd.SinkConfig) (*logforwarder.LogSink, error) {
 	if !cfg.Enabled {
 		return nil, errors.New("log forwarding not enabled")
 	}
-	client, err := syslog.Open(*cfg)
+	client, err := syslog.Open(syslog.RawConfig{
+		Enabled:    cfg.Enabled,
+		Host:       cfg.Endpoint,
+		CACert:     cfg.CACert,
+		ClientCert: cfg.ClientCert,
+		ClientKey:  cfg.ClientKey,
+	})
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -10,7 +10,6 @@ import (
 	"github.com/juju/juju/api/base"
 	logfwdapi "github.com/juju/juju/api/logfwd"
 	"github.com/juju/juju/logfwd"
-	"github.com/juju/juju/logfwd/syslog"
 )
 
 // TrackingSinkArgs holds the args to OpenTrackingSender.
@@ -19,7 +18,7 @@ type TrackingSinkArgs struct {
 	AllModels bool
 
 	// Config is the logging config that will be used.
-	Config *syslog.RawConfig
+	Config *logfwd.SinkConfig
 
 	// Caller is the API caller that will be used.
 	Caller base.APICaller
@@ -33,17 +32,33 @@ type TrackingSinkArgs struct {
 }
 
 // OpenTrackingSink opens a log record sender to use with a worker.
-// The sender also tracks records that were successfully sent.
+// The sender also tracks records that were successfully sent. If the
+// underlying sink takes batches of records, the sender collects them
+// into batches and tracks each batch once it has been sent.
 func OpenTrackingSink(args TrackingSinkArgs) (*LogSink, error) {
 	sink, err := args.OpenSink(args.Config)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	tracker := newLastSentTracker(args.Name, args.Caller)
+
+	if batchSink, ok := sink.SendCloser.(batchSendCloser); ok {
+		batchSize, _ := args.Config.Batching()
+		return &LogSink{
+			&batchingSender{
+				sink:      batchSink.BatchSendCloser,
+				tracker:   tracker,
+				allModels: args.AllModels,
+				batchSize: batchSize,
+			},
+		}, nil
+	}
 
 	return &LogSink{
 		&trackingSender{
 			SendCloser: sink,
-			tracker:    newLastSentTracker(args.Name, args.Caller),
+			tracker:    tracker,
+			allModels:  args.AllModels,
 		},
 	}, nil
 }
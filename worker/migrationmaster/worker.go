@@ -4,6 +4,8 @@
 package migrationmaster
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/juju/errors"
@@ -13,7 +15,9 @@ import (
 	"github.com/juju/juju/api/migrationmaster"
 	"github.com/juju/juju/api/migrationtarget"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/description"
 	"github.com/juju/juju/core/migration"
+	jujumigration "github.com/juju/juju/migration"
 	"github.com/juju/juju/watcher"
 	"github.com/juju/juju/worker/catacomb"
 	"github.com/juju/juju/worker/dependency"
@@ -45,9 +49,26 @@ type Facade interface {
 	// migration.
 	SetPhase(migration.Phase) error
 
+	// SetStatusMessage sets a human readable message about the
+	// progress of the currently active model migration.
+	SetStatusMessage(string) error
+
 	// Export returns a serialized representation of the model
 	// associated with the API connection.
 	Export() ([]byte, error)
+
+	// UploadBinaries sends the tools, charms and resources used by
+	// the model associated with the API connection to the target
+	// controller of its active migration.
+	UploadBinaries() error
+
+	// NeedsCleanup reports whether the model associated with the
+	// API connection has cleanups pending.
+	NeedsCleanup() (bool, error)
+
+	// Reap removes the model associated with the API connection
+	// from the source controller once it has been migrated.
+	Reap() error
 }
 
 // Config defines the operation of a Worker.
@@ -127,11 +148,11 @@ func (w *Worker) run() error {
 		case migration.READONLY:
 			phase, err = w.doREADONLY()
 		case migration.PRECHECK:
-			phase, err = w.doPRECHECK()
+			phase, err = w.doPRECHECK(status)
 		case migration.IMPORT:
-			phase, err = w.doIMPORT(status.TargetInfo)
+			phase, err = w.doIMPORT(status)
 		case migration.VALIDATION:
-			phase, err = w.doVALIDATION(status.TargetInfo, status.ModelUUID)
+			phase, err = w.doVALIDATION(status)
 		case migration.SUCCESS:
 			phase, err = w.doSUCCESS()
 		case migration.LOGTRANSFER:
@@ -139,7 +160,7 @@ func (w *Worker) run() error {
 		case migration.REAP:
 			phase, err = w.doREAP()
 		case migration.ABORT:
-			phase, err = w.doABORT(status.TargetInfo, status.ModelUUID)
+			phase, err = w.doABORT(status)
 		default:
 			return errors.Errorf("unknown phase: %v [%d]", phase.String(), phase)
 		}
@@ -148,8 +169,8 @@ func (w *Worker) run() error {
 			// A phase handler should only return an error if the
 			// migration master should exit. In the face of other
 			// errors the handler should log the problem and then
-			// return the appropriate error phases to transition to -
-			// i.e. ABORT or REAPFAILED)
+			// return the appropriate error phases to transition to
+			// (i.e. ABORT or REAPFAILED).
 			return errors.Trace(err)
 		}
 
@@ -166,8 +187,10 @@ func (w *Worker) run() error {
 			// TODO(mjs) - use manifold Filter so that the dep engine
 			// error types aren't required here.
 			return dependency.ErrUninstall
-		} else if phase.IsTerminal() {
-			// Some other terminal phase, exit and try again.
+		} else if phase.IsTerminal() || phase == migration.REAPFAILED {
+			// Some other terminal phase, or the migration needs an
+			// operator to resume it or clean it up. Exit and try
+			// again.
 			return ErrDoneForNow
 		}
 	}
@@ -192,24 +215,123 @@ func (w *Worker) doREADONLY() (migration.Phase, error) {
 	return migration.PRECHECK, nil
 }
 
-func (w *Worker) doPRECHECK() (migration.Phase, error) {
-	// TODO(mjs) - To be implemented.
+func (w *Worker) doPRECHECK(status migrationmaster.MigrationStatus) (migration.Phase, error) {
+	if status.Offline {
+		return w.checkImported(status)
+	}
+
+	logger.Infof("opening API connection to target controller")
+	conn, err := openAPIConn(status.TargetInfo)
+	if err != nil {
+		return w.fail("failed to connect to target controller: %v", err)
+	}
+	defer conn.Close()
+
+	// The target controller checks the model's volumes, as only it
+	// can see the cloud that the model is moving to.
+	logger.Infof("running prechecks")
+	backend := &precheckBackend{w.config.Facade}
+	err = jujumigration.Precheck(backend, migrationtarget.NewClient(conn))
+	if err != nil {
+		return w.fail("%v", err)
+	}
 	return migration.IMPORT, nil
 }
 
-func (w *Worker) doIMPORT(targetInfo migration.TargetInfo) (migration.Phase, error) {
+// checkImported makes sure that the model imported into the target
+// controller from a model archive, before an offline migration
+// started, can take over from the source model. The archive may be
+// older than the source model, so the imported model must hold every
+// machine and unit the source model has now, or their agents would be
+// stranded once the source model is reaped.
+func (w *Worker) checkImported(status migrationmaster.MigrationStatus) (migration.Phase, error) {
 	logger.Infof("exporting model")
 	bytes, err := w.config.Facade.Export()
 	if err != nil {
-		logger.Errorf("model export failed: %v", err)
-		return migration.ABORT, nil
+		return w.fail("model export failed: %v", err)
+	}
+	model, err := description.Deserialize(bytes)
+	if err != nil {
+		return w.fail("model export failed: %v", err)
 	}
+	machineIds, unitNames := modelAgents(model)
 
 	logger.Infof("opening API connection to target controller")
-	conn, err := openAPIConn(targetInfo)
+	conn, err := openAPIConn(status.TargetInfo)
+	if err != nil {
+		return w.fail("failed to connect to target controller: %v", err)
+	}
+	defer conn.Close()
+
+	logger.Infof("checking model imported into target controller")
+	targetClient := migrationtarget.NewClient(conn)
+	blockers, err := targetClient.CheckImported(status.ModelUUID, machineIds, unitNames)
+	if err != nil {
+		return w.fail("failed to check imported model on target controller: %v", err)
+	}
+	if len(blockers) > 0 {
+		return w.fail("precheck failed: %s", strings.Join(blockers, "; "))
+	}
+	return migration.IMPORT, nil
+}
+
+// modelAgents returns the IDs of all the machines, containers
+// included, and the names of all the units in the model.
+func modelAgents(model description.Model) (machineIds, unitNames []string) {
+	var addMachines func([]description.Machine)
+	addMachines = func(machines []description.Machine) {
+		for _, machine := range machines {
+			machineIds = append(machineIds, machine.Id())
+			addMachines(machine.Containers())
+		}
+	}
+	addMachines(model.Machines())
+	for _, application := range model.Applications() {
+		for _, unit := range application.Units() {
+			unitNames = append(unitNames, unit.Name())
+		}
+	}
+	return machineIds, unitNames
+}
+
+// precheckBackend implements migration.PrecheckBackend using the
+// migration master facade.
+type precheckBackend struct {
+	facade Facade
+}
+
+// NeedsCleanup implements migration.PrecheckBackend.
+func (b *precheckBackend) NeedsCleanup() (bool, error) {
+	return b.facade.NeedsCleanup()
+}
+
+// Export implements migration.PrecheckBackend.
+func (b *precheckBackend) Export() (description.Model, error) {
+	bytes, err := b.facade.Export()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return description.Deserialize(bytes)
+}
+
+func (w *Worker) doIMPORT(status migrationmaster.MigrationStatus) (migration.Phase, error) {
+	if status.Offline {
+		// The model was imported from a model archive before the
+		// migration started.
+		logger.Infof("model already imported into target controller")
+		return migration.VALIDATION, nil
+	}
+
+	logger.Infof("exporting model")
+	bytes, err := w.config.Facade.Export()
+	if err != nil {
+		return w.fail("model export failed: %v", err)
+	}
+
+	logger.Infof("opening API connection to target controller")
+	conn, err := openAPIConn(status.TargetInfo)
 	if err != nil {
-		logger.Errorf("failed to connect to target controller: %v", err)
-		return migration.ABORT, nil
+		return w.fail("failed to connect to target controller: %v", err)
 	}
 	defer conn.Close()
 
@@ -217,20 +339,32 @@ func (w *Worker) doIMPORT(targetInfo migration.TargetInfo) (migration.Phase, err
 	targetClient := migrationtarget.NewClient(conn)
 	err = targetClient.Import(bytes)
 	if err != nil {
-		logger.Errorf("failed to import model into target controller: %v", err)
-		return migration.ABORT, nil
+		return w.fail("failed to import model into target controller: %v", err)
+	}
+
+	// The source controller sends the binaries itself, as only it
+	// can read them from its blob storage.
+	logger.Infof("uploading binaries into target controller")
+	if err := w.config.Facade.UploadBinaries(); err != nil {
+		return w.fail("failed to upload binaries into target controller: %v", err)
 	}
 
 	return migration.VALIDATION, nil
 }
 
-func (w *Worker) doVALIDATION(targetInfo migration.TargetInfo, modelUUID string) (migration.Phase, error) {
+func (w *Worker) doVALIDATION(status migrationmaster.MigrationStatus) (migration.Phase, error) {
 	// TODO(mjs) - Wait for all agents to report back.
 
+	if status.Offline {
+		// A model imported from a model archive is activated
+		// as part of the import, which PRECHECK has confirmed.
+		return migration.SUCCESS, nil
+	}
+
 	// Once all agents have validated, activate the model.
-	err := activateModel(targetInfo, modelUUID)
+	err := activateModel(status.TargetInfo, status.ModelUUID)
 	if err != nil {
-		return migration.ABORT, nil
+		return w.fail("failed to activate model on target controller: %v", err)
 	}
 	return migration.SUCCESS, nil
 }
@@ -261,12 +395,24 @@ func (w *Worker) doLOGTRANSFER() (migration.Phase, error) {
 }
 
 func (w *Worker) doREAP() (migration.Phase, error) {
-	// TODO(mjs) - To be implemented.
+	logger.Infof("removing model from source controller")
+	if err := w.config.Facade.Reap(); err != nil {
+		// The model has already moved to the target controller, so
+		// it's too late to abort. An operator can resume the
+		// migration or clean it up.
+		return w.failTo(migration.REAPFAILED, "failed to remove model from source controller: %v", err)
+	}
 	return migration.DONE, nil
 }
 
-func (w *Worker) doABORT(targetInfo migration.TargetInfo, modelUUID string) (migration.Phase, error) {
-	if err := removeImportedModel(targetInfo, modelUUID); err != nil {
+func (w *Worker) doABORT(status migrationmaster.MigrationStatus) (migration.Phase, error) {
+	if status.Offline {
+		// The model wasn't imported by this migration, so it's left
+		// for the target controller's administrator to deal with.
+		logger.Infof("leaving model imported from archive on target controller")
+		return migration.ABORTDONE, nil
+	}
+	if err := removeImportedModel(status.TargetInfo, status.ModelUUID); err != nil {
 		// This isn't fatal. Removing the imported model is a best
 		// efforts attempt.
 		logger.Errorf("failed to reverse model import: %v", err)
@@ -286,6 +432,24 @@ func removeImportedModel(targetInfo migration.TargetInfo, modelUUID string) erro
 	return errors.Trace(err)
 }
 
+// fail logs why the migration can't proceed and records it as the
+// migration's status message (which becomes the abort reason), before
+// returning the ABORT phase.
+func (w *Worker) fail(format string, args ...interface{}) (migration.Phase, error) {
+	return w.failTo(migration.ABORT, format, args...)
+}
+
+// failTo logs why the migration can't proceed and records it as the
+// migration's status message, before returning the given phase.
+func (w *Worker) failTo(phase migration.Phase, format string, args ...interface{}) (migration.Phase, error) {
+	message := fmt.Sprintf(format, args...)
+	logger.Errorf(message)
+	if err := w.config.Facade.SetStatusMessage(message); err != nil {
+		return migration.UNKNOWN, errors.Annotate(err, "failed to set status message")
+	}
+	return phase, nil
+}
+
 func (w *Worker) waitForActiveMigration() (migrationmaster.MigrationStatus, error) {
 	var empty migrationmaster.MigrationStatus
 
@@ -317,6 +481,12 @@ func (w *Worker) waitForActiveMigration() (migrationmaster.MigrationStatus, erro
 		if modelHasMigrated(status.Phase) {
 			return empty, dependency.ErrUninstall
 		}
+		if status.Phase == migration.REAPFAILED {
+			// Resuming or cleaning up the migration doesn't change
+			// which migration is active, so the watcher won't
+			// report it. Exit and check again later.
+			return empty, ErrDoneForNow
+		}
 		if !status.Phase.IsTerminal() {
 			return status, nil
 		}
@@ -337,5 +507,5 @@ func openAPIConn(targetInfo migration.TargetInfo) (api.Connection, error) {
 }
 
 func modelHasMigrated(phase migration.Phase) bool {
-	return phase == migration.DONE || phase == migration.REAPFAILED
+	return phase == migration.DONE
 }
@@ -9,12 +9,14 @@ import (
 	"github.com/juju/errors"
 	jujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api"
 	masterapi "github.com/juju/juju/api/migrationmaster"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/description"
 	"github.com/juju/juju/core/migration"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/watcher"
@@ -35,8 +37,32 @@ type Suite struct {
 var _ = gc.Suite(&Suite{})
 
 var (
-	fakeSerializedModel = []byte("model")
-	modelTagString      = names.NewModelTag("model-uuid").String()
+	fakeSerializedModel = func() []byte {
+		model := description.NewModel(description.ModelArgs{
+			Owner:  names.NewUserTag("admin"),
+			Config: map[string]interface{}{"uuid": "model-uuid"},
+		})
+		tools := description.AgentToolsArgs{Version: version.MustParseBinary("2.0.0-xenial-amd64")}
+		status := description.StatusArgs{Value: "running", Updated: time.Now()}
+		machine := model.AddMachine(description.MachineArgs{Id: names.NewMachineTag("0")})
+		machine.SetTools(tools)
+		machine.SetStatus(status)
+		container := machine.AddContainer(description.MachineArgs{Id: names.NewMachineTag("0/lxd/0")})
+		container.SetTools(tools)
+		container.SetStatus(status)
+		application := model.AddApplication(description.ApplicationArgs{Tag: names.NewApplicationTag("mysql")})
+		application.SetStatus(status)
+		unit := application.AddUnit(description.UnitArgs{Tag: names.NewUnitTag("mysql/0")})
+		unit.SetTools(tools)
+		unit.SetAgentStatus(status)
+		unit.SetWorkloadStatus(status)
+		bytes, err := description.Serialize(model)
+		if err != nil {
+			panic(err)
+		}
+		return bytes
+	}()
+	modelTagString = names.NewModelTag("model-uuid").String()
 
 	// Define stub calls that commonly appear in tests here to allow reuse.
 	apiOpenCall = jujutesting.StubCall{
@@ -57,6 +83,16 @@ var (
 			params.SerializedModel{Bytes: fakeSerializedModel},
 		},
 	}
+	checkImportedCall = jujutesting.StubCall{
+		"APICall:MigrationTarget.CheckImported",
+		[]interface{}{
+			params.MigrationImportedCheck{
+				ModelTag: modelTagString,
+				Machines: []string{"machine-0", "machine-0-lxd-0"},
+				Units:    []string{"unit-mysql-0"},
+			},
+		},
+	}
 	activateCall = jujutesting.StubCall{
 		"APICall:MigrationTarget.Activate",
 		[]interface{}{
@@ -115,10 +151,15 @@ func (s *Suite) TestSuccessfulMigration(c *gc.C) {
 		{"guard.Lockdown", nil},
 		{"masterClient.SetPhase", []interface{}{migration.READONLY}},
 		{"masterClient.SetPhase", []interface{}{migration.PRECHECK}},
+		apiOpenCall,
+		{"masterClient.NeedsCleanup", nil},
+		{"masterClient.Export", nil},
+		connCloseCall,
 		{"masterClient.SetPhase", []interface{}{migration.IMPORT}},
 		{"masterClient.Export", nil},
 		apiOpenCall,
 		importCall,
+		{"masterClient.UploadBinaries", nil},
 		connCloseCall,
 		{"masterClient.SetPhase", []interface{}{migration.VALIDATION}},
 		apiOpenCall,
@@ -127,10 +168,139 @@ func (s *Suite) TestSuccessfulMigration(c *gc.C) {
 		{"masterClient.SetPhase", []interface{}{migration.SUCCESS}},
 		{"masterClient.SetPhase", []interface{}{migration.LOGTRANSFER}},
 		{"masterClient.SetPhase", []interface{}{migration.REAP}},
+		{"masterClient.Reap", nil},
 		{"masterClient.SetPhase", []interface{}{migration.DONE}},
 	})
 }
 
+func (s *Suite) TestOfflineMigration(c *gc.C) {
+	masterClient := newStubMasterClient(s.stub)
+	masterClient.status.Offline = true
+	worker, err := migrationmaster.New(migrationmaster.Config{
+		Facade: masterClient,
+		Guard:  newStubGuard(s.stub),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.triggerMigration(masterClient)
+
+	err = workertest.CheckKilled(c, worker)
+	c.Assert(errors.Cause(err), gc.Equals, dependency.ErrUninstall)
+
+	// The model has already been imported into the target controller
+	// and activated there, so the target controller is only asked to
+	// confirm that it holds all of the model's machines and units.
+	s.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"masterClient.Watch", nil},
+		{"masterClient.GetMigrationStatus", nil},
+		{"guard.Lockdown", nil},
+		{"masterClient.SetPhase", []interface{}{migration.READONLY}},
+		{"masterClient.SetPhase", []interface{}{migration.PRECHECK}},
+		{"masterClient.Export", nil},
+		apiOpenCall,
+		checkImportedCall,
+		connCloseCall,
+		{"masterClient.SetPhase", []interface{}{migration.IMPORT}},
+		{"masterClient.SetPhase", []interface{}{migration.VALIDATION}},
+		{"masterClient.SetPhase", []interface{}{migration.SUCCESS}},
+		{"masterClient.SetPhase", []interface{}{migration.LOGTRANSFER}},
+		{"masterClient.SetPhase", []interface{}{migration.REAP}},
+		{"masterClient.Reap", nil},
+		{"masterClient.SetPhase", []interface{}{migration.DONE}},
+	})
+}
+
+func (s *Suite) TestOfflineMigrationPrecheckFailure(c *gc.C) {
+	masterClient := newStubMasterClient(s.stub)
+	masterClient.status.Offline = true
+	worker, err := migrationmaster.New(migrationmaster.Config{
+		Facade: masterClient,
+		Guard:  newStubGuard(s.stub),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.connection.importedBlockers = []string{
+		"machine 0/lxd/0 not found on target controller",
+		"unit mysql/0 not found on target controller",
+	}
+	s.triggerMigration(masterClient)
+
+	err = workertest.CheckKilled(c, worker)
+	c.Assert(err, gc.Equals, migrationmaster.ErrDoneForNow)
+
+	// The source model isn't reaped, and the model imported from the
+	// archive isn't removed from the target controller.
+	s.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"masterClient.Watch", nil},
+		{"masterClient.GetMigrationStatus", nil},
+		{"guard.Lockdown", nil},
+		{"masterClient.SetPhase", []interface{}{migration.READONLY}},
+		{"masterClient.SetPhase", []interface{}{migration.PRECHECK}},
+		{"masterClient.Export", nil},
+		apiOpenCall,
+		checkImportedCall,
+		{"masterClient.SetStatusMessage", []interface{}{
+			"precheck failed: machine 0/lxd/0 not found on target controller; " +
+				"unit mysql/0 not found on target controller",
+		}},
+		connCloseCall,
+		{"masterClient.SetPhase", []interface{}{migration.ABORT}},
+		{"masterClient.SetPhase", []interface{}{migration.ABORTDONE}},
+	})
+}
+
+func (s *Suite) TestOfflineMigrationCheckError(c *gc.C) {
+	masterClient := newStubMasterClient(s.stub)
+	masterClient.status.Offline = true
+	worker, err := migrationmaster.New(migrationmaster.Config{
+		Facade: masterClient,
+		Guard:  newStubGuard(s.stub),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.connection.checkImportedErr = errors.New("boom")
+	s.triggerMigration(masterClient)
+
+	err = workertest.CheckKilled(c, worker)
+	c.Assert(err, gc.Equals, migrationmaster.ErrDoneForNow)
+
+	s.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"masterClient.Watch", nil},
+		{"masterClient.GetMigrationStatus", nil},
+		{"guard.Lockdown", nil},
+		{"masterClient.SetPhase", []interface{}{migration.READONLY}},
+		{"masterClient.SetPhase", []interface{}{migration.PRECHECK}},
+		{"masterClient.Export", nil},
+		apiOpenCall,
+		checkImportedCall,
+		{"masterClient.SetStatusMessage", []interface{}{"failed to check imported model on target controller: boom"}},
+		connCloseCall,
+		{"masterClient.SetPhase", []interface{}{migration.ABORT}},
+		{"masterClient.SetPhase", []interface{}{migration.ABORTDONE}},
+	})
+}
+
+func (s *Suite) TestOfflineMigrationAbort(c *gc.C) {
+	masterClient := newStubMasterClient(s.stub)
+	masterClient.status.Offline = true
+	masterClient.status.Phase = migration.ABORT
+	worker, err := migrationmaster.New(migrationmaster.Config{
+		Facade: masterClient,
+		Guard:  newStubGuard(s.stub),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.triggerMigration(masterClient)
+
+	err = workertest.CheckKilled(c, worker)
+	c.Assert(err, gc.Equals, migrationmaster.ErrDoneForNow)
+
+	// The model imported from the archive isn't removed from the
+	// target controller.
+	s.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"masterClient.Watch", nil},
+		{"masterClient.GetMigrationStatus", nil},
+		{"guard.Lockdown", nil},
+		{"masterClient.SetPhase", []interface{}{migration.ABORTDONE}},
+	})
+}
+
 func (s *Suite) TestMigrationResume(c *gc.C) {
 	// Test that a partially complete migration can be resumed.
 
@@ -152,10 +322,58 @@ func (s *Suite) TestMigrationResume(c *gc.C) {
 		{"guard.Lockdown", nil},
 		{"masterClient.SetPhase", []interface{}{migration.LOGTRANSFER}},
 		{"masterClient.SetPhase", []interface{}{migration.REAP}},
+		{"masterClient.Reap", nil},
 		{"masterClient.SetPhase", []interface{}{migration.DONE}},
 	})
 }
 
+func (s *Suite) TestReapFailure(c *gc.C) {
+	masterClient := newStubMasterClient(s.stub)
+	masterClient.status.Phase = migration.REAP
+	masterClient.reapErr = errors.New("boom")
+	worker, err := migrationmaster.New(migrationmaster.Config{
+		Facade: masterClient,
+		Guard:  newStubGuard(s.stub),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.triggerMigration(masterClient)
+
+	err = workertest.CheckKilled(c, worker)
+	c.Assert(err, gc.Equals, migrationmaster.ErrDoneForNow)
+
+	// The model has moved to the target controller, so the migration
+	// isn't aborted.
+	s.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"masterClient.Watch", nil},
+		{"masterClient.GetMigrationStatus", nil},
+		{"guard.Lockdown", nil},
+		{"masterClient.Reap", nil},
+		{"masterClient.SetStatusMessage", []interface{}{"failed to remove model from source controller: boom"}},
+		{"masterClient.SetPhase", []interface{}{migration.REAPFAILED}},
+	})
+}
+
+func (s *Suite) TestPreviouslyFailedReap(c *gc.C) {
+	masterClient := newStubMasterClient(s.stub)
+	masterClient.status.Phase = migration.REAPFAILED
+	s.triggerMigration(masterClient)
+	worker, err := migrationmaster.New(migrationmaster.Config{
+		Facade: masterClient,
+		Guard:  newStubGuard(s.stub),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = workertest.CheckKilled(c, worker)
+	c.Assert(err, gc.Equals, migrationmaster.ErrDoneForNow)
+
+	// The worker waits for the migration to be resumed or cleaned up
+	// without locking down the model's agents.
+	s.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"masterClient.Watch", nil},
+		{"masterClient.GetMigrationStatus", nil},
+	})
+}
+
 func (s *Suite) TestPreviouslyAbortedMigration(c *gc.C) {
 	masterClient := newStubMasterClient(s.stub)
 	masterClient.status.Phase = migration.ABORTDONE
@@ -302,8 +520,11 @@ func (s *Suite) TestExportFailure(c *gc.C) {
 		{"guard.Lockdown", nil},
 		{"masterClient.SetPhase", []interface{}{migration.READONLY}},
 		{"masterClient.SetPhase", []interface{}{migration.PRECHECK}},
-		{"masterClient.SetPhase", []interface{}{migration.IMPORT}},
+		apiOpenCall,
+		{"masterClient.NeedsCleanup", nil},
 		{"masterClient.Export", nil},
+		{"masterClient.SetStatusMessage", []interface{}{"precheck export: boom"}},
+		connCloseCall,
 		{"masterClient.SetPhase", []interface{}{migration.ABORT}},
 		apiOpenCall,
 		abortCall,
@@ -331,11 +552,41 @@ func (s *Suite) TestAPIOpenFailure(c *gc.C) {
 		{"guard.Lockdown", nil},
 		{"masterClient.SetPhase", []interface{}{migration.READONLY}},
 		{"masterClient.SetPhase", []interface{}{migration.PRECHECK}},
-		{"masterClient.SetPhase", []interface{}{migration.IMPORT}},
-		{"masterClient.Export", nil},
 		apiOpenCall,
+		{"masterClient.SetStatusMessage", []interface{}{"failed to connect to target controller: boom"}},
+		{"masterClient.SetPhase", []interface{}{migration.ABORT}},
+		apiOpenCall,
+		{"masterClient.SetPhase", []interface{}{migration.ABORTDONE}},
+	})
+}
+
+func (s *Suite) TestPrecheckFailure(c *gc.C) {
+	masterClient := newStubMasterClient(s.stub)
+	masterClient.needsCleanup = true
+	worker, err := migrationmaster.New(migrationmaster.Config{
+		Facade: masterClient,
+		Guard:  newStubGuard(s.stub),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.triggerMigration(masterClient)
+
+	err = workertest.CheckKilled(c, worker)
+	c.Assert(err, gc.Equals, migrationmaster.ErrDoneForNow)
+
+	s.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"masterClient.Watch", nil},
+		{"masterClient.GetMigrationStatus", nil},
+		{"guard.Lockdown", nil},
+		{"masterClient.SetPhase", []interface{}{migration.READONLY}},
+		{"masterClient.SetPhase", []interface{}{migration.PRECHECK}},
+		apiOpenCall,
+		{"masterClient.NeedsCleanup", nil},
+		{"masterClient.SetStatusMessage", []interface{}{"precheck failed: cleanup needed"}},
+		connCloseCall,
 		{"masterClient.SetPhase", []interface{}{migration.ABORT}},
 		apiOpenCall,
+		abortCall,
+		connCloseCall,
 		{"masterClient.SetPhase", []interface{}{migration.ABORTDONE}},
 	})
 }
@@ -359,10 +610,53 @@ func (s *Suite) TestImportFailure(c *gc.C) {
 		{"guard.Lockdown", nil},
 		{"masterClient.SetPhase", []interface{}{migration.READONLY}},
 		{"masterClient.SetPhase", []interface{}{migration.PRECHECK}},
+		apiOpenCall,
+		{"masterClient.NeedsCleanup", nil},
+		{"masterClient.Export", nil},
+		connCloseCall,
 		{"masterClient.SetPhase", []interface{}{migration.IMPORT}},
 		{"masterClient.Export", nil},
 		apiOpenCall,
 		importCall,
+		{"masterClient.SetStatusMessage", []interface{}{"failed to import model into target controller: boom"}},
+		connCloseCall,
+		{"masterClient.SetPhase", []interface{}{migration.ABORT}},
+		apiOpenCall,
+		abortCall,
+		connCloseCall,
+		{"masterClient.SetPhase", []interface{}{migration.ABORTDONE}},
+	})
+}
+
+func (s *Suite) TestUploadBinariesFailure(c *gc.C) {
+	masterClient := newStubMasterClient(s.stub)
+	masterClient.uploadErr = errors.New("boom")
+	worker, err := migrationmaster.New(migrationmaster.Config{
+		Facade: masterClient,
+		Guard:  newStubGuard(s.stub),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.triggerMigration(masterClient)
+
+	err = workertest.CheckKilled(c, worker)
+	c.Assert(err, gc.Equals, migrationmaster.ErrDoneForNow)
+
+	s.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"masterClient.Watch", nil},
+		{"masterClient.GetMigrationStatus", nil},
+		{"guard.Lockdown", nil},
+		{"masterClient.SetPhase", []interface{}{migration.READONLY}},
+		{"masterClient.SetPhase", []interface{}{migration.PRECHECK}},
+		apiOpenCall,
+		{"masterClient.NeedsCleanup", nil},
+		{"masterClient.Export", nil},
+		connCloseCall,
+		{"masterClient.SetPhase", []interface{}{migration.IMPORT}},
+		{"masterClient.Export", nil},
+		apiOpenCall,
+		importCall,
+		{"masterClient.UploadBinaries", nil},
+		{"masterClient.SetStatusMessage", []interface{}{"failed to upload binaries into target controller: boom"}},
 		connCloseCall,
 		{"masterClient.SetPhase", []interface{}{migration.ABORT}},
 		apiOpenCall,
@@ -419,6 +713,9 @@ type stubMasterClient struct {
 	status         masterapi.MigrationStatus
 	statusErr      error
 	exportErr      error
+	needsCleanup   bool
+	uploadErr      error
+	reapErr        error
 }
 
 func (c *stubMasterClient) Watch() (watcher.NotifyWatcher, error) {
@@ -446,11 +743,31 @@ func (c *stubMasterClient) Export() ([]byte, error) {
 	return fakeSerializedModel, nil
 }
 
+func (c *stubMasterClient) UploadBinaries() error {
+	c.stub.AddCall("masterClient.UploadBinaries")
+	return c.uploadErr
+}
+
+func (c *stubMasterClient) NeedsCleanup() (bool, error) {
+	c.stub.AddCall("masterClient.NeedsCleanup")
+	return c.needsCleanup, nil
+}
+
+func (c *stubMasterClient) Reap() error {
+	c.stub.AddCall("masterClient.Reap")
+	return c.reapErr
+}
+
 func (c *stubMasterClient) SetPhase(phase migration.Phase) error {
 	c.stub.AddCall("masterClient.SetPhase", phase)
 	return nil
 }
 
+func (c *stubMasterClient) SetStatusMessage(message string) error {
+	c.stub.AddCall("masterClient.SetStatusMessage", message)
+	return nil
+}
+
 func newMockWatcher(changes chan struct{}) *mockWatcher {
 	return &mockWatcher{
 		Worker:  workertest.NewErrorWorker(nil),
@@ -469,8 +786,10 @@ func (w *mockWatcher) Changes() watcher.NotifyChannel {
 
 type stubConnection struct {
 	api.Connection
-	stub      *jujutesting.Stub
-	importErr error
+	stub             *jujutesting.Stub
+	importErr        error
+	importedBlockers []string
+	checkImportedErr error
 }
 
 func (c *stubConnection) BestFacadeVersion(string) int {
@@ -484,6 +803,12 @@ func (c *stubConnection) APICall(objType string, version int, id, request string
 		switch request {
 		case "Import":
 			return c.importErr
+		case "CheckImported":
+			if c.checkImportedErr != nil {
+				return c.checkImportedErr
+			}
+			response.(*params.MigrationImportCheckResult).Blockers = c.importedBlockers
+			return nil
 		case "Activate":
 			return nil
 		}
@@ -25,6 +25,10 @@ type Facade interface {
 	// for the migration for the model associated with the API
 	// connection.
 	Watch() (watcher.MigrationStatusWatcher, error)
+
+	// Report submits whether the agent successfully completed its
+	// actions for a migration phase.
+	Report(migrationId string, phase migration.Phase, success bool) error
 }
 
 // Config defines the operation of a Worker.
@@ -121,16 +125,18 @@ func (w *Worker) handle(status watcher.MigrationStatus) error {
 
 	switch status.Phase {
 	case migration.QUIESCE:
-		// TODO(mjs) - once Will's stable mode work comes
-		// together this worker will only start up when a
-		// migration is active. Here the minion should report
-		// to the controller that it is running so that the
-		// migration can progress to READONLY.
+		// Let the controller know that this agent is running and
+		// locked down so that the migration can progress.
+		return w.report(status, true)
 	case migration.VALIDATION:
 		// TODO(mjs) - check connection to the target
 		// controller here and report success/failure.
+		return w.report(status, true)
 	case migration.SUCCESS:
 		err := w.doSUCCESS(status.TargetAPIAddrs, status.TargetCACert)
+		if reportErr := w.report(status, err == nil); reportErr != nil {
+			return errors.Trace(reportErr)
+		}
 		if err != nil {
 			return errors.Trace(err)
 		}
@@ -145,6 +151,12 @@ func (w *Worker) handle(status watcher.MigrationStatus) error {
 	return nil
 }
 
+func (w *Worker) report(status watcher.MigrationStatus, success bool) error {
+	logger.Debugf("reporting back for phase %s: %v", status.Phase, success)
+	err := w.config.Facade.Report(status.MigrationId, status.Phase, success)
+	return errors.Annotate(err, "failed to report phase progress")
+}
+
 func (w *Worker) doSUCCESS(targetAddrs []string, caCert string) error {
 	hps, err := apiAddrsToHostPorts(targetAddrs)
 	if err != nil {
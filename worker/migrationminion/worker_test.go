@@ -129,6 +129,7 @@ func (s *Suite) TestNONE(c *gc.C) {
 func (s *Suite) TestSUCCESS(c *gc.C) {
 	addrs := []string{"1.1.1.1:1", "9.9.9.9:9"}
 	s.client.watcher.changes <- watcher.MigrationStatus{
+		MigrationId:    "id",
 		Phase:          migration.SUCCESS,
 		TargetAPIAddrs: addrs,
 		TargetCACert:   "top secret",
@@ -148,7 +149,61 @@ func (s *Suite) TestSUCCESS(c *gc.C) {
 	workertest.CleanKill(c, w)
 	c.Assert(s.agent.conf.addrs, gc.DeepEquals, addrs)
 	c.Assert(s.agent.conf.caCert, gc.DeepEquals, "top secret")
-	s.stub.CheckCallNames(c, "Watch", "Lockdown")
+	s.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Watch", nil},
+		{"Lockdown", nil},
+		{"Report", []interface{}{"id", migration.SUCCESS, true}},
+	})
+}
+
+func (s *Suite) TestQUIESCEReports(c *gc.C) {
+	s.checkReportsSuccess(c, migration.QUIESCE)
+}
+
+func (s *Suite) TestVALIDATIONReports(c *gc.C) {
+	s.checkReportsSuccess(c, migration.VALIDATION)
+}
+
+func (s *Suite) checkReportsSuccess(c *gc.C, phase migration.Phase) {
+	s.client.watcher.changes <- watcher.MigrationStatus{
+		MigrationId: "id",
+		Phase:       phase,
+	}
+	w, err := migrationminion.New(migrationminion.Config{
+		Facade: s.client,
+		Guard:  s.guard,
+		Agent:  s.agent,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	select {
+	case <-s.client.reported:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for report")
+	}
+	workertest.CleanKill(c, w)
+	s.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Watch", nil},
+		{"Lockdown", nil},
+		{"Report", []interface{}{"id", phase, true}},
+	})
+}
+
+func (s *Suite) TestReportError(c *gc.C) {
+	s.client.reportErr = errors.New("boom")
+	s.client.watcher.changes <- watcher.MigrationStatus{
+		MigrationId: "id",
+		Phase:       migration.QUIESCE,
+	}
+	w, err := migrationminion.New(migrationminion.Config{
+		Facade: s.client,
+		Guard:  s.guard,
+		Agent:  s.agent,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = workertest.CheckKilled(c, w)
+	c.Check(err, gc.ErrorMatches, "failed to report phase progress: boom")
 }
 
 func newStubGuard(stub *jujutesting.Stub) *stubGuard {
@@ -173,15 +228,18 @@ func (g *stubGuard) Unlock() error {
 
 func newStubMinionClient(stub *jujutesting.Stub) *stubMinionClient {
 	return &stubMinionClient{
-		stub:    stub,
-		watcher: newStubWatcher(),
+		stub:     stub,
+		watcher:  newStubWatcher(),
+		reported: make(chan bool, 1),
 	}
 }
 
 type stubMinionClient struct {
-	stub     *jujutesting.Stub
-	watcher  *stubWatcher
-	watchErr error
+	stub      *jujutesting.Stub
+	watcher   *stubWatcher
+	watchErr  error
+	reportErr error
+	reported  chan bool
 }
 
 func (c *stubMinionClient) Watch() (watcher.MigrationStatusWatcher, error) {
@@ -192,6 +250,15 @@ func (c *stubMinionClient) Watch() (watcher.MigrationStatusWatcher, error) {
 	return c.watcher, nil
 }
 
+func (c *stubMinionClient) Report(id string, phase migration.Phase, success bool) error {
+	c.stub.MethodCall(c, "Report", id, phase, success)
+	select {
+	case c.reported <- true:
+	default:
+	}
+	return c.reportErr
+}
+
 func newStubWatcher() *stubWatcher {
 	return &stubWatcher{
 		Worker:  workertest.NewErrorWorker(nil),
@@ -782,10 +782,11 @@ func volumesToApiserver(volumes []storage.Volume) []params.Volume {
 		result[i] = params.Volume{
 			v.Tag.String(),
 			params.VolumeInfo{
-				v.VolumeId,
-				v.HardwareId,
-				v.Size,
-				v.Persistent,
+				VolumeId:   v.VolumeId,
+				HardwareId: v.HardwareId,
+				Size:       v.Size,
+				Persistent: v.Persistent,
+				Encrypted:  v.Encrypted,
 			},
 		}
 	}
@@ -10,6 +10,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/provider/registry"
 	"github.com/juju/juju/watcher"
 )
 
@@ -79,6 +80,12 @@ func filesystemAttachmentsChanged(ctx *context, watcherIds []watcher.MachineStor
 		// attachments go directly from Dying to removed.
 		logger.Warningf("unexpected dead filesystem attachments: %v", dead)
 	}
+	if alive, err = filterFilesystemAttachments(ctx, alive); err != nil {
+		return errors.Trace(err)
+	}
+	if dying, err = filterFilesystemAttachments(ctx, dying); err != nil {
+		return errors.Trace(err)
+	}
 	if len(alive)+len(dying) == 0 {
 		return nil
 	}
@@ -106,6 +113,63 @@ func filesystemAttachmentsChanged(ctx *context, watcherIds []watcher.MachineStor
 	return nil
 }
 
+// filterFilesystemAttachments returns the subset of the specified filesystem
+// attachments that the storage provisioner is responsible for. Attachments of
+// shared filesystems are managed by the storage provisioner of the machine
+// they are attached to, whereas attachments of other environ-scoped
+// filesystems are managed by the environ storage provisioner.
+func filterFilesystemAttachments(ctx *context, ids []params.MachineStorageId) ([]params.MachineStorageId, error) {
+	_, machineScope := ctx.config.Scope.(names.MachineTag)
+	filtered := make([]params.MachineStorageId, 0, len(ids))
+	var environScoped []params.MachineStorageId
+	for _, id := range ids {
+		filesystemTag, err := names.ParseFilesystemTag(id.AttachmentTag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if _, ok := names.FilesystemMachine(filesystemTag); ok {
+			filtered = append(filtered, id)
+			continue
+		}
+		environScoped = append(environScoped, id)
+	}
+	if len(environScoped) == 0 {
+		return filtered, nil
+	}
+	paramsResults, err := ctx.config.Filesystems.FilesystemAttachmentParams(environScoped)
+	if err != nil {
+		return nil, errors.Annotate(err, "getting filesystem attachment params")
+	}
+	for i, result := range paramsResults {
+		if result.Error != nil {
+			if params.IsCodeNotFound(result.Error) {
+				// The attachment has since been removed.
+				continue
+			}
+			return nil, errors.Annotate(result.Error, "getting filesystem attachment parameters")
+		}
+		provider, err := registry.StorageProvider(storage.ProviderType(result.Result.Provider))
+		if err != nil {
+			return nil, errors.Annotate(err, "getting provider")
+		}
+		if storage.IsSharedFilesystemProvider(provider) == machineScope {
+			filtered = append(filtered, environScoped[i])
+		}
+	}
+	return filtered, nil
+}
+
+// isSharedFilesystemAttachment reports whether or not the filesystem
+// attachment with the specified parameters is an attachment of a shared
+// filesystem, managed by this machine's storage provisioner.
+func isSharedFilesystemAttachment(ctx *context, params storage.FilesystemAttachmentParams) bool {
+	if _, ok := ctx.config.Scope.(names.MachineTag); !ok {
+		return false
+	}
+	_, ok := names.FilesystemMachine(params.Filesystem)
+	return !ok
+}
+
 // processDyingFilesystems processes the FilesystemResults for Dying filesystems,
 // removing them from provisioning-pending as necessary.
 func processDyingFilesystems(ctx *context, tags []names.FilesystemTag, filesystemResults []params.FilesystemResult) error {
@@ -158,9 +222,14 @@ func updatePendingFilesystemAttachment(
 	params storage.FilesystemAttachmentParams,
 ) {
 	var incomplete bool
+	shared := isSharedFilesystemAttachment(ctx, params)
 	filesystem, ok := ctx.filesystems[params.Filesystem]
 	if !ok {
-		incomplete = true
+		// Shared filesystems are provisioned by the environ storage
+		// provisioner, so the machine storage provisioner will not
+		// observe them. If the filesystem has not been provisioned
+		// yet, the attachment will be retried until it has been.
+		incomplete = !shared
 	} else {
 		params.FilesystemId = filesystem.FilesystemId
 		if filesystem.Volume != (names.VolumeTag{}) {
@@ -177,7 +246,7 @@ func updatePendingFilesystemAttachment(
 		watchMachine(ctx, params.Machine)
 		incomplete = true
 	}
-	if params.FilesystemId == "" {
+	if params.FilesystemId == "" && !shared {
 		incomplete = true
 	}
 	if incomplete {
@@ -436,8 +505,9 @@ func filesystemFromParams(in params.Filesystem) (storage.Filesystem, error) {
 		filesystemTag,
 		volumeTag,
 		storage.FilesystemInfo{
-			in.Info.FilesystemId,
-			in.Info.Size,
+			FilesystemId: in.Info.FilesystemId,
+			Size:         in.Info.Size,
+			Encrypted:    in.Info.Encrypted,
 		},
 	}, nil
 }
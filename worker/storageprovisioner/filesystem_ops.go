@@ -115,6 +115,10 @@ func createFilesystems(ctx *context, ops map[names.FilesystemTag]*createFilesyst
 
 // attachFilesystems creates filesystem attachments with the specified parameters.
 func attachFilesystems(ctx *context, ops map[params.MachineStorageId]*attachFilesystemOp) error {
+	reschedule, err := refreshSharedFilesystemIds(ctx, ops)
+	if err != nil {
+		return errors.Trace(err)
+	}
 	filesystemAttachmentParams := make([]storage.FilesystemAttachmentParams, 0, len(ops))
 	for _, op := range ops {
 		args := op.args
@@ -133,7 +137,6 @@ func attachFilesystems(ctx *context, ops map[params.MachineStorageId]*attachFile
 	if err != nil {
 		return errors.Trace(err)
 	}
-	var reschedule []scheduleOp
 	var filesystemAttachments []storage.FilesystemAttachment
 	var statuses []params.EntityStatusArgs
 	for sourceName, filesystemAttachmentParams := range paramsBySource {
@@ -183,6 +186,41 @@ func attachFilesystems(ctx *context, ops map[params.MachineStorageId]*attachFile
 	return nil
 }
 
+// refreshSharedFilesystemIds updates the filesystem IDs of attachments of
+// shared filesystems that had not been provisioned when the attachments
+// were scheduled. Operations for filesystems that are still not
+// provisioned are removed from the map and returned, to be rescheduled.
+func refreshSharedFilesystemIds(ctx *context, ops map[params.MachineStorageId]*attachFilesystemOp) ([]scheduleOp, error) {
+	var ids []params.MachineStorageId
+	for id, op := range ops {
+		if op.args.FilesystemId == "" {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	attachmentParams, err := filesystemAttachmentParams(ctx, ids)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var reschedule []scheduleOp
+	for i, id := range ids {
+		op := ops[id]
+		if attachmentParams[i].FilesystemId == "" {
+			logger.Debugf(
+				"%s is not yet provisioned, will retry attachment",
+				names.ReadableString(op.args.Filesystem),
+			)
+			delete(ops, id)
+			reschedule = append(reschedule, op)
+			continue
+		}
+		op.args.FilesystemId = attachmentParams[i].FilesystemId
+	}
+	return reschedule, nil
+}
+
 // destroyFilesystems destroys filesystems with the specified parameters.
 func destroyFilesystems(ctx *context, ops map[names.FilesystemTag]*destroyFilesystemOp) error {
 	tags := make([]names.FilesystemTag, 0, len(ops))
@@ -464,8 +502,9 @@ func filesystemsFromStorage(in []storage.Filesystem) []params.Filesystem {
 			f.Tag.String(),
 			"",
 			params.FilesystemInfo{
-				f.FilesystemId,
-				f.Size,
+				FilesystemId: f.FilesystemId,
+				Size:         f.Size,
+				Encrypted:    f.Encrypted,
 			},
 		}
 		if f.Volume != (names.VolumeTag{}) {
@@ -483,8 +522,8 @@ func filesystemAttachmentsFromStorage(in []storage.FilesystemAttachment) []param
 			f.Filesystem.String(),
 			f.Machine.String(),
 			params.FilesystemAttachmentInfo{
-				f.Path,
-				f.ReadOnly,
+				MountPoint: f.Path,
+				ReadOnly:   f.ReadOnly,
 			},
 		}
 	}
@@ -357,10 +357,12 @@ func (f *mockFilesystemAccessor) FilesystemAttachmentParams(ids []params.Machine
 		// Parameters are returned regardless of whether the attachment
 		// exists; this is to support reattachment.
 		instanceId := f.provisionedMachines[id.MachineTag]
+		filesystem := f.provisionedFilesystems[id.AttachmentTag]
 		result = append(result, params.FilesystemAttachmentParamsResult{Result: params.FilesystemAttachmentParams{
 			MachineTag:    id.MachineTag,
 			FilesystemTag: id.AttachmentTag,
 			InstanceId:    string(instanceId),
+			FilesystemId:  filesystem.Info.FilesystemId,
 			Provider:      "dummy",
 			ReadOnly:      true,
 		}})
@@ -453,6 +455,7 @@ func (m *mockLifecycleManager) RemoveAttachments(ids []params.MachineStorageId)
 type dummyProvider struct {
 	storage.Provider
 	dynamic bool
+	shared  bool
 
 	volumeSourceFunc             func(*config.Config, *storage.Config) (storage.VolumeSource, error)
 	filesystemSourceFunc         func(*config.Config, *storage.Config) (storage.FilesystemSource, error)
@@ -498,6 +501,10 @@ func (p *dummyProvider) Dynamic() bool {
 	return p.dynamic
 }
 
+func (p *dummyProvider) SharedFilesystems() bool {
+	return p.shared
+}
+
 func (s *dummyVolumeSource) ValidateVolumeParams(params storage.VolumeParams) error {
 	if s.provider != nil && s.provider.validateVolumeParamsFunc != nil {
 		return s.provider.validateVolumeParamsFunc(params)
@@ -27,6 +27,8 @@
 package storageprovisioner
 
 import (
+	"path/filepath"
+
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/utils/set"
@@ -296,8 +298,12 @@ func (w *storageProvisioner) loop() error {
 		incompleteFilesystemAttachmentParams: make(map[params.MachineStorageId]storage.FilesystemAttachmentParams),
 		pendingVolumeBlockDevices:            make(set.Tags),
 	}
+	var luksKeyDir string
+	if w.config.StorageDir != "" {
+		luksKeyDir = filepath.Join(w.config.StorageDir, "luks")
+	}
 	ctx.managedFilesystemSource = newManagedFilesystemSource(
-		ctx.volumeBlockDevices, ctx.filesystems,
+		ctx.volumeBlockDevices, ctx.filesystems, luksKeyDir,
 	)
 	for {
 
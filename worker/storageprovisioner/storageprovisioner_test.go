@@ -44,6 +44,7 @@ func (s *storageProvisionerSuite) SetUpTest(c *gc.C) {
 		func(
 			blockDevices map[names.VolumeTag]storage.BlockDevice,
 			filesystems map[names.FilesystemTag]storage.Filesystem,
+			keyDir string,
 		) storage.FilesystemSource {
 			s.managedFilesystemSource = &mockManagedFilesystemSource{
 				blockDevices: blockDevices,
@@ -954,6 +955,82 @@ func (s *storageProvisionerSuite) TestFilesystemAttachmentAdded(c *gc.C) {
 	assertNoEvent(c, filesystemAttachmentInfoSet, "filesystem attachment info set")
 }
 
+func (s *storageProvisionerSuite) TestSharedFilesystemAttachmentAdded(c *gc.C) {
+	s.provider.shared = true
+
+	filesystemAttachmentInfoSet := make(chan interface{})
+	filesystemAccessor := newMockFilesystemAccessor()
+	filesystemAccessor.setFilesystemAttachmentInfo = func(filesystemAttachments []params.FilesystemAttachment) ([]params.ErrorResult, error) {
+		filesystemAttachmentInfoSet <- filesystemAttachments
+		return make([]params.ErrorResult, len(filesystemAttachments)), nil
+	}
+
+	// filesystem-1 is an environ-scoped filesystem, which the machine
+	// storage provisioner does not observe; its filesystem ID is
+	// obtained from the attachment parameters.
+	filesystemAccessor.provisionedFilesystems["filesystem-1"] = params.Filesystem{
+		FilesystemTag: "filesystem-1",
+		Info: params.FilesystemInfo{
+			FilesystemId: "fs-123",
+		},
+	}
+	filesystemAccessor.provisionedMachines["machine-0"] = instance.Id("already-provisioned-0")
+
+	args := &workerArgs{
+		scope:       names.NewMachineTag("0"),
+		filesystems: filesystemAccessor,
+	}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	filesystemAccessor.attachmentsWatcher.changes <- []watcher.MachineStorageId{{
+		MachineTag: "machine-0", AttachmentTag: "filesystem-1",
+	}}
+	args.environ.watcher.changes <- struct{}{}
+	filesystemAttachments := waitChannel(
+		c, filesystemAttachmentInfoSet, "waiting for filesystem attachments to be set",
+	)
+	c.Assert(filesystemAttachments, jc.DeepEquals, []params.FilesystemAttachment{{
+		FilesystemTag: "filesystem-1",
+		MachineTag:    "machine-0",
+		Info: params.FilesystemAttachmentInfo{
+			MountPoint: "/srv/fs-123",
+		},
+	}})
+}
+
+func (s *storageProvisionerSuite) TestSharedFilesystemAttachmentIgnoredByEnvironProvisioner(c *gc.C) {
+	s.provider.shared = true
+
+	filesystemAttachmentInfoSet := make(chan interface{})
+	filesystemAccessor := newMockFilesystemAccessor()
+	filesystemAccessor.setFilesystemAttachmentInfo = func(filesystemAttachments []params.FilesystemAttachment) ([]params.ErrorResult, error) {
+		filesystemAttachmentInfoSet <- nil
+		return make([]params.ErrorResult, len(filesystemAttachments)), nil
+	}
+	filesystemAccessor.provisionedFilesystems["filesystem-1"] = params.Filesystem{
+		FilesystemTag: "filesystem-1",
+		Info: params.FilesystemInfo{
+			FilesystemId: "fs-123",
+		},
+	}
+	filesystemAccessor.provisionedMachines["machine-1"] = instance.Id("already-provisioned-1")
+
+	args := &workerArgs{filesystems: filesystemAccessor}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	// The attachment is made by machine-1's storage provisioner.
+	filesystemAccessor.attachmentsWatcher.changes <- []watcher.MachineStorageId{{
+		MachineTag: "machine-1", AttachmentTag: "filesystem-1",
+	}}
+	filesystemAccessor.filesystemsWatcher.changes <- []string{"1"}
+	args.environ.watcher.changes <- struct{}{}
+	assertNoEvent(c, filesystemAttachmentInfoSet, "filesystem attachment info set")
+}
+
 func (s *storageProvisionerSuite) TestCreateVolumeBackedFilesystem(c *gc.C) {
 	filesystemInfoSet := make(chan interface{})
 	filesystemAccessor := newMockFilesystemAccessor()
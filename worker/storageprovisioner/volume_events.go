@@ -388,10 +388,11 @@ func volumesFromStorage(in []storage.Volume) []params.Volume {
 		out[i] = params.Volume{
 			v.Tag.String(),
 			params.VolumeInfo{
-				v.VolumeId,
-				v.HardwareId,
-				v.Size,
-				v.Persistent,
+				VolumeId:   v.VolumeId,
+				HardwareId: v.HardwareId,
+				Size:       v.Size,
+				Persistent: v.Persistent,
+				Encrypted:  v.Encrypted,
 			},
 		}
 	}
@@ -423,10 +424,11 @@ func volumeFromParams(in params.Volume) (storage.Volume, error) {
 	return storage.Volume{
 		volumeTag,
 		storage.VolumeInfo{
-			in.Info.VolumeId,
-			in.Info.HardwareId,
-			in.Info.Size,
-			in.Info.Persistent,
+			VolumeId:   in.Info.VolumeId,
+			HardwareId: in.Info.HardwareId,
+			Size:       in.Info.Size,
+			Persistent: in.Info.Persistent,
+			Encrypted:  in.Info.Encrypted,
 		},
 	}, nil
 }